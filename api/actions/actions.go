@@ -0,0 +1,221 @@
+// Package actions calls the Invocable Actions REST API: standard actions
+// such as posting to Chatter or sending email, and custom actions such as
+// autolaunched flows, invocable Apex methods, and quick actions.
+//
+// It works on top of an api.Client, so requests share its authentication,
+// retries, and compression:
+//
+//	results, err := actions.New(client).Invoke(ctx, "flow", "Close_Case", []map[string]interface{}{
+//		{"caseId": "500xx000001abcd"},
+//	})
+package actions
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/open-cli-collective/salesforce-cli/api"
+)
+
+// TypeStandard is the action type of standard actions. Other types, such as
+// "flow" and "apex", are custom action types.
+const TypeStandard = "standard"
+
+// Client calls invocable actions through a REST API client.
+type Client struct {
+	api *api.Client
+}
+
+// New returns a client for the invocable actions of client's org.
+func New(client *api.Client) *Client {
+	return &Client{api: client}
+}
+
+// Action is an invocable action, as listed by List.
+type Action struct {
+	Name  string `json:"name"`
+	Label string `json:"label"`
+	// Type is the kind of action, e.g. FLOW, APEX, or CHATTERPOST
+	Type string `json:"type"`
+}
+
+// Parameter is an input or output of an action.
+type Parameter struct {
+	Name        string `json:"name"`
+	Label       string `json:"label"`
+	Description string `json:"description"`
+	Type        string `json:"type"`
+	Required    bool   `json:"required"`
+	SObjectType string `json:"sobjectType,omitempty"`
+	MaxOccurs   int    `json:"maxOccurs,omitempty"`
+}
+
+// Describe describes an action's inputs and outputs.
+type Describe struct {
+	Name        string      `json:"name"`
+	Label       string      `json:"label"`
+	Description string      `json:"description"`
+	Type        string      `json:"type"`
+	Inputs      []Parameter `json:"inputs"`
+	Outputs     []Parameter `json:"outputs"`
+}
+
+// Error is an error an action invocation reported.
+type Error struct {
+	StatusCode string   `json:"statusCode"`
+	Message    string   `json:"message"`
+	Fields     []string `json:"fields,omitempty"`
+}
+
+// Result is the outcome of one input of an invocation.
+type Result struct {
+	ActionName   string                 `json:"actionName"`
+	IsSuccess    bool                   `json:"isSuccess"`
+	Errors       []Error                `json:"errors"`
+	OutputValues map[string]interface{} `json:"outputValues"`
+	Version      int                    `json:"version,omitempty"`
+}
+
+// ErrorMessage joins the result's error messages.
+func (r Result) ErrorMessage() string {
+	msgs := make([]string, 0, len(r.Errors))
+	for _, e := range r.Errors {
+		if e.StatusCode != "" {
+			msgs = append(msgs, e.StatusCode+": "+e.Message)
+		} else {
+			msgs = append(msgs, e.Message)
+		}
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// actionList is the response listing the actions of one type.
+type actionList struct {
+	Actions []Action `json:"actions"`
+}
+
+// CustomTypes returns the custom action types available in the org, such as
+// apex, flow, and quickAction, sorted by name.
+func (c *Client) CustomTypes(ctx context.Context) ([]string, error) {
+	body, err := c.api.Get(ctx, "/actions/custom")
+	if err != nil {
+		return nil, err
+	}
+
+	var resources map[string]string
+	if err := json.Unmarshal(body, &resources); err != nil {
+		return nil, fmt.Errorf("failed to parse custom action types: %w", err)
+	}
+
+	types := make([]string, 0, len(resources))
+	for name := range resources {
+		types = append(types, name)
+	}
+	sort.Strings(types)
+	return types, nil
+}
+
+// List returns the actions of a type: TypeStandard, or a custom action type
+// such as "flow" or "apex".
+func (c *Client) List(ctx context.Context, actionType string) ([]Action, error) {
+	body, err := c.api.Get(ctx, typePath(actionType))
+	if err != nil {
+		return nil, err
+	}
+
+	var list actionList
+	if err := json.Unmarshal(body, &list); err != nil {
+		return nil, fmt.Errorf("failed to parse actions: %w", err)
+	}
+
+	return list.Actions, nil
+}
+
+// Describe returns the inputs and outputs of an action.
+func (c *Client) Describe(ctx context.Context, actionType, name string) (*Describe, error) {
+	body, err := c.api.Get(ctx, Path(actionType, name))
+	if err != nil {
+		return nil, err
+	}
+
+	var desc Describe
+	if err := json.Unmarshal(body, &desc); err != nil {
+		return nil, fmt.Errorf("failed to parse action describe: %w", err)
+	}
+
+	return &desc, nil
+}
+
+// Invoke runs an action once for each input, in one request, and returns a
+// result per input. A failed input is reported in its result, not as an
+// error.
+func (c *Client) Invoke(ctx context.Context, actionType, name string, inputs []map[string]interface{}) ([]Result, error) {
+	if inputs == nil {
+		inputs = []map[string]interface{}{}
+	}
+
+	body, err := c.api.Post(ctx, Path(actionType, name), map[string]interface{}{"inputs": inputs})
+	if err != nil {
+		// Failed inputs are reported with a 400 status and the same results
+		if results, ok := failedResults(err); ok {
+			return results, nil
+		}
+		return nil, err
+	}
+
+	var results []Result
+	if err := json.Unmarshal(body, &results); err != nil {
+		return nil, fmt.Errorf("failed to parse action results: %w", err)
+	}
+
+	return results, nil
+}
+
+// failedResults returns the results in the body of a 400 response to an
+// invocation, if it has them.
+func failedResults(err error) ([]Result, bool) {
+	var apiErr *api.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadRequest {
+		return nil, false
+	}
+
+	var results []Result
+	if json.Unmarshal(apiErr.Body, &results) != nil || len(results) == 0 || results[0].ActionName == "" {
+		return nil, false
+	}
+	return results, true
+}
+
+// NormalizeType returns the type as the API spells it: "standard", or a
+// custom type with a lowercase first letter, so that Flow, flow, and
+// QuickAction are accepted as flow and quickAction.
+func NormalizeType(actionType string) string {
+	if strings.EqualFold(actionType, TypeStandard) {
+		return TypeStandard
+	}
+	if actionType == "" {
+		return actionType
+	}
+	return strings.ToLower(actionType[:1]) + actionType[1:]
+}
+
+// typePath returns the path listing the actions of a type.
+func typePath(actionType string) string {
+	actionType = NormalizeType(actionType)
+	if actionType == TypeStandard {
+		return "/actions/standard"
+	}
+	return "/actions/custom/" + url.PathEscape(actionType)
+}
+
+// Path returns the REST API path of an action, relative to the versioned
+// base URL.
+func Path(actionType, name string) string {
+	return typePath(actionType) + "/" + url.PathEscape(name)
+}
@@ -0,0 +1,145 @@
+package actions
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/salesforce-cli/api"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client, err := api.New(api.ClientConfig{InstanceURL: server.URL, HTTPClient: server.Client()})
+	require.NoError(t, err)
+	return New(client)
+}
+
+func TestClient_CustomTypes(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/services/data/v62.0/actions/custom", r.URL.Path)
+		_, _ = w.Write([]byte(`{"quickAction": "/services/data/v62.0/actions/custom/quickAction",
+			"apex": "/services/data/v62.0/actions/custom/apex",
+			"flow": "/services/data/v62.0/actions/custom/flow"}`))
+	})
+
+	types, err := client.CustomTypes(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"apex", "flow", "quickAction"}, types)
+}
+
+func TestClient_List(t *testing.T) {
+	tests := []struct {
+		actionType string
+		wantPath   string
+	}{
+		{"standard", "/services/data/v62.0/actions/standard"},
+		{"Standard", "/services/data/v62.0/actions/standard"},
+		{"Flow", "/services/data/v62.0/actions/custom/flow"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.actionType, func(t *testing.T) {
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, tt.wantPath, r.URL.Path)
+				_, _ = w.Write([]byte(`{"actions": [{"name": "Close_Case", "label": "Close Case", "type": "FLOW"}]}`))
+			})
+
+			list, err := client.List(context.Background(), tt.actionType)
+			require.NoError(t, err)
+			assert.Equal(t, []Action{{Name: "Close_Case", Label: "Close Case", Type: "FLOW"}}, list)
+		})
+	}
+}
+
+func TestClient_Describe(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/services/data/v62.0/actions/custom/flow/Close_Case", r.URL.Path)
+		_, _ = w.Write([]byte(`{"name": "Close_Case", "label": "Close Case", "type": "FLOW",
+			"inputs": [{"name": "caseId", "type": "STRING", "required": true}],
+			"outputs": [{"name": "closed", "type": "BOOLEAN"}]}`))
+	})
+
+	desc, err := client.Describe(context.Background(), "flow", "Close_Case")
+	require.NoError(t, err)
+	assert.Equal(t, "Close Case", desc.Label)
+	require.Len(t, desc.Inputs, 1)
+	assert.True(t, desc.Inputs[0].Required)
+	require.Len(t, desc.Outputs, 1)
+	assert.Equal(t, "closed", desc.Outputs[0].Name)
+}
+
+func TestClient_Invoke(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/services/data/v62.0/actions/custom/flow/Close_Case", r.URL.Path)
+
+		var body map[string][]map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, []map[string]interface{}{{"caseId": "500xx1"}}, body["inputs"])
+
+		_, _ = w.Write([]byte(`[{"actionName": "Close_Case", "isSuccess": true, "errors": null,
+			"outputValues": {"closed": true}}]`))
+	})
+
+	results, err := client.Invoke(context.Background(), "flow", "Close_Case", []map[string]interface{}{{"caseId": "500xx1"}})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.True(t, results[0].IsSuccess)
+	assert.Equal(t, true, results[0].OutputValues["closed"])
+}
+
+func TestClient_Invoke_Failed(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`[
+			{"actionName": "Close_Case", "isSuccess": true, "outputValues": {}},
+			{"actionName": "Close_Case", "isSuccess": false, "outputValues": null,
+				"errors": [{"statusCode": "REQUIRED_FIELD_MISSING", "message": "Missing required input parameter: caseId"}]}
+		]`))
+	})
+
+	results, err := client.Invoke(context.Background(), "flow", "Close_Case", []map[string]interface{}{{"caseId": "500xx1"}, {}})
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.True(t, results[0].IsSuccess)
+	assert.False(t, results[1].IsSuccess)
+	assert.Equal(t, "REQUIRED_FIELD_MISSING: Missing required input parameter: caseId", results[1].ErrorMessage())
+}
+
+func TestClient_Invoke_NotFound(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`[{"errorCode": "NOT_FOUND", "message": "The requested resource does not exist"}]`))
+	})
+
+	_, err := client.Invoke(context.Background(), "flow", "Missing", nil)
+	require.Error(t, err)
+	assert.True(t, api.IsNotFound(err))
+}
+
+func TestNormalizeType(t *testing.T) {
+	tests := map[string]string{
+		"standard":    "standard",
+		"STANDARD":    "standard",
+		"Flow":        "flow",
+		"apex":        "apex",
+		"QuickAction": "quickAction",
+		"":            "",
+	}
+
+	for in, want := range tests {
+		t.Run(in, func(t *testing.T) {
+			assert.Equal(t, want, NormalizeType(in))
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package api
+
+import "context"
+
+// BackgroundClient calls Client methods with context.Background(). It is a
+// convenience for scripts and other callers without a context of their own;
+// code that has a context should pass it to the Client methods directly so
+// cancellation and timeouts propagate.
+type BackgroundClient struct {
+	client *Client
+}
+
+// Background returns a BackgroundClient for c.
+func (c *Client) Background() *BackgroundClient {
+	return &BackgroundClient{client: c}
+}
+
+// Query executes a SOQL query and returns the results
+func (b *BackgroundClient) Query(soql string) (*QueryResult, error) {
+	return b.client.Query(context.Background(), soql)
+}
+
+// QueryAll executes a query and retrieves all results (handles pagination)
+func (b *BackgroundClient) QueryAll(soql string) (*QueryResult, error) {
+	return b.client.QueryAll(context.Background(), soql)
+}
+
+// Search executes a SOSL search and returns the results
+func (b *BackgroundClient) Search(sosl string) (*SearchResult, error) {
+	return b.client.Search(context.Background(), sosl)
+}
+
+// DescribeSObject returns detailed metadata about an SObject type
+func (b *BackgroundClient) DescribeSObject(objectName string) (*SObjectDescribe, error) {
+	return b.client.DescribeSObject(context.Background(), objectName)
+}
+
+// GetLimits returns the org's API limits
+func (b *BackgroundClient) GetLimits() (Limits, error) {
+	return b.client.GetLimits(context.Background())
+}
+
+// GetRecord retrieves a single record by ID
+func (b *BackgroundClient) GetRecord(objectName, recordID string, fields []string) (*SObject, error) {
+	return b.client.GetRecord(context.Background(), objectName, recordID, fields)
+}
+
+// CreateRecord creates a new record and returns the result
+func (b *BackgroundClient) CreateRecord(objectName string, record map[string]interface{}) (*RecordResult, error) {
+	return b.client.CreateRecord(context.Background(), objectName, record)
+}
+
+// UpdateRecord updates an existing record
+func (b *BackgroundClient) UpdateRecord(objectName, recordID string, record map[string]interface{}) error {
+	return b.client.UpdateRecord(context.Background(), objectName, recordID, record)
+}
+
+// UpsertRecord creates or updates a record by external ID
+func (b *BackgroundClient) UpsertRecord(objectName, extIDField, extIDValue string, record map[string]interface{}) (*RecordResult, error) {
+	return b.client.UpsertRecord(context.Background(), objectName, extIDField, extIDValue, record)
+}
+
+// DeleteRecord deletes a record
+func (b *BackgroundClient) DeleteRecord(objectName, recordID string) error {
+	return b.client.DeleteRecord(context.Background(), objectName, recordID)
+}
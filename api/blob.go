@@ -0,0 +1,38 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// GetBlob streams the binary content at path to w without holding it in
+// memory, and returns the number of bytes written. Paths are the blob
+// resources of binary fields, e.g.
+// /sobjects/ContentVersion/{id}/VersionData, /sobjects/Attachment/{id}/Body,
+// or /sobjects/Document/{id}/Body.
+func (c *Client) GetBlob(ctx context.Context, path string, w io.Writer) (int64, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.buildURL(path), nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "*/*")
+
+	resp, err := c.Retry.Do(c.HTTPClient, req)
+	if err != nil {
+		return 0, fmt.Errorf("request failed: %w", err)
+	}
+
+	if resp.StatusCode >= 400 {
+		return 0, ParseAPIError(resp)
+	}
+	defer resp.Body.Close()
+
+	n, err := io.Copy(w, resp.Body)
+	if err != nil {
+		return n, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	return n, nil
+}
@@ -0,0 +1,49 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_GetBlob(t *testing.T) {
+	content := bytes.Repeat([]byte{0x25, 0x50, 0x44, 0x46, 0x00, 0xff}, 10000)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/services/data/v62.0/sobjects/ContentVersion/068xx0000001/VersionData", r.URL.Path)
+		w.Header().Set("Content-Type", "application/octetstream")
+		_, _ = w.Write(content)
+	}))
+	defer server.Close()
+
+	client, err := New(ClientConfig{InstanceURL: server.URL, HTTPClient: server.Client()})
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	n, err := client.GetBlob(context.Background(), "/sobjects/ContentVersion/068xx0000001/VersionData", &buf)
+	require.NoError(t, err)
+	assert.Equal(t, int64(len(content)), n)
+	assert.Equal(t, content, buf.Bytes())
+}
+
+func TestClient_GetBlob_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`[{"errorCode":"NOT_FOUND","message":"The requested resource does not exist"}]`))
+	}))
+	defer server.Close()
+
+	client, err := New(ClientConfig{InstanceURL: server.URL, HTTPClient: server.Client()})
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	_, err = client.GetBlob(context.Background(), "/sobjects/Attachment/00Pxx0000001/Body", &buf)
+	assert.True(t, IsNotFound(err))
+	assert.Zero(t, buf.Len())
+}
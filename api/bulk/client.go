@@ -8,6 +8,9 @@ import (
 	"io"
 	"net/http"
 	"strings"
+	"time"
+
+	"github.com/open-cli-collective/salesforce-cli/api"
 )
 
 // Client is a Salesforce Bulk API 2.0 client.
@@ -16,6 +19,8 @@ type Client struct {
 	instanceURL string
 	apiVersion  string
 	baseURL     string
+	retry       api.RetryPolicy
+	responses   *api.ResponseRecorder
 }
 
 // ClientConfig contains configuration for creating a new Bulk API client.
@@ -23,6 +28,23 @@ type ClientConfig struct {
 	InstanceURL string
 	HTTPClient  *http.Client
 	APIVersion  string
+	// Retry controls how requests failing with transient errors are retried
+	// (optional, defaults to a single attempt)
+	Retry api.RetryPolicy
+	// Middleware wraps the HTTP client's transport, first outermost
+	// (optional)
+	Middleware []api.Middleware
+	// Compression controls gzip compression of request and response bodies
+	// (optional, defaults to none beyond what the transport does itself)
+	Compression api.Compression
+
+	// Timeout fails a request attempt that makes no progress for this long
+	// (optional, defaults to none)
+	Timeout time.Duration
+
+	// RateLimiter limits how often requests are sent; share one between
+	// clients to limit them together (optional, defaults to no limit)
+	RateLimiter *api.RateLimiter
 }
 
 // New creates a new Bulk API client.
@@ -40,14 +62,35 @@ func New(cfg ClientConfig) (*Client, error) {
 		apiVersion = "v62.0"
 	}
 
+	// Responses are recorded outermost, as returned; compression and the
+	// timeout go innermost, to see the bytes on the wire
+	responses := &api.ResponseRecorder{}
+	middleware := api.WithResponseRecorder(cfg.Middleware, responses)
+	middleware = api.WithRateLimit(middleware, cfg.RateLimiter)
+	middleware = api.WithCompression(middleware, cfg.Compression)
+	middleware = api.WithTimeout(middleware, cfg.Timeout)
+
 	return &Client{
-		httpClient:  cfg.HTTPClient,
+		httpClient:  api.WrapHTTPClient(cfg.HTTPClient, middleware...),
 		instanceURL: instanceURL,
 		apiVersion:  apiVersion,
 		baseURL:     fmt.Sprintf("%s/services/data/%s", instanceURL, apiVersion),
+		retry:       cfg.Retry,
+		responses:   responses,
 	}, nil
 }
 
+// InstanceURL returns the URL of the Salesforce instance the client calls.
+func (c *Client) InstanceURL() string {
+	return c.instanceURL
+}
+
+// LastResponse returns the metadata of the last Bulk API response, or nil if
+// there has been none, as api.Client.LastResponse does.
+func (c *Client) LastResponse() *api.ResponseInfo {
+	return c.responses.Last()
+}
+
 // doRequest performs an HTTP request and returns the response body.
 func (c *Client) doRequest(ctx context.Context, method, path string, body interface{}) ([]byte, error) {
 	var bodyReader io.Reader
@@ -61,6 +104,11 @@ func (c *Client) doRequest(ctx context.Context, method, path string, body interf
 		case []byte:
 			bodyReader = bytes.NewReader(v)
 			contentType = "text/csv"
+		case json.RawMessage:
+			bodyReader = bytes.NewReader(v)
+		case io.Reader:
+			bodyReader = v
+			contentType = "text/csv"
 		default:
 			jsonBody, err := json.Marshal(body)
 			if err != nil {
@@ -83,7 +131,7 @@ func (c *Client) doRequest(ctx context.Context, method, path string, body interf
 	req.Header.Set("Content-Type", contentType)
 	req.Header.Set("Accept", "application/json")
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.retry.Do(c.httpClient, req)
 	if err != nil {
 		return nil, fmt.Errorf("request failed: %w", err)
 	}
@@ -101,8 +149,42 @@ func (c *Client) doRequest(ctx context.Context, method, path string, body interf
 	return respBody, nil
 }
 
-// doCSVRequest performs an HTTP request expecting CSV response.
+// doCSVRequest performs an HTTP request expecting CSV response. Results are
+// downloaded gzip-compressed when Compression.Response is set, and arrive
+// here already decompressed.
 func (c *Client) doCSVRequest(ctx context.Context, method, path string) ([]byte, error) {
+	body, _, err := c.doCSVRequestHeader(ctx, method, path)
+	return body, err
+}
+
+// doCSVRequestHeader performs an HTTP request expecting CSV response, and
+// also returns the response headers.
+func (c *Client) doCSVRequestHeader(ctx context.Context, method, path string) ([]byte, http.Header, error) {
+	resp, err := c.openCSVRequest(ctx, method, path)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	return respBody, resp.Header, nil
+}
+
+// openCSVRequest performs an HTTP request expecting CSV response, and
+// returns the response for its body to be streamed. The caller must close
+// the body.
+func (c *Client) openCSVRequest(ctx context.Context, method, path string) (*http.Response, error) {
+	return c.openRequest(ctx, method, path, "text/csv")
+}
+
+// openRequest performs an HTTP request expecting a response of the accept
+// media type, and returns the response for its body to be streamed. The
+// caller must close the body.
+func (c *Client) openRequest(ctx context.Context, method, path, accept string) (*http.Response, error) {
 	fullURL := path
 	if !strings.HasPrefix(path, "http") {
 		fullURL = c.baseURL + path
@@ -113,22 +195,21 @@ func (c *Client) doCSVRequest(ctx context.Context, method, path string) ([]byte,
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	req.Header.Set("Accept", "text/csv")
+	req.Header.Set("Accept", accept)
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.retry.Do(c.httpClient, req)
 	if err != nil {
 		return nil, fmt.Errorf("request failed: %w", err)
 	}
-	defer resp.Body.Close()
-
-	respBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
-	}
 
 	if resp.StatusCode >= 400 {
+		defer resp.Body.Close()
+		respBody, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read response: %w", err)
+		}
 		return nil, fmt.Errorf("API error (status %d): %s", resp.StatusCode, string(respBody))
 	}
 
-	return respBody, nil
+	return resp, nil
 }
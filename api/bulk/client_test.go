@@ -1,14 +1,21 @@
 package bulk
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
 	"encoding/json"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/salesforce-cli/api"
 )
 
 func TestNew(t *testing.T) {
@@ -86,6 +93,34 @@ func TestCreateJob(t *testing.T) {
 	assert.Equal(t, StateOpen, job.State)
 }
 
+func TestCreateJob_LineEndingAndDelimiter(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req CreateJobRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		assert.Equal(t, LineEndingCRLF, req.LineEnding)
+		assert.Equal(t, DelimiterSemicolon, req.ColumnDelimiter)
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(JobInfo{ID: "750xx000000001", LineEnding: req.LineEnding, ColumnDelimiter: req.ColumnDelimiter})
+	}))
+	defer server.Close()
+
+	client, err := New(ClientConfig{
+		InstanceURL: server.URL,
+		HTTPClient:  server.Client(),
+	})
+	require.NoError(t, err)
+
+	job, err := client.CreateJob(context.Background(), JobConfig{
+		Object:          "Account",
+		Operation:       OperationInsert,
+		LineEnding:      LineEndingCRLF,
+		ColumnDelimiter: DelimiterSemicolon,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, DelimiterSemicolon, job.ColumnDelimiter)
+}
+
 func TestUploadJobData(t *testing.T) {
 	csvData := []byte("Name,Industry\nAcme,Technology")
 
@@ -107,6 +142,84 @@ func TestUploadJobData(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestUploadJobDataFrom(t *testing.T) {
+	var uploaded []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, "text/csv", r.Header.Get("Content-Type"))
+		uploaded, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	client, err := New(ClientConfig{
+		InstanceURL: server.URL,
+		HTTPClient:  server.Client(),
+	})
+	require.NoError(t, err)
+
+	pr, pw := io.Pipe()
+	go func() {
+		_, _ = pw.Write([]byte("Name\n"))
+		_, _ = pw.Write([]byte("Acme\n"))
+		pw.Close()
+	}()
+
+	err = client.UploadJobDataFrom(context.Background(), "750xx000000001", pr)
+	require.NoError(t, err)
+	assert.Equal(t, "Name\nAcme\n", string(uploaded))
+}
+
+func TestUploadJobRecords(t *testing.T) {
+	var uploaded []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Contains(t, r.URL.Path, "/jobs/ingest/750xx000000001/batches")
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		_ = json.NewDecoder(r.Body).Decode(&uploaded)
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	client, err := New(ClientConfig{
+		InstanceURL: server.URL,
+		HTTPClient:  server.Client(),
+	})
+	require.NoError(t, err)
+
+	err = client.UploadJobRecords(context.Background(), "750xx000000001", []map[string]interface{}{
+		{"Name": "Acme", "NumberOfEmployees": 50},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []map[string]interface{}{{"Name": "Acme", "NumberOfEmployees": float64(50)}}, uploaded)
+}
+
+func TestUploadJobData_Compression(t *testing.T) {
+	csvData := []byte("Name,Industry\n" + strings.Repeat("Acme,Technology\n", 500))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "text/csv", r.Header.Get("Content-Type"))
+		assert.Equal(t, "gzip", r.Header.Get("Content-Encoding"))
+		zr, err := gzip.NewReader(r.Body)
+		require.NoError(t, err)
+		body, err := io.ReadAll(zr)
+		require.NoError(t, err)
+		assert.Equal(t, csvData, body)
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	client, err := New(ClientConfig{
+		InstanceURL: server.URL,
+		HTTPClient:  server.Client(),
+		Compression: api.Compression{Request: true, Response: true},
+	})
+	require.NoError(t, err)
+
+	err = client.UploadJobData(context.Background(), "750xx000000001", csvData)
+	require.NoError(t, err)
+}
+
 func TestCloseJob(t *testing.T) {
 	expectedJob := JobInfo{
 		ID:    "750xx000000001",
@@ -164,6 +277,36 @@ func TestGetJob(t *testing.T) {
 	assert.Equal(t, 2, job.NumberRecordsFailed)
 }
 
+func TestPollJob_OnJob(t *testing.T) {
+	polls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		polls++
+		job := JobInfo{ID: "750xx000000001", State: StateInProgress, NumberRecordsProcessed: 1}
+		if polls > 1 {
+			job.State = StateJobComplete
+			job.NumberRecordsProcessed = 2
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(job)
+	}))
+	defer server.Close()
+
+	client, err := New(ClientConfig{
+		InstanceURL: server.URL,
+		HTTPClient:  server.Client(),
+	})
+	require.NoError(t, err)
+
+	var states []State
+	job, err := client.PollJob(context.Background(), "750xx000000001", PollConfig{
+		Interval: time.Millisecond,
+		OnJob:    func(job *JobInfo) { states = append(states, job.State) },
+	})
+	require.NoError(t, err)
+	assert.Equal(t, StateJobComplete, job.State)
+	assert.Equal(t, []State{StateInProgress, StateJobComplete}, states)
+}
+
 func TestListJobs(t *testing.T) {
 	expected := JobsResponse{
 		Done: true,
@@ -215,6 +358,32 @@ func TestGetSuccessfulResults(t *testing.T) {
 	assert.Equal(t, csvData, string(data))
 }
 
+func TestGetSuccessfulResults_Retry(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "text/csv")
+		_, _ = w.Write([]byte("sf__Id\n001xx000001"))
+	}))
+	defer server.Close()
+
+	client, err := New(ClientConfig{
+		InstanceURL: server.URL,
+		HTTPClient:  server.Client(),
+		Retry:       api.RetryPolicy{MaxAttempts: 2, Backoff: time.Millisecond, RetryableStatus: []int{http.StatusServiceUnavailable}},
+	})
+	require.NoError(t, err)
+
+	data, err := client.GetSuccessfulResults(context.Background(), "750xx000000001")
+	require.NoError(t, err)
+	assert.Equal(t, "sf__Id\n001xx000001", string(data))
+	assert.Equal(t, 2, calls)
+}
+
 func TestGetFailedResults(t *testing.T) {
 	csvData := "sf__Id,sf__Error,Name\n,REQUIRED_FIELD_MISSING,Acme"
 
@@ -237,6 +406,52 @@ func TestGetFailedResults(t *testing.T) {
 	assert.Contains(t, string(data), "REQUIRED_FIELD_MISSING")
 }
 
+func TestResultDownloads_Compression(t *testing.T) {
+	results := map[string]string{
+		"/services/data/v62.0/jobs/ingest/750xx000000001/successfulResults": "sf__Id,sf__Created,Name\n" + strings.Repeat("001xx000001,true,Acme\n", 500),
+		"/services/data/v62.0/jobs/ingest/750xx000000001/failedResults":     "sf__Id,sf__Error,Name\n" + strings.Repeat(",REQUIRED_FIELD_MISSING,Acme\n", 500),
+		"/services/data/v62.0/jobs/query/750xx000000002/results":            "Id,Name\n" + strings.Repeat("001xx000001,Acme\n", 500),
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "gzip", r.Header.Get("Accept-Encoding"))
+		data, ok := results[r.URL.Path]
+		if !assert.True(t, ok, "unexpected request %s", r.URL.Path) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/csv")
+		w.Header().Set("Content-Encoding", "gzip")
+		zw := gzip.NewWriter(w)
+		_, _ = zw.Write([]byte(data))
+		_ = zw.Close()
+	}))
+	defer server.Close()
+
+	// The transport doesn't decompress on its own, so the client must
+	client, err := New(ClientConfig{
+		InstanceURL: server.URL,
+		HTTPClient:  &http.Client{Transport: &http.Transport{DisableCompression: true}},
+		Compression: api.Compression{Response: true},
+	})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	data, err := client.GetSuccessfulResults(ctx, "750xx000000001")
+	require.NoError(t, err)
+	assert.Equal(t, results["/services/data/v62.0/jobs/ingest/750xx000000001/successfulResults"], string(data))
+
+	data, err = client.GetFailedResults(ctx, "750xx000000001")
+	require.NoError(t, err)
+	assert.Equal(t, results["/services/data/v62.0/jobs/ingest/750xx000000001/failedResults"], string(data))
+
+	var buf bytes.Buffer
+	_, err = client.WriteQueryResults(ctx, "750xx000000002", &buf, QueryResultsOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, results["/services/data/v62.0/jobs/query/750xx000000002/results"], buf.String())
+}
+
 func TestCreateQueryJob(t *testing.T) {
 	expectedJob := QueryJobInfo{
 		ID:        "750xx000000001",
@@ -295,6 +510,101 @@ func TestGetQueryResults(t *testing.T) {
 	assert.Contains(t, string(data), "Test")
 }
 
+func TestGetQueryResultsJSON(t *testing.T) {
+	pages := map[string]struct{ data, next string }{
+		"":        {`[{"Id":"001xx000001","Name":"Acme"}]`, "MTAwMDA"},
+		"MTAwMDA": {`[{"Id":"001xx000002","Name":"Test"}]`, "null"},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/json", r.Header.Get("Accept"))
+		page := pages[r.URL.Query().Get("locator")]
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Sforce-Locator", page.next)
+		_, _ = w.Write([]byte(page.data))
+	}))
+	defer server.Close()
+
+	client, err := New(ClientConfig{
+		InstanceURL: server.URL,
+		HTTPClient:  server.Client(),
+	})
+	require.NoError(t, err)
+
+	records, err := client.GetQueryResultsJSON(context.Background(), "750xx000000001")
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	assert.Equal(t, "Acme", records[0]["Name"])
+	assert.Equal(t, "Test", records[1]["Name"])
+}
+
+func TestGetQueryResults_Pages(t *testing.T) {
+	pages := map[string]struct{ data, next string }{
+		"":        {"Id,Name\n001xx000001,Acme\n", "MTAwMDA"},
+		"MTAwMDA": {"Id,Name\n001xx000002,\"Two\nLines\"\n", "MjAwMDA"},
+		"MjAwMDA": {"Id,Name\n001xx000003,Last", "null"},
+	}
+
+	var locators []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		locator := r.URL.Query().Get("locator")
+		locators = append(locators, locator)
+
+		page := pages[locator]
+		w.Header().Set("Content-Type", "text/csv")
+		w.Header().Set("Sforce-Locator", page.next)
+		w.Header().Set("Sforce-NumberOfRecords", "1")
+		_, _ = w.Write([]byte(page.data))
+	}))
+	defer server.Close()
+
+	client, err := New(ClientConfig{
+		InstanceURL: server.URL,
+		HTTPClient:  server.Client(),
+	})
+	require.NoError(t, err)
+
+	data, err := client.GetQueryResults(context.Background(), "750xx000000001")
+	require.NoError(t, err)
+	assert.Equal(t, "Id,Name\n001xx000001,Acme\n001xx000002,\"Two\nLines\"\n001xx000003,Last", string(data))
+	assert.Equal(t, []string{"", "MTAwMDA", "MjAwMDA"}, locators)
+
+	var buf bytes.Buffer
+	records, err := client.WriteQueryResults(context.Background(), "750xx000000001", &buf, QueryResultsOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, 3, records)
+	assert.Equal(t, string(data), buf.String())
+}
+
+func TestGetQueryResultsPage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/services/data/v62.0/jobs/query/750xx000000001/results", r.URL.Path)
+		assert.Equal(t, "MTAwMDA", r.URL.Query().Get("locator"))
+		assert.Equal(t, "500", r.URL.Query().Get("maxRecords"))
+
+		w.Header().Set("Content-Type", "text/csv")
+		w.Header().Set("Sforce-Locator", "null")
+		w.Header().Set("Sforce-NumberOfRecords", "2")
+		_, _ = w.Write([]byte("Id\n001xx000001\n001xx000002\n"))
+	}))
+	defer server.Close()
+
+	client, err := New(ClientConfig{
+		InstanceURL: server.URL,
+		HTTPClient:  server.Client(),
+	})
+	require.NoError(t, err)
+
+	page, err := client.GetQueryResultsPage(context.Background(), "750xx000000001", QueryResultsOptions{
+		Locator:    "MTAwMDA",
+		MaxRecords: 500,
+	})
+	require.NoError(t, err)
+	assert.Empty(t, page.Locator)
+	assert.Equal(t, 2, page.NumberOfRecords)
+	assert.Equal(t, "001xx000001\n001xx000002\n", string(page.Rows()))
+}
+
 func TestAbortJob(t *testing.T) {
 	expectedJob := JobInfo{
 		ID:    "750xx000000001",
@@ -0,0 +1,200 @@
+package bulk
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// NullValue is the value Bulk API uses in CSV data to set a field to null.
+// Query results represent null as an empty field instead.
+const NullValue = "#N/A"
+
+// delimiters maps each column delimiter to the character it names.
+var delimiters = map[ColumnDelimiter]rune{
+	DelimiterComma:     ',',
+	DelimiterSemicolon: ';',
+	DelimiterTab:       '\t',
+	DelimiterPipe:      '|',
+	DelimiterCaret:     '^',
+	DelimiterBackquote: '`',
+}
+
+// ParseColumnDelimiter returns the column delimiter s names, either by its
+// Bulk API name in any case (e.g. semicolon) or as the character itself
+// (e.g. ";"). An empty s is the default, COMMA.
+func ParseColumnDelimiter(s string) (ColumnDelimiter, error) {
+	if s == "" {
+		return DelimiterComma, nil
+	}
+	for d, r := range delimiters {
+		if strings.EqualFold(s, string(d)) || s == string(r) || (r == '\t' && s == `\t`) {
+			return d, nil
+		}
+	}
+	return "", fmt.Errorf("invalid column delimiter: %s (must be COMMA, SEMICOLON, TAB, PIPE, CARET, or BACKQUOTE)", s)
+}
+
+// Rune returns the character that separates columns; ',' for an empty
+// delimiter.
+func (d ColumnDelimiter) Rune() rune {
+	if r, ok := delimiters[d]; ok {
+		return r
+	}
+	return ','
+}
+
+// ParseLineEnding returns the line ending s names, LF or CRLF in any case.
+// An empty s is the default, LF.
+func ParseLineEnding(s string) (LineEnding, error) {
+	switch strings.ToUpper(s) {
+	case "", string(LineEndingLF):
+		return LineEndingLF, nil
+	case string(LineEndingCRLF):
+		return LineEndingCRLF, nil
+	}
+	return "", fmt.Errorf("invalid line ending: %s (must be LF or CRLF)", s)
+}
+
+// CSVRecordReader reads records from Bulk API CSV data one at a time, keyed
+// by the header row.
+type CSVRecordReader struct {
+	r      *csv.Reader
+	header []string
+}
+
+// NewCSVRecordReader returns a reader that decodes CSV data from r. The
+// first row is read as the header on the first call to Read.
+func NewCSVRecordReader(r io.Reader) *CSVRecordReader {
+	cr := csv.NewReader(r)
+	cr.ReuseRecord = true
+	return &CSVRecordReader{r: cr}
+}
+
+// Header returns the column names, reading the header row if needed.
+func (c *CSVRecordReader) Header() ([]string, error) {
+	if c.header != nil {
+		return c.header, nil
+	}
+
+	row, err := c.r.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, io.EOF
+		}
+		return nil, fmt.Errorf("failed to read CSV header: %w", err)
+	}
+
+	c.header = append([]string(nil), row...)
+	return c.header, nil
+}
+
+// Read returns the next record, or io.EOF when there are no more. Null
+// values (empty fields and NullValue) are returned as empty strings.
+func (c *CSVRecordReader) Read() (map[string]string, error) {
+	header, err := c.Header()
+	if err != nil {
+		return nil, err
+	}
+
+	row, err := c.r.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, io.EOF
+		}
+		return nil, fmt.Errorf("failed to read CSV: %w", err)
+	}
+
+	record := make(map[string]string, len(header))
+	for i, name := range header {
+		value := row[i]
+		if value == NullValue {
+			value = ""
+		}
+		record[name] = value
+	}
+
+	return record, nil
+}
+
+// ParseCSVRecords decodes Bulk API CSV data (such as query or job results)
+// into records keyed by the header row. Null values are empty strings.
+func ParseCSVRecords(data []byte) ([]map[string]string, error) {
+	reader := NewCSVRecordReader(bytes.NewReader(data))
+
+	var records []map[string]string
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return records, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+}
+
+// MaxUploadBytes is the most job data Bulk API 2.0 accepts in one upload.
+// The limit applies to the data as Salesforce stores it, which can be larger
+// than the CSV sent, so SplitUploadBytes is used when splitting files.
+const MaxUploadBytes = 150 * 1024 * 1024
+
+// SplitUploadBytes is the size Salesforce recommends keeping each upload
+// under, leaving room below MaxUploadBytes.
+const SplitUploadBytes = 100 * 1024 * 1024
+
+// SplitCSV splits CSV data with a header row into parts of at most maxBytes,
+// each starting with the header, so that each can be uploaded as a job of
+// its own. Columns are separated by delimiter. Parts end on record
+// boundaries, so quoted fields with line breaks are never cut; a record too
+// large for a part gets one to itself. Data that fits is returned as its
+// only part.
+func SplitCSV(data []byte, maxBytes int, delimiter ColumnDelimiter) ([][]byte, error) {
+	if len(data) <= maxBytes {
+		return [][]byte{data}, nil
+	}
+
+	r := csv.NewReader(bytes.NewReader(data))
+	r.Comma = delimiter.Rune()
+	r.ReuseRecord = true
+
+	if _, err := r.Read(); err != nil {
+		return nil, fmt.Errorf("failed to read CSV header: %w", err)
+	}
+	header := data[:r.InputOffset()]
+
+	var parts [][]byte
+	start, end := len(header), len(header)
+	for {
+		_, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CSV: %w", err)
+		}
+
+		next := int(r.InputOffset())
+		if end > start && len(header)+next-start > maxBytes {
+			parts = append(parts, joinPart(header, data[start:end]))
+			start = end
+		}
+		end = next
+	}
+	if end > start || len(parts) == 0 {
+		parts = append(parts, joinPart(header, data[start:end]))
+	}
+
+	return parts, nil
+}
+
+// joinPart returns the header followed by rows, in a new slice.
+func joinPart(header, rows []byte) []byte {
+	part := make([]byte, 0, len(header)+len(rows))
+	part = append(part, header...)
+	return append(part, rows...)
+}
@@ -0,0 +1,179 @@
+package bulk
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCSVRecords(t *testing.T) {
+	data := "\"Id\",\"Name\",\"Description\",\"Phone\"\n" +
+		"\"001xx000001\",\"Acme, Inc.\",\"Line one\nLine \"\"two\"\"\",\"\"\n" +
+		"\"001xx000002\",\"Test\",\"#N/A\",\"555-0100\"\n"
+
+	records, err := ParseCSVRecords([]byte(data))
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+
+	assert.Equal(t, map[string]string{
+		"Id":          "001xx000001",
+		"Name":        "Acme, Inc.",
+		"Description": "Line one\nLine \"two\"",
+		"Phone":       "",
+	}, records[0])
+	assert.Equal(t, "", records[1]["Description"])
+	assert.Equal(t, "555-0100", records[1]["Phone"])
+}
+
+func TestParseCSVRecords_Empty(t *testing.T) {
+	records, err := ParseCSVRecords(nil)
+	require.NoError(t, err)
+	assert.Empty(t, records)
+
+	records, err = ParseCSVRecords([]byte("Id,Name\n"))
+	require.NoError(t, err)
+	assert.Empty(t, records)
+}
+
+func TestParseCSVRecords_Malformed(t *testing.T) {
+	_, err := ParseCSVRecords([]byte("Id,Name\n001,Acme,extra\n"))
+	require.Error(t, err)
+}
+
+func TestCSVRecordReader(t *testing.T) {
+	reader := NewCSVRecordReader(strings.NewReader("Id,Name\n001,Acme\n002,Test\n"))
+
+	header, err := reader.Header()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Id", "Name"}, header)
+
+	first, err := reader.Read()
+	require.NoError(t, err)
+	second, err := reader.Read()
+	require.NoError(t, err)
+
+	// Records must not share storage
+	assert.Equal(t, "Acme", first["Name"])
+	assert.Equal(t, "Test", second["Name"])
+
+	_, err = reader.Read()
+	assert.True(t, errors.Is(err, io.EOF))
+}
+
+func TestSplitCSV(t *testing.T) {
+	data := "Name,Description\n" +
+		"Acme,short\n" +
+		"Globex,\"two\nlines\"\n" +
+		"Initech,short\n" +
+		"Umbrella,last"
+
+	tests := []struct {
+		name     string
+		maxBytes int
+		want     []string
+	}{
+		{
+			name:     "fits",
+			maxBytes: len(data),
+			want:     []string{data},
+		},
+		{
+			name:     "one record per part",
+			maxBytes: 20,
+			want: []string{
+				"Name,Description\nAcme,short\n",
+				"Name,Description\nGlobex,\"two\nlines\"\n",
+				"Name,Description\nInitech,short\n",
+				"Name,Description\nUmbrella,last",
+			},
+		},
+		{
+			name:     "several records per part",
+			maxBytes: 60,
+			want: []string{
+				"Name,Description\nAcme,short\nGlobex,\"two\nlines\"\n",
+				"Name,Description\nInitech,short\nUmbrella,last",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parts, err := SplitCSV([]byte(data), tt.maxBytes, DelimiterComma)
+			require.NoError(t, err)
+
+			got := make([]string, len(parts))
+			for i, part := range parts {
+				got[i] = string(part)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSplitCSV_HeaderOnly(t *testing.T) {
+	parts, err := SplitCSV([]byte("Name,Description\n"), 5, DelimiterComma)
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("Name,Description\n")}, parts)
+}
+
+func TestSplitCSV_Malformed(t *testing.T) {
+	_, err := SplitCSV([]byte("Name\n\"unterminated\n"), 5, DelimiterComma)
+	assert.Error(t, err)
+}
+
+func TestSplitCSV_Semicolons(t *testing.T) {
+	data := "Name;Description\r\nAcme;\"a;b\"\r\nGlobex;short\r\n"
+
+	parts, err := SplitCSV([]byte(data), 30, DelimiterSemicolon)
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{
+		[]byte("Name;Description\r\nAcme;\"a;b\"\r\n"),
+		[]byte("Name;Description\r\nGlobex;short\r\n"),
+	}, parts)
+}
+
+func TestParseColumnDelimiter(t *testing.T) {
+	tests := []struct {
+		in   string
+		want ColumnDelimiter
+	}{
+		{"", DelimiterComma},
+		{"comma", DelimiterComma},
+		{"SEMICOLON", DelimiterSemicolon},
+		{";", DelimiterSemicolon},
+		{"tab", DelimiterTab},
+		{`\t`, DelimiterTab},
+		{"|", DelimiterPipe},
+		{"^", DelimiterCaret},
+		{"`", DelimiterBackquote},
+	}
+	for _, tt := range tests {
+		got, err := ParseColumnDelimiter(tt.in)
+		require.NoError(t, err, tt.in)
+		assert.Equal(t, tt.want, got, tt.in)
+	}
+
+	_, err := ParseColumnDelimiter("colon")
+	assert.Error(t, err)
+
+	assert.Equal(t, ';', DelimiterSemicolon.Rune())
+	assert.Equal(t, ',', ColumnDelimiter("").Rune())
+}
+
+func TestParseLineEnding(t *testing.T) {
+	got, err := ParseLineEnding("crlf")
+	require.NoError(t, err)
+	assert.Equal(t, LineEndingCRLF, got)
+
+	got, err = ParseLineEnding("")
+	require.NoError(t, err)
+	assert.Equal(t, LineEndingLF, got)
+
+	_, err = ParseLineEnding("CR")
+	assert.Error(t, err)
+}
@@ -1,28 +1,47 @@
 package bulk
 
 import (
+	"bufio"
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"net/url"
+	"strconv"
 	"time"
 )
 
-// CreateJob creates a new bulk ingest job.
-func (c *Client) CreateJob(ctx context.Context, cfg JobConfig) (*JobInfo, error) {
+// ingestJobsPath is the path of the ingest jobs resource.
+const ingestJobsPath = "/jobs/ingest"
+
+// Request returns the request body that creates a job with this config.
+func (cfg JobConfig) Request() CreateJobRequest {
 	contentType := cfg.ContentType
 	if contentType == "" {
 		contentType = ContentTypeCSV
 	}
 
-	req := CreateJobRequest{
+	return CreateJobRequest{
 		Object:              cfg.Object,
 		Operation:           cfg.Operation,
 		ExternalIDFieldName: cfg.ExternalID,
 		ContentType:         contentType,
+		LineEnding:          cfg.LineEnding,
+		ColumnDelimiter:     cfg.ColumnDelimiter,
 	}
+}
 
-	body, err := c.doRequest(ctx, http.MethodPost, "/jobs/ingest", req)
+// IngestJobsURL returns the URL that ingest jobs are created at.
+func (c *Client) IngestJobsURL() string {
+	return c.baseURL + ingestJobsPath
+}
+
+// CreateJob creates a new bulk ingest job.
+func (c *Client) CreateJob(ctx context.Context, cfg JobConfig) (*JobInfo, error) {
+	body, err := c.doRequest(ctx, http.MethodPost, ingestJobsPath, cfg.Request())
 	if err != nil {
 		return nil, err
 	}
@@ -42,6 +61,36 @@ func (c *Client) UploadJobData(ctx context.Context, jobID string, data []byte) e
 	return err
 }
 
+// UploadJobDataFrom uploads CSV data read from r to a bulk job, streaming
+// it as it is read, so data of unknown length need not be held in memory.
+// The upload can't be retried, since r can't be read again.
+func (c *Client) UploadJobDataFrom(ctx context.Context, jobID string, r io.Reader) error {
+	path := fmt.Sprintf("/jobs/ingest/%s/batches", jobID)
+	_, err := c.doRequest(ctx, http.MethodPut, path, r)
+	return err
+}
+
+// UploadJobJSON uploads data to a bulk job created with ContentTypeJSON, as
+// a JSON array of records.
+func (c *Client) UploadJobJSON(ctx context.Context, jobID string, data []byte) error {
+	path := fmt.Sprintf("/jobs/ingest/%s/batches", jobID)
+	_, err := c.doRequest(ctx, http.MethodPut, path, json.RawMessage(data))
+	return err
+}
+
+// UploadJobRecords uploads records to a bulk job created with
+// ContentTypeJSON. Values may be of any type that encodes to JSON.
+func (c *Client) UploadJobRecords(ctx context.Context, jobID string, records []map[string]interface{}) error {
+	if records == nil {
+		records = []map[string]interface{}{}
+	}
+	data, err := json.Marshal(records)
+	if err != nil {
+		return fmt.Errorf("failed to marshal records: %w", err)
+	}
+	return c.UploadJobJSON(ctx, jobID, data)
+}
+
 // CloseJob marks a job as UploadComplete to start processing.
 func (c *Client) CloseJob(ctx context.Context, jobID string) (*JobInfo, error) {
 	path := fmt.Sprintf("/jobs/ingest/%s", jobID)
@@ -137,7 +186,9 @@ func (c *Client) GetUnprocessedRecords(ctx context.Context, jobID string) ([]byt
 // PollJob polls a job until it reaches a terminal state or timeout.
 func (c *Client) PollJob(ctx context.Context, jobID string, cfg PollConfig) (*JobInfo, error) {
 	if cfg.Interval == 0 {
-		cfg = DefaultPollConfig()
+		// Keep the callbacks
+		defaults := DefaultPollConfig()
+		cfg.Interval, cfg.Timeout = defaults.Interval, defaults.Timeout
 	}
 
 	deadline := time.Now().Add(cfg.Timeout)
@@ -149,7 +200,7 @@ func (c *Client) PollJob(ctx context.Context, jobID string, cfg PollConfig) (*Jo
 		case <-ctx.Done():
 			return nil, ctx.Err()
 		case <-ticker.C:
-			if time.Now().After(deadline) {
+			if cfg.Timeout > 0 && time.Now().After(deadline) {
 				return nil, fmt.Errorf("timeout waiting for job to complete")
 			}
 
@@ -157,6 +208,9 @@ func (c *Client) PollJob(ctx context.Context, jobID string, cfg PollConfig) (*Jo
 			if err != nil {
 				return nil, err
 			}
+			if cfg.OnJob != nil {
+				cfg.OnJob(job)
+			}
 
 			switch job.State {
 			case StateJobComplete, StateFailed, StateAborted:
@@ -174,9 +228,11 @@ func (c *Client) CreateQueryJob(ctx context.Context, cfg QueryConfig) (*QueryJob
 	}
 
 	req := CreateQueryJobRequest{
-		Operation:   OperationQuery,
-		Query:       cfg.Query,
-		ContentType: contentType,
+		Operation:       OperationQuery,
+		Query:           cfg.Query,
+		ContentType:     contentType,
+		LineEnding:      cfg.LineEnding,
+		ColumnDelimiter: cfg.ColumnDelimiter,
 	}
 
 	body, err := c.doRequest(ctx, http.MethodPost, "/jobs/query", req)
@@ -208,10 +264,147 @@ func (c *Client) GetQueryJob(ctx context.Context, jobID string) (*QueryJobInfo,
 	return &job, nil
 }
 
-// GetQueryResults retrieves results from a bulk query job.
+// GetQueryResults retrieves all the results of a bulk query job, following
+// the locator of each page to the next, as one CSV file with a single
+// header row. Use WriteQueryResults for results too large to hold in
+// memory.
 func (c *Client) GetQueryResults(ctx context.Context, jobID string) ([]byte, error) {
+	var buf bytes.Buffer
+	if _, err := c.WriteQueryResults(ctx, jobID, &buf, QueryResultsOptions{}); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// WriteQueryResults streams all the results of a bulk query job to w as one
+// CSV file with a single header row, copying each page to w as it is
+// downloaded, so only a small buffer is held in memory. opts.MaxRecords
+// sets the page size, and opts.Locator the page to start from. It returns
+// the number of records written.
+func (c *Client) WriteQueryResults(ctx context.Context, jobID string, w io.Writer, opts QueryResultsOptions) (int, error) {
+	out := &lastByteWriter{w: w}
+	records := 0
+	for first := true; ; first = false {
+		resp, err := c.openCSVRequest(ctx, http.MethodGet, queryResultsPath(jobID, opts))
+		if err != nil {
+			return records, err
+		}
+
+		body := bufio.NewReader(resp.Body)
+		if !first {
+			// Pages after the first repeat the header row
+			if _, err := body.ReadString('\n'); err != nil && !errors.Is(err, io.EOF) {
+				resp.Body.Close()
+				return records, fmt.Errorf("failed to read response: %w", err)
+			}
+			if out.last != 0 && out.last != '\n' {
+				if _, err := out.Write([]byte{'\n'}); err != nil {
+					resp.Body.Close()
+					return records, err
+				}
+			}
+		}
+		_, err = io.Copy(out, body)
+		resp.Body.Close()
+		if err != nil {
+			return records, fmt.Errorf("failed to copy query results: %w", err)
+		}
+
+		n, _ := strconv.Atoi(resp.Header.Get("Sforce-NumberOfRecords"))
+		records += n
+
+		opts.Locator = nextLocator(resp.Header)
+		if opts.Locator == "" {
+			return records, nil
+		}
+	}
+}
+
+// GetQueryResultsJSON retrieves all the results of a bulk query job
+// created with ContentTypeJSON, following the locator of each page to the
+// next. Numbers are kept as json.Number.
+func (c *Client) GetQueryResultsJSON(ctx context.Context, jobID string) ([]map[string]interface{}, error) {
+	var (
+		records []map[string]interface{}
+		opts    QueryResultsOptions
+	)
+	for {
+		resp, err := c.openRequest(ctx, http.MethodGet, queryResultsPath(jobID, opts), "application/json")
+		if err != nil {
+			return nil, err
+		}
+		page, err := ReadJSONRecords(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, page...)
+
+		opts.Locator = nextLocator(resp.Header)
+		if opts.Locator == "" {
+			return records, nil
+		}
+	}
+}
+
+// GetQueryResultsPage retrieves one page of the results of a bulk query
+// job. Pass the Locator of each page in opts to get the next, until a page
+// has none.
+func (c *Client) GetQueryResultsPage(ctx context.Context, jobID string, opts QueryResultsOptions) (*QueryResultsPage, error) {
+	data, header, err := c.doCSVRequestHeader(ctx, http.MethodGet, queryResultsPath(jobID, opts))
+	if err != nil {
+		return nil, err
+	}
+
+	page := &QueryResultsPage{
+		Data:    data,
+		Locator: nextLocator(header),
+	}
+	if n := header.Get("Sforce-NumberOfRecords"); n != "" {
+		page.NumberOfRecords, _ = strconv.Atoi(n)
+	}
+	return page, nil
+}
+
+// queryResultsPath returns the path of a page of query job results.
+func queryResultsPath(jobID string, opts QueryResultsOptions) string {
 	path := fmt.Sprintf("/jobs/query/%s/results", jobID)
-	return c.doCSVRequest(ctx, http.MethodGet, path)
+
+	params := url.Values{}
+	if opts.Locator != "" {
+		params.Set("locator", opts.Locator)
+	}
+	if opts.MaxRecords > 0 {
+		params.Set("maxRecords", strconv.Itoa(opts.MaxRecords))
+	}
+	if len(params) > 0 {
+		path += "?" + params.Encode()
+	}
+	return path
+}
+
+// nextLocator returns the locator of the next page of results from a
+// response's headers, or "" on the last page, whose locator is "null".
+func nextLocator(header http.Header) string {
+	locator := header.Get("Sforce-Locator")
+	if locator == "null" {
+		return ""
+	}
+	return locator
+}
+
+// lastByteWriter remembers the last byte written through it.
+type lastByteWriter struct {
+	w    io.Writer
+	last byte
+}
+
+func (l *lastByteWriter) Write(p []byte) (int, error) {
+	n, err := l.w.Write(p)
+	if n > 0 {
+		l.last = p[n-1]
+	}
+	return n, err
 }
 
 // AbortQueryJob aborts a bulk query job.
@@ -257,7 +450,9 @@ func (c *Client) ListQueryJobs(ctx context.Context) (*QueryJobsResponse, error)
 // PollQueryJob polls a query job until it reaches a terminal state or timeout.
 func (c *Client) PollQueryJob(ctx context.Context, jobID string, cfg PollConfig) (*QueryJobInfo, error) {
 	if cfg.Interval == 0 {
-		cfg = DefaultPollConfig()
+		// Keep the callbacks
+		defaults := DefaultPollConfig()
+		cfg.Interval, cfg.Timeout = defaults.Interval, defaults.Timeout
 	}
 
 	deadline := time.Now().Add(cfg.Timeout)
@@ -269,7 +464,7 @@ func (c *Client) PollQueryJob(ctx context.Context, jobID string, cfg PollConfig)
 		case <-ctx.Done():
 			return nil, ctx.Err()
 		case <-ticker.C:
-			if time.Now().After(deadline) {
+			if cfg.Timeout > 0 && time.Now().After(deadline) {
 				return nil, fmt.Errorf("timeout waiting for query job to complete")
 			}
 
@@ -277,6 +472,9 @@ func (c *Client) PollQueryJob(ctx context.Context, jobID string, cfg PollConfig)
 			if err != nil {
 				return nil, err
 			}
+			if cfg.OnQueryJob != nil {
+				cfg.OnQueryJob(job)
+			}
 
 			switch job.State {
 			case StateJobComplete, StateFailed, StateAborted:
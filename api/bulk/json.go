@@ -0,0 +1,109 @@
+package bulk
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ReadJSONRecords decodes records for a JSON ingest job from r, which holds
+// either a JSON array of objects or JSON Lines, one object per line. Numbers
+// are kept as json.Number so that large values and IDs are not rounded.
+func ReadJSONRecords(r io.Reader) ([]map[string]interface{}, error) {
+	br := bufio.NewReader(r)
+	first, err := firstNonSpace(br)
+	if errors.Is(err, io.EOF) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read JSON: %w", err)
+	}
+
+	dec := json.NewDecoder(br)
+	dec.UseNumber()
+
+	if first == '[' {
+		var records []map[string]interface{}
+		if err := dec.Decode(&records); err != nil {
+			return nil, fmt.Errorf("invalid JSON records: %w", err)
+		}
+		return records, nil
+	}
+
+	var records []map[string]interface{}
+	for {
+		var record map[string]interface{}
+		err := dec.Decode(&record)
+		if errors.Is(err, io.EOF) {
+			return records, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid JSON record %d: %w", len(records)+1, err)
+		}
+		records = append(records, record)
+	}
+}
+
+// firstNonSpace peeks at the first byte of r that is not white space.
+func firstNonSpace(r *bufio.Reader) (byte, error) {
+	for {
+		b, err := r.ReadByte()
+		if err != nil {
+			return 0, err
+		}
+		switch b {
+		case ' ', '\t', '\r', '\n':
+			continue
+		}
+		return b, r.UnreadByte()
+	}
+}
+
+// SplitJSONRecords encodes records as JSON arrays of at most maxBytes, so
+// that each can be uploaded as a job of its own, as SplitCSV does for CSV.
+// A record too large for a part gets one to itself. It returns the parts and
+// the number of records in each.
+func SplitJSONRecords(records []map[string]interface{}, maxBytes int) ([][]byte, []int, error) {
+	var (
+		parts  [][]byte
+		counts []int
+		buf    bytes.Buffer
+		n      int
+	)
+	flush := func() {
+		buf.WriteByte(']')
+		parts = append(parts, bytes.Clone(buf.Bytes()))
+		counts = append(counts, n)
+		buf.Reset()
+		n = 0
+	}
+
+	for _, record := range records {
+		data, err := json.Marshal(record)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to encode JSON record: %w", err)
+		}
+		// The record, its separator, and the closing bracket must fit
+		if n > 0 && buf.Len()+len(data)+2 > maxBytes {
+			flush()
+		}
+		if n == 0 {
+			buf.WriteByte('[')
+		} else {
+			buf.WriteByte(',')
+		}
+		buf.Write(data)
+		n++
+	}
+	if n > 0 || len(parts) == 0 {
+		if n == 0 {
+			buf.WriteByte('[')
+		}
+		flush()
+	}
+
+	return parts, counts, nil
+}
@@ -0,0 +1,70 @@
+package bulk
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadJSONRecords(t *testing.T) {
+	t.Run("array", func(t *testing.T) {
+		records, err := ReadJSONRecords(strings.NewReader(` [{"Name":"Acme","NumberOfEmployees":12345678901234567},{"Name":"Test","Active__c":true}]`))
+		require.NoError(t, err)
+		require.Len(t, records, 2)
+		assert.Equal(t, json.Number("12345678901234567"), records[0]["NumberOfEmployees"])
+		assert.Equal(t, true, records[1]["Active__c"])
+	})
+
+	t.Run("lines", func(t *testing.T) {
+		records, err := ReadJSONRecords(strings.NewReader("{\"Name\":\"Acme\"}\n\n{\"Name\":\"Test\",\"Address__c\":{\"City\":\"Paris\"}}\n"))
+		require.NoError(t, err)
+		require.Len(t, records, 2)
+		assert.Equal(t, map[string]interface{}{"City": "Paris"}, records[1]["Address__c"])
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		records, err := ReadJSONRecords(strings.NewReader("  \n"))
+		require.NoError(t, err)
+		assert.Empty(t, records)
+	})
+
+	t.Run("invalid line", func(t *testing.T) {
+		_, err := ReadJSONRecords(strings.NewReader("{\"Name\":\"Acme\"}\n{\"Name\":\n"))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "record 2")
+	})
+}
+
+func TestSplitJSONRecords(t *testing.T) {
+	records := []map[string]interface{}{
+		{"Name": "Acme"},
+		{"Name": "Test"},
+		{"Name": "Last"},
+	}
+
+	parts, counts, err := SplitJSONRecords(records, 1024)
+	require.NoError(t, err)
+	assert.Equal(t, []string{`[{"Name":"Acme"},{"Name":"Test"},{"Name":"Last"}]`}, partStrings(parts))
+	assert.Equal(t, []int{3}, counts)
+
+	parts, counts, err = SplitJSONRecords(records, 36)
+	require.NoError(t, err)
+	assert.Equal(t, []string{`[{"Name":"Acme"},{"Name":"Test"}]`, `[{"Name":"Last"}]`}, partStrings(parts))
+	assert.Equal(t, []int{2, 1}, counts)
+
+	parts, counts, err = SplitJSONRecords(nil, 36)
+	require.NoError(t, err)
+	assert.Equal(t, []string{`[]`}, partStrings(parts))
+	assert.Equal(t, []int{0}, counts)
+}
+
+func partStrings(parts [][]byte) []string {
+	s := make([]string, len(parts))
+	for i, part := range parts {
+		s[i] = string(part)
+	}
+	return s
+}
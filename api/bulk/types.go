@@ -1,18 +1,22 @@
 // Package bulk provides a client for the Salesforce Bulk API 2.0.
 package bulk
 
-import "time"
+import (
+	"bytes"
+	"time"
+)
 
 // Operation represents a bulk job operation type.
 type Operation string
 
 // Bulk job operations.
 const (
-	OperationInsert Operation = "insert"
-	OperationUpdate Operation = "update"
-	OperationUpsert Operation = "upsert"
-	OperationDelete Operation = "delete"
-	OperationQuery  Operation = "query"
+	OperationInsert     Operation = "insert"
+	OperationUpdate     Operation = "update"
+	OperationUpsert     Operation = "upsert"
+	OperationDelete     Operation = "delete"
+	OperationHardDelete Operation = "hardDelete"
+	OperationQuery      Operation = "query"
 )
 
 // State represents a bulk job state.
@@ -37,49 +41,72 @@ const (
 	ContentTypeJSON ContentType = "JSON"
 )
 
+// LineEnding represents the line ending of CSV job data.
+type LineEnding string
+
+// Line endings.
+const (
+	LineEndingLF   LineEnding = "LF"
+	LineEndingCRLF LineEnding = "CRLF"
+)
+
+// ColumnDelimiter represents the character that separates the columns of
+// CSV job data.
+type ColumnDelimiter string
+
+// Column delimiters.
+const (
+	DelimiterComma     ColumnDelimiter = "COMMA"
+	DelimiterSemicolon ColumnDelimiter = "SEMICOLON"
+	DelimiterTab       ColumnDelimiter = "TAB"
+	DelimiterPipe      ColumnDelimiter = "PIPE"
+	DelimiterCaret     ColumnDelimiter = "CARET"
+	DelimiterBackquote ColumnDelimiter = "BACKQUOTE"
+)
+
 // JobInfo represents information about a bulk job.
 type JobInfo struct {
-	ID                      string      `json:"id,omitempty"`
-	Operation               Operation   `json:"operation"`
-	Object                  string      `json:"object"`
-	CreatedByID             string      `json:"createdById,omitempty"`
-	CreatedDate             string      `json:"createdDate,omitempty"`
-	SystemModstamp          string      `json:"systemModstamp,omitempty"`
-	State                   State       `json:"state,omitempty"`
-	ExternalIDFieldName     string      `json:"externalIdFieldName,omitempty"`
-	ConcurrencyMode         string      `json:"concurrencyMode,omitempty"`
-	ContentType             ContentType `json:"contentType,omitempty"`
-	APIVersion              float64     `json:"apiVersion,omitempty"`
-	JobType                 string      `json:"jobType,omitempty"`
-	LineEnding              string      `json:"lineEnding,omitempty"`
-	ColumnDelimiter         string      `json:"columnDelimiter,omitempty"`
-	NumberRecordsProcessed  int         `json:"numberRecordsProcessed,omitempty"`
-	NumberRecordsFailed     int         `json:"numberRecordsFailed,omitempty"`
-	Retries                 int         `json:"retries,omitempty"`
-	TotalProcessingTime     int         `json:"totalProcessingTime,omitempty"`
-	APIActiveProcessingTime int         `json:"apiActiveProcessingTime,omitempty"`
-	ApexProcessingTime      int         `json:"apexProcessingTime,omitempty"`
-	ErrorMessage            string      `json:"errorMessage,omitempty"`
+	ID                      string          `json:"id,omitempty"`
+	Operation               Operation       `json:"operation"`
+	Object                  string          `json:"object"`
+	CreatedByID             string          `json:"createdById,omitempty"`
+	CreatedDate             string          `json:"createdDate,omitempty"`
+	SystemModstamp          string          `json:"systemModstamp,omitempty"`
+	State                   State           `json:"state,omitempty"`
+	ExternalIDFieldName     string          `json:"externalIdFieldName,omitempty"`
+	ConcurrencyMode         string          `json:"concurrencyMode,omitempty"`
+	ContentType             ContentType     `json:"contentType,omitempty"`
+	APIVersion              float64         `json:"apiVersion,omitempty"`
+	JobType                 string          `json:"jobType,omitempty"`
+	LineEnding              LineEnding      `json:"lineEnding,omitempty"`
+	ColumnDelimiter         ColumnDelimiter `json:"columnDelimiter,omitempty"`
+	NumberRecordsProcessed  int             `json:"numberRecordsProcessed,omitempty"`
+	NumberRecordsFailed     int             `json:"numberRecordsFailed,omitempty"`
+	Retries                 int             `json:"retries,omitempty"`
+	TotalProcessingTime     int             `json:"totalProcessingTime,omitempty"`
+	APIActiveProcessingTime int             `json:"apiActiveProcessingTime,omitempty"`
+	ApexProcessingTime      int             `json:"apexProcessingTime,omitempty"`
+	ErrorMessage            string          `json:"errorMessage,omitempty"`
 }
 
 // QueryJobInfo represents information about a bulk query job.
 type QueryJobInfo struct {
-	ID                     string      `json:"id,omitempty"`
-	Operation              Operation   `json:"operation"`
-	Object                 string      `json:"object,omitempty"`
-	CreatedByID            string      `json:"createdById,omitempty"`
-	CreatedDate            string      `json:"createdDate,omitempty"`
-	SystemModstamp         string      `json:"systemModstamp,omitempty"`
-	State                  State       `json:"state,omitempty"`
-	ConcurrencyMode        string      `json:"concurrencyMode,omitempty"`
-	ContentType            ContentType `json:"contentType,omitempty"`
-	APIVersion             float64     `json:"apiVersion,omitempty"`
-	LineEnding             string      `json:"lineEnding,omitempty"`
-	ColumnDelimiter        string      `json:"columnDelimiter,omitempty"`
-	NumberRecordsProcessed int         `json:"numberRecordsProcessed,omitempty"`
-	Retries                int         `json:"retries,omitempty"`
-	TotalProcessingTime    int         `json:"totalProcessingTime,omitempty"`
-	Query                  string      `json:"query,omitempty"`
+	ID                     string          `json:"id,omitempty"`
+	Operation              Operation       `json:"operation"`
+	Object                 string          `json:"object,omitempty"`
+	CreatedByID            string          `json:"createdById,omitempty"`
+	CreatedDate            string          `json:"createdDate,omitempty"`
+	SystemModstamp         string          `json:"systemModstamp,omitempty"`
+	State                  State           `json:"state,omitempty"`
+	ConcurrencyMode        string          `json:"concurrencyMode,omitempty"`
+	ContentType            ContentType     `json:"contentType,omitempty"`
+	APIVersion             float64         `json:"apiVersion,omitempty"`
+	LineEnding             LineEnding      `json:"lineEnding,omitempty"`
+	ColumnDelimiter        ColumnDelimiter `json:"columnDelimiter,omitempty"`
+	NumberRecordsProcessed int             `json:"numberRecordsProcessed,omitempty"`
+	Retries                int             `json:"retries,omitempty"`
+	TotalProcessingTime    int             `json:"totalProcessingTime,omitempty"`
+	Query                  string          `json:"query,omitempty"`
 }
 
 // JobsResponse represents a list of bulk jobs.
@@ -98,21 +125,21 @@ type QueryJobsResponse struct {
 
 // CreateJobRequest represents a request to create a bulk ingest job.
 type CreateJobRequest struct {
-	Object              string      `json:"object"`
-	Operation           Operation   `json:"operation"`
-	ExternalIDFieldName string      `json:"externalIdFieldName,omitempty"`
-	ContentType         ContentType `json:"contentType,omitempty"`
-	LineEnding          string      `json:"lineEnding,omitempty"`
-	ColumnDelimiter     string      `json:"columnDelimiter,omitempty"`
+	Object              string          `json:"object"`
+	Operation           Operation       `json:"operation"`
+	ExternalIDFieldName string          `json:"externalIdFieldName,omitempty"`
+	ContentType         ContentType     `json:"contentType,omitempty"`
+	LineEnding          LineEnding      `json:"lineEnding,omitempty"`
+	ColumnDelimiter     ColumnDelimiter `json:"columnDelimiter,omitempty"`
 }
 
 // CreateQueryJobRequest represents a request to create a bulk query job.
 type CreateQueryJobRequest struct {
-	Operation       Operation   `json:"operation"`
-	Query           string      `json:"query"`
-	ContentType     ContentType `json:"contentType,omitempty"`
-	LineEnding      string      `json:"lineEnding,omitempty"`
-	ColumnDelimiter string      `json:"columnDelimiter,omitempty"`
+	Operation       Operation       `json:"operation"`
+	Query           string          `json:"query"`
+	ContentType     ContentType     `json:"contentType,omitempty"`
+	LineEnding      LineEnding      `json:"lineEnding,omitempty"`
+	ColumnDelimiter ColumnDelimiter `json:"columnDelimiter,omitempty"`
 }
 
 // UpdateJobRequest represents a request to update a bulk job state.
@@ -126,18 +153,63 @@ type JobConfig struct {
 	Operation   Operation
 	ExternalID  string
 	ContentType ContentType
+	// LineEnding and ColumnDelimiter describe CSV data; empty values
+	// leave Salesforce's defaults, LF and COMMA
+	LineEnding      LineEnding
+	ColumnDelimiter ColumnDelimiter
 }
 
 // QueryConfig contains configuration for creating a bulk query job.
 type QueryConfig struct {
 	Query       string
 	ContentType ContentType
+	// LineEnding and ColumnDelimiter choose how CSV results are written;
+	// empty values leave Salesforce's defaults, LF and COMMA
+	LineEnding      LineEnding
+	ColumnDelimiter ColumnDelimiter
+}
+
+// QueryResultsOptions selects a page of query job results.
+type QueryResultsOptions struct {
+	// Locator is the Locator of the previous page; empty for the first page
+	Locator string
+	// MaxRecords is the most records a page holds; zero lets Salesforce
+	// choose, based on the size of the records
+	MaxRecords int
+}
+
+// QueryResultsPage is one page of the results of a query job.
+type QueryResultsPage struct {
+	// Data is the page's records as CSV, with a header row
+	Data []byte
+	// Locator identifies the next page; empty on the last page
+	Locator string
+	// NumberOfRecords is the number of records on the page
+	NumberOfRecords int
+}
+
+// Rows returns the page's data without its header row.
+func (p *QueryResultsPage) Rows() []byte {
+	i := bytes.IndexByte(p.Data, '\n')
+	if i < 0 {
+		return nil
+	}
+	return p.Data[i+1:]
 }
 
 // PollConfig contains configuration for polling job status.
 type PollConfig struct {
 	Interval time.Duration
-	Timeout  time.Duration
+	// Timeout is how long to wait for the job; zero or less waits
+	// indefinitely
+	Timeout time.Duration
+
+	// OnJob is called with each status of an ingest job PollJob gets
+	// (optional)
+	OnJob func(job *JobInfo)
+	// OnQueryJob is called with each status of a query job PollQueryJob
+	// gets (optional)
+	OnQueryJob func(job *QueryJobInfo)
 }
 
 // DefaultPollConfig returns default polling configuration.
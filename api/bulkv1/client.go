@@ -0,0 +1,176 @@
+package bulkv1
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/open-cli-collective/salesforce-cli/api"
+)
+
+// Client is a Salesforce Bulk API 1.0 client.
+type Client struct {
+	httpClient  *http.Client
+	instanceURL string
+	apiVersion  string
+	baseURL     string
+	sessionID   func(ctx context.Context) (string, error)
+	retry       api.RetryPolicy
+	responses   *api.ResponseRecorder
+}
+
+// ClientConfig contains configuration for creating a new Bulk API 1.0
+// client.
+type ClientConfig struct {
+	InstanceURL string
+	HTTPClient  *http.Client
+	APIVersion  string
+	// SessionID returns the access token to send in the X-SFDC-Session
+	// header, which Bulk API 1.0 requires besides any Authorization header
+	// the HTTP client sets (optional)
+	SessionID func(ctx context.Context) (string, error)
+	// Retry controls how requests failing with transient errors are retried
+	// (optional, defaults to a single attempt)
+	Retry api.RetryPolicy
+	// Middleware wraps the HTTP client's transport, first outermost
+	// (optional)
+	Middleware []api.Middleware
+	// Compression controls gzip compression of request and response bodies
+	// (optional, defaults to none beyond what the transport does itself)
+	Compression api.Compression
+
+	// Timeout fails a request attempt that makes no progress for this long
+	// (optional, defaults to none)
+	Timeout time.Duration
+
+	// RateLimiter limits how often requests are sent; share one between
+	// clients to limit them together (optional, defaults to no limit)
+	RateLimiter *api.RateLimiter
+}
+
+// New creates a new Bulk API 1.0 client.
+func New(cfg ClientConfig) (*Client, error) {
+	if cfg.InstanceURL == "" {
+		return nil, fmt.Errorf("instance URL is required")
+	}
+	if cfg.HTTPClient == nil {
+		return nil, fmt.Errorf("HTTP client is required")
+	}
+
+	instanceURL := strings.TrimSuffix(cfg.InstanceURL, "/")
+	apiVersion := cfg.APIVersion
+	if apiVersion == "" {
+		apiVersion = "v62.0"
+	}
+
+	// Responses are recorded outermost, as returned; compression and the
+	// timeout go innermost, to see the bytes on the wire
+	responses := &api.ResponseRecorder{}
+	middleware := api.WithResponseRecorder(cfg.Middleware, responses)
+	middleware = api.WithRateLimit(middleware, cfg.RateLimiter)
+	middleware = api.WithCompression(middleware, cfg.Compression)
+	middleware = api.WithTimeout(middleware, cfg.Timeout)
+
+	// The async API's path has the version without its "v"
+	return &Client{
+		httpClient:  api.WrapHTTPClient(cfg.HTTPClient, middleware...),
+		instanceURL: instanceURL,
+		apiVersion:  apiVersion,
+		baseURL:     fmt.Sprintf("%s/services/async/%s", instanceURL, strings.TrimPrefix(apiVersion, "v")),
+		sessionID:   cfg.SessionID,
+		retry:       cfg.Retry,
+		responses:   responses,
+	}, nil
+}
+
+// LastResponse returns the metadata of the last Bulk API response, or nil if
+// there has been none, as api.Client.LastResponse does.
+func (c *Client) LastResponse() *api.ResponseInfo {
+	return c.responses.Last()
+}
+
+// doRequest performs an HTTP request and decodes the XML response into out,
+// if not nil. A string body is sent as CSV, anything else as XML.
+func (c *Client) doRequest(ctx context.Context, method, path string, header http.Header, body, out interface{}) error {
+	resp, err := c.openRequest(ctx, method, path, header, body)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+	if out == nil {
+		return nil
+	}
+	if err := xml.Unmarshal(respBody, out); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+	return nil
+}
+
+// openRequest performs an HTTP request and returns the response for its
+// body to be read. The caller must close the body.
+func (c *Client) openRequest(ctx context.Context, method, path string, header http.Header, body interface{}) (*http.Response, error) {
+	var bodyReader io.Reader
+	contentType := "application/xml; charset=UTF-8"
+
+	switch v := body.(type) {
+	case nil:
+	case string:
+		bodyReader = strings.NewReader(v)
+		contentType = "text/csv; charset=UTF-8"
+	default:
+		xmlBody, err := xml.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal request body: %w", err)
+		}
+		bodyReader = bytes.NewReader(append([]byte(xml.Header), xmlBody...))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, bodyReader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if c.sessionID != nil {
+		session, err := c.sessionID(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get session ID: %w", err)
+		}
+		req.Header.Set("X-SFDC-Session", session)
+	}
+
+	resp, err := c.retry.Do(c.httpClient, req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+
+	if resp.StatusCode >= 400 {
+		defer resp.Body.Close()
+		respBody, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read response: %w", err)
+		}
+		var apiErr apiError
+		if xml.Unmarshal(respBody, &apiErr) == nil && apiErr.ExceptionCode != "" {
+			return nil, fmt.Errorf("API error (status %d): %s: %s", resp.StatusCode, apiErr.ExceptionCode, apiErr.ExceptionMessage)
+		}
+		return nil, fmt.Errorf("API error (status %d): %s", resp.StatusCode, string(respBody))
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,219 @@
+package bulkv1
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client, err := New(ClientConfig{
+		InstanceURL: server.URL,
+		HTTPClient:  server.Client(),
+		SessionID: func(ctx context.Context) (string, error) {
+			return "00Dxx!token", nil
+		},
+	})
+	require.NoError(t, err)
+	return client
+}
+
+func TestNew(t *testing.T) {
+	_, err := New(ClientConfig{HTTPClient: &http.Client{}})
+	assert.Error(t, err)
+	_, err = New(ClientConfig{InstanceURL: "https://test.salesforce.com"})
+	assert.Error(t, err)
+
+	client, err := New(ClientConfig{InstanceURL: "https://test.salesforce.com/", HTTPClient: &http.Client{}})
+	require.NoError(t, err)
+	assert.Equal(t, "https://test.salesforce.com/services/async/62.0", client.baseURL)
+	assert.Equal(t, "https://test.salesforce.com/services/async/62.0/job", client.JobsURL())
+}
+
+func TestCreateJob_PKChunking(t *testing.T) {
+	tests := []struct {
+		name       string
+		chunking   *PKChunking
+		wantHeader string
+	}{
+		{name: "off", chunking: nil, wantHeader: ""},
+		{name: "default", chunking: &PKChunking{}, wantHeader: "true"},
+		{name: "options", chunking: &PKChunking{ChunkSize: 250000, Parent: "Account", StartRow: "001xx000000001"}, wantHeader: "chunkSize=250000; parent=Account; startRow=001xx000000001"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodPost, r.Method)
+				assert.Equal(t, "/services/async/62.0/job", r.URL.Path)
+				assert.Equal(t, "00Dxx!token", r.Header.Get("X-SFDC-Session"))
+				assert.Equal(t, tt.wantHeader, r.Header.Get("Sforce-Enable-PKChunking"))
+				assert.Equal(t, "application/xml; charset=UTF-8", r.Header.Get("Content-Type"))
+
+				body, _ := io.ReadAll(r.Body)
+				assert.Contains(t, string(body), `<jobInfo xmlns="http://www.force.com/2009/06/asyncapi/dataload"><operation>query</operation><object>Account</object><contentType>CSV</contentType></jobInfo>`)
+
+				w.Header().Set("Content-Type", "application/xml")
+				_, _ = w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?>
+<jobInfo xmlns="http://www.force.com/2009/06/asyncapi/dataload">
+ <id>750xx000000001</id>
+ <operation>query</operation>
+ <object>Account</object>
+ <state>Open</state>
+ <contentType>CSV</contentType>
+</jobInfo>`))
+			})
+
+			job, err := client.CreateJob(context.Background(), JobConfig{Object: "Account", Operation: OperationQuery, PKChunking: tt.chunking})
+			require.NoError(t, err)
+			assert.Equal(t, "750xx000000001", job.ID)
+			assert.Equal(t, JobOpen, job.State)
+		})
+	}
+
+	t.Run("chunk size too large", func(t *testing.T) {
+		client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			t.Error("unexpected request")
+		})
+		_, err := client.CreateJob(context.Background(), JobConfig{Object: "Account", Operation: OperationQuery, PKChunking: &PKChunking{ChunkSize: 300000}})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "chunk size 300000 is over the maximum of 250000")
+	})
+}
+
+func TestCreateJob_Serial(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		assert.Contains(t, string(body), `<jobInfo xmlns="http://www.force.com/2009/06/asyncapi/dataload"><operation>upsert</operation><object>Contact</object><externalIdFieldName>Email</externalIdFieldName><concurrencyMode>Serial</concurrencyMode><contentType>CSV</contentType></jobInfo>`)
+
+		_, _ = w.Write([]byte(`<jobInfo xmlns="http://www.force.com/2009/06/asyncapi/dataload"><id>750xx000000001</id><operation>upsert</operation><object>Contact</object><state>Open</state><concurrencyMode>Serial</concurrencyMode></jobInfo>`))
+	})
+
+	job, err := client.CreateJob(context.Background(), JobConfig{
+		Object:          "Contact",
+		Operation:       OperationUpsert,
+		ExternalID:      "Email",
+		ConcurrencyMode: ConcurrencySerial,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, ConcurrencySerial, job.ConcurrencyMode)
+}
+
+func TestAddBatch(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/services/async/62.0/job/750xx000000001/batch", r.URL.Path)
+		assert.Equal(t, "text/csv; charset=UTF-8", r.Header.Get("Content-Type"))
+		body, _ := io.ReadAll(r.Body)
+		assert.Equal(t, "Name\nAcme\n", string(body))
+
+		_, _ = w.Write([]byte(`<batchInfo xmlns="http://www.force.com/2009/06/asyncapi/dataload"><id>751xx000000001</id><jobId>750xx000000001</jobId><state>Queued</state></batchInfo>`))
+	})
+
+	batch, err := client.AddBatch(context.Background(), "750xx000000001", []byte("Name\nAcme\n"))
+	require.NoError(t, err)
+	assert.Equal(t, "751xx000000001", batch.ID)
+}
+
+func TestGetBatchResults(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/services/async/62.0/job/750xx000000001/batch/751xx000000001/result", r.URL.Path)
+		w.Header().Set("Content-Type", "text/csv")
+		_, _ = w.Write([]byte("\"Id\",\"Success\",\"Created\",\"Error\"\n\"001xx000001\",\"true\",\"true\",\"\"\n"))
+	})
+
+	data, err := client.GetBatchResults(context.Background(), "750xx000000001", "751xx000000001")
+	require.NoError(t, err)
+	assert.Equal(t, "\"Id\",\"Success\",\"Created\",\"Error\"\n\"001xx000001\",\"true\",\"true\",\"\"\n", string(data))
+}
+
+func TestAddQueryBatch(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/services/async/62.0/job/750xx000000001/batch", r.URL.Path)
+		assert.Equal(t, "text/csv; charset=UTF-8", r.Header.Get("Content-Type"))
+		body, _ := io.ReadAll(r.Body)
+		assert.Equal(t, "SELECT Id FROM Account", string(body))
+
+		_, _ = w.Write([]byte(`<batchInfo xmlns="http://www.force.com/2009/06/asyncapi/dataload"><id>751xx000000001</id><jobId>750xx000000001</jobId><state>Queued</state></batchInfo>`))
+	})
+
+	batch, err := client.AddQueryBatch(context.Background(), "750xx000000001", "SELECT Id FROM Account")
+	require.NoError(t, err)
+	assert.Equal(t, "751xx000000001", batch.ID)
+	assert.Equal(t, BatchQueued, batch.State)
+}
+
+func TestCloseJob(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/services/async/62.0/job/750xx000000001", r.URL.Path)
+		body, _ := io.ReadAll(r.Body)
+		assert.Contains(t, string(body), "<state>Closed</state>")
+
+		_, _ = w.Write([]byte(`<jobInfo xmlns="http://www.force.com/2009/06/asyncapi/dataload"><id>750xx000000001</id><state>Closed</state></jobInfo>`))
+	})
+
+	job, err := client.CloseJob(context.Background(), "750xx000000001")
+	require.NoError(t, err)
+	assert.Equal(t, JobClosed, job.State)
+}
+
+func TestAPIError(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`<error xmlns="http://www.force.com/2009/06/asyncapi/dataload"><exceptionCode>InvalidJob</exceptionCode><exceptionMessage>Invalid job id</exceptionMessage></error>`))
+	})
+
+	_, err := client.GetJob(context.Background(), "750xx000000009")
+	require.Error(t, err)
+	assert.Equal(t, "API error (status 400): InvalidJob: Invalid job id", err.Error())
+}
+
+func TestWriteQueryResults(t *testing.T) {
+	results := map[string]string{
+		"/services/async/62.0/job/750xx000000001/batch/751xx000000002/result/752xx000000001": "\"Id\",\"Name\"\n\"001xx000001\",\"Acme\"\n",
+		"/services/async/62.0/job/750xx000000001/batch/751xx000000002/result/752xx000000002": "\"Id\",\"Name\"\n\"001xx000002\",\"Globex\"",
+		"/services/async/62.0/job/750xx000000001/batch/751xx000000003/result/752xx000000003": "\"Id\",\"Name\"\n\"001xx000003\",\"Initech\"\n",
+	}
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/services/async/62.0/job/750xx000000001/batch":
+			_, _ = w.Write([]byte(`<batchInfoList xmlns="http://www.force.com/2009/06/asyncapi/dataload">
+ <batchInfo><id>751xx000000001</id><jobId>750xx000000001</jobId><state>NotProcessed</state><numberRecordsProcessed>0</numberRecordsProcessed></batchInfo>
+ <batchInfo><id>751xx000000002</id><jobId>750xx000000001</jobId><state>Completed</state><numberRecordsProcessed>2</numberRecordsProcessed></batchInfo>
+ <batchInfo><id>751xx000000003</id><jobId>750xx000000001</jobId><state>Completed</state><numberRecordsProcessed>1</numberRecordsProcessed></batchInfo>
+</batchInfoList>`))
+		case "/services/async/62.0/job/750xx000000001/batch/751xx000000002/result":
+			_, _ = w.Write([]byte(`<result-list xmlns="http://www.force.com/2009/06/asyncapi/dataload"><result>752xx000000001</result><result>752xx000000002</result></result-list>`))
+		case "/services/async/62.0/job/750xx000000001/batch/751xx000000003/result":
+			_, _ = w.Write([]byte(`<result-list xmlns="http://www.force.com/2009/06/asyncapi/dataload"><result>752xx000000003</result></result-list>`))
+		default:
+			data, ok := results[r.URL.Path]
+			if !ok || strings.Contains(r.URL.Path, "751xx000000001") {
+				t.Errorf("unexpected request %s", r.URL.Path)
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.Header().Set("Content-Type", "text/csv")
+			_, _ = w.Write([]byte(data))
+		}
+	})
+
+	var buf bytes.Buffer
+	records, err := client.WriteQueryResults(context.Background(), "750xx000000001", &buf)
+	require.NoError(t, err)
+	assert.Equal(t, 3, records)
+	assert.Equal(t, "\"Id\",\"Name\"\n\"001xx000001\",\"Acme\"\n\"001xx000002\",\"Globex\"\n\"001xx000003\",\"Initech\"\n", buf.String())
+}
@@ -0,0 +1,238 @@
+package bulkv1
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// MaxChunkSize is the largest PK chunking chunk size Salesforce accepts.
+const MaxChunkSize = 250000
+
+// Limits of an ingest batch's CSV data.
+const (
+	MaxBatchRecords = 10000
+	MaxBatchBytes   = 10000000
+)
+
+// jobsPath is the path of the jobs resource.
+const jobsPath = "/job"
+
+// header returns the request headers that enable PK chunking, if it is
+// configured.
+func (p *PKChunking) header() http.Header {
+	if p == nil {
+		return nil
+	}
+
+	options := []string{"true"}
+	if p.ChunkSize > 0 || p.Parent != "" || p.StartRow != "" {
+		options = nil
+	}
+	if p.ChunkSize > 0 {
+		options = append(options, "chunkSize="+strconv.Itoa(p.ChunkSize))
+	}
+	if p.Parent != "" {
+		options = append(options, "parent="+p.Parent)
+	}
+	if p.StartRow != "" {
+		options = append(options, "startRow="+p.StartRow)
+	}
+	return http.Header{"Sforce-Enable-Pkchunking": {strings.Join(options, "; ")}}
+}
+
+// Request returns the request body that creates a job with this config.
+func (cfg JobConfig) Request() CreateJobRequest {
+	return CreateJobRequest{
+		Xmlns:               namespace,
+		Operation:           cfg.Operation,
+		Object:              cfg.Object,
+		ExternalIDFieldName: cfg.ExternalID,
+		ConcurrencyMode:     cfg.ConcurrencyMode,
+		ContentType:         "CSV",
+	}
+}
+
+// JobsURL returns the URL that jobs are created at.
+func (c *Client) JobsURL() string {
+	return c.baseURL + jobsPath
+}
+
+// CreateJob creates a new job.
+func (c *Client) CreateJob(ctx context.Context, cfg JobConfig) (*JobInfo, error) {
+	if cfg.PKChunking != nil && cfg.PKChunking.ChunkSize > MaxChunkSize {
+		return nil, fmt.Errorf("chunk size %d is over the maximum of %d", cfg.PKChunking.ChunkSize, MaxChunkSize)
+	}
+
+	var job JobInfo
+	if err := c.doRequest(ctx, http.MethodPost, jobsPath, cfg.PKChunking.header(), cfg.Request(), &job); err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// GetJob retrieves information about a job.
+func (c *Client) GetJob(ctx context.Context, jobID string) (*JobInfo, error) {
+	var job JobInfo
+	if err := c.doRequest(ctx, http.MethodGet, jobsPath+"/"+jobID, nil, nil, &job); err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// CloseJob closes a job so that no more batches can be added. Batches
+// already added still run.
+func (c *Client) CloseJob(ctx context.Context, jobID string) (*JobInfo, error) {
+	return c.setJobState(ctx, jobID, JobClosed)
+}
+
+// AbortJob aborts a job, stopping any batches not yet processed.
+func (c *Client) AbortJob(ctx context.Context, jobID string) (*JobInfo, error) {
+	return c.setJobState(ctx, jobID, JobAborted)
+}
+
+func (c *Client) setJobState(ctx context.Context, jobID string, state JobState) (*JobInfo, error) {
+	req := updateJobRequest{Xmlns: namespace, State: state}
+
+	var job JobInfo
+	if err := c.doRequest(ctx, http.MethodPost, jobsPath+"/"+jobID, nil, req, &job); err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// AddQueryBatch adds a batch running soql to a query job.
+func (c *Client) AddQueryBatch(ctx context.Context, jobID, soql string) (*BatchInfo, error) {
+	var batch BatchInfo
+	if err := c.doRequest(ctx, http.MethodPost, jobsPath+"/"+jobID+"/batch", nil, soql, &batch); err != nil {
+		return nil, err
+	}
+	return &batch, nil
+}
+
+// AddBatch adds a batch of CSV records to an ingest job. The data must have
+// a header row and stay within MaxBatchRecords and MaxBatchBytes.
+func (c *Client) AddBatch(ctx context.Context, jobID string, data []byte) (*BatchInfo, error) {
+	var batch BatchInfo
+	if err := c.doRequest(ctx, http.MethodPost, jobsPath+"/"+jobID+"/batch", nil, string(data), &batch); err != nil {
+		return nil, err
+	}
+	return &batch, nil
+}
+
+// ListBatches returns the batches of a job, including those Salesforce added
+// for PK chunking.
+func (c *Client) ListBatches(ctx context.Context, jobID string) ([]BatchInfo, error) {
+	var list batchInfoList
+	if err := c.doRequest(ctx, http.MethodGet, jobsPath+"/"+jobID+"/batch", nil, nil, &list); err != nil {
+		return nil, err
+	}
+	return list.Batches, nil
+}
+
+// GetBatchResults returns the CSV results of an ingest batch: a row for
+// each of its records, in order, with the columns Id, Success, Created, and
+// Error.
+func (c *Client) GetBatchResults(ctx context.Context, jobID, batchID string) ([]byte, error) {
+	path := fmt.Sprintf("%s/%s/batch/%s/result", jobsPath, jobID, batchID)
+	resp, err := c.openRequest(ctx, http.MethodGet, path, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	return data, nil
+}
+
+// GetBatchResultIDs returns the IDs of a query batch's result sets.
+func (c *Client) GetBatchResultIDs(ctx context.Context, jobID, batchID string) ([]string, error) {
+	var list resultList
+	path := fmt.Sprintf("%s/%s/batch/%s/result", jobsPath, jobID, batchID)
+	if err := c.doRequest(ctx, http.MethodGet, path, nil, nil, &list); err != nil {
+		return nil, err
+	}
+	return list.Results, nil
+}
+
+// WriteQueryResults writes the CSV results of every completed batch of a
+// query job to w, in batch order, as one CSV with a single header line. The
+// batch that held a PK chunked query has no results of its own and is
+// skipped. It returns the number of records written, as the batches report.
+func (c *Client) WriteQueryResults(ctx context.Context, jobID string, w io.Writer) (int, error) {
+	batches, err := c.ListBatches(ctx, jobID)
+	if err != nil {
+		return 0, err
+	}
+
+	out := &lastByteWriter{w: w}
+	records := 0
+	first := true
+	for _, batch := range batches {
+		if batch.State != BatchCompleted {
+			continue
+		}
+
+		ids, err := c.GetBatchResultIDs(ctx, jobID, batch.ID)
+		if err != nil {
+			return records, fmt.Errorf("failed to list results of batch %s: %w", batch.ID, err)
+		}
+		for _, id := range ids {
+			path := fmt.Sprintf("%s/%s/batch/%s/result/%s", jobsPath, jobID, batch.ID, id)
+			if err := c.writeResult(ctx, path, out, !first); err != nil {
+				return records, fmt.Errorf("failed to get results of batch %s: %w", batch.ID, err)
+			}
+			first = false
+		}
+		records += batch.NumberRecordsProcessed
+	}
+	return records, nil
+}
+
+// writeResult streams a result set to out, leaving out its header line with
+// skipHeader and starting it on a new line.
+func (c *Client) writeResult(ctx context.Context, path string, out *lastByteWriter, skipHeader bool) error {
+	resp, err := c.openRequest(ctx, http.MethodGet, path, nil, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body := bufio.NewReader(resp.Body)
+	if skipHeader {
+		// Every result set repeats the header row
+		if _, err := body.ReadString('\n'); err != nil && !errors.Is(err, io.EOF) {
+			return fmt.Errorf("failed to read response: %w", err)
+		}
+	}
+	if out.last != 0 && out.last != '\n' {
+		if _, err := out.Write([]byte{'\n'}); err != nil {
+			return err
+		}
+	}
+
+	_, err = body.WriteTo(out)
+	return err
+}
+
+// lastByteWriter remembers the last byte written through it.
+type lastByteWriter struct {
+	w    io.Writer
+	last byte
+}
+
+func (l *lastByteWriter) Write(p []byte) (int, error) {
+	n, err := l.w.Write(p)
+	if n > 0 {
+		l.last = p[n-1]
+	}
+	return n, err
+}
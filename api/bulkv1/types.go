@@ -0,0 +1,154 @@
+// Package bulkv1 provides a client for the Salesforce Bulk API 1.0, for the
+// features Bulk API 2.0 lacks, such as PK chunking of query jobs under the
+// caller's control and ingest jobs that process their batches serially.
+package bulkv1
+
+import "encoding/xml"
+
+// namespace is the XML namespace of Bulk API 1.0 requests and responses.
+const namespace = "http://www.force.com/2009/06/asyncapi/dataload"
+
+// Operation represents a job operation.
+type Operation string
+
+// Job operations.
+const (
+	OperationInsert     Operation = "insert"
+	OperationUpdate     Operation = "update"
+	OperationUpsert     Operation = "upsert"
+	OperationDelete     Operation = "delete"
+	OperationHardDelete Operation = "hardDelete"
+	OperationQuery      Operation = "query"
+	OperationQueryAll   Operation = "queryAll"
+)
+
+// ConcurrencyMode is how the batches of a job are processed.
+type ConcurrencyMode string
+
+// Concurrency modes. Serial processes one batch at a time, avoiding the
+// record lock contention parallel batches can run into.
+const (
+	ConcurrencyParallel ConcurrencyMode = "Parallel"
+	ConcurrencySerial   ConcurrencyMode = "Serial"
+)
+
+// JobState represents the state of a job.
+type JobState string
+
+// Job states.
+const (
+	JobOpen    JobState = "Open"
+	JobClosed  JobState = "Closed"
+	JobAborted JobState = "Aborted"
+	JobFailed  JobState = "Failed"
+)
+
+// BatchState represents the state of a batch.
+type BatchState string
+
+// Batch states. With PK chunking, the batch holding the query is
+// NotProcessed and its records are processed in batches of their own.
+const (
+	BatchQueued       BatchState = "Queued"
+	BatchInProgress   BatchState = "InProgress"
+	BatchCompleted    BatchState = "Completed"
+	BatchFailed       BatchState = "Failed"
+	BatchNotProcessed BatchState = "NotProcessed"
+)
+
+// JobConfig contains the settings of a new job.
+type JobConfig struct {
+	Object    string
+	Operation Operation
+	// ExternalID is the external ID field of an upsert job
+	ExternalID string
+	// ConcurrencyMode is how the job's batches are processed (optional,
+	// defaults to parallel)
+	ConcurrencyMode ConcurrencyMode
+	// PKChunking splits a query job into batches by record ID (optional)
+	PKChunking *PKChunking
+}
+
+// PKChunking configures PK chunking of a query job.
+type PKChunking struct {
+	// ChunkSize is the number of records in each batch (optional, defaults
+	// to 100,000; at most 250,000)
+	ChunkSize int
+	// Parent is the parent object of a sharing object being queried
+	// (optional)
+	Parent string
+	// StartRow is the ID the first chunk starts at (optional)
+	StartRow string
+}
+
+// JobInfo represents information about a job.
+type JobInfo struct {
+	XMLName                 xml.Name        `xml:"jobInfo" json:"-"`
+	ID                      string          `xml:"id,omitempty" json:"id"`
+	Operation               Operation       `xml:"operation,omitempty" json:"operation"`
+	Object                  string          `xml:"object,omitempty" json:"object"`
+	ExternalIDFieldName     string          `xml:"externalIdFieldName,omitempty" json:"externalIdFieldName,omitempty"`
+	CreatedDate             string          `xml:"createdDate,omitempty" json:"createdDate,omitempty"`
+	State                   JobState        `xml:"state,omitempty" json:"state"`
+	ConcurrencyMode         ConcurrencyMode `xml:"concurrencyMode,omitempty" json:"concurrencyMode,omitempty"`
+	ContentType             string          `xml:"contentType,omitempty" json:"contentType,omitempty"`
+	NumberBatchesQueued     int             `xml:"numberBatchesQueued,omitempty" json:"numberBatchesQueued"`
+	NumberBatchesInProgress int             `xml:"numberBatchesInProgress,omitempty" json:"numberBatchesInProgress"`
+	NumberBatchesCompleted  int             `xml:"numberBatchesCompleted,omitempty" json:"numberBatchesCompleted"`
+	NumberBatchesFailed     int             `xml:"numberBatchesFailed,omitempty" json:"numberBatchesFailed"`
+	NumberBatchesTotal      int             `xml:"numberBatchesTotal,omitempty" json:"numberBatchesTotal"`
+	NumberRecordsProcessed  int             `xml:"numberRecordsProcessed,omitempty" json:"numberRecordsProcessed"`
+	NumberRecordsFailed     int             `xml:"numberRecordsFailed,omitempty" json:"numberRecordsFailed"`
+}
+
+// CreateJobRequest is the body of a create job request. Salesforce expects
+// its elements in this order.
+type CreateJobRequest struct {
+	XMLName             xml.Name        `xml:"jobInfo" json:"-"`
+	Xmlns               string          `xml:"xmlns,attr" json:"-"`
+	Operation           Operation       `xml:"operation" json:"operation"`
+	Object              string          `xml:"object" json:"object"`
+	ExternalIDFieldName string          `xml:"externalIdFieldName,omitempty" json:"externalIdFieldName,omitempty"`
+	ConcurrencyMode     ConcurrencyMode `xml:"concurrencyMode,omitempty" json:"concurrencyMode,omitempty"`
+	ContentType         string          `xml:"contentType" json:"contentType"`
+}
+
+// updateJobRequest is the body of a request changing a job's state.
+type updateJobRequest struct {
+	XMLName xml.Name `xml:"jobInfo"`
+	Xmlns   string   `xml:"xmlns,attr"`
+	State   JobState `xml:"state"`
+}
+
+// BatchInfo represents information about a batch of a job.
+type BatchInfo struct {
+	ID                     string     `xml:"id" json:"id"`
+	JobID                  string     `xml:"jobId" json:"jobId"`
+	State                  BatchState `xml:"state" json:"state"`
+	StateMessage           string     `xml:"stateMessage,omitempty" json:"stateMessage,omitempty"`
+	CreatedDate            string     `xml:"createdDate,omitempty" json:"createdDate,omitempty"`
+	NumberRecordsProcessed int        `xml:"numberRecordsProcessed" json:"numberRecordsProcessed"`
+	NumberRecordsFailed    int        `xml:"numberRecordsFailed" json:"numberRecordsFailed"`
+}
+
+// batchInfoList is the response listing a job's batches.
+type batchInfoList struct {
+	Batches []BatchInfo `xml:"batchInfo"`
+}
+
+// resultList is the response listing a query batch's result IDs.
+type resultList struct {
+	Results []string `xml:"result"`
+}
+
+// apiError is the error body of a failed request.
+type apiError struct {
+	ExceptionCode    string `xml:"exceptionCode"`
+	ExceptionMessage string `xml:"exceptionMessage"`
+}
+
+// Finished reports whether the batch has stopped processing, one way or
+// another.
+func (b BatchState) Finished() bool {
+	return b == BatchCompleted || b == BatchFailed || b == BatchNotProcessed
+}
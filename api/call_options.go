@@ -0,0 +1,87 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+// CallOptions are Salesforce headers that change how requests are processed.
+// Zero fields are not sent.
+//
+// Set them for every request of a client with ClientConfig.CallOptions, or
+// for the requests made with one context with WithCallOptions.
+type CallOptions struct {
+	// DefaultNamespace is the namespace prefix assumed for fields and
+	// objects named without one, so a managed package's code can leave it
+	// out (Sforce-Call-Options: defaultNamespace)
+	DefaultNamespace string
+	// Client identifies the calling application in the org's API usage
+	// (Sforce-Call-Options: client)
+	Client string
+	// AllowDuplicates saves records that duplicate rules would otherwise
+	// block, when the rules allow that (Sforce-Duplicate-Rule-Header)
+	AllowDuplicates bool
+	// NoAutoAssign skips the active assignment rules when creating or
+	// updating cases and leads (Sforce-Auto-Assign)
+	NoAutoAssign bool
+}
+
+// Header returns the headers for o.
+func (o CallOptions) Header() http.Header {
+	header := make(http.Header)
+
+	var callOptions []string
+	if o.Client != "" {
+		callOptions = append(callOptions, "client="+o.Client)
+	}
+	if o.DefaultNamespace != "" {
+		callOptions = append(callOptions, "defaultNamespace="+o.DefaultNamespace)
+	}
+	if len(callOptions) > 0 {
+		header.Set("Sforce-Call-Options", strings.Join(callOptions, ", "))
+	}
+	if o.AllowDuplicates {
+		header.Set("Sforce-Duplicate-Rule-Header", "allowSave=true")
+	}
+	if o.NoAutoAssign {
+		header.Set("Sforce-Auto-Assign", "FALSE")
+	}
+	return header
+}
+
+// merge returns o with the non-zero fields of override replacing its own.
+func (o CallOptions) merge(override CallOptions) CallOptions {
+	if override.DefaultNamespace != "" {
+		o.DefaultNamespace = override.DefaultNamespace
+	}
+	if override.Client != "" {
+		o.Client = override.Client
+	}
+	o.AllowDuplicates = o.AllowDuplicates || override.AllowDuplicates
+	o.NoAutoAssign = o.NoAutoAssign || override.NoAutoAssign
+	return o
+}
+
+// callOptionsKey is the context key of the options set with WithCallOptions.
+type callOptionsKey struct{}
+
+// WithCallOptions returns a context that adds o to the requests made with
+// it, on top of the client's CallOptions and any set on ctx before.
+func WithCallOptions(ctx context.Context, o CallOptions) context.Context {
+	return context.WithValue(ctx, callOptionsKey{}, contextCallOptions(ctx).merge(o))
+}
+
+// contextCallOptions returns the options set on ctx with WithCallOptions.
+func contextCallOptions(ctx context.Context) CallOptions {
+	o, _ := ctx.Value(callOptionsKey{}).(CallOptions)
+	return o
+}
+
+// setCallOptions sets the headers of the client's CallOptions, and of those
+// on the request's context, on req.
+func (c *Client) setCallOptions(req *http.Request) {
+	for name, values := range c.CallOptions.merge(contextCallOptions(req.Context())).Header() {
+		req.Header[name] = values
+	}
+}
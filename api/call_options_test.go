@@ -0,0 +1,60 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCallOptions_Header(t *testing.T) {
+	assert.Empty(t, CallOptions{}.Header())
+
+	header := CallOptions{
+		DefaultNamespace: "acme",
+		Client:           "Nightly Sync",
+		AllowDuplicates:  true,
+		NoAutoAssign:     true,
+	}.Header()
+	assert.Equal(t, "client=Nightly Sync, defaultNamespace=acme", header.Get("Sforce-Call-Options"))
+	assert.Equal(t, "allowSave=true", header.Get("Sforce-Duplicate-Rule-Header"))
+	assert.Equal(t, "FALSE", header.Get("Sforce-Auto-Assign"))
+}
+
+func TestClient_CallOptions(t *testing.T) {
+	var got []http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = append(got, r.Header.Clone())
+		_, _ = w.Write([]byte(`{"id":"00Qxx0000001","success":true,"errors":[]}`))
+	}))
+	defer server.Close()
+
+	client, err := New(ClientConfig{
+		InstanceURL: server.URL,
+		HTTPClient:  server.Client(),
+		CallOptions: CallOptions{DefaultNamespace: "acme"},
+	})
+	require.NoError(t, err)
+
+	// The client's options are sent with every request
+	_, err = client.CreateRecord(context.Background(), "Lead", map[string]interface{}{"LastName": "Doe"})
+	require.NoError(t, err)
+
+	// Options on the context are added to them for that request only
+	ctx := WithCallOptions(context.Background(), CallOptions{AllowDuplicates: true})
+	ctx = WithCallOptions(ctx, CallOptions{NoAutoAssign: true, DefaultNamespace: "other"})
+	_, err = client.CreateRecord(ctx, "Lead", map[string]interface{}{"LastName": "Doe"})
+	require.NoError(t, err)
+
+	require.Len(t, got, 2)
+	assert.Equal(t, "defaultNamespace=acme", got[0].Get("Sforce-Call-Options"))
+	assert.Empty(t, got[0].Get("Sforce-Duplicate-Rule-Header"))
+	assert.Empty(t, got[0].Get("Sforce-Auto-Assign"))
+
+	assert.Equal(t, "defaultNamespace=other", got[1].Get("Sforce-Call-Options"))
+	assert.Equal(t, "allowSave=true", got[1].Get("Sforce-Duplicate-Rule-Header"))
+	assert.Equal(t, "FALSE", got[1].Get("Sforce-Auto-Assign"))
+}
@@ -0,0 +1,157 @@
+// Package chatter calls the Chatter resources of the Connect REST API:
+// posting feed elements and comments, with @mentions.
+//
+// It works on top of an api.Client, so requests share its authentication,
+// retries, and compression:
+//
+//	post, err := chatter.New(client).Post(ctx, groupID, chatter.NewMessage("Deploy complete"))
+package chatter
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/open-cli-collective/salesforce-cli/api"
+)
+
+// SubjectMe is the subject of the current user's own feed.
+const SubjectMe = "me"
+
+// Message segment types.
+const (
+	SegmentText    = "Text"
+	SegmentMention = "Mention"
+)
+
+// Client calls the Chatter REST API through a REST API client.
+type Client struct {
+	api *api.Client
+}
+
+// New returns a Chatter client for client's org.
+func New(client *api.Client) *Client {
+	return &Client{api: client}
+}
+
+// MessageSegment is a piece of a message: text, or a mention of a user or
+// group by ID.
+type MessageSegment struct {
+	Type string `json:"type"`
+	Text string `json:"text,omitempty"`
+	ID   string `json:"id,omitempty"`
+}
+
+// Message is the body of a post or comment.
+type Message struct {
+	MessageSegments []MessageSegment `json:"messageSegments"`
+}
+
+// NewMessage returns a message holding text.
+func NewMessage(text string) Message {
+	var m Message
+	return m.Text(text)
+}
+
+// Text returns the message with text appended.
+func (m Message) Text(text string) Message {
+	m.MessageSegments = append(m.MessageSegments, MessageSegment{Type: SegmentText, Text: text})
+	return m
+}
+
+// Mention returns the message with a mention of a user or group appended.
+// Mentioned users are notified.
+func (m Message) Mention(id string) Message {
+	m.MessageSegments = append(m.MessageSegments, MessageSegment{Type: SegmentMention, ID: id})
+	return m
+}
+
+// Actor is the user, group, or record a feed element belongs to or was
+// created by.
+type Actor struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// Body is the rendered body of a feed element or comment.
+type Body struct {
+	Text string `json:"text"`
+}
+
+// FeedElement is a post in a feed.
+type FeedElement struct {
+	ID          string `json:"id"`
+	URL         string `json:"url"`
+	CreatedDate string `json:"createdDate"`
+	Body        Body   `json:"body"`
+	Actor       Actor  `json:"actor"`
+	Parent      Actor  `json:"parent"`
+}
+
+// Comment is a comment on a feed element.
+type Comment struct {
+	ID          string `json:"id"`
+	URL         string `json:"url"`
+	CreatedDate string `json:"createdDate"`
+	Body        Body   `json:"body"`
+	User        Actor  `json:"user"`
+}
+
+// FeedElementsPath is the path posts are sent to.
+const FeedElementsPath = "/chatter/feed-elements"
+
+// FeedItemInput is the request body of Post.
+type FeedItemInput struct {
+	Body            Message `json:"body"`
+	FeedElementType string  `json:"feedElementType"`
+	SubjectID       string  `json:"subjectId"`
+}
+
+// NewFeedItemInput returns the request body posting message to the feed of
+// subjectID.
+func NewFeedItemInput(subjectID string, message Message) FeedItemInput {
+	return FeedItemInput{Body: message, FeedElementType: "FeedItem", SubjectID: subjectID}
+}
+
+// CommentInput is the request body of Comment.
+type CommentInput struct {
+	Body Message `json:"body"`
+}
+
+// CommentsPath returns the path comments on a feed element are sent to.
+func CommentsPath(feedElementID string) string {
+	return fmt.Sprintf("%s/%s/capabilities/comments/items", FeedElementsPath, url.PathEscape(feedElementID))
+}
+
+// Post posts a message to the feed of a subject: a group, a record, a user,
+// or SubjectMe.
+func (c *Client) Post(ctx context.Context, subjectID string, message Message) (*FeedElement, error) {
+	body, err := c.api.Post(ctx, FeedElementsPath, NewFeedItemInput(subjectID, message))
+	if err != nil {
+		return nil, err
+	}
+
+	var element FeedElement
+	if err := json.Unmarshal(body, &element); err != nil {
+		return nil, fmt.Errorf("failed to parse feed element: %w", err)
+	}
+
+	return &element, nil
+}
+
+// Comment adds a comment to a feed element.
+func (c *Client) Comment(ctx context.Context, feedElementID string, message Message) (*Comment, error) {
+	body, err := c.api.Post(ctx, CommentsPath(feedElementID), CommentInput{Body: message})
+	if err != nil {
+		return nil, err
+	}
+
+	var comment Comment
+	if err := json.Unmarshal(body, &comment); err != nil {
+		return nil, fmt.Errorf("failed to parse comment: %w", err)
+	}
+
+	return &comment, nil
+}
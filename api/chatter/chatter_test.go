@@ -0,0 +1,78 @@
+package chatter
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/salesforce-cli/api"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client, err := api.New(api.ClientConfig{InstanceURL: server.URL, HTTPClient: server.Client()})
+	require.NoError(t, err)
+	return New(client)
+}
+
+func TestMessage(t *testing.T) {
+	message := NewMessage("Deploy failed, see ").Mention("005xx1").Text(" and ").Mention("005xx2")
+
+	assert.Equal(t, []MessageSegment{
+		{Type: SegmentText, Text: "Deploy failed, see "},
+		{Type: SegmentMention, ID: "005xx1"},
+		{Type: SegmentText, Text: " and "},
+		{Type: SegmentMention, ID: "005xx2"},
+	}, message.MessageSegments)
+}
+
+func TestClient_Post(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/services/data/v62.0/chatter/feed-elements", r.URL.Path)
+
+		var input map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&input))
+		assert.Equal(t, "FeedItem", input["feedElementType"])
+		assert.Equal(t, "0F9xx0000000001", input["subjectId"])
+		assert.Equal(t, map[string]interface{}{"messageSegments": []interface{}{
+			map[string]interface{}{"type": "Text", "text": "Deploy complete"},
+			map[string]interface{}{"type": "Mention", "id": "005xx1"},
+		}}, input["body"])
+
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id": "0D5xx0000000001", "body": {"text": "Deploy complete @Jane"},
+			"parent": {"id": "0F9xx0000000001", "name": "Releases", "type": "CollaborationGroup"}}`))
+	})
+
+	element, err := client.Post(context.Background(), "0F9xx0000000001", NewMessage("Deploy complete").Mention("005xx1"))
+	require.NoError(t, err)
+	assert.Equal(t, "0D5xx0000000001", element.ID)
+	assert.Equal(t, "Releases", element.Parent.Name)
+}
+
+func TestClient_Comment(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/services/data/v62.0/chatter/feed-elements/0D5xx0000000001/capabilities/comments/items", r.URL.Path)
+
+		var input CommentInput
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&input))
+		assert.Equal(t, NewMessage("Thanks"), input.Body)
+
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id": "0D7xx0000000001", "body": {"text": "Thanks"}}`))
+	})
+
+	comment, err := client.Comment(context.Background(), "0D5xx0000000001", NewMessage("Thanks"))
+	require.NoError(t, err)
+	assert.Equal(t, "0D7xx0000000001", comment.ID)
+}
@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 )
 
 // DefaultAPIVersion is the default Salesforce API version
@@ -27,6 +28,22 @@ type Client struct {
 
 	// BaseURL is the full REST API base URL
 	BaseURL string
+
+	// Retry controls how requests failing with transient errors are retried
+	Retry RetryPolicy
+
+	// CallOptions are sent with every request
+	CallOptions CallOptions
+
+	// DescribeCache keeps DescribeSObject results between calls (optional)
+	DescribeCache DescribeCache
+
+	// DescribeCacheTTL is how long cached describes are used without asking
+	// Salesforce whether they changed
+	DescribeCacheTTL time.Duration
+
+	// responses records the metadata of the last response
+	responses *ResponseRecorder
 }
 
 // ClientConfig contains configuration for creating a new client
@@ -39,6 +56,36 @@ type ClientConfig struct {
 
 	// APIVersion is the API version to use (optional, defaults to DefaultAPIVersion)
 	APIVersion string
+
+	// Retry controls how requests failing with transient errors are retried
+	// (optional, defaults to a single attempt)
+	Retry RetryPolicy
+
+	// Middleware wraps the HTTP client's transport, first outermost (optional)
+	Middleware []Middleware
+
+	// Compression controls gzip compression of request and response bodies
+	// (optional, defaults to none beyond what the transport does itself)
+	Compression Compression
+
+	// Timeout fails a request attempt that makes no progress for this long
+	// (optional, defaults to none)
+	Timeout time.Duration
+
+	// RateLimiter limits how often requests are sent; share one between
+	// clients to limit them together (optional, defaults to no limit)
+	RateLimiter *RateLimiter
+
+	// CallOptions are sent with every request (optional)
+	CallOptions CallOptions
+
+	// DescribeCache keeps DescribeSObject results between calls (optional)
+	DescribeCache DescribeCache
+
+	// DescribeCacheTTL is how long cached describes are used without asking
+	// Salesforce whether they changed (optional, defaults to revalidating
+	// every time)
+	DescribeCacheTTL time.Duration
 }
 
 // New creates a new Salesforce API client
@@ -57,11 +104,25 @@ func New(cfg ClientConfig) (*Client, error) {
 		apiVersion = DefaultAPIVersion
 	}
 
+	// Responses are recorded outermost, as returned; compression and the
+	// timeout go innermost, to see the bytes on the wire
+	responses := &ResponseRecorder{}
+	middleware := WithResponseRecorder(cfg.Middleware, responses)
+	middleware = WithRateLimit(middleware, cfg.RateLimiter)
+	middleware = WithCompression(middleware, cfg.Compression)
+	middleware = WithTimeout(middleware, cfg.Timeout)
+
 	return &Client{
-		HTTPClient:  cfg.HTTPClient,
+		HTTPClient:  WrapHTTPClient(cfg.HTTPClient, middleware...),
 		InstanceURL: instanceURL,
 		APIVersion:  apiVersion,
 		BaseURL:     fmt.Sprintf("%s/services/data/%s", instanceURL, apiVersion),
+		Retry:       cfg.Retry,
+		CallOptions: cfg.CallOptions,
+		responses:   responses,
+
+		DescribeCache:    cfg.DescribeCache,
+		DescribeCacheTTL: cfg.DescribeCacheTTL,
 	}, nil
 }
 
@@ -102,41 +163,69 @@ func (c *Client) Delete(ctx context.Context, path string) ([]byte, error) {
 }
 
 func (c *Client) doRequest(ctx context.Context, method, path string, body interface{}) ([]byte, error) {
+	respBody, _, err := c.send(ctx, method, path, body, nil)
+	return respBody, err
+}
+
+// send performs a request with extra headers, e.g. preconditions, and
+// returns the response body and headers. A 304 response returns
+// ErrNotModified.
+func (c *Client) send(ctx context.Context, method, path string, body interface{}, header http.Header) ([]byte, http.Header, error) {
 	fullURL := c.buildURL(path)
 
 	var bodyReader io.Reader
 	if body != nil {
 		jsonBody, err := json.Marshal(body)
 		if err != nil {
-			return nil, fmt.Errorf("failed to marshal request body: %w", err)
+			return nil, nil, fmt.Errorf("failed to marshal request body: %w", err)
 		}
 		bodyReader = bytes.NewReader(jsonBody)
 	}
 
 	req, err := http.NewRequestWithContext(ctx, method, fullURL, bodyReader)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
+	c.setCallOptions(req)
+	for name, values := range header {
+		req.Header[name] = values
+	}
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Accept", "application/json")
 
-	resp, err := c.HTTPClient.Do(req)
+	resp, err := c.Retry.Do(c.HTTPClient, req)
 	if err != nil {
-		return nil, fmt.Errorf("request failed: %w", err)
+		return nil, nil, fmt.Errorf("request failed: %w", err)
 	}
 
 	if resp.StatusCode >= 400 {
-		return nil, ParseAPIError(resp)
+		return nil, nil, ParseAPIError(resp)
 	}
 
 	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotModified {
+		return nil, resp.Header, ErrNotModified
+	}
+
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
+		return nil, nil, fmt.Errorf("failed to read response: %w", err)
 	}
 
-	return respBody, nil
+	return respBody, resp.Header, nil
+}
+
+// LastResponse returns the metadata of the client's last response, such as
+// its Salesforce request ID, or nil if there has been none. Responses to
+// failed requests count too, so after an error it describes that failure.
+func (c *Client) LastResponse() *ResponseInfo {
+	return c.responses.Last()
+}
+
+// ResourceURL returns the full URL that a request for path is sent to.
+func (c *Client) ResourceURL(path string) string {
+	return c.buildURL(path)
 }
 
 func (c *Client) buildURL(path string) string {
@@ -184,19 +273,13 @@ func (c *Client) GetSObjects(ctx context.Context) (*SObjectsResponse, error) {
 	return &resp, nil
 }
 
-// DescribeSObject returns detailed metadata about an SObject type
+// DescribeSObject returns detailed metadata about an SObject type. With a
+// DescribeCache, cached results are used while they are fresh.
 func (c *Client) DescribeSObject(ctx context.Context, objectName string) (*SObjectDescribe, error) {
-	body, err := c.Get(ctx, fmt.Sprintf("/sobjects/%s/describe", objectName))
-	if err != nil {
-		return nil, err
-	}
-
-	var desc SObjectDescribe
-	if err := json.Unmarshal(body, &desc); err != nil {
-		return nil, fmt.Errorf("failed to parse describe response: %w", err)
+	if c.DescribeCache != nil {
+		return c.cachedDescribe(ctx, objectName)
 	}
-
-	return &desc, nil
+	return c.describeSObject(ctx, objectName, time.Time{})
 }
 
 // GetLimits returns the org's API limits
@@ -214,6 +297,41 @@ func (c *Client) GetLimits(ctx context.Context) (Limits, error) {
 	return limits, nil
 }
 
+// recordCountBatchSize is the number of objects requested per record count
+// call, keeping the query string well under URL length limits
+const recordCountBatchSize = 100
+
+// GetRecordCounts returns the approximate record counts of the given objects,
+// keyed by object name. Counts come from storage statistics and may lag
+// recent changes; objects without statistics are omitted.
+func (c *Client) GetRecordCounts(ctx context.Context, objectNames []string) (map[string]int, error) {
+	counts := make(map[string]int, len(objectNames))
+
+	for start := 0; start < len(objectNames); start += recordCountBatchSize {
+		end := start + recordCountBatchSize
+		if end > len(objectNames) {
+			end = len(objectNames)
+		}
+
+		path := "/limits/recordCount?sObjects=" + url.QueryEscape(strings.Join(objectNames[start:end], ","))
+		body, err := c.Get(ctx, path)
+		if err != nil {
+			return nil, err
+		}
+
+		var resp RecordCountResponse
+		if err := json.Unmarshal(body, &resp); err != nil {
+			return nil, fmt.Errorf("failed to parse record count response: %w", err)
+		}
+
+		for _, rc := range resp.SObjects {
+			counts[rc.Name] = rc.Count
+		}
+	}
+
+	return counts, nil
+}
+
 // Query executes a SOQL query and returns the results
 func (c *Client) Query(ctx context.Context, soql string) (*QueryResult, error) {
 	path := fmt.Sprintf("/query?q=%s", url.QueryEscape(soql))
@@ -267,22 +385,7 @@ func (c *Client) QueryAll(ctx context.Context, soql string) (*QueryResult, error
 
 // GetRecord retrieves a single record by ID
 func (c *Client) GetRecord(ctx context.Context, objectName, recordID string, fields []string) (*SObject, error) {
-	path := fmt.Sprintf("/sobjects/%s/%s", objectName, recordID)
-	if len(fields) > 0 {
-		path += "?fields=" + strings.Join(fields, ",")
-	}
-
-	body, err := c.Get(ctx, path)
-	if err != nil {
-		return nil, err
-	}
-
-	var record SObject
-	if err := json.Unmarshal(body, &record); err != nil {
-		return nil, fmt.Errorf("failed to parse record: %w", err)
-	}
-
-	return &record, nil
+	return c.GetRecordIf(ctx, objectName, recordID, fields, Conditions{})
 }
 
 // CreateRecord creates a new record and returns the result
@@ -303,9 +406,35 @@ func (c *Client) CreateRecord(ctx context.Context, objectName string, record map
 
 // UpdateRecord updates an existing record
 func (c *Client) UpdateRecord(ctx context.Context, objectName, recordID string, record map[string]interface{}) error {
-	path := fmt.Sprintf("/sobjects/%s/%s", objectName, recordID)
-	_, err := c.Patch(ctx, path, record)
-	return err
+	return c.UpdateRecordIf(ctx, objectName, recordID, record, Conditions{})
+}
+
+// UpsertRecord creates or updates the record whose external ID field
+// extIDField has the value extIDValue: the record is updated if one matches
+// and created otherwise. The result's Created field tells which happened.
+func (c *Client) UpsertRecord(ctx context.Context, objectName, extIDField, extIDValue string, record map[string]interface{}) (*RecordResult, error) {
+	path := fmt.Sprintf("/sobjects/%s/%s/%s", objectName, extIDField, url.PathEscape(extIDValue))
+	body, err := c.Patch(ctx, path, record)
+	if err != nil {
+		return nil, err
+	}
+
+	// Before API v46.0, an update returns no content
+	if len(bytes.TrimSpace(body)) == 0 {
+		return &RecordResult{Success: true}, nil
+	}
+
+	// A value matching several records returns 300 with a list of their URLs
+	if bytes.HasPrefix(bytes.TrimSpace(body), []byte("[")) {
+		return nil, fmt.Errorf("%s value %q matches more than one %s record", extIDField, extIDValue, objectName)
+	}
+
+	var result RecordResult
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse upsert result: %w", err)
+	}
+
+	return &result, nil
 }
 
 // DeleteRecord deletes a record
@@ -3,8 +3,10 @@ package api
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -279,6 +281,47 @@ func TestClient_UpdateRecord(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestClient_UpsertRecord(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      int
+		response    string
+		wantCreated bool
+		wantID      string
+	}{
+		{"created", http.StatusCreated, `{"id":"001xx000003ABCDEF","success":true,"errors":[],"created":true}`, true, "001xx000003ABCDEF"},
+		{"updated", http.StatusOK, `{"id":"001xx000003ABCDEF","success":true,"errors":[],"created":false}`, false, "001xx000003ABCDEF"},
+		{"updated before v46.0", http.StatusNoContent, "", false, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodPatch, r.Method)
+				assert.Equal(t, "/services/data/v62.0/sobjects/Account/Ext_Id__c/A 1", r.URL.Path)
+				assert.Equal(t, "/services/data/v62.0/sobjects/Account/Ext_Id__c/A%201", r.URL.EscapedPath())
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.response))
+			}))
+			defer server.Close()
+
+			client, err := New(ClientConfig{
+				InstanceURL: server.URL,
+				HTTPClient:  server.Client(),
+			})
+			require.NoError(t, err)
+
+			result, err := client.UpsertRecord(context.Background(), "Account", "Ext_Id__c", "A 1", map[string]interface{}{
+				"Name": "Acme",
+			})
+			require.NoError(t, err)
+			assert.True(t, result.Success)
+			assert.Equal(t, tt.wantCreated, result.Created)
+			assert.Equal(t, tt.wantID, result.ID)
+		})
+	}
+}
+
 func TestClient_DeleteRecord(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		assert.Equal(t, http.MethodDelete, r.Method)
@@ -438,3 +481,58 @@ func TestClient_RecordURL(t *testing.T) {
 	url := client.RecordURL("001xx000003ABCDEF")
 	assert.Equal(t, "https://mycompany.my.salesforce.com/001xx000003ABCDEF", url)
 }
+
+func TestClient_Background(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Contains(t, r.URL.Path, "/query")
+		json.NewEncoder(w).Encode(QueryResult{
+			TotalSize: 1,
+			Done:      true,
+			Records:   []SObject{{ID: "001xx000003ABCDEF"}},
+		})
+	}))
+	defer server.Close()
+
+	client, err := New(ClientConfig{
+		InstanceURL: server.URL,
+		HTTPClient:  server.Client(),
+	})
+	require.NoError(t, err)
+
+	result, err := client.Background().Query("SELECT Id FROM Account")
+	require.NoError(t, err)
+	assert.Equal(t, "001xx000003ABCDEF", result.Records[0].ID)
+}
+
+func TestClient_GetRecordCounts_Batches(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/services/data/v62.0/limits/recordCount", r.URL.Path)
+		names := strings.Split(r.URL.Query().Get("sObjects"), ",")
+		requests = append(requests, r.URL.Query().Get("sObjects"))
+
+		resp := RecordCountResponse{}
+		for _, name := range names {
+			resp.SObjects = append(resp.SObjects, RecordCount{Name: name, Count: len(name)})
+		}
+		json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	client, err := New(ClientConfig{
+		InstanceURL: server.URL,
+		HTTPClient:  server.Client(),
+	})
+	require.NoError(t, err)
+
+	names := make([]string, 0, recordCountBatchSize+1)
+	for i := 0; i <= recordCountBatchSize; i++ {
+		names = append(names, fmt.Sprintf("Obj%d__c", i))
+	}
+
+	counts, err := client.GetRecordCounts(context.Background(), names)
+	require.NoError(t, err)
+	assert.Len(t, requests, 2)
+	assert.Len(t, counts, recordCountBatchSize+1)
+	assert.Equal(t, len("Obj0__c"), counts["Obj0__c"])
+}
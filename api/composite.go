@@ -0,0 +1,278 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// MaxCompositeSubrequests is the maximum number of subrequests in a single
+// composite request.
+const MaxCompositeSubrequests = 25
+
+// Composite validation errors
+var (
+	ErrCompositeEmpty    = errors.New("composite request has no subrequests")
+	ErrCompositeTooLarge = fmt.Errorf("composite request has more than %d subrequests", MaxCompositeSubrequests)
+)
+
+// Composite executes a composite request. Subrequests run in order, and each
+// can use the results of earlier ones through references such as
+// @{NewAccount.id} (see Ref). Subrequest URLs may be relative to the REST API
+// base URL, e.g. /sobjects/Account, in which case the client's API version is
+// added.
+//
+// A failing subrequest does not fail the call; check each subresponse, or
+// use CompositeResponse.Err. With AllOrNone, a failure rolls back the whole
+// request.
+func (c *Client) Composite(ctx context.Context, req *CompositeRequest) (*CompositeResponse, error) {
+	if err := validateComposite(req.CompositeRequest); err != nil {
+		return nil, err
+	}
+
+	resolved := *req
+	resolved.CompositeRequest = c.resolveSubrequests(req.CompositeRequest)
+
+	body, err := c.Post(ctx, "/composite", resolved)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp CompositeResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse composite response: %w", err)
+	}
+
+	return &resp, nil
+}
+
+// resolveSubrequests returns a copy of subrequests with server-relative URLs.
+func (c *Client) resolveSubrequests(subrequests []CompositeSubrequest) []CompositeSubrequest {
+	resolved := make([]CompositeSubrequest, len(subrequests))
+	for i, sub := range subrequests {
+		sub.URL = c.subrequestURL(sub.URL)
+		resolved[i] = sub
+	}
+	return resolved
+}
+
+// subrequestURL returns the server-relative URL of a subrequest, adding the
+// REST API base path to paths relative to it.
+func (c *Client) subrequestURL(path string) string {
+	if strings.HasPrefix(path, "/services/") {
+		return path
+	}
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	return "/services/data/" + c.APIVersion + path
+}
+
+// validateComposite checks the number of subrequests and that their
+// reference IDs are set and unique.
+func validateComposite(subrequests []CompositeSubrequest) error {
+	if len(subrequests) > MaxCompositeSubrequests {
+		return ErrCompositeTooLarge
+	}
+	return validateReferenceIDs(subrequests)
+}
+
+// validateReferenceIDs checks that there are subrequests and that their
+// reference IDs are set and unique.
+func validateReferenceIDs(subrequests []CompositeSubrequest) error {
+	if len(subrequests) == 0 {
+		return ErrCompositeEmpty
+	}
+
+	seen := make(map[string]bool, len(subrequests))
+	for i, sub := range subrequests {
+		if sub.ReferenceID == "" {
+			return fmt.Errorf("subrequest %d has no reference ID", i+1)
+		}
+		if seen[sub.ReferenceID] {
+			return fmt.Errorf("duplicate reference ID: %s", sub.ReferenceID)
+		}
+		seen[sub.ReferenceID] = true
+	}
+	return nil
+}
+
+// Ref returns a reference to a field of an earlier subrequest's result, for
+// use in later subrequest URLs and bodies, e.g. Ref("NewAccount", "id").
+func Ref(referenceID, field string) string {
+	return fmt.Sprintf("@{%s.%s}", referenceID, field)
+}
+
+// CompositeBuilder builds a composite request from chained subrequests.
+//
+//	req, err := api.NewCompositeBuilder().
+//		AllOrNone(true).
+//		Create("NewAccount", "Account", map[string]interface{}{"Name": "Acme"}).
+//		Create("NewContact", "Contact", map[string]interface{}{
+//			"LastName":  "Smith",
+//			"AccountId": api.Ref("NewAccount", "id"),
+//		}).
+//		Build()
+type CompositeBuilder struct {
+	req CompositeRequest
+}
+
+// NewCompositeBuilder returns an empty composite request builder.
+func NewCompositeBuilder() *CompositeBuilder {
+	return &CompositeBuilder{}
+}
+
+// AllOrNone sets whether a failing subrequest rolls back the whole request.
+func (b *CompositeBuilder) AllOrNone(allOrNone bool) *CompositeBuilder {
+	b.req.AllOrNone = allOrNone
+	return b
+}
+
+// Add appends a subrequest.
+func (b *CompositeBuilder) Add(sub CompositeSubrequest) *CompositeBuilder {
+	b.req.CompositeRequest = append(b.req.CompositeRequest, sub)
+	return b
+}
+
+// Create appends a subrequest creating a record.
+func (b *CompositeBuilder) Create(referenceID, objectName string, fields map[string]interface{}) *CompositeBuilder {
+	return b.Add(CompositeSubrequest{
+		Method:      http.MethodPost,
+		URL:         fmt.Sprintf("/sobjects/%s", objectName),
+		ReferenceID: referenceID,
+		Body:        fields,
+	})
+}
+
+// Update appends a subrequest updating a record. The record ID may be a
+// reference to an earlier result.
+func (b *CompositeBuilder) Update(referenceID, objectName, recordID string, fields map[string]interface{}) *CompositeBuilder {
+	return b.Add(CompositeSubrequest{
+		Method:      http.MethodPatch,
+		URL:         fmt.Sprintf("/sobjects/%s/%s", objectName, recordID),
+		ReferenceID: referenceID,
+		Body:        fields,
+	})
+}
+
+// Delete appends a subrequest deleting a record.
+func (b *CompositeBuilder) Delete(referenceID, objectName, recordID string) *CompositeBuilder {
+	return b.Add(CompositeSubrequest{
+		Method:      http.MethodDelete,
+		URL:         fmt.Sprintf("/sobjects/%s/%s", objectName, recordID),
+		ReferenceID: referenceID,
+	})
+}
+
+// Get appends a subrequest retrieving a record, with only the given fields
+// if any are specified.
+func (b *CompositeBuilder) Get(referenceID, objectName, recordID string, fields ...string) *CompositeBuilder {
+	path := fmt.Sprintf("/sobjects/%s/%s", objectName, recordID)
+	if len(fields) > 0 {
+		path += "?fields=" + strings.Join(fields, ",")
+	}
+	return b.Add(CompositeSubrequest{
+		Method:      http.MethodGet,
+		URL:         path,
+		ReferenceID: referenceID,
+	})
+}
+
+// Query appends a subrequest running a SOQL query. Later subrequests can
+// refer to its records, e.g. Ref("Accounts", "records[0].Id").
+func (b *CompositeBuilder) Query(referenceID, soql string) *CompositeBuilder {
+	return b.Add(CompositeSubrequest{
+		Method:      http.MethodGet,
+		URL:         "/query?q=" + url.QueryEscape(soql),
+		ReferenceID: referenceID,
+	})
+}
+
+// Build validates and returns the composite request.
+func (b *CompositeBuilder) Build() (*CompositeRequest, error) {
+	if err := validateComposite(b.req.CompositeRequest); err != nil {
+		return nil, err
+	}
+	req := b.req
+	return &req, nil
+}
+
+// Subresponse returns the response to the subrequest with the given
+// reference ID.
+func (r *CompositeResponse) Subresponse(referenceID string) (*CompositeSubresponse, bool) {
+	for i := range r.CompositeResponse {
+		if r.CompositeResponse[i].ReferenceID == referenceID {
+			return &r.CompositeResponse[i], true
+		}
+	}
+	return nil, false
+}
+
+// Err returns the errors of all failed subrequests, or nil if all succeeded.
+func (r *CompositeResponse) Err() error {
+	var errs []error
+	for _, sub := range r.CompositeResponse {
+		if err := sub.Err(); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", sub.ReferenceID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// IDs maps the reference IDs of successful subrequests that created records
+// to the new record IDs.
+func (r *CompositeResponse) IDs() map[string]string {
+	ids := make(map[string]string)
+	for _, sub := range r.CompositeResponse {
+		if id := sub.RecordID(); id != "" {
+			ids[sub.ReferenceID] = id
+		}
+	}
+	return ids
+}
+
+// Succeeded returns true if the subrequest succeeded.
+func (s *CompositeSubresponse) Succeeded() bool {
+	return s.HTTPStatusCode >= 200 && s.HTTPStatusCode < 300
+}
+
+// Err returns the subrequest's error as an *APIError, or nil if it succeeded.
+func (s *CompositeSubresponse) Err() error {
+	if s.Succeeded() {
+		return nil
+	}
+
+	apiErr := &APIError{StatusCode: s.HTTPStatusCode}
+	_ = json.Unmarshal(s.Body, &apiErr.Errors)
+	return apiErr
+}
+
+// RecordID returns the ID of the record a successful create subrequest
+// created, or "" for other subrequests.
+func (s *CompositeSubresponse) RecordID() string {
+	var result RecordResult
+	if !s.Succeeded() || json.Unmarshal(s.Body, &result) != nil {
+		return ""
+	}
+	return result.ID
+}
+
+// Decode unmarshals the subrequest's response body into v, e.g. a
+// *RecordResult for a create, an *SObject for a get, or a *QueryResult for a
+// query. If the subrequest failed, its error is returned instead.
+func (s *CompositeSubresponse) Decode(v interface{}) error {
+	if err := s.Err(); err != nil {
+		return err
+	}
+	if len(s.Body) == 0 || string(s.Body) == "null" {
+		return nil
+	}
+	if err := json.Unmarshal(s.Body, v); err != nil {
+		return fmt.Errorf("failed to parse %s response: %w", s.ReferenceID, err)
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// MaxBatchSubrequests is the maximum number of subrequests in a single batch
+// request.
+const MaxBatchSubrequests = 25
+
+// BatchRequest represents a composite batch API request
+type BatchRequest struct {
+	HaltOnError   bool              `json:"haltOnError,omitempty"`
+	BatchRequests []BatchSubrequest `json:"batchRequests"`
+}
+
+// BatchSubrequest represents a single request within a batch
+type BatchSubrequest struct {
+	Method    string      `json:"method"`
+	URL       string      `json:"url"`
+	RichInput interface{} `json:"richInput,omitempty"`
+}
+
+// BatchResponse represents a composite batch API response
+type BatchResponse struct {
+	HasErrors bool               `json:"hasErrors"`
+	Results   []BatchSubresponse `json:"results"`
+}
+
+// BatchSubresponse represents a single response within a batch, in the
+// order of the subrequests
+type BatchSubresponse struct {
+	StatusCode int             `json:"statusCode"`
+	Result     json.RawMessage `json:"result"`
+}
+
+// Batch executes up to 25 independent subrequests in one call. Unlike
+// Composite, subrequests cannot refer to each other and each succeeds or
+// fails on its own; with HaltOnError, the subrequests after a failure are
+// not run. Subrequest URLs may be relative to the REST API base URL, e.g.
+// /sobjects/Account, in which case the client's API version is added.
+//
+// A failing subrequest does not fail the call; check each subresponse, or
+// use BatchResponse.Err.
+func (c *Client) Batch(ctx context.Context, req *BatchRequest) (*BatchResponse, error) {
+	if len(req.BatchRequests) == 0 {
+		return nil, errors.New("batch request has no subrequests")
+	}
+	if len(req.BatchRequests) > MaxBatchSubrequests {
+		return nil, fmt.Errorf("batch request has more than %d subrequests", MaxBatchSubrequests)
+	}
+
+	resolved := BatchRequest{
+		HaltOnError:   req.HaltOnError,
+		BatchRequests: make([]BatchSubrequest, len(req.BatchRequests)),
+	}
+	for i, sub := range req.BatchRequests {
+		if sub.Method == "" || sub.URL == "" {
+			return nil, fmt.Errorf("subrequest %d needs a method and a URL", i+1)
+		}
+		sub.Method = strings.ToUpper(sub.Method)
+		sub.URL = c.batchSubrequestURL(sub.URL)
+		resolved.BatchRequests[i] = sub
+	}
+
+	body, err := c.Post(ctx, "/composite/batch", resolved)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp BatchResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse batch response: %w", err)
+	}
+
+	return &resp, nil
+}
+
+// batchSubrequestURL returns a batch subrequest URL, which is relative to
+// /services/data/ and starts with the API version, e.g. v62.0/limits.
+func (c *Client) batchSubrequestURL(path string) string {
+	path = strings.TrimPrefix(strings.TrimPrefix(path, "/services/data/"), "/")
+	if version, _, _ := strings.Cut(path, "/"); isAPIVersion(version) {
+		return path
+	}
+	return c.APIVersion + "/" + path
+}
+
+// isAPIVersion reports whether s is an API version such as v62.0.
+func isAPIVersion(s string) bool {
+	rest, ok := strings.CutPrefix(s, "v")
+	major, minor, found := strings.Cut(rest, ".")
+	return ok && found && isDigits(major) && isDigits(minor)
+}
+
+func isDigits(s string) bool {
+	return s != "" && strings.Trim(s, "0123456789") == ""
+}
+
+// Err returns the errors of all failed subrequests, or nil if all succeeded.
+func (r *BatchResponse) Err() error {
+	var errs []error
+	for i := range r.Results {
+		if err := r.Results[i].Err(); err != nil {
+			errs = append(errs, fmt.Errorf("subrequest %d: %w", i+1, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Succeeded returns true if the subrequest succeeded.
+func (s *BatchSubresponse) Succeeded() bool {
+	return s.StatusCode >= 200 && s.StatusCode < 300
+}
+
+// Err returns the subrequest's error as an *APIError, or nil if it succeeded.
+func (s *BatchSubresponse) Err() error {
+	if s.Succeeded() {
+		return nil
+	}
+
+	apiErr := &APIError{StatusCode: s.StatusCode}
+	_ = json.Unmarshal(s.Result, &apiErr.Errors)
+	return apiErr
+}
+
+// Decode unmarshals the subrequest's result into v. If the subrequest
+// failed, its error is returned instead.
+func (s *BatchSubresponse) Decode(v interface{}) error {
+	if err := s.Err(); err != nil {
+		return err
+	}
+	if len(s.Result) == 0 || string(s.Result) == "null" {
+		return nil
+	}
+	if err := json.Unmarshal(s.Result, v); err != nil {
+		return fmt.Errorf("failed to parse batch result: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_BatchSubrequestURL(t *testing.T) {
+	client, err := New(ClientConfig{InstanceURL: "https://test.salesforce.com", HTTPClient: http.DefaultClient})
+	require.NoError(t, err)
+
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"/sobjects/Account/001xx000001", "v62.0/sobjects/Account/001xx000001"},
+		{"sobjects/Account", "v62.0/sobjects/Account"},
+		{"v59.0/limits", "v59.0/limits"},
+		{"/v59.0/limits", "v59.0/limits"},
+		{"/services/data/v60.0/query?q=SELECT+Id+FROM+Account", "v60.0/query?q=SELECT+Id+FROM+Account"},
+		{"/versions", "v62.0/versions"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			assert.Equal(t, tt.want, client.batchSubrequestURL(tt.path))
+		})
+	}
+}
+
+func TestClient_Batch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/services/data/v62.0/composite/batch", r.URL.Path)
+
+		var req BatchRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.True(t, req.HaltOnError)
+		require.Len(t, req.BatchRequests, 2)
+		assert.Equal(t, BatchSubrequest{Method: "GET", URL: "v62.0/sobjects/Account/001xx000001"}, req.BatchRequests[0])
+		assert.Equal(t, "PATCH", req.BatchRequests[1].Method)
+		assert.Equal(t, map[string]interface{}{"Phone": "555-0100"}, req.BatchRequests[1].RichInput)
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"hasErrors":true,"results":[
+			{"statusCode":200,"result":{"attributes":{"type":"Account"},"Id":"001xx000001","Name":"Acme"}},
+			{"statusCode":404,"result":[{"errorCode":"NOT_FOUND","message":"The requested resource does not exist"}]}
+		]}`))
+	}))
+	defer server.Close()
+
+	client, err := New(ClientConfig{InstanceURL: server.URL, HTTPClient: server.Client()})
+	require.NoError(t, err)
+
+	resp, err := client.Batch(context.Background(), &BatchRequest{
+		HaltOnError: true,
+		BatchRequests: []BatchSubrequest{
+			{Method: "get", URL: "/sobjects/Account/001xx000001"},
+			{Method: "PATCH", URL: "/sobjects/Contact/003xx000001", RichInput: map[string]interface{}{"Phone": "555-0100"}},
+		},
+	})
+	require.NoError(t, err)
+	assert.True(t, resp.HasErrors)
+	require.Len(t, resp.Results, 2)
+
+	var record SObject
+	require.NoError(t, resp.Results[0].Decode(&record))
+	assert.Equal(t, "Acme", record.GetString("Name"))
+
+	err = resp.Results[1].Decode(&record)
+	assert.True(t, IsNotFound(err))
+	assert.ErrorContains(t, resp.Err(), "subrequest 2: NOT_FOUND")
+}
+
+func TestClient_Batch_Validation(t *testing.T) {
+	client, err := New(ClientConfig{InstanceURL: "https://test.salesforce.com", HTTPClient: http.DefaultClient})
+	require.NoError(t, err)
+
+	tooMany := make([]BatchSubrequest, MaxBatchSubrequests+1)
+	for i := range tooMany {
+		tooMany[i] = BatchSubrequest{Method: "GET", URL: "/limits"}
+	}
+
+	tests := []struct {
+		name     string
+		requests []BatchSubrequest
+		wantErr  string
+	}{
+		{"empty", nil, "no subrequests"},
+		{"too many", tooMany, "more than 25 subrequests"},
+		{"missing URL", []BatchSubrequest{{Method: "GET"}}, "subrequest 1 needs a method and a URL"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := client.Batch(context.Background(), &BatchRequest{BatchRequests: tt.requests})
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
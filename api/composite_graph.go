@@ -0,0 +1,170 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// MaxGraphNodes is the maximum number of subrequests in a single graph of a
+// composite graph request.
+const MaxGraphNodes = 500
+
+// ErrGraphTooLarge is returned for a graph with more than MaxGraphNodes
+// subrequests.
+var ErrGraphTooLarge = fmt.Errorf("graph has more than %d subrequests", MaxGraphNodes)
+
+// CompositeGraphRequest represents a composite graph API request
+type CompositeGraphRequest struct {
+	Graphs []CompositeGraph `json:"graphs"`
+}
+
+// CompositeGraph is a graph of related subrequests, which succeed or fail
+// together
+type CompositeGraph struct {
+	GraphID          string                `json:"graphId"`
+	CompositeRequest []CompositeSubrequest `json:"compositeRequest"`
+}
+
+// CompositeGraphResponse represents a composite graph API response
+type CompositeGraphResponse struct {
+	Graphs []CompositeGraphResult `json:"graphs"`
+}
+
+// CompositeGraphResult is the result of a single graph
+type CompositeGraphResult struct {
+	GraphID       string            `json:"graphId"`
+	GraphResponse CompositeResponse `json:"graphResponse"`
+	IsSuccessful  bool              `json:"isSuccessful"`
+}
+
+// CompositeGraph executes a composite graph request. Each graph is applied
+// or rolled back as a whole, independently of the others, and subrequests
+// can refer to earlier results in the same graph (see Ref). As in Composite,
+// subrequest URLs may be relative to the REST API base URL.
+//
+// A failing graph does not fail the call; check each graph's IsSuccessful,
+// or use CompositeGraphResponse.Err.
+func (c *Client) CompositeGraph(ctx context.Context, req *CompositeGraphRequest) (*CompositeGraphResponse, error) {
+	if len(req.Graphs) == 0 {
+		return nil, errors.New("composite graph request has no graphs")
+	}
+
+	resolved := CompositeGraphRequest{Graphs: make([]CompositeGraph, len(req.Graphs))}
+	seen := make(map[string]bool, len(req.Graphs))
+	for i, graph := range req.Graphs {
+		if graph.GraphID == "" {
+			return nil, fmt.Errorf("graph %d has no graph ID", i+1)
+		}
+		if seen[graph.GraphID] {
+			return nil, fmt.Errorf("duplicate graph ID: %s", graph.GraphID)
+		}
+		seen[graph.GraphID] = true
+
+		if err := validateGraph(graph.CompositeRequest); err != nil {
+			return nil, fmt.Errorf("graph %s: %w", graph.GraphID, err)
+		}
+
+		resolved.Graphs[i] = CompositeGraph{
+			GraphID:          graph.GraphID,
+			CompositeRequest: c.resolveSubrequests(graph.CompositeRequest),
+		}
+	}
+
+	body, err := c.Post(ctx, "/composite/graph", resolved)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp CompositeGraphResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse composite graph response: %w", err)
+	}
+
+	return &resp, nil
+}
+
+// validateGraph checks the number of subrequests in a graph and that their
+// reference IDs are set and unique.
+func validateGraph(subrequests []CompositeSubrequest) error {
+	if len(subrequests) > MaxGraphNodes {
+		return ErrGraphTooLarge
+	}
+	return validateReferenceIDs(subrequests)
+}
+
+// BuildGraph validates the subrequests and returns them as a graph with the
+// given ID. AllOrNone does not apply, since a graph always rolls back as a
+// whole.
+func (b *CompositeBuilder) BuildGraph(graphID string) (*CompositeGraph, error) {
+	if err := validateGraph(b.req.CompositeRequest); err != nil {
+		return nil, err
+	}
+	return &CompositeGraph{
+		GraphID:          graphID,
+		CompositeRequest: append([]CompositeSubrequest(nil), b.req.CompositeRequest...),
+	}, nil
+}
+
+// Graph returns the result of the graph with the given ID.
+func (r *CompositeGraphResponse) Graph(graphID string) (*CompositeGraphResult, bool) {
+	for i := range r.Graphs {
+		if r.Graphs[i].GraphID == graphID {
+			return &r.Graphs[i], true
+		}
+	}
+	return nil, false
+}
+
+// Err returns the errors of all failed graphs, or nil if all succeeded.
+func (r *CompositeGraphResponse) Err() error {
+	var errs []error
+	for i := range r.Graphs {
+		if err := r.Graphs[i].Err(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Err returns the error that caused the graph to be rolled back, or nil if
+// it succeeded. The subrequests that were halted by the rollback are left
+// out, so that the error names the subrequests that failed.
+func (g *CompositeGraphResult) Err() error {
+	if g.IsSuccessful {
+		return nil
+	}
+
+	var errs []error
+	for _, sub := range g.GraphResponse.CompositeResponse {
+		err := sub.Err()
+		if err == nil || isProcessingHalted(err) {
+			continue
+		}
+		errs = append(errs, fmt.Errorf("%s: %w", sub.ReferenceID, err))
+	}
+	if len(errs) == 0 {
+		// Only halted subrequests, so report all of them
+		if err := g.GraphResponse.Err(); err != nil {
+			return fmt.Errorf("graph %s failed: %w", g.GraphID, err)
+		}
+		return fmt.Errorf("graph %s failed", g.GraphID)
+	}
+	return fmt.Errorf("graph %s failed: %w", g.GraphID, errors.Join(errs...))
+}
+
+// isProcessingHalted reports whether err is a subrequest that was not run, or
+// was rolled back, because another subrequest failed.
+func isProcessingHalted(err error) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	for _, e := range apiErr.Errors {
+		if e.ErrorCode != "PROCESSING_HALTED" {
+			return false
+		}
+	}
+	return len(apiErr.Errors) > 0
+}
@@ -0,0 +1,118 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_CompositeGraph(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/services/data/v62.0/composite/graph", r.URL.Path)
+
+		var req CompositeGraphRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		require.Len(t, req.Graphs, 2)
+		assert.Equal(t, "g1", req.Graphs[0].GraphID)
+		assert.Equal(t, "/services/data/v62.0/sobjects/Account", req.Graphs[0].CompositeRequest[0].URL)
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"graphs":[
+			{"graphId":"g1","isSuccessful":true,"graphResponse":{"compositeResponse":[
+				{"body":{"id":"001xx000001","success":true,"errors":[]},"httpHeaders":{},"httpStatusCode":201,"referenceId":"NewAccount"},
+				{"body":{"id":"003xx000001","success":true,"errors":[]},"httpHeaders":{},"httpStatusCode":201,"referenceId":"NewContact"}
+			]}},
+			{"graphId":"g2","isSuccessful":false,"graphResponse":{"compositeResponse":[
+				{"body":[{"errorCode":"PROCESSING_HALTED","message":"The transaction was rolled back since another operation in the same transaction failed."}],"httpHeaders":{},"httpStatusCode":400,"referenceId":"OtherAccount"},
+				{"body":[{"errorCode":"REQUIRED_FIELD_MISSING","message":"Required fields are missing: [LastName]","fields":["LastName"]}],"httpHeaders":{},"httpStatusCode":400,"referenceId":"OtherContact"}
+			]}}
+		]}`))
+	}))
+	defer server.Close()
+
+	client, err := New(ClientConfig{InstanceURL: server.URL, HTTPClient: server.Client()})
+	require.NoError(t, err)
+
+	g1, err := NewCompositeBuilder().
+		Create("NewAccount", "Account", map[string]interface{}{"Name": "Acme"}).
+		Create("NewContact", "Contact", map[string]interface{}{"LastName": "Smith", "AccountId": Ref("NewAccount", "id")}).
+		BuildGraph("g1")
+	require.NoError(t, err)
+	g2, err := NewCompositeBuilder().
+		Create("OtherAccount", "Account", map[string]interface{}{"Name": "Other"}).
+		Create("OtherContact", "Contact", map[string]interface{}{"AccountId": Ref("OtherAccount", "id")}).
+		BuildGraph("g2")
+	require.NoError(t, err)
+
+	resp, err := client.CompositeGraph(context.Background(), &CompositeGraphRequest{Graphs: []CompositeGraph{*g1, *g2}})
+	require.NoError(t, err)
+
+	ok1, found := resp.Graph("g1")
+	require.True(t, found)
+	assert.NoError(t, ok1.Err())
+	assert.Equal(t, map[string]string{"NewAccount": "001xx000001", "NewContact": "003xx000001"}, ok1.GraphResponse.IDs())
+
+	failed, found := resp.Graph("g2")
+	require.True(t, found)
+	err = failed.Err()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "graph g2 failed: OtherContact: REQUIRED_FIELD_MISSING")
+	assert.NotContains(t, err.Error(), "PROCESSING_HALTED", "halted subrequests should be left out")
+	assert.Empty(t, failed.GraphResponse.IDs())
+
+	assert.ErrorContains(t, resp.Err(), "graph g2 failed")
+
+	_, found = resp.Graph("g3")
+	assert.False(t, found)
+}
+
+func TestClient_CompositeGraph_Validation(t *testing.T) {
+	node := CompositeSubrequest{Method: http.MethodDelete, URL: "/sobjects/Account/001xx000001", ReferenceID: "ref"}
+	large := make([]CompositeSubrequest, MaxGraphNodes+1)
+	for i := range large {
+		large[i] = node
+	}
+
+	tests := []struct {
+		name    string
+		graphs  []CompositeGraph
+		wantErr string
+	}{
+		{"no graphs", nil, "no graphs"},
+		{"missing graph ID", []CompositeGraph{{CompositeRequest: []CompositeSubrequest{node}}}, "graph 1 has no graph ID"},
+		{"duplicate graph ID", []CompositeGraph{{GraphID: "g", CompositeRequest: []CompositeSubrequest{node}}, {GraphID: "g", CompositeRequest: []CompositeSubrequest{node}}}, "duplicate graph ID: g"},
+		{"empty graph", []CompositeGraph{{GraphID: "g"}}, "graph g: " + ErrCompositeEmpty.Error()},
+		{"too many nodes", []CompositeGraph{{GraphID: "g", CompositeRequest: large}}, ErrGraphTooLarge.Error()},
+		{"duplicate reference ID", []CompositeGraph{{GraphID: "g", CompositeRequest: []CompositeSubrequest{node, node}}}, "duplicate reference ID: ref"},
+	}
+
+	client, err := New(ClientConfig{InstanceURL: "https://test.salesforce.com", HTTPClient: http.DefaultClient})
+	require.NoError(t, err)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := client.CompositeGraph(context.Background(), &CompositeGraphRequest{Graphs: tt.graphs})
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestCompositeGraphResult_Err_OnlyHalted(t *testing.T) {
+	result := CompositeGraphResult{
+		GraphID: "g",
+		GraphResponse: CompositeResponse{CompositeResponse: []CompositeSubresponse{
+			{ReferenceID: "a", HTTPStatusCode: 400, Body: json.RawMessage(`[{"errorCode":"PROCESSING_HALTED","message":"halted"}]`)},
+		}},
+	}
+
+	err := result.Err()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "graph g failed: a: PROCESSING_HALTED")
+}
@@ -0,0 +1,206 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompositeBuilder_Build(t *testing.T) {
+	tooMany := NewCompositeBuilder()
+	for i := 0; i <= MaxCompositeSubrequests; i++ {
+		tooMany.Delete(fmt.Sprintf("ref%d", i), "Account", "001xx000001")
+	}
+
+	tests := []struct {
+		name    string
+		builder *CompositeBuilder
+		wantErr string
+	}{
+		{
+			name: "valid",
+			builder: NewCompositeBuilder().
+				Create("NewAccount", "Account", map[string]interface{}{"Name": "Acme"}).
+				Get("Account", "Account", Ref("NewAccount", "id"), "Name"),
+		},
+		{
+			name:    "empty",
+			builder: NewCompositeBuilder(),
+			wantErr: ErrCompositeEmpty.Error(),
+		},
+		{
+			name:    "too many subrequests",
+			builder: tooMany,
+			wantErr: ErrCompositeTooLarge.Error(),
+		},
+		{
+			name: "duplicate reference ID",
+			builder: NewCompositeBuilder().
+				Delete("ref", "Account", "001xx000001").
+				Delete("ref", "Account", "001xx000002"),
+			wantErr: "duplicate reference ID: ref",
+		},
+		{
+			name:    "missing reference ID",
+			builder: NewCompositeBuilder().Query("", "SELECT Id FROM Account"),
+			wantErr: "subrequest 1 has no reference ID",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := tt.builder.Build()
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.NotEmpty(t, req.CompositeRequest)
+		})
+	}
+}
+
+func TestCompositeBuilder_Subrequests(t *testing.T) {
+	req, err := NewCompositeBuilder().
+		AllOrNone(true).
+		Create("NewAccount", "Account", map[string]interface{}{"Name": "Acme"}).
+		Update("UpdateAccount", "Account", Ref("NewAccount", "id"), map[string]interface{}{"Industry": "Tech"}).
+		Get("GetAccount", "Account", Ref("NewAccount", "id"), "Name", "Industry").
+		Query("Contacts", "SELECT Id FROM Contact WHERE Name = 'A B'").
+		Delete("DeleteContact", "Contact", Ref("Contacts", "records[0].Id")).
+		Build()
+	require.NoError(t, err)
+
+	assert.True(t, req.AllOrNone)
+	want := []struct{ method, url string }{
+		{http.MethodPost, "/sobjects/Account"},
+		{http.MethodPatch, "/sobjects/Account/@{NewAccount.id}"},
+		{http.MethodGet, "/sobjects/Account/@{NewAccount.id}?fields=Name,Industry"},
+		{http.MethodGet, "/query?q=SELECT+Id+FROM+Contact+WHERE+Name+%3D+%27A+B%27"},
+		{http.MethodDelete, "/sobjects/Contact/@{Contacts.records[0].Id}"},
+	}
+	require.Len(t, req.CompositeRequest, len(want))
+	for i, w := range want {
+		assert.Equal(t, w.method, req.CompositeRequest[i].Method)
+		assert.Equal(t, w.url, req.CompositeRequest[i].URL)
+	}
+}
+
+func TestClient_Composite(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/services/data/v62.0/composite", r.URL.Path)
+
+		var req CompositeRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.True(t, req.AllOrNone)
+		require.Len(t, req.CompositeRequest, 2)
+		assert.Equal(t, "/services/data/v62.0/sobjects/Account", req.CompositeRequest[0].URL)
+		assert.Equal(t, "/services/data/v62.0/sobjects/Account/@{NewAccount.id}", req.CompositeRequest[1].URL)
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"compositeResponse":[
+			{"body":{"id":"001xx000001","success":true,"errors":[]},"httpHeaders":{},"httpStatusCode":201,"referenceId":"NewAccount"},
+			{"body":{"attributes":{"type":"Account"},"Id":"001xx000001","Name":"Acme"},"httpHeaders":{},"httpStatusCode":200,"referenceId":"GetAccount"}
+		]}`))
+	}))
+	defer server.Close()
+
+	client, err := New(ClientConfig{InstanceURL: server.URL, HTTPClient: server.Client()})
+	require.NoError(t, err)
+
+	req, err := NewCompositeBuilder().
+		AllOrNone(true).
+		Create("NewAccount", "Account", map[string]interface{}{"Name": "Acme"}).
+		Get("GetAccount", "Account", Ref("NewAccount", "id")).
+		Build()
+	require.NoError(t, err)
+
+	resp, err := client.Composite(context.Background(), req)
+	require.NoError(t, err)
+	require.NoError(t, resp.Err())
+
+	created, ok := resp.Subresponse("NewAccount")
+	require.True(t, ok)
+	var result RecordResult
+	require.NoError(t, created.Decode(&result))
+	assert.Equal(t, "001xx000001", result.ID)
+
+	got, ok := resp.Subresponse("GetAccount")
+	require.True(t, ok)
+	var record SObject
+	require.NoError(t, got.Decode(&record))
+	assert.Equal(t, "Acme", record.GetString("Name"))
+
+	_, ok = resp.Subresponse("Missing")
+	assert.False(t, ok)
+	// The request's own URLs are not rewritten
+	assert.Equal(t, "/sobjects/Account", req.CompositeRequest[0].URL)
+}
+
+func TestCompositeSubresponse_Decode(t *testing.T) {
+	tests := []struct {
+		name    string
+		sub     CompositeSubresponse
+		wantID  string
+		wantErr string
+	}{
+		{
+			name:   "created",
+			sub:    CompositeSubresponse{ReferenceID: "a", HTTPStatusCode: 201, Body: json.RawMessage(`{"id":"001xx000001","success":true}`)},
+			wantID: "001xx000001",
+		},
+		{
+			name: "no content",
+			sub:  CompositeSubresponse{ReferenceID: "a", HTTPStatusCode: 204, Body: json.RawMessage(`null`)},
+		},
+		{
+			name:    "failed",
+			sub:     CompositeSubresponse{ReferenceID: "a", HTTPStatusCode: 400, Body: json.RawMessage(`[{"errorCode":"REQUIRED_FIELD_MISSING","message":"Required fields are missing: [Name]","fields":["Name"]}]`)},
+			wantErr: "REQUIRED_FIELD_MISSING: Required fields are missing: [Name] (fields: Name)",
+		},
+		{
+			name:    "rolled back",
+			sub:     CompositeSubresponse{ReferenceID: "a", HTTPStatusCode: 400, Body: json.RawMessage(`[{"errorCode":"PROCESSING_HALTED","message":"The transaction was rolled back since another operation in the same transaction failed."}]`)},
+			wantErr: "PROCESSING_HALTED",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var result RecordResult
+			err := tt.sub.Decode(&result)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				assert.True(t, IsBadRequest(err))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantID, result.ID)
+		})
+	}
+}
+
+func TestCompositeResponse_Err(t *testing.T) {
+	resp := CompositeResponse{CompositeResponse: []CompositeSubresponse{
+		{ReferenceID: "ok", HTTPStatusCode: 200, Body: json.RawMessage(`{}`)},
+		{ReferenceID: "bad", HTTPStatusCode: 404, Body: json.RawMessage(`[{"errorCode":"NOT_FOUND","message":"The requested resource does not exist"}]`)},
+	}}
+
+	err := resp.Err()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "bad: NOT_FOUND")
+	assert.NotContains(t, err.Error(), "ok:")
+	assert.True(t, IsNotFound(err))
+
+	resp.CompositeResponse = resp.CompositeResponse[:1]
+	assert.NoError(t, resp.Err())
+}
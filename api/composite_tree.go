@@ -0,0 +1,103 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// MaxTreeRecords is the maximum number of records, parents and children
+// together, in a single record tree request.
+const MaxTreeRecords = 200
+
+// SObjectTree is a set of records of one object type with nested child
+// records, in the format of the composite tree API and of
+// "sf data export tree" files. Each record has an attributes object with its
+// type and a referenceId; child records are nested under their relationship
+// name, e.g. "Contacts": {"records": [...]}.
+type SObjectTree struct {
+	Records []map[string]interface{} `json:"records"`
+}
+
+// SObjectTreeResult is the result of creating a record tree
+type SObjectTreeResult struct {
+	HasErrors bool                      `json:"hasErrors"`
+	Results   []SObjectTreeRecordResult `json:"results"`
+}
+
+// SObjectTreeRecordResult is the result for one record of a tree: its ID if
+// the tree was created, or its errors if not
+type SObjectTreeRecordResult struct {
+	ReferenceID string        `json:"referenceId"`
+	ID          string        `json:"id,omitempty"`
+	Errors      []RecordError `json:"errors,omitempty"`
+}
+
+// Count returns the number of records in the tree, including children.
+func (t *SObjectTree) Count() int {
+	return countTreeRecords(t.Records)
+}
+
+func countTreeRecords(records []map[string]interface{}) int {
+	n := len(records)
+	for _, record := range records {
+		for key, value := range record {
+			if key == "attributes" {
+				continue
+			}
+			if children, ok := value.(map[string]interface{}); ok {
+				if nested, ok := children["records"].([]interface{}); ok {
+					n += countTreeRecords(toRecordMaps(nested))
+				}
+			}
+		}
+	}
+	return n
+}
+
+func toRecordMaps(values []interface{}) []map[string]interface{} {
+	records := make([]map[string]interface{}, 0, len(values))
+	for _, v := range values {
+		if record, ok := v.(map[string]interface{}); ok {
+			records = append(records, record)
+		}
+	}
+	return records
+}
+
+// CreateRecordTree creates records of objectName together with their nested
+// child records in a single call. The tree is created as a whole or not at
+// all: if any record fails, HasErrors is set and the failing records' errors
+// are returned in the result, with no error.
+func (c *Client) CreateRecordTree(ctx context.Context, objectName string, tree *SObjectTree) (*SObjectTreeResult, error) {
+	if len(tree.Records) == 0 {
+		return nil, errors.New("record tree has no records")
+	}
+	if n := tree.Count(); n > MaxTreeRecords {
+		return nil, fmt.Errorf("record tree has %d records, more than the limit of %d", n, MaxTreeRecords)
+	}
+
+	body, err := c.Post(ctx, fmt.Sprintf("/composite/tree/%s", objectName), tree)
+	if err != nil {
+		// A failed tree is reported with a 400 whose body is the tree
+		// result rather than an error array
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadRequest || len(apiErr.Errors) != 1 {
+			return nil, err
+		}
+		var result SObjectTreeResult
+		if json.Unmarshal([]byte(apiErr.Errors[0].Message), &result) != nil || !result.HasErrors {
+			return nil, err
+		}
+		return &result, nil
+	}
+
+	var result SObjectTreeResult
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse record tree result: %w", err)
+	}
+
+	return &result, nil
+}
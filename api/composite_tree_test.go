@@ -0,0 +1,109 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const accountTreeJSON = `{"records":[{
+	"attributes":{"type":"Account","referenceId":"AcmeRef"},
+	"Name":"Acme",
+	"Contacts":{"records":[
+		{"attributes":{"type":"Contact","referenceId":"SmithRef"},"LastName":"Smith"},
+		{"attributes":{"type":"Contact","referenceId":"JonesRef"},"LastName":"Jones"}
+	]}
+}]}`
+
+func TestSObjectTree_Count(t *testing.T) {
+	var tree SObjectTree
+	require.NoError(t, json.Unmarshal([]byte(accountTreeJSON), &tree))
+	assert.Equal(t, 3, tree.Count())
+}
+
+func TestClient_CreateRecordTree(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     int
+		response   string
+		wantErr    string
+		wantResult *SObjectTreeResult
+	}{
+		{
+			name:     "created",
+			status:   http.StatusCreated,
+			response: `{"hasErrors":false,"results":[{"referenceId":"AcmeRef","id":"001xx000001"},{"referenceId":"SmithRef","id":"003xx000001"}]}`,
+			wantResult: &SObjectTreeResult{Results: []SObjectTreeRecordResult{
+				{ReferenceID: "AcmeRef", ID: "001xx000001"},
+				{ReferenceID: "SmithRef", ID: "003xx000001"},
+			}},
+		},
+		{
+			name:     "record errors",
+			status:   http.StatusBadRequest,
+			response: `{"hasErrors":true,"results":[{"referenceId":"SmithRef","errors":[{"statusCode":"REQUIRED_FIELD_MISSING","message":"Required fields are missing: [LastName]","fields":["LastName"]}]}]}`,
+			wantResult: &SObjectTreeResult{HasErrors: true, Results: []SObjectTreeRecordResult{
+				{ReferenceID: "SmithRef", Errors: []RecordError{{StatusCode: "REQUIRED_FIELD_MISSING", Message: "Required fields are missing: [LastName]", Fields: []string{"LastName"}}}},
+			}},
+		},
+		{
+			name:     "request error",
+			status:   http.StatusBadRequest,
+			response: `[{"errorCode":"INVALID_TYPE","message":"sObject type 'Acount' is not supported."}]`,
+			wantErr:  "INVALID_TYPE",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodPost, r.Method)
+				assert.Equal(t, "/services/data/v62.0/composite/tree/Account", r.URL.Path)
+
+				var tree SObjectTree
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&tree))
+				assert.Equal(t, 3, tree.Count())
+
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.response))
+			}))
+			defer server.Close()
+
+			client, err := New(ClientConfig{InstanceURL: server.URL, HTTPClient: server.Client()})
+			require.NoError(t, err)
+
+			var tree SObjectTree
+			require.NoError(t, json.Unmarshal([]byte(accountTreeJSON), &tree))
+
+			result, err := client.CreateRecordTree(context.Background(), "Account", &tree)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantResult, result)
+		})
+	}
+}
+
+func TestClient_CreateRecordTree_Limits(t *testing.T) {
+	client, err := New(ClientConfig{InstanceURL: "https://test.salesforce.com", HTTPClient: http.DefaultClient})
+	require.NoError(t, err)
+
+	_, err = client.CreateRecordTree(context.Background(), "Account", &SObjectTree{})
+	assert.ErrorContains(t, err, "no records")
+
+	tree := &SObjectTree{}
+	for i := 0; i <= MaxTreeRecords; i++ {
+		tree.Records = append(tree.Records, map[string]interface{}{"Name": "Acme"})
+	}
+	_, err = client.CreateRecordTree(context.Background(), "Account", tree)
+	assert.ErrorContains(t, err, "201 records, more than the limit of 200")
+}
@@ -0,0 +1,175 @@
+package api
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// DefaultMinCompressBytes is the smallest request body Compression gzips
+// unless MinRequestBytes says otherwise. Smaller bodies gain too little to
+// be worth the CPU time.
+const DefaultMinCompressBytes = 1024
+
+// Compression controls gzip compression of request and response bodies.
+type Compression struct {
+	// Response asks for gzip-compressed responses and decompresses them.
+	// Go's default transport does this on its own, but only while no
+	// Accept-Encoding header is set and compression is not disabled; this
+	// makes it work with any transport.
+	Response bool
+
+	// Request gzips request bodies of at least MinRequestBytes. Only bodies
+	// that can be replayed, i.e. built in memory, are compressed; streamed
+	// uploads are sent as they are.
+	Request bool
+
+	// MinRequestBytes is the smallest body Request compresses (optional,
+	// defaults to DefaultMinCompressBytes)
+	MinRequestBytes int
+}
+
+// Enabled reports whether any compression is turned on.
+func (c Compression) Enabled() bool {
+	return c.Response || c.Request
+}
+
+// Gzip returns a Middleware applying c to every request.
+func Gzip(c Compression) Middleware {
+	minBytes := c.MinRequestBytes
+	if minBytes <= 0 {
+		minBytes = DefaultMinCompressBytes
+	}
+
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			if c.Request {
+				var err error
+				if req, err = gzipRequest(req, minBytes); err != nil {
+					return nil, err
+				}
+			}
+
+			askedForGzip := false
+			if c.Response && req.Header.Get("Accept-Encoding") == "" {
+				req = req.Clone(req.Context())
+				req.Header.Set("Accept-Encoding", "gzip")
+				askedForGzip = true
+			}
+
+			resp, err := next.RoundTrip(req)
+			if err != nil || !askedForGzip {
+				return resp, err
+			}
+			return gunzipResponse(resp)
+		})
+	}
+}
+
+// WithCompression returns middleware followed by a Gzip middleware for c,
+// making compression the innermost layer so the other middleware see plain
+// bodies. middleware is not modified, and is returned as is when c is off.
+func WithCompression(middleware []Middleware, c Compression) []Middleware {
+	if !c.Enabled() {
+		return middleware
+	}
+	wrapped := make([]Middleware, 0, len(middleware)+1)
+	wrapped = append(wrapped, middleware...)
+	return append(wrapped, Gzip(c))
+}
+
+// gzipRequest returns a copy of req with its body gzipped, or req itself if
+// the body is too small, already encoded, or cannot be replayed.
+func gzipRequest(req *http.Request, minBytes int) (*http.Request, error) {
+	if req.Body == nil || req.Body == http.NoBody || req.GetBody == nil {
+		return req, nil
+	}
+	if req.Header.Get("Content-Encoding") != "" {
+		return req, nil
+	}
+	if req.ContentLength >= 0 && req.ContentLength < int64(minBytes) {
+		return req, nil
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+	data, err := io.ReadAll(body)
+	body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read request body: %w", err)
+	}
+	if len(data) < minBytes {
+		return req, nil
+	}
+
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(data); err != nil {
+		return nil, fmt.Errorf("failed to compress request body: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("failed to compress request body: %w", err)
+	}
+	compressed := buf.Bytes()
+
+	// The original body is replaced, so close it as the transport would.
+	req.Body.Close()
+
+	out := req.Clone(req.Context())
+	out.Header.Set("Content-Encoding", "gzip")
+	out.ContentLength = int64(len(compressed))
+	out.Body = io.NopCloser(bytes.NewReader(compressed))
+	out.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(compressed)), nil
+	}
+	return out, nil
+}
+
+// gunzipResponse replaces a gzip-encoded response body with a reader that
+// decompresses it, and drops the headers describing the encoded body.
+func gunzipResponse(resp *http.Response) (*http.Response, error) {
+	if !strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip") {
+		return resp, nil
+	}
+	if resp.Body == nil || resp.Body == http.NoBody {
+		return resp, nil
+	}
+
+	resp.Body = &gzipReader{body: resp.Body}
+	resp.Header.Del("Content-Encoding")
+	resp.Header.Del("Content-Length")
+	resp.ContentLength = -1
+	resp.Uncompressed = true
+	return resp, nil
+}
+
+// gzipReader decompresses a response body, starting on the first Read so
+// that bodies that are closed unread, e.g. after errors, cost nothing.
+type gzipReader struct {
+	body io.ReadCloser
+	zr   *gzip.Reader
+	err  error
+}
+
+func (g *gzipReader) Read(p []byte) (int, error) {
+	if g.err != nil {
+		return 0, g.err
+	}
+	if g.zr == nil {
+		g.zr, g.err = gzip.NewReader(g.body)
+		if g.err != nil {
+			g.err = fmt.Errorf("failed to decompress response: %w", g.err)
+			return 0, g.err
+		}
+	}
+	return g.zr.Read(p)
+}
+
+func (g *gzipReader) Close() error {
+	return g.body.Close()
+}
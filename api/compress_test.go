@@ -0,0 +1,334 @@
+package api
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// gzipBytes returns data gzip-compressed.
+func gzipBytes(t testing.TB, data []byte) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	_, err := zw.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+	return buf.Bytes()
+}
+
+// readBody returns a request body, decompressing it if it is gzipped.
+func readBody(t testing.TB, r *http.Request) []byte {
+	t.Helper()
+
+	var body io.Reader = r.Body
+	if r.Header.Get("Content-Encoding") == "gzip" {
+		zr, err := gzip.NewReader(r.Body)
+		require.NoError(t, err)
+		body = zr
+	}
+	data, err := io.ReadAll(body)
+	require.NoError(t, err)
+	return data
+}
+
+func TestClient_Compression(t *testing.T) {
+	name := strings.Repeat("Acme ", 500)
+	response := []byte(`{"id":"001xx000001","success":true,"errors":[]}`)
+
+	var encoding string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "gzip", r.Header.Get("Accept-Encoding"))
+		encoding = r.Header.Get("Content-Encoding")
+
+		var record map[string]string
+		require.NoError(t, json.Unmarshal(readBody(t, r), &record))
+		assert.Equal(t, name, record["Name"])
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Encoding", "gzip")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write(gzipBytes(t, response))
+	}))
+	defer server.Close()
+
+	client, err := New(ClientConfig{
+		InstanceURL: server.URL,
+		HTTPClient:  server.Client(),
+		Compression: Compression{Response: true, Request: true},
+	})
+	require.NoError(t, err)
+
+	result, err := client.CreateRecord(context.Background(), "Account", map[string]interface{}{"Name": name})
+	require.NoError(t, err)
+	assert.Equal(t, "001xx000001", result.ID)
+	assert.Equal(t, "gzip", encoding)
+
+	t.Run("small bodies are sent as they are", func(t *testing.T) {
+		name = "Acme"
+		_, err := client.CreateRecord(context.Background(), "Account", map[string]interface{}{"Name": name})
+		require.NoError(t, err)
+		assert.Empty(t, encoding)
+	})
+}
+
+func TestClient_Compression_Off(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Empty(t, r.Header.Get("Content-Encoding"))
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client, err := New(ClientConfig{InstanceURL: server.URL, HTTPClient: server.Client()})
+	require.NoError(t, err)
+
+	_, err = client.Post(context.Background(), "/sobjects/Account", map[string]string{"Name": strings.Repeat("Acme ", 500)})
+	require.NoError(t, err)
+}
+
+func TestClient_Compression_ErrorResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Encoding", "gzip")
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write(gzipBytes(t, []byte(`[{"errorCode":"MALFORMED_QUERY","message":"unexpected token"}]`)))
+	}))
+	defer server.Close()
+
+	client, err := New(ClientConfig{
+		InstanceURL: server.URL,
+		HTTPClient:  server.Client(),
+		Compression: Compression{Response: true},
+	})
+	require.NoError(t, err)
+
+	_, err = client.Query(context.Background(), "SELECT")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "MALFORMED_QUERY")
+}
+
+func TestClient_Compression_Retry(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "gzip", r.Header.Get("Content-Encoding"))
+		assert.Equal(t, `"`+strings.Repeat("x", 2048)+`"`, string(readBody(t, r)))
+		if attempts.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client, err := New(ClientConfig{
+		InstanceURL: server.URL,
+		HTTPClient:  server.Client(),
+		Retry:       RetryPolicy{MaxAttempts: 2, Backoff: time.Millisecond, RetryableStatus: []int{http.StatusServiceUnavailable}},
+		Compression: Compression{Request: true},
+	})
+	require.NoError(t, err)
+
+	_, err = client.Put(context.Background(), "/sobjects/Document/015xx0000001", strings.Repeat("x", 2048))
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), attempts.Load())
+}
+
+func TestGzip_StreamedBodyIsNotCompressed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Empty(t, r.Header.Get("Content-Encoding"))
+		assert.Equal(t, 4096, len(readBody(t, r)))
+	}))
+	defer server.Close()
+
+	httpClient := WrapHTTPClient(server.Client(), Gzip(Compression{Request: true}))
+
+	pr, pw := io.Pipe()
+	go func() {
+		_, _ = pw.Write(make([]byte, 4096))
+		pw.Close()
+	}()
+	req, err := http.NewRequest(http.MethodPost, server.URL, pr)
+	require.NoError(t, err)
+
+	resp, err := httpClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+}
+
+func TestTrace_DecodesGzipBodies(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Encoding", "gzip")
+		_, _ = w.Write(gzipBytes(t, []byte(`{"totalSize":0,"done":true,"records":[]}`)))
+	}))
+	defer server.Close()
+
+	var trace bytes.Buffer
+	transport := Trace(&trace)(server.Client().Transport)
+	httpClient := WrapHTTPClient(&http.Client{Transport: transport}, Gzip(Compression{Response: true, Request: true, MinRequestBytes: 1}))
+
+	req, err := http.NewRequest(http.MethodPost, server.URL, strings.NewReader(`{"Name":"Acme"}`))
+	require.NoError(t, err)
+
+	resp, err := httpClient.Do(req)
+	require.NoError(t, err)
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	require.NoError(t, err)
+	assert.Equal(t, `{"totalSize":0,"done":true,"records":[]}`, string(body))
+
+	out := trace.String()
+	assert.Contains(t, out, "Content-Encoding: gzip")
+	assert.Contains(t, out, `{"Name":"Acme"}`)
+	assert.Contains(t, out, `{"totalSize":0,"done":true,"records":[]}`)
+}
+
+// wireCounter counts the request and response bytes sent over the wire.
+type wireCounter struct {
+	sent, received atomic.Int64
+}
+
+func (w *wireCounter) middleware(next http.RoundTripper) http.RoundTripper {
+	return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		if req.Body != nil && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			n, _ := io.Copy(io.Discard, body)
+			body.Close()
+			w.sent.Add(n)
+		}
+		resp, err := next.RoundTrip(req)
+		if err != nil {
+			return nil, err
+		}
+		data, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		w.received.Add(int64(len(data)))
+		resp.Body = io.NopCloser(bytes.NewReader(data))
+		return resp, err
+	})
+}
+
+// benchmarkRecords returns a page of Account records like a query returns.
+func benchmarkRecords(n int) []SObject {
+	records := make([]SObject, n)
+	for i := range records {
+		records[i] = SObject{
+			Attributes: SObjectAttributes{Type: "Account", URL: fmt.Sprintf("/services/data/v62.0/sobjects/Account/001xx%010d", i)},
+			ID:         fmt.Sprintf("001xx%010d", i),
+			Fields: map[string]interface{}{
+				"Name":          fmt.Sprintf("Account %d", i),
+				"Industry":      "Technology",
+				"BillingCity":   "San Francisco",
+				"AnnualRevenue": float64(i * 1000),
+			},
+		}
+	}
+	return records
+}
+
+// BenchmarkCompression_Query measures a 2,000-record query page with and
+// without gzip. The wire-B/op metric is the bytes received over the wire.
+func BenchmarkCompression_Query(b *testing.B) {
+	page, err := json.Marshal(map[string]interface{}{"totalSize": 2000, "done": true, "records": benchmarkRecords(2000)})
+	require.NoError(b, err)
+	compressed := gzipBytes(b, page)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Header.Get("Accept-Encoding") == "gzip" {
+			w.Header().Set("Content-Encoding", "gzip")
+			_, _ = w.Write(compressed)
+			return
+		}
+		_, _ = w.Write(page)
+	}))
+	defer server.Close()
+
+	for _, bc := range []struct {
+		name        string
+		compression Compression
+	}{
+		{"identity", Compression{}},
+		{"gzip", Compression{Response: true}},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			var wire wireCounter
+			transport := wire.middleware(&http.Transport{DisableCompression: true})
+			client, err := New(ClientConfig{
+				InstanceURL: server.URL,
+				HTTPClient:  &http.Client{Transport: transport},
+				Compression: bc.compression,
+			})
+			require.NoError(b, err)
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := client.Query(context.Background(), "SELECT Id FROM Account"); err != nil {
+					b.Fatal(err)
+				}
+			}
+			b.ReportMetric(float64(wire.received.Load())/float64(b.N), "wire-B/op")
+		})
+	}
+}
+
+// BenchmarkCompression_Upload measures sending a 10,000-row CSV, like a
+// bulk upload, with and without gzip. The wire-B/op metric is the bytes
+// sent over the wire.
+func BenchmarkCompression_Upload(b *testing.B) {
+	var csv strings.Builder
+	csv.WriteString("Name,Industry,BillingCity,AnnualRevenue\n")
+	for i := 0; i < 10000; i++ {
+		fmt.Fprintf(&csv, "Account %d,Technology,San Francisco,%d\n", i, i*1000)
+	}
+	data := []byte(csv.String())
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	for _, bc := range []struct {
+		name        string
+		compression Compression
+	}{
+		{"identity", Compression{}},
+		{"gzip", Compression{Request: true}},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			var wire wireCounter
+			httpClient := WrapHTTPClient(server.Client(), Gzip(bc.compression), wire.middleware)
+
+			b.SetBytes(int64(len(data)))
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				req, err := http.NewRequest(http.MethodPut, server.URL+"/services/data/v62.0/jobs/ingest/750xx0000001/batches", bytes.NewReader(data))
+				if err != nil {
+					b.Fatal(err)
+				}
+				req.Header.Set("Content-Type", "text/csv")
+				resp, err := httpClient.Do(req)
+				if err != nil {
+					b.Fatal(err)
+				}
+				resp.Body.Close()
+			}
+			b.ReportMetric(float64(wire.sent.Load())/float64(b.N), "wire-B/op")
+		})
+	}
+}
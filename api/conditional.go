@@ -0,0 +1,80 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Conditions are preconditions for a record request, sent as conditional
+// HTTP headers. Zero fields are not sent.
+//
+// On a GET, IfNoneMatch and IfModifiedSince make the request return
+// ErrNotModified if the record has not changed. On an update, IfMatch and
+// IfUnmodifiedSince make it fail with ErrPreconditionFailed if the record has
+// changed, so that concurrent changes are not overwritten.
+type Conditions struct {
+	// IfMatch is an ETag, as returned in SObject.ETag, that the record must
+	// still have
+	IfMatch string
+	// IfNoneMatch is an ETag that the record must no longer have
+	IfNoneMatch string
+	// IfModifiedSince requires the record to have changed after this time
+	IfModifiedSince time.Time
+	// IfUnmodifiedSince requires the record not to have changed after this
+	// time
+	IfUnmodifiedSince time.Time
+}
+
+// header returns the conditional headers for c.
+func (c Conditions) header() http.Header {
+	header := make(http.Header)
+	if c.IfMatch != "" {
+		header.Set("If-Match", c.IfMatch)
+	}
+	if c.IfNoneMatch != "" {
+		header.Set("If-None-Match", c.IfNoneMatch)
+	}
+	if !c.IfModifiedSince.IsZero() {
+		header.Set("If-Modified-Since", c.IfModifiedSince.UTC().Format(http.TimeFormat))
+	}
+	if !c.IfUnmodifiedSince.IsZero() {
+		header.Set("If-Unmodified-Since", c.IfUnmodifiedSince.UTC().Format(http.TimeFormat))
+	}
+	return header
+}
+
+// GetRecordIf retrieves a single record by ID if it meets the conditions. It
+// returns ErrNotModified if an IfNoneMatch or IfModifiedSince condition
+// found the record unchanged.
+func (c *Client) GetRecordIf(ctx context.Context, objectName, recordID string, fields []string, cond Conditions) (*SObject, error) {
+	path := fmt.Sprintf("/sobjects/%s/%s", objectName, recordID)
+	if len(fields) > 0 {
+		path += "?fields=" + strings.Join(fields, ",")
+	}
+
+	body, header, err := c.send(ctx, http.MethodGet, path, nil, cond.header())
+	if err != nil {
+		return nil, err
+	}
+
+	var record SObject
+	if err := json.Unmarshal(body, &record); err != nil {
+		return nil, fmt.Errorf("failed to parse record: %w", err)
+	}
+	record.ETag = header.Get("ETag")
+
+	return &record, nil
+}
+
+// UpdateRecordIf updates an existing record if it meets the conditions. It
+// returns ErrPreconditionFailed if an IfMatch or IfUnmodifiedSince condition
+// found that the record had changed, in which case nothing is updated.
+func (c *Client) UpdateRecordIf(ctx context.Context, objectName, recordID string, record map[string]interface{}, cond Conditions) error {
+	path := fmt.Sprintf("/sobjects/%s/%s", objectName, recordID)
+	_, _, err := c.send(ctx, http.MethodPatch, path, record, cond.header())
+	return err
+}
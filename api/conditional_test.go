@@ -0,0 +1,82 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConditions_Header(t *testing.T) {
+	at := time.Date(2024, 1, 15, 10, 30, 0, 0, time.FixedZone("PST", -8*3600))
+
+	header := Conditions{
+		IfMatch:           `"abc"`,
+		IfNoneMatch:       `"def"`,
+		IfModifiedSince:   at,
+		IfUnmodifiedSince: at,
+	}.header()
+
+	assert.Equal(t, `"abc"`, header.Get("If-Match"))
+	assert.Equal(t, `"def"`, header.Get("If-None-Match"))
+	assert.Equal(t, "Mon, 15 Jan 2024 18:30:00 GMT", header.Get("If-Modified-Since"))
+	assert.Equal(t, "Mon, 15 Jan 2024 18:30:00 GMT", header.Get("If-Unmodified-Since"))
+
+	assert.Empty(t, Conditions{}.header())
+}
+
+func TestClient_GetRecordIf(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"attributes":{"type":"Account"},"Id":"001xx000003ABCDEF","Name":"Acme"}`))
+	}))
+	defer server.Close()
+
+	client, err := New(ClientConfig{InstanceURL: server.URL, HTTPClient: server.Client()})
+	require.NoError(t, err)
+
+	record, err := client.GetRecord(context.Background(), "Account", "001xx000003ABCDEF", nil)
+	require.NoError(t, err)
+	assert.Equal(t, `"v1"`, record.ETag)
+
+	_, err = client.GetRecordIf(context.Background(), "Account", "001xx000003ABCDEF", nil, Conditions{IfNoneMatch: record.ETag})
+	assert.True(t, IsNotModified(err))
+}
+
+func TestClient_UpdateRecordIf(t *testing.T) {
+	lastRead := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+	modified := lastRead.Add(time.Hour)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPatch, r.Method)
+		since, err := http.ParseTime(r.Header.Get("If-Unmodified-Since"))
+		require.NoError(t, err)
+		if modified.After(since) {
+			w.WriteHeader(http.StatusPreconditionFailed)
+			_, _ = w.Write([]byte(`[{"errorCode":"PRECONDITION_FAILED","message":"The requested resource has been modified since the time specified by the If-Unmodified-Since header."}]`))
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client, err := New(ClientConfig{InstanceURL: server.URL, HTTPClient: server.Client()})
+	require.NoError(t, err)
+
+	fields := map[string]interface{}{"Name": "Acme"}
+
+	err = client.UpdateRecordIf(context.Background(), "Account", "001xx000003ABCDEF", fields, Conditions{IfUnmodifiedSince: lastRead})
+	assert.True(t, IsPreconditionFailed(err))
+
+	err = client.UpdateRecordIf(context.Background(), "Account", "001xx000003ABCDEF", fields, Conditions{IfUnmodifiedSince: modified})
+	assert.NoError(t, err)
+}
@@ -0,0 +1,160 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+)
+
+// DescribeCache stores describe results so DescribeSObject need not fetch
+// them on every call. Caching is best effort: implementations should treat
+// failures to read or write entries as cache misses.
+type DescribeCache interface {
+	// Get returns the cached entry for an object, if there is one.
+	Get(objectName string) (CachedDescribe, bool)
+	// Put stores the entry for an object.
+	Put(objectName string, entry CachedDescribe)
+}
+
+// CachedDescribe is a describe result and the time it was fetched or last
+// confirmed to be unchanged.
+type CachedDescribe struct {
+	Describe  *SObjectDescribe `json:"describe"`
+	FetchedAt time.Time        `json:"fetched_at"`
+}
+
+// describeSObject fetches a describe, sending If-Modified-Since when since is
+// set. A 304 response returns ErrNotModified.
+func (c *Client) describeSObject(ctx context.Context, objectName string, since time.Time) (*SObjectDescribe, error) {
+	var header http.Header
+	if !since.IsZero() {
+		header = http.Header{"If-Modified-Since": {since.UTC().Format(http.TimeFormat)}}
+	}
+
+	body, _, err := c.send(ctx, http.MethodGet, fmt.Sprintf("/sobjects/%s/describe", objectName), nil, header)
+	if err != nil {
+		return nil, err
+	}
+
+	var desc SObjectDescribe
+	if err := json.Unmarshal(body, &desc); err != nil {
+		return nil, fmt.Errorf("failed to parse describe response: %w", err)
+	}
+
+	return &desc, nil
+}
+
+// DescribeSObjectIfModified returns the describe of an object if its
+// metadata changed after since, and ErrNotModified otherwise.
+func (c *Client) DescribeSObjectIfModified(ctx context.Context, objectName string, since time.Time) (*SObjectDescribe, error) {
+	return c.describeSObject(ctx, objectName, since)
+}
+
+// cachedDescribe returns the describe of an object through the client's
+// DescribeCache. Entries younger than DescribeCacheTTL are used as they are;
+// older ones are revalidated with If-Modified-Since, so unchanged objects
+// are not downloaded again.
+func (c *Client) cachedDescribe(ctx context.Context, objectName string) (*SObjectDescribe, error) {
+	cached, ok := c.DescribeCache.Get(objectName)
+	if ok && cached.Describe == nil {
+		ok = false
+	}
+	if ok && time.Since(cached.FetchedAt) < c.DescribeCacheTTL {
+		return cached.Describe, nil
+	}
+
+	var since time.Time
+	if ok {
+		since = cached.FetchedAt
+	}
+
+	desc, err := c.describeSObject(ctx, objectName, since)
+	if ok && IsNotModified(err) {
+		desc, err = cached.Describe, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	c.DescribeCache.Put(objectName, CachedDescribe{Describe: desc, FetchedAt: time.Now()})
+	return desc, nil
+}
+
+// cacheableObjectName matches object names that are safe to use as file
+// names.
+var cacheableObjectName = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*$`)
+
+// FileDescribeCache is a DescribeCache that keeps one JSON file per object
+// in a directory. Use a separate directory for each org and API version.
+type FileDescribeCache struct {
+	Dir string
+}
+
+// NewFileDescribeCache returns a cache that stores entries in dir, which is
+// created when the first entry is stored.
+func NewFileDescribeCache(dir string) *FileDescribeCache {
+	return &FileDescribeCache{Dir: dir}
+}
+
+// Get reads the entry for an object. Missing or unreadable entries are
+// reported as misses.
+func (f *FileDescribeCache) Get(objectName string) (CachedDescribe, bool) {
+	path, ok := f.path(objectName)
+	if !ok {
+		return CachedDescribe{}, false
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return CachedDescribe{}, false
+	}
+
+	var entry CachedDescribe
+	if err := json.Unmarshal(data, &entry); err != nil || entry.Describe == nil {
+		return CachedDescribe{}, false
+	}
+	return entry, true
+}
+
+// Put writes the entry for an object. Write failures are ignored; the
+// object is fetched again next time.
+func (f *FileDescribeCache) Put(objectName string, entry CachedDescribe) {
+	path, ok := f.path(objectName)
+	if !ok {
+		return
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(f.Dir, 0700); err != nil {
+		return
+	}
+
+	// Write to a temporary file and rename it so concurrent commands never
+	// read a partial entry.
+	tmp, err := os.CreateTemp(f.Dir, objectName+".*.tmp")
+	if err != nil {
+		return
+	}
+	_, writeErr := tmp.Write(data)
+	closeErr := tmp.Close()
+	if writeErr != nil || closeErr != nil || os.Rename(tmp.Name(), path) != nil {
+		_ = os.Remove(tmp.Name())
+	}
+}
+
+// path returns the file of an object's entry, or false if the name is not
+// safe to use as a file name.
+func (f *FileDescribeCache) path(objectName string) (string, bool) {
+	if f.Dir == "" || !cacheableObjectName.MatchString(objectName) {
+		return "", false
+	}
+	return filepath.Join(f.Dir, objectName+".json"), true
+}
@@ -0,0 +1,145 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryDescribeCache is a DescribeCache kept in a map.
+type memoryDescribeCache map[string]CachedDescribe
+
+func (m memoryDescribeCache) Get(objectName string) (CachedDescribe, bool) {
+	entry, ok := m[objectName]
+	return entry, ok
+}
+
+func (m memoryDescribeCache) Put(objectName string, entry CachedDescribe) {
+	m[objectName] = entry
+}
+
+func TestClient_DescribeSObject_Cache(t *testing.T) {
+	var (
+		requests        int
+		ifModifiedSince string
+		notModified     bool
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		assert.Equal(t, "/services/data/v62.0/sobjects/Account/describe", r.URL.Path)
+		ifModifiedSince = r.Header.Get("If-Modified-Since")
+		if notModified {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(SObjectDescribe{Name: "Account", Label: "Account (fresh)"})
+	}))
+	defer server.Close()
+
+	cache := memoryDescribeCache{}
+	client, err := New(ClientConfig{
+		InstanceURL:      server.URL,
+		HTTPClient:       server.Client(),
+		DescribeCache:    cache,
+		DescribeCacheTTL: time.Hour,
+	})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	t.Run("miss fetches and stores", func(t *testing.T) {
+		desc, err := client.DescribeSObject(ctx, "Account")
+		require.NoError(t, err)
+		assert.Equal(t, "Account (fresh)", desc.Label)
+		assert.Equal(t, 1, requests)
+		assert.Empty(t, ifModifiedSince)
+		assert.WithinDuration(t, time.Now(), cache["Account"].FetchedAt, time.Minute)
+	})
+
+	t.Run("fresh entry is used without a request", func(t *testing.T) {
+		cache["Account"] = CachedDescribe{Describe: &SObjectDescribe{Name: "Account", Label: "Account (cached)"}, FetchedAt: time.Now()}
+
+		desc, err := client.DescribeSObject(ctx, "Account")
+		require.NoError(t, err)
+		assert.Equal(t, "Account (cached)", desc.Label)
+		assert.Equal(t, 1, requests)
+	})
+
+	t.Run("stale entry is revalidated", func(t *testing.T) {
+		fetched := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+		cache["Account"] = CachedDescribe{Describe: &SObjectDescribe{Name: "Account", Label: "Account (cached)"}, FetchedAt: fetched}
+		notModified = true
+
+		desc, err := client.DescribeSObject(ctx, "Account")
+		require.NoError(t, err)
+		assert.Equal(t, "Account (cached)", desc.Label)
+		assert.Equal(t, 2, requests)
+		assert.Equal(t, "Mon, 15 Jan 2024 10:30:00 GMT", ifModifiedSince)
+		assert.WithinDuration(t, time.Now(), cache["Account"].FetchedAt, time.Minute, "revalidated entry is fresh again")
+	})
+
+	t.Run("changed object replaces the entry", func(t *testing.T) {
+		cache["Account"] = CachedDescribe{Describe: &SObjectDescribe{Name: "Account", Label: "Account (cached)"}, FetchedAt: time.Now().Add(-2 * time.Hour)}
+		notModified = false
+
+		desc, err := client.DescribeSObject(ctx, "Account")
+		require.NoError(t, err)
+		assert.Equal(t, "Account (fresh)", desc.Label)
+		assert.Equal(t, "Account (fresh)", cache["Account"].Describe.Label)
+		assert.NotEmpty(t, ifModifiedSince)
+	})
+}
+
+func TestClient_DescribeSObjectIfModified(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.NotEmpty(t, r.Header.Get("If-Modified-Since"))
+		w.WriteHeader(http.StatusNotModified)
+	}))
+	defer server.Close()
+
+	client, err := New(ClientConfig{InstanceURL: server.URL, HTTPClient: server.Client()})
+	require.NoError(t, err)
+
+	_, err = client.DescribeSObjectIfModified(context.Background(), "Account", time.Now())
+	assert.True(t, IsNotModified(err))
+}
+
+func TestFileDescribeCache(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "describe", "example.my.salesforce.com", "v62.0")
+	cache := NewFileDescribeCache(dir)
+
+	_, ok := cache.Get("Account")
+	assert.False(t, ok)
+
+	fetched := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+	cache.Put("Account", CachedDescribe{
+		Describe:  &SObjectDescribe{Name: "Account", Fields: []Field{{Name: "Name", Type: "string"}}},
+		FetchedAt: fetched,
+	})
+
+	entry, ok := cache.Get("Account")
+	require.True(t, ok)
+	assert.Equal(t, "Account", entry.Describe.Name)
+	assert.Equal(t, "Name", entry.Describe.Fields[0].Name)
+	assert.True(t, fetched.Equal(entry.FetchedAt))
+
+	t.Run("unsafe names are not cached", func(t *testing.T) {
+		cache.Put("../Account", CachedDescribe{Describe: &SObjectDescribe{Name: "Account"}})
+		_, ok := cache.Get("../Account")
+		assert.False(t, ok)
+		assert.NoFileExists(t, filepath.Join(filepath.Dir(dir), "Account.json"))
+	})
+
+	t.Run("corrupt entries are misses", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "Contact.json"), []byte("{not json"), 0600))
+		_, ok := cache.Get("Contact")
+		assert.False(t, ok)
+	})
+}
@@ -11,13 +11,15 @@ import (
 
 // Sentinel errors for common API error conditions
 var (
-	ErrNotFound       = errors.New("resource not found")
-	ErrUnauthorized   = errors.New("unauthorized - check your OAuth token")
-	ErrForbidden      = errors.New("forbidden - insufficient permissions")
-	ErrBadRequest     = errors.New("bad request")
-	ErrRateLimited    = errors.New("rate limited - try again later")
-	ErrServerError    = errors.New("server error")
-	ErrInvalidSession = errors.New("invalid session - token may be expired")
+	ErrNotFound           = errors.New("resource not found")
+	ErrUnauthorized       = errors.New("unauthorized - check your OAuth token")
+	ErrForbidden          = errors.New("forbidden - insufficient permissions")
+	ErrBadRequest         = errors.New("bad request")
+	ErrRateLimited        = errors.New("rate limited - try again later")
+	ErrServerError        = errors.New("server error")
+	ErrInvalidSession     = errors.New("invalid session - token may be expired")
+	ErrPreconditionFailed = errors.New("precondition failed - the record has changed")
+	ErrNotModified        = errors.New("not modified")
 )
 
 // Validation errors
@@ -31,6 +33,9 @@ var (
 type APIError struct {
 	StatusCode int
 	Errors     []SalesforceError
+	// Body is the raw response body, for APIs that return more than errors
+	// with a failure status, such as invocable actions
+	Body []byte
 }
 
 // SalesforceError represents a single error from the Salesforce API
@@ -85,6 +90,8 @@ func (e *APIError) Unwrap() error {
 		return ErrBadRequest
 	case http.StatusTooManyRequests:
 		return ErrRateLimited
+	case http.StatusPreconditionFailed:
+		return ErrPreconditionFailed
 	default:
 		if e.StatusCode >= 500 {
 			return ErrServerError
@@ -118,6 +125,17 @@ func IsRateLimited(err error) bool {
 	return errors.Is(err, ErrRateLimited)
 }
 
+// IsPreconditionFailed returns true if the error indicates that a conditional
+// request's precondition did not hold
+func IsPreconditionFailed(err error) bool {
+	return errors.Is(err, ErrPreconditionFailed)
+}
+
+// IsNotModified returns true if a conditional GET found the record unchanged
+func IsNotModified(err error) bool {
+	return errors.Is(err, ErrNotModified)
+}
+
 // IsServerError returns true if the error indicates a server error
 func IsServerError(err error) bool {
 	return errors.Is(err, ErrServerError)
@@ -143,6 +161,7 @@ func ParseAPIError(resp *http.Response) error {
 
 	apiErr := &APIError{
 		StatusCode: resp.StatusCode,
+		Body:       body,
 	}
 
 	// Try to parse as Salesforce error array
@@ -218,6 +218,7 @@ func TestParseAPIError(t *testing.T) {
 			apiErr, ok := err.(*APIError)
 			assert.True(t, ok)
 			assert.Equal(t, tt.statusCode, apiErr.StatusCode)
+			assert.Equal(t, tt.body, string(apiErr.Body))
 			assert.Len(t, apiErr.Errors, tt.expectErrors)
 			if tt.expectErrors > 0 {
 				assert.Equal(t, tt.expectCode, apiErr.Errors[0].ErrorCode)
@@ -0,0 +1,67 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+)
+
+// QueryPlan is one way Salesforce could run a query, as returned by
+// Explain.
+type QueryPlan struct {
+	// Cardinality is the estimated number of records the plan returns
+	Cardinality int64 `json:"cardinality"`
+	// Fields are the indexed fields the plan uses, if any
+	Fields []string `json:"fields"`
+	// LeadingOperationType is the primary operation: Index, Other,
+	// Sharing, or TableScan
+	LeadingOperationType string `json:"leadingOperationType"`
+	// Notes explain why an index could not be used
+	Notes []QueryPlanNote `json:"notes"`
+	// RelativeCost is the plan's cost relative to the query optimizer's
+	// selectivity threshold; above 1 the query is not selective
+	RelativeCost float64 `json:"relativeCost"`
+	// SObjectCardinality is the approximate number of records of the object
+	SObjectCardinality int64 `json:"sobjectCardinality"`
+	// SObjectType is the object the plan queries
+	SObjectType string `json:"sobjectType"`
+}
+
+// QueryPlanNote is a note on a query plan, e.g. that a filter field is not
+// indexed.
+type QueryPlanNote struct {
+	Description   string   `json:"description"`
+	Fields        []string `json:"fields"`
+	TableEnumOrID string   `json:"tableEnumOrId"`
+}
+
+// ExplainResult is the response of Explain.
+type ExplainResult struct {
+	// Plans are ordered from the lowest relative cost, the plan the
+	// optimizer would choose, to the highest
+	Plans       []QueryPlan `json:"plans"`
+	SourceQuery string      `json:"sourceQuery,omitempty"`
+}
+
+// Selective reports whether the optimizer's chosen plan is under the
+// selectivity threshold.
+func (r *ExplainResult) Selective() bool {
+	return len(r.Plans) == 0 || r.Plans[0].RelativeCost <= 1
+}
+
+// Explain returns the query plans Salesforce considers for a SOQL query,
+// without running it.
+func (c *Client) Explain(ctx context.Context, soql string) (*ExplainResult, error) {
+	body, err := c.Get(ctx, "/query?explain="+url.QueryEscape(soql))
+	if err != nil {
+		return nil, err
+	}
+
+	var result ExplainResult
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse explain result: %w", err)
+	}
+
+	return &result, nil
+}
@@ -0,0 +1,46 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_Explain(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/services/data/v62.0/query", r.URL.Path)
+		assert.Equal(t, "SELECT Id FROM Account WHERE Industry = 'Energy'", r.URL.Query().Get("explain"))
+		assert.Empty(t, r.URL.Query().Get("q"))
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"plans": [
+				{"cardinality": 1200, "fields": [], "leadingOperationType": "TableScan",
+				 "notes": [{"description": "Not considering filter for optimization because unindexed", "fields": ["Industry"], "tableEnumOrId": "Account"}],
+				 "relativeCost": 2.15, "sobjectCardinality": 40000, "sobjectType": "Account"}
+			],
+			"sourceQuery": "SELECT Id FROM Account WHERE Industry = 'Energy'"
+		}`))
+	}))
+	defer server.Close()
+
+	client, err := New(ClientConfig{InstanceURL: server.URL, HTTPClient: server.Client()})
+	require.NoError(t, err)
+
+	result, err := client.Explain(context.Background(), "SELECT Id FROM Account WHERE Industry = 'Energy'")
+	require.NoError(t, err)
+
+	require.Len(t, result.Plans, 1)
+	plan := result.Plans[0]
+	assert.Equal(t, int64(1200), plan.Cardinality)
+	assert.Equal(t, "TableScan", plan.LeadingOperationType)
+	assert.Equal(t, 2.15, plan.RelativeCost)
+	assert.Equal(t, int64(40000), plan.SObjectCardinality)
+	assert.Equal(t, "Account", plan.SObjectType)
+	assert.Equal(t, []string{"Industry"}, plan.Notes[0].Fields)
+	assert.False(t, result.Selective())
+}
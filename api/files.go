@@ -0,0 +1,167 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"os"
+	"path/filepath"
+)
+
+// UploadOptions controls how UploadFile stores and shares a file.
+type UploadOptions struct {
+	// Description is stored on the file's ContentVersion (optional)
+	Description string
+
+	// LinkTo is the ID of a record the file is shared with (optional)
+	LinkTo string
+
+	// ShareType is the permission the linked record's users get: V (viewer),
+	// C (collaborator), or I (inferred from the record); defaults to V
+	ShareType string
+
+	// Visibility is AllUsers or InternalUsers; defaults to AllUsers
+	Visibility string
+}
+
+// UploadResult identifies the records created by UploadFile.
+type UploadResult struct {
+	ContentVersionID      string `json:"contentVersionId"`
+	ContentDocumentID     string `json:"contentDocumentId"`
+	ContentDocumentLinkID string `json:"contentDocumentLinkId,omitempty"`
+}
+
+// UploadFile uploads the file at path as a new ContentVersion, which creates
+// its ContentDocument. The content is streamed as multipart/form-data, so
+// files larger than the base64 JSON limit are supported and never held in
+// memory. The title defaults to the file's name. With opts.LinkTo, the file
+// is then shared with that record through a ContentDocumentLink.
+func (c *Client) UploadFile(ctx context.Context, title, path string, opts UploadOptions) (*UploadResult, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	defer f.Close()
+
+	name := filepath.Base(path)
+	if title == "" {
+		title = name
+	}
+	entity := map[string]interface{}{
+		"Title":        title,
+		"PathOnClient": name,
+	}
+	if opts.Description != "" {
+		entity["Description"] = opts.Description
+	}
+
+	version, err := c.createContentVersion(ctx, entity, name, f)
+	if err != nil {
+		return nil, err
+	}
+
+	rec, err := c.GetRecord(ctx, "ContentVersion", version.ID, []string{"ContentDocumentId"})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get content document of %s: %w", version.ID, err)
+	}
+	result := &UploadResult{
+		ContentVersionID:  version.ID,
+		ContentDocumentID: rec.GetString("ContentDocumentId"),
+	}
+
+	if opts.LinkTo == "" {
+		return result, nil
+	}
+
+	shareType := opts.ShareType
+	if shareType == "" {
+		shareType = "V"
+	}
+	visibility := opts.Visibility
+	if visibility == "" {
+		visibility = "AllUsers"
+	}
+
+	link, err := c.CreateRecord(ctx, "ContentDocumentLink", map[string]interface{}{
+		"ContentDocumentId": result.ContentDocumentID,
+		"LinkedEntityId":    opts.LinkTo,
+		"ShareType":         shareType,
+		"Visibility":        visibility,
+	})
+	if err != nil {
+		return result, fmt.Errorf("uploaded %s but failed to link it to %s: %w", result.ContentDocumentID, opts.LinkTo, err)
+	}
+	result.ContentDocumentLinkID = link.ID
+
+	return result, nil
+}
+
+// createContentVersion posts a ContentVersion with its content as a
+// multipart request: the record fields as JSON in entity_content, followed
+// by the file in VersionData.
+func (c *Client) createContentVersion(ctx context.Context, entity map[string]interface{}, name string, content io.Reader) (*RecordResult, error) {
+	pr, pw := io.Pipe()
+	defer pr.Close()
+
+	mw := multipart.NewWriter(pw)
+	go func() {
+		pw.CloseWithError(writeContentVersionParts(mw, entity, name, content))
+	}()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.buildURL("/sobjects/ContentVersion"), pr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	c.setCallOptions(req)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.Retry.Do(c.HTTPClient, req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+
+	if resp.StatusCode >= 400 {
+		return nil, ParseAPIError(resp)
+	}
+	defer resp.Body.Close()
+
+	var result RecordResult
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to parse create result: %w", err)
+	}
+	if !result.Success || result.ID == "" {
+		return nil, fmt.Errorf("failed to create ContentVersion")
+	}
+
+	return &result, nil
+}
+
+// writeContentVersionParts writes the parts of a ContentVersion upload and
+// closes the multipart writer.
+func writeContentVersionParts(mw *multipart.Writer, entity map[string]interface{}, name string, content io.Reader) error {
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Disposition", `form-data; name="entity_content"`)
+	header.Set("Content-Type", "application/json")
+	part, err := mw.CreatePart(header)
+	if err != nil {
+		return err
+	}
+	if err := json.NewEncoder(part).Encode(entity); err != nil {
+		return err
+	}
+
+	part, err = mw.CreateFormFile("VersionData", name)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(part, content); err != nil {
+		return err
+	}
+
+	return mw.Close()
+}
@@ -0,0 +1,132 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// uploadServer accepts a multipart ContentVersion upload and the requests
+// that follow it, recording what it received.
+type uploadServer struct {
+	entity  map[string]interface{}
+	name    string
+	content []byte
+	link    map[string]interface{}
+}
+
+func (u *uploadServer) start(t *testing.T) *Client {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/services/data/v62.0/sobjects/ContentVersion":
+			mr, err := r.MultipartReader()
+			require.NoError(t, err)
+
+			part, err := mr.NextPart()
+			require.NoError(t, err)
+			assert.Equal(t, "entity_content", part.FormName())
+			assert.Equal(t, "application/json", part.Header.Get("Content-Type"))
+			require.NoError(t, json.NewDecoder(part).Decode(&u.entity))
+
+			part, err = mr.NextPart()
+			require.NoError(t, err)
+			assert.Equal(t, "VersionData", part.FormName())
+			u.name = part.FileName()
+			u.content, err = io.ReadAll(part)
+			require.NoError(t, err)
+
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"id":"068xx0000001","success":true,"errors":[]}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/services/data/v62.0/sobjects/ContentVersion/068xx0000001":
+			assert.Equal(t, "ContentDocumentId", r.URL.Query().Get("fields"))
+			_, _ = w.Write([]byte(`{"attributes":{"type":"ContentVersion"},"Id":"068xx0000001","ContentDocumentId":"069xx0000001"}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/services/data/v62.0/sobjects/ContentDocumentLink/":
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&u.link))
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"id":"06Axx0000001","success":true,"errors":[]}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := New(ClientConfig{InstanceURL: server.URL, HTTPClient: server.Client()})
+	require.NoError(t, err)
+	return client
+}
+
+func TestClient_UploadFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "report.pdf")
+	content := []byte("%PDF-1.7\x00\xff")
+	require.NoError(t, os.WriteFile(path, content, 0644))
+
+	var server uploadServer
+	client := server.start(t)
+
+	result, err := client.UploadFile(context.Background(), "", path, UploadOptions{})
+	require.NoError(t, err)
+
+	assert.Equal(t, &UploadResult{ContentVersionID: "068xx0000001", ContentDocumentID: "069xx0000001"}, result)
+	assert.Equal(t, map[string]interface{}{"Title": "report.pdf", "PathOnClient": "report.pdf"}, server.entity)
+	assert.Equal(t, "report.pdf", server.name)
+	assert.Equal(t, content, server.content)
+	assert.Nil(t, server.link)
+}
+
+func TestClient_UploadFile_LinkTo(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "notes.txt")
+	require.NoError(t, os.WriteFile(path, []byte("hello"), 0644))
+
+	var server uploadServer
+	client := server.start(t)
+
+	result, err := client.UploadFile(context.Background(), "Meeting notes", path, UploadOptions{
+		Description: "Kickoff",
+		LinkTo:      "001xx000003DGbYAAW",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "06Axx0000001", result.ContentDocumentLinkID)
+	assert.Equal(t, "Meeting notes", server.entity["Title"])
+	assert.Equal(t, "Kickoff", server.entity["Description"])
+	assert.Equal(t, map[string]interface{}{
+		"ContentDocumentId": "069xx0000001",
+		"LinkedEntityId":    "001xx000003DGbYAAW",
+		"ShareType":         "V",
+		"Visibility":        "AllUsers",
+	}, server.link)
+}
+
+func TestClient_UploadFile_Error(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "big.bin")
+	require.NoError(t, os.WriteFile(path, make([]byte, 1<<20), 0644))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`[{"errorCode":"STORAGE_LIMIT_EXCEEDED","message":"storage limit exceeded"}]`))
+	}))
+	defer server.Close()
+
+	client, err := New(ClientConfig{InstanceURL: server.URL, HTTPClient: server.Client()})
+	require.NoError(t, err)
+
+	_, err = client.UploadFile(context.Background(), "", path, UploadOptions{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "STORAGE_LIMIT_EXCEEDED")
+
+	_, err = client.UploadFile(context.Background(), "", filepath.Join(t.TempDir(), "missing.bin"), UploadOptions{})
+	assert.ErrorContains(t, err, "failed to open file")
+}
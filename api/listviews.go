@@ -0,0 +1,211 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// ListView is a list view of an object, as returned by ListViews.
+type ListView struct {
+	ID             string `json:"id"`
+	DeveloperName  string `json:"developerName"`
+	Label          string `json:"label"`
+	SOQLCompatible bool   `json:"soqlCompatible"`
+	DescribeURL    string `json:"describeUrl"`
+	ResultsURL     string `json:"resultsUrl"`
+	URL            string `json:"url"`
+}
+
+// listViewsPage is one page of the listviews resource.
+type listViewsPage struct {
+	Done           bool       `json:"done"`
+	ListViews      []ListView `json:"listviews"`
+	NextRecordsURL string     `json:"nextRecordsUrl"`
+}
+
+// ListViewColumn is a column of a list view.
+type ListViewColumn struct {
+	FieldNameOrPath string `json:"fieldNameOrPath"`
+	Label           string `json:"label"`
+	Type            string `json:"type"`
+	Hidden          bool   `json:"hidden"`
+	Sortable        bool   `json:"sortable"`
+}
+
+// ListViewOrder is a sort column of a list view.
+type ListViewOrder struct {
+	FieldNameOrPath string `json:"fieldNameOrPath"`
+	SortDirection   string `json:"sortDirection"`
+	NullsPosition   string `json:"nullsPosition"`
+}
+
+// ListViewDescribe describes a list view: its columns and the SOQL query
+// it runs.
+type ListViewDescribe struct {
+	ID          string           `json:"id"`
+	SObjectType string           `json:"sobjectType"`
+	Query       string           `json:"query"`
+	Scope       string           `json:"scope"`
+	Columns     []ListViewColumn `json:"columns"`
+	OrderBy     []ListViewOrder  `json:"orderBy"`
+}
+
+// ListViewValue is the value of one column of a list view result row.
+// Value is nil for empty fields.
+type ListViewValue struct {
+	FieldNameOrPath string  `json:"fieldNameOrPath"`
+	Value           *string `json:"value"`
+}
+
+// ListViewRecord is a row of list view results.
+type ListViewRecord struct {
+	Columns []ListViewValue `json:"columns"`
+}
+
+// ListViewResults is a page of the records a list view shows.
+type ListViewResults struct {
+	ID            string           `json:"id"`
+	DeveloperName string           `json:"developerName"`
+	Label         string           `json:"label"`
+	Columns       []ListViewColumn `json:"columns"`
+	Records       []ListViewRecord `json:"records"`
+	Size          int              `json:"size"`
+	Done          bool             `json:"done"`
+}
+
+// QueryResult converts the results to the shape of a query result, keyed
+// by field name, with hidden columns other than Id left out. Field values
+// are strings, as list views return them.
+func (r *ListViewResults) QueryResult(objectName string) *QueryResult {
+	hidden := make(map[string]bool, len(r.Columns))
+	for _, col := range r.Columns {
+		hidden[col.FieldNameOrPath] = col.Hidden
+	}
+
+	records := make([]SObject, 0, len(r.Records))
+	for _, row := range r.Records {
+		rec := SObject{
+			Attributes: SObjectAttributes{Type: objectName},
+			Fields:     make(map[string]interface{}, len(row.Columns)),
+		}
+		for _, col := range row.Columns {
+			if col.FieldNameOrPath == "Id" {
+				if col.Value != nil {
+					rec.ID = *col.Value
+				}
+				continue
+			}
+			if hidden[col.FieldNameOrPath] {
+				continue
+			}
+			if col.Value == nil {
+				rec.Fields[col.FieldNameOrPath] = nil
+			} else {
+				rec.Fields[col.FieldNameOrPath] = *col.Value
+			}
+		}
+		records = append(records, rec)
+	}
+
+	return &QueryResult{
+		TotalSize: r.Size,
+		Done:      r.Done,
+		Records:   records,
+	}
+}
+
+// ListViews returns the list views of an object that the user can see.
+func (c *Client) ListViews(ctx context.Context, objectName string) ([]ListView, error) {
+	var views []ListView
+
+	path := fmt.Sprintf("/sobjects/%s/listviews", objectName)
+	for path != "" {
+		body, err := c.Get(ctx, path)
+		if err != nil {
+			return nil, err
+		}
+
+		var page listViewsPage
+		if err := json.Unmarshal(body, &page); err != nil {
+			return nil, fmt.Errorf("failed to parse list views: %w", err)
+		}
+		views = append(views, page.ListViews...)
+
+		if page.Done {
+			break
+		}
+		path = page.NextRecordsURL
+	}
+
+	return views, nil
+}
+
+// FindListView returns the list view of an object with the given ID or
+// developer name.
+func (c *Client) FindListView(ctx context.Context, objectName, idOrName string) (*ListView, error) {
+	views, err := c.ListViews(ctx, objectName)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, view := range views {
+		if view.ID == idOrName || strings.EqualFold(view.DeveloperName, idOrName) {
+			return &views[i], nil
+		}
+		// Accept the 15-character form of an 18-character ID
+		if len(idOrName) == 15 && strings.HasPrefix(view.ID, idOrName) {
+			return &views[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("list view %q not found on %s", idOrName, objectName)
+}
+
+// DescribeListView returns the columns and SOQL query of a list view.
+func (c *Client) DescribeListView(ctx context.Context, objectName, listViewID string) (*ListViewDescribe, error) {
+	body, err := c.Get(ctx, fmt.Sprintf("/sobjects/%s/listviews/%s/describe", objectName, listViewID))
+	if err != nil {
+		return nil, err
+	}
+
+	var desc ListViewDescribe
+	if err := json.Unmarshal(body, &desc); err != nil {
+		return nil, fmt.Errorf("failed to parse list view describe: %w", err)
+	}
+
+	return &desc, nil
+}
+
+// GetListViewResults runs a list view and returns up to limit of its
+// records, skipping the first offset. A limit of zero uses the Salesforce
+// default of 25; the maximum is 2,000.
+func (c *Client) GetListViewResults(ctx context.Context, objectName, listViewID string, limit, offset int) (*ListViewResults, error) {
+	path := fmt.Sprintf("/sobjects/%s/listviews/%s/results", objectName, listViewID)
+
+	params := url.Values{}
+	if limit > 0 {
+		params.Set("limit", strconv.Itoa(limit))
+	}
+	if offset > 0 {
+		params.Set("offset", strconv.Itoa(offset))
+	}
+	if len(params) > 0 {
+		path += "?" + params.Encode()
+	}
+
+	body, err := c.Get(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+
+	var results ListViewResults
+	if err := json.Unmarshal(body, &results); err != nil {
+		return nil, fmt.Errorf("failed to parse list view results: %w", err)
+	}
+
+	return &results, nil
+}
@@ -0,0 +1,75 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_ListViews_Pages(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		assert.Equal(t, "/services/data/v62.0/sobjects/Account/listviews", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("offset") == "" {
+			_, _ = w.Write([]byte(`{"done": false, "nextRecordsUrl": "/services/data/v62.0/sobjects/Account/listviews?offset=1",
+				"listviews": [{"id": "00Bxx0000001abcEAA", "developerName": "AllAccounts"}]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"done": true, "listviews": [{"id": "00Bxx0000002abcEAA", "developerName": "MyAccounts"}]}`))
+	}))
+	defer server.Close()
+
+	client, err := New(ClientConfig{InstanceURL: server.URL, HTTPClient: server.Client()})
+	require.NoError(t, err)
+
+	views, err := client.ListViews(context.Background(), "Account")
+	require.NoError(t, err)
+	assert.Equal(t, 2, calls)
+	require.Len(t, views, 2)
+	assert.Equal(t, "MyAccounts", views[1].DeveloperName)
+
+	view, err := client.FindListView(context.Background(), "Account", "00Bxx0000002abc")
+	require.NoError(t, err)
+	assert.Equal(t, "00Bxx0000002abcEAA", view.ID)
+}
+
+func TestClient_GetListViewResults(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/services/data/v62.0/sobjects/Account/listviews/00Bxx0000001abcEAA/results", r.URL.Path)
+		assert.Equal(t, "limit=100&offset=200", r.URL.RawQuery)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"columns": [
+				{"fieldNameOrPath": "Name", "label": "Account Name", "hidden": false},
+				{"fieldNameOrPath": "Id", "label": "Account ID", "hidden": true},
+				{"fieldNameOrPath": "LastModifiedDate", "label": "Last Modified Date", "hidden": true}
+			],
+			"done": false,
+			"records": [{"columns": [
+				{"fieldNameOrPath": "Name", "value": "Acme"},
+				{"fieldNameOrPath": "Id", "value": "001xx000003DGbYAAW"},
+				{"fieldNameOrPath": "LastModifiedDate", "value": "2024-01-15"}
+			]}],
+			"size": 1
+		}`))
+	}))
+	defer server.Close()
+
+	client, err := New(ClientConfig{InstanceURL: server.URL, HTTPClient: server.Client()})
+	require.NoError(t, err)
+
+	results, err := client.GetListViewResults(context.Background(), "Account", "00Bxx0000001abcEAA", 100, 200)
+	require.NoError(t, err)
+
+	result := results.QueryResult("Account")
+	assert.False(t, result.Done)
+	require.Len(t, result.Records, 1)
+	assert.Equal(t, "001xx000003DGbYAAW", result.Records[0].ID)
+	assert.Equal(t, map[string]interface{}{"Name": "Acme"}, result.Records[0].Fields)
+}
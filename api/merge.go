@@ -0,0 +1,191 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"golang.org/x/oauth2"
+)
+
+// MaxMergeRecords is the maximum number of duplicate records that can be
+// merged into a master record in a single merge (three records in total).
+const MaxMergeRecords = 2
+
+// mergeableObjects lists the object types that support merge.
+var mergeableObjects = map[string]bool{
+	"Account": true,
+	"Contact": true,
+	"Lead":    true,
+}
+
+// Merge validation errors
+var (
+	ErrMergeNotSupported = errors.New("merge is only supported for Account, Contact, and Lead")
+	ErrMergeRecordCount  = fmt.Errorf("merge requires 1 to %d records to merge into the master", MaxMergeRecords)
+)
+
+// MergeResult represents the result of merging records
+type MergeResult struct {
+	ID                string   `json:"id"`
+	Success           bool     `json:"success"`
+	MergedRecordIDs   []string `json:"mergedRecordIds"`
+	UpdatedRelatedIDs []string `json:"updatedRelatedIds"`
+}
+
+// IsMergeable returns true if records of the object type can be merged
+func IsMergeable(objectName string) bool {
+	return mergeableObjects[objectName]
+}
+
+// MergeRecords merges duplicate records into a master record. The merged
+// records are deleted and their related records are re-parented to the master.
+// The REST API has no merge resource, so this uses the SOAP API merge call.
+func (c *Client) MergeRecords(ctx context.Context, objectName, masterID string, mergeIDs []string) (*MergeResult, error) {
+	if !IsMergeable(objectName) {
+		return nil, fmt.Errorf("%w: %s", ErrMergeNotSupported, objectName)
+	}
+	if len(mergeIDs) == 0 || len(mergeIDs) > MaxMergeRecords {
+		return nil, ErrMergeRecordCount
+	}
+
+	sessionID, err := c.sessionID()
+	if err != nil {
+		return nil, err
+	}
+
+	var ids strings.Builder
+	for _, id := range mergeIDs {
+		ids.WriteString("<urn:recordToMergeIds>")
+		_ = xml.EscapeText(&ids, []byte(id))
+		ids.WriteString("</urn:recordToMergeIds>")
+	}
+
+	var envelope bytes.Buffer
+	envelope.WriteString(`<?xml version="1.0" encoding="UTF-8"?>`)
+	envelope.WriteString(`<soapenv:Envelope xmlns:soapenv="http://schemas.xmlsoap.org/soap/envelope/" xmlns:urn="urn:partner.soap.sforce.com" xmlns:sobj="urn:sobject.partner.soap.sforce.com" xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance">`)
+	envelope.WriteString(`<soapenv:Header><urn:SessionHeader><urn:sessionId>`)
+	_ = xml.EscapeText(&envelope, []byte(sessionID))
+	envelope.WriteString(`</urn:sessionId></urn:SessionHeader></soapenv:Header>`)
+	envelope.WriteString(`<soapenv:Body><urn:merge><urn:request>`)
+	fmt.Fprintf(&envelope, `<urn:masterRecord xsi:type="sobj:%s"><sobj:type>%s</sobj:type><sobj:Id>`, objectName, objectName)
+	_ = xml.EscapeText(&envelope, []byte(masterID))
+	envelope.WriteString(`</sobj:Id></urn:masterRecord>`)
+	envelope.WriteString(ids.String())
+	envelope.WriteString(`</urn:request></urn:merge></soapenv:Body></soapenv:Envelope>`)
+
+	body, statusCode, err := c.doSOAPRequest(ctx, "merge", envelope.Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		Fault *struct {
+			Code   string `xml:"faultcode"`
+			String string `xml:"faultstring"`
+		} `xml:"Body>Fault"`
+		Result *struct {
+			ID                string   `xml:"id"`
+			Success           bool     `xml:"success"`
+			MergedRecordIDs   []string `xml:"mergedRecordIds"`
+			UpdatedRelatedIDs []string `xml:"updatedRelatedIds"`
+			Errors            []struct {
+				StatusCode string   `xml:"statusCode"`
+				Message    string   `xml:"message"`
+				Fields     []string `xml:"fields"`
+			} `xml:"errors"`
+		} `xml:"Body>mergeResponse>result"`
+	}
+	if err := xml.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse merge response: %w", err)
+	}
+
+	if resp.Fault != nil {
+		code := resp.Fault.Code
+		if idx := strings.Index(code, ":"); idx >= 0 {
+			code = code[idx+1:]
+		}
+		if code == "INVALID_SESSION_ID" && statusCode < http.StatusBadRequest {
+			statusCode = http.StatusUnauthorized
+		}
+		return nil, &APIError{
+			StatusCode: statusCode,
+			Errors:     []SalesforceError{{ErrorCode: code, Message: resp.Fault.String}},
+		}
+	}
+
+	if resp.Result == nil {
+		return nil, fmt.Errorf("merge response did not include a result")
+	}
+
+	if !resp.Result.Success {
+		apiErr := &APIError{StatusCode: http.StatusBadRequest}
+		for _, e := range resp.Result.Errors {
+			apiErr.Errors = append(apiErr.Errors, SalesforceError{
+				ErrorCode: e.StatusCode,
+				Message:   e.Message,
+				Fields:    e.Fields,
+			})
+		}
+		return nil, apiErr
+	}
+
+	return &MergeResult{
+		ID:                resp.Result.ID,
+		Success:           resp.Result.Success,
+		MergedRecordIDs:   resp.Result.MergedRecordIDs,
+		UpdatedRelatedIDs: resp.Result.UpdatedRelatedIDs,
+	}, nil
+}
+
+// doSOAPRequest posts a SOAP envelope to the partner endpoint and returns the
+// response body. SOAP faults are returned in the body with a 500 status, so
+// the status code is returned for the caller to interpret.
+func (c *Client) doSOAPRequest(ctx context.Context, action string, envelope []byte) ([]byte, int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.SOAPURL(), bytes.NewReader(envelope))
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "text/xml; charset=UTF-8")
+	req.Header.Set("SOAPAction", action)
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, 0, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	return body, resp.StatusCode, nil
+}
+
+// SOAPURL returns the partner SOAP API endpoint.
+func (c *Client) SOAPURL() string {
+	return fmt.Sprintf("%s/services/Soap/u/%s", c.InstanceURL, strings.TrimPrefix(c.APIVersion, "v"))
+}
+
+// sessionID returns the current OAuth access token, which the SOAP API
+// expects in the SessionHeader rather than the Authorization header.
+func (c *Client) sessionID() (string, error) {
+	transport, ok := baseTransport(c.HTTPClient.Transport).(*oauth2.Transport)
+	if !ok || transport.Source == nil {
+		return "", fmt.Errorf("SOAP API calls require an OAuth-authenticated HTTP client")
+	}
+
+	tok, err := transport.Source.Token()
+	if err != nil {
+		return "", fmt.Errorf("failed to get access token: %w", err)
+	}
+
+	return tok.AccessToken, nil
+}
@@ -0,0 +1,113 @@
+package api
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+)
+
+func newSOAPTestClient(t *testing.T, server *httptest.Server) *Client {
+	t.Helper()
+
+	ctx := context.WithValue(context.Background(), oauth2.HTTPClient, server.Client())
+	httpClient := oauth2.NewClient(ctx, oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "00Dxx!session"}))
+
+	client, err := New(ClientConfig{
+		InstanceURL: server.URL,
+		HTTPClient:  httpClient,
+	})
+	require.NoError(t, err)
+	return client
+}
+
+func TestClient_MergeRecords(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/services/Soap/u/62.0", r.URL.Path)
+		assert.Equal(t, "merge", r.Header.Get("SOAPAction"))
+
+		body, _ := io.ReadAll(r.Body)
+		assert.Contains(t, string(body), "<urn:sessionId>00Dxx!session</urn:sessionId>")
+		assert.Contains(t, string(body), "<sobj:Id>001xx000001</sobj:Id>")
+		assert.Contains(t, string(body), "<urn:recordToMergeIds>001xx000002</urn:recordToMergeIds>")
+
+		w.Header().Set("Content-Type", "text/xml")
+		_, _ = w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?>
+<soapenv:Envelope xmlns:soapenv="http://schemas.xmlsoap.org/soap/envelope/" xmlns="urn:partner.soap.sforce.com">
+<soapenv:Body><mergeResponse><result>
+<id>001xx000001</id>
+<mergedRecordIds>001xx000002</mergedRecordIds>
+<success>true</success>
+<updatedRelatedIds>003xx000001</updatedRelatedIds>
+<updatedRelatedIds>006xx000001</updatedRelatedIds>
+</result></mergeResponse></soapenv:Body></soapenv:Envelope>`))
+	}))
+	defer server.Close()
+
+	client := newSOAPTestClient(t, server)
+
+	result, err := client.MergeRecords(context.Background(), "Account", "001xx000001", []string{"001xx000002"})
+	require.NoError(t, err)
+
+	assert.True(t, result.Success)
+	assert.Equal(t, "001xx000001", result.ID)
+	assert.Equal(t, []string{"001xx000002"}, result.MergedRecordIDs)
+	assert.Equal(t, []string{"003xx000001", "006xx000001"}, result.UpdatedRelatedIDs)
+}
+
+func TestClient_MergeRecords_Errors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/xml")
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?>
+<soapenv:Envelope xmlns:soapenv="http://schemas.xmlsoap.org/soap/envelope/" xmlns:sf="urn:fault.partner.soap.sforce.com">
+<soapenv:Body><soapenv:Fault>
+<faultcode>sf:INVALID_ID_FIELD</faultcode>
+<faultstring>INVALID_ID_FIELD: invalid record id</faultstring>
+</soapenv:Fault></soapenv:Body></soapenv:Envelope>`))
+	}))
+	defer server.Close()
+
+	client := newSOAPTestClient(t, server)
+
+	tests := []struct {
+		name     string
+		object   string
+		mergeIDs []string
+		wantErr  error
+		wantMsg  string
+	}{
+		{"unsupported object", "Opportunity", []string{"006xx000002"}, ErrMergeNotSupported, ""},
+		{"no duplicates", "Account", nil, ErrMergeRecordCount, ""},
+		{"too many duplicates", "Account", []string{"a", "b", "c"}, ErrMergeRecordCount, ""},
+		{"soap fault", "Account", []string{"bad"}, ErrServerError, "INVALID_ID_FIELD"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := client.MergeRecords(context.Background(), tt.object, "001xx000001", tt.mergeIDs)
+			require.Error(t, err)
+			assert.ErrorIs(t, err, tt.wantErr)
+			if tt.wantMsg != "" {
+				assert.Contains(t, err.Error(), tt.wantMsg)
+			}
+		})
+	}
+}
+
+func TestClient_MergeRecords_RequiresOAuth(t *testing.T) {
+	client, err := New(ClientConfig{
+		InstanceURL: "https://test.salesforce.com",
+		HTTPClient:  http.DefaultClient,
+	})
+	require.NoError(t, err)
+
+	_, err = client.MergeRecords(context.Background(), "Lead", "00Qxx000001", []string{"00Qxx000002"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "OAuth")
+}
@@ -6,6 +6,7 @@ import (
 	"context"
 	"encoding/base64"
 	"encoding/json"
+	"encoding/xml"
 	"fmt"
 	"io"
 	"net/http"
@@ -13,6 +14,10 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
+
+	"github.com/open-cli-collective/salesforce-cli/api"
+	"github.com/open-cli-collective/salesforce-cli/api/soql"
 )
 
 // DefaultAPIVersion is the default Salesforce API version.
@@ -24,6 +29,8 @@ type Client struct {
 	instanceURL string
 	apiVersion  string
 	baseURL     string
+	retry       api.RetryPolicy
+	responses   *api.ResponseRecorder
 }
 
 // ClientConfig contains configuration for creating a new Metadata API client.
@@ -31,6 +38,23 @@ type ClientConfig struct {
 	InstanceURL string
 	HTTPClient  *http.Client
 	APIVersion  string
+	// Retry controls how requests failing with transient errors are retried
+	// (optional, defaults to a single attempt)
+	Retry api.RetryPolicy
+	// Middleware wraps the HTTP client's transport, first outermost
+	// (optional)
+	Middleware []api.Middleware
+	// Compression controls gzip compression of request and response bodies
+	// (optional, defaults to none beyond what the transport does itself)
+	Compression api.Compression
+
+	// Timeout fails a request attempt that makes no progress for this long
+	// (optional, defaults to none)
+	Timeout time.Duration
+
+	// RateLimiter limits how often requests are sent; share one between
+	// clients to limit them together (optional, defaults to no limit)
+	RateLimiter *api.RateLimiter
 }
 
 // New creates a new Metadata API client.
@@ -48,14 +72,38 @@ func New(cfg ClientConfig) (*Client, error) {
 		apiVersion = DefaultAPIVersion
 	}
 
+	// Responses are recorded outermost, as returned; compression and the
+	// timeout go innermost, to see the bytes on the wire
+	responses := &api.ResponseRecorder{}
+	middleware := api.WithResponseRecorder(cfg.Middleware, responses)
+	middleware = api.WithRateLimit(middleware, cfg.RateLimiter)
+	middleware = api.WithCompression(middleware, cfg.Compression)
+	middleware = api.WithTimeout(middleware, cfg.Timeout)
+
 	return &Client{
-		httpClient:  cfg.HTTPClient,
+		httpClient:  api.WrapHTTPClient(cfg.HTTPClient, middleware...),
 		instanceURL: instanceURL,
 		apiVersion:  apiVersion,
 		baseURL:     fmt.Sprintf("%s/services/data/%s", instanceURL, apiVersion),
+		retry:       cfg.Retry,
+		responses:   responses,
 	}, nil
 }
 
+// LastResponse returns the metadata of the last Metadata API response, or nil if
+// there has been none, as api.Client.LastResponse does.
+func (c *Client) LastResponse() *api.ResponseInfo {
+	return c.responses.Last()
+}
+
+// ResourceURL returns the full URL that a request for path is sent to.
+func (c *Client) ResourceURL(path string) string {
+	if strings.HasPrefix(path, "http") {
+		return path
+	}
+	return c.baseURL + path
+}
+
 // doRequest performs an HTTP request and returns the response body.
 func (c *Client) doRequest(ctx context.Context, method, path string, body interface{}) ([]byte, error) {
 	var bodyReader io.Reader
@@ -67,12 +115,7 @@ func (c *Client) doRequest(ctx context.Context, method, path string, body interf
 		bodyReader = bytes.NewReader(jsonBody)
 	}
 
-	fullURL := path
-	if !strings.HasPrefix(path, "http") {
-		fullURL = c.baseURL + path
-	}
-
-	req, err := http.NewRequestWithContext(ctx, method, fullURL, bodyReader)
+	req, err := http.NewRequestWithContext(ctx, method, c.ResourceURL(path), bodyReader)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -80,7 +123,7 @@ func (c *Client) doRequest(ctx context.Context, method, path string, body interf
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Accept", "application/json")
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.retry.Do(c.httpClient, req)
 	if err != nil {
 		return nil, fmt.Errorf("request failed: %w", err)
 	}
@@ -320,6 +363,77 @@ func CreateZipFromDirectory(sourceDir string) ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
+// metadataNamespace is the XML namespace of metadata manifests.
+const metadataNamespace = "http://soap.sforce.com/2006/04/metadata"
+
+// packageXML is the XML form of a package.xml or destructiveChanges.xml
+// manifest.
+type packageXML struct {
+	XMLName xml.Name         `xml:"Package"`
+	XMLNS   string           `xml:"xmlns,attr"`
+	Types   []packageTypeXML `xml:"types"`
+	Version string           `xml:"version,omitempty"`
+}
+
+type packageTypeXML struct {
+	Members []string `xml:"members"`
+	Name    string   `xml:"name"`
+}
+
+// CreateDestructiveZip creates a deployment package that deletes the
+// components of types: an empty package.xml for apiVersion (e.g. "62.0")
+// and a destructiveChanges.xml listing them.
+func CreateDestructiveZip(types []PackageType, apiVersion string) ([]byte, error) {
+	buf := new(bytes.Buffer)
+	zipWriter := zip.NewWriter(buf)
+
+	destructive := packageXML{XMLNS: metadataNamespace}
+	for _, t := range types {
+		destructive.Types = append(destructive.Types, packageTypeXML{Members: t.Members, Name: t.Name})
+	}
+	manifests := []struct {
+		name string
+		pkg  packageXML
+	}{
+		{"package.xml", packageXML{XMLNS: metadataNamespace, Version: apiVersion}},
+		{"destructiveChanges.xml", destructive},
+	}
+
+	for _, m := range manifests {
+		data, err := xml.MarshalIndent(m.pkg, "", "    ")
+		if err != nil {
+			return nil, err
+		}
+		writer, err := zipWriter.Create(m.name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := io.WriteString(writer, xml.Header); err != nil {
+			return nil, err
+		}
+		if _, err := writer.Write(data); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := zipWriter.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// DeployDestructiveChanges deploys a package deleting the components of
+// types, which is how metadata such as Apex classes is deleted from
+// production orgs.
+func (c *Client) DeployDestructiveChanges(ctx context.Context, types []PackageType, options DeployOptions) (*DeployResult, error) {
+	zipData, err := CreateDestructiveZip(types, strings.TrimPrefix(c.apiVersion, "v"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create deployment package: %w", err)
+	}
+	return c.Deploy(ctx, zipData, options)
+}
+
 // ExtractZipToDirectory extracts a zip file to a directory.
 func ExtractZipToDirectory(zipData []byte, destDir string) error {
 	reader, err := zip.NewReader(bytes.NewReader(zipData), int64(len(zipData)))
@@ -375,21 +489,26 @@ func extractFile(file *zip.File, destPath string) error {
 // Retrieve retrieves metadata from the org using the Tooling API.
 // For complex retrieves with package.xml, use the official Salesforce CLI.
 func (c *Client) Retrieve(ctx context.Context, metadataType, componentName string) ([]byte, error) {
-	var soql string
+	var contentField string
 	switch metadataType {
-	case "ApexClass":
-		soql = fmt.Sprintf("SELECT Id, Name, Body FROM ApexClass WHERE Name = '%s'", componentName)
-	case "ApexTrigger":
-		soql = fmt.Sprintf("SELECT Id, Name, Body FROM ApexTrigger WHERE Name = '%s'", componentName)
-	case "ApexPage":
-		soql = fmt.Sprintf("SELECT Id, Name, Markup FROM ApexPage WHERE Name = '%s'", componentName)
-	case "ApexComponent":
-		soql = fmt.Sprintf("SELECT Id, Name, Markup FROM ApexComponent WHERE Name = '%s'", componentName)
+	case "ApexClass", "ApexTrigger":
+		contentField = "Body"
+	case "ApexPage", "ApexComponent":
+		contentField = "Markup"
 	default:
 		return nil, fmt.Errorf("direct retrieve not supported for type: %s (use sf CLI for complex retrieves)", metadataType)
 	}
 
-	path := fmt.Sprintf("/tooling/query?q=%s", url.QueryEscape(soql))
+	q, err := soql.Select("Id", "Name", contentField).
+		From(metadataType).
+		Where("Name = :name").
+		Bind("name", componentName).
+		Build()
+	if err != nil {
+		return nil, err
+	}
+
+	path := fmt.Sprintf("/tooling/query?q=%s", url.QueryEscape(q))
 	body, err := c.Get(ctx, path)
 	if err != nil {
 		return nil, err
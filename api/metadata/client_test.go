@@ -5,6 +5,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"os"
@@ -318,6 +319,32 @@ func TestCreateZipFromDirectory(t *testing.T) {
 	assert.Contains(t, fileNames, "classes/MyClass.cls-meta.xml")
 }
 
+func TestCreateDestructiveZip(t *testing.T) {
+	zipData, err := CreateDestructiveZip([]PackageType{{Name: "ApexClass", Members: []string{"OldClass"}}}, "62.0")
+	require.NoError(t, err)
+
+	reader, err := zip.NewReader(bytes.NewReader(zipData), int64(len(zipData)))
+	require.NoError(t, err)
+
+	files := make(map[string]string)
+	for _, f := range reader.File {
+		rc, err := f.Open()
+		require.NoError(t, err)
+		data, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		_ = rc.Close()
+		files[f.Name] = string(data)
+	}
+
+	require.Len(t, files, 2)
+	assert.Contains(t, files["package.xml"], `<Package xmlns="http://soap.sforce.com/2006/04/metadata">`)
+	assert.Contains(t, files["package.xml"], "<version>62.0</version>")
+	assert.NotContains(t, files["package.xml"], "<types>")
+	assert.Contains(t, files["destructiveChanges.xml"], "<members>OldClass</members>")
+	assert.Contains(t, files["destructiveChanges.xml"], "<name>ApexClass</name>")
+	assert.NotContains(t, files["destructiveChanges.xml"], "<version>")
+}
+
 func TestExtractZipToDirectory(t *testing.T) {
 	// Create a test zip
 	buf := new(bytes.Buffer)
@@ -0,0 +1,82 @@
+package api
+
+import "net/http"
+
+// Middleware wraps the transport of an API client, e.g. to log requests,
+// record metrics, or add headers. It is called for every attempt of a
+// request, including retries.
+type Middleware func(next http.RoundTripper) http.RoundTripper
+
+// RoundTripperFunc adapts an ordinary function to an http.RoundTripper.
+type RoundTripperFunc func(*http.Request) (*http.Response, error)
+
+// RoundTrip calls f(req).
+func (f RoundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// Hooks returns a Middleware calling before ahead of each request and after
+// once it completes. Either may be nil.
+//
+// before gets a copy of the request that it may modify, e.g. to set headers;
+// returning an error fails the request without sending it. after gets the
+// request as sent and its response or error.
+func Hooks(before func(*http.Request) error, after func(*http.Request, *http.Response, error)) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			if before != nil {
+				req = req.Clone(req.Context())
+				if err := before(req); err != nil {
+					return nil, err
+				}
+			}
+
+			resp, err := next.RoundTrip(req)
+			if after != nil {
+				after(req, resp, err)
+			}
+			return resp, err
+		})
+	}
+}
+
+// WrapHTTPClient returns a copy of client whose transport is wrapped in
+// middleware, the first being outermost, or client itself if there is none.
+// Requests pass through the middleware before client's own transport, so
+// headers it adds, such as Authorization, are not yet set.
+func WrapHTTPClient(client *http.Client, middleware ...Middleware) *http.Client {
+	if len(middleware) == 0 {
+		return client
+	}
+
+	base := client.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	transport := base
+	for i := len(middleware) - 1; i >= 0; i-- {
+		transport = middleware[i](transport)
+	}
+
+	wrapped := *client
+	wrapped.Transport = &middlewareTransport{RoundTripper: transport, base: base}
+	return &wrapped
+}
+
+// middlewareTransport is a transport wrapped in middleware. It keeps the
+// original transport so that the OAuth token source can still be found.
+type middlewareTransport struct {
+	http.RoundTripper
+	base http.RoundTripper
+}
+
+// baseTransport returns the transport underneath any middleware.
+func baseTransport(rt http.RoundTripper) http.RoundTripper {
+	for {
+		wrapped, ok := rt.(*middlewareTransport)
+		if !ok {
+			return rt
+		}
+		rt = wrapped.base
+	}
+}
@@ -0,0 +1,106 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+)
+
+func TestClient_Middleware(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "abc123", r.Header.Get("X-Request-Id"))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	var statuses []int
+	client, err := New(ClientConfig{
+		InstanceURL: server.URL,
+		HTTPClient:  server.Client(),
+		Middleware: []Middleware{
+			Hooks(func(req *http.Request) error {
+				req.Header.Set("X-Request-Id", "abc123")
+				return nil
+			}, func(req *http.Request, resp *http.Response, err error) {
+				require.NoError(t, err)
+				assert.Equal(t, "abc123", req.Header.Get("X-Request-Id"))
+				statuses = append(statuses, resp.StatusCode)
+			}),
+		},
+	})
+	require.NoError(t, err)
+
+	_, err = client.Get(context.Background(), "/limits")
+	require.NoError(t, err)
+	assert.Equal(t, []int{http.StatusOK}, statuses)
+}
+
+func TestHooks_BeforeError(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+	}))
+	defer server.Close()
+
+	errBlocked := errors.New("blocked")
+	httpClient := WrapHTTPClient(server.Client(), Hooks(func(*http.Request) error {
+		return errBlocked
+	}, nil))
+
+	req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+
+	_, err = httpClient.Do(req)
+	assert.ErrorIs(t, err, errBlocked)
+	assert.Equal(t, 0, calls)
+}
+
+func TestWrapHTTPClient_Order(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	var order []string
+	record := func(name string) Middleware {
+		return func(next http.RoundTripper) http.RoundTripper {
+			return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				order = append(order, name+" before")
+				resp, err := next.RoundTrip(req)
+				order = append(order, name+" after")
+				return resp, err
+			})
+		}
+	}
+
+	original := server.Client()
+	httpClient := WrapHTTPClient(original, record("outer"), record("inner"))
+	assert.NotSame(t, original, httpClient, "the original client should not be modified")
+
+	resp, err := httpClient.Get(server.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	assert.Equal(t, []string{"outer before", "inner before", "inner after", "outer after"}, order)
+	assert.Same(t, original, WrapHTTPClient(original))
+}
+
+func TestWrapHTTPClient_KeepsTokenSource(t *testing.T) {
+	httpClient := oauth2.NewClient(context.Background(), oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "token123"}))
+
+	client, err := New(ClientConfig{
+		InstanceURL: "https://test.salesforce.com",
+		HTTPClient:  httpClient,
+		Middleware:  []Middleware{Hooks(nil, nil)},
+	})
+	require.NoError(t, err)
+
+	sessionID, err := client.sessionID()
+	require.NoError(t, err)
+	assert.Equal(t, "token123", sessionID)
+}
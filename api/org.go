@@ -0,0 +1,75 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// OrgInfo describes the org a client is connected to.
+type OrgInfo struct {
+	ID               string `json:"id"`
+	Name             string `json:"name"`
+	IsSandbox        bool   `json:"isSandbox"`
+	OrganizationType string `json:"organizationType,omitempty"`
+	DefaultCurrency  string `json:"defaultCurrencyIsoCode,omitempty"`
+}
+
+// GetOrgInfo returns the name, ID, sandbox status, and default currency of
+// the org.
+func (c *Client) GetOrgInfo(ctx context.Context) (*OrgInfo, error) {
+	result, err := c.Query(ctx, "SELECT Id, Name, IsSandbox, OrganizationType, DefaultCurrencyIsoCode FROM Organization")
+	if err != nil {
+		return nil, err
+	}
+
+	if len(result.Records) == 0 {
+		return nil, fmt.Errorf("organization record not found")
+	}
+
+	rec := result.Records[0]
+	info := &OrgInfo{ID: rec.ID}
+	if v, ok := rec.Fields["Name"].(string); ok {
+		info.Name = v
+	}
+	if v, ok := rec.Fields["IsSandbox"].(bool); ok {
+		info.IsSandbox = v
+	}
+	if v, ok := rec.Fields["OrganizationType"].(string); ok {
+		info.OrganizationType = v
+	}
+	if v, ok := rec.Fields["DefaultCurrencyIsoCode"].(string); ok {
+		info.DefaultCurrency = v
+	}
+
+	return info, nil
+}
+
+// UserInfo describes the logged-in user, as returned by the OAuth userinfo
+// endpoint of the Identity API.
+type UserInfo struct {
+	UserID            string `json:"user_id"`
+	OrganizationID    string `json:"organization_id"`
+	PreferredUsername string `json:"preferred_username"`
+	Name              string `json:"name"`
+	Email             string `json:"email"`
+	Locale            string `json:"locale"`
+	Language          string `json:"language"`
+	ZoneInfo          string `json:"zoneinfo"`
+	UserType          string `json:"user_type"`
+}
+
+// GetUserInfo returns the identity of the user the client is logged in as.
+func (c *Client) GetUserInfo(ctx context.Context) (*UserInfo, error) {
+	body, err := c.Get(ctx, "/services/oauth2/userinfo")
+	if err != nil {
+		return nil, err
+	}
+
+	var info UserInfo
+	if err := json.Unmarshal(body, &info); err != nil {
+		return nil, fmt.Errorf("failed to parse user info: %w", err)
+	}
+
+	return &info, nil
+}
@@ -0,0 +1,108 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// QueryInto runs a query and decodes every record, across all pages, into a
+// T using its JSON tags. Relationship fields decode into nested structs and
+// subquery results into a struct with a Records slice:
+//
+//	type Contact struct {
+//		ID      string `json:"Id"`
+//		Name    string `json:"Name"`
+//		Account struct {
+//			Name string `json:"Name"`
+//		} `json:"Account"`
+//		CreatedDate api.DateTime `json:"CreatedDate"`
+//	}
+//
+//	contacts, err := api.QueryInto[Contact](ctx, client, "SELECT Id, Name, Account.Name, CreatedDate FROM Contact")
+func QueryInto[T any](ctx context.Context, c *Client, soql string) ([]T, error) {
+	var records []T
+
+	it := c.QueryIterator(ctx, soql)
+	for it.Next() {
+		var rec T
+		if err := it.Record().Decode(&rec); err != nil {
+			return nil, err
+		}
+		records = append(records, rec)
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
+
+// DecodeRecords decodes records, such as the Records of a QueryResult, into
+// a slice of T using its JSON tags.
+func DecodeRecords[T any](records []SObject) ([]T, error) {
+	decoded := make([]T, len(records))
+	for i, rec := range records {
+		if err := rec.Decode(&decoded[i]); err != nil {
+			return nil, err
+		}
+	}
+	return decoded, nil
+}
+
+// Decode unmarshals the record into v, which is usually a pointer to a
+// struct with JSON tags matching the field names.
+func (s SObject) Decode(v interface{}) error {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s record: %w", s.Attributes.Type, err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("failed to decode %s record %s: %w", s.Attributes.Type, s.ID, err)
+	}
+	return nil
+}
+
+// DateTime is a time.Time that decodes Salesforce datetime values, which
+// use a +0000 style offset that time.Time does not accept, as well as
+// RFC 3339 and plain dates.
+type DateTime struct {
+	time.Time
+}
+
+// dateTimeLayouts are the formats Salesforce uses for date and datetime
+// fields.
+var dateTimeLayouts = []string{
+	"2006-01-02T15:04:05.000-0700",
+	time.RFC3339Nano,
+	"2006-01-02",
+}
+
+// UnmarshalJSON parses a Salesforce date or datetime string. null leaves
+// the time zero.
+func (d *DateTime) UnmarshalJSON(data []byte) error {
+	s := string(data)
+	if s == "null" {
+		return nil
+	}
+
+	s = strings.Trim(s, `"`)
+	for _, layout := range dateTimeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			d.Time = t
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid datetime %q", s)
+}
+
+// MarshalJSON formats the time the way Salesforce does, or null if it is
+// zero.
+func (d DateTime) MarshalJSON() ([]byte, error) {
+	if d.IsZero() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(d.UTC().Format("2006-01-02T15:04:05.000-0700"))
+}
@@ -0,0 +1,111 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testContact struct {
+	ID      string `json:"Id"`
+	Name    string `json:"Name"`
+	Account struct {
+		Name string `json:"Name"`
+	} `json:"Account"`
+	Cases struct {
+		Records []struct {
+			Subject string `json:"Subject"`
+		} `json:"records"`
+	} `json:"Cases"`
+	Score       float64  `json:"Score__c"`
+	CreatedDate DateTime `json:"CreatedDate"`
+}
+
+func TestQueryInto(t *testing.T) {
+	server, _ := pagedQueryServer(t,
+		[]SObject{{
+			Attributes: SObjectAttributes{Type: "Contact"},
+			ID:         "003000000000001",
+			Fields: map[string]interface{}{
+				"Name":        "Ada Lovelace",
+				"Account":     map[string]interface{}{"attributes": map[string]interface{}{"type": "Account"}, "Name": "Acme"},
+				"Cases":       map[string]interface{}{"totalSize": 1, "done": true, "records": []interface{}{map[string]interface{}{"Subject": "Broken"}}},
+				"Score__c":    4.5,
+				"CreatedDate": "2024-01-15T10:30:00.000+0000",
+			},
+		}},
+		[]SObject{{
+			Attributes: SObjectAttributes{Type: "Contact"},
+			ID:         "003000000000002",
+			Fields: map[string]interface{}{
+				"Name":        "Grace Hopper",
+				"Account":     nil,
+				"Cases":       nil,
+				"Score__c":    nil,
+				"CreatedDate": nil,
+			},
+		}},
+	)
+
+	client, err := New(ClientConfig{InstanceURL: server.URL, HTTPClient: server.Client()})
+	require.NoError(t, err)
+
+	contacts, err := QueryInto[testContact](context.Background(), client, "SELECT Id, Name, Account.Name FROM Contact")
+	require.NoError(t, err)
+	require.Len(t, contacts, 2)
+
+	assert.Equal(t, "003000000000001", contacts[0].ID)
+	assert.Equal(t, "Ada Lovelace", contacts[0].Name)
+	assert.Equal(t, "Acme", contacts[0].Account.Name)
+	require.Len(t, contacts[0].Cases.Records, 1)
+	assert.Equal(t, "Broken", contacts[0].Cases.Records[0].Subject)
+	assert.Equal(t, 4.5, contacts[0].Score)
+	assert.True(t, contacts[0].CreatedDate.Equal(time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)))
+
+	assert.Equal(t, "Grace Hopper", contacts[1].Name)
+	assert.Empty(t, contacts[1].Account.Name)
+	assert.True(t, contacts[1].CreatedDate.IsZero())
+}
+
+func TestDecodeRecords_TypeMismatch(t *testing.T) {
+	records := []SObject{{
+		Attributes: SObjectAttributes{Type: "Contact"},
+		ID:         "003000000000001",
+		Fields:     map[string]interface{}{"Name": 42.0},
+	}}
+
+	_, err := DecodeRecords[testContact](records)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to decode Contact record 003000000000001")
+}
+
+func TestDateTime(t *testing.T) {
+	tests := []struct {
+		input string
+		want  time.Time
+	}{
+		{`"2024-01-15T10:30:00.000+0000"`, time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)},
+		{`"2024-01-15T11:30:00+01:00"`, time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)},
+		{`"2024-01-15"`, time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)},
+		{`null`, time.Time{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			var d DateTime
+			require.NoError(t, json.Unmarshal([]byte(tt.input), &d))
+			assert.True(t, tt.want.Equal(d.Time), "got %v", d.Time)
+		})
+	}
+
+	var d DateTime
+	assert.Error(t, json.Unmarshal([]byte(`"yesterday"`), &d))
+
+	data, err := json.Marshal(DateTime{time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)})
+	require.NoError(t, err)
+	assert.Equal(t, `"2024-01-15T10:30:00.000+0000"`, string(data))
+}
@@ -0,0 +1,113 @@
+package api
+
+import "context"
+
+// QueryIterator walks the records of a query one at a time, fetching the
+// next page through nextRecordsUrl only when the current one is used up.
+// Only a single page is held in memory, so it suits queries that return
+// far more records than QueryAll can comfortably collect.
+//
+//	it := client.QueryIterator(ctx, "SELECT Id, Name FROM Account")
+//	for it.Next() {
+//		rec := it.Record()
+//		...
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type QueryIterator struct {
+	ctx       context.Context
+	first     func(ctx context.Context) (*QueryResult, error)
+	queryMore func(ctx context.Context, nextRecordsURL string) (*QueryResult, error)
+
+	page    *QueryResult
+	index   int
+	current SObject
+	err     error
+}
+
+// QueryIterator returns an iterator over all records matching soql. No
+// request is made until the first call to Next.
+func (c *Client) QueryIterator(ctx context.Context, soql string) *QueryIterator {
+	return &QueryIterator{
+		ctx: ctx,
+		first: func(ctx context.Context) (*QueryResult, error) {
+			return c.Query(ctx, soql)
+		},
+		queryMore: c.QueryMore,
+	}
+}
+
+// NewQueryIterator returns an iterator that starts from an already fetched
+// first page and uses queryMore to fetch the pages after it. This lets
+// callers iterate results from other query endpoints, such as /queryAll or
+// the Tooling API, once they are converted to a QueryResult.
+func NewQueryIterator(ctx context.Context, first *QueryResult, queryMore func(ctx context.Context, nextRecordsURL string) (*QueryResult, error)) *QueryIterator {
+	return &QueryIterator{
+		ctx:       ctx,
+		page:      first,
+		queryMore: queryMore,
+	}
+}
+
+// Next advances to the next record, fetching another page if needed. It
+// returns false when there are no more records or a request fails; check
+// Err to tell the two apart.
+func (it *QueryIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+
+	if it.page == nil {
+		if it.first == nil {
+			return false
+		}
+		page, err := it.first(it.ctx)
+		it.first = nil
+		if err != nil {
+			it.err = err
+			return false
+		}
+		it.page = page
+	}
+
+	for it.index >= len(it.page.Records) {
+		if it.page.Done || it.page.NextRecordsURL == "" {
+			return false
+		}
+		if err := it.ctx.Err(); err != nil {
+			it.err = err
+			return false
+		}
+		page, err := it.queryMore(it.ctx, it.page.NextRecordsURL)
+		if err != nil {
+			it.err = err
+			return false
+		}
+		it.page = page
+		it.index = 0
+	}
+
+	it.current = it.page.Records[it.index]
+	it.index++
+	return true
+}
+
+// Record returns the record Next advanced to.
+func (it *QueryIterator) Record() SObject {
+	return it.current
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *QueryIterator) Err() error {
+	return it.err
+}
+
+// TotalSize returns the total number of records the query matches, as
+// reported by the first page. It is 0 until Next has been called.
+func (it *QueryIterator) TotalSize() int {
+	if it.page == nil {
+		return 0
+	}
+	return it.page.TotalSize
+}
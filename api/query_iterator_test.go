@@ -0,0 +1,136 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// pagedQueryServer serves pages of records in order, linking each page to
+// the next through nextRecordsUrl.
+func pagedQueryServer(t *testing.T, pages ...[]SObject) (*httptest.Server, *int) {
+	t.Helper()
+
+	total := 0
+	for _, page := range pages {
+		total += len(page)
+	}
+
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls == 0 {
+			assert.Equal(t, "/services/data/v62.0/query", r.URL.Path)
+		} else {
+			assert.Equal(t, fmt.Sprintf("/services/data/v62.0/query/01gxx-%d", calls), r.URL.Path)
+		}
+
+		resp := QueryResult{
+			TotalSize: total,
+			Done:      calls == len(pages)-1,
+			Records:   pages[calls],
+		}
+		if !resp.Done {
+			resp.NextRecordsURL = fmt.Sprintf("/services/data/v62.0/query/01gxx-%d", calls+1)
+		}
+		calls++
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &calls
+}
+
+func TestClient_QueryIterator(t *testing.T) {
+	server, calls := pagedQueryServer(t,
+		[]SObject{{ID: "001"}, {ID: "002"}},
+		[]SObject{{ID: "003"}},
+		[]SObject{{ID: "004"}, {ID: "005"}},
+	)
+
+	client, err := New(ClientConfig{InstanceURL: server.URL, HTTPClient: server.Client()})
+	require.NoError(t, err)
+
+	it := client.QueryIterator(context.Background(), "SELECT Id FROM Account")
+	assert.Equal(t, 0, *calls, "no request before Next")
+
+	var ids []string
+	for it.Next() {
+		ids = append(ids, it.Record().ID)
+		if len(ids) == 2 {
+			assert.Equal(t, 1, *calls, "next page fetched only when needed")
+		}
+	}
+	require.NoError(t, it.Err())
+
+	assert.Equal(t, []string{"001", "002", "003", "004", "005"}, ids)
+	assert.Equal(t, 5, it.TotalSize())
+	assert.Equal(t, 3, *calls)
+	assert.False(t, it.Next())
+}
+
+func TestClient_QueryIterator_EmptyPage(t *testing.T) {
+	server, _ := pagedQueryServer(t,
+		[]SObject{{ID: "001"}},
+		[]SObject{},
+		[]SObject{{ID: "002"}},
+	)
+
+	client, err := New(ClientConfig{InstanceURL: server.URL, HTTPClient: server.Client()})
+	require.NoError(t, err)
+
+	it := client.QueryIterator(context.Background(), "SELECT Id FROM Account")
+	var ids []string
+	for it.Next() {
+		ids = append(ids, it.Record().ID)
+	}
+	require.NoError(t, it.Err())
+	assert.Equal(t, []string{"001", "002"}, ids)
+}
+
+func TestClient_QueryIterator_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`[{"message":"unexpected token: FORM","errorCode":"MALFORMED_QUERY"}]`))
+	}))
+	defer server.Close()
+
+	client, err := New(ClientConfig{InstanceURL: server.URL, HTTPClient: server.Client()})
+	require.NoError(t, err)
+
+	it := client.QueryIterator(context.Background(), "SELECT Id FORM Account")
+	assert.False(t, it.Next())
+
+	var apiErr *APIError
+	require.ErrorAs(t, it.Err(), &apiErr)
+	assert.Equal(t, "MALFORMED_QUERY", apiErr.Errors[0].ErrorCode)
+	assert.False(t, it.Next())
+}
+
+func TestNewQueryIterator(t *testing.T) {
+	first := &QueryResult{
+		TotalSize:      3,
+		NextRecordsURL: "/next",
+		Records:        []SObject{{ID: "001"}, {ID: "002"}},
+	}
+	queryMore := func(ctx context.Context, nextRecordsURL string) (*QueryResult, error) {
+		assert.Equal(t, "/next", nextRecordsURL)
+		return nil, errors.New("connection reset")
+	}
+
+	it := NewQueryIterator(context.Background(), first, queryMore)
+
+	var ids []string
+	for it.Next() {
+		ids = append(ids, it.Record().ID)
+	}
+	assert.Equal(t, []string{"001", "002"}, ids)
+	assert.EqualError(t, it.Err(), "connection reset")
+	assert.Equal(t, 3, it.TotalSize())
+}
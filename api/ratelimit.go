@@ -0,0 +1,89 @@
+package api
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// RateLimiter limits how often requests are sent, as a token bucket: it
+// allows bursts of up to burst requests, refilled at rate per second. It is
+// safe for concurrent use, so one limiter can be shared by several clients
+// to limit them together.
+type RateLimiter struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// NewRateLimiter returns a limiter allowing rps requests per second on
+// average, and bursts of up to burst requests. A burst below 1 means the
+// rate rounded up, so that a full second's worth can be sent at once.
+func NewRateLimiter(rps float64, burst int) *RateLimiter {
+	if burst < 1 {
+		burst = int(math.Max(1, math.Ceil(rps)))
+	}
+	return &RateLimiter{
+		rate:   rps,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// Wait blocks until a request may be sent, or ctx is done.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	l.mu.Lock()
+	now := time.Now()
+	l.tokens = math.Min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	l.last = now
+
+	// Take a token now, even if it is not there yet, so that waiters are
+	// served in order
+	l.tokens--
+	var wait time.Duration
+	if l.tokens < 0 {
+		wait = time.Duration(-l.tokens / l.rate * float64(time.Second))
+	}
+	l.mu.Unlock()
+
+	if wait == 0 {
+		return nil
+	}
+	if err := sleep(ctx, wait); err != nil {
+		l.mu.Lock()
+		l.tokens++
+		l.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// RateLimit returns a Middleware that waits for l before each request
+// attempt, retries included.
+func RateLimit(l *RateLimiter) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			if err := l.Wait(req.Context()); err != nil {
+				return nil, err
+			}
+			return next.RoundTrip(req)
+		})
+	}
+}
+
+// WithRateLimit returns middleware followed by a RateLimit middleware for l,
+// so that requests wait for the limiter after passing the other middleware.
+// middleware is not modified, and is returned as is when l is nil.
+func WithRateLimit(middleware []Middleware, l *RateLimiter) []Middleware {
+	if l == nil {
+		return middleware
+	}
+	wrapped := make([]Middleware, 0, len(middleware)+1)
+	wrapped = append(wrapped, middleware...)
+	return append(wrapped, RateLimit(l))
+}
@@ -0,0 +1,72 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimiter_Burst(t *testing.T) {
+	l := NewRateLimiter(10, 3)
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		require.NoError(t, l.Wait(context.Background()))
+	}
+	assert.Less(t, time.Since(start), 50*time.Millisecond, "burst should not wait")
+
+	require.NoError(t, l.Wait(context.Background()))
+	assert.GreaterOrEqual(t, time.Since(start), 80*time.Millisecond, "request after the burst should wait for a token")
+}
+
+func TestRateLimiter_DefaultBurst(t *testing.T) {
+	assert.Equal(t, 1.0, NewRateLimiter(0.5, 0).burst)
+	assert.Equal(t, 3.0, NewRateLimiter(2.5, 0).burst)
+	assert.Equal(t, 5.0, NewRateLimiter(2.5, 5).burst)
+}
+
+func TestRateLimiter_Cancelled(t *testing.T) {
+	l := NewRateLimiter(0.1, 1)
+	require.NoError(t, l.Wait(context.Background()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	err := l.Wait(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// The cancelled waiter gives its token back
+	assert.InDelta(t, 0.0, l.tokens, 0.01)
+}
+
+func TestClient_RateLimiter(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	// Two clients sharing a limiter are limited together
+	limiter := NewRateLimiter(20, 1)
+	first, err := New(ClientConfig{InstanceURL: server.URL, HTTPClient: server.Client(), RateLimiter: limiter})
+	require.NoError(t, err)
+	second, err := New(ClientConfig{InstanceURL: server.URL, HTTPClient: server.Client(), RateLimiter: limiter})
+	require.NoError(t, err)
+
+	start := time.Now()
+	for i := 0; i < 2; i++ {
+		_, err = first.Get(context.Background(), "/limits")
+		require.NoError(t, err)
+		_, err = second.Get(context.Background(), "/limits")
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, int32(4), calls.Load())
+	assert.GreaterOrEqual(t, time.Since(start), 140*time.Millisecond)
+}
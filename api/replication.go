@@ -0,0 +1,81 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// replicationTimeFormat is the ISO 8601 format the getDeleted and getUpdated
+// resources take for start and end.
+const replicationTimeFormat = "2006-01-02T15:04:05-07:00"
+
+// DeletedRecord is a record reported by GetDeleted.
+type DeletedRecord struct {
+	ID          string   `json:"id"`
+	DeletedDate DateTime `json:"deletedDate"`
+}
+
+// DeletedResult is the response of GetDeleted.
+type DeletedResult struct {
+	DeletedRecords []DeletedRecord `json:"deletedRecords"`
+	// EarliestDateAvailable is the oldest time deleted records can be
+	// requested for; older ones have been purged from the Recycle Bin
+	EarliestDateAvailable DateTime `json:"earliestDateAvailable"`
+	// LatestDateCovered is the last time the result is complete up to; start
+	// the next sync from it
+	LatestDateCovered DateTime `json:"latestDateCovered"`
+}
+
+// UpdatedResult is the response of GetUpdated.
+type UpdatedResult struct {
+	IDs []string `json:"ids"`
+	// LatestDateCovered is the last time the result is complete up to; start
+	// the next sync from it
+	LatestDateCovered DateTime `json:"latestDateCovered"`
+}
+
+// GetDeleted returns the records of an object deleted between start and
+// end. Salesforce only keeps deletions for 30 days, and rounds start and end
+// down to the minute.
+func (c *Client) GetDeleted(ctx context.Context, objectName string, start, end time.Time) (*DeletedResult, error) {
+	body, err := c.Get(ctx, replicationPath(objectName, "deleted", start, end))
+	if err != nil {
+		return nil, err
+	}
+
+	var result DeletedResult
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse deleted records: %w", err)
+	}
+
+	return &result, nil
+}
+
+// GetUpdated returns the IDs of the records of an object created or updated
+// between start and end. Salesforce only reports the last 30 days, and
+// rounds start and end down to the minute.
+func (c *Client) GetUpdated(ctx context.Context, objectName string, start, end time.Time) (*UpdatedResult, error) {
+	body, err := c.Get(ctx, replicationPath(objectName, "updated", start, end))
+	if err != nil {
+		return nil, err
+	}
+
+	var result UpdatedResult
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse updated records: %w", err)
+	}
+
+	return &result, nil
+}
+
+// replicationPath returns the path of the deleted or updated resource of an
+// object for a time range.
+func replicationPath(objectName, resource string, start, end time.Time) string {
+	params := url.Values{}
+	params.Set("start", start.UTC().Format(replicationTimeFormat))
+	params.Set("end", end.UTC().Format(replicationTimeFormat))
+	return fmt.Sprintf("/sobjects/%s/%s/?%s", objectName, resource, params.Encode())
+}
@@ -0,0 +1,58 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_GetDeleted(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/services/data/v62.0/sobjects/Account/deleted/", r.URL.Path)
+		assert.Equal(t, "2024-01-14T10:30:00+00:00", r.URL.Query().Get("start"))
+		assert.Equal(t, "2024-01-15T10:30:00+00:00", r.URL.Query().Get("end"))
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"deletedRecords": [{"id": "001xx000003DGbYAAW", "deletedDate": "2024-01-15T08:12:45.000+0000"}],
+			"earliestDateAvailable": "2023-12-20T00:00:00.000+0000",
+			"latestDateCovered": "2024-01-15T10:30:00.000+0000"
+		}`))
+	}))
+	defer server.Close()
+
+	client, err := New(ClientConfig{InstanceURL: server.URL, HTTPClient: server.Client()})
+	require.NoError(t, err)
+
+	end := time.Date(2024, 1, 15, 11, 30, 0, 0, time.FixedZone("CET", 3600))
+	result, err := client.GetDeleted(context.Background(), "Account", end.Add(-24*time.Hour), end)
+	require.NoError(t, err)
+
+	require.Len(t, result.DeletedRecords, 1)
+	assert.Equal(t, "001xx000003DGbYAAW", result.DeletedRecords[0].ID)
+	assert.True(t, result.DeletedRecords[0].DeletedDate.Equal(time.Date(2024, 1, 15, 8, 12, 45, 0, time.UTC)))
+	assert.True(t, result.LatestDateCovered.Equal(time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)))
+}
+
+func TestClient_GetUpdated(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/services/data/v62.0/sobjects/Contact/updated/", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"ids": ["003xx0000001", "003xx0000002"], "latestDateCovered": "2024-01-15T10:30:00.000+0000"}`))
+	}))
+	defer server.Close()
+
+	client, err := New(ClientConfig{InstanceURL: server.URL, HTTPClient: server.Client()})
+	require.NoError(t, err)
+
+	end := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+	result, err := client.GetUpdated(context.Background(), "Contact", end.Add(-time.Hour), end)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"003xx0000001", "003xx0000002"}, result.IDs)
+}
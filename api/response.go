@@ -0,0 +1,118 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ResponseInfo is the metadata Salesforce sends with a response, in its
+// headers. Use it to correlate a call with Salesforce's logs, e.g. when
+// filing a support case, or to watch API usage.
+type ResponseInfo struct {
+	// Method and URL are the request the response is for
+	Method string
+	URL    string
+	// StatusCode is the HTTP status of the response
+	StatusCode int
+	// RequestID is the ID Salesforce gave the request, from the
+	// X-Request-Id or Sforce-Request-Id header, if sent
+	RequestID string
+	// APIUsage is the org's daily API request usage, from the
+	// Sforce-Limit-Info header, or nil if it was not sent
+	APIUsage *LimitInfo
+	// ETag and Location are the headers of the same name, if sent
+	ETag     string
+	Location string
+	// Header holds all the response headers
+	Header http.Header
+}
+
+// NewResponseInfo returns the metadata of resp.
+func NewResponseInfo(resp *http.Response) *ResponseInfo {
+	info := &ResponseInfo{
+		StatusCode: resp.StatusCode,
+		RequestID:  resp.Header.Get("X-Request-Id"),
+		APIUsage:   parseLimitInfo(resp.Header.Get("Sforce-Limit-Info")),
+		ETag:       resp.Header.Get("ETag"),
+		Location:   resp.Header.Get("Location"),
+		Header:     resp.Header.Clone(),
+	}
+	if info.RequestID == "" {
+		info.RequestID = resp.Header.Get("Sforce-Request-Id")
+	}
+	if resp.Request != nil {
+		info.Method = resp.Request.Method
+		info.URL = resp.Request.URL.String()
+	}
+	return info
+}
+
+// parseLimitInfo parses a Sforce-Limit-Info header such as
+// "api-usage=25/15000" into the used and maximum API requests. It returns
+// nil if the header has no API usage.
+func parseLimitInfo(value string) *LimitInfo {
+	for _, part := range strings.Split(value, ",") {
+		name, usage, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok || name != "api-usage" {
+			continue
+		}
+		used, max, ok := strings.Cut(usage, "/")
+		if !ok {
+			return nil
+		}
+		usedN, err1 := strconv.Atoi(used)
+		maxN, err2 := strconv.Atoi(max)
+		if err1 != nil || err2 != nil {
+			return nil
+		}
+		return &LimitInfo{Max: maxN, Remaining: maxN - usedN}
+	}
+	return nil
+}
+
+// ResponseRecorder keeps the metadata of the last response sent through its
+// middleware. It is safe for concurrent use; with concurrent requests, the
+// last response is whichever completed last.
+type ResponseRecorder struct {
+	mu   sync.Mutex
+	last *ResponseInfo
+}
+
+// Middleware returns a Middleware recording every response, including
+// errors and those of retried attempts.
+func (r *ResponseRecorder) Middleware() Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			resp, err := next.RoundTrip(req)
+			if resp != nil {
+				info := NewResponseInfo(resp)
+				r.mu.Lock()
+				r.last = info
+				r.mu.Unlock()
+			}
+			return resp, err
+		})
+	}
+}
+
+// Last returns the metadata of the last response, or nil if there has been
+// none. A nil recorder has none.
+func (r *ResponseRecorder) Last() *ResponseInfo {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.last
+}
+
+// WithResponseRecorder returns middleware preceded by r's middleware, so
+// that r sees responses as the caller gets them. middleware is not
+// modified.
+func WithResponseRecorder(middleware []Middleware, r *ResponseRecorder) []Middleware {
+	wrapped := make([]Middleware, 0, len(middleware)+1)
+	wrapped = append(wrapped, r.Middleware())
+	return append(wrapped, middleware...)
+}
@@ -0,0 +1,65 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLimitInfo(t *testing.T) {
+	assert.Equal(t, &LimitInfo{Max: 15000, Remaining: 14975}, parseLimitInfo("api-usage=25/15000"))
+	assert.Equal(t, &LimitInfo{Max: 5000, Remaining: 4990}, parseLimitInfo("per-app-api-usage=2/250(appName=sfdc), api-usage=10/5000"))
+	assert.Nil(t, parseLimitInfo(""))
+	assert.Nil(t, parseLimitInfo("api-usage=lots"))
+}
+
+func TestClient_LastResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Sforce-Limit-Info", "api-usage=25/15000")
+		switch r.URL.Path {
+		case "/services/data/v62.0/sobjects/Account/":
+			w.Header().Set("X-Request-Id", "req-create")
+			w.Header().Set("Location", "/services/data/v62.0/sobjects/Account/001xx0000001")
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"id":"001xx0000001","success":true,"errors":[]}`))
+		default:
+			w.Header().Set("Sforce-Request-Id", "req-missing")
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`[{"errorCode":"NOT_FOUND","message":"not found"}]`))
+		}
+	}))
+	defer server.Close()
+
+	client, err := New(ClientConfig{InstanceURL: server.URL, HTTPClient: server.Client()})
+	require.NoError(t, err)
+	assert.Nil(t, client.LastResponse())
+
+	_, err = client.CreateRecord(context.Background(), "Account", map[string]interface{}{"Name": "Acme"})
+	require.NoError(t, err)
+
+	info := client.LastResponse()
+	require.NotNil(t, info)
+	assert.Equal(t, http.MethodPost, info.Method)
+	assert.Equal(t, server.URL+"/services/data/v62.0/sobjects/Account/", info.URL)
+	assert.Equal(t, http.StatusCreated, info.StatusCode)
+	assert.Equal(t, "req-create", info.RequestID)
+	assert.Equal(t, "/services/data/v62.0/sobjects/Account/001xx0000001", info.Location)
+	assert.Equal(t, &LimitInfo{Max: 15000, Remaining: 14975}, info.APIUsage)
+
+	// Failed requests are recorded too
+	_, err = client.GetRecord(context.Background(), "Account", "001xx0000002", nil)
+	require.Error(t, err)
+
+	info = client.LastResponse()
+	require.NotNil(t, info)
+	assert.Equal(t, http.StatusNotFound, info.StatusCode)
+	assert.Equal(t, "req-missing", info.RequestID)
+}
+
+func TestClient_LastResponse_NotFromNew(t *testing.T) {
+	assert.Nil(t, (&Client{}).LastResponse())
+}
@@ -0,0 +1,206 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+// RetryPolicy controls how requests that fail with a transient error are
+// retried. The zero value makes a single attempt.
+type RetryPolicy struct {
+	// MaxAttempts is the number of attempts, including the first. Values
+	// below 1 mean 1.
+	MaxAttempts int
+	// Backoff is the wait before the first retry. It doubles after each
+	// retry, up to MaxBackoff.
+	Backoff time.Duration
+	// MaxBackoff caps the wait between attempts. Zero means no cap.
+	MaxBackoff time.Duration
+	// Jitter is the fraction of each wait, from 0 to 1, that is randomized
+	// so that concurrent clients do not retry in lockstep.
+	Jitter float64
+	// RetryableStatus lists the HTTP status codes that are retried.
+	RetryableStatus []int
+
+	// WaitOnRateLimit retries requests rejected by rate limiting (a 429
+	// response, or a 403 with REQUEST_LIMIT_EXCEEDED) after the wait given in
+	// the Retry-After header, instead of returning the rejection. Rejected
+	// requests were not applied, so any method is retried, and these retries
+	// do not count against MaxAttempts.
+	WaitOnRateLimit bool
+	// MaxRateLimitWait caps the total time one request spends waiting on rate
+	// limits. Zero means no cap.
+	MaxRateLimitWait time.Duration
+}
+
+// DefaultRetryPolicy returns the policy used by the CLI: three attempts,
+// backing off from half a second, on server and gateway errors.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 3,
+		Backoff:     500 * time.Millisecond,
+		MaxBackoff:  10 * time.Second,
+		Jitter:      0.2,
+		RetryableStatus: []int{
+			http.StatusInternalServerError,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+		MaxRateLimitWait: 15 * time.Minute,
+	}
+}
+
+// Do sends req with client, retrying network errors and retryable status
+// codes as the policy allows.
+//
+// Only idempotent requests (GET, HEAD, OPTIONS, PUT, and DELETE) are retried,
+// since a failed POST or PATCH may still have been applied. The body of a
+// retried request is replayed with req.GetBody, which http.NewRequest sets
+// for byte and string readers. Waits end early when req's context is done.
+//
+// With WaitOnRateLimit, rate-limited requests of any method are also retried
+// until MaxRateLimitWait is used up.
+func (p RetryPolicy) Do(client *http.Client, req *http.Request) (*http.Response, error) {
+	replayable := req.Body == nil || req.GetBody != nil
+	attempts := p.MaxAttempts
+	if attempts < 1 || !isIdempotent(req.Method) || !replayable {
+		attempts = 1
+	}
+
+	var (
+		attempt = 1
+		limited int
+		waited  time.Duration
+	)
+	for {
+		resp, err := client.Do(req)
+
+		var wait time.Duration
+		switch {
+		case err == nil && p.WaitOnRateLimit && replayable && isRateLimited(resp):
+			limited++
+			wait = retryAfter(resp, p.wait(limited))
+			if p.MaxRateLimitWait > 0 && waited+wait > p.MaxRateLimitWait {
+				return resp, nil
+			}
+			waited += wait
+		case attempt < attempts && p.retryable(req.Context(), resp, err):
+			wait = p.wait(attempt)
+			attempt++
+		default:
+			return resp, err
+		}
+
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+			resp.Body.Close()
+		}
+
+		if err := sleep(req.Context(), wait); err != nil {
+			return nil, err
+		}
+
+		next := req.Clone(req.Context())
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			next.Body = body
+		}
+		req = next
+	}
+}
+
+// retryable reports whether a response or error is worth another attempt.
+func (p RetryPolicy) retryable(ctx context.Context, resp *http.Response, err error) bool {
+	if err != nil {
+		// Token endpoint failures are retried by the token source, and a
+		// cancelled request must not be sent again
+		var retrieveErr *oauth2.RetrieveError
+		return ctx.Err() == nil && !errors.As(err, &retrieveErr)
+	}
+	return slices.Contains(p.RetryableStatus, resp.StatusCode)
+}
+
+// isRateLimited reports whether resp rejects the request for exceeding a
+// rate or concurrency limit. For a 403, the body is checked for
+// REQUEST_LIMIT_EXCEEDED and replaced so that it can still be read.
+func isRateLimited(resp *http.Response) bool {
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusForbidden:
+		body, err := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+		resp.Body.Close()
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+		return err == nil && bytes.Contains(body, []byte("REQUEST_LIMIT_EXCEEDED"))
+	}
+	return false
+}
+
+// retryAfter returns the wait requested by resp's Retry-After header, given
+// in seconds or as an HTTP date, or fallback if there is none.
+func retryAfter(resp *http.Response, fallback time.Duration) time.Duration {
+	value := resp.Header.Get("Retry-After")
+	if value == "" {
+		return fallback
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return max(time.Until(at), 0)
+	}
+	return fallback
+}
+
+// wait returns how long to wait before retry number attempt (from 1).
+func (p RetryPolicy) wait(attempt int) time.Duration {
+	d := p.Backoff
+	for i := 1; i < attempt; i++ {
+		d *= 2
+		if p.MaxBackoff > 0 && d >= p.MaxBackoff {
+			break
+		}
+	}
+	if p.MaxBackoff > 0 && d > p.MaxBackoff {
+		d = p.MaxBackoff
+	}
+	if p.Jitter > 0 {
+		d -= time.Duration(rand.Float64() * p.Jitter * float64(d))
+	}
+	return d
+}
+
+// sleep waits for d, returning early with the context's error if ctx is
+// done first.
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,220 @@
+package api
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// flakyServer fails the first failures requests with status, then succeeds.
+// It returns the server and a counter of requests received.
+func flakyServer(t *testing.T, failures int, status int) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		n := calls.Add(1)
+		if int(n) <= failures {
+			w.WriteHeader(status)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"body":"` + string(body) + `"}`))
+	}))
+	t.Cleanup(server.Close)
+	return server, &calls
+}
+
+func testRetryPolicy(attempts int) RetryPolicy {
+	policy := DefaultRetryPolicy()
+	policy.MaxAttempts = attempts
+	policy.Backoff = time.Millisecond
+	return policy
+}
+
+func TestRetryPolicy_Do(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		failures   int
+		status     int
+		attempts   int
+		wantStatus int
+		wantCalls  int32
+	}{
+		{"succeeds after retries", http.MethodGet, 2, http.StatusServiceUnavailable, 3, http.StatusOK, 3},
+		{"gives up after max attempts", http.MethodGet, 5, http.StatusBadGateway, 3, http.StatusBadGateway, 3},
+		{"does not retry client errors", http.MethodGet, 1, http.StatusBadRequest, 3, http.StatusBadRequest, 1},
+		{"does not retry POST", http.MethodPost, 1, http.StatusServiceUnavailable, 3, http.StatusServiceUnavailable, 1},
+		{"does not retry PATCH", http.MethodPatch, 1, http.StatusServiceUnavailable, 3, http.StatusServiceUnavailable, 1},
+		{"retries PUT", http.MethodPut, 1, http.StatusInternalServerError, 3, http.StatusOK, 2},
+		{"zero policy makes one attempt", http.MethodGet, 1, http.StatusServiceUnavailable, 0, http.StatusServiceUnavailable, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, calls := flakyServer(t, tt.failures, tt.status)
+
+			req, err := http.NewRequest(tt.method, server.URL, strings.NewReader("payload"))
+			require.NoError(t, err)
+
+			resp, err := testRetryPolicy(tt.attempts).Do(server.Client(), req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tt.wantStatus, resp.StatusCode)
+			assert.Equal(t, tt.wantCalls, calls.Load())
+			if resp.StatusCode == http.StatusOK {
+				body, _ := io.ReadAll(resp.Body)
+				assert.JSONEq(t, `{"body":"payload"}`, string(body), "body should be replayed on retry")
+			}
+		})
+	}
+}
+
+func TestRetryPolicy_Do_NetworkError(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			// Drop the connection without a response
+			conn, _, err := w.(http.Hijacker).Hijack()
+			require.NoError(t, err)
+			conn.Close()
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+
+	resp, err := testRetryPolicy(2).Do(server.Client(), req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestRetryPolicy_Do_ContextCancelled(t *testing.T) {
+	server, calls := flakyServer(t, 5, http.StatusServiceUnavailable)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+
+	policy := testRetryPolicy(3)
+	policy.Backoff = time.Hour
+	time.AfterFunc(10*time.Millisecond, cancel)
+
+	_, err = policy.Do(server.Client(), req)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestRetryPolicy_Wait(t *testing.T) {
+	policy := RetryPolicy{Backoff: 100 * time.Millisecond, MaxBackoff: 300 * time.Millisecond}
+
+	assert.Equal(t, 100*time.Millisecond, policy.wait(1))
+	assert.Equal(t, 200*time.Millisecond, policy.wait(2))
+	assert.Equal(t, 300*time.Millisecond, policy.wait(3))
+	assert.Equal(t, 300*time.Millisecond, policy.wait(10))
+
+	policy.Jitter = 0.5
+	for i := 0; i < 20; i++ {
+		d := policy.wait(1)
+		assert.GreaterOrEqual(t, d, 50*time.Millisecond)
+		assert.LessOrEqual(t, d, 100*time.Millisecond)
+	}
+}
+
+func TestClient_RetriesTransientErrors(t *testing.T) {
+	server, calls := flakyServer(t, 1, http.StatusServiceUnavailable)
+
+	client, err := New(ClientConfig{
+		InstanceURL: server.URL,
+		HTTPClient:  server.Client(),
+		Retry:       testRetryPolicy(3),
+	})
+	require.NoError(t, err)
+
+	_, err = client.Get(context.Background(), "/limits")
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestRetryPolicy_Do_RateLimit(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     int
+		body       string
+		wait       bool
+		maxWait    time.Duration
+		wantStatus int
+		wantCalls  int32
+	}{
+		{"waits on 429", http.StatusTooManyRequests, "", true, 0, http.StatusOK, 2},
+		{"waits on REQUEST_LIMIT_EXCEEDED", http.StatusForbidden, `[{"errorCode":"REQUEST_LIMIT_EXCEEDED","message":"ConcurrentPerOrgLongTxn Limit exceeded."}]`, true, 0, http.StatusOK, 2},
+		{"fails when not waiting", http.StatusTooManyRequests, "", false, 0, http.StatusTooManyRequests, 1},
+		{"other 403 is not a rate limit", http.StatusForbidden, `[{"errorCode":"INSUFFICIENT_ACCESS"}]`, true, 0, http.StatusForbidden, 1},
+		{"gives up past the wait cap", http.StatusTooManyRequests, "", true, 500 * time.Millisecond, http.StatusTooManyRequests, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if calls.Add(1) == 1 {
+					w.Header().Set("Retry-After", "1")
+					w.WriteHeader(tt.status)
+					_, _ = w.Write([]byte(tt.body))
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer server.Close()
+
+			// POST, since rate-limited requests are retried whatever the method
+			req, err := http.NewRequest(http.MethodPost, server.URL, strings.NewReader("{}"))
+			require.NoError(t, err)
+
+			policy := testRetryPolicy(1)
+			policy.WaitOnRateLimit = tt.wait
+			policy.MaxRateLimitWait = tt.maxWait
+
+			resp, err := policy.Do(server.Client(), req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tt.wantStatus, resp.StatusCode)
+			assert.Equal(t, tt.wantCalls, calls.Load())
+			if tt.wantStatus != http.StatusOK {
+				body, _ := io.ReadAll(resp.Body)
+				assert.Equal(t, tt.body, string(body), "rejected body should still be readable")
+			}
+		})
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	header := func(value string) *http.Response {
+		return &http.Response{Header: http.Header{"Retry-After": []string{value}}}
+	}
+
+	assert.Equal(t, 30*time.Second, retryAfter(header("30"), time.Second))
+	assert.Equal(t, time.Second, retryAfter(header(""), time.Second))
+	assert.Equal(t, time.Second, retryAfter(header("soon"), time.Second))
+	assert.Equal(t, time.Duration(0), retryAfter(header("Mon, 02 Jan 2006 15:04:05 GMT"), time.Second))
+
+	d := retryAfter(header(time.Now().Add(time.Minute).UTC().Format(http.TimeFormat)), time.Second)
+	assert.InDelta(t, float64(time.Minute), float64(d), float64(2*time.Second))
+}
@@ -0,0 +1,269 @@
+// Package soql builds SOQL queries with safely escaped values.
+//
+// Field lists, conditions, and ORDER BY clauses are written by the caller
+// and used as is. Values that come from users or other records are never
+// formatted into the query text; conditions refer to them with :name
+// placeholders, and Bind supplies them as literals:
+//
+//	q, err := soql.Select("Id", "Name").
+//		From("ApexClass").
+//		Where("Name = :name").
+//		Bind("name", className).
+//		Build()
+package soql
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DateTimeFormat is the layout of SOQL datetime literals.
+const DateTimeFormat = "2006-01-02T15:04:05Z"
+
+// DateFormat is the layout of SOQL date literals.
+const DateFormat = "2006-01-02"
+
+// Common errors
+var (
+	ErrNoFields      = errors.New("soql: no fields selected")
+	ErrNoObject      = errors.New("soql: no object to select from")
+	ErrInvalidObject = errors.New("soql: invalid object name")
+)
+
+// objectName matches an sObject API name, including namespaced and custom
+// objects such as ns__Invoice__c.
+var objectName = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*$`)
+
+// Date is a value bound as a SOQL date literal rather than a datetime.
+type Date time.Time
+
+// Query is a SOQL query under construction. Methods return the query so
+// calls can be chained; errors are reported by Build.
+type Query struct {
+	fields  []string
+	object  string
+	where   []string
+	binds   map[string]interface{}
+	orderBy []string
+	limit   int
+}
+
+// Select starts a query for the given fields.
+func Select(fields ...string) *Query {
+	return &Query{fields: fields}
+}
+
+// From sets the object to query.
+func (q *Query) From(object string) *Query {
+	q.object = object
+	return q
+}
+
+// Where adds a condition. Conditions added by separate calls are joined
+// with AND, so a condition using OR must be parenthesized. Values belong in
+// :name placeholders, set with Bind.
+func (q *Query) Where(condition string) *Query {
+	q.where = append(q.where, condition)
+	return q
+}
+
+// Bind sets the value of a :name placeholder. Strings, numbers, booleans,
+// time.Time (as a datetime), Date, nil, and slices of these (as an IN list)
+// are supported.
+func (q *Query) Bind(name string, value interface{}) *Query {
+	if q.binds == nil {
+		q.binds = make(map[string]interface{})
+	}
+	q.binds[name] = value
+	return q
+}
+
+// OrderBy adds ORDER BY items, e.g. "Name" or "StartTime DESC".
+func (q *Query) OrderBy(items ...string) *Query {
+	q.orderBy = append(q.orderBy, items...)
+	return q
+}
+
+// Limit sets the maximum number of rows returned. Zero means no limit.
+func (q *Query) Limit(n int) *Query {
+	q.limit = n
+	return q
+}
+
+// Build returns the query text with every placeholder replaced by its
+// escaped value.
+func (q *Query) Build() (string, error) {
+	if len(q.fields) == 0 {
+		return "", ErrNoFields
+	}
+	if q.object == "" {
+		return "", ErrNoObject
+	}
+	if !objectName.MatchString(q.object) {
+		return "", fmt.Errorf("%w: %q", ErrInvalidObject, q.object)
+	}
+
+	var b strings.Builder
+	b.WriteString("SELECT ")
+	b.WriteString(strings.Join(q.fields, ", "))
+	b.WriteString(" FROM ")
+	b.WriteString(q.object)
+
+	if len(q.where) > 0 {
+		conditions := make([]string, len(q.where))
+		for i, condition := range q.where {
+			bound, err := q.bind(condition)
+			if err != nil {
+				return "", err
+			}
+			conditions[i] = bound
+		}
+		b.WriteString(" WHERE ")
+		b.WriteString(strings.Join(conditions, " AND "))
+	}
+
+	if len(q.orderBy) > 0 {
+		b.WriteString(" ORDER BY ")
+		b.WriteString(strings.Join(q.orderBy, ", "))
+	}
+
+	if q.limit > 0 {
+		b.WriteString(" LIMIT ")
+		b.WriteString(strconv.Itoa(q.limit))
+	}
+
+	return b.String(), nil
+}
+
+// bind replaces the :name placeholders in a condition with their values.
+// Text inside quoted literals is left alone, as are colons not followed by
+// a letter, such as those in datetime literals.
+func (q *Query) bind(condition string) (string, error) {
+	var b strings.Builder
+	var quote byte
+
+	for i := 0; i < len(condition); i++ {
+		c := condition[i]
+
+		if quote != 0 {
+			b.WriteByte(c)
+			if c == '\\' && i+1 < len(condition) {
+				i++
+				b.WriteByte(condition[i])
+			} else if c == quote {
+				quote = 0
+			}
+			continue
+		}
+
+		if c == '\'' || c == '"' {
+			quote = c
+			b.WriteByte(c)
+			continue
+		}
+
+		if c != ':' || i+1 >= len(condition) || !isLetter(condition[i+1]) {
+			b.WriteByte(c)
+			continue
+		}
+
+		end := i + 1
+		for end < len(condition) && isNameChar(condition[end]) {
+			end++
+		}
+		name := condition[i+1 : end]
+
+		value, ok := q.binds[name]
+		if !ok {
+			return "", fmt.Errorf("soql: no value bound for :%s", name)
+		}
+		literal, err := Literal(value)
+		if err != nil {
+			return "", fmt.Errorf("soql: :%s: %w", name, err)
+		}
+		b.WriteString(literal)
+		i = end - 1
+	}
+
+	return b.String(), nil
+}
+
+// Literal formats a value as a SOQL literal. Strings are quoted and
+// escaped, and slices become parenthesized lists for IN and NOT IN.
+func Literal(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "null", nil
+	case string:
+		return Quote(v), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case int:
+		return strconv.Itoa(v), nil
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	case int32:
+		return strconv.FormatInt(int64(v), 10), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32), nil
+	case time.Time:
+		return v.UTC().Format(DateTimeFormat), nil
+	case Date:
+		return time.Time(v).Format(DateFormat), nil
+	}
+
+	rv := reflect.ValueOf(value)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return "", fmt.Errorf("unsupported value type %T", value)
+	}
+	if rv.Len() == 0 {
+		return "", errors.New("empty list")
+	}
+
+	items := make([]string, rv.Len())
+	for i := range items {
+		item := rv.Index(i).Interface()
+		if k := reflect.ValueOf(item).Kind(); k == reflect.Slice || k == reflect.Array {
+			return "", fmt.Errorf("unsupported nested list %T", value)
+		}
+		literal, err := Literal(item)
+		if err != nil {
+			return "", err
+		}
+		items[i] = literal
+	}
+	return "(" + strings.Join(items, ",") + ")", nil
+}
+
+// quoteEscaper escapes the characters SOQL requires to be escaped in
+// string literals.
+var quoteEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	`'`, `\'`,
+	`"`, `\"`,
+	"\n", `\n`,
+	"\r", `\r`,
+	"\t", `\t`,
+	"\b", `\b`,
+	"\f", `\f`,
+)
+
+// Quote returns s as a single-quoted SOQL string literal.
+func Quote(s string) string {
+	return "'" + quoteEscaper.Replace(s) + "'"
+}
+
+func isLetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isNameChar(c byte) bool {
+	return isLetter(c) || (c >= '0' && c <= '9') || c == '_'
+}
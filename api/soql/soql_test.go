@@ -0,0 +1,155 @@
+package soql
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuild(t *testing.T) {
+	since := time.Date(2024, 1, 15, 11, 0, 0, 0, time.FixedZone("CET", 3600))
+
+	tests := []struct {
+		name  string
+		query *Query
+		want  string
+	}{
+		{
+			name:  "fields and object",
+			query: Select("Id", "Name").From("Account"),
+			want:  "SELECT Id, Name FROM Account",
+		},
+		{
+			name:  "bound string",
+			query: Select("Id").From("ApexClass").Where("Name = :name").Bind("name", "MyClass"),
+			want:  "SELECT Id FROM ApexClass WHERE Name = 'MyClass'",
+		},
+		{
+			name: "conditions joined with AND",
+			query: Select("Id").From("ApexLog").
+				Where("LogUserId = :user").
+				Where("StartTime >= :since").
+				Where("DurationMilliseconds > :ms").
+				Bind("user", "005000000000001").
+				Bind("since", since).
+				Bind("ms", 2000),
+			want: "SELECT Id FROM ApexLog WHERE LogUserId = '005000000000001' AND StartTime >= 2024-01-15T10:00:00Z AND DurationMilliseconds > 2000",
+		},
+		{
+			name:  "IN list",
+			query: Select("Id").From("ApexCodeCoverage").Where("ApexTestClassId IN :ids").Bind("ids", []string{"01p1", "01p2"}),
+			want:  "SELECT Id FROM ApexCodeCoverage WHERE ApexTestClassId IN ('01p1','01p2')",
+		},
+		{
+			name:  "placeholder reused",
+			query: Select("Id").From("Contact").Where("(FirstName = :name OR LastName = :name)").Bind("name", "Lee"),
+			want:  "SELECT Id FROM Contact WHERE (FirstName = 'Lee' OR LastName = 'Lee')",
+		},
+		{
+			name:  "quoted text and datetime literals are not placeholders",
+			query: Select("Id").From("Case").Where("Subject = 'a :b' AND CreatedDate > 2024-01-01T00:00:00Z"),
+			want:  "SELECT Id FROM Case WHERE Subject = 'a :b' AND CreatedDate > 2024-01-01T00:00:00Z",
+		},
+		{
+			name:  "order by and limit",
+			query: Select("Id").From("ApexLog").OrderBy("StartTime DESC", "Id").Limit(5),
+			want:  "SELECT Id FROM ApexLog ORDER BY StartTime DESC, Id LIMIT 5",
+		},
+		{
+			name:  "custom object",
+			query: Select("Id").From("ns__Invoice__c").Where("ns__Total__c > :min").Bind("min", 10.5),
+			want:  "SELECT Id FROM ns__Invoice__c WHERE ns__Total__c > 10.5",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.query.Build()
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestBuild_EscapesValues(t *testing.T) {
+	got, err := Select("Id").From("ApexClass").Where("Name = :name").Bind("name", "x' OR Name != '").Build()
+	require.NoError(t, err)
+	assert.Equal(t, `SELECT Id FROM ApexClass WHERE Name = 'x\' OR Name != \''`, got)
+}
+
+func TestBuild_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   *Query
+		wantErr string
+	}{
+		{
+			name:    "no fields",
+			query:   Select().From("Account"),
+			wantErr: "soql: no fields selected",
+		},
+		{
+			name:    "no object",
+			query:   Select("Id"),
+			wantErr: "soql: no object to select from",
+		},
+		{
+			name:    "invalid object",
+			query:   Select("Id").From("Account WHERE Id != null"),
+			wantErr: `soql: invalid object name: "Account WHERE Id != null"`,
+		},
+		{
+			name:    "unbound placeholder",
+			query:   Select("Id").From("Account").Where("Name = :name"),
+			wantErr: "soql: no value bound for :name",
+		},
+		{
+			name:    "empty list",
+			query:   Select("Id").From("Account").Where("Id IN :ids").Bind("ids", []string{}),
+			wantErr: "soql: :ids: empty list",
+		},
+		{
+			name:    "unsupported type",
+			query:   Select("Id").From("Account").Where("Name = :name").Bind("name", struct{}{}),
+			wantErr: "soql: :name: unsupported value type struct {}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.query.Build()
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestLiteral(t *testing.T) {
+	tests := []struct {
+		value interface{}
+		want  string
+	}{
+		{nil, "null"},
+		{"it's", `'it\'s'`},
+		{"back\\slash", `'back\\slash'`},
+		{"line\nbreak\ttab", `'line\nbreak\ttab'`},
+		{`say "hi"`, `'say \"hi\"'`},
+		{true, "true"},
+		{42, "42"},
+		{int64(-7), "-7"},
+		{1.25, "1.25"},
+		{time.Date(2024, 3, 1, 9, 30, 0, 0, time.UTC), "2024-03-01T09:30:00Z"},
+		{Date(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)), "2024-03-01"},
+		{[]int{1, 2}, "(1,2)"},
+		{[]interface{}{"a", nil}, "('a',null)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			got, err := Literal(tt.value)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
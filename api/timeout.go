@@ -0,0 +1,121 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultTimeout is the request timeout used by the CLI unless configured
+// otherwise.
+const DefaultTimeout = 2 * time.Minute
+
+// ErrTimeout is returned, wrapped, when a request makes no progress within
+// its timeout.
+var ErrTimeout = errors.New("request timed out")
+
+// Timeout returns a Middleware that fails a request attempt once it goes d
+// without progress: while the request body is sent, while waiting for the
+// response, and while the response body is read. Each chunk of body sent
+// or received starts the timeout again, so long uploads and downloads are
+// not cut off as long as data keeps moving. A d of zero or less disables
+// the timeout.
+//
+// A timed-out attempt fails with an error wrapping ErrTimeout, which the
+// RetryPolicy treats like any other network error.
+func Timeout(d time.Duration) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		if d <= 0 {
+			return next
+		}
+
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			ctx, cancel := context.WithCancel(req.Context())
+			t := &idleTimer{d: d}
+			t.timer = time.AfterFunc(d, func() {
+				t.expired.Store(true)
+				cancel()
+			})
+
+			req = req.WithContext(ctx)
+			if req.Body != nil && req.Body != http.NoBody {
+				req.Body = &timeoutBody{ReadCloser: req.Body, timer: t}
+			}
+
+			resp, err := next.RoundTrip(req)
+			if err != nil {
+				t.timer.Stop()
+				cancel()
+				return nil, t.wrap(err)
+			}
+
+			resp.Body = &timeoutBody{ReadCloser: resp.Body, timer: t, cancel: cancel}
+			return resp, nil
+		})
+	}
+}
+
+// WithTimeout returns middleware followed by a Timeout middleware for d,
+// making the timeout the innermost layer so that it sees the bytes sent and
+// received on the wire. Apply it after WithCompression. middleware is not
+// modified, and is returned as is when d is zero or less.
+func WithTimeout(middleware []Middleware, d time.Duration) []Middleware {
+	if d <= 0 {
+		return middleware
+	}
+	wrapped := make([]Middleware, 0, len(middleware)+1)
+	wrapped = append(wrapped, middleware...)
+	return append(wrapped, Timeout(d))
+}
+
+// idleTimer cancels a request after d without progress.
+type idleTimer struct {
+	d       time.Duration
+	timer   *time.Timer
+	expired atomic.Bool
+}
+
+// progress restarts the timeout, unless it already expired.
+func (t *idleTimer) progress() {
+	if !t.expired.Load() {
+		t.timer.Reset(t.d)
+	}
+}
+
+// wrap returns err as a timeout error if the timeout expired.
+func (t *idleTimer) wrap(err error) error {
+	if err == nil || err == io.EOF || !t.expired.Load() {
+		return err
+	}
+	return fmt.Errorf("%w: no progress for %s", ErrTimeout, t.d)
+}
+
+// timeoutBody is a request or response body that restarts its request's
+// timeout whenever data is read. Closing a response body releases the
+// request's context.
+type timeoutBody struct {
+	io.ReadCloser
+	timer  *idleTimer
+	cancel context.CancelFunc
+}
+
+func (b *timeoutBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if n > 0 {
+		b.timer.progress()
+	}
+	return n, b.timer.wrap(err)
+}
+
+func (b *timeoutBody) Close() error {
+	err := b.ReadCloser.Close()
+	if b.cancel != nil {
+		b.timer.timer.Stop()
+		b.cancel()
+	}
+	return err
+}
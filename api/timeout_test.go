@@ -0,0 +1,116 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_Timeout(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client, err := New(ClientConfig{InstanceURL: server.URL, HTTPClient: server.Client(), Timeout: 50 * time.Millisecond})
+	require.NoError(t, err)
+
+	start := time.Now()
+	_, err = client.Get(context.Background(), "/limits")
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ErrTimeout), "got %v", err)
+	assert.Contains(t, err.Error(), "request timed out: no progress for 50ms")
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
+
+func TestClient_Timeout_Retried(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			<-r.Context().Done()
+			return
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client, err := New(ClientConfig{
+		InstanceURL: server.URL,
+		HTTPClient:  server.Client(),
+		Timeout:     50 * time.Millisecond,
+		Retry:       RetryPolicy{MaxAttempts: 2},
+	})
+	require.NoError(t, err)
+
+	_, err = client.Get(context.Background(), "/limits")
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestTimeout_SlowBodyKeepsProgressing(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		flusher := w.(http.Flusher)
+		for i := 0; i < 5; i++ {
+			_, _ = w.Write([]byte("chunk "))
+			flusher.Flush()
+			time.Sleep(30 * time.Millisecond)
+		}
+	}))
+	defer server.Close()
+
+	// The whole body takes longer than the timeout, but no gap does
+	client := WrapHTTPClient(server.Client(), Timeout(100*time.Millisecond))
+
+	resp, err := client.Get(server.URL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, strings.Repeat("chunk ", 5), string(body))
+}
+
+func TestTimeout_StalledBody(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("partial"))
+		w.(http.Flusher).Flush()
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client := WrapHTTPClient(server.Client(), Timeout(50*time.Millisecond))
+
+	resp, err := client.Get(server.URL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	_, err = io.ReadAll(resp.Body)
+	assert.ErrorIs(t, err, ErrTimeout)
+}
+
+func TestWithTimeout(t *testing.T) {
+	middleware := []Middleware{Hooks(nil, nil)}
+
+	assert.Len(t, WithTimeout(middleware, 0), 1)
+	assert.Len(t, WithTimeout(middleware, time.Second), 2)
+	assert.Len(t, middleware, 1)
+}
@@ -8,8 +8,12 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"path"
 	"strings"
 	"time"
+
+	"github.com/open-cli-collective/salesforce-cli/api"
+	"github.com/open-cli-collective/salesforce-cli/api/soql"
 )
 
 // DefaultAPIVersion is the default Salesforce API version.
@@ -21,6 +25,8 @@ type Client struct {
 	instanceURL string
 	apiVersion  string
 	baseURL     string
+	retry       api.RetryPolicy
+	responses   *api.ResponseRecorder
 }
 
 // ClientConfig contains configuration for creating a new Tooling API client.
@@ -28,6 +34,23 @@ type ClientConfig struct {
 	InstanceURL string
 	HTTPClient  *http.Client
 	APIVersion  string
+	// Retry controls how requests failing with transient errors are retried
+	// (optional, defaults to a single attempt)
+	Retry api.RetryPolicy
+	// Middleware wraps the HTTP client's transport, first outermost
+	// (optional)
+	Middleware []api.Middleware
+	// Compression controls gzip compression of request and response bodies
+	// (optional, defaults to none beyond what the transport does itself)
+	Compression api.Compression
+
+	// Timeout fails a request attempt that makes no progress for this long
+	// (optional, defaults to none)
+	Timeout time.Duration
+
+	// RateLimiter limits how often requests are sent; share one between
+	// clients to limit them together (optional, defaults to no limit)
+	RateLimiter *api.RateLimiter
 }
 
 // New creates a new Tooling API client.
@@ -45,14 +68,30 @@ func New(cfg ClientConfig) (*Client, error) {
 		apiVersion = DefaultAPIVersion
 	}
 
+	// Responses are recorded outermost, as returned; compression and the
+	// timeout go innermost, to see the bytes on the wire
+	responses := &api.ResponseRecorder{}
+	middleware := api.WithResponseRecorder(cfg.Middleware, responses)
+	middleware = api.WithRateLimit(middleware, cfg.RateLimiter)
+	middleware = api.WithCompression(middleware, cfg.Compression)
+	middleware = api.WithTimeout(middleware, cfg.Timeout)
+
 	return &Client{
-		httpClient:  cfg.HTTPClient,
+		httpClient:  api.WrapHTTPClient(cfg.HTTPClient, middleware...),
 		instanceURL: instanceURL,
 		apiVersion:  apiVersion,
 		baseURL:     fmt.Sprintf("%s/services/data/%s/tooling", instanceURL, apiVersion),
+		retry:       cfg.Retry,
+		responses:   responses,
 	}, nil
 }
 
+// LastResponse returns the metadata of the last Tooling API response, or nil if
+// there has been none, as api.Client.LastResponse does.
+func (c *Client) LastResponse() *api.ResponseInfo {
+	return c.responses.Last()
+}
+
 // doRequest performs an HTTP request and returns the response body.
 func (c *Client) doRequest(ctx context.Context, method, path string, body interface{}) ([]byte, error) {
 	var bodyReader io.Reader
@@ -64,12 +103,7 @@ func (c *Client) doRequest(ctx context.Context, method, path string, body interf
 		bodyReader = bytes.NewReader(jsonBody)
 	}
 
-	fullURL := path
-	if !strings.HasPrefix(path, "http") {
-		fullURL = c.baseURL + path
-	}
-
-	req, err := http.NewRequestWithContext(ctx, method, fullURL, bodyReader)
+	req, err := http.NewRequestWithContext(ctx, method, c.ResourceURL(path), bodyReader)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -77,7 +111,7 @@ func (c *Client) doRequest(ctx context.Context, method, path string, body interf
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Accept", "application/json")
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.retry.Do(c.httpClient, req)
 	if err != nil {
 		return nil, fmt.Errorf("request failed: %w", err)
 	}
@@ -95,6 +129,17 @@ func (c *Client) doRequest(ctx context.Context, method, path string, body interf
 	return respBody, nil
 }
 
+// ResourceURL returns the full URL that a request for path is sent to.
+func (c *Client) ResourceURL(path string) string {
+	if strings.HasPrefix(path, "/services/") {
+		return c.instanceURL + path
+	}
+	if strings.HasPrefix(path, "http") {
+		return path
+	}
+	return c.baseURL + path
+}
+
 // Get performs a GET request.
 func (c *Client) Get(ctx context.Context, path string) ([]byte, error) {
 	return c.doRequest(ctx, http.MethodGet, path, nil)
@@ -105,6 +150,12 @@ func (c *Client) Post(ctx context.Context, path string, body interface{}) ([]byt
 	return c.doRequest(ctx, http.MethodPost, path, body)
 }
 
+// Delete performs a DELETE request.
+func (c *Client) Delete(ctx context.Context, path string) error {
+	_, err := c.doRequest(ctx, http.MethodDelete, path, nil)
+	return err
+}
+
 // Query executes a SOQL query against the Tooling API.
 func (c *Client) Query(ctx context.Context, soql string) (*QueryResult, error) {
 	path := fmt.Sprintf("/query?q=%s", url.QueryEscape(soql))
@@ -121,6 +172,42 @@ func (c *Client) Query(ctx context.Context, soql string) (*QueryResult, error) {
 	return &result, nil
 }
 
+// QueryMore retrieves the next batch of records using the nextRecordsUrl
+// from a previous Tooling API query.
+func (c *Client) QueryMore(ctx context.Context, nextRecordsURL string) (*QueryResult, error) {
+	body, err := c.Get(ctx, c.instanceURL+nextRecordsURL)
+	if err != nil {
+		return nil, err
+	}
+
+	var result QueryResult
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse query result: %w", err)
+	}
+
+	return &result, nil
+}
+
+// QueryAll executes a Tooling API query and retrieves all results (handles pagination).
+func (c *Client) QueryAll(ctx context.Context, soql string) (*QueryResult, error) {
+	result, err := c.Query(ctx, soql)
+	if err != nil {
+		return nil, err
+	}
+
+	for !result.Done && result.NextRecordsURL != "" {
+		nextPage, err := c.QueryMore(ctx, result.NextRecordsURL)
+		if err != nil {
+			return nil, err
+		}
+		result.Records = append(result.Records, nextPage.Records...)
+		result.Done = nextPage.Done
+		result.NextRecordsURL = nextPage.NextRecordsURL
+	}
+
+	return result, nil
+}
+
 // ListApexClasses returns all Apex classes.
 func (c *Client) ListApexClasses(ctx context.Context) ([]ApexClass, error) {
 	soql := "SELECT Id, Name, Status, IsValid, ApiVersion, LengthWithoutComments, NamespacePrefix FROM ApexClass ORDER BY Name"
@@ -157,8 +244,15 @@ func (c *Client) ListApexTriggers(ctx context.Context) ([]ApexTrigger, error) {
 
 // GetApexClass returns an Apex class by name, including body.
 func (c *Client) GetApexClass(ctx context.Context, name string) (*ApexClass, error) {
-	soql := fmt.Sprintf("SELECT Id, Name, Body, Status, IsValid, ApiVersion, LengthWithoutComments, NamespacePrefix FROM ApexClass WHERE Name = '%s'", name)
-	result, err := c.Query(ctx, soql)
+	q, err := soql.Select("Id", "Name", "Body", "Status", "IsValid", "ApiVersion", "LengthWithoutComments", "NamespacePrefix").
+		From("ApexClass").
+		Where("Name = :name").
+		Bind("name", name).
+		Build()
+	if err != nil {
+		return nil, err
+	}
+	result, err := c.Query(ctx, q)
 	if err != nil {
 		return nil, err
 	}
@@ -173,8 +267,15 @@ func (c *Client) GetApexClass(ctx context.Context, name string) (*ApexClass, err
 
 // GetApexTrigger returns an Apex trigger by name, including body.
 func (c *Client) GetApexTrigger(ctx context.Context, name string) (*ApexTrigger, error) {
-	soql := fmt.Sprintf("SELECT Id, Name, Body, Status, IsValid, ApiVersion, TableEnumOrId, NamespacePrefix FROM ApexTrigger WHERE Name = '%s'", name)
-	result, err := c.Query(ctx, soql)
+	q, err := soql.Select("Id", "Name", "Body", "Status", "IsValid", "ApiVersion", "TableEnumOrId", "NamespacePrefix").
+		From("ApexTrigger").
+		Where("Name = :name").
+		Bind("name", name).
+		Build()
+	if err != nil {
+		return nil, err
+	}
+	result, err := c.Query(ctx, q)
 	if err != nil {
 		return nil, err
 	}
@@ -187,6 +288,18 @@ func (c *Client) GetApexTrigger(ctx context.Context, name string) (*ApexTrigger,
 	return &trigger, nil
 }
 
+// DeleteApexClass deletes an Apex class. Salesforce refuses to delete Apex
+// this way in production orgs, where it takes a destructive deploy.
+func (c *Client) DeleteApexClass(ctx context.Context, classID string) error {
+	return c.Delete(ctx, "/sobjects/ApexClass/"+classID)
+}
+
+// DeleteApexTrigger deletes an Apex trigger, with the same restriction as
+// DeleteApexClass.
+func (c *Client) DeleteApexTrigger(ctx context.Context, triggerID string) error {
+	return c.Delete(ctx, "/sobjects/ApexTrigger/"+triggerID)
+}
+
 // ExecuteAnonymous executes anonymous Apex code.
 func (c *Client) ExecuteAnonymous(ctx context.Context, code string) (*ExecuteAnonymousResult, error) {
 	path := fmt.Sprintf("/executeAnonymous?anonymousBody=%s", url.QueryEscape(code))
@@ -205,9 +318,13 @@ func (c *Client) ExecuteAnonymous(ctx context.Context, code string) (*ExecuteAno
 
 // RunTestsAsync enqueues Apex tests to run asynchronously.
 func (c *Client) RunTestsAsync(ctx context.Context, classIDs []string) (string, error) {
-	req := RunTestsRequest{
-		ClassIDs: classIDs,
-	}
+	return c.RunTests(ctx, RunTestsRequest{ClassIDs: classIDs})
+}
+
+// RunTests enqueues the classes, suites, or test methods of a request to run
+// asynchronously and returns the ID of the test job. Salesforce rejects a
+// request that names test methods along with classes or suites.
+func (c *Client) RunTests(ctx context.Context, req RunTestsRequest) (string, error) {
 	body, err := c.Post(ctx, "/runTestsAsynchronous", req)
 	if err != nil {
 		return "", err
@@ -223,13 +340,38 @@ func (c *Client) RunTestsAsync(ctx context.Context, classIDs []string) (string,
 	return jobID, nil
 }
 
+// RunTestsSync runs the tests of one class and waits for their results, which
+// include the coverage they produced. With no methods, all of the class's
+// test methods run. Salesforce only runs one class synchronously, and the
+// request lasts as long as the tests do.
+func (c *Client) RunTestsSync(ctx context.Context, classID string, methods []string) (*RunTestsSyncResult, error) {
+	req := RunTestsSyncRequest{
+		Tests: []TestItem{{ClassID: classID, TestMethods: methods}},
+	}
+	body, err := c.Post(ctx, "/runTestsSynchronous", req)
+	if err != nil {
+		return nil, err
+	}
+
+	var result RunTestsSyncResult
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse test results: %w", err)
+	}
+
+	return &result, nil
+}
+
 // GetTestResults returns test results for a given async job.
 func (c *Client) GetTestResults(ctx context.Context, asyncJobID string) ([]ApexTestResult, error) {
-	soql := fmt.Sprintf(
-		"SELECT Id, ApexClassId, ApexClass.Name, MethodName, Outcome, Message, StackTrace, RunTime, AsyncApexJobId FROM ApexTestResult WHERE AsyncApexJobId = '%s'",
-		asyncJobID,
-	)
-	result, err := c.Query(ctx, soql)
+	q, err := soql.Select("Id", "ApexClassId", "ApexClass.Name", "MethodName", "Outcome", "Message", "StackTrace", "RunTime", "AsyncApexJobId").
+		From("ApexTestResult").
+		Where("AsyncApexJobId = :jobId").
+		Bind("jobId", asyncJobID).
+		Build()
+	if err != nil {
+		return nil, err
+	}
+	result, err := c.Query(ctx, q)
 	if err != nil {
 		return nil, err
 	}
@@ -245,11 +387,15 @@ func (c *Client) GetTestResults(ctx context.Context, asyncJobID string) ([]ApexT
 
 // GetAsyncJobStatus returns the status of an async Apex job.
 func (c *Client) GetAsyncJobStatus(ctx context.Context, jobID string) (*AsyncApexJob, error) {
-	soql := fmt.Sprintf(
-		"SELECT Id, Status, JobItemsProcessed, TotalJobItems, NumberOfErrors, ExtendedStatus, CompletedDate FROM AsyncApexJob WHERE Id = '%s'",
-		jobID,
-	)
-	result, err := c.Query(ctx, soql)
+	q, err := soql.Select("Id", "Status", "JobItemsProcessed", "TotalJobItems", "NumberOfErrors", "ExtendedStatus", "CompletedDate").
+		From("AsyncApexJob").
+		Where("Id = :jobId").
+		Bind("jobId", jobID).
+		Build()
+	if err != nil {
+		return nil, err
+	}
+	result, err := c.Query(ctx, q)
 	if err != nil {
 		return nil, err
 	}
@@ -264,16 +410,33 @@ func (c *Client) GetAsyncJobStatus(ctx context.Context, jobID string) (*AsyncApe
 
 // ListApexLogs returns debug logs.
 func (c *Client) ListApexLogs(ctx context.Context, userID string, limit int) ([]ApexLog, error) {
-	soql := "SELECT Id, LogUserId, Operation, Request, Status, LogLength, DurationMilliseconds, StartTime, Location, Application FROM ApexLog"
-	if userID != "" {
-		soql += fmt.Sprintf(" WHERE LogUserId = '%s'", userID)
+	return c.ListApexLogsFiltered(ctx, ApexLogFilter{UserID: userID, Limit: limit})
+}
+
+// ListApexLogsFiltered returns debug logs matching the filter, newest first.
+func (c *Client) ListApexLogsFiltered(ctx context.Context, filter ApexLogFilter) ([]ApexLog, error) {
+	query := soql.Select("Id", "LogUserId", "Operation", "Request", "Status", "LogLength", "DurationMilliseconds", "StartTime", "Location", "Application").
+		From("ApexLog")
+
+	if filter.UserID != "" {
+		query.Where("LogUserId = :userId").Bind("userId", filter.UserID)
 	}
-	soql += " ORDER BY StartTime DESC"
-	if limit > 0 {
-		soql += fmt.Sprintf(" LIMIT %d", limit)
+	if !filter.Since.IsZero() {
+		query.Where("StartTime >= :since").Bind("since", filter.Since)
+	}
+	if !filter.Until.IsZero() {
+		query.Where("StartTime <= :until").Bind("until", filter.Until)
+	}
+	if filter.MinDurationMS > 0 {
+		query.Where("DurationMilliseconds > :minDuration").Bind("minDuration", filter.MinDurationMS)
 	}
 
-	result, err := c.Query(ctx, soql)
+	q, err := query.OrderBy("StartTime DESC").Limit(filter.Limit).Build()
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := c.Query(ctx, q)
 	if err != nil {
 		return nil, err
 	}
@@ -297,7 +460,7 @@ func (c *Client) GetApexLogBody(ctx context.Context, logID string) (string, erro
 		return "", fmt.Errorf("failed to create request: %w", err)
 	}
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.retry.Do(c.httpClient, req)
 	if err != nil {
 		return "", fmt.Errorf("request failed: %w", err)
 	}
@@ -315,6 +478,128 @@ func (c *Client) GetApexLogBody(ctx context.Context, logID string) (string, erro
 	return string(body), nil
 }
 
+// GetCurrentUserID returns the ID of the authenticated user.
+func (c *Client) GetCurrentUserID(ctx context.Context) (string, error) {
+	body, err := c.Get(ctx, c.instanceURL+"/services/oauth2/userinfo")
+	if err != nil {
+		return "", err
+	}
+
+	var info struct {
+		UserID string `json:"user_id"`
+	}
+	if err := json.Unmarshal(body, &info); err != nil {
+		return "", fmt.Errorf("failed to parse user info: %w", err)
+	}
+	if info.UserID == "" {
+		return "", fmt.Errorf("user info did not include a user ID")
+	}
+
+	return info.UserID, nil
+}
+
+// EnableDebugLogging makes sure debug logs are captured for the user until
+// the expiration time. If the user already has an active DEVELOPER_LOG trace
+// flag it is reused and an empty ID is returned; otherwise a new trace flag
+// is created and its ID returned so the caller can remove it afterwards.
+func (c *Client) EnableDebugLogging(ctx context.Context, userID string, expiration time.Time) (string, error) {
+	now := time.Now().UTC()
+	q, err := soql.Select("Id").
+		From("TraceFlag").
+		Where("TracedEntityId = :userId AND LogType = 'DEVELOPER_LOG' AND ExpirationDate > :now").
+		Bind("userId", userID).
+		Bind("now", now).
+		Limit(1).
+		Build()
+	if err != nil {
+		return "", err
+	}
+	active, err := c.Query(ctx, q)
+	if err != nil {
+		return "", err
+	}
+	if len(active.Records) > 0 {
+		return "", nil
+	}
+
+	debugLevelID, err := c.ensureDebugLevel(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return c.create(ctx, "TraceFlag", map[string]interface{}{
+		"TracedEntityId": userID,
+		"LogType":        "DEVELOPER_LOG",
+		"DebugLevelId":   debugLevelID,
+		"StartDate":      now.Format(soql.DateTimeFormat),
+		"ExpirationDate": expiration.UTC().Format(soql.DateTimeFormat),
+	})
+}
+
+// DeleteTraceFlag deletes a trace flag.
+func (c *Client) DeleteTraceFlag(ctx context.Context, traceFlagID string) error {
+	return c.Delete(ctx, "/sobjects/TraceFlag/"+traceFlagID)
+}
+
+// debugLevelName is the developer name of the debug level used by
+// EnableDebugLogging.
+const debugLevelName = "SFDC_CLI"
+
+// ensureDebugLevel returns the ID of the CLI's debug level, creating it if
+// needed. Only Apex code is logged at DEBUG so USER_DEBUG lines are kept
+// while the log stays small.
+func (c *Client) ensureDebugLevel(ctx context.Context) (string, error) {
+	q, err := soql.Select("Id").
+		From("DebugLevel").
+		Where("DeveloperName = :name").
+		Bind("name", debugLevelName).
+		Limit(1).
+		Build()
+	if err != nil {
+		return "", err
+	}
+	result, err := c.Query(ctx, q)
+	if err != nil {
+		return "", err
+	}
+	if len(result.Records) > 0 {
+		if id, ok := result.Records[0]["Id"].(string); ok {
+			return id, nil
+		}
+	}
+
+	return c.create(ctx, "DebugLevel", map[string]interface{}{
+		"DeveloperName": debugLevelName,
+		"MasterLabel":   debugLevelName,
+		"ApexCode":      "DEBUG",
+		"ApexProfiling": "NONE",
+		"Callout":       "NONE",
+		"Database":      "NONE",
+		"System":        "NONE",
+		"Validation":    "NONE",
+		"Visualforce":   "NONE",
+		"Workflow":      "NONE",
+	})
+}
+
+// create inserts a Tooling API sObject and returns its ID.
+func (c *Client) create(ctx context.Context, objectType string, fields map[string]interface{}) (string, error) {
+	body, err := c.Post(ctx, "/sobjects/"+objectType, fields)
+	if err != nil {
+		return "", err
+	}
+
+	var result CreateResult
+	if err := json.Unmarshal(body, &result); err != nil {
+		return "", fmt.Errorf("failed to parse create result: %w", err)
+	}
+	if !result.Success || result.ID == "" {
+		return "", fmt.Errorf("failed to create %s", objectType)
+	}
+
+	return result.ID, nil
+}
+
 // GetCodeCoverage returns aggregate code coverage for the org.
 func (c *Client) GetCodeCoverage(ctx context.Context) ([]ApexCodeCoverageAggregate, error) {
 	soql := "SELECT Id, ApexClassOrTriggerId, ApexClassOrTrigger.Name, NumLinesCovered, NumLinesUncovered FROM ApexCodeCoverageAggregate ORDER BY ApexClassOrTrigger.Name"
@@ -334,11 +619,15 @@ func (c *Client) GetCodeCoverage(ctx context.Context) ([]ApexCodeCoverageAggrega
 
 // GetCodeCoverageForClass returns aggregate code coverage for a specific class.
 func (c *Client) GetCodeCoverageForClass(ctx context.Context, className string) (*ApexCodeCoverageAggregate, error) {
-	soql := fmt.Sprintf(
-		"SELECT Id, ApexClassOrTriggerId, ApexClassOrTrigger.Name, NumLinesCovered, NumLinesUncovered FROM ApexCodeCoverageAggregate WHERE ApexClassOrTrigger.Name = '%s'",
-		className,
-	)
-	result, err := c.Query(ctx, soql)
+	q, err := soql.Select("Id", "ApexClassOrTriggerId", "ApexClassOrTrigger.Name", "NumLinesCovered", "NumLinesUncovered").
+		From("ApexCodeCoverageAggregate").
+		Where("ApexClassOrTrigger.Name = :name").
+		Bind("name", className).
+		Build()
+	if err != nil {
+		return nil, err
+	}
+	result, err := c.Query(ctx, q)
 	if err != nil {
 		return nil, err
 	}
@@ -351,10 +640,151 @@ func (c *Client) GetCodeCoverageForClass(ctx context.Context, className string)
 	return &cov, nil
 }
 
+// GetCodeCoverageLines returns aggregate code coverage with the covered and
+// uncovered line numbers of each class and trigger, only of the one named
+// className if it isn't empty.
+func (c *Client) GetCodeCoverageLines(ctx context.Context, className string) ([]ApexCodeCoverageAggregate, error) {
+	query := soql.Select("Id", "ApexClassOrTriggerId", "ApexClassOrTrigger.Name", "NumLinesCovered", "NumLinesUncovered", "Coverage").
+		From("ApexCodeCoverageAggregate").
+		OrderBy("ApexClassOrTrigger.Name")
+	if className != "" {
+		query.Where("ApexClassOrTrigger.Name = :name").Bind("name", className)
+	}
+	q, err := query.Build()
+	if err != nil {
+		return nil, err
+	}
+	result, err := c.QueryAll(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	coverage := make([]ApexCodeCoverageAggregate, 0, len(result.Records))
+	for _, rec := range result.Records {
+		coverage = append(coverage, recordToApexCodeCoverageAggregate(rec))
+	}
+
+	return coverage, nil
+}
+
+// maxSourceIDs is the most IDs looked up in one source query, keeping the
+// query's URL within limits.
+const maxSourceIDs = 200
+
+// GetApexSources returns the bodies of the Apex classes and triggers with
+// the given IDs, by ID. IDs of neither are missing from the map.
+func (c *Client) GetApexSources(ctx context.Context, ids []string) (map[string]string, error) {
+	// Key prefixes tell classes from triggers
+	byObject := make(map[string][]string)
+	for _, id := range ids {
+		switch {
+		case strings.HasPrefix(id, "01p"):
+			byObject["ApexClass"] = append(byObject["ApexClass"], id)
+		case strings.HasPrefix(id, "01q"):
+			byObject["ApexTrigger"] = append(byObject["ApexTrigger"], id)
+		}
+	}
+
+	sources := make(map[string]string, len(ids))
+	for _, object := range []string{"ApexClass", "ApexTrigger"} {
+		objectIDs := byObject[object]
+		for start := 0; start < len(objectIDs); start += maxSourceIDs {
+			end := start + maxSourceIDs
+			if end > len(objectIDs) {
+				end = len(objectIDs)
+			}
+			q, err := soql.Select("Id", "Body").
+				From(object).
+				Where("Id IN :ids").
+				Bind("ids", objectIDs[start:end]).
+				Build()
+			if err != nil {
+				return nil, err
+			}
+			result, err := c.QueryAll(ctx, q)
+			if err != nil {
+				return nil, err
+			}
+			for _, rec := range result.Records {
+				id, _ := rec["Id"].(string)
+				body, _ := rec["Body"].(string)
+				sources[id] = body
+			}
+		}
+	}
+	return sources, nil
+}
+
+// testCoverageFields are the ApexCodeCoverage fields queried for the
+// coverage of test methods.
+var testCoverageFields = []string{"Id", "ApexClassOrTriggerId", "ApexClassOrTrigger.Name", "ApexTestClassId", "ApexTestClass.Name", "TestMethodName", "NumLinesCovered", "NumLinesUncovered", "Coverage"}
+
+// GetCoverageByTest returns the per-test-method code coverage recorded in
+// the org, only of the class or trigger named className if it isn't empty.
+func (c *Client) GetCoverageByTest(ctx context.Context, className string) ([]ApexCodeCoverage, error) {
+	query := soql.Select(testCoverageFields...).
+		From("ApexCodeCoverage").
+		OrderBy("ApexTestClass.Name", "ApexClassOrTrigger.Name")
+	if className != "" {
+		query.Where("ApexClassOrTrigger.Name = :name").Bind("name", className)
+	}
+	q, err := query.Build()
+	if err != nil {
+		return nil, err
+	}
+	result, err := c.QueryAll(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	coverage := make([]ApexCodeCoverage, 0, len(result.Records))
+	for _, rec := range result.Records {
+		coverage = append(coverage, recordToApexCodeCoverage(rec))
+	}
+
+	return coverage, nil
+}
+
+// GetTestCoverage returns the per-test-method code coverage produced by the
+// given test classes. Salesforce keeps only the coverage from the most recent
+// run of each test method.
+func (c *Client) GetTestCoverage(ctx context.Context, testClassIDs []string) ([]ApexCodeCoverage, error) {
+	if len(testClassIDs) == 0 {
+		return nil, nil
+	}
+
+	q, err := soql.Select(testCoverageFields...).
+		From("ApexCodeCoverage").
+		Where("ApexTestClassId IN :ids").
+		Bind("ids", testClassIDs).
+		Build()
+	if err != nil {
+		return nil, err
+	}
+	result, err := c.QueryAll(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	coverage := make([]ApexCodeCoverage, 0, len(result.Records))
+	for _, rec := range result.Records {
+		coverage = append(coverage, recordToApexCodeCoverage(rec))
+	}
+
+	return coverage, nil
+}
+
 // GetApexClassID returns the ID of an Apex class by name.
 func (c *Client) GetApexClassID(ctx context.Context, className string) (string, error) {
-	soql := fmt.Sprintf("SELECT Id FROM ApexClass WHERE Name = '%s'", className)
-	result, err := c.Query(ctx, soql)
+	q, err := soql.Select("Id").
+		From("ApexClass").
+		Where("Name = :name").
+		Bind("name", className).
+		Build()
+	if err != nil {
+		return "", err
+	}
+	result, err := c.Query(ctx, q)
 	if err != nil {
 		return "", err
 	}
@@ -367,6 +797,103 @@ func (c *Client) GetApexClassID(ctx context.Context, className string) (string,
 	return id, nil
 }
 
+// GetApexClassIDs returns the IDs of Apex classes by name, only looking in
+// namespace if it isn't empty. Names that match no class are missing from
+// the map.
+func (c *Client) GetApexClassIDs(ctx context.Context, namespace string, classNames []string) (map[string]string, error) {
+	ids := make(map[string]string, len(classNames))
+	if len(classNames) == 0 {
+		return ids, nil
+	}
+
+	query := soql.Select("Id", "Name").
+		From("ApexClass").
+		Where("Name IN :names").
+		Bind("names", classNames)
+	if namespace != "" {
+		query.Where("NamespacePrefix = :ns").Bind("ns", namespace)
+	}
+	q, err := query.Build()
+	if err != nil {
+		return nil, err
+	}
+	result, err := c.QueryAll(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, rec := range result.Records {
+		name, _ := rec["Name"].(string)
+		id, _ := rec["Id"].(string)
+		// Names are unique within a namespace; without one, the first wins
+		if _, ok := ids[name]; !ok {
+			ids[name] = id
+		}
+	}
+	return ids, nil
+}
+
+// FindApexClasses returns the Apex classes whose names match pattern, in
+// which * matches any run of characters and ? any one character, ordered by
+// name. Only classes in namespace are returned if it isn't empty, and an
+// empty pattern matches every class.
+func (c *Client) FindApexClasses(ctx context.Context, namespace, pattern string) ([]ApexClass, error) {
+	if _, err := path.Match(pattern, ""); err != nil {
+		return nil, fmt.Errorf("invalid class pattern %q: %w", pattern, err)
+	}
+
+	query := soql.Select("Id", "Name", "Status", "IsValid", "ApiVersion", "LengthWithoutComments", "NamespacePrefix").
+		From("ApexClass").
+		OrderBy("Name")
+	if namespace != "" {
+		query.Where("NamespacePrefix = :ns").Bind("ns", namespace)
+	}
+	q, err := query.Build()
+	if err != nil {
+		return nil, err
+	}
+	result, err := c.QueryAll(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	// SOQL's LIKE can't escape its wildcards, so names are matched here
+	classes := make([]ApexClass, 0, len(result.Records))
+	for _, rec := range result.Records {
+		class := recordToApexClass(rec)
+		if pattern != "" {
+			if ok, _ := path.Match(pattern, class.Name); !ok {
+				continue
+			}
+		}
+		classes = append(classes, class)
+	}
+	return classes, nil
+}
+
+// GetApexTestSuiteID returns the ID of an Apex test suite by name.
+func (c *Client) GetApexTestSuiteID(ctx context.Context, suiteName string) (string, error) {
+	q, err := soql.Select("Id").
+		From("ApexTestSuite").
+		Where("TestSuiteName = :name").
+		Bind("name", suiteName).
+		Build()
+	if err != nil {
+		return "", err
+	}
+	result, err := c.Query(ctx, q)
+	if err != nil {
+		return "", err
+	}
+
+	if len(result.Records) == 0 {
+		return "", fmt.Errorf("apex test suite not found: %s", suiteName)
+	}
+
+	id, _ := result.Records[0]["Id"].(string)
+	return id, nil
+}
+
 // Helper functions to convert generic records to typed structs
 
 func recordToApexClass(rec Record) ApexClass {
@@ -541,9 +1068,67 @@ func recordToApexCodeCoverageAggregate(rec Record) ApexCodeCoverageAggregate {
 	if v, ok := rec["NumLinesUncovered"].(float64); ok {
 		cov.NumLinesUncovered = int(v)
 	}
+	if detail, ok := rec["Coverage"].(map[string]interface{}); ok {
+		cov.Coverage = &CoverageDetail{
+			CoveredLines:   lineNumbers(detail["coveredLines"]),
+			UncoveredLines: lineNumbers(detail["uncoveredLines"]),
+		}
+	}
 	return cov
 }
 
+func recordToApexCodeCoverage(rec Record) ApexCodeCoverage {
+	cov := ApexCodeCoverage{}
+	if v, ok := rec["Id"].(string); ok {
+		cov.ID = v
+	}
+	if v, ok := rec["ApexClassOrTriggerId"].(string); ok {
+		cov.ApexClassOrTriggerID = v
+	}
+	if nested, ok := rec["ApexClassOrTrigger"].(map[string]interface{}); ok {
+		if v, ok := nested["Name"].(string); ok {
+			cov.ApexClassOrTrigger.Name = v
+		}
+	}
+	if v, ok := rec["ApexTestClassId"].(string); ok {
+		cov.ApexTestClassID = v
+	}
+	if nested, ok := rec["ApexTestClass"].(map[string]interface{}); ok {
+		if v, ok := nested["Name"].(string); ok {
+			cov.ApexTestClass.Name = v
+		}
+	}
+	if v, ok := rec["TestMethodName"].(string); ok {
+		cov.TestMethodName = v
+	}
+	if v, ok := rec["NumLinesCovered"].(float64); ok {
+		cov.NumLinesCovered = int(v)
+	}
+	if v, ok := rec["NumLinesUncovered"].(float64); ok {
+		cov.NumLinesUncovered = int(v)
+	}
+	if detail, ok := rec["Coverage"].(map[string]interface{}); ok {
+		cov.Coverage.CoveredLines = lineNumbers(detail["coveredLines"])
+		cov.Coverage.UncoveredLines = lineNumbers(detail["uncoveredLines"])
+	}
+	return cov
+}
+
+// lineNumbers converts a JSON array of line numbers to ints.
+func lineNumbers(v interface{}) []int {
+	values, ok := v.([]interface{})
+	if !ok {
+		return nil
+	}
+	lines := make([]int, 0, len(values))
+	for _, value := range values {
+		if n, ok := value.(float64); ok {
+			lines = append(lines, int(n))
+		}
+	}
+	return lines
+}
+
 func parseTime(s string) (time.Time, error) {
 	// Salesforce datetime format
 	formats := []string{
@@ -5,10 +5,14 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/salesforce-cli/api"
 )
 
 func TestNew(t *testing.T) {
@@ -55,6 +59,69 @@ func TestNew(t *testing.T) {
 	}
 }
 
+func TestMiddleware(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "sfdc-test", r.Header.Get("X-Client"))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"totalSize":0,"done":true,"records":[]}`))
+	}))
+	defer server.Close()
+
+	var paths []string
+	client, err := New(ClientConfig{
+		InstanceURL: server.URL,
+		HTTPClient:  server.Client(),
+		Middleware: []api.Middleware{
+			api.Hooks(func(req *http.Request) error {
+				req.Header.Set("X-Client", "sfdc-test")
+				return nil
+			}, func(req *http.Request, resp *http.Response, err error) {
+				paths = append(paths, req.URL.Path)
+			}),
+		},
+	})
+	require.NoError(t, err)
+
+	_, err = client.Query(context.Background(), "SELECT Id FROM ApexClass")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"/services/data/v62.0/tooling/query"}, paths)
+}
+
+func TestQueryAll(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/services/data/v62.0/tooling/query/01gxx0000000001-2000" {
+			_ = json.NewEncoder(w).Encode(QueryResult{
+				TotalSize: 2,
+				Done:      true,
+				Records:   []Record{{"Id": "7tf000000000002"}},
+			})
+			return
+		}
+		assert.Equal(t, "/services/data/v62.0/tooling/query", r.URL.Path)
+		_ = json.NewEncoder(w).Encode(QueryResult{
+			TotalSize:      2,
+			Done:           false,
+			NextRecordsURL: "/services/data/v62.0/tooling/query/01gxx0000000001-2000",
+			Records:        []Record{{"Id": "7tf000000000001"}},
+		})
+	}))
+	defer server.Close()
+
+	client, err := New(ClientConfig{
+		InstanceURL: server.URL,
+		HTTPClient:  server.Client(),
+	})
+	require.NoError(t, err)
+
+	result, err := client.QueryAll(context.Background(), "SELECT Id FROM TraceFlag")
+	require.NoError(t, err)
+
+	assert.True(t, result.Done)
+	assert.Len(t, result.Records, 2)
+	assert.Equal(t, "7tf000000000002", result.Records[1]["Id"])
+}
+
 func TestListApexClasses(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		assert.Contains(t, r.URL.Path, "/tooling/query")
@@ -197,6 +264,26 @@ func TestGetApexClassNotFound(t *testing.T) {
 	assert.Contains(t, err.Error(), "not found")
 }
 
+func TestGetApexClassIDEscapesName(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, `SELECT Id FROM ApexClass WHERE Name = 'x\' OR Name != \''`, r.URL.Query().Get("q"))
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(QueryResult{TotalSize: 0, Done: true, Records: []Record{}})
+	}))
+	defer server.Close()
+
+	client, err := New(ClientConfig{
+		InstanceURL: server.URL,
+		HTTPClient:  server.Client(),
+	})
+	require.NoError(t, err)
+
+	_, err = client.GetApexClassID(context.Background(), "x' OR Name != '")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "not found")
+}
+
 func TestExecuteAnonymous(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		assert.Contains(t, r.URL.Path, "/executeAnonymous")
@@ -273,6 +360,101 @@ func TestRunTestsAsync(t *testing.T) {
 	assert.Equal(t, "7071x00000ABCDE", jobID)
 }
 
+func TestFindApexClasses(t *testing.T) {
+	var query string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query().Get("q")
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(QueryResult{TotalSize: 3, Done: true, Records: []Record{
+			{"Id": "01p1", "Name": "Account_Test", "NamespacePrefix": "acme"},
+			{"Id": "01p2", "Name": "AccountService", "NamespacePrefix": "acme"},
+			{"Id": "01p3", "Name": "Contact_Test", "NamespacePrefix": "acme"},
+		}})
+	}))
+	defer server.Close()
+
+	client, err := New(ClientConfig{
+		InstanceURL: server.URL,
+		HTTPClient:  server.Client(),
+	})
+	require.NoError(t, err)
+
+	classes, err := client.FindApexClasses(context.Background(), "acme", "*_Test")
+	require.NoError(t, err)
+	assert.Contains(t, query, "WHERE NamespacePrefix = 'acme'")
+	require.Len(t, classes, 2)
+	assert.Equal(t, "Account_Test", classes[0].Name)
+	assert.Equal(t, "Contact_Test", classes[1].Name)
+
+	_, err = client.FindApexClasses(context.Background(), "", "[Test")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid class pattern")
+}
+
+func TestRunTestsWithMethods(t *testing.T) {
+	var body map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Contains(t, r.URL.Path, "/runTestsAsynchronous")
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`"7071x00000ABCDE"`))
+	}))
+	defer server.Close()
+
+	client, err := New(ClientConfig{
+		InstanceURL: server.URL,
+		HTTPClient:  server.Client(),
+	})
+	require.NoError(t, err)
+
+	jobID, err := client.RunTests(context.Background(), RunTestsRequest{
+		Tests: []TestItem{{ClassID: "01p1", TestMethods: []string{"testInsert"}}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "7071x00000ABCDE", jobID)
+	assert.Equal(t, []interface{}{map[string]interface{}{"classId": "01p1", "testMethods": []interface{}{"testInsert"}}}, body["tests"])
+	assert.NotContains(t, body, "classids")
+}
+
+func TestRunTestsSync(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Contains(t, r.URL.Path, "/runTestsSynchronous")
+
+		var req RunTestsSyncRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, []TestItem{{ClassID: "01p000000000001", TestMethods: []string{"testFailure"}}}, req.Tests)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{
+			"numTestsRun": 1,
+			"numFailures": 1,
+			"totalTime": 210.0,
+			"successes": [],
+			"failures": [{"id": "01p000000000001", "name": "MyTest", "namespace": null, "methodName": "testFailure", "message": "System.AssertException: Assertion Failed", "stackTrace": "Class.MyTest.testFailure: line 5, column 1", "type": "Class", "time": 200.0}],
+			"codeCoverage": [{"id": "01p000000000002", "name": "MyClass", "namespace": null, "type": "Class", "numLocations": 10, "numLocationsNotCovered": 2, "locationsNotCovered": [{"line": 7, "column": 0, "numExecutions": 0, "time": -1.0}]}],
+			"codeCoverageWarnings": []
+		}`))
+	}))
+	defer server.Close()
+
+	client, err := New(ClientConfig{
+		InstanceURL: server.URL,
+		HTTPClient:  server.Client(),
+	})
+	require.NoError(t, err)
+
+	result, err := client.RunTestsSync(context.Background(), "01p000000000001", []string{"testFailure"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.NumFailures)
+	require.Len(t, result.Failures, 1)
+	assert.Equal(t, "testFailure", result.Failures[0].MethodName)
+	assert.Equal(t, "Class.MyTest.testFailure: line 5, column 1", result.Failures[0].StackTrace)
+	require.Len(t, result.CodeCoverage, 1)
+	assert.Equal(t, 10, result.CodeCoverage[0].NumLocations)
+	assert.Equal(t, []CodeLocation{{Line: 7}}, result.CodeCoverage[0].LocationsNotCovered)
+}
+
 func TestGetTestResults(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		response := QueryResult{
@@ -360,6 +542,63 @@ func TestListApexLogs(t *testing.T) {
 	assert.Equal(t, 5000, logs[0].LogLength)
 }
 
+func TestListApexLogsFiltered(t *testing.T) {
+	tests := []struct {
+		name        string
+		filter      ApexLogFilter
+		wantContain []string
+		wantMissing []string
+	}{
+		{
+			name:        "no filter",
+			filter:      ApexLogFilter{},
+			wantMissing: []string{"WHERE", "LIMIT"},
+		},
+		{
+			name: "time window",
+			filter: ApexLogFilter{
+				Since: time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC),
+				Until: time.Date(2024, 1, 15, 11, 0, 0, 0, time.FixedZone("CET", 3600)),
+			},
+			wantContain: []string{"WHERE StartTime >= 2024-01-15T10:00:00Z AND StartTime <= 2024-01-15T10:00:00Z"},
+		},
+		{
+			name:        "duration and user",
+			filter:      ApexLogFilter{UserID: "005000000000001", MinDurationMS: 2000, Limit: 5},
+			wantContain: []string{"WHERE LogUserId = '005000000000001' AND DurationMilliseconds > 2000", "LIMIT 5"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var soql string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				soql = r.URL.Query().Get("q")
+				w.Header().Set("Content-Type", "application/json")
+				_ = json.NewEncoder(w).Encode(QueryResult{Done: true, Records: []Record{}})
+			}))
+			defer server.Close()
+
+			client, err := New(ClientConfig{
+				InstanceURL: server.URL,
+				HTTPClient:  server.Client(),
+			})
+			require.NoError(t, err)
+
+			_, err = client.ListApexLogsFiltered(context.Background(), tt.filter)
+			require.NoError(t, err)
+
+			assert.Contains(t, soql, "ORDER BY StartTime DESC")
+			for _, want := range tt.wantContain {
+				assert.Contains(t, soql, want)
+			}
+			for _, missing := range tt.wantMissing {
+				assert.NotContains(t, soql, missing)
+			}
+		})
+	}
+}
+
 func TestGetApexLogBody(t *testing.T) {
 	logContent := "DEBUG|Hello World\nUSER_DEBUG|Test message"
 
@@ -382,6 +621,127 @@ func TestGetApexLogBody(t *testing.T) {
 	assert.Equal(t, logContent, body)
 }
 
+func TestGetCurrentUserID(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/services/oauth2/userinfo", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]string{"user_id": "005000000000001"})
+	}))
+	defer server.Close()
+
+	client, err := New(ClientConfig{
+		InstanceURL: server.URL,
+		HTTPClient:  server.Client(),
+	})
+	require.NoError(t, err)
+
+	userID, err := client.GetCurrentUserID(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "005000000000001", userID)
+}
+
+func TestEnableDebugLogging(t *testing.T) {
+	tests := []struct {
+		name          string
+		activeFlag    bool
+		debugLevel    bool
+		wantID        string
+		wantCreations []string
+	}{
+		{
+			name:       "reuses active trace flag",
+			activeFlag: true,
+			wantID:     "",
+		},
+		{
+			name:          "creates trace flag with existing debug level",
+			debugLevel:    true,
+			wantID:        "7tf000000000001",
+			wantCreations: []string{"TraceFlag"},
+		},
+		{
+			name:          "creates debug level and trace flag",
+			wantID:        "7tf000000000001",
+			wantCreations: []string{"DebugLevel", "TraceFlag"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var creations []string
+			var traceFlag map[string]interface{}
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+
+				if r.Method == http.MethodPost {
+					objectType := r.URL.Path[len("/services/data/v62.0/tooling/sobjects/"):]
+					creations = append(creations, objectType)
+					if objectType == "TraceFlag" {
+						_ = json.NewDecoder(r.Body).Decode(&traceFlag)
+						_ = json.NewEncoder(w).Encode(CreateResult{ID: "7tf000000000001", Success: true})
+						return
+					}
+					_ = json.NewEncoder(w).Encode(CreateResult{ID: "7dl000000000002", Success: true})
+					return
+				}
+
+				q := r.URL.Query().Get("q")
+				var records []Record
+				if strings.Contains(q, "FROM TraceFlag") && tt.activeFlag {
+					records = []Record{{"Id": "7tf000000000009"}}
+				}
+				if strings.Contains(q, "FROM DebugLevel") && tt.debugLevel {
+					records = []Record{{"Id": "7dl000000000001"}}
+				}
+				_ = json.NewEncoder(w).Encode(QueryResult{TotalSize: len(records), Done: true, Records: records})
+			}))
+			defer server.Close()
+
+			client, err := New(ClientConfig{
+				InstanceURL: server.URL,
+				HTTPClient:  server.Client(),
+			})
+			require.NoError(t, err)
+
+			expiration := time.Date(2024, 1, 15, 10, 10, 0, 0, time.UTC)
+			id, err := client.EnableDebugLogging(context.Background(), "005000000000001", expiration)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantID, id)
+			assert.Equal(t, tt.wantCreations, creations)
+
+			if tt.wantID != "" {
+				assert.Equal(t, "005000000000001", traceFlag["TracedEntityId"])
+				assert.Equal(t, "DEVELOPER_LOG", traceFlag["LogType"])
+				assert.Equal(t, "2024-01-15T10:10:00Z", traceFlag["ExpirationDate"])
+				if tt.debugLevel {
+					assert.Equal(t, "7dl000000000001", traceFlag["DebugLevelId"])
+				} else {
+					assert.Equal(t, "7dl000000000002", traceFlag["DebugLevelId"])
+				}
+			}
+		})
+	}
+}
+
+func TestDeleteTraceFlag(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodDelete, r.Method)
+		assert.Equal(t, "/services/data/v62.0/tooling/sobjects/TraceFlag/7tf000000000001", r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client, err := New(ClientConfig{
+		InstanceURL: server.URL,
+		HTTPClient:  server.Client(),
+	})
+	require.NoError(t, err)
+
+	err = client.DeleteTraceFlag(context.Background(), "7tf000000000001")
+	require.NoError(t, err)
+}
+
 func TestGetCodeCoverage(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		response := QueryResult{
@@ -475,3 +835,34 @@ func TestAPIError(t *testing.T) {
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "401")
 }
+
+func TestGetContainerAsyncRequest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Contains(t, r.URL.Path, "/sobjects/ContainerAsyncRequest/1drR")
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{
+			"Id": "1drR", "MetadataContainerId": "1dcC", "State": "Failed", "IsCheckOnly": false,
+			"DeployDetails": {"componentSuccesses": [], "componentFailures": [
+				{"componentType": "ApexClass", "fullName": "AccountService", "lineNumber": 3, "columnNumber": 12, "problem": "Variable does not exist: x", "problemType": "Error", "success": false}
+			]}
+		}`))
+	}))
+	defer server.Close()
+
+	client, err := New(ClientConfig{
+		InstanceURL: server.URL,
+		HTTPClient:  server.Client(),
+	})
+	require.NoError(t, err)
+
+	req, err := client.GetContainerAsyncRequest(context.Background(), "1drR")
+	require.NoError(t, err)
+	assert.Equal(t, "Failed", req.State)
+	require.NotNil(t, req.DeployDetails)
+	require.Len(t, req.DeployDetails.ComponentFailures, 1)
+	failure := req.DeployDetails.ComponentFailures[0]
+	assert.Equal(t, "AccountService", failure.FullName)
+	assert.Equal(t, 3, failure.LineNumber)
+	assert.Equal(t, 12, failure.ColumnNumber)
+	assert.Equal(t, "Variable does not exist: x", failure.Problem)
+}
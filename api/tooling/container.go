@@ -0,0 +1,67 @@
+package tooling
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// Saving changes to existing Apex classes goes through a MetadataContainer:
+// the new bodies are added to the container as ApexClassMembers, then a
+// ContainerAsyncRequest compiles and saves them together, reporting any
+// compile errors. New classes are created directly with CreateApexClass.
+
+// CreateApexClass creates an Apex class from its source, which Salesforce
+// compiles first, and returns the new class's ID. The class's name comes
+// from the source.
+func (c *Client) CreateApexClass(ctx context.Context, body string) (string, error) {
+	return c.create(ctx, "ApexClass", map[string]interface{}{"Body": body})
+}
+
+// CreateMetadataContainer creates a container for changes to save together.
+// Container names are unique and at most 32 characters.
+func (c *Client) CreateMetadataContainer(ctx context.Context, name string) (string, error) {
+	return c.create(ctx, "MetadataContainer", map[string]interface{}{"Name": name})
+}
+
+// AddApexClassMember adds the new body of an existing Apex class to a
+// container.
+func (c *Client) AddApexClassMember(ctx context.Context, containerID, classID, body string) (string, error) {
+	return c.create(ctx, "ApexClassMember", map[string]interface{}{
+		"MetadataContainerId": containerID,
+		"ContentEntityId":     classID,
+		"Body":                body,
+	})
+}
+
+// DeployContainer requests that the members of a container be compiled and
+// saved, or only compiled if checkOnly is set, and returns the request's ID
+// to follow with GetContainerAsyncRequest.
+func (c *Client) DeployContainer(ctx context.Context, containerID string, checkOnly bool) (string, error) {
+	return c.create(ctx, "ContainerAsyncRequest", map[string]interface{}{
+		"MetadataContainerId": containerID,
+		"IsCheckOnly":         checkOnly,
+	})
+}
+
+// GetContainerAsyncRequest returns the state of a container deploy, with the
+// compile errors of a failed one.
+func (c *Client) GetContainerAsyncRequest(ctx context.Context, requestID string) (*ContainerAsyncRequest, error) {
+	body, err := c.Get(ctx, "/sobjects/ContainerAsyncRequest/"+requestID)
+	if err != nil {
+		return nil, err
+	}
+
+	var req ContainerAsyncRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		return nil, fmt.Errorf("failed to parse container request: %w", err)
+	}
+
+	return &req, nil
+}
+
+// DeleteMetadataContainer deletes a container and its members. Saved
+// changes are kept.
+func (c *Client) DeleteMetadataContainer(ctx context.Context, containerID string) error {
+	return c.Delete(ctx, "/sobjects/MetadataContainer/"+containerID)
+}
@@ -0,0 +1,175 @@
+package tooling
+
+import "sort"
+
+// TestClassCoverage is the coverage one test class contributes to one class
+// or trigger: the lines any of its test methods cover.
+type TestClassCoverage struct {
+	TestClassID        string  `json:"testClassId"`
+	TestClassName      string  `json:"testClassName"`
+	ClassOrTriggerID   string  `json:"classOrTriggerId"`
+	ClassOrTriggerName string  `json:"classOrTriggerName"`
+	NumLinesCovered    int     `json:"numLinesCovered"`
+	NumLines           int     `json:"numLines"`
+	Percent            float64 `json:"percent"`
+}
+
+// LineCoverage is the combined coverage of a class or trigger, with the
+// lines no test covers.
+type LineCoverage struct {
+	ClassOrTriggerID   string  `json:"classOrTriggerId"`
+	ClassOrTriggerName string  `json:"classOrTriggerName"`
+	NumLinesCovered    int     `json:"numLinesCovered"`
+	NumLines           int     `json:"numLines"`
+	Percent            float64 `json:"percent"`
+	UncoveredLines     []int   `json:"uncoveredLines"`
+}
+
+// coveredLines collects the lines of a class or trigger, and those covered,
+// from per-test-method coverage. Rows without line detail only have counts,
+// so the most any of them reports is kept.
+type coveredLines struct {
+	name     string
+	covered  map[int]bool
+	all      map[int]bool
+	numCover int
+	numTotal int
+}
+
+func newCoveredLines(name string) *coveredLines {
+	return &coveredLines{name: name, covered: make(map[int]bool), all: make(map[int]bool)}
+}
+
+func (l *coveredLines) add(row ApexCodeCoverage) {
+	for _, line := range row.Coverage.CoveredLines {
+		l.covered[line] = true
+		l.all[line] = true
+	}
+	for _, line := range row.Coverage.UncoveredLines {
+		l.all[line] = true
+	}
+	if row.NumLinesCovered > l.numCover {
+		l.numCover = row.NumLinesCovered
+	}
+	if total := row.NumLinesCovered + row.NumLinesUncovered; total > l.numTotal {
+		l.numTotal = total
+	}
+}
+
+// counts returns the number of covered lines and of all lines, from line
+// detail where there is any.
+func (l *coveredLines) counts() (covered, total int) {
+	if len(l.all) > 0 {
+		return len(l.covered), len(l.all)
+	}
+	return l.numCover, l.numTotal
+}
+
+// CoverageByTestClass returns the coverage each test class contributes to
+// each class or trigger its test methods exercise, ordered by test class and
+// then class or trigger name. A class's line count is that of all of rows.
+func CoverageByTestClass(rows []ApexCodeCoverage) []TestClassCoverage {
+	type key struct{ test, class string }
+	byTest := make(map[key]*coveredLines)
+	testNames := make(map[string]string)
+	classes := make(map[string]*coveredLines)
+
+	for _, row := range rows {
+		k := key{row.ApexTestClassID, row.ApexClassOrTriggerID}
+		l, ok := byTest[k]
+		if !ok {
+			l = newCoveredLines(row.ApexClassOrTrigger.Name)
+			byTest[k] = l
+		}
+		l.add(row)
+
+		class, ok := classes[row.ApexClassOrTriggerID]
+		if !ok {
+			class = newCoveredLines(row.ApexClassOrTrigger.Name)
+			classes[row.ApexClassOrTriggerID] = class
+		}
+		class.add(row)
+
+		if name := row.ApexTestClass.Name; name != "" {
+			testNames[row.ApexTestClassID] = name
+		}
+	}
+
+	coverage := make([]TestClassCoverage, 0, len(byTest))
+	for k, l := range byTest {
+		covered, _ := l.counts()
+		_, total := classes[k.class].counts()
+		name := testNames[k.test]
+		if name == "" {
+			name = k.test
+		}
+		coverage = append(coverage, TestClassCoverage{
+			TestClassID:        k.test,
+			TestClassName:      name,
+			ClassOrTriggerID:   k.class,
+			ClassOrTriggerName: l.name,
+			NumLinesCovered:    covered,
+			NumLines:           total,
+			Percent:            linePercent(covered, total),
+		})
+	}
+
+	sort.Slice(coverage, func(i, j int) bool {
+		if coverage[i].TestClassName != coverage[j].TestClassName {
+			return coverage[i].TestClassName < coverage[j].TestClassName
+		}
+		return coverage[i].ClassOrTriggerName < coverage[j].ClassOrTriggerName
+	})
+
+	return coverage
+}
+
+// CombineCoverage returns the coverage of each class or trigger that rows
+// exercise, combined across their test methods, ordered by name. Uncovered
+// lines are only known for rows with line detail.
+func CombineCoverage(rows []ApexCodeCoverage) []LineCoverage {
+	classes := make(map[string]*coveredLines)
+	for _, row := range rows {
+		l, ok := classes[row.ApexClassOrTriggerID]
+		if !ok {
+			l = newCoveredLines(row.ApexClassOrTrigger.Name)
+			classes[row.ApexClassOrTriggerID] = l
+		}
+		l.add(row)
+	}
+
+	coverage := make([]LineCoverage, 0, len(classes))
+	for id, l := range classes {
+		covered, total := l.counts()
+		uncovered := make([]int, 0, len(l.all)-len(l.covered))
+		for line := range l.all {
+			if !l.covered[line] {
+				uncovered = append(uncovered, line)
+			}
+		}
+		sort.Ints(uncovered)
+
+		coverage = append(coverage, LineCoverage{
+			ClassOrTriggerID:   id,
+			ClassOrTriggerName: l.name,
+			NumLinesCovered:    covered,
+			NumLines:           total,
+			Percent:            linePercent(covered, total),
+			UncoveredLines:     uncovered,
+		})
+	}
+
+	sort.Slice(coverage, func(i, j int) bool {
+		return coverage[i].ClassOrTriggerName < coverage[j].ClassOrTriggerName
+	})
+
+	return coverage
+}
+
+// linePercent returns covered lines as a percentage of all lines.
+func linePercent(covered, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(covered) / float64(total) * 100
+}
@@ -0,0 +1,68 @@
+package tooling
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func coverageRow(testID, testName, method, classID, className string, covered, uncovered []int) ApexCodeCoverage {
+	row := ApexCodeCoverage{
+		ApexClassOrTriggerID: classID,
+		ApexTestClassID:      testID,
+		TestMethodName:       method,
+		NumLinesCovered:      len(covered),
+		NumLinesUncovered:    len(uncovered),
+		Coverage:             CoverageDetail{CoveredLines: covered, UncoveredLines: uncovered},
+	}
+	row.ApexClassOrTrigger.Name = className
+	row.ApexTestClass.Name = testName
+	return row
+}
+
+func TestCoverageByTestClass(t *testing.T) {
+	rows := []ApexCodeCoverage{
+		coverageRow("01pT2", "ContactTest", "testUpdate", "01pA", "AccountService", []int{1, 5}, []int{2, 3, 4}),
+		coverageRow("01pT1", "AccountTest", "testInsert", "01pA", "AccountService", []int{1, 2}, []int{3, 4, 5}),
+		coverageRow("01pT1", "AccountTest", "testUpdate", "01pA", "AccountService", []int{1, 3}, []int{2, 4, 5}),
+		coverageRow("01pT1", "AccountTest", "testInsert", "01pB", "AccountTrigger", []int{1}, nil),
+	}
+
+	coverage := CoverageByTestClass(rows)
+	require.Len(t, coverage, 3)
+
+	assert.Equal(t, "AccountTest", coverage[0].TestClassName)
+	assert.Equal(t, "AccountService", coverage[0].ClassOrTriggerName)
+	assert.Equal(t, 3, coverage[0].NumLinesCovered)
+	assert.Equal(t, 5, coverage[0].NumLines)
+	assert.InDelta(t, 60.0, coverage[0].Percent, 0.01)
+
+	assert.Equal(t, "AccountTrigger", coverage[1].ClassOrTriggerName)
+	assert.Equal(t, 1, coverage[1].NumLines)
+
+	assert.Equal(t, "ContactTest", coverage[2].TestClassName)
+	assert.Equal(t, 2, coverage[2].NumLinesCovered)
+}
+
+func TestCombineCoverage(t *testing.T) {
+	rows := []ApexCodeCoverage{
+		coverageRow("01pT1", "AccountTest", "testInsert", "01pA", "AccountService", []int{1, 2}, []int{3, 4, 5}),
+		coverageRow("01pT2", "ContactTest", "testUpdate", "01pA", "AccountService", []int{1, 5}, []int{2, 3, 4}),
+		{ApexClassOrTriggerID: "01pB", NumLinesCovered: 2, NumLinesUncovered: 2},
+	}
+	rows[2].ApexClassOrTrigger.Name = "AccountTrigger"
+
+	coverage := CombineCoverage(rows)
+	require.Len(t, coverage, 2)
+
+	assert.Equal(t, "AccountService", coverage[0].ClassOrTriggerName)
+	assert.Equal(t, 3, coverage[0].NumLinesCovered)
+	assert.Equal(t, 5, coverage[0].NumLines)
+	assert.Equal(t, []int{3, 4}, coverage[0].UncoveredLines)
+
+	// Counts only, without line detail
+	assert.Equal(t, 2, coverage[1].NumLinesCovered)
+	assert.Equal(t, 4, coverage[1].NumLines)
+	assert.Empty(t, coverage[1].UncoveredLines)
+}
@@ -27,6 +27,15 @@ type ApexTrigger struct {
 	NamespacePrefix string  `json:"NamespacePrefix,omitempty"`
 }
 
+// ApexLogFilter contains optional criteria for listing debug logs.
+type ApexLogFilter struct {
+	UserID        string
+	Since         time.Time // StartTime >= Since (ignored if zero)
+	Until         time.Time // StartTime <= Until (ignored if zero)
+	MinDurationMS int       // DurationMilliseconds > MinDurationMS (ignored if zero)
+	Limit         int
+}
+
 // ApexLog represents a debug log entry.
 type ApexLog struct {
 	ID             string    `json:"Id"`
@@ -74,9 +83,20 @@ type ApexCodeCoverage struct {
 	ApexClassOrTrigger   struct {
 		Name string `json:"Name"`
 	} `json:"ApexClassOrTrigger,omitempty"`
-	ApexTestClassID   string `json:"ApexTestClassId"`
-	NumLinesCovered   int    `json:"NumLinesCovered"`
-	NumLinesUncovered int    `json:"NumLinesUncovered"`
+	ApexTestClassID string `json:"ApexTestClassId"`
+	ApexTestClass   struct {
+		Name string `json:"Name"`
+	} `json:"ApexTestClass,omitempty"`
+	TestMethodName    string         `json:"TestMethodName,omitempty"`
+	NumLinesCovered   int            `json:"NumLinesCovered"`
+	NumLinesUncovered int            `json:"NumLinesUncovered"`
+	Coverage          CoverageDetail `json:"Coverage"`
+}
+
+// CoverageDetail lists the covered and uncovered line numbers of a class.
+type CoverageDetail struct {
+	CoveredLines   []int `json:"coveredLines"`
+	UncoveredLines []int `json:"uncoveredLines"`
 }
 
 // ApexCodeCoverageAggregate represents aggregate code coverage.
@@ -86,8 +106,9 @@ type ApexCodeCoverageAggregate struct {
 	ApexClassOrTrigger   struct {
 		Name string `json:"Name"`
 	} `json:"ApexClassOrTrigger,omitempty"`
-	NumLinesCovered   int `json:"NumLinesCovered"`
-	NumLinesUncovered int `json:"NumLinesUncovered"`
+	NumLinesCovered   int             `json:"NumLinesCovered"`
+	NumLinesUncovered int             `json:"NumLinesUncovered"`
+	Coverage          *CoverageDetail `json:"Coverage,omitempty"` // only when queried
 }
 
 // ExecuteAnonymousResult represents the result of executing anonymous Apex.
@@ -123,16 +144,128 @@ type QueryResult struct {
 	NextRecordsURL string   `json:"nextRecordsUrl,omitempty"`
 }
 
+// CreateResult represents the result of creating a Tooling API sObject.
+type CreateResult struct {
+	ID      string        `json:"id"`
+	Success bool          `json:"success"`
+	Errors  []interface{} `json:"errors"`
+}
+
 // Record represents a generic record from a Tooling API query.
 type Record map[string]interface{}
 
-// RunTestsRequest represents a request to run Apex tests.
+// RunTestsRequest represents a request to run Apex tests. Tests, which
+// names test methods, can't be combined with ClassIDs or SuiteIDs.
 type RunTestsRequest struct {
-	ClassIDs       []string `json:"classids,omitempty"`
-	SuiteIDs       []string `json:"suiteids,omitempty"`
-	MaxFailedTests int      `json:"maxFailedTests,omitempty"`
-	TestLevel      string   `json:"testLevel,omitempty"`
+	ClassIDs       []string   `json:"classids,omitempty"`
+	SuiteIDs       []string   `json:"suiteids,omitempty"`
+	Tests          []TestItem `json:"tests,omitempty"`
+	MaxFailedTests int        `json:"maxFailedTests,omitempty"`
+	TestLevel      string     `json:"testLevel,omitempty"`
 }
 
 // RunTestsAsyncResult represents the result of enqueuing tests.
 type RunTestsAsyncResult string
+
+// TestItem names a test class to run, optionally with only some of its
+// methods.
+type TestItem struct {
+	ClassID     string   `json:"classId"`
+	TestMethods []string `json:"testMethods,omitempty"`
+}
+
+// RunTestsSyncRequest represents a request to run Apex tests synchronously.
+type RunTestsSyncRequest struct {
+	Tests []TestItem `json:"tests"`
+}
+
+// RunTestsSyncResult represents the result of running Apex tests
+// synchronously, with the coverage the run produced.
+type RunTestsSyncResult struct {
+	NumTestsRun          int                   `json:"numTestsRun"`
+	NumFailures          int                   `json:"numFailures"`
+	TotalTime            float64               `json:"totalTime"` // milliseconds
+	Successes            []RunTestSuccess      `json:"successes"`
+	Failures             []RunTestFailure      `json:"failures"`
+	CodeCoverage         []CodeCoverageResult  `json:"codeCoverage"`
+	CodeCoverageWarnings []CodeCoverageWarning `json:"codeCoverageWarnings"`
+}
+
+// RunTestSuccess is a test method that passed in a synchronous run.
+type RunTestSuccess struct {
+	ID         string  `json:"id"`
+	Name       string  `json:"name"`
+	Namespace  string  `json:"namespace,omitempty"`
+	MethodName string  `json:"methodName"`
+	Time       float64 `json:"time"` // milliseconds
+}
+
+// RunTestFailure is a test method that failed in a synchronous run.
+type RunTestFailure struct {
+	ID         string  `json:"id"`
+	Name       string  `json:"name"`
+	Namespace  string  `json:"namespace,omitempty"`
+	MethodName string  `json:"methodName"`
+	Message    string  `json:"message"`
+	StackTrace string  `json:"stackTrace,omitempty"`
+	Type       string  `json:"type,omitempty"`
+	Time       float64 `json:"time"` // milliseconds
+}
+
+// CodeCoverageResult is the coverage a synchronous run produced for one
+// class or trigger. Locations are lines.
+type CodeCoverageResult struct {
+	ID                     string         `json:"id"`
+	Name                   string         `json:"name"`
+	Namespace              string         `json:"namespace,omitempty"`
+	Type                   string         `json:"type"` // Class or Trigger
+	NumLocations           int            `json:"numLocations"`
+	NumLocationsNotCovered int            `json:"numLocationsNotCovered"`
+	LocationsNotCovered    []CodeLocation `json:"locationsNotCovered,omitempty"`
+}
+
+// CodeLocation is a line of code in a coverage result.
+type CodeLocation struct {
+	Line          int `json:"line"`
+	Column        int `json:"column"`
+	NumExecutions int `json:"numExecutions"`
+}
+
+// CodeCoverageWarning is a coverage problem a synchronous run reported,
+// such as a class below the required coverage.
+type CodeCoverageWarning struct {
+	ID        string `json:"id"`
+	Name      string `json:"name,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+	Message   string `json:"message"`
+}
+
+// ContainerAsyncRequest represents a request to compile and save the members
+// of a MetadataContainer.
+type ContainerAsyncRequest struct {
+	ID                  string         `json:"Id"`
+	MetadataContainerID string         `json:"MetadataContainerId"`
+	State               string         `json:"State"` // Queued, Completed, Failed, Error, Aborted, Invalidated
+	IsCheckOnly         bool           `json:"IsCheckOnly"`
+	ErrorMsg            string         `json:"ErrorMsg,omitempty"`
+	DeployDetails       *DeployDetails `json:"DeployDetails,omitempty"`
+}
+
+// DeployDetails lists the components a ContainerAsyncRequest compiled.
+type DeployDetails struct {
+	ComponentSuccesses []DeployMessage `json:"componentSuccesses"`
+	ComponentFailures  []DeployMessage `json:"componentFailures"`
+}
+
+// DeployMessage is the outcome of compiling one component. Failures give
+// the line and column of the problem.
+type DeployMessage struct {
+	ComponentType string `json:"componentType"`
+	FullName      string `json:"fullName"`
+	FileName      string `json:"fileName,omitempty"`
+	Success       bool   `json:"success"`
+	LineNumber    int    `json:"lineNumber,omitempty"`
+	ColumnNumber  int    `json:"columnNumber,omitempty"`
+	Problem       string `json:"problem,omitempty"`
+	ProblemType   string `json:"problemType,omitempty"` // Error or Warning
+}
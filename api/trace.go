@@ -0,0 +1,175 @@
+package api
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxTraceBody is the most of each request and response body that Trace
+// prints.
+const maxTraceBody = 4096
+
+// redacted replaces secrets in traced requests and responses.
+const redacted = "[REDACTED]"
+
+// sensitiveHeaders are the headers whose values Trace redacts.
+var sensitiveHeaders = map[string]bool{
+	"Authorization": true,
+	"Cookie":        true,
+	"Set-Cookie":    true,
+}
+
+// sensitiveBody matches secrets in JSON, form, and SOAP bodies: OAuth tokens,
+// client secrets, passwords, and session IDs.
+var sensitiveBody = []*regexp.Regexp{
+	regexp.MustCompile(`("(?:access_token|refresh_token|id_token|client_secret|password|token)"\s*:\s*")[^"]*`),
+	regexp.MustCompile(`((?:^|&)(?:access_token|refresh_token|client_secret|password|token|assertion|code|code_verifier)=)[^&]*`),
+	regexp.MustCompile(`(<(?:\w+:)?(?:sessionId|password)>)[^<]*`),
+}
+
+// Trace returns a Middleware that writes each request and response to w:
+// the method, URL, headers, and body of the request, and the status,
+// latency, headers, and body of the response. Bodies are truncated, and
+// Authorization headers, tokens, and passwords are redacted.
+func Trace(w io.Writer) Middleware {
+	var mu sync.Mutex
+
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			reqBody, err := peekRequestBody(req)
+			if err != nil {
+				return nil, err
+			}
+
+			start := time.Now()
+			resp, err := next.RoundTrip(req)
+			latency := time.Since(start).Round(time.Millisecond)
+
+			var b strings.Builder
+			fmt.Fprintf(&b, "--> %s %s\n", req.Method, req.URL.Redacted())
+			writeTraceHeaders(&b, req.Header)
+			writeTraceBody(&b, decodeTraceBody(reqBody, req.Header))
+
+			if err == nil {
+				var respBody []byte
+				respBody, err = io.ReadAll(resp.Body)
+				resp.Body.Close()
+				resp.Body = io.NopCloser(bytes.NewReader(respBody))
+				if err == nil {
+					fmt.Fprintf(&b, "<-- %s (%s)\n", resp.Status, latency)
+					writeTraceHeaders(&b, resp.Header)
+					writeTraceBody(&b, decodeTraceBody(respBody, resp.Header))
+				} else {
+					resp = nil
+				}
+			}
+			if err != nil {
+				fmt.Fprintf(&b, "<-- error after %s: %v\n", latency, err)
+			}
+			b.WriteString("\n")
+
+			mu.Lock()
+			_, _ = io.WriteString(w, b.String())
+			mu.Unlock()
+
+			return resp, err
+		})
+	}
+}
+
+// peekRequestBody returns the body of req without consuming it.
+func peekRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		defer body.Close()
+		return io.ReadAll(body)
+	}
+
+	data, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(data))
+	return data, nil
+}
+
+// decodeTraceBody returns a gzip-encoded body decompressed, so that it can
+// be read, or body itself if it is not gzipped or fails to decompress.
+func decodeTraceBody(body []byte, header http.Header) []byte {
+	if len(body) == 0 || !strings.EqualFold(header.Get("Content-Encoding"), "gzip") {
+		return body
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(body))
+	if err != nil {
+		return body
+	}
+	decoded, err := io.ReadAll(zr)
+	if err != nil {
+		return body
+	}
+	return decoded
+}
+
+func writeTraceHeaders(b *strings.Builder, header http.Header) {
+	names := make([]string, 0, len(header))
+	for name := range header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		for _, value := range header[name] {
+			if sensitiveHeaders[http.CanonicalHeaderKey(name)] {
+				value = redactHeader(value)
+			}
+			fmt.Fprintf(b, "%s: %s\n", name, value)
+		}
+	}
+}
+
+// redactHeader redacts a header value, keeping the scheme of credentials
+// such as "Bearer <token>".
+func redactHeader(value string) string {
+	if scheme, _, ok := strings.Cut(value, " "); ok && !strings.Contains(scheme, "=") {
+		return scheme + " " + redacted
+	}
+	return redacted
+}
+
+func writeTraceBody(b *strings.Builder, body []byte) {
+	if len(body) == 0 {
+		return
+	}
+
+	text := redactBody(string(body))
+	b.WriteString("\n")
+	if len(text) > maxTraceBody {
+		fmt.Fprintf(b, "%s\n... (%d more bytes)\n", text[:maxTraceBody], len(text)-maxTraceBody)
+		return
+	}
+	b.WriteString(strings.TrimRight(text, "\n"))
+	b.WriteString("\n")
+}
+
+// redactBody replaces secrets in a request or response body.
+func redactBody(body string) string {
+	for _, re := range sensitiveBody {
+		body = re.ReplaceAllString(body, "${1}"+redacted)
+	}
+	return body
+}
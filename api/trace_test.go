@@ -0,0 +1,89 @@
+package api
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrace(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		assert.Equal(t, `{"Name":"Acme"}`, string(body), "request body should still be sent")
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id":"001xx000001","access_token":"00Dxx!secret"}`))
+	}))
+	defer server.Close()
+
+	var trace bytes.Buffer
+	client := WrapHTTPClient(server.Client(), Trace(&trace))
+
+	req, err := http.NewRequest(http.MethodPost, server.URL+"/services/data/v62.0/sobjects/Account", strings.NewReader(`{"Name":"Acme"}`))
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer 00Dxx!token")
+
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Contains(t, string(body), "00Dxx!secret", "the caller should get the unredacted response")
+
+	out := trace.String()
+	assert.Contains(t, out, "--> POST "+server.URL+"/services/data/v62.0/sobjects/Account")
+	assert.Contains(t, out, "Authorization: Bearer [REDACTED]")
+	assert.Contains(t, out, `{"Name":"Acme"}`)
+	assert.Contains(t, out, "<-- 201 Created (")
+	assert.Contains(t, out, `"access_token":"[REDACTED]"`)
+	assert.NotContains(t, out, "00Dxx!token")
+	assert.NotContains(t, out, "00Dxx!secret")
+}
+
+func TestTrace_TruncatesBody(t *testing.T) {
+	large := strings.Repeat("x", maxTraceBody+100)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(large))
+	}))
+	defer server.Close()
+
+	var trace bytes.Buffer
+	client := WrapHTTPClient(server.Client(), Trace(&trace))
+
+	resp, err := client.Get(server.URL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, large, string(body))
+	assert.Contains(t, trace.String(), "... (100 more bytes)")
+}
+
+func TestRedactBody(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{"JSON token", `{"access_token": "abc", "instance_url": "https://x"}`, `{"access_token": "[REDACTED]", "instance_url": "https://x"}`},
+		{"form secrets", "grant_type=refresh_token&refresh_token=abc&client_secret=def", "grant_type=refresh_token&refresh_token=[REDACTED]&client_secret=[REDACTED]"},
+		{"form token revoke", "token=abc", "token=[REDACTED]"},
+		{"SOAP session", "<urn:sessionId>abc</urn:sessionId>", "<urn:sessionId>[REDACTED]</urn:sessionId>"},
+		{"SOAP password", "<urn:password>hunter2</urn:password>", "<urn:password>[REDACTED]</urn:password>"},
+		{"nothing secret", `{"Name":"Acme"}`, `{"Name":"Acme"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, redactBody(tt.body))
+		})
+	}
+}
@@ -24,6 +24,10 @@ type SObject struct {
 	// Fields contains all other fields as a map
 	// Use GetString, GetBool, etc. helpers to access typed values
 	Fields map[string]interface{} `json:"-"`
+
+	// ETag is the record version returned by GetRecord, for use with
+	// Conditions.IfMatch (empty for objects without ETag support)
+	ETag string `json:"-"`
 }
 
 // SObjectAttributes contains metadata about an SObject record
@@ -199,21 +203,39 @@ type SObjectDescribe struct {
 
 // Field represents a field on an SObject
 type Field struct {
-	Name              string          `json:"name"`
-	Label             string          `json:"label"`
-	Type              string          `json:"type"`
-	Length            int             `json:"length,omitempty"`
-	Precision         int             `json:"precision,omitempty"`
-	Scale             int             `json:"scale,omitempty"`
-	Nillable          bool            `json:"nillable"`
-	Createable        bool            `json:"createable"`
-	Updateable        bool            `json:"updateable"`
-	Custom            bool            `json:"custom"`
-	CalculatedFormula string          `json:"calculatedFormula,omitempty"`
-	DefaultValue      interface{}     `json:"defaultValue,omitempty"`
-	PicklistValues    []PicklistValue `json:"picklistValues,omitempty"`
-	ReferenceTo       []string        `json:"referenceTo,omitempty"`
-	RelationshipName  string          `json:"relationshipName,omitempty"`
+	Name                   string          `json:"name"`
+	Label                  string          `json:"label"`
+	Type                   string          `json:"type"`
+	Length                 int             `json:"length,omitempty"`
+	Precision              int             `json:"precision,omitempty"`
+	Scale                  int             `json:"scale,omitempty"`
+	Nillable               bool            `json:"nillable"`
+	Createable             bool            `json:"createable"`
+	Updateable             bool            `json:"updateable"`
+	Custom                 bool            `json:"custom"`
+	Filterable             bool            `json:"filterable"`
+	Sortable               bool            `json:"sortable"`
+	Groupable              bool            `json:"groupable"`
+	CalculatedFormula      string          `json:"calculatedFormula,omitempty"`
+	DefaultValue           interface{}     `json:"defaultValue,omitempty"`
+	DefaultedOnCreate      bool            `json:"defaultedOnCreate"`
+	PicklistValues         []PicklistValue `json:"picklistValues,omitempty"`
+	ReferenceTo            []string        `json:"referenceTo,omitempty"`
+	RelationshipName       string          `json:"relationshipName,omitempty"`
+	InlineHelpText         string          `json:"inlineHelpText,omitempty"`
+	SecurityClassification string          `json:"securityClassification,omitempty"`
+	ComplianceGroup        string          `json:"complianceGroup,omitempty"`
+}
+
+// IsSensitive returns true if the field has a data classification or compliance category set
+func (f Field) IsSensitive() bool {
+	return f.SecurityClassification != "" || f.ComplianceGroup != ""
+}
+
+// IsRequiredOnCreate returns true if a value must be supplied for the field when
+// inserting a record: it is createable, not nillable, and has no default.
+func (f Field) IsRequiredOnCreate() bool {
+	return f.Createable && !f.Nillable && !f.DefaultedOnCreate && f.DefaultValue == nil
 }
 
 // PicklistValue represents a picklist option
@@ -240,6 +262,17 @@ type LimitInfo struct {
 	Remaining int `json:"Remaining"`
 }
 
+// RecordCountResponse is the response of the record count resource
+type RecordCountResponse struct {
+	SObjects []RecordCount `json:"sObjects"`
+}
+
+// RecordCount is the approximate number of records of an object
+type RecordCount struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
 // SearchResult represents the result of a SOSL search
 type SearchResult struct {
 	SearchRecords []SearchRecord `json:"searchRecords"`
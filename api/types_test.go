@@ -235,7 +235,13 @@ func TestSObjectDescribe(t *testing.T) {
 				"length": 255,
 				"nillable": false,
 				"createable": true,
-				"updateable": true
+				"updateable": true,
+				"filterable": true,
+				"sortable": true,
+				"groupable": true,
+				"inlineHelpText": "Legal name of the account",
+				"securityClassification": "Internal",
+				"complianceGroup": "PII;GDPR"
 			}
 		]
 	}`
@@ -252,6 +258,35 @@ func TestSObjectDescribe(t *testing.T) {
 	assert.Len(t, desc.Fields, 2)
 	assert.Equal(t, "id", desc.Fields[0].Type)
 	assert.Equal(t, 255, desc.Fields[1].Length)
+	assert.Equal(t, "Legal name of the account", desc.Fields[1].InlineHelpText)
+	assert.Equal(t, "Internal", desc.Fields[1].SecurityClassification)
+	assert.Equal(t, "PII;GDPR", desc.Fields[1].ComplianceGroup)
+	assert.False(t, desc.Fields[0].IsSensitive())
+	assert.True(t, desc.Fields[1].IsSensitive())
+	assert.True(t, desc.Fields[1].Filterable)
+	assert.True(t, desc.Fields[1].Sortable)
+	assert.True(t, desc.Fields[1].Groupable)
+	assert.False(t, desc.Fields[0].Groupable)
+}
+
+func TestField_IsRequiredOnCreate(t *testing.T) {
+	tests := []struct {
+		name  string
+		field Field
+		want  bool
+	}{
+		{"required", Field{Createable: true}, true},
+		{"nillable", Field{Createable: true, Nillable: true}, false},
+		{"not createable", Field{}, false},
+		{"defaulted on create", Field{Createable: true, DefaultedOnCreate: true}, false},
+		{"default value", Field{Createable: true, DefaultValue: "Open"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.field.IsRequiredOnCreate())
+		})
+	}
 }
 
 func TestAPIVersion(t *testing.T) {
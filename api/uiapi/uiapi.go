@@ -0,0 +1,323 @@
+// Package uiapi calls the User Interface API, which returns object
+// metadata, records, and layouts with the org's runtime rules applied:
+// picklist values filtered by record type, fields the user can see, and
+// page layouts.
+//
+// It works on top of an api.Client, so requests share its authentication,
+// retries, and compression:
+//
+//	values, err := uiapi.New(client).PicklistValues(ctx, "Account", recordTypeID, "Industry")
+package uiapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/open-cli-collective/salesforce-cli/api"
+)
+
+// MasterRecordTypeID is the ID of the master record type, which objects
+// without record types use.
+const MasterRecordTypeID = "012000000000000AAA"
+
+// Layout types and modes accepted by Layout.
+const (
+	LayoutTypeFull    = "Full"
+	LayoutTypeCompact = "Compact"
+
+	ModeView   = "View"
+	ModeEdit   = "Edit"
+	ModeCreate = "Create"
+)
+
+// Client calls the User Interface API through a REST API client.
+type Client struct {
+	api *api.Client
+}
+
+// New returns a User Interface API client for client's org.
+func New(client *api.Client) *Client {
+	return &Client{api: client}
+}
+
+// ObjectInfo is the metadata of an object as the user sees it.
+type ObjectInfo struct {
+	APIName             string                    `json:"apiName"`
+	Label               string                    `json:"label"`
+	LabelPlural         string                    `json:"labelPlural"`
+	KeyPrefix           string                    `json:"keyPrefix"`
+	Custom              bool                      `json:"custom"`
+	Createable          bool                      `json:"createable"`
+	Updateable          bool                      `json:"updateable"`
+	Deletable           bool                      `json:"deletable"`
+	DefaultRecordTypeID string                    `json:"defaultRecordTypeId"`
+	Fields              map[string]Field          `json:"fields"`
+	RecordTypeInfos     map[string]RecordTypeInfo `json:"recordTypeInfos"`
+}
+
+// Field is a field of an object, as returned in ObjectInfo.
+type Field struct {
+	APIName        string `json:"apiName"`
+	Label          string `json:"label"`
+	DataType       string `json:"dataType"`
+	Required       bool   `json:"required"`
+	Createable     bool   `json:"createable"`
+	Updateable     bool   `json:"updateable"`
+	Custom         bool   `json:"custom"`
+	Length         int    `json:"length"`
+	ControllerName string `json:"controllerName,omitempty"`
+	InlineHelpText string `json:"inlineHelpText,omitempty"`
+}
+
+// IsPicklist reports whether the field is a picklist or multi-select
+// picklist.
+func (f Field) IsPicklist() bool {
+	return f.DataType == "Picklist" || f.DataType == "MultiPicklist"
+}
+
+// RecordTypeInfo is a record type of an object. Name is the record type's
+// label.
+type RecordTypeInfo struct {
+	RecordTypeID             string `json:"recordTypeId"`
+	Name                     string `json:"name"`
+	Master                   bool   `json:"master"`
+	Available                bool   `json:"available"`
+	DefaultRecordTypeMapping bool   `json:"defaultRecordTypeMapping"`
+}
+
+// RecordTypeID returns the ID of a record type given its ID, the 15-character
+// form of its ID, or its name (case-insensitive). An empty nameOrID returns
+// the user's default record type.
+func (o *ObjectInfo) RecordTypeID(nameOrID string) (string, error) {
+	if nameOrID == "" {
+		if o.DefaultRecordTypeID != "" {
+			return o.DefaultRecordTypeID, nil
+		}
+		return MasterRecordTypeID, nil
+	}
+
+	for id, info := range o.RecordTypeInfos {
+		if id == nameOrID || strings.EqualFold(info.Name, nameOrID) {
+			return id, nil
+		}
+		if len(nameOrID) == 15 && strings.HasPrefix(id, nameOrID) {
+			return id, nil
+		}
+	}
+
+	return "", fmt.Errorf("record type %q not found on %s", nameOrID, o.APIName)
+}
+
+// PicklistValue is a value of a picklist field. ValidFor lists the indexes,
+// in PicklistValues.ControllerValues, of the controlling field values the
+// value is valid for.
+type PicklistValue struct {
+	Label    string `json:"label"`
+	Value    string `json:"value"`
+	ValidFor []int  `json:"validFor"`
+}
+
+// PicklistValues are the values of a picklist field available for a record
+// type.
+type PicklistValues struct {
+	// ControllerValues maps the values of the controlling field, for
+	// dependent picklists, to the indexes used in PicklistValue.ValidFor
+	ControllerValues map[string]int  `json:"controllerValues"`
+	DefaultValue     *PicklistValue  `json:"defaultValue"`
+	Values           []PicklistValue `json:"values"`
+}
+
+// ValidFor returns the controlling field values a value is valid for.
+func (p *PicklistValues) ValidFor(value PicklistValue) []string {
+	controllers := make(map[int]string, len(p.ControllerValues))
+	for name, index := range p.ControllerValues {
+		controllers[index] = name
+	}
+
+	names := make([]string, 0, len(value.ValidFor))
+	for _, index := range value.ValidFor {
+		if name, ok := controllers[index]; ok {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// picklistFieldValues is the response listing the values of every picklist
+// field of an object.
+type picklistFieldValues struct {
+	PicklistFieldValues map[string]PicklistValues `json:"picklistFieldValues"`
+}
+
+// FieldValue is the value of a record field. DisplayValue is the formatted
+// value, e.g. a picklist label or a lookup's name, and is nil when it is the
+// same as Value.
+type FieldValue struct {
+	DisplayValue *string     `json:"displayValue"`
+	Value        interface{} `json:"value"`
+}
+
+// Record is a record as returned by the User Interface API.
+type Record struct {
+	APIName          string                `json:"apiName"`
+	ID               string                `json:"id"`
+	RecordTypeID     string                `json:"recordTypeId"`
+	LastModifiedDate string                `json:"lastModifiedDate"`
+	SystemModstamp   string                `json:"systemModstamp"`
+	Fields           map[string]FieldValue `json:"fields"`
+}
+
+// Layout is a page layout.
+type Layout struct {
+	ID         string          `json:"id"`
+	LayoutType string          `json:"layoutType"`
+	Mode       string          `json:"mode"`
+	Sections   []LayoutSection `json:"sections"`
+}
+
+// LayoutSection is a section of a page layout.
+type LayoutSection struct {
+	ID         string      `json:"id"`
+	Heading    string      `json:"heading"`
+	UseHeading bool        `json:"useHeading"`
+	Columns    int         `json:"columns"`
+	Rows       int         `json:"rows"`
+	LayoutRows []LayoutRow `json:"layoutRows"`
+}
+
+// LayoutRow is a row of a layout section.
+type LayoutRow struct {
+	LayoutItems []LayoutItem `json:"layoutItems"`
+}
+
+// LayoutItem is a cell of a layout row, usually holding one field.
+type LayoutItem struct {
+	Label             string            `json:"label"`
+	Required          bool              `json:"required"`
+	EditableForNew    bool              `json:"editableForNew"`
+	EditableForUpdate bool              `json:"editableForUpdate"`
+	LayoutComponents  []LayoutComponent `json:"layoutComponents"`
+}
+
+// LayoutComponent is a field or other component in a layout item.
+type LayoutComponent struct {
+	APIName       string `json:"apiName"`
+	ComponentType string `json:"componentType"`
+	Label         string `json:"label"`
+}
+
+// ObjectInfo returns the metadata of an object.
+func (c *Client) ObjectInfo(ctx context.Context, objectName string) (*ObjectInfo, error) {
+	body, err := c.api.Get(ctx, "/ui-api/object-info/"+url.PathEscape(objectName))
+	if err != nil {
+		return nil, err
+	}
+
+	var info ObjectInfo
+	if err := json.Unmarshal(body, &info); err != nil {
+		return nil, fmt.Errorf("failed to parse object info: %w", err)
+	}
+
+	return &info, nil
+}
+
+// PicklistValues returns the values of a picklist field available for a
+// record type. Use MasterRecordTypeID for objects without record types.
+func (c *Client) PicklistValues(ctx context.Context, objectName, recordTypeID, fieldName string) (*PicklistValues, error) {
+	path := fmt.Sprintf("/ui-api/object-info/%s/picklist-values/%s/%s",
+		url.PathEscape(objectName), url.PathEscape(recordTypeID), url.PathEscape(fieldName))
+
+	body, err := c.api.Get(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+
+	var values PicklistValues
+	if err := json.Unmarshal(body, &values); err != nil {
+		return nil, fmt.Errorf("failed to parse picklist values: %w", err)
+	}
+
+	return &values, nil
+}
+
+// AllPicklistValues returns the values of every picklist field of an object
+// available for a record type, keyed by field name.
+func (c *Client) AllPicklistValues(ctx context.Context, objectName, recordTypeID string) (map[string]PicklistValues, error) {
+	path := fmt.Sprintf("/ui-api/object-info/%s/picklist-values/%s",
+		url.PathEscape(objectName), url.PathEscape(recordTypeID))
+
+	body, err := c.api.Get(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+
+	var values picklistFieldValues
+	if err := json.Unmarshal(body, &values); err != nil {
+		return nil, fmt.Errorf("failed to parse picklist values: %w", err)
+	}
+
+	return values.PicklistFieldValues, nil
+}
+
+// GetRecord returns a record with the given fields, named as Object.Field.
+// Fields the user cannot see are an error; optionalFields are left out
+// instead.
+func (c *Client) GetRecord(ctx context.Context, recordID string, fields, optionalFields []string) (*Record, error) {
+	params := url.Values{}
+	if len(fields) > 0 {
+		params.Set("fields", strings.Join(fields, ","))
+	}
+	if len(optionalFields) > 0 {
+		params.Set("optionalFields", strings.Join(optionalFields, ","))
+	}
+
+	path := "/ui-api/records/" + url.PathEscape(recordID)
+	if len(params) > 0 {
+		path += "?" + params.Encode()
+	}
+
+	body, err := c.api.Get(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+
+	var record Record
+	if err := json.Unmarshal(body, &record); err != nil {
+		return nil, fmt.Errorf("failed to parse record: %w", err)
+	}
+
+	return &record, nil
+}
+
+// Layout returns the page layout of an object for a record type. An empty
+// layoutType or mode uses LayoutTypeFull and ModeView.
+func (c *Client) Layout(ctx context.Context, objectName, recordTypeID, layoutType, mode string) (*Layout, error) {
+	if layoutType == "" {
+		layoutType = LayoutTypeFull
+	}
+	if mode == "" {
+		mode = ModeView
+	}
+
+	params := url.Values{}
+	params.Set("layoutType", layoutType)
+	params.Set("mode", mode)
+	if recordTypeID != "" {
+		params.Set("recordTypeId", recordTypeID)
+	}
+
+	body, err := c.api.Get(ctx, "/ui-api/layout/"+url.PathEscape(objectName)+"?"+params.Encode())
+	if err != nil {
+		return nil, err
+	}
+
+	var layout Layout
+	if err := json.Unmarshal(body, &layout); err != nil {
+		return nil, fmt.Errorf("failed to parse layout: %w", err)
+	}
+
+	return &layout, nil
+}
@@ -0,0 +1,151 @@
+package uiapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/salesforce-cli/api"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client, err := api.New(api.ClientConfig{InstanceURL: server.URL, HTTPClient: server.Client()})
+	require.NoError(t, err)
+	return New(client)
+}
+
+func TestClient_ObjectInfo(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/services/data/v62.0/ui-api/object-info/Account", r.URL.Path)
+		_, _ = w.Write([]byte(`{
+			"apiName": "Account",
+			"label": "Account",
+			"defaultRecordTypeId": "012xx0000000001AAA",
+			"fields": {"Industry": {"apiName": "Industry", "label": "Industry", "dataType": "Picklist"}},
+			"recordTypeInfos": {
+				"012000000000000AAA": {"recordTypeId": "012000000000000AAA", "name": "Master", "master": true},
+				"012xx0000000001AAA": {"recordTypeId": "012xx0000000001AAA", "name": "Partner", "available": true}
+			}
+		}`))
+	})
+
+	info, err := client.ObjectInfo(context.Background(), "Account")
+	require.NoError(t, err)
+	assert.True(t, info.Fields["Industry"].IsPicklist())
+
+	tests := []struct {
+		nameOrID string
+		want     string
+		wantErr  string
+	}{
+		{"", "012xx0000000001AAA", ""},
+		{"partner", "012xx0000000001AAA", ""},
+		{"012xx0000000001", "012xx0000000001AAA", ""},
+		{"012000000000000AAA", "012000000000000AAA", ""},
+		{"Reseller", "", `record type "Reseller" not found on Account`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.nameOrID, func(t *testing.T) {
+			got, err := info.RecordTypeID(tt.nameOrID)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestObjectInfo_RecordTypeID_NoDefault(t *testing.T) {
+	info := &ObjectInfo{APIName: "Task"}
+
+	id, err := info.RecordTypeID("")
+	require.NoError(t, err)
+	assert.Equal(t, MasterRecordTypeID, id)
+}
+
+func TestClient_PicklistValues(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/services/data/v62.0/ui-api/object-info/Account/picklist-values/012xx0000000001AAA/Rating", r.URL.Path)
+		_, _ = w.Write([]byte(`{
+			"controllerValues": {"Agriculture": 0, "Banking": 1},
+			"defaultValue": {"label": "Warm", "value": "Warm", "validFor": [0, 1]},
+			"values": [
+				{"label": "Hot", "value": "Hot", "validFor": [1]},
+				{"label": "Warm", "value": "Warm", "validFor": [0, 1]}
+			]
+		}`))
+	})
+
+	values, err := client.PicklistValues(context.Background(), "Account", "012xx0000000001AAA", "Rating")
+	require.NoError(t, err)
+	require.Len(t, values.Values, 2)
+	assert.Equal(t, "Warm", values.DefaultValue.Value)
+	assert.Equal(t, []string{"Banking"}, values.ValidFor(values.Values[0]))
+	assert.Equal(t, []string{"Agriculture", "Banking"}, values.ValidFor(values.Values[1]))
+}
+
+func TestClient_AllPicklistValues(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/services/data/v62.0/ui-api/object-info/Account/picklist-values/012000000000000AAA", r.URL.Path)
+		_, _ = w.Write([]byte(`{"picklistFieldValues": {
+			"Industry": {"controllerValues": {}, "values": [{"label": "Banking", "value": "Banking", "validFor": []}]},
+			"Type": {"controllerValues": {}, "values": []}
+		}}`))
+	})
+
+	all, err := client.AllPicklistValues(context.Background(), "Account", MasterRecordTypeID)
+	require.NoError(t, err)
+	assert.Len(t, all, 2)
+	assert.Equal(t, "Banking", all["Industry"].Values[0].Value)
+}
+
+func TestClient_GetRecord(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/services/data/v62.0/ui-api/records/001xx000003DGbYAAW", r.URL.Path)
+		assert.Equal(t, "Account.Name,Account.Industry", r.URL.Query().Get("fields"))
+		assert.Equal(t, "Account.Rating", r.URL.Query().Get("optionalFields"))
+		_, _ = w.Write([]byte(`{"apiName": "Account", "id": "001xx000003DGbYAAW", "fields": {
+			"Name": {"displayValue": null, "value": "Acme"},
+			"Industry": {"displayValue": "Banking", "value": "Banking"}
+		}}`))
+	})
+
+	record, err := client.GetRecord(context.Background(), "001xx000003DGbYAAW",
+		[]string{"Account.Name", "Account.Industry"}, []string{"Account.Rating"})
+	require.NoError(t, err)
+	assert.Equal(t, "Acme", record.Fields["Name"].Value)
+	assert.Nil(t, record.Fields["Name"].DisplayValue)
+	require.NotNil(t, record.Fields["Industry"].DisplayValue)
+	assert.Equal(t, "Banking", *record.Fields["Industry"].DisplayValue)
+}
+
+func TestClient_Layout(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/services/data/v62.0/ui-api/layout/Account", r.URL.Path)
+		assert.Equal(t, "layoutType=Full&mode=Edit&recordTypeId=012000000000000AAA", r.URL.RawQuery)
+		_, _ = w.Write([]byte(`{"id": "00hxx0000001", "layoutType": "Full", "mode": "Edit", "sections": [
+			{"heading": "Account Information", "columns": 2, "layoutRows": [
+				{"layoutItems": [{"label": "Account Name", "required": true,
+					"layoutComponents": [{"apiName": "Name", "componentType": "Field", "label": "Account Name"}]}]}
+			]}
+		]}`))
+	})
+
+	layout, err := client.Layout(context.Background(), "Account", MasterRecordTypeID, "", ModeEdit)
+	require.NoError(t, err)
+	require.Len(t, layout.Sections, 1)
+	item := layout.Sections[0].LayoutRows[0].LayoutItems[0]
+	assert.True(t, item.Required)
+	assert.Equal(t, "Name", item.LayoutComponents[0].APIName)
+}
@@ -2,19 +2,30 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"os/signal"
 
+	"github.com/open-cli-collective/salesforce-cli/internal/cmd/actioncmd"
 	"github.com/open-cli-collective/salesforce-cli/internal/cmd/apexcmd"
+	"github.com/open-cli-collective/salesforce-cli/internal/cmd/apicmd"
+	"github.com/open-cli-collective/salesforce-cli/internal/cmd/authcmd"
 	"github.com/open-cli-collective/salesforce-cli/internal/cmd/bulkcmd"
+	"github.com/open-cli-collective/salesforce-cli/internal/cmd/cachecmd"
+	"github.com/open-cli-collective/salesforce-cli/internal/cmd/chattercmd"
 	"github.com/open-cli-collective/salesforce-cli/internal/cmd/completion"
 	"github.com/open-cli-collective/salesforce-cli/internal/cmd/configcmd"
 	"github.com/open-cli-collective/salesforce-cli/internal/cmd/coveragecmd"
+	"github.com/open-cli-collective/salesforce-cli/internal/cmd/doctorcmd"
+	"github.com/open-cli-collective/salesforce-cli/internal/cmd/filecmd"
 	"github.com/open-cli-collective/salesforce-cli/internal/cmd/initcmd"
 	"github.com/open-cli-collective/salesforce-cli/internal/cmd/limitscmd"
+	"github.com/open-cli-collective/salesforce-cli/internal/cmd/listviewcmd"
 	"github.com/open-cli-collective/salesforce-cli/internal/cmd/logcmd"
 	"github.com/open-cli-collective/salesforce-cli/internal/cmd/metadatacmd"
 	"github.com/open-cli-collective/salesforce-cli/internal/cmd/objectcmd"
+	"github.com/open-cli-collective/salesforce-cli/internal/cmd/orgcmd"
 	"github.com/open-cli-collective/salesforce-cli/internal/cmd/querycmd"
 	"github.com/open-cli-collective/salesforce-cli/internal/cmd/recordcmd"
 	"github.com/open-cli-collective/salesforce-cli/internal/cmd/root"
@@ -40,7 +51,10 @@ func run() error {
 
 	// Register all commands
 	initcmd.Register(rootCmd, opts)
+	authcmd.Register(rootCmd, opts)
 	configcmd.Register(rootCmd, opts)
+	cachecmd.Register(rootCmd, opts)
+	doctorcmd.Register(rootCmd, opts)
 	completion.Register(rootCmd, opts)
 
 	// REST API commands
@@ -49,6 +63,12 @@ func run() error {
 	searchcmd.Register(rootCmd, opts)
 	objectcmd.Register(rootCmd, opts)
 	limitscmd.Register(rootCmd, opts)
+	orgcmd.Register(rootCmd, opts)
+	apicmd.Register(rootCmd, opts)
+	filecmd.Register(rootCmd, opts)
+	listviewcmd.Register(rootCmd, opts)
+	actioncmd.Register(rootCmd, opts)
+	chattercmd.Register(rootCmd, opts)
 
 	// Bulk API commands
 	bulkcmd.Register(rootCmd, opts)
@@ -61,5 +81,9 @@ func run() error {
 	// Metadata API commands
 	metadatacmd.Register(rootCmd, opts)
 
-	return rootCmd.Execute()
+	// Cancel in-flight requests (including token refreshes) on Ctrl-C
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	return rootCmd.ExecuteContext(ctx)
 }
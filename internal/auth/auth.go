@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"net/url"
 	"strings"
 
 	"golang.org/x/oauth2"
@@ -17,6 +18,9 @@ const (
 	// No server listens on this — the browser shows an error and the user copies the URL.
 	CallbackURL = "http://localhost:8080/callback"
 
+	// DefaultCallbackPort is the localhost port used in CallbackURL
+	DefaultCallbackPort = 8080
+
 	// ProductionLoginURL is the Salesforce production login endpoint
 	ProductionLoginURL = "https://login.salesforce.com"
 
@@ -48,42 +52,156 @@ func GetOAuthConfig(instanceURL, clientID string) *oauth2.Config {
 	}
 }
 
+// CallbackURLForPort returns the OAuth redirect URL for a localhost port.
+func CallbackURLForPort(port int) string {
+	return fmt.Sprintf("http://localhost:%d/callback", port)
+}
+
 // GetHTTPClient returns an HTTP client with OAuth2 authentication.
 // It retrieves tokens from keychain (preferred) or falls back to file storage.
+// For the JWT bearer and client credentials flows, new tokens are requested
+// with the configured private key or client secret when needed; the password
+// flow logs in again if SFDC_PASSWORD is set. Requests rejected with
+// INVALID_SESSION_ID are sent once more with a new token.
 // Returns an error if no token is found - caller should direct user to run 'sfdc init'.
+// Token refreshes made by the client use ctx, so it should be the context of
+// the command the client is created for.
 func GetHTTPClient(ctx context.Context) (*http.Client, error) {
+	cache, tokenSource, err := newTokenSource(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return newHTTPClient(ctx, cache, tokenSource), nil
+}
+
+// Token returns the access token of the current login, as used by
+// GetHTTPClient. With refresh, the cached token is discarded and a new one
+// is requested (and stored) first.
+func Token(ctx context.Context, refresh bool) (*oauth2.Token, error) {
+	cache, tokenSource, err := newTokenSource(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if refresh {
+		cache.discard()
+	}
+	return tokenSource.Token()
+}
+
+// newTokenSource returns the token source of the configured login, and the
+// cache at its bottom that holds the current token.
+func newTokenSource(ctx context.Context) (*cachingTokenSource, oauth2.TokenSource, error) {
 	// Load config to get instance URL and client ID
 	cfg, err := config.Load()
 	if err != nil {
-		return nil, fmt.Errorf("failed to load config: %w", err)
+		return nil, nil, fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if !cfg.Configured() {
+		return nil, nil, fmt.Errorf("not configured - please run 'sfdc init' first")
 	}
 
-	if cfg.InstanceURL == "" || cfg.ClientID == "" {
-		return nil, fmt.Errorf("not configured - please run 'sfdc init' first")
+	var (
+		cache   *cachingTokenSource
+		initial *oauth2.Token
+		persist = true
+	)
+	switch cfg.AuthFlow {
+	case config.AuthFlowJWT:
+		// The JWT bearer flow signs a new assertion whenever the token
+		// expires, so nothing is stored
+		jwtConfig, err := JWTConfig(cfg)
+		if err != nil {
+			return nil, nil, err
+		}
+		cache = newCachingTokenSource(nil, jwtSessionSource(ctx, jwtConfig))
+		persist = false
+	case config.AuthFlowClientCredentials:
+		// The client credentials flow requests a new token with the stored
+		// secret whenever the stored token expires
+		ccConfig, err := ClientCredentialsConfig(cfg)
+		if err != nil {
+			return nil, nil, err
+		}
+		initial, _ = keychain.GetToken()
+		cache = clientCredentialsCache(ctx, ccConfig, initial)
+	case config.AuthFlowPassword:
+		// The password flow logs in again with SFDC_PASSWORD when the stored
+		// session expires
+		initial, _ = keychain.GetToken()
+		cache = passwordCache(ctx, cfg, initial)
+	default:
+		// Logins imported from an SFDX auth URL may use a Connected App with
+		// a secret
+		oauthConfig := GetOAuthConfig(cfg.InstanceURL, cfg.ClientID)
+		oauthConfig.ClientSecret = ClientSecret(cfg)
+
+		// Try to load token from keychain
+		initial, err = keychain.GetToken()
+		if err != nil {
+			return nil, nil, fmt.Errorf("no OAuth token found - please run 'sfdc init' first: %w", err)
+		}
+		cache = refreshTokenCache(ctx, oauthConfig, initial)
 	}
 
-	// Get OAuth config
-	oauthConfig := GetOAuthConfig(cfg.InstanceURL, cfg.ClientID)
+	// Save new tokens, and retry transient token endpoint failures
+	var tokenSource oauth2.TokenSource = cache
+	if persist {
+		tokenSource = keychain.PersistTokenSource(tokenSource, initial)
+	}
+	tokenSource = NewRetryTokenSource(ctx, tokenSource)
+
+	return cache, tokenSource, nil
+}
 
-	// Try to load token from keychain
-	tok, err := keychain.GetToken()
+// RevokeToken revokes token at the org's OAuth revoke endpoint. Revoking a
+// refresh token also revokes the access tokens issued with it.
+func RevokeToken(ctx context.Context, instanceURL, token string) error {
+	endpoint := normalizeInstanceURL(instanceURL) + "/services/oauth2/revoke"
+	resp, body, err := postTokenForm(ctx, endpoint, url.Values{"token": {token}})
 	if err != nil {
-		return nil, fmt.Errorf("no OAuth token found - please run 'sfdc init' first: %w", err)
+		return err
 	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("revoke failed (HTTP %d): %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
 
-	// Create persistent token source that saves refreshed tokens
-	tokenSource := keychain.NewPersistentTokenSource(oauthConfig, tok)
-	return oauth2.NewClient(ctx, tokenSource), nil
+// GetAuthURL returns the OAuth authorization URL for the given config, and
+// the PKCE code verifier whose S256 challenge it carries. The verifier must
+// be passed to ExchangeAuthCode with the code, so that an intercepted code
+// cannot be exchanged by anyone else.
+func GetAuthURL(config *oauth2.Config) (authURL, verifier string) {
+	verifier = oauth2.GenerateVerifier()
+	authURL = config.AuthCodeURL("state-token", oauth2.AccessTypeOffline, oauth2.S256ChallengeOption(verifier))
+	return authURL, verifier
 }
 
-// GetAuthURL returns the OAuth authorization URL for the given config.
-func GetAuthURL(config *oauth2.Config) string {
-	return config.AuthCodeURL("state-token", oauth2.AccessTypeOffline)
+// ExchangeAuthCode exchanges an authorization code for a token, proving
+// with verifier (from GetAuthURL) that the code was requested by this client.
+// Failures are not retried: a code can only be used once, so a retry after
+// a timeout could only fail with invalid_grant.
+func ExchangeAuthCode(ctx context.Context, config *oauth2.Config, code, verifier string) (*oauth2.Token, error) {
+	return config.Exchange(ctx, code, oauth2.VerifierOption(verifier))
 }
 
-// ExchangeAuthCode exchanges an authorization code for a token.
-func ExchangeAuthCode(ctx context.Context, config *oauth2.Config, code string) (*oauth2.Token, error) {
-	return config.Exchange(ctx, code)
+// ExchangeRefreshToken requests a new access token with a refresh token.
+// Transient token endpoint failures are retried.
+func ExchangeRefreshToken(ctx context.Context, config *oauth2.Config, refreshToken string) (*oauth2.Token, error) {
+	return retryToken(ctx, tokenRetryAttempts, tokenRetryBackoff, func() (*oauth2.Token, error) {
+		return config.TokenSource(ctx, &oauth2.Token{RefreshToken: refreshToken}).Token()
+	})
+}
+
+// contextHTTPClient returns the HTTP client set in ctx under
+// oauth2.HTTPClient, which the oauth2 package also uses for token requests,
+// or http.DefaultClient if there is none.
+func contextHTTPClient(ctx context.Context) *http.Client {
+	if client, ok := ctx.Value(oauth2.HTTPClient).(*http.Client); ok && client != nil {
+		return client
+	}
+	return http.DefaultClient
 }
 
 // normalizeInstanceURL ensures the instance URL has proper format.
@@ -1,9 +1,17 @@
 package auth
 
 import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
 )
 
 func TestGetOAuthConfig(t *testing.T) {
@@ -61,10 +69,55 @@ func TestIsSandboxURL(t *testing.T) {
 
 func TestGetAuthURL(t *testing.T) {
 	config := GetOAuthConfig("https://login.salesforce.com", "test-client-id")
-	url := GetAuthURL(config)
+	authURL, verifier := GetAuthURL(config)
 
-	assert.Contains(t, url, "https://login.salesforce.com/services/oauth2/authorize")
-	assert.Contains(t, url, "client_id=test-client-id")
-	assert.Contains(t, url, "redirect_uri=")
-	assert.Contains(t, url, "response_type=code")
+	assert.Contains(t, authURL, "https://login.salesforce.com/services/oauth2/authorize")
+	assert.Contains(t, authURL, "client_id=test-client-id")
+	assert.Contains(t, authURL, "redirect_uri=")
+	assert.Contains(t, authURL, "response_type=code")
+
+	u, err := url.Parse(authURL)
+	require.NoError(t, err)
+	assert.Equal(t, "S256", u.Query().Get("code_challenge_method"))
+	assert.Equal(t, oauth2.S256ChallengeFromVerifier(verifier), u.Query().Get("code_challenge"))
+
+	// Each login uses a new verifier
+	_, other := GetAuthURL(config)
+	assert.NotEqual(t, verifier, other)
+}
+
+func TestExchangeAuthCode_SendsVerifier(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "authorization_code", r.PostForm.Get("grant_type"))
+		assert.Equal(t, "the-code", r.PostForm.Get("code"))
+		assert.Equal(t, "the-verifier", r.PostForm.Get("code_verifier"))
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]string{"access_token": "access-token", "token_type": "Bearer"})
+	}))
+	defer server.Close()
+
+	config := GetOAuthConfig(server.URL, "test-client-id")
+	token, err := ExchangeAuthCode(context.Background(), config, "the-code", "the-verifier")
+	require.NoError(t, err)
+	assert.Equal(t, "access-token", token.AccessToken)
+}
+
+func TestExchangeAuthCode_NotRetried(t *testing.T) {
+	server, calls := newTokenServer(t, []int{http.StatusServiceUnavailable}, "Service Unavailable")
+
+	config := &oauth2.Config{
+		ClientID: "test-client-id",
+		Endpoint: oauth2.Endpoint{TokenURL: server.URL, AuthStyle: oauth2.AuthStyleInParams},
+	}
+	ctx := context.WithValue(context.Background(), oauth2.HTTPClient, server.Client())
+	_, err := ExchangeAuthCode(ctx, config, "the-code", "the-verifier")
+	require.Error(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(calls))
+}
+
+func TestCallbackURLForPort(t *testing.T) {
+	assert.Equal(t, CallbackURL, CallbackURLForPort(DefaultCallbackPort))
+	assert.Equal(t, "http://localhost:1717/callback", CallbackURLForPort(1717))
 }
@@ -0,0 +1,67 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+
+	"github.com/open-cli-collective/salesforce-cli/internal/config"
+	"github.com/open-cli-collective/salesforce-cli/internal/keychain"
+)
+
+// ClientCredentialsConfig returns the client credentials flow configuration
+// for cfg. Salesforce only accepts this flow on an org's My Domain URL, not
+// on the shared login URLs.
+func ClientCredentialsConfig(cfg *config.Config) (*clientcredentials.Config, error) {
+	secret := ClientSecret(cfg)
+	if cfg.ClientID == "" || secret == "" {
+		return nil, fmt.Errorf("client credentials login requires a client ID and client secret")
+	}
+
+	return &clientcredentials.Config{
+		ClientID:     cfg.ClientID,
+		ClientSecret: secret,
+		TokenURL:     normalizeInstanceURL(cfg.InstanceURL) + "/services/oauth2/token",
+		AuthStyle:    oauth2.AuthStyleInParams,
+	}, nil
+}
+
+// ClientSecret returns the Connected App client secret of cfg: the one from
+// SFDC_CLIENT_SECRET, or else the one stored in secure storage at login, or
+// an empty string if there is none.
+func ClientSecret(cfg *config.Config) string {
+	if cfg.ClientSecret != "" {
+		return cfg.ClientSecret
+	}
+	secret, _ := keychain.GetClientSecret()
+	return secret
+}
+
+// NewClientCredentialsTokenSource returns a TokenSource that obtains access
+// tokens with the client credentials flow, starting from the stored token
+// initial (which may be nil) and requesting a new one whenever the current
+// one is due to expire. Requests are made with ctx.
+func NewClientCredentialsTokenSource(ctx context.Context, conf *clientcredentials.Config, initial *oauth2.Token) oauth2.TokenSource {
+	return clientCredentialsCache(ctx, conf, initial)
+}
+
+func clientCredentialsCache(ctx context.Context, conf *clientcredentials.Config, initial *oauth2.Token) *cachingTokenSource {
+	return newCachingTokenSource(initial, clientCredentialsSessionSource(ctx, conf))
+}
+
+// ExchangeClientCredentials obtains an access token with the client
+// credentials flow. The token's "instance_url" extra holds the org's instance
+// URL. Transient token endpoint failures are retried.
+func ExchangeClientCredentials(ctx context.Context, conf *clientcredentials.Config) (*oauth2.Token, error) {
+	return retryToken(ctx, tokenRetryAttempts, tokenRetryBackoff, clientCredentialsSessionSource(ctx, conf).Token)
+}
+
+func clientCredentialsSessionSource(ctx context.Context, conf *clientcredentials.Config) *sessionTokenSource {
+	return &sessionTokenSource{
+		fetch: func() (*oauth2.Token, error) {
+			return conf.Token(ctx)
+		},
+	}
+}
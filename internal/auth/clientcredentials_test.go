@@ -0,0 +1,84 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+
+	"github.com/open-cli-collective/salesforce-cli/internal/config"
+	"github.com/open-cli-collective/salesforce-cli/internal/keychain"
+)
+
+func TestGetHTTPClient_ClientCredentials(t *testing.T) {
+	var tokenRequests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/services/oauth2/token" {
+			tokenRequests.Add(1)
+			require.NoError(t, r.ParseForm())
+			assert.Equal(t, "client_credentials", r.PostForm.Get("grant_type"))
+			assert.Equal(t, "client-secret", r.PostForm.Get("client_secret"))
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(map[string]string{"access_token": "new-token", "token_type": "Bearer"})
+			return
+		}
+		_, _ = w.Write([]byte(r.Header.Get("Authorization")))
+	}))
+	defer server.Close()
+
+	config.SetConfigDir(t.TempDir())
+	defer config.SetConfigDir("")
+	t.Setenv(config.OrgEnvVar, "")
+	t.Setenv("SFDC_INSTANCE_URL", "")
+	t.Setenv("SFDC_CLIENT_SECRET", "")
+	require.NoError(t, config.Save(&config.Config{
+		InstanceURL: server.URL,
+		ClientID:    "client-id",
+		AuthFlow:    config.AuthFlowClientCredentials,
+	}))
+	require.NoError(t, keychain.SetClientSecret("client-secret"))
+
+	get := func() string {
+		client, err := GetHTTPClient(context.Background())
+		require.NoError(t, err)
+		resp, err := client.Get(server.URL + "/services/data/")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		var buf [64]byte
+		n, _ := resp.Body.Read(buf[:])
+		return string(buf[:n])
+	}
+
+	t.Run("uses the stored token while valid", func(t *testing.T) {
+		require.NoError(t, keychain.SetToken(&oauth2.Token{
+			AccessToken: "stored-token",
+			TokenType:   "Bearer",
+			Expiry:      time.Now().Add(time.Hour),
+		}))
+
+		assert.Equal(t, "Bearer stored-token", get())
+		assert.Equal(t, int32(0), tokenRequests.Load())
+	})
+
+	t.Run("requests and stores a new token when expired", func(t *testing.T) {
+		require.NoError(t, keychain.SetToken(&oauth2.Token{
+			AccessToken: "stored-token",
+			TokenType:   "Bearer",
+			Expiry:      time.Now().Add(-time.Minute),
+		}))
+
+		assert.Equal(t, "Bearer new-token", get())
+		assert.Equal(t, int32(1), tokenRequests.Load())
+
+		stored, err := keychain.GetToken()
+		require.NoError(t, err)
+		assert.Equal(t, "new-token", stored.AccessToken)
+	})
+}
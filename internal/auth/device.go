@@ -0,0 +1,155 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+// defaultDeviceInterval is how often the token endpoint is polled during the
+// device flow when the server does not say.
+var defaultDeviceInterval = 5 * time.Second
+
+// DeviceCode is the response to a device authorization request: the user
+// enters UserCode at VerificationURI while the CLI polls with DeviceCode.
+type DeviceCode struct {
+	DeviceCode      string `json:"device_code"`
+	UserCode        string `json:"user_code"`
+	VerificationURI string `json:"verification_uri"`
+	// Interval is the minimum number of seconds between polls
+	Interval int `json:"interval"`
+}
+
+// deviceTokenResponse is a token endpoint response during the device flow.
+type deviceTokenResponse struct {
+	AccessToken      string `json:"access_token"`
+	RefreshToken     string `json:"refresh_token"`
+	TokenType        string `json:"token_type"`
+	InstanceURL      string `json:"instance_url"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// RequestDeviceCode starts the OAuth 2.0 device flow. The Connected App must
+// have "Enable for Device Flow" checked.
+func RequestDeviceCode(ctx context.Context, config *oauth2.Config) (*DeviceCode, error) {
+	form := url.Values{
+		"response_type": {"device_code"},
+		"client_id":     {config.ClientID},
+		"scope":         {strings.Join(config.Scopes, " ")},
+	}
+
+	var dc DeviceCode
+	resp, body, err := postTokenForm(ctx, config.Endpoint.TokenURL, form)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, deviceRetrieveError(resp, body)
+	}
+	if err := json.Unmarshal(body, &dc); err != nil {
+		return nil, fmt.Errorf("failed to parse device code response: %w", err)
+	}
+	if dc.DeviceCode == "" || dc.UserCode == "" {
+		return nil, fmt.Errorf("device code response is missing the device or user code")
+	}
+
+	return &dc, nil
+}
+
+// PollDeviceToken polls the token endpoint until the user approves or denies
+// the device code, the code expires, or ctx is cancelled. The token's
+// "instance_url" extra holds the org's instance URL.
+func PollDeviceToken(ctx context.Context, config *oauth2.Config, dc *DeviceCode) (*oauth2.Token, error) {
+	interval := time.Duration(dc.Interval) * time.Second
+	if interval <= 0 {
+		interval = defaultDeviceInterval
+	}
+
+	form := url.Values{
+		"grant_type": {"device"},
+		"client_id":  {config.ClientID},
+		"code":       {dc.DeviceCode},
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(interval):
+		}
+
+		resp, body, err := postTokenForm(ctx, config.Endpoint.TokenURL, form)
+		if err != nil {
+			return nil, err
+		}
+
+		var tr deviceTokenResponse
+		if err := json.Unmarshal(body, &tr); err != nil {
+			return nil, fmt.Errorf("failed to parse token response: %w", err)
+		}
+
+		switch tr.Error {
+		case "":
+			if resp.StatusCode != http.StatusOK || tr.AccessToken == "" {
+				return nil, deviceRetrieveError(resp, body)
+			}
+			tok := &oauth2.Token{
+				AccessToken:  tr.AccessToken,
+				RefreshToken: tr.RefreshToken,
+				TokenType:    tr.TokenType,
+			}
+			return tok.WithExtra(map[string]interface{}{"instance_url": tr.InstanceURL}), nil
+		case "authorization_pending":
+			continue
+		case "slow_down":
+			interval += defaultDeviceInterval
+			continue
+		default:
+			return nil, deviceRetrieveError(resp, body)
+		}
+	}
+}
+
+// postTokenForm posts form to the token endpoint and returns the response
+// with its body read.
+func postTokenForm(ctx context.Context, tokenURL string, form url.Values) (*http.Response, []byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := contextHTTPClient(ctx).Do(req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("token request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read token response: %w", err)
+	}
+	return resp, body, nil
+}
+
+// deviceRetrieveError converts an error response into an oauth2.RetrieveError,
+// so that it is reported like other token endpoint failures.
+func deviceRetrieveError(resp *http.Response, body []byte) error {
+	var tr deviceTokenResponse
+	_ = json.Unmarshal(body, &tr)
+	return &oauth2.RetrieveError{
+		Response:         resp,
+		Body:             body,
+		ErrorCode:        tr.Error,
+		ErrorDescription: tr.ErrorDescription,
+	}
+}
@@ -0,0 +1,97 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+)
+
+func TestDeviceFlow(t *testing.T) {
+	oldInterval := defaultDeviceInterval
+	defaultDeviceInterval = time.Millisecond
+	defer func() { defaultDeviceInterval = oldInterval }()
+
+	polls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "client-id", r.PostForm.Get("client_id"))
+		w.Header().Set("Content-Type", "application/json")
+
+		if r.PostForm.Get("response_type") == "device_code" {
+			assert.Equal(t, "api refresh_token offline_access", r.PostForm.Get("scope"))
+			_ = json.NewEncoder(w).Encode(DeviceCode{
+				DeviceCode:      "device-code",
+				UserCode:        "ABCD1234",
+				VerificationURI: "https://login.salesforce.com/setup/connect",
+			})
+			return
+		}
+
+		assert.Equal(t, "device", r.PostForm.Get("grant_type"))
+		assert.Equal(t, "device-code", r.PostForm.Get("code"))
+		polls++
+		if polls < 3 {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":"authorization_pending","error_description":"authorization pending"}`))
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"access_token":  "access-token",
+			"refresh_token": "refresh-token",
+			"token_type":    "Bearer",
+			"instance_url":  "https://mycompany.my.salesforce.com",
+		})
+	}))
+	defer server.Close()
+
+	config := GetOAuthConfig(server.URL, "client-id")
+
+	dc, err := RequestDeviceCode(context.Background(), config)
+	require.NoError(t, err)
+	assert.Equal(t, "ABCD1234", dc.UserCode)
+	assert.Equal(t, "https://login.salesforce.com/setup/connect", dc.VerificationURI)
+
+	tok, err := PollDeviceToken(context.Background(), config, dc)
+	require.NoError(t, err)
+	assert.Equal(t, 3, polls)
+	assert.Equal(t, "access-token", tok.AccessToken)
+	assert.Equal(t, "refresh-token", tok.RefreshToken)
+	assert.Equal(t, "https://mycompany.my.salesforce.com", tok.Extra("instance_url"))
+}
+
+func TestPollDeviceToken_Denied(t *testing.T) {
+	oldInterval := defaultDeviceInterval
+	defaultDeviceInterval = time.Millisecond
+	defer func() { defaultDeviceInterval = oldInterval }()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error":"access_denied","error_description":"end-user denied authorization"}`))
+	}))
+	defer server.Close()
+
+	config := GetOAuthConfig(server.URL, "client-id")
+	_, err := PollDeviceToken(context.Background(), config, &DeviceCode{DeviceCode: "device-code", Interval: 0})
+
+	var retrieveErr *oauth2.RetrieveError
+	require.True(t, errors.As(err, &retrieveErr))
+	assert.Equal(t, "access_denied", retrieveErr.ErrorCode)
+}
+
+func TestPollDeviceToken_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	config := GetOAuthConfig("https://login.salesforce.com", "client-id")
+	_, err := PollDeviceToken(ctx, config, &DeviceCode{DeviceCode: "device-code"})
+	assert.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,192 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	"golang.org/x/oauth2"
+)
+
+// invalidSessionCode is the error code Salesforce returns (with a 401) when
+// an access token has expired or been revoked before its reported expiry.
+const invalidSessionCode = "INVALID_SESSION_ID"
+
+// maxErrorPeek bounds how much of a 401 response body is read to look for
+// invalidSessionCode.
+const maxErrorPeek = 64 << 10
+
+// cachingTokenSource caches tokens like oauth2.ReuseTokenSource, but the
+// cached token can be discarded when the server rejects it, so that the next
+// call obtains a new one.
+type cachingTokenSource struct {
+	mu    sync.Mutex
+	tok   *oauth2.Token
+	fetch oauth2.TokenSource
+}
+
+func newCachingTokenSource(initial *oauth2.Token, fetch oauth2.TokenSource) *cachingTokenSource {
+	return &cachingTokenSource{tok: initial, fetch: fetch}
+}
+
+func (c *cachingTokenSource) Token() (*oauth2.Token, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.tok.Valid() {
+		return c.tok, nil
+	}
+
+	tok, err := c.fetch.Token()
+	if err != nil {
+		return nil, err
+	}
+	c.tok = tok
+	return tok, nil
+}
+
+// invalidate discards the cached token if it is still accessToken. A token
+// that was already replaced, e.g. by a concurrent request, is kept.
+func (c *cachingTokenSource) invalidate(accessToken string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.tok != nil && c.tok.AccessToken == accessToken {
+		c.reset()
+	}
+}
+
+// discard discards the cached token, so that the next call obtains a new one.
+func (c *cachingTokenSource) discard() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.tok != nil {
+		c.reset()
+	}
+}
+
+// reset replaces the cached token with one holding only its refresh token,
+// which the refresh flow needs. Called with c.mu held.
+func (c *cachingTokenSource) reset() {
+	c.tok = &oauth2.Token{RefreshToken: c.tok.RefreshToken}
+}
+
+// NewRefreshTokenSource returns a TokenSource for the browser login flow,
+// starting from the stored token initial and refreshing it with its refresh
+// token when it expires or is rejected by the server. Requests are made with
+// ctx.
+func NewRefreshTokenSource(ctx context.Context, config *oauth2.Config, initial *oauth2.Token) oauth2.TokenSource {
+	return refreshTokenCache(ctx, config, initial)
+}
+
+func refreshTokenCache(ctx context.Context, config *oauth2.Config, initial *oauth2.Token) *cachingTokenSource {
+	cache := newCachingTokenSource(initial, nil)
+	cache.fetch = tokenSourceFunc(func() (*oauth2.Token, error) {
+		// Called with cache.mu held
+		if cache.tok == nil || cache.tok.RefreshToken == "" {
+			return nil, fmt.Errorf("no refresh token - please run 'sfdc init' again")
+		}
+		// A token without an access token makes the config's source
+		// refresh immediately
+		return config.TokenSource(ctx, &oauth2.Token{RefreshToken: cache.tok.RefreshToken}).Token()
+	})
+	return cache
+}
+
+// tokenSourceFunc adapts a function to oauth2.TokenSource.
+type tokenSourceFunc func() (*oauth2.Token, error)
+
+func (f tokenSourceFunc) Token() (*oauth2.Token, error) {
+	return f()
+}
+
+// sessionRetryTransport retries a request once with a new token when
+// Salesforce rejects the current one as INVALID_SESSION_ID. It is the base of
+// an oauth2.Transport, so requests arrive with the rejected token already set.
+type sessionRetryTransport struct {
+	base  http.RoundTripper
+	cache *cachingTokenSource
+	// source returns tokens from cache, persisting and retrying as the flow
+	// requires
+	source oauth2.TokenSource
+}
+
+func (t *sessionRetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+
+	// A request whose body cannot be rebuilt cannot be sent again
+	if req.Body != nil && req.GetBody == nil {
+		return resp, nil
+	}
+
+	invalid, err := isInvalidSession(resp)
+	if err != nil || !invalid {
+		return resp, err
+	}
+
+	t.cache.invalidate(strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer "))
+	tok, err := t.source.Token()
+	if err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+
+	retry := req.Clone(req.Context())
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return resp, nil
+		}
+		retry.Body = body
+	}
+	tok.SetAuthHeader(retry)
+
+	resp.Body.Close()
+	return t.base.RoundTrip(retry)
+}
+
+// isInvalidSession reports whether a 401 response is an INVALID_SESSION_ID
+// error. The body is restored so the caller can still read it.
+func isInvalidSession(resp *http.Response) (bool, error) {
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxErrorPeek))
+	if err != nil {
+		resp.Body.Close()
+		return false, err
+	}
+	resp.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(body), resp.Body), resp.Body}
+
+	return bytes.Contains(body, []byte(invalidSessionCode)), nil
+}
+
+// newHTTPClient returns an HTTP client that authenticates with source and,
+// when a request is rejected as INVALID_SESSION_ID, discards the token
+// cached in cache and retries the request once with a new one. The base
+// transport is taken from ctx like oauth2.NewClient.
+func newHTTPClient(ctx context.Context, cache *cachingTokenSource, source oauth2.TokenSource) *http.Client {
+	base := oauth2.NewClient(ctx, nil).Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	return &http.Client{
+		Transport: &oauth2.Transport{
+			Source: source,
+			Base: &sessionRetryTransport{
+				base:   base,
+				cache:  cache,
+				source: source,
+			},
+		},
+	}
+}
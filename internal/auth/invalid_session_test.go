@@ -0,0 +1,137 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+
+	"github.com/open-cli-collective/salesforce-cli/internal/config"
+	"github.com/open-cli-collective/salesforce-cli/internal/keychain"
+)
+
+const invalidSessionBody = `[{"message":"Session expired or invalid","errorCode":"INVALID_SESSION_ID"}]`
+
+// setupRefreshLogin saves a browser login for instanceURL with a stored token.
+func setupRefreshLogin(t *testing.T, instanceURL string) {
+	t.Helper()
+
+	config.SetConfigDir(t.TempDir())
+	t.Cleanup(func() { config.SetConfigDir("") })
+	t.Setenv(config.OrgEnvVar, "")
+	t.Setenv("SFDC_INSTANCE_URL", "")
+	t.Setenv("SFDC_CLIENT_ID", "")
+	t.Setenv("SFDC_TOKEN_STORAGE", "")
+
+	require.NoError(t, config.Save(&config.Config{InstanceURL: instanceURL, ClientID: "client-id"}))
+	require.NoError(t, keychain.SetToken(&oauth2.Token{AccessToken: "old", RefreshToken: "refresh", TokenType: "Bearer"}))
+}
+
+func TestGetHTTPClient_RetriesInvalidSession(t *testing.T) {
+	var tokenRequests, apiRequests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/services/oauth2/token" {
+			atomic.AddInt32(&tokenRequests, 1)
+			require.NoError(t, r.ParseForm())
+			assert.Equal(t, "refresh", r.PostForm.Get("refresh_token"))
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(map[string]string{"access_token": "new", "token_type": "Bearer"})
+			return
+		}
+
+		atomic.AddInt32(&apiRequests, 1)
+		body, _ := io.ReadAll(r.Body)
+		assert.Equal(t, `{"Name":"Acme"}`, string(body))
+		if r.Header.Get("Authorization") != "Bearer new" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(invalidSessionBody))
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	setupRefreshLogin(t, server.URL)
+
+	client, err := GetHTTPClient(context.Background())
+	require.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		resp, err := client.Post(server.URL+"/services/data/v62.0/sobjects/Account", "application/json", strings.NewReader(`{"Name":"Acme"}`))
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	}
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&tokenRequests))
+	assert.Equal(t, int32(3), atomic.LoadInt32(&apiRequests))
+
+	stored, err := keychain.GetToken()
+	require.NoError(t, err)
+	assert.Equal(t, "new", stored.AccessToken)
+	assert.Equal(t, "refresh", stored.RefreshToken)
+}
+
+func TestGetHTTPClient_RetriesInvalidSessionOnce(t *testing.T) {
+	var apiRequests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/services/oauth2/token" {
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(map[string]string{"access_token": "new", "token_type": "Bearer"})
+			return
+		}
+		atomic.AddInt32(&apiRequests, 1)
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(invalidSessionBody))
+	}))
+	defer server.Close()
+
+	setupRefreshLogin(t, server.URL)
+
+	client, err := GetHTTPClient(context.Background())
+	require.NoError(t, err)
+
+	resp, err := client.Get(server.URL + "/services/data/")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&apiRequests))
+	body, _ := io.ReadAll(resp.Body)
+	assert.Equal(t, invalidSessionBody, string(body))
+}
+
+func TestGetHTTPClient_OtherUnauthorized(t *testing.T) {
+	var tokenRequests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/services/oauth2/token" {
+			atomic.AddInt32(&tokenRequests, 1)
+			return
+		}
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`[{"message":"Bad OAuth token","errorCode":"INVALID_AUTH_HEADER"}]`))
+	}))
+	defer server.Close()
+
+	setupRefreshLogin(t, server.URL)
+
+	client, err := GetHTTPClient(context.Background())
+	require.NoError(t, err)
+
+	resp, err := client.Get(server.URL + "/services/data/")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	assert.Equal(t, int32(0), atomic.LoadInt32(&tokenRequests))
+	body, _ := io.ReadAll(resp.Body)
+	assert.Contains(t, string(body), "INVALID_AUTH_HEADER")
+}
@@ -0,0 +1,86 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/jwt"
+
+	"github.com/open-cli-collective/salesforce-cli/internal/config"
+)
+
+// jwtAssertionLifetime is how long a signed assertion is valid. Salesforce
+// rejects assertions that expire more than 3 minutes in the future.
+const jwtAssertionLifetime = 3 * time.Minute
+
+// JWTConfig returns the JWT bearer flow configuration for cfg, reading the
+// private key from cfg.JWTKeyFile.
+func JWTConfig(cfg *config.Config) (*jwt.Config, error) {
+	if cfg.ClientID == "" || cfg.Username == "" || cfg.JWTKeyFile == "" {
+		return nil, fmt.Errorf("JWT login requires a client ID, username, and private key file")
+	}
+
+	key, err := os.ReadFile(cfg.JWTKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read private key: %w", err)
+	}
+
+	audience := cfg.JWTAudience
+	if audience == "" {
+		audience = JWTAudience(cfg.InstanceURL)
+	}
+
+	return &jwt.Config{
+		Email:      cfg.ClientID,
+		Subject:    cfg.Username,
+		PrivateKey: key,
+		TokenURL:   normalizeInstanceURL(cfg.InstanceURL) + "/services/oauth2/token",
+		Audience:   audience,
+		Expires:    jwtAssertionLifetime,
+	}, nil
+}
+
+// JWTAudience returns the login URL JWT assertions for an instance must be
+// issued for: the sandbox login URL for sandbox instances, otherwise the
+// production login URL.
+func JWTAudience(instanceURL string) string {
+	u, err := url.Parse(normalizeInstanceURL(instanceURL))
+	if err != nil {
+		return ProductionLoginURL
+	}
+
+	host := strings.ToLower(u.Hostname())
+	if IsSandboxURL(instanceURL) || strings.Contains(host, ".sandbox.") || strings.Contains(host, "--") {
+		return SandboxLoginURL
+	}
+	return ProductionLoginURL
+}
+
+// NewJWTTokenSource returns a TokenSource that obtains access tokens with the
+// JWT bearer flow, requesting a new one whenever the last is due to expire.
+// Requests are made with ctx.
+func NewJWTTokenSource(ctx context.Context, conf *jwt.Config) oauth2.TokenSource {
+	return newCachingTokenSource(nil, jwtSessionSource(ctx, conf))
+}
+
+// ExchangeJWT obtains an access token with the JWT bearer flow. The token's
+// "instance_url" extra holds the org's instance URL. Transient token endpoint
+// failures are retried.
+func ExchangeJWT(ctx context.Context, conf *jwt.Config) (*oauth2.Token, error) {
+	return retryToken(ctx, tokenRetryAttempts, tokenRetryBackoff, jwtSessionSource(ctx, conf).Token)
+}
+
+func jwtSessionSource(ctx context.Context, conf *jwt.Config) *sessionTokenSource {
+	return &sessionTokenSource{
+		fetch: func() (*oauth2.Token, error) {
+			// The jwt package caches tokens that have no expiry forever, so a
+			// new source is used for every request
+			return conf.TokenSource(ctx).Token()
+		},
+	}
+}
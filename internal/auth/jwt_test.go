@@ -0,0 +1,158 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2/jws"
+
+	"github.com/open-cli-collective/salesforce-cli/internal/config"
+)
+
+func writeTestKey(t *testing.T) (string, *rsa.PrivateKey) {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "server.key")
+	data := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	require.NoError(t, os.WriteFile(path, data, 0600))
+
+	return path, key
+}
+
+func TestExchangeJWT(t *testing.T) {
+	keyFile, key := writeTestKey(t)
+
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		assert.Equal(t, "/services/oauth2/token", r.URL.Path)
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "urn:ietf:params:oauth:grant-type:jwt-bearer", r.PostForm.Get("grant_type"))
+
+		assertion := r.PostForm.Get("assertion")
+		require.NoError(t, jws.Verify(assertion, &key.PublicKey))
+		claims, err := jws.Decode(assertion)
+		require.NoError(t, err)
+		assert.Equal(t, "client-id", claims.Iss)
+		assert.Equal(t, "ci@example.com", claims.Sub)
+		assert.Equal(t, SandboxLoginURL, claims.Aud)
+		assert.LessOrEqual(t, claims.Exp, time.Now().Add(jwtAssertionLifetime).Unix())
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"access_token": "access-token",
+			"instance_url": "https://mycompany--dev1.sandbox.my.salesforce.com",
+			"token_type":   "Bearer",
+		})
+	}))
+	defer server.Close()
+
+	conf, err := JWTConfig(&config.Config{
+		InstanceURL: server.URL,
+		ClientID:    "client-id",
+		Username:    "ci@example.com",
+		JWTKeyFile:  keyFile,
+		JWTAudience: SandboxLoginURL,
+	})
+	require.NoError(t, err)
+
+	tok, err := ExchangeJWT(context.Background(), conf)
+	require.NoError(t, err)
+	assert.Equal(t, "access-token", tok.AccessToken)
+	assert.Equal(t, "https://mycompany--dev1.sandbox.my.salesforce.com", tok.Extra("instance_url"))
+	assert.WithinDuration(t, time.Now().Add(sessionLifetime), tok.Expiry, time.Minute)
+
+	t.Run("token source reuses the token until it expires", func(t *testing.T) {
+		requests = 0
+		src := NewJWTTokenSource(context.Background(), conf)
+		for i := 0; i < 3; i++ {
+			tok, err := src.Token()
+			require.NoError(t, err)
+			assert.Equal(t, "access-token", tok.AccessToken)
+		}
+		assert.Equal(t, 1, requests)
+	})
+}
+
+func TestJWTConfig_Errors(t *testing.T) {
+	_, err := JWTConfig(&config.Config{InstanceURL: "login.salesforce.com", ClientID: "client-id"})
+	assert.Error(t, err)
+
+	_, err = JWTConfig(&config.Config{
+		InstanceURL: "login.salesforce.com",
+		ClientID:    "client-id",
+		Username:    "ci@example.com",
+		JWTKeyFile:  filepath.Join(t.TempDir(), "missing.key"),
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to read private key")
+}
+
+func TestJWTAudience(t *testing.T) {
+	tests := []struct {
+		instanceURL string
+		want        string
+	}{
+		{"login.salesforce.com", ProductionLoginURL},
+		{"https://mycompany.my.salesforce.com", ProductionLoginURL},
+		{"test.salesforce.com", SandboxLoginURL},
+		{"https://mycompany--dev1.sandbox.my.salesforce.com", SandboxLoginURL},
+		{"mycompany--uat.my.salesforce.com", SandboxLoginURL},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.instanceURL, func(t *testing.T) {
+			assert.Equal(t, tt.want, JWTAudience(tt.instanceURL))
+		})
+	}
+}
+
+func TestGetHTTPClient_JWT(t *testing.T) {
+	keyFile, _ := writeTestKey(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/services/oauth2/token" {
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(map[string]string{"access_token": "jwt-token", "token_type": "Bearer"})
+			return
+		}
+		assert.Equal(t, "Bearer jwt-token", r.Header.Get("Authorization"))
+	}))
+	defer server.Close()
+
+	config.SetConfigDir(t.TempDir())
+	defer config.SetConfigDir("")
+	t.Setenv(config.OrgEnvVar, "")
+	t.Setenv("SFDC_INSTANCE_URL", "")
+	t.Setenv("SFDC_JWT_KEY_FILE", "")
+	require.NoError(t, config.Save(&config.Config{
+		InstanceURL: server.URL,
+		ClientID:    "client-id",
+		AuthFlow:    config.AuthFlowJWT,
+		Username:    "ci@example.com",
+		JWTKeyFile:  keyFile,
+	}))
+
+	client, err := GetHTTPClient(context.Background())
+	require.NoError(t, err)
+
+	resp, err := client.Get(server.URL + "/services/data/")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
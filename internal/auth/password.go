@@ -0,0 +1,127 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"golang.org/x/oauth2"
+
+	"github.com/open-cli-collective/salesforce-cli/api"
+	"github.com/open-cli-collective/salesforce-cli/internal/config"
+)
+
+// ErrPasswordRequired is returned when a password flow session expires and
+// no password is available to log in again.
+var ErrPasswordRequired = errors.New("session expired - set SFDC_PASSWORD (and SFDC_SECURITY_TOKEN) or run 'sfdc auth login --username-password' again")
+
+// LoginPassword logs in with a username and password through the SOAP API
+// login call, which needs no Connected App. securityToken is appended to the
+// password; it is required unless the caller's IP is trusted by the org.
+//
+// The returned token holds the session ID, expires when the session does,
+// and has the org's instance URL in its "instance_url" extra. Network
+// failures are retried; rejected credentials are not.
+func LoginPassword(ctx context.Context, loginURL, username, password, securityToken string) (*oauth2.Token, error) {
+	return retryToken(ctx, tokenRetryAttempts, tokenRetryBackoff, func() (*oauth2.Token, error) {
+		return soapLogin(ctx, loginURL, username, password+securityToken)
+	})
+}
+
+// NewPasswordTokenSource returns a TokenSource for the password flow,
+// starting from the stored token initial (which may be nil). When the session
+// expires, it logs in again if cfg has a password (from SFDC_PASSWORD) and
+// otherwise returns ErrPasswordRequired.
+func NewPasswordTokenSource(ctx context.Context, cfg *config.Config, initial *oauth2.Token) oauth2.TokenSource {
+	return passwordCache(ctx, cfg, initial)
+}
+
+func passwordCache(ctx context.Context, cfg *config.Config, initial *oauth2.Token) *cachingTokenSource {
+	return newCachingTokenSource(initial, &sessionTokenSource{
+		fetch: func() (*oauth2.Token, error) {
+			if cfg.Password == "" {
+				return nil, ErrPasswordRequired
+			}
+			return soapLogin(ctx, cfg.InstanceURL, cfg.Username, cfg.Password+cfg.SecurityToken)
+		},
+	})
+}
+
+func soapLogin(ctx context.Context, loginURL, username, password string) (*oauth2.Token, error) {
+	var envelope bytes.Buffer
+	envelope.WriteString(`<?xml version="1.0" encoding="UTF-8"?>`)
+	envelope.WriteString(`<soapenv:Envelope xmlns:soapenv="http://schemas.xmlsoap.org/soap/envelope/" xmlns:urn="urn:partner.soap.sforce.com">`)
+	envelope.WriteString(`<soapenv:Body><urn:login><urn:username>`)
+	_ = xml.EscapeText(&envelope, []byte(username))
+	envelope.WriteString(`</urn:username><urn:password>`)
+	_ = xml.EscapeText(&envelope, []byte(password))
+	envelope.WriteString(`</urn:password></urn:login></soapenv:Body></soapenv:Envelope>`)
+
+	endpoint := fmt.Sprintf("%s/services/Soap/u/%s", normalizeInstanceURL(loginURL), strings.TrimPrefix(api.DefaultAPIVersion, "v"))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, &envelope)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "text/xml; charset=UTF-8")
+	req.Header.Set("SOAPAction", "login")
+
+	resp, err := contextHTTPClient(ctx).Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("login request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read login response: %w", err)
+	}
+
+	var result struct {
+		Fault *struct {
+			Code   string `xml:"faultcode"`
+			String string `xml:"faultstring"`
+		} `xml:"Body>Fault"`
+		Result *struct {
+			ServerURL       string `xml:"serverUrl"`
+			SessionID       string `xml:"sessionId"`
+			PasswordExpired bool   `xml:"passwordExpired"`
+			SessionSeconds  int    `xml:"userInfo>sessionSecondsValid"`
+		} `xml:"Body>loginResponse>result"`
+	}
+	if err := xml.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse login response (HTTP %d): %w", resp.StatusCode, err)
+	}
+
+	if result.Fault != nil {
+		return nil, fmt.Errorf("login failed: %s", result.Fault.String)
+	}
+	if result.Result == nil || result.Result.SessionID == "" {
+		return nil, fmt.Errorf("login response did not include a session")
+	}
+	if result.Result.PasswordExpired {
+		return nil, fmt.Errorf("login failed: the password has expired - change it in Salesforce first")
+	}
+
+	server, err := url.Parse(result.Result.ServerURL)
+	if err != nil || server.Host == "" {
+		return nil, fmt.Errorf("login response had an invalid server URL: %q", result.Result.ServerURL)
+	}
+
+	tok := &oauth2.Token{
+		AccessToken: result.Result.SessionID,
+		TokenType:   "Bearer",
+	}
+	if result.Result.SessionSeconds > 0 {
+		tok.Expiry = time.Now().Add(time.Duration(result.Result.SessionSeconds) * time.Second)
+	}
+	return tok.WithExtra(map[string]interface{}{
+		"instance_url": server.Scheme + "://" + server.Host,
+	}), nil
+}
@@ -0,0 +1,81 @@
+package auth
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+
+	"github.com/open-cli-collective/salesforce-cli/internal/config"
+)
+
+const loginResponse = `<?xml version="1.0" encoding="UTF-8"?>
+<soapenv:Envelope xmlns:soapenv="http://schemas.xmlsoap.org/soap/envelope/" xmlns="urn:partner.soap.sforce.com">
+<soapenv:Body><loginResponse><result>
+<passwordExpired>false</passwordExpired>
+<serverUrl>https://mycompany.my.salesforce.com/services/Soap/u/62.0/00D000000000001</serverUrl>
+<sessionId>00D!session</sessionId>
+<userInfo><sessionSecondsValid>7200</sessionSecondsValid></userInfo>
+</result></loginResponse></soapenv:Body></soapenv:Envelope>`
+
+func TestLoginPassword(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "login", r.Header.Get("SOAPAction"))
+		body, _ := io.ReadAll(r.Body)
+		assert.Contains(t, string(body), "<urn:username>admin@example.com</urn:username>")
+		assert.Contains(t, string(body), "<urn:password>p&amp;sswordTOKEN</urn:password>")
+
+		_, _ = w.Write([]byte(loginResponse))
+	}))
+	defer server.Close()
+
+	tok, err := LoginPassword(context.Background(), server.URL, "admin@example.com", "p&ssword", "TOKEN")
+	require.NoError(t, err)
+	assert.Equal(t, "00D!session", tok.AccessToken)
+	assert.Equal(t, "https://mycompany.my.salesforce.com", tok.Extra("instance_url"))
+	assert.WithinDuration(t, time.Now().Add(2*time.Hour), tok.Expiry, time.Minute)
+}
+
+func TestLoginPassword_Fault(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?>
+<soapenv:Envelope xmlns:soapenv="http://schemas.xmlsoap.org/soap/envelope/" xmlns:sf="urn:fault.partner.soap.sforce.com">
+<soapenv:Body><soapenv:Fault>
+<faultcode>INVALID_LOGIN</faultcode>
+<faultstring>INVALID_LOGIN: Invalid username, password, security token; or user locked out.</faultstring>
+</soapenv:Fault></soapenv:Body></soapenv:Envelope>`))
+	}))
+	defer server.Close()
+
+	_, err := LoginPassword(context.Background(), server.URL, "admin@example.com", "wrong", "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "INVALID_LOGIN")
+	assert.Equal(t, 1, requests)
+}
+
+func TestNewPasswordTokenSource(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(loginResponse))
+	}))
+	defer server.Close()
+
+	expired := &oauth2.Token{AccessToken: "old", Expiry: time.Now().Add(-time.Minute)}
+
+	cfg := &config.Config{InstanceURL: server.URL, Username: "admin@example.com", AuthFlow: config.AuthFlowPassword}
+	_, err := NewPasswordTokenSource(context.Background(), cfg, expired).Token()
+	assert.ErrorIs(t, err, ErrPasswordRequired)
+
+	cfg.Password = "password"
+	tok, err := NewPasswordTokenSource(context.Background(), cfg, expired).Token()
+	require.NoError(t, err)
+	assert.Equal(t, "00D!session", tok.AccessToken)
+}
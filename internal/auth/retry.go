@@ -0,0 +1,119 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+const (
+	// tokenRetryAttempts is the maximum number of token endpoint requests per
+	// refresh or login
+	tokenRetryAttempts = 3
+
+	// tokenRetryBackoff is the delay before the first retry; it doubles after
+	// each failed attempt
+	tokenRetryBackoff = 500 * time.Millisecond
+)
+
+// ErrReauthRequired is returned when the token endpoint rejects the stored
+// credentials, e.g. because the refresh token was revoked or expired.
+var ErrReauthRequired = errors.New("authentication expired or revoked - please run 'sfdc init' to re-authenticate")
+
+// permanentTokenErrors are OAuth error codes that mean the stored
+// credentials were rejected, so retrying cannot fix them. invalid_request is
+// not one: it points to a malformed request or misconfigured client, which
+// logging in again would not fix, so it is returned as is.
+var permanentTokenErrors = map[string]bool{
+	"invalid_grant":          true,
+	"invalid_client":         true,
+	"invalid_client_id":      true,
+	"unauthorized_client":    true,
+	"unsupported_grant_type": true,
+}
+
+// retryTokenSource retries transient token endpoint failures with backoff.
+type retryTokenSource struct {
+	ctx      context.Context
+	base     oauth2.TokenSource
+	attempts int
+	backoff  time.Duration
+}
+
+// NewRetryTokenSource wraps a TokenSource so that transient token refresh
+// failures (network errors, 429 and 5xx responses) are retried. Rejected
+// credentials are not retried and return ErrReauthRequired. Cancelling ctx
+// stops further retries.
+func NewRetryTokenSource(ctx context.Context, base oauth2.TokenSource) oauth2.TokenSource {
+	return &retryTokenSource{
+		ctx:      ctx,
+		base:     base,
+		attempts: tokenRetryAttempts,
+		backoff:  tokenRetryBackoff,
+	}
+}
+
+// Token returns a token from the base source, retrying transient failures.
+func (r *retryTokenSource) Token() (*oauth2.Token, error) {
+	return retryToken(r.ctx, r.attempts, r.backoff, r.base.Token)
+}
+
+// retryToken calls fetch until it succeeds, fails permanently, or the
+// attempts are used up.
+func retryToken(ctx context.Context, attempts int, backoff time.Duration, fetch func() (*oauth2.Token, error)) (*oauth2.Token, error) {
+	var err error
+	for attempt := 1; ; attempt++ {
+		var tok *oauth2.Token
+		tok, err = fetch()
+		if err == nil {
+			return tok, nil
+		}
+
+		if isReauthError(err) {
+			return nil, fmt.Errorf("%w: %w", ErrReauthRequired, err)
+		}
+		if !isRetryableTokenError(err) || attempt >= attempts {
+			return nil, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// isReauthError reports whether the token endpoint rejected the credentials.
+func isReauthError(err error) bool {
+	var retrieveErr *oauth2.RetrieveError
+	if !errors.As(err, &retrieveErr) {
+		return false
+	}
+	return permanentTokenErrors[retrieveErr.ErrorCode]
+}
+
+// isRetryableTokenError reports whether a token request failure is transient.
+func isRetryableTokenError(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var retrieveErr *oauth2.RetrieveError
+	if errors.As(err, &retrieveErr) {
+		if retrieveErr.Response == nil {
+			return false
+		}
+		status := retrieveErr.Response.StatusCode
+		return status == http.StatusTooManyRequests || status >= http.StatusInternalServerError
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
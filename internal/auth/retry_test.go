@@ -0,0 +1,160 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+)
+
+// newTokenServer returns a token endpoint that replies with the given
+// status codes in order, then succeeds.
+func newTokenServer(t *testing.T, failures []int, body string) (*httptest.Server, *int32) {
+	t.Helper()
+	var calls int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int(atomic.AddInt32(&calls, 1))
+		w.Header().Set("Content-Type", "application/json")
+		if n <= len(failures) {
+			w.WriteHeader(failures[n-1])
+			_, _ = w.Write([]byte(body))
+			return
+		}
+		_, _ = w.Write([]byte(`{"access_token":"new-token","token_type":"Bearer","refresh_token":"refresh"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &calls
+}
+
+func newExpiredTokenSource(server *httptest.Server) oauth2.TokenSource {
+	config := &oauth2.Config{
+		ClientID: "test-client-id",
+		Endpoint: oauth2.Endpoint{TokenURL: server.URL, AuthStyle: oauth2.AuthStyleInParams},
+	}
+	ctx := context.WithValue(context.Background(), oauth2.HTTPClient, server.Client())
+	expired := &oauth2.Token{
+		AccessToken:  "old-token",
+		RefreshToken: "refresh",
+		Expiry:       time.Now().Add(-time.Hour),
+	}
+	return config.TokenSource(ctx, expired)
+}
+
+func TestRetryTokenSource(t *testing.T) {
+	tests := []struct {
+		name       string
+		failures   []int
+		body       string
+		wantToken  bool
+		wantCalls  int32
+		wantReauth bool
+	}{
+		{
+			name:      "transient 503 then success",
+			failures:  []int{http.StatusServiceUnavailable},
+			body:      "Service Unavailable",
+			wantToken: true,
+			wantCalls: 2,
+		},
+		{
+			name:      "gives up after max attempts",
+			failures:  []int{http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway},
+			body:      "Bad Gateway",
+			wantCalls: 3,
+		},
+		{
+			name:       "invalid_grant is not retried",
+			failures:   []int{http.StatusBadRequest},
+			body:       `{"error":"invalid_grant","error_description":"expired access/refresh token"}`,
+			wantCalls:  1,
+			wantReauth: true,
+		},
+		{
+			name:      "invalid_request is not a reauth signal",
+			failures:  []int{http.StatusBadRequest},
+			body:      `{"error":"invalid_request","error_description":"missing required parameter"}`,
+			wantCalls: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, calls := newTokenServer(t, tt.failures, tt.body)
+
+			ts := &retryTokenSource{
+				ctx:      context.Background(),
+				base:     newExpiredTokenSource(server),
+				attempts: tokenRetryAttempts,
+				backoff:  time.Millisecond,
+			}
+
+			tok, err := ts.Token()
+			assert.Equal(t, tt.wantCalls, atomic.LoadInt32(calls))
+
+			if !tt.wantToken {
+				require.Error(t, err)
+				assert.Equal(t, tt.wantReauth, errors.Is(err, ErrReauthRequired))
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, "new-token", tok.AccessToken)
+		})
+	}
+}
+
+func TestRetryTokenStopsOnContextCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	calls := 0
+	_, err := retryToken(ctx, 3, time.Hour, func() (*oauth2.Token, error) {
+		calls++
+		return nil, &oauth2.RetrieveError{Response: &http.Response{StatusCode: http.StatusServiceUnavailable}}
+	})
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 1, calls)
+}
+
+func TestRetryTokenSourceUsesContext(t *testing.T) {
+	server, calls := newTokenServer(t, []int{http.StatusServiceUnavailable}, "Service Unavailable")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	ts := NewRetryTokenSource(ctx, newExpiredTokenSource(server))
+	_, err := ts.Token()
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, int32(1), atomic.LoadInt32(calls))
+}
+
+func TestIsRetryableTokenError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"503", &oauth2.RetrieveError{Response: &http.Response{StatusCode: http.StatusServiceUnavailable}}, true},
+		{"429", &oauth2.RetrieveError{Response: &http.Response{StatusCode: http.StatusTooManyRequests}}, true},
+		{"400", &oauth2.RetrieveError{Response: &http.Response{StatusCode: http.StatusBadRequest}}, false},
+		{"context canceled", context.Canceled, false},
+		{"other error", errors.New("boom"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, isRetryableTokenError(tt.err))
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package auth
+
+import (
+	"sync"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+// sessionLifetime is how long an access token without a reported expiry is
+// used before a new one is requested. Salesforce does not report the session
+// lifetime for the JWT bearer and client credentials flows, so this is the
+// shortest session timeout an org can set.
+const sessionLifetime = 15 * time.Minute
+
+// sessionTokenSource requests a new token on every call, setting an expiry on
+// tokens that have none so that a wrapping ReuseTokenSource knows when to
+// call again.
+type sessionTokenSource struct {
+	mu    sync.Mutex
+	fetch func() (*oauth2.Token, error)
+}
+
+func (s *sessionTokenSource) Token() (*oauth2.Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tok, err := s.fetch()
+	if err != nil {
+		return nil, err
+	}
+	if tok.Expiry.IsZero() {
+		tok.Expiry = time.Now().Add(sessionLifetime)
+	}
+	return tok, nil
+}
@@ -0,0 +1,44 @@
+package auth
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// sfdxAuthURLPattern matches force://<clientId>:<clientSecret>:<refreshToken>@<instance>.
+// The client secret is empty for Connected Apps without one, such as the
+// default app of the sf CLI.
+var sfdxAuthURLPattern = regexp.MustCompile(`^force://([^:]+):([^:]*):([^@]+)@(.+)$`)
+
+// SFDXAuthURL is an org login in the format the sf CLI uses to share it, as
+// printed by 'sf org display --verbose'.
+type SFDXAuthURL struct {
+	ClientID     string
+	ClientSecret string
+	RefreshToken string
+	// InstanceURL is the org's URL, with https:// added if the auth URL had
+	// no scheme
+	InstanceURL string
+}
+
+// ParseSFDXAuthURL parses a force:// auth URL.
+func ParseSFDXAuthURL(s string) (*SFDXAuthURL, error) {
+	m := sfdxAuthURLPattern.FindStringSubmatch(strings.TrimSpace(s))
+	if m == nil {
+		return nil, fmt.Errorf("invalid SFDX auth URL: expected force://<clientId>:<clientSecret>:<refreshToken>@<instanceUrl>")
+	}
+
+	return &SFDXAuthURL{
+		ClientID:     m[1],
+		ClientSecret: m[2],
+		RefreshToken: m[3],
+		InstanceURL:  normalizeInstanceURL(m[4]),
+	}, nil
+}
+
+// String returns the auth URL in force:// format.
+func (u *SFDXAuthURL) String() string {
+	instance := strings.TrimPrefix(normalizeInstanceURL(u.InstanceURL), "https://")
+	return fmt.Sprintf("force://%s:%s:%s@%s", u.ClientID, u.ClientSecret, u.RefreshToken, instance)
+}
@@ -0,0 +1,69 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSFDXAuthURL(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  SFDXAuthURL
+	}{
+		{
+			name:  "without client secret",
+			input: "force://PlatformCLI::5Aep861abc.def==@mycompany.my.salesforce.com",
+			want: SFDXAuthURL{
+				ClientID:     "PlatformCLI",
+				RefreshToken: "5Aep861abc.def==",
+				InstanceURL:  "https://mycompany.my.salesforce.com",
+			},
+		},
+		{
+			name:  "with client secret and scheme",
+			input: "  force://3MVG9abc:SECRET123:5Aep861xyz@https://mycompany--dev.sandbox.my.salesforce.com/\n",
+			want: SFDXAuthURL{
+				ClientID:     "3MVG9abc",
+				ClientSecret: "SECRET123",
+				RefreshToken: "5Aep861xyz",
+				InstanceURL:  "https://mycompany--dev.sandbox.my.salesforce.com",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseSFDXAuthURL(tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, *got)
+		})
+	}
+}
+
+func TestParseSFDXAuthURL_Invalid(t *testing.T) {
+	for _, input := range []string{
+		"",
+		"https://mycompany.my.salesforce.com",
+		"force://PlatformCLI@mycompany.my.salesforce.com",
+		"force://PlatformCLI::@mycompany.my.salesforce.com",
+	} {
+		_, err := ParseSFDXAuthURL(input)
+		assert.Error(t, err, input)
+	}
+}
+
+func TestSFDXAuthURL_String(t *testing.T) {
+	u := &SFDXAuthURL{
+		ClientID:     "PlatformCLI",
+		RefreshToken: "5Aep861abc",
+		InstanceURL:  "https://mycompany.my.salesforce.com",
+	}
+	assert.Equal(t, "force://PlatformCLI::5Aep861abc@mycompany.my.salesforce.com", u.String())
+
+	parsed, err := ParseSFDXAuthURL(u.String())
+	require.NoError(t, err)
+	assert.Equal(t, *u, *parsed)
+}
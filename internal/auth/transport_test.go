@@ -0,0 +1,98 @@
+package auth
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/salesforce-cli/internal/config"
+)
+
+func TestNewTransport_Default(t *testing.T) {
+	transport, err := NewTransport(&config.Config{})
+	require.NoError(t, err)
+	assert.Same(t, http.DefaultTransport, transport)
+}
+
+func TestNewTransport_Proxy(t *testing.T) {
+	tests := []struct {
+		proxy   string
+		want    string
+		wantErr string
+	}{
+		{proxy: "http://proxy.corp:3128", want: "http://proxy.corp:3128"},
+		{proxy: "proxy.corp:3128", want: "http://proxy.corp:3128"},
+		{proxy: "socks5://127.0.0.1:1080", want: "socks5://127.0.0.1:1080"},
+		{proxy: "ftp://proxy.corp", wantErr: `invalid proxy URL "ftp://proxy.corp": scheme must be http, https, or socks5`},
+		{proxy: "http://", wantErr: `invalid proxy URL "http://"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.proxy, func(t *testing.T) {
+			transport, err := NewTransport(&config.Config{Proxy: tt.proxy})
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			req, _ := http.NewRequest(http.MethodGet, "https://example.my.salesforce.com", nil)
+			proxyURL, err := transport.(*http.Transport).Proxy(req)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, proxyURL.String())
+		})
+	}
+}
+
+func TestNewTransport_CACert(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	// Without the server's CA, the certificate is rejected
+	_, err := http.DefaultClient.Get(server.URL)
+	require.Error(t, err)
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	cert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	require.NoError(t, os.WriteFile(caFile, cert, 0600))
+
+	transport, err := NewTransport(&config.Config{CACertFile: caFile})
+	require.NoError(t, err)
+
+	resp, err := (&http.Client{Transport: transport}).Get(server.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestNewTransport_CACertErrors(t *testing.T) {
+	dir := t.TempDir()
+	notPEM := filepath.Join(dir, "ca.txt")
+	require.NoError(t, os.WriteFile(notPEM, []byte("not a certificate"), 0600))
+
+	_, err := NewTransport(&config.Config{CACertFile: filepath.Join(dir, "missing.pem")})
+	assert.ErrorContains(t, err, "failed to read CA certificates")
+
+	_, err = NewTransport(&config.Config{CACertFile: notPEM})
+	assert.EqualError(t, err, "no PEM certificates found in "+notPEM)
+}
+
+func TestNewTransport_InsecureSkipVerify(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	transport, err := NewTransport(&config.Config{InsecureSkipVerify: true})
+	require.NoError(t, err)
+
+	resp, err := (&http.Client{Transport: transport}).Get(server.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+}
@@ -0,0 +1,84 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/open-cli-collective/salesforce-cli/api"
+)
+
+// Stages of VerifyConnection, recorded in VerifyError.
+const (
+	// StageToken is getting an access token for the configured login
+	StageToken = "token"
+	// StageAPI is the request to the REST API with the token
+	StageAPI = "api"
+)
+
+// VerifyError is returned by VerifyConnection. Stage tells whether the
+// token or the API request failed.
+type VerifyError struct {
+	Stage string
+	Err   error
+}
+
+func (e *VerifyError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *VerifyError) Unwrap() error {
+	return e.Err
+}
+
+// Connection describes a verified connection to the REST API.
+type Connection struct {
+	// Versions are the API versions supported by the instance
+	Versions []api.APIVersion
+	// ServerTime is the time in the response's Date header, or zero if it
+	// had none
+	ServerTime time.Time
+}
+
+// VerifyConnection checks that the configured login can get an access token
+// and use it to call the REST API at instanceURL. Errors are *VerifyError.
+func VerifyConnection(ctx context.Context, instanceURL string) (*Connection, error) {
+	cache, tokenSource, err := newTokenSource(ctx)
+	if err != nil {
+		return nil, &VerifyError{Stage: StageToken, Err: err}
+	}
+	if _, err := tokenSource.Token(); err != nil {
+		return nil, &VerifyError{Stage: StageToken, Err: err}
+	}
+
+	// The client reuses the token just obtained
+	client := newHTTPClient(ctx, cache, tokenSource)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, normalizeInstanceURL(instanceURL)+"/services/data/", nil)
+	if err != nil {
+		return nil, &VerifyError{Stage: StageAPI, Err: fmt.Errorf("failed to create request: %w", err)}
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, &VerifyError{Stage: StageAPI, Err: fmt.Errorf("failed to access Salesforce API: %w", err)}
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, &VerifyError{Stage: StageAPI, Err: fmt.Errorf("API returned status %d", resp.StatusCode)}
+	}
+
+	conn := &Connection{}
+	if err := json.NewDecoder(resp.Body).Decode(&conn.Versions); err != nil {
+		return nil, &VerifyError{Stage: StageAPI, Err: fmt.Errorf("failed to parse API versions: %w", err)}
+	}
+	if date, err := http.ParseTime(resp.Header.Get("Date")); err == nil {
+		conn.ServerTime = date
+	}
+
+	return conn, nil
+}
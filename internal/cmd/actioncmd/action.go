@@ -0,0 +1,34 @@
+// Package actioncmd provides commands for invocable actions.
+package actioncmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/salesforce-cli/internal/cmd/root"
+)
+
+// Register registers the action command with the root command.
+func Register(parent *cobra.Command, opts *root.Options) {
+	parent.AddCommand(NewCommand(opts))
+}
+
+// NewCommand creates the action command with subcommands.
+func NewCommand(opts *root.Options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "action",
+		Short: "Work with invocable actions",
+		Long: `List, describe, and invoke invocable actions.
+
+Actions have a type: "standard" for standard actions such as chatterPost and
+emailSimple, or a custom action type such as flow (autolaunched flows), apex
+(invocable Apex methods), or quickAction. Types are case-insensitive, so Flow
+and flow are the same.`,
+	}
+
+	cmd.AddCommand(newTypesCommand(opts))
+	cmd.AddCommand(newListCommand(opts))
+	cmd.AddCommand(newDescribeCommand(opts))
+	cmd.AddCommand(newInvokeCommand(opts))
+
+	return cmd
+}
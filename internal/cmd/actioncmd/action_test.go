@@ -0,0 +1,209 @@
+package actioncmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/salesforce-cli/api"
+	"github.com/open-cli-collective/salesforce-cli/internal/cmd/root"
+)
+
+// actionServer serves the Close_Case flow. Invocations fail for inputs
+// without a caseId; received collects the inputs of each invocation.
+func actionServer(t *testing.T, received *[][]map[string]interface{}) *api.Client {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/services/data/v62.0/actions/custom/flow" && r.Method == http.MethodGet:
+			_, _ = w.Write([]byte(`{"actions": [{"name": "Close_Case", "label": "Close Case", "type": "FLOW"}]}`))
+		case r.URL.Path == "/services/data/v62.0/actions/custom/flow/Close_Case" && r.Method == http.MethodGet:
+			_, _ = w.Write([]byte(`{"name": "Close_Case", "label": "Close Case", "type": "FLOW",
+				"inputs": [{"name": "caseId", "type": "STRING", "required": true, "description": "Case to close"}],
+				"outputs": []}`))
+		case r.URL.Path == "/services/data/v62.0/actions/custom/flow/Close_Case" && r.Method == http.MethodPost:
+			var body struct {
+				Inputs []map[string]interface{} `json:"inputs"`
+			}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			if received != nil {
+				*received = append(*received, body.Inputs)
+			}
+
+			status := http.StatusOK
+			results := make([]map[string]interface{}, 0, len(body.Inputs))
+			for _, input := range body.Inputs {
+				if input["caseId"] == nil {
+					status = http.StatusBadRequest
+					results = append(results, map[string]interface{}{
+						"actionName": "Close_Case", "isSuccess": false,
+						"errors": []map[string]interface{}{{"statusCode": "REQUIRED_FIELD_MISSING", "message": "Missing caseId"}},
+					})
+					continue
+				}
+				results = append(results, map[string]interface{}{
+					"actionName": "Close_Case", "isSuccess": true,
+					"outputValues": map[string]interface{}{"closed": true, "status": "Closed"},
+				})
+			}
+			w.WriteHeader(status)
+			_ = json.NewEncoder(w).Encode(results)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := api.New(api.ClientConfig{InstanceURL: server.URL, HTTPClient: server.Client()})
+	require.NoError(t, err)
+	return client
+}
+
+func newTestOptions(client *api.Client) (*root.Options, *bytes.Buffer) {
+	stdout := &bytes.Buffer{}
+	opts := &root.Options{
+		Output:  "table",
+		NoColor: true,
+		Stdout:  stdout,
+		Stderr:  &bytes.Buffer{},
+	}
+	opts.SetAPIClient(client)
+	return opts, stdout
+}
+
+func TestListCommand(t *testing.T) {
+	opts, stdout := newTestOptions(actionServer(t, nil))
+
+	cmd := NewCommand(opts)
+	cmd.SetArgs([]string{"list", "Flow"})
+
+	require.NoError(t, cmd.Execute())
+	assert.Contains(t, stdout.String(), "Close_Case")
+	assert.Contains(t, stdout.String(), "Close Case")
+	assert.Contains(t, stdout.String(), "1 action(s)")
+}
+
+func TestDescribeCommand(t *testing.T) {
+	opts, stdout := newTestOptions(actionServer(t, nil))
+
+	cmd := NewCommand(opts)
+	cmd.SetArgs([]string{"describe", "flow", "Close_Case"})
+
+	require.NoError(t, cmd.Execute())
+	out := stdout.String()
+	assert.Contains(t, out, "Close Case (FLOW)")
+	assert.Contains(t, out, "Inputs:")
+	assert.Contains(t, out, "Case to close")
+	assert.Contains(t, out, "Outputs:\n  (none)")
+}
+
+func TestInvokeCommand(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		wantInputs []map[string]interface{}
+	}{
+		{
+			name:       "single object",
+			input:      `{"caseId": "500xx1"}`,
+			wantInputs: []map[string]interface{}{{"caseId": "500xx1"}},
+		},
+		{
+			name:       "array",
+			input:      `[{"caseId": "500xx1"}, {"caseId": "500xx2"}]`,
+			wantInputs: []map[string]interface{}{{"caseId": "500xx1"}, {"caseId": "500xx2"}},
+		},
+		{
+			name:       "inputs object",
+			input:      `{"inputs": [{"caseId": "500xx1"}]}`,
+			wantInputs: []map[string]interface{}{{"caseId": "500xx1"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "inputs.json")
+			require.NoError(t, os.WriteFile(path, []byte(tt.input), 0644))
+
+			var received [][]map[string]interface{}
+			opts, stdout := newTestOptions(actionServer(t, &received))
+
+			cmd := NewCommand(opts)
+			cmd.SetArgs([]string{"invoke", "Flow", "Close_Case", "--input", path})
+
+			require.NoError(t, cmd.Execute())
+			require.Len(t, received, 1)
+			assert.Equal(t, tt.wantInputs, received[0])
+			assert.Contains(t, stdout.String(), "closed=true, status=Closed")
+		})
+	}
+}
+
+func TestInvokeCommand_Stdin(t *testing.T) {
+	var received [][]map[string]interface{}
+	opts, _ := newTestOptions(actionServer(t, &received))
+	opts.Stdin = strings.NewReader(`{"caseId": "500xx1"}`)
+
+	cmd := NewCommand(opts)
+	cmd.SetArgs([]string{"invoke", "flow", "Close_Case", "--input", "-"})
+
+	require.NoError(t, cmd.Execute())
+	require.Len(t, received, 1)
+	assert.Equal(t, []map[string]interface{}{{"caseId": "500xx1"}}, received[0])
+}
+
+func TestInvokeCommand_Failed(t *testing.T) {
+	opts, stdout := newTestOptions(actionServer(t, nil))
+	opts.Stdin = strings.NewReader(`[{"caseId": "500xx1"}, {}]`)
+
+	cmd := NewCommand(opts)
+	cmd.SetArgs([]string{"invoke", "flow", "Close_Case", "--input", "-"})
+
+	err := cmd.Execute()
+	assert.EqualError(t, err, "1 of 2 inputs failed")
+	assert.Contains(t, stdout.String(), "REQUIRED_FIELD_MISSING: Missing caseId")
+}
+
+func TestInvokeCommand_DryRun(t *testing.T) {
+	var received [][]map[string]interface{}
+	opts, stdout := newTestOptions(actionServer(t, &received))
+	opts.DryRun = true
+	opts.Output = "json"
+	opts.Stdin = strings.NewReader(`{"caseId": "500xx1"}`)
+
+	cmd := NewCommand(opts)
+	cmd.SetArgs([]string{"invoke", "flow", "Close_Case", "--input", "-"})
+
+	require.NoError(t, cmd.Execute())
+	assert.Empty(t, received)
+
+	var out struct {
+		DryRun  bool               `json:"dryRun"`
+		Request root.DryRunRequest `json:"request"`
+	}
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &out))
+	assert.True(t, out.DryRun)
+	assert.Equal(t, http.MethodPost, out.Request.Method)
+	assert.True(t, strings.HasSuffix(out.Request.URL, "/services/data/v62.0/actions/custom/flow/Close_Case"))
+}
+
+func TestFormatOutputs(t *testing.T) {
+	got := formatOutputs(map[string]interface{}{
+		"status": "Closed",
+		"count":  float64(2),
+		"ids":    []interface{}{"a", "b"},
+		"note":   nil,
+	})
+	assert.Equal(t, `count=2, ids=["a","b"], note=, status=Closed`, got)
+}
@@ -0,0 +1,83 @@
+package actioncmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/salesforce-cli/api/actions"
+	"github.com/open-cli-collective/salesforce-cli/internal/cmd/root"
+)
+
+func newDescribeCommand(opts *root.Options) *cobra.Command {
+	return &cobra.Command{
+		Use:   "describe <type> <name>",
+		Short: "Show the inputs and outputs of an action",
+		Long: `Show the inputs an action takes and the outputs it returns.
+
+Examples:
+  sfdc action describe flow Close_Case
+  sfdc action describe standard chatterPost
+  sfdc action describe apex AccountScorer -o json`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDescribe(cmd.Context(), opts, args[0], args[1])
+		},
+	}
+}
+
+func runDescribe(ctx context.Context, opts *root.Options, actionType, name string) error {
+	client, err := opts.APIClient()
+	if err != nil {
+		return fmt.Errorf("failed to create API client: %w", err)
+	}
+
+	desc, err := actions.New(client).Describe(ctx, actionType, name)
+	if err != nil {
+		return fmt.Errorf("failed to describe action: %w", err)
+	}
+
+	v := opts.View()
+
+	if opts.Output == "json" {
+		return v.JSON(desc)
+	}
+
+	v.Info("%s (%s)", desc.Label, desc.Type)
+	if desc.Description != "" {
+		v.Info("%s", desc.Description)
+	}
+
+	headers := []string{"Name", "Type", "Required", "Description"}
+	for _, section := range []struct {
+		title  string
+		params []actions.Parameter
+	}{
+		{"Inputs", desc.Inputs},
+		{"Outputs", desc.Outputs},
+	} {
+		v.Info("\n%s:", section.title)
+		if len(section.params) == 0 {
+			v.Info("  (none)")
+			continue
+		}
+		rows := make([][]string, 0, len(section.params))
+		for _, p := range section.params {
+			required := ""
+			if p.Required {
+				required = "yes"
+			}
+			typ := p.Type
+			if p.SObjectType != "" {
+				typ += " (" + p.SObjectType + ")"
+			}
+			rows = append(rows, []string{p.Name, typ, required, p.Description})
+		}
+		if err := v.Table(headers, rows); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,177 @@
+package actioncmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/salesforce-cli/api/actions"
+	"github.com/open-cli-collective/salesforce-cli/internal/cmd/root"
+)
+
+func newInvokeCommand(opts *root.Options) *cobra.Command {
+	var input string
+
+	cmd := &cobra.Command{
+		Use:   "invoke <type> <name>",
+		Short: "Invoke an action",
+		Long: `Invoke an action with JSON inputs.
+
+The input file holds one input object, an array of them, or an object with
+an "inputs" array, as the REST API takes it. Each input runs the action once,
+in a single request. With no --input, the action runs once with no inputs.
+
+A result is shown for each input. The command fails if any input failed.
+
+Examples:
+  sfdc action invoke Flow Close_Case --input inputs.json
+  sfdc action invoke standard chatterPost --input post.json
+  echo '{"caseId":"500xx000001abcd"}' | sfdc action invoke flow Close_Case --input -
+  sfdc action invoke apex AccountScorer --input accounts.json -o json`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runInvoke(cmd.Context(), opts, args[0], args[1], input)
+		},
+	}
+
+	cmd.Flags().StringVar(&input, "input", "", "JSON file of inputs, or - for stdin")
+
+	return cmd
+}
+
+func runInvoke(ctx context.Context, opts *root.Options, actionType, name, input string) error {
+	inputs := []map[string]interface{}{{}}
+	if input != "" {
+		var err error
+		if inputs, err = readInputs(opts, input); err != nil {
+			return err
+		}
+	}
+
+	client, err := opts.APIClient()
+	if err != nil {
+		return fmt.Errorf("failed to create API client: %w", err)
+	}
+
+	if opts.DryRun {
+		return opts.PrintDryRun(root.DryRunRequest{
+			Operation: "invoke action",
+			Method:    http.MethodPost,
+			URL:       client.ResourceURL(actions.Path(actionType, name)),
+			Payload:   map[string]interface{}{"inputs": inputs},
+		})
+	}
+
+	results, err := actions.New(client).Invoke(ctx, actionType, name, inputs)
+	if err != nil {
+		return fmt.Errorf("failed to invoke action: %w", err)
+	}
+
+	v := opts.View()
+
+	failed := 0
+	for _, r := range results {
+		if !r.IsSuccess {
+			failed++
+		}
+	}
+
+	if opts.Output == "json" {
+		if err := v.JSON(results); err != nil {
+			return err
+		}
+	} else {
+		rows := make([][]string, 0, len(results))
+		for i, r := range results {
+			status, detail := "yes", formatOutputs(r.OutputValues)
+			if !r.IsSuccess {
+				status, detail = "no", r.ErrorMessage()
+			}
+			rows = append(rows, []string{fmt.Sprintf("%d", i+1), status, detail})
+		}
+		if err := v.Table([]string{"#", "Success", "Outputs / Errors"}, rows); err != nil {
+			return err
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d inputs failed", failed, len(results))
+	}
+	return nil
+}
+
+// readInputs reads action inputs from a file, or from stdin if file is "-".
+func readInputs(opts *root.Options, file string) ([]map[string]interface{}, error) {
+	var (
+		data []byte
+		err  error
+	)
+	if file == "-" {
+		data, err = io.ReadAll(opts.Stdin)
+	} else {
+		data, err = os.ReadFile(file)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read inputs: %w", err)
+	}
+
+	data = bytes.TrimSpace(data)
+	if bytes.HasPrefix(data, []byte("[")) {
+		var inputs []map[string]interface{}
+		if err := json.Unmarshal(data, &inputs); err != nil {
+			return nil, fmt.Errorf("failed to parse inputs: %w", err)
+		}
+		return inputs, nil
+	}
+
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return nil, fmt.Errorf("failed to parse inputs: %w", err)
+	}
+	if raw, ok := obj["inputs"]; ok && len(obj) == 1 {
+		var inputs []map[string]interface{}
+		if err := json.Unmarshal(raw, &inputs); err != nil {
+			return nil, fmt.Errorf("failed to parse inputs: %w", err)
+		}
+		return inputs, nil
+	}
+
+	var single map[string]interface{}
+	if err := json.Unmarshal(data, &single); err != nil {
+		return nil, fmt.Errorf("failed to parse inputs: %w", err)
+	}
+	return []map[string]interface{}{single}, nil
+}
+
+// formatOutputs formats output values as name=value pairs sorted by name.
+func formatOutputs(values map[string]interface{}) string {
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	pairs := make([]string, 0, len(names))
+	for _, name := range names {
+		value := values[name]
+		var s string
+		switch value.(type) {
+		case nil:
+		case string, float64, bool:
+			s = fmt.Sprint(value)
+		default:
+			data, _ := json.Marshal(value)
+			s = string(data)
+		}
+		pairs = append(pairs, name+"="+s)
+	}
+	return strings.Join(pairs, ", ")
+}
@@ -0,0 +1,101 @@
+package actioncmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/salesforce-cli/api/actions"
+	"github.com/open-cli-collective/salesforce-cli/internal/cmd/root"
+)
+
+func newTypesCommand(opts *root.Options) *cobra.Command {
+	return &cobra.Command{
+		Use:   "types",
+		Short: "List custom action types",
+		Long: `List the custom action types available in the org, such as apex, flow,
+and quickAction. Standard actions have the type "standard".
+
+Examples:
+  sfdc action types`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runTypes(cmd.Context(), opts)
+		},
+	}
+}
+
+func runTypes(ctx context.Context, opts *root.Options) error {
+	client, err := opts.APIClient()
+	if err != nil {
+		return fmt.Errorf("failed to create API client: %w", err)
+	}
+
+	types, err := actions.New(client).CustomTypes(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list action types: %w", err)
+	}
+
+	v := opts.View()
+
+	if opts.Output == "json" {
+		return v.JSON(types)
+	}
+
+	rows := make([][]string, 0, len(types))
+	for _, t := range types {
+		rows = append(rows, []string{t})
+	}
+	return v.Table([]string{"Type"}, rows)
+}
+
+func newListCommand(opts *root.Options) *cobra.Command {
+	return &cobra.Command{
+		Use:   "list <type>",
+		Short: "List the actions of a type",
+		Long: `List the invocable actions of a type.
+
+Examples:
+  sfdc action list standard
+  sfdc action list flow
+  sfdc action list apex -o json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runList(cmd.Context(), opts, args[0])
+		},
+	}
+}
+
+func runList(ctx context.Context, opts *root.Options, actionType string) error {
+	client, err := opts.APIClient()
+	if err != nil {
+		return fmt.Errorf("failed to create API client: %w", err)
+	}
+
+	list, err := actions.New(client).List(ctx, actionType)
+	if err != nil {
+		return fmt.Errorf("failed to list actions: %w", err)
+	}
+
+	v := opts.View()
+
+	if opts.Output == "json" {
+		return v.JSON(list)
+	}
+
+	if len(list) == 0 {
+		v.Info("No %s actions found", actions.NormalizeType(actionType))
+		return nil
+	}
+
+	rows := make([][]string, 0, len(list))
+	for _, action := range list {
+		rows = append(rows, []string{action.Name, action.Label, action.Type})
+	}
+	if err := v.Table([]string{"Name", "Label", "Type"}, rows); err != nil {
+		return err
+	}
+	v.Info("\n%d action(s)", len(list))
+	return nil
+}
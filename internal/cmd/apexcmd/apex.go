@@ -24,13 +24,20 @@ Examples:
   sfdc apex list --triggers               # List all Apex triggers
   sfdc apex get MyController              # Get class source code
   sfdc apex execute "System.debug('Hi');" # Execute anonymous Apex
-  sfdc apex test --class MyTest           # Run Apex tests`,
+  sfdc apex test --class MyTest           # Run Apex tests
+  sfdc apex new MyService                 # Create a class skeleton locally
+  sfdc apex push MyService.cls            # Save a class to the org
+  sfdc apex delete MyOldClass             # Delete a class from the org`,
 	}
 
 	cmd.AddCommand(newListCommand(opts))
 	cmd.AddCommand(newGetCommand(opts))
 	cmd.AddCommand(newExecuteCommand(opts))
 	cmd.AddCommand(newTestCommand(opts))
+	cmd.AddCommand(newNewCommand(opts))
+	cmd.AddCommand(newPushCommand(opts))
+	cmd.AddCommand(newCreateCommand(opts))
+	cmd.AddCommand(newDeleteCommand(opts))
 
 	return cmd
 }
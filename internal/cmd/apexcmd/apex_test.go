@@ -1,18 +1,26 @@
 package apexcmd
 
 import (
+	"archive/zip"
 	"bytes"
+	"encoding/base64"
 	"encoding/json"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/open-cli-collective/salesforce-cli/api"
+	"github.com/open-cli-collective/salesforce-cli/api/metadata"
 	"github.com/open-cli-collective/salesforce-cli/api/tooling"
 	"github.com/open-cli-collective/salesforce-cli/internal/cmd/root"
+	"github.com/open-cli-collective/salesforce-cli/internal/config"
 )
 
 func TestApexListClasses(t *testing.T) {
@@ -201,6 +209,49 @@ func TestApexExecuteSuccess(t *testing.T) {
 	assert.Contains(t, output, "Executed successfully")
 }
 
+func TestApexExecuteDryRun(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request in dry run: %s %s", r.Method, r.URL.Path)
+	}))
+	defer server.Close()
+
+	client, err := tooling.New(tooling.ClientConfig{
+		InstanceURL: server.URL,
+		HTTPClient:  server.Client(),
+	})
+	require.NoError(t, err)
+
+	for _, capture := range []bool{false, true} {
+		stdout := &bytes.Buffer{}
+		stderr := &bytes.Buffer{}
+		opts := &root.Options{
+			Output:  "table",
+			NoColor: true,
+			DryRun:  true,
+			Stdout:  stdout,
+			Stderr:  stderr,
+		}
+		opts.SetToolingClient(client)
+
+		args := []string{"execute", "System.debug('Hello');"}
+		if capture {
+			args = append(args, "--capture")
+		}
+		cmd := NewCommand(opts)
+		cmd.SetArgs(args)
+		cmd.SetOut(stdout)
+
+		require.NoError(t, cmd.Execute())
+		assert.Contains(t, stderr.String(), "Dry run: no changes were made")
+		assert.Contains(t, stdout.String(), "GET "+server.URL+"/services/data/v62.0/tooling/executeAnonymous")
+		if capture {
+			assert.Contains(t, stdout.String(), "TraceFlag:")
+		} else {
+			assert.NotContains(t, stdout.String(), "TraceFlag:")
+		}
+	}
+}
+
 func TestApexExecuteCompileError(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		response := tooling.ExecuteAnonymousResult{
@@ -290,7 +341,7 @@ func TestApexTestNoWait(t *testing.T) {
 				TotalSize: 1,
 				Done:      true,
 				Records: []tooling.Record{
-					{"Id": "01p000000000001"},
+					{"Id": "01p000000000001", "Name": "MyTest"},
 				},
 			}
 			_ = json.NewEncoder(w).Encode(response)
@@ -465,3 +516,1087 @@ func TestApexListJSON(t *testing.T) {
 	require.NoError(t, err)
 	assert.Len(t, result, 1)
 }
+
+func TestApexExecuteFileFromStdin(t *testing.T) {
+	var gotCode string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotCode = r.URL.Query().Get("anonymousBody")
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(tooling.ExecuteAnonymousResult{Compiled: true, Success: true})
+	}))
+	defer server.Close()
+
+	client, err := tooling.New(tooling.ClientConfig{
+		InstanceURL: server.URL,
+		HTTPClient:  server.Client(),
+	})
+	require.NoError(t, err)
+
+	stdout := &bytes.Buffer{}
+	opts := &root.Options{
+		Output: "table",
+		Stdin:  strings.NewReader("System.debug('piped');\n"),
+		Stdout: stdout,
+		Stderr: &bytes.Buffer{},
+	}
+	opts.SetToolingClient(client)
+
+	cmd := NewCommand(opts)
+	cmd.SetArgs([]string{"execute", "--file", "-"})
+	cmd.SetOut(stdout)
+
+	err = cmd.Execute()
+	require.NoError(t, err)
+	assert.Equal(t, "System.debug('piped');", gotCode)
+}
+
+func newCaptureServer(t *testing.T, logBody string, deleted *[]string) *httptest.Server {
+	t.Helper()
+	logQueries := 0
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.URL.Path == "/services/oauth2/userinfo":
+			_ = json.NewEncoder(w).Encode(map[string]string{"user_id": "005000000000001"})
+		case r.URL.Path == "/services/data/v62.0/tooling/query":
+			q := r.URL.Query().Get("q")
+			var records []tooling.Record
+			switch {
+			case strings.Contains(q, "FROM DebugLevel"):
+				records = []tooling.Record{{"Id": "7dl000000000001"}}
+			case strings.Contains(q, "FROM ApexLog"):
+				logQueries++
+				records = []tooling.Record{{"Id": "07L000000000001", "Operation": "/aura"}}
+				if logQueries > 1 {
+					records = append([]tooling.Record{
+						{"Id": "07L000000000002", "Operation": "/services/data/v62.0/tooling/executeAnonymous/"},
+					}, records...)
+				}
+			}
+			_ = json.NewEncoder(w).Encode(tooling.QueryResult{TotalSize: len(records), Done: true, Records: records})
+		case r.Method == http.MethodPost && r.URL.Path == "/services/data/v62.0/tooling/sobjects/TraceFlag":
+			_ = json.NewEncoder(w).Encode(tooling.CreateResult{ID: "7tf000000000001", Success: true})
+		case r.Method == http.MethodDelete:
+			*deleted = append(*deleted, r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+		case strings.HasSuffix(r.URL.Path, "/executeAnonymous"):
+			_ = json.NewEncoder(w).Encode(tooling.ExecuteAnonymousResult{Compiled: true, Success: true})
+		case r.URL.Path == "/services/data/v62.0/sobjects/ApexLog/07L000000000002/Body":
+			_, _ = w.Write([]byte(logBody))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestApexExecuteCapture(t *testing.T) {
+	logBody := "62.0 APEX_CODE,DEBUG\n" +
+		"12:00:00.1 (100)|EXECUTION_STARTED\n" +
+		"12:00:00.1 (200)|USER_DEBUG|[1]|DEBUG|first\n" +
+		"12:00:00.1 (300)|USER_DEBUG|[2]|DEBUG|42\n" +
+		"12:00:00.1 (400)|CUMULATIVE_LIMIT_USAGE\n"
+
+	var deleted []string
+	server := newCaptureServer(t, logBody, &deleted)
+	defer server.Close()
+
+	client, err := tooling.New(tooling.ClientConfig{
+		InstanceURL: server.URL,
+		HTTPClient:  server.Client(),
+	})
+	require.NoError(t, err)
+
+	stdout := &bytes.Buffer{}
+	opts := &root.Options{
+		Output: "table",
+		Stdout: stdout,
+		Stderr: &bytes.Buffer{},
+	}
+	opts.SetToolingClient(client)
+
+	cmd := NewCommand(opts)
+	cmd.SetArgs([]string{"execute", "System.debug('first'); System.debug(42);", "--capture"})
+	cmd.SetOut(stdout)
+
+	err = cmd.Execute()
+	require.NoError(t, err)
+	assert.Equal(t, "42\n", stdout.String())
+	assert.Equal(t, []string{"/services/data/v62.0/tooling/sobjects/TraceFlag/7tf000000000001"}, deleted)
+}
+
+func TestApexExecuteCaptureNoDebug(t *testing.T) {
+	var deleted []string
+	server := newCaptureServer(t, "12:00:00.1 (100)|EXECUTION_STARTED\n", &deleted)
+	defer server.Close()
+
+	client, err := tooling.New(tooling.ClientConfig{
+		InstanceURL: server.URL,
+		HTTPClient:  server.Client(),
+	})
+	require.NoError(t, err)
+
+	opts := &root.Options{
+		Output: "table",
+		Stdout: &bytes.Buffer{},
+		Stderr: &bytes.Buffer{},
+	}
+	opts.SetToolingClient(client)
+
+	cmd := NewCommand(opts)
+	cmd.SetArgs([]string{"execute", "Integer i = 1;", "--capture"})
+
+	err = cmd.Execute()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "no USER_DEBUG output")
+	assert.Len(t, deleted, 1)
+}
+
+func TestLastUserDebug(t *testing.T) {
+	tests := []struct {
+		name      string
+		body      string
+		want      string
+		wantFound bool
+	}{
+		{
+			name:      "single line",
+			body:      "12:00:00.1 (1)|USER_DEBUG|[1]|DEBUG|hello\n",
+			want:      "hello",
+			wantFound: true,
+		},
+		{
+			name: "multi-line message",
+			body: "12:00:00.1 (1)|USER_DEBUG|[1]|DEBUG|{\n  \"a\": 1\n}\n" +
+				"12:00:00.1 (2)|CUMULATIVE_LIMIT_USAGE\n",
+			want:      "{\n  \"a\": 1\n}",
+			wantFound: true,
+		},
+		{
+			name:      "message containing pipes",
+			body:      "12:00:00.1 (1)|USER_DEBUG|[3]|INFO|a|b\n",
+			want:      "a|b",
+			wantFound: true,
+		},
+		{
+			name:      "no debug output",
+			body:      "12:00:00.1 (1)|EXECUTION_STARTED\n",
+			wantFound: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, found := lastUserDebug(tt.body)
+			assert.Equal(t, tt.wantFound, found)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestApexNewClass(t *testing.T) {
+	dir := t.TempDir()
+	stdout := &bytes.Buffer{}
+	opts := &root.Options{
+		Output: "table",
+		Stdout: stdout,
+		Stderr: &bytes.Buffer{},
+	}
+
+	cmd := NewCommand(opts)
+	cmd.SetArgs([]string{"new", "MyService", "--dir", dir})
+
+	err := cmd.Execute()
+	require.NoError(t, err)
+
+	body, err := os.ReadFile(filepath.Join(dir, "MyService.cls"))
+	require.NoError(t, err)
+	assert.Equal(t, "public with sharing class MyService {\n\n}\n", string(body))
+
+	meta, err := os.ReadFile(filepath.Join(dir, "MyService.cls-meta.xml"))
+	require.NoError(t, err)
+	assert.Contains(t, string(meta), "<ApexClass xmlns=\"http://soap.sforce.com/2006/04/metadata\">")
+	assert.Contains(t, string(meta), "<apiVersion>62.0</apiVersion>")
+
+	// Refuses to overwrite without --force
+	cmd = NewCommand(opts)
+	cmd.SetArgs([]string{"new", "MyService", "--dir", dir})
+	err = cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "already exists")
+
+	cmd = NewCommand(opts)
+	cmd.SetArgs([]string{"new", "MyService", "--dir", dir, "--force"})
+	assert.NoError(t, cmd.Execute())
+}
+
+func TestApexNewTriggerPromptsForEvents(t *testing.T) {
+	dir := t.TempDir()
+	stdout := &bytes.Buffer{}
+	opts := &root.Options{
+		Output:     "table",
+		APIVersion: "v60.0",
+		Stdin:      strings.NewReader("after update, before insert\n"),
+		Stdout:     stdout,
+		Stderr:     &bytes.Buffer{},
+	}
+
+	cmd := NewCommand(opts)
+	cmd.SetArgs([]string{"new", "AccountTrigger", "--type", "trigger", "--sobject", "Account", "--dir", dir})
+
+	err := cmd.Execute()
+	require.NoError(t, err)
+
+	body, err := os.ReadFile(filepath.Join(dir, "AccountTrigger.trigger"))
+	require.NoError(t, err)
+	assert.Equal(t, "trigger AccountTrigger on Account (before insert, after update) {\n\n}\n", string(body))
+
+	meta, err := os.ReadFile(filepath.Join(dir, "AccountTrigger.trigger-meta.xml"))
+	require.NoError(t, err)
+	assert.Contains(t, string(meta), "<ApexTrigger")
+	assert.Contains(t, string(meta), "<apiVersion>60.0</apiVersion>")
+}
+
+func TestApexNewValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{"invalid name", []string{"new", "1Bad"}, "invalid name"},
+		{"invalid type", []string{"new", "Foo", "--type", "enum"}, "invalid type"},
+		{"trigger without sobject", []string{"new", "Foo", "--type", "trigger"}, "--sobject is required"},
+		{"invalid event", []string{"new", "Foo", "--type", "trigger", "--sobject", "Account", "--events", "before save"}, "invalid trigger event"},
+		{"class with events", []string{"new", "Foo", "--events", "before insert"}, "only valid with --type trigger"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := &root.Options{
+				Stdout: &bytes.Buffer{},
+				Stderr: &bytes.Buffer{},
+			}
+
+			cmd := NewCommand(opts)
+			cmd.SetArgs(append(tt.args, "--dir", t.TempDir()))
+
+			err := cmd.Execute()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestApexTestCoverageOnly(t *testing.T) {
+	var enqueued tooling.RunTestsRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		query := r.URL.Query().Get("q")
+
+		switch {
+		case strings.Contains(r.URL.Path, "runTestsAsynchronous"):
+			_ = json.NewDecoder(r.Body).Decode(&enqueued)
+			w.Write([]byte(`"7071x00000ABCDE"`))
+		case strings.Contains(query, "FROM ApexClass WHERE Name IN ('AccountTest','ContactTest')"):
+			_ = json.NewEncoder(w).Encode(tooling.QueryResult{TotalSize: 2, Done: true, Records: []tooling.Record{
+				{"Id": "01pT1", "Name": "AccountTest"},
+				{"Id": "01pT2", "Name": "ContactTest"},
+			}})
+		case strings.Contains(query, "FROM AsyncApexJob"):
+			_ = json.NewEncoder(w).Encode(tooling.QueryResult{TotalSize: 1, Done: true, Records: []tooling.Record{{"Id": "7071x00000ABCDE", "Status": "Completed"}}})
+		case strings.Contains(query, "FROM ApexTestResult"):
+			_ = json.NewEncoder(w).Encode(tooling.QueryResult{TotalSize: 2, Done: true, Records: []tooling.Record{
+				{"ApexClassId": "01pT1", "MethodName": "testInsert", "Outcome": "Pass"},
+				{"ApexClassId": "01pT2", "MethodName": "testUpdate", "Outcome": "Fail"},
+			}})
+		case strings.Contains(query, "FROM ApexCodeCoverage WHERE ApexTestClassId IN ('01pT1','01pT2')"):
+			_ = json.NewEncoder(w).Encode(tooling.QueryResult{TotalSize: 3, Done: true, Records: []tooling.Record{
+				{
+					"ApexClassOrTriggerId": "01pA", "ApexClassOrTrigger": map[string]interface{}{"Name": "AccountService"},
+					"ApexTestClassId": "01pT1", "TestMethodName": "testInsert",
+					"Coverage": map[string]interface{}{"coveredLines": []interface{}{1.0, 2.0}, "uncoveredLines": []interface{}{3.0, 4.0}},
+				},
+				{
+					"ApexClassOrTriggerId": "01pA", "ApexClassOrTrigger": map[string]interface{}{"Name": "AccountService"},
+					"ApexTestClassId": "01pT2", "TestMethodName": "testUpdate",
+					"Coverage": map[string]interface{}{"coveredLines": []interface{}{2.0, 3.0}, "uncoveredLines": []interface{}{1.0, 4.0}},
+				},
+				{
+					// From a method that was not part of this run
+					"ApexClassOrTriggerId": "01pB", "ApexClassOrTrigger": map[string]interface{}{"Name": "Unrelated"},
+					"ApexTestClassId": "01pT1", "TestMethodName": "testOld",
+					"Coverage": map[string]interface{}{"coveredLines": []interface{}{1.0}, "uncoveredLines": []interface{}{}},
+				},
+			}})
+		default:
+			t.Errorf("unexpected request: %s %s", r.URL.Path, query)
+		}
+	}))
+	defer server.Close()
+
+	client, err := tooling.New(tooling.ClientConfig{
+		InstanceURL: server.URL,
+		HTTPClient:  server.Client(),
+	})
+	require.NoError(t, err)
+
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	opts := &root.Options{
+		Output: "table",
+		Stdout: stdout,
+		Stderr: stderr,
+	}
+	opts.SetToolingClient(client)
+
+	cmd := NewCommand(opts)
+	cmd.SetArgs([]string{"test", "--class", "AccountTest", "--class", "ContactTest", "--coverage-only"})
+	cmd.SetOut(stdout)
+
+	err = cmd.Execute()
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"01pT1", "01pT2"}, enqueued.ClassIDs)
+
+	output := stdout.String()
+	assert.Contains(t, output, "AccountService")
+	assert.Contains(t, output, "75.0%")
+	assert.NotContains(t, output, "Unrelated")
+	assert.NotContains(t, output, "testInsert")
+	assert.Contains(t, output, "Overall: 3/4 lines covered")
+	assert.Contains(t, stderr.String(), "1 test(s) failed")
+}
+
+func TestApexTestWait(t *testing.T) {
+	status, updateOutcome := "Completed", "Fail"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		query := r.URL.Query().Get("q")
+
+		switch {
+		case strings.Contains(r.URL.Path, "runTestsAsynchronous"):
+			w.Write([]byte(`"7071x00000ABCDE"`))
+		case strings.Contains(query, "FROM ApexClass WHERE Name IN ('AccountTest')"):
+			_ = json.NewEncoder(w).Encode(tooling.QueryResult{TotalSize: 1, Done: true, Records: []tooling.Record{{"Id": "01pT1", "Name": "AccountTest"}}})
+		case strings.Contains(query, "FROM AsyncApexJob"):
+			_ = json.NewEncoder(w).Encode(tooling.QueryResult{TotalSize: 1, Done: true, Records: []tooling.Record{
+				{"Id": "7071x00000ABCDE", "Status": status, "JobItemsProcessed": 1.0, "TotalJobItems": 1.0},
+			}})
+		case strings.Contains(query, "FROM ApexTestResult"):
+			_ = json.NewEncoder(w).Encode(tooling.QueryResult{TotalSize: 3, Done: true, Records: []tooling.Record{
+				{"ApexClassId": "01pT1", "ApexClass": map[string]interface{}{"Name": "AccountTest"}, "MethodName": "testInsert", "Outcome": "Pass", "RunTime": 120.0},
+				{"ApexClassId": "01pT1", "ApexClass": map[string]interface{}{"Name": "AccountTest"}, "MethodName": "testUpdate", "Outcome": updateOutcome, "RunTime": 80.0, "Message": "System.AssertException: Assertion Failed"},
+				{"ApexClassId": "01pT1", "ApexClass": map[string]interface{}{"Name": "AccountTest"}, "MethodName": "testDelete", "Outcome": "Skip"},
+			}})
+		case strings.Contains(query, "FROM ApexCodeCoverage WHERE ApexTestClassId IN ('01pT1')"):
+			_ = json.NewEncoder(w).Encode(tooling.QueryResult{TotalSize: 1, Done: true, Records: []tooling.Record{
+				{
+					"ApexClassOrTriggerId": "01pA", "ApexClassOrTrigger": map[string]interface{}{"Name": "AccountService"},
+					"ApexTestClassId": "01pT1", "ApexTestClass": map[string]interface{}{"Name": "AccountTest"}, "TestMethodName": "testInsert",
+					"Coverage": map[string]interface{}{"coveredLines": []interface{}{1.0, 2.0, 3.0}, "uncoveredLines": []interface{}{4.0}},
+				},
+			}})
+		default:
+			t.Errorf("unexpected request: %s %s", r.URL.Path, query)
+		}
+	}))
+	defer server.Close()
+
+	run := func(t *testing.T, args ...string) (string, string, error) {
+		t.Helper()
+		client, err := tooling.New(tooling.ClientConfig{
+			InstanceURL: server.URL,
+			HTTPClient:  server.Client(),
+		})
+		require.NoError(t, err)
+
+		stdout := &bytes.Buffer{}
+		stderr := &bytes.Buffer{}
+		opts := &root.Options{
+			Output: "table",
+			Stdout: stdout,
+			Stderr: stderr,
+		}
+		opts.SetToolingClient(client)
+
+		cmd := NewCommand(opts)
+		cmd.SetArgs(append([]string{"test", "--class", "AccountTest", "--wait"}, args...))
+		cmd.SetOut(stdout)
+		err = cmd.Execute()
+		return stdout.String(), stderr.String(), err
+	}
+
+	t.Run("failed test", func(t *testing.T) {
+		output, errOutput, err := run(t)
+		require.Error(t, err)
+		assert.Equal(t, "1 test(s) failed", err.Error())
+
+		assert.Contains(t, output, "1 of 1 test classes completed")
+		assert.Contains(t, output, "testInsert")
+		assert.Contains(t, output, "1 passed, 1 failed, 1 skipped, 200ms total")
+		assert.Contains(t, output, "Coverage: 3/4 lines covered (75.0%) in 1 classes and triggers")
+		assert.Contains(t, errOutput, "AccountTest.testUpdate:")
+		assert.Contains(t, errOutput, "System.AssertException: Assertion Failed")
+	})
+
+	t.Run("aborted run", func(t *testing.T) {
+		status, updateOutcome = "Aborted", "Pass"
+		defer func() { status, updateOutcome = "Completed", "Fail" }()
+
+		output, _, err := run(t)
+		require.Error(t, err)
+		assert.Equal(t, "test job 7071x00000ABCDE aborted", err.Error())
+		assert.Contains(t, output, "2 passed, 1 skipped")
+	})
+
+	t.Run("coverage by test class", func(t *testing.T) {
+		output, _, err := run(t, "--coverage")
+		require.Error(t, err)
+
+		assert.Contains(t, output, "Test Class")
+		assert.Contains(t, output, "AccountService")
+		assert.Contains(t, output, "3/4")
+		assert.Contains(t, output, "Uncovered lines:")
+		assert.Contains(t, output, "AccountService: 4")
+	})
+}
+
+func TestApexTestSync(t *testing.T) {
+	var request tooling.RunTestsSyncRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		query := r.URL.Query().Get("q")
+
+		switch {
+		case strings.Contains(r.URL.Path, "runTestsSynchronous"):
+			_ = json.NewDecoder(r.Body).Decode(&request)
+			w.Write([]byte(`{
+				"numTestsRun": 2, "numFailures": 1, "totalTime": 200,
+				"successes": [{"id": "01pT1", "name": "AccountTest", "methodName": "testInsert", "time": 120}],
+				"failures": [{"id": "01pT1", "name": "AccountTest", "methodName": "testUpdate", "time": 80, "message": "System.AssertException: Assertion Failed", "stackTrace": "Class.AccountTest.testUpdate: line 12"}],
+				"codeCoverage": [{"id": "01pA", "name": "AccountService", "type": "Class", "numLocations": 4, "numLocationsNotCovered": 1}],
+				"codeCoverageWarnings": [{"id": "01pA", "name": "AccountService", "message": "Average test coverage across all Apex Classes and Triggers is 75%, at least 75% test coverage is required."}]
+			}`))
+		case strings.Contains(query, "FROM ApexClass WHERE Name IN ('AccountTest')"):
+			_ = json.NewEncoder(w).Encode(tooling.QueryResult{TotalSize: 1, Done: true, Records: []tooling.Record{{"Id": "01pT1", "Name": "AccountTest"}}})
+		default:
+			t.Errorf("unexpected request: %s %s", r.URL.Path, query)
+		}
+	}))
+	defer server.Close()
+
+	client, err := tooling.New(tooling.ClientConfig{
+		InstanceURL: server.URL,
+		HTTPClient:  server.Client(),
+	})
+	require.NoError(t, err)
+
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	opts := &root.Options{
+		Output: "table",
+		Stdout: stdout,
+		Stderr: stderr,
+	}
+	opts.SetToolingClient(client)
+
+	cmd := NewCommand(opts)
+	cmd.SetArgs([]string{"test", "--class", "AccountTest", "--sync"})
+	cmd.SetOut(stdout)
+	err = cmd.Execute()
+	require.Error(t, err)
+	assert.Equal(t, "1 test(s) failed", err.Error())
+
+	assert.Equal(t, []tooling.TestItem{{ClassID: "01pT1"}}, request.Tests)
+	output := stdout.String()
+	assert.Contains(t, output, "testInsert")
+	assert.Contains(t, output, "1 passed, 1 failed, 200ms total")
+	assert.Contains(t, output, "Coverage: 3/4 lines covered (75.0%) in 1 classes and triggers")
+	assert.Contains(t, stderr.String(), "at least 75% test coverage is required")
+	assert.Contains(t, stderr.String(), "AccountTest.testUpdate:")
+}
+
+func TestApexTestSyncRequiresSingleClass(t *testing.T) {
+	opts := &root.Options{
+		Stdout: &bytes.Buffer{},
+		Stderr: &bytes.Buffer{},
+	}
+
+	cmd := NewCommand(opts)
+	cmd.SetArgs([]string{"test", "--class", "AccountTest", "--class", "ContactTest", "--sync"})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--sync can only be used with a single --class")
+}
+
+func TestApexTestSelection(t *testing.T) {
+	var (
+		enqueued tooling.RunTestsRequest
+		queries  []string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		query := r.URL.Query().Get("q")
+		if query != "" {
+			queries = append(queries, query)
+		}
+
+		switch {
+		case strings.Contains(r.URL.Path, "runTestsAsynchronous"):
+			_ = json.NewDecoder(r.Body).Decode(&enqueued)
+			w.Write([]byte(`"7071x00000ABCDE"`))
+		case strings.Contains(query, "FROM ApexClass WHERE Name IN"):
+			_ = json.NewEncoder(w).Encode(tooling.QueryResult{TotalSize: 2, Done: true, Records: []tooling.Record{
+				{"Id": "01pT1", "Name": "AccountTest"},
+				{"Id": "01pT2", "Name": "ContactTest"},
+			}})
+		case strings.Contains(query, "FROM ApexClass ORDER BY Name"):
+			_ = json.NewEncoder(w).Encode(tooling.QueryResult{TotalSize: 3, Done: true, Records: []tooling.Record{
+				{"Id": "01pT1", "Name": "Account_Test"},
+				{"Id": "01pA", "Name": "AccountService"},
+				{"Id": "01pT2", "Name": "Contact_Test"},
+			}})
+		case strings.Contains(query, "FROM ApexTestSuite WHERE TestSuiteName = 'Smoke'"):
+			_ = json.NewEncoder(w).Encode(tooling.QueryResult{TotalSize: 1, Done: true, Records: []tooling.Record{{"Id": "05FS1"}}})
+		case strings.Contains(query, "FROM ApexTestSuite"):
+			_ = json.NewEncoder(w).Encode(tooling.QueryResult{TotalSize: 0, Done: true})
+		default:
+			t.Errorf("unexpected request: %s %s", r.URL.Path, query)
+		}
+	}))
+	defer server.Close()
+
+	run := func(t *testing.T, args ...string) (string, error) {
+		t.Helper()
+		enqueued, queries = tooling.RunTestsRequest{}, nil
+
+		client, err := tooling.New(tooling.ClientConfig{
+			InstanceURL: server.URL,
+			HTTPClient:  server.Client(),
+		})
+		require.NoError(t, err)
+
+		stdout := &bytes.Buffer{}
+		opts := &root.Options{
+			Output: "table",
+			Stdout: stdout,
+			Stderr: &bytes.Buffer{},
+		}
+		opts.SetToolingClient(client)
+
+		cmd := NewCommand(opts)
+		cmd.SetArgs(append([]string{"test"}, args...))
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+		err = cmd.Execute()
+		return stdout.String(), err
+	}
+
+	t.Run("test methods", func(t *testing.T) {
+		output, err := run(t, "--tests", "AccountTest.testInsert,ContactTest", "--tests", "AccountTest.testUpdate", "--namespace", "acme")
+		require.NoError(t, err)
+
+		assert.Equal(t, []tooling.TestItem{
+			{ClassID: "01pT1", TestMethods: []string{"testInsert", "testUpdate"}},
+			{ClassID: "01pT2"},
+		}, enqueued.Tests)
+		assert.Empty(t, enqueued.ClassIDs)
+		assert.Contains(t, queries[0], "NamespacePrefix = 'acme'")
+		assert.Contains(t, output, "Running tests for AccountTest, ContactTest...")
+	})
+
+	t.Run("pattern and suite", func(t *testing.T) {
+		output, err := run(t, "--class-pattern", "*_Test", "--suite", "Smoke")
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"01pT1", "01pT2"}, enqueued.ClassIDs)
+		assert.Equal(t, []string{"05FS1"}, enqueued.SuiteIDs)
+		assert.Empty(t, enqueued.Tests)
+		assert.Contains(t, output, "Running tests for Account_Test, Contact_Test, suite Smoke...")
+	})
+
+	t.Run("missing classes", func(t *testing.T) {
+		_, err := run(t, "--tests", "AccountTest,LeadTest,CaseTest.testClose")
+		require.Error(t, err)
+		assert.Equal(t, "test classes not found: LeadTest, CaseTest", err.Error())
+		assert.Empty(t, enqueued.ClassIDs)
+	})
+
+	t.Run("missing suite", func(t *testing.T) {
+		_, err := run(t, "--suite", "Nightly")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "apex test suite not found: Nightly")
+	})
+
+	t.Run("suite with methods", func(t *testing.T) {
+		_, err := run(t, "--suite", "Smoke", "--tests", "AccountTest.testInsert")
+		require.Error(t, err)
+		assert.Equal(t, "--suite cannot be combined with test methods", err.Error())
+	})
+
+	t.Run("invalid test", func(t *testing.T) {
+		_, err := run(t, "--tests", "AccountTest.")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `invalid test "AccountTest."`)
+	})
+}
+
+func TestWriteJUnit(t *testing.T) {
+	results := []tooling.ApexTestResult{
+		{ClassName: "AccountTest", MethodName: "testInsert", Outcome: "Pass", RunTime: 120, TestTimestamp: "2026-10-16T10:00:00.000+0000"},
+		{ClassName: "AccountTest", MethodName: "testUpdate", Outcome: "Fail", RunTime: 80, Message: "System.AssertException: Assertion Failed: expected 1", StackTrace: "Class.AccountTest.testUpdate: line 12, column 1"},
+		{ClassName: "ContactTest", MethodName: "testSkip", Outcome: "Skip"},
+		{ClassName: "ContactTest", MethodName: "testBroken", Outcome: "CompileFail", Message: "Variable does not exist: x"},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, writeJUnit(&buf, results))
+	assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites name="Apex" tests="4" failures="1" errors="1" skipped="1" time="0.200">
+  <testsuite name="AccountTest" tests="2" failures="1" errors="0" skipped="0" time="0.200" timestamp="2026-10-16T10:00:00.000+0000">
+    <testcase classname="AccountTest" name="testInsert" time="0.120"></testcase>
+    <testcase classname="AccountTest" name="testUpdate" time="0.080">
+      <failure message="System.AssertException: Assertion Failed: expected 1" type="System.AssertException">Class.AccountTest.testUpdate: line 12, column 1</failure>
+    </testcase>
+  </testsuite>
+  <testsuite name="ContactTest" tests="2" failures="0" errors="1" skipped="1" time="0.000">
+    <testcase classname="ContactTest" name="testSkip" time="0.000">
+      <skipped></skipped>
+    </testcase>
+    <testcase classname="ContactTest" name="testBroken" time="0.000">
+      <error message="Variable does not exist: x" type="CompileFail"></error>
+    </testcase>
+  </testsuite>
+</testsuites>
+`, buf.String())
+}
+
+func TestApexTestJUnit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		query := r.URL.Query().Get("q")
+
+		switch {
+		case strings.Contains(r.URL.Path, "runTestsAsynchronous"):
+			w.Write([]byte(`"7071x00000ABCDE"`))
+		case strings.Contains(query, "FROM ApexClass WHERE Name IN ('AccountTest')"):
+			_ = json.NewEncoder(w).Encode(tooling.QueryResult{TotalSize: 1, Done: true, Records: []tooling.Record{{"Id": "01pT1", "Name": "AccountTest"}}})
+		case strings.Contains(query, "FROM AsyncApexJob"):
+			_ = json.NewEncoder(w).Encode(tooling.QueryResult{TotalSize: 1, Done: true, Records: []tooling.Record{{"Id": "7071x00000ABCDE", "Status": "Completed"}}})
+		case strings.Contains(query, "FROM ApexTestResult"):
+			_ = json.NewEncoder(w).Encode(tooling.QueryResult{TotalSize: 2, Done: true, Records: []tooling.Record{
+				{"ApexClassId": "01pT1", "ApexClass": map[string]interface{}{"Name": "AccountTest"}, "MethodName": "testInsert", "Outcome": "Pass"},
+				{"ApexClassId": "01pT1", "ApexClass": map[string]interface{}{"Name": "AccountTest"}, "MethodName": "testUpdate", "Outcome": "Fail", "Message": "System.AssertException: Assertion Failed"},
+			}})
+		case strings.Contains(query, "FROM ApexCodeCoverage"):
+			_ = json.NewEncoder(w).Encode(tooling.QueryResult{Done: true})
+		default:
+			t.Errorf("unexpected request: %s %s", r.URL.Path, query)
+		}
+	}))
+	defer server.Close()
+
+	run := func(t *testing.T, args ...string) (string, error) {
+		t.Helper()
+		client, err := tooling.New(tooling.ClientConfig{
+			InstanceURL: server.URL,
+			HTTPClient:  server.Client(),
+		})
+		require.NoError(t, err)
+
+		stdout := &bytes.Buffer{}
+		opts := &root.Options{
+			Output: "table",
+			Stdout: stdout,
+			Stderr: &bytes.Buffer{},
+		}
+		opts.SetToolingClient(client)
+
+		cmd := NewCommand(opts)
+		cmd.SetArgs(append([]string{"test", "--class", "AccountTest", "--result-format", "junit"}, args...))
+		cmd.SetOut(stdout)
+		err = cmd.Execute()
+		return stdout.String(), err
+	}
+
+	t.Run("stdout", func(t *testing.T) {
+		output, err := run(t)
+		require.Error(t, err)
+		assert.Equal(t, "1 test(s) failed", err.Error())
+		assert.True(t, strings.HasPrefix(output, "<?xml"))
+		assert.Contains(t, output, `<testsuites name="Apex" tests="2" failures="1"`)
+		assert.NotContains(t, output, "1 passed")
+	})
+
+	t.Run("file", func(t *testing.T) {
+		out := filepath.Join(t.TempDir(), "results.xml")
+		output, err := run(t, "--out", out)
+		require.Error(t, err)
+		assert.Contains(t, output, "JUnit results written to "+out)
+		assert.Contains(t, output, "1 passed, 1 failed")
+
+		data, err := os.ReadFile(out)
+		require.NoError(t, err)
+		assert.Contains(t, string(data), `<failure message="System.AssertException: Assertion Failed" type="System.AssertException"></failure>`)
+	})
+
+	t.Run("out without format", func(t *testing.T) {
+		opts := &root.Options{Stdout: &bytes.Buffer{}, Stderr: &bytes.Buffer{}}
+		cmd := NewCommand(opts)
+		cmd.SetArgs([]string{"test", "--class", "AccountTest", "--out", "results.xml"})
+		assert.ErrorContains(t, cmd.Execute(), "--out requires --result-format")
+	})
+}
+
+func TestApexTestMethodRequiresSingleClass(t *testing.T) {
+	opts := &root.Options{
+		Stdout: &bytes.Buffer{},
+		Stderr: &bytes.Buffer{},
+	}
+
+	cmd := NewCommand(opts)
+	cmd.SetArgs([]string{"test", "--class", "A", "--class", "B", "--method", "testX"})
+
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--method can only be used with a single --class")
+}
+
+func newPushServer(t *testing.T, state string, deleted *[]string, members *[]map[string]interface{}) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		query := r.URL.Query().Get("q")
+
+		switch {
+		case strings.Contains(query, "FROM ApexClass WHERE Name IN"):
+			_ = json.NewEncoder(w).Encode(tooling.QueryResult{TotalSize: 1, Done: true, Records: []tooling.Record{
+				{"Id": "01pA", "Name": "AccountService"},
+			}})
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/sobjects/ApexClass"):
+			w.Write([]byte(`{"id": "01pN", "success": true, "errors": []}`))
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/sobjects/MetadataContainer"):
+			w.Write([]byte(`{"id": "1dcC", "success": true, "errors": []}`))
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/sobjects/ApexClassMember"):
+			var member map[string]interface{}
+			_ = json.NewDecoder(r.Body).Decode(&member)
+			*members = append(*members, member)
+			w.Write([]byte(`{"id": "400M", "success": true, "errors": []}`))
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/sobjects/ContainerAsyncRequest"):
+			w.Write([]byte(`{"id": "1drR", "success": true, "errors": []}`))
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/sobjects/ContainerAsyncRequest/1drR"):
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"Id":    "1drR",
+				"State": state,
+				"DeployDetails": map[string]interface{}{
+					"componentFailures": []interface{}{
+						map[string]interface{}{"fullName": "AccountService", "lineNumber": 3, "columnNumber": 12, "problem": "Variable does not exist: x", "problemType": "Error"},
+					},
+				},
+			})
+		case r.Method == http.MethodDelete:
+			*deleted = append(*deleted, r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request: %s %s %s", r.Method, r.URL.Path, query)
+		}
+	}))
+}
+
+func TestApexPush(t *testing.T) {
+	dir := t.TempDir()
+	service := filepath.Join(dir, "AccountService.cls")
+	require.NoError(t, os.WriteFile(service, []byte("public class AccountService {}"), 0644))
+	newThing := filepath.Join(dir, "NewThing.cls")
+	require.NoError(t, os.WriteFile(newThing, []byte("public class NewThing {}"), 0644))
+
+	run := func(t *testing.T, state string) (string, string, []string, []map[string]interface{}, error) {
+		t.Helper()
+		var (
+			deleted []string
+			members []map[string]interface{}
+		)
+		server := newPushServer(t, state, &deleted, &members)
+		defer server.Close()
+
+		client, err := tooling.New(tooling.ClientConfig{
+			InstanceURL: server.URL,
+			HTTPClient:  server.Client(),
+		})
+		require.NoError(t, err)
+
+		stdout := &bytes.Buffer{}
+		stderr := &bytes.Buffer{}
+		opts := &root.Options{
+			Output: "table",
+			Stdout: stdout,
+			Stderr: stderr,
+		}
+		opts.SetToolingClient(client)
+
+		cmd := NewCommand(opts)
+		cmd.SetArgs([]string{"push", service, newThing})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+		err = cmd.Execute()
+		return stdout.String(), stderr.String(), deleted, members, err
+	}
+
+	t.Run("saved", func(t *testing.T) {
+		output, _, deleted, members, err := run(t, "Completed")
+		require.NoError(t, err)
+
+		require.Len(t, members, 1)
+		assert.Equal(t, "1dcC", members[0]["MetadataContainerId"])
+		assert.Equal(t, "01pA", members[0]["ContentEntityId"])
+		assert.Equal(t, "public class AccountService {}", members[0]["Body"])
+		assert.Equal(t, []string{"/services/data/v62.0/tooling/sobjects/MetadataContainer/1dcC"}, deleted)
+		assert.Contains(t, output, "Saved AccountService (01pA)")
+		assert.Contains(t, output, "Created NewThing (01pN)")
+	})
+
+	t.Run("compile errors", func(t *testing.T) {
+		_, errOutput, deleted, _, err := run(t, "Failed")
+		require.Error(t, err)
+		assert.Equal(t, "classes failed to compile: 1 error(s)", err.Error())
+		assert.Contains(t, errOutput, service+":3:12: Variable does not exist: x")
+		assert.Len(t, deleted, 1)
+	})
+}
+
+func TestApexCreateExisting(t *testing.T) {
+	var deleted []string
+	var members []map[string]interface{}
+	server := newPushServer(t, "Completed", &deleted, &members)
+	defer server.Close()
+
+	client, err := tooling.New(tooling.ClientConfig{
+		InstanceURL: server.URL,
+		HTTPClient:  server.Client(),
+	})
+	require.NoError(t, err)
+
+	file := filepath.Join(t.TempDir(), "AccountService.cls")
+	require.NoError(t, os.WriteFile(file, []byte("public class AccountService {}"), 0644))
+
+	opts := &root.Options{
+		Output: "table",
+		Stdout: &bytes.Buffer{},
+		Stderr: &bytes.Buffer{},
+	}
+	opts.SetToolingClient(client)
+
+	cmd := NewCommand(opts)
+	cmd.SetArgs([]string{"create", "--name", "AccountService", "--file", file})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	err = cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "apex class AccountService already exists")
+}
+
+// apexDeleteServer serves an org of the given type with one Apex class,
+// recording Tooling deletes and the destructive changes deployed.
+func apexDeleteServer(t *testing.T, isSandbox bool, deleted *[]string, deploys *[]metadata.DeployRequest) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		q := r.URL.Query().Get("q")
+		switch {
+		case strings.Contains(q, "FROM Organization"):
+			_ = json.NewEncoder(w).Encode(api.QueryResult{
+				TotalSize: 1,
+				Done:      true,
+				Records: []api.SObject{{
+					ID:     "00Dxx0000001gPL",
+					Fields: map[string]interface{}{"Name": "Acme Corp", "IsSandbox": isSandbox},
+				}},
+			})
+		case strings.Contains(q, "FROM ApexClass"):
+			_ = json.NewEncoder(w).Encode(tooling.QueryResult{
+				TotalSize: 1,
+				Done:      true,
+				Records:   []tooling.Record{{"Id": "01p000000000001", "Name": "OldClass"}},
+			})
+		case r.Method == http.MethodDelete:
+			*deleted = append(*deleted, r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/metadata/deployRequest"):
+			var req metadata.DeployRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			*deploys = append(*deploys, req)
+			_ = json.NewEncoder(w).Encode(metadata.DeployResult{ID: "0Af000000000001", Status: "Pending"})
+		case strings.Contains(r.URL.Path, "/metadata/deployRequest/0Af000000000001"):
+			_ = json.NewEncoder(w).Encode(metadata.DeployResult{ID: "0Af000000000001", Status: "Succeeded", Done: true, Success: true})
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.String())
+		}
+	}))
+}
+
+func TestApexDelete(t *testing.T) {
+	tests := []struct {
+		name        string
+		sandbox     bool
+		args        []string
+		stdin       string
+		wantDeleted bool
+		wantDeploy  bool
+		wantOutput  string
+	}{
+		{name: "sandbox", sandbox: true, args: []string{"--yes"}, wantDeleted: true, wantOutput: "Deleted Apex class OldClass"},
+		{name: "sandbox confirmed", sandbox: true, stdin: "y\n", wantDeleted: true, wantOutput: "Deleted Apex class OldClass"},
+		{name: "declined", sandbox: true, stdin: "n\n", wantOutput: "Cancelled"},
+		{name: "production", args: []string{"--yes"}, wantDeploy: true, wantOutput: "Deleted Apex class OldClass"},
+		{name: "production confirmed twice", stdin: "y\ny\n", wantDeploy: true, wantOutput: "Deleted Apex class OldClass"},
+		{name: "check only", sandbox: true, args: []string{"--check-only"}, wantDeploy: true, wantOutput: "can be deleted"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(config.HomeEnvVar, t.TempDir())
+			t.Setenv("SFDC_PRODUCTION_GUARD", "")
+
+			var deleted []string
+			var deploys []metadata.DeployRequest
+			server := apexDeleteServer(t, tt.sandbox, &deleted, &deploys)
+			defer server.Close()
+
+			apiClient, err := api.New(api.ClientConfig{InstanceURL: server.URL, HTTPClient: server.Client()})
+			require.NoError(t, err)
+			toolingClient, err := tooling.New(tooling.ClientConfig{InstanceURL: server.URL, HTTPClient: server.Client()})
+			require.NoError(t, err)
+			metadataClient, err := metadata.New(metadata.ClientConfig{InstanceURL: server.URL, HTTPClient: server.Client()})
+			require.NoError(t, err)
+
+			stdout := &bytes.Buffer{}
+			opts := &root.Options{
+				Output:  "table",
+				NoColor: true,
+				Stdin:   strings.NewReader(tt.stdin),
+				Stdout:  stdout,
+				Stderr:  &bytes.Buffer{},
+			}
+			opts.SetAPIClient(apiClient)
+			opts.SetToolingClient(toolingClient)
+			opts.SetMetadataClient(metadataClient)
+
+			cmd := NewCommand(opts)
+			cmd.SetArgs(append([]string{"delete", "OldClass"}, tt.args...))
+			cmd.SetOut(stdout)
+			cmd.SetErr(&bytes.Buffer{})
+			require.NoError(t, cmd.Execute())
+
+			assert.Contains(t, stdout.String(), tt.wantOutput)
+			if tt.wantDeleted {
+				require.Len(t, deleted, 1)
+				assert.True(t, strings.HasSuffix(deleted[0], "/tooling/sobjects/ApexClass/01p000000000001"))
+			} else {
+				assert.Empty(t, deleted)
+			}
+
+			if !tt.wantDeploy {
+				assert.Empty(t, deploys)
+				return
+			}
+			require.Len(t, deploys, 1)
+			assert.Equal(t, tt.name == "check only", deploys[0].DeployOptions.CheckOnly)
+
+			zipData, err := base64.StdEncoding.DecodeString(deploys[0].ZipFile)
+			require.NoError(t, err)
+			reader, err := zip.NewReader(bytes.NewReader(zipData), int64(len(zipData)))
+			require.NoError(t, err)
+			var destructive string
+			for _, f := range reader.File {
+				if f.Name == "destructiveChanges.xml" {
+					rc, err := f.Open()
+					require.NoError(t, err)
+					data, err := io.ReadAll(rc)
+					require.NoError(t, err)
+					_ = rc.Close()
+					destructive = string(data)
+				}
+			}
+			assert.Contains(t, destructive, "<members>OldClass</members>")
+			assert.Contains(t, destructive, "<name>ApexClass</name>")
+		})
+	}
+}
+
+func TestApexDeleteDryRun(t *testing.T) {
+	tests := []struct {
+		name        string
+		sandbox     bool
+		args        []string
+		wantMethod  string
+		wantPath    string
+		wantPayload []string
+	}{
+		{
+			name:       "sandbox",
+			sandbox:    true,
+			wantMethod: "DELETE",
+			wantPath:   "/services/data/v62.0/tooling/sobjects/ApexClass/01p000000000001",
+		},
+		{
+			name:        "production",
+			wantMethod:  "POST",
+			wantPath:    "/services/data/v62.0/metadata/deployRequest",
+			wantPayload: []string{`"name": "ApexClass"`, `"OldClass"`, `"rollbackOnError": true`},
+		},
+		{
+			name:        "check only",
+			sandbox:     true,
+			args:        []string{"--check-only"},
+			wantMethod:  "POST",
+			wantPath:    "/services/data/v62.0/metadata/deployRequest",
+			wantPayload: []string{`"checkOnly": true`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(config.HomeEnvVar, t.TempDir())
+
+			var deleted []string
+			var deploys []metadata.DeployRequest
+			server := apexDeleteServer(t, tt.sandbox, &deleted, &deploys)
+			defer server.Close()
+
+			apiClient, err := api.New(api.ClientConfig{InstanceURL: server.URL, HTTPClient: server.Client()})
+			require.NoError(t, err)
+			toolingClient, err := tooling.New(tooling.ClientConfig{InstanceURL: server.URL, HTTPClient: server.Client()})
+			require.NoError(t, err)
+			metadataClient, err := metadata.New(metadata.ClientConfig{InstanceURL: server.URL, HTTPClient: server.Client()})
+			require.NoError(t, err)
+
+			stdout := &bytes.Buffer{}
+			opts := &root.Options{
+				Output:  "table",
+				NoColor: true,
+				DryRun:  true,
+				Stdin:   strings.NewReader(""),
+				Stdout:  stdout,
+				Stderr:  &bytes.Buffer{},
+			}
+			opts.SetAPIClient(apiClient)
+			opts.SetToolingClient(toolingClient)
+			opts.SetMetadataClient(metadataClient)
+
+			cmd := NewCommand(opts)
+			cmd.SetArgs(append([]string{"delete", "OldClass"}, tt.args...))
+			cmd.SetOut(stdout)
+			require.NoError(t, cmd.Execute())
+
+			assert.Empty(t, deleted)
+			assert.Empty(t, deploys)
+			assert.Contains(t, stdout.String(), tt.wantMethod+" "+server.URL+tt.wantPath)
+			for _, want := range tt.wantPayload {
+				assert.Contains(t, stdout.String(), want)
+			}
+		})
+	}
+}
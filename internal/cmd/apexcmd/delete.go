@@ -0,0 +1,304 @@
+package apexcmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/salesforce-cli/api/metadata"
+	"github.com/open-cli-collective/salesforce-cli/internal/cmd/root"
+)
+
+// apexComponent is an Apex class or trigger in the org.
+type apexComponent struct {
+	name   string
+	id     string
+	kind   string // class or trigger
+	object string // ApexClass or ApexTrigger
+}
+
+// deletedApex is the JSON output of a deleted, or validated, class or
+// trigger.
+type deletedApex struct {
+	Name      string `json:"name"`
+	ID        string `json:"id"`
+	Type      string `json:"type"`
+	Deleted   bool   `json:"deleted"`
+	CheckOnly bool   `json:"checkOnly,omitempty"`
+	DeployID  string `json:"deployId,omitempty"`
+}
+
+func newDeleteCommand(opts *root.Options) *cobra.Command {
+	var (
+		trigger   bool
+		checkOnly bool
+		testLevel string
+		yes       bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "delete <name>",
+		Short: "Delete an Apex class or trigger from the org",
+		Long: `Delete an Apex class or trigger from the org.
+
+In a sandbox or developer org, the class or trigger is deleted through the
+Tooling API. Salesforce doesn't allow that in production, so there it is
+deleted by deploying destructive changes through the Metadata API, which
+runs tests as any production deploy does; use --test-level to choose them.
+Orgs whose type can't be determined are treated as production.
+
+With --check-only, the deletion is validated with a check-only destructive
+deploy, which fails if other Apex still refers to the class or trigger.
+Nothing is deleted.
+
+With --dry-run, the request of the path the command would take is shown:
+the Tooling API delete, or the destructive deploy with its package types.
+
+The command asks for confirmation before deleting, and names the org and
+asks again in a production org. Use --yes to skip both prompts.
+
+Examples:
+  sfdc apex delete MyOldClass
+  sfdc apex delete MyOldTrigger --trigger
+  sfdc apex delete MyOldClass --check-only
+  sfdc apex delete MyOldClass --test-level RunLocalTests --yes`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !apexIdentifier.MatchString(args[0]) {
+				return fmt.Errorf("invalid name %q: must start with a letter and contain only letters, digits, and underscores", args[0])
+			}
+			return runDelete(cmd.Context(), opts, args[0], trigger, checkOnly, testLevel, yes)
+		},
+	}
+
+	cmd.Flags().BoolVar(&trigger, "trigger", false, "Delete a trigger instead of a class")
+	cmd.Flags().BoolVar(&checkOnly, "check-only", false, "Validate the deletion without deleting")
+	cmd.Flags().StringVar(&testLevel, "test-level", "", "Test level of a destructive deploy: NoTestRun, RunLocalTests, RunAllTestsInOrg")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Skip the confirmation prompts")
+	cmd.Flags().DurationVar(&opts.WaitTimeout, "wait-timeout", root.DefaultWaitTimeout, "How long to wait for a destructive deploy (0 for no limit)")
+
+	return cmd
+}
+
+func runDelete(ctx context.Context, opts *root.Options, name string, trigger, checkOnly bool, testLevel string, yes bool) error {
+	v := opts.View()
+
+	client, err := opts.ToolingClient()
+	if err != nil {
+		return fmt.Errorf("failed to create tooling client: %w", err)
+	}
+
+	comp := apexComponent{name: name}
+	if trigger {
+		comp.kind, comp.object = "trigger", "ApexTrigger"
+		t, err := client.GetApexTrigger(ctx, name)
+		if err != nil {
+			return fmt.Errorf("failed to get apex trigger: %w", err)
+		}
+		comp.id = t.ID
+	} else {
+		comp.kind, comp.object = "class", "ApexClass"
+		c, err := client.GetApexClass(ctx, name)
+		if err != nil {
+			return fmt.Errorf("failed to get apex class: %w", err)
+		}
+		comp.id = c.ID
+	}
+
+	if opts.DryRun {
+		// Show the request of the path the command would take
+		byDeploy := checkOnly
+		if !byDeploy {
+			sandbox, err := opts.IsSandbox(ctx)
+			byDeploy = err != nil || !sandbox
+		}
+		if byDeploy {
+			return printDeployDryRun(opts, comp, checkOnly, testLevel)
+		}
+		return opts.PrintDryRun(root.DryRunRequest{
+			Operation: "apex delete",
+			Object:    comp.object,
+			Method:    http.MethodDelete,
+			URL:       client.ResourceURL(fmt.Sprintf("/sobjects/%s/%s", comp.object, comp.id)),
+			Details:   map[string]interface{}{"Name": comp.name},
+		})
+	}
+
+	if checkOnly {
+		return deleteByDeploy(ctx, opts, comp, true, testLevel)
+	}
+
+	if !yes {
+		proceed, err := opts.Confirm(fmt.Sprintf("Delete Apex %s %s? [y/N]: ", comp.kind, comp.name))
+		if err != nil {
+			return err
+		}
+		if !proceed {
+			v.Info("Cancelled")
+			return nil
+		}
+	}
+
+	proceed, err := opts.ConfirmProduction(ctx, fmt.Sprintf("apex delete of %s %s", comp.kind, comp.name), yes)
+	if err != nil {
+		return err
+	}
+	if !proceed {
+		v.Info("Cancelled")
+		return nil
+	}
+
+	// An org of unknown type is treated as production: the destructive
+	// deploy works in sandboxes too
+	if sandbox, err := opts.IsSandbox(ctx); err != nil || !sandbox {
+		return deleteByDeploy(ctx, opts, comp, false, testLevel)
+	}
+
+	if trigger {
+		err = client.DeleteApexTrigger(ctx, comp.id)
+	} else {
+		err = client.DeleteApexClass(ctx, comp.id)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to delete apex %s: %w", comp.kind, err)
+	}
+
+	return renderDeleted(opts, deletedApex{Name: comp.name, ID: comp.id, Type: comp.object, Deleted: true})
+}
+
+// deleteByDeploy deletes comp, or only validates deleting it if checkOnly is
+// set, by deploying destructive changes and waiting for the deploy.
+func deleteByDeploy(ctx context.Context, opts *root.Options, comp apexComponent, checkOnly bool, testLevel string) error {
+	v := opts.View()
+
+	client, err := opts.MetadataClient()
+	if err != nil {
+		return fmt.Errorf("failed to create metadata client: %w", err)
+	}
+
+	action := "Deleting"
+	if checkOnly {
+		action = "Validating deletion of"
+	}
+	v.Info("%s %s %s through a destructive deploy...", action, comp.kind, comp.name)
+
+	result, err := client.DeployDestructiveChanges(ctx, comp.packageTypes(), destructiveDeployOptions(checkOnly, testLevel))
+	if err != nil {
+		return fmt.Errorf("failed to start deployment: %w", err)
+	}
+
+	var status *metadata.DeployResult
+	err = root.Poll(ctx, 3*time.Second, opts.WaitTimeout, func() (bool, error) {
+		status, err = client.GetDeployStatus(ctx, result.ID, true)
+		if err != nil {
+			return false, fmt.Errorf("failed to get deployment status: %w", err)
+		}
+		return status.Done, nil
+	})
+	if errors.Is(err, root.ErrWaitTimeout) {
+		return fmt.Errorf("%w waiting for deployment %s; the deployment keeps running", err, result.ID)
+	}
+	if err != nil {
+		return err
+	}
+
+	if !status.Success {
+		return destructiveDeployErrors(opts, comp, status)
+	}
+
+	return renderDeleted(opts, deletedApex{
+		Name:      comp.name,
+		ID:        comp.id,
+		Type:      comp.object,
+		Deleted:   !checkOnly,
+		CheckOnly: checkOnly,
+		DeployID:  result.ID,
+	})
+}
+
+// printDeployDryRun shows the destructive deploy that deleteByDeploy would
+// start.
+func printDeployDryRun(opts *root.Options, comp apexComponent, checkOnly bool, testLevel string) error {
+	client, err := opts.MetadataClient()
+	if err != nil {
+		return fmt.Errorf("failed to create metadata client: %w", err)
+	}
+
+	return opts.PrintDryRun(root.DryRunRequest{
+		Operation: "apex delete",
+		Object:    comp.object,
+		Method:    http.MethodPost,
+		URL:       client.ResourceURL("/metadata/deployRequest"),
+		Payload: map[string]interface{}{
+			"destructiveChanges": comp.packageTypes(),
+			"deployOptions":      destructiveDeployOptions(checkOnly, testLevel),
+		},
+		Details: map[string]interface{}{"Name": comp.name, "Deploy": "destructive changes"},
+	})
+}
+
+// packageTypes returns the package manifest types naming comp.
+func (c apexComponent) packageTypes() []metadata.PackageType {
+	return []metadata.PackageType{{Name: c.object, Members: []string{c.name}}}
+}
+
+// destructiveDeployOptions returns the options of a destructive deploy of
+// an Apex class or trigger.
+func destructiveDeployOptions(checkOnly bool, testLevel string) metadata.DeployOptions {
+	return metadata.DeployOptions{
+		CheckOnly:       checkOnly,
+		RollbackOnError: true,
+		SinglePackage:   true,
+		TestLevel:       testLevel,
+	}
+}
+
+// destructiveDeployErrors prints the component failures of a failed
+// destructive deploy, and returns an error counting them and failed tests.
+func destructiveDeployErrors(opts *root.Options, comp apexComponent, status *metadata.DeployResult) error {
+	if status.DeployDetails != nil {
+		for _, f := range status.DeployDetails.ComponentFailures {
+			fmt.Fprintf(opts.Stderr, "%s.%s: %s\n", f.ComponentType, f.FullName, f.Problem)
+		}
+	}
+	if status.ErrorMessage != "" {
+		opts.View().Error("Error: %s", status.ErrorMessage)
+	}
+
+	var parts []string
+	if status.NumberComponentErrors > 0 {
+		parts = append(parts, fmt.Sprintf("%d component error(s)", status.NumberComponentErrors))
+	}
+	if status.NumberTestErrors > 0 {
+		parts = append(parts, fmt.Sprintf("%d test error(s)", status.NumberTestErrors))
+	}
+	if len(parts) == 0 {
+		return fmt.Errorf("failed to delete apex %s %s (deployment %s)", comp.kind, comp.name, status.ID)
+	}
+	return fmt.Errorf("failed to delete apex %s %s: %s", comp.kind, comp.name, strings.Join(parts, ", "))
+}
+
+// renderDeleted reports a deleted, or validated, class or trigger.
+func renderDeleted(opts *root.Options, deleted deletedApex) error {
+	v := opts.View()
+
+	if opts.Output == "json" {
+		return v.JSON(deleted)
+	}
+
+	kind := "class"
+	if deleted.Type == "ApexTrigger" {
+		kind = "trigger"
+	}
+	if deleted.CheckOnly {
+		v.Success("Apex %s %s can be deleted (validated by deployment %s)", kind, deleted.Name, deleted.DeployID)
+		return nil
+	}
+	v.Success("Deleted Apex %s %s (%s)", kind, deleted.Name, deleted.ID)
+	return nil
+}
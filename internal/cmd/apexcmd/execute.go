@@ -4,34 +4,60 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"net/http"
 	"os"
+	"regexp"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
+	"github.com/open-cli-collective/salesforce-cli/api/tooling"
 	"github.com/open-cli-collective/salesforce-cli/internal/cmd/root"
 )
 
+// captureTraceDuration is how long the trace flag created by --capture lives
+// if it cannot be removed after execution.
+const captureTraceDuration = 10 * time.Minute
+
 func newExecuteCommand(opts *root.Options) *cobra.Command {
 	var file string
+	var capture bool
 
 	cmd := &cobra.Command{
 		Use:   "execute [code]",
 		Short: "Execute anonymous Apex code",
 		Long: `Execute anonymous Apex code.
 
-The code can be provided as an argument, from a file, or via stdin.
+The code can be provided as an argument, from a file, or via stdin
+(use "-" as the argument or file name).
+
+With --capture, debug logging is enabled for the run and the message of the
+last System.debug() statement is printed as the command's only output, so a
+script can return a value:
+
+  Integer n = [SELECT COUNT() FROM Account];
+  System.debug(n);
 
 Examples:
   sfdc apex execute "System.debug('Hello');"
   sfdc apex execute --file script.apex
   echo "System.debug(UserInfo.getUserName());" | sfdc apex execute -
-  sfdc apex execute -                           # Read from stdin`,
+  sfdc apex execute -                           # Read from stdin
+  sfdc apex execute --file count.apex --capture # Print the last debug value
+  cat count.apex | sfdc apex execute --file - --capture`,
 		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			var code string
 
-			if file != "" {
+			if file == "-" {
+				// Read from stdin
+				data, err := io.ReadAll(opts.Stdin)
+				if err != nil {
+					return fmt.Errorf("failed to read stdin: %w", err)
+				}
+				code = string(data)
+			} else if file != "" {
 				// Read from file
 				data, readErr := os.ReadFile(file)
 				if readErr != nil {
@@ -58,11 +84,18 @@ Examples:
 				return fmt.Errorf("empty code provided")
 			}
 
+			if opts.DryRun {
+				return printExecuteDryRun(opts, code, capture)
+			}
+			if capture {
+				return runExecuteCapture(cmd.Context(), opts, code)
+			}
 			return runExecute(cmd.Context(), opts, code)
 		},
 	}
 
-	cmd.Flags().StringVarP(&file, "file", "f", "", "File containing Apex code")
+	cmd.Flags().StringVarP(&file, "file", "f", "", "File containing Apex code (- for stdin)")
+	cmd.Flags().BoolVar(&capture, "capture", false, "Print the last USER_DEBUG message as the result")
 
 	return cmd
 }
@@ -84,6 +117,39 @@ func runExecute(ctx context.Context, opts *root.Options, code string) error {
 		return v.JSON(result)
 	}
 
+	if err := reportExecuteFailure(opts, result); err != nil {
+		return err
+	}
+
+	v.Success("Executed successfully")
+	return nil
+}
+
+// printExecuteDryRun shows the execution that would have run, without
+// running it or, with capture, enabling debug logging.
+func printExecuteDryRun(opts *root.Options, code string, capture bool) error {
+	client, err := opts.ToolingClient()
+	if err != nil {
+		return fmt.Errorf("failed to create tooling client: %w", err)
+	}
+
+	details := map[string]interface{}{"Lines": strings.Count(code, "\n") + 1}
+	if capture {
+		details["TraceFlag"] = fmt.Sprintf("created for the run, expiring after %s", captureTraceDuration)
+	}
+	return opts.PrintDryRun(root.DryRunRequest{
+		Operation: "apex execute",
+		Method:    http.MethodGet,
+		URL:       client.ResourceURL("/executeAnonymous"),
+		Details:   details,
+	})
+}
+
+// reportExecuteFailure writes compile and runtime errors to stderr and
+// returns an error if the execution did not succeed.
+func reportExecuteFailure(opts *root.Options, result *tooling.ExecuteAnonymousResult) error {
+	v := opts.View()
+
 	if !result.Compiled {
 		v.Error("Compile error at line %d, column %d:", result.Line, result.Column)
 		fmt.Fprintln(opts.Stderr, result.CompileProblem)
@@ -99,6 +165,133 @@ func runExecute(ctx context.Context, opts *root.Options, code string) error {
 		return fmt.Errorf("execution failed")
 	}
 
-	v.Success("Executed successfully")
 	return nil
 }
+
+// captureResult is the JSON output of a captured execution.
+type captureResult struct {
+	*tooling.ExecuteAnonymousResult
+	Value string `json:"value"`
+	LogID string `json:"logId"`
+}
+
+func runExecuteCapture(ctx context.Context, opts *root.Options, code string) error {
+	client, err := opts.ToolingClient()
+	if err != nil {
+		return fmt.Errorf("failed to create tooling client: %w", err)
+	}
+
+	v := opts.View()
+
+	userID, err := client.GetCurrentUserID(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get current user: %w", err)
+	}
+
+	// Remember the newest existing log so it is not mistaken for ours
+	previous, err := client.ListApexLogsFiltered(ctx, tooling.ApexLogFilter{UserID: userID, Limit: 1})
+	if err != nil {
+		return fmt.Errorf("failed to list logs: %w", err)
+	}
+
+	traceFlagID, err := client.EnableDebugLogging(ctx, userID, time.Now().Add(captureTraceDuration))
+	if err != nil {
+		return fmt.Errorf("failed to enable debug logging: %w", err)
+	}
+	if traceFlagID != "" {
+		defer func() {
+			if err := client.DeleteTraceFlag(ctx, traceFlagID); err != nil {
+				v.Warning("Failed to remove trace flag %s: %v", traceFlagID, err)
+			}
+		}()
+	}
+
+	result, err := client.ExecuteAnonymous(ctx, code)
+	if err != nil {
+		return fmt.Errorf("failed to execute anonymous apex: %w", err)
+	}
+
+	if err := reportExecuteFailure(opts, result); err != nil {
+		return err
+	}
+
+	logs, err := client.ListApexLogsFiltered(ctx, tooling.ApexLogFilter{UserID: userID, Limit: 10})
+	if err != nil {
+		return fmt.Errorf("failed to list logs: %w", err)
+	}
+
+	logID := findExecuteLog(logs, previous)
+	if logID == "" {
+		return fmt.Errorf("no debug log found for the execution")
+	}
+
+	body, err := client.GetApexLogBody(ctx, logID)
+	if err != nil {
+		return fmt.Errorf("failed to get log: %w", err)
+	}
+
+	value, ok := lastUserDebug(body)
+	if !ok {
+		return fmt.Errorf("no USER_DEBUG output found in log %s", logID)
+	}
+
+	if opts.Output == "json" {
+		return v.JSON(captureResult{ExecuteAnonymousResult: result, Value: value, LogID: logID})
+	}
+
+	fmt.Fprintln(opts.Stdout, value)
+	return nil
+}
+
+// findExecuteLog returns the ID of the newest anonymous Apex log that is not
+// in previous, or "" if there is none.
+func findExecuteLog(logs, previous []tooling.ApexLog) string {
+	seen := make(map[string]bool, len(previous))
+	for _, log := range previous {
+		seen[log.ID] = true
+	}
+
+	for _, log := range logs {
+		if seen[log.ID] {
+			// Logs are newest first, so everything after this is older
+			return ""
+		}
+		if strings.Contains(strings.ToLower(log.Operation), "executeanonymous") {
+			return log.ID
+		}
+	}
+
+	return ""
+}
+
+// logLineStart matches the timestamp prefix of a debug log line, e.g.
+// "12:00:00.123 (123456)|".
+var logLineStart = regexp.MustCompile(`^\d{2}:\d{2}:\d{2}\.\d+ \(\d+\)\|`)
+
+// lastUserDebug returns the message of the last USER_DEBUG line in a debug
+// log. Messages spanning several lines are returned in full.
+func lastUserDebug(body string) (string, bool) {
+	var message []string
+	found := false
+	inDebug := false
+
+	for _, line := range strings.Split(strings.ReplaceAll(body, "\r\n", "\n"), "\n") {
+		if !logLineStart.MatchString(line) {
+			if inDebug {
+				message = append(message, line)
+			}
+			continue
+		}
+
+		inDebug = false
+		// Format: timestamp|USER_DEBUG|[line]|LEVEL|message
+		parts := strings.SplitN(line, "|", 5)
+		if len(parts) == 5 && parts[1] == "USER_DEBUG" {
+			message = []string{parts[4]}
+			found = true
+			inDebug = true
+		}
+	}
+
+	return strings.TrimRight(strings.Join(message, "\n"), "\n"), found
+}
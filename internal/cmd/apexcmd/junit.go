@@ -0,0 +1,145 @@
+package apexcmd
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/open-cli-collective/salesforce-cli/api/tooling"
+)
+
+// junitTestSuites is the root element of a JUnit XML report.
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Errors   int              `xml:"errors,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Time     string           `xml:"time,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+// junitTestSuite holds the tests of one Apex test class.
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Errors    int             `xml:"errors,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	Time      string          `xml:"time,attr"`
+	Timestamp string          `xml:"timestamp,attr,omitempty"`
+	Cases     []junitTestCase `xml:"testcase"`
+}
+
+// junitTestCase is the result of one test method.
+type junitTestCase struct {
+	ClassName string        `xml:"classname,attr"`
+	Name      string        `xml:"name,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitProblem `xml:"failure,omitempty"`
+	Error     *junitProblem `xml:"error,omitempty"`
+	Skipped   *struct{}     `xml:"skipped,omitempty"`
+}
+
+// junitProblem is a failed test's message, with its stack trace as the
+// element's text.
+type junitProblem struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr,omitempty"`
+	Text    string `xml:",chardata"`
+}
+
+// writeJUnit writes test results as a JUnit XML report, with a test suite
+// for each test class in the order they first appear. Failed assertions
+// are failures; tests that did not compile are errors.
+func writeJUnit(w io.Writer, results []tooling.ApexTestResult) error {
+	report := junitTestSuites{Name: "Apex"}
+	suites := make(map[string]int)
+	// Runtimes in milliseconds, summed per suite and in total
+	var suiteTimes []int
+	totalTime := 0
+
+	for _, r := range results {
+		name := r.ClassName
+		if name == "" {
+			name = r.ApexClassID
+		}
+		i, ok := suites[name]
+		if !ok {
+			i = len(report.Suites)
+			suites[name] = i
+			report.Suites = append(report.Suites, junitTestSuite{Name: name, Timestamp: r.TestTimestamp})
+			suiteTimes = append(suiteTimes, 0)
+		}
+		suite := &report.Suites[i]
+
+		tc := junitTestCase{ClassName: name, Name: r.MethodName, Time: junitSeconds(r.RunTime)}
+		switch r.Outcome {
+		case "Fail":
+			tc.Failure = &junitProblem{Message: r.Message, Type: exceptionType(r.Message), Text: r.StackTrace}
+			suite.Failures++
+		case "CompileFail":
+			tc.Error = &junitProblem{Message: r.Message, Type: "CompileFail", Text: r.StackTrace}
+			suite.Errors++
+		case "Skip":
+			tc.Skipped = &struct{}{}
+			suite.Skipped++
+		}
+		suite.Cases = append(suite.Cases, tc)
+		suite.Tests++
+		suiteTimes[i] += r.RunTime
+		totalTime += r.RunTime
+	}
+
+	for i := range report.Suites {
+		suite := &report.Suites[i]
+		suite.Time = junitSeconds(suiteTimes[i])
+		report.Tests += suite.Tests
+		report.Failures += suite.Failures
+		report.Errors += suite.Errors
+		report.Skipped += suite.Skipped
+	}
+	report.Time = junitSeconds(totalTime)
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(report); err != nil {
+		return fmt.Errorf("failed to write JUnit report: %w", err)
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// writeJUnitFile writes test results as a JUnit XML report to a file.
+func writeJUnitFile(path string, results []tooling.ApexTestResult) error {
+	var buf bytes.Buffer
+	if err := writeJUnit(&buf, results); err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	return nil
+}
+
+// junitSeconds formats a runtime in milliseconds as JUnit's seconds.
+func junitSeconds(ms int) string {
+	return fmt.Sprintf("%.3f", float64(ms)/1000)
+}
+
+// exceptionType returns the exception class a test failure message starts
+// with, such as System.AssertException, or "" if it has none.
+func exceptionType(message string) string {
+	name, _, ok := strings.Cut(message, ":")
+	if !ok || strings.ContainsAny(name, " \t\n") {
+		return ""
+	}
+	return name
+}
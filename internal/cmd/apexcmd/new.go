@@ -0,0 +1,234 @@
+package apexcmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/salesforce-cli/api"
+	"github.com/open-cli-collective/salesforce-cli/internal/cmd/root"
+)
+
+// defaultTriggerEvents are used when the user accepts the events prompt
+// without typing anything.
+const defaultTriggerEvents = "before insert"
+
+// apexIdentifier matches valid Apex class and trigger names.
+var apexIdentifier = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*$`)
+
+// triggerEvents are the valid Apex trigger events.
+var triggerEvents = []string{
+	"before insert",
+	"before update",
+	"before delete",
+	"after insert",
+	"after update",
+	"after delete",
+	"after undelete",
+}
+
+// newFlags holds the flags of the new command.
+type newFlags struct {
+	kind    string
+	sobject string
+	events  string
+	dir     string
+	force   bool
+}
+
+func newNewCommand(opts *root.Options) *cobra.Command {
+	var flags newFlags
+
+	cmd := &cobra.Command{
+		Use:   "new <name>",
+		Short: "Create a local Apex class or trigger skeleton",
+		Long: `Create a skeleton Apex class or trigger with its -meta.xml file, ready to
+edit and deploy.
+
+The metadata file uses the --api-version flag, or the default API version.
+For triggers, the events are prompted for unless --events is given.
+Existing files are not overwritten unless --force is given.
+
+Examples:
+  sfdc apex new MyService
+  sfdc apex new MyService --dir force-app/main/default/classes
+  sfdc apex new AccountTrigger --type trigger --sobject Account
+  sfdc apex new AccountTrigger --type trigger --sobject Account --events "before insert,after update"`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runNew(opts, args[0], flags)
+		},
+	}
+
+	cmd.Flags().StringVar(&flags.kind, "type", "class", "What to create: class or trigger")
+	cmd.Flags().StringVar(&flags.sobject, "sobject", "", "Object the trigger is on (required for triggers)")
+	cmd.Flags().StringVar(&flags.events, "events", "", "Comma-separated trigger events (e.g. \"before insert,after update\")")
+	cmd.Flags().StringVar(&flags.dir, "dir", ".", "Directory to create the files in")
+	cmd.Flags().BoolVar(&flags.force, "force", false, "Overwrite existing files")
+
+	return cmd
+}
+
+func runNew(opts *root.Options, name string, flags newFlags) error {
+	if !apexIdentifier.MatchString(name) {
+		return fmt.Errorf("invalid name %q: must start with a letter and contain only letters, digits, and underscores", name)
+	}
+
+	var (
+		ext  string
+		body string
+		meta string
+	)
+
+	apiVersion := strings.TrimPrefix(opts.APIVersion, "v")
+	if apiVersion == "" {
+		apiVersion = strings.TrimPrefix(api.DefaultAPIVersion, "v")
+	}
+
+	switch flags.kind {
+	case "class":
+		if flags.sobject != "" || flags.events != "" {
+			return fmt.Errorf("--sobject and --events are only valid with --type trigger")
+		}
+		ext = ".cls"
+		body = classTemplate(name)
+		meta = metaTemplate("ApexClass", apiVersion)
+	case "trigger":
+		if flags.sobject == "" {
+			return fmt.Errorf("--sobject is required for triggers")
+		}
+		if !apexIdentifier.MatchString(flags.sobject) {
+			return fmt.Errorf("invalid object name: %s", flags.sobject)
+		}
+
+		events := flags.events
+		if events == "" {
+			var err error
+			events, err = promptTriggerEvents(opts)
+			if err != nil {
+				return err
+			}
+		}
+		parsed, err := parseTriggerEvents(events)
+		if err != nil {
+			return err
+		}
+
+		ext = ".trigger"
+		body = triggerTemplate(name, flags.sobject, parsed)
+		meta = metaTemplate("ApexTrigger", apiVersion)
+	default:
+		return fmt.Errorf("invalid type %q: must be class or trigger", flags.kind)
+	}
+
+	sourcePath := filepath.Join(flags.dir, name+ext)
+	metaPath := sourcePath + "-meta.xml"
+
+	if !flags.force {
+		for _, path := range []string{sourcePath, metaPath} {
+			if _, err := os.Stat(path); err == nil {
+				return fmt.Errorf("%s already exists (use --force to overwrite)", path)
+			}
+		}
+	}
+
+	if err := os.MkdirAll(flags.dir, 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+	if err := os.WriteFile(sourcePath, []byte(body), 0644); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	if err := os.WriteFile(metaPath, []byte(meta), 0644); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+
+	v := opts.View()
+	if opts.Output == "json" {
+		return v.JSON(map[string]interface{}{
+			"name":     name,
+			"type":     flags.kind,
+			"source":   sourcePath,
+			"metadata": metaPath,
+		})
+	}
+
+	v.Success("Created %s", sourcePath)
+	v.Success("Created %s", metaPath)
+	return nil
+}
+
+// promptTriggerEvents asks for the trigger events on stdin.
+func promptTriggerEvents(opts *root.Options) (string, error) {
+	v := opts.View()
+	v.Info("Trigger events: %s", strings.Join(triggerEvents, ", "))
+	v.Print("Events (comma-separated) [%s]: ", defaultTriggerEvents)
+
+	reader := bufio.NewReader(opts.Stdin)
+	response, err := reader.ReadString('\n')
+	if err != nil && response == "" {
+		return "", fmt.Errorf("failed to read input: %w", err)
+	}
+
+	response = strings.TrimSpace(response)
+	if response == "" {
+		return defaultTriggerEvents, nil
+	}
+	return response, nil
+}
+
+// parseTriggerEvents validates a comma-separated list of trigger events and
+// returns them in the canonical order, without duplicates.
+func parseTriggerEvents(s string) ([]string, error) {
+	requested := make(map[string]bool)
+	for _, event := range strings.Split(s, ",") {
+		event = strings.Join(strings.Fields(strings.ToLower(event)), " ")
+		if event == "" {
+			continue
+		}
+		valid := false
+		for _, e := range triggerEvents {
+			if e == event {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return nil, fmt.Errorf("invalid trigger event %q: must be one of %s", event, strings.Join(triggerEvents, ", "))
+		}
+		requested[event] = true
+	}
+
+	if len(requested) == 0 {
+		return nil, fmt.Errorf("at least one trigger event is required")
+	}
+
+	var events []string
+	for _, e := range triggerEvents {
+		if requested[e] {
+			events = append(events, e)
+		}
+	}
+	return events, nil
+}
+
+func classTemplate(name string) string {
+	return fmt.Sprintf("public with sharing class %s {\n\n}\n", name)
+}
+
+func triggerTemplate(name, sobject string, events []string) string {
+	return fmt.Sprintf("trigger %s on %s (%s) {\n\n}\n", name, sobject, strings.Join(events, ", "))
+}
+
+func metaTemplate(metadataType, apiVersion string) string {
+	return fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8"?>
+<%s xmlns="http://soap.sforce.com/2006/04/metadata">
+    <apiVersion>%s</apiVersion>
+    <status>Active</status>
+</%s>
+`, metadataType, apiVersion, metadataType)
+}
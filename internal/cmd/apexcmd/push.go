@@ -0,0 +1,322 @@
+package apexcmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/salesforce-cli/api/tooling"
+	"github.com/open-cli-collective/salesforce-cli/internal/cmd/root"
+)
+
+// classSource is the source of an Apex class read from a .cls file.
+type classSource struct {
+	name string
+	file string
+	body string
+	id   string // the existing class's, if any
+}
+
+// savedClass is the JSON output of a saved class.
+type savedClass struct {
+	Name    string `json:"name"`
+	ID      string `json:"id"`
+	Created bool   `json:"created"`
+}
+
+func newPushCommand(opts *root.Options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "push <file.cls>...",
+		Short: "Save Apex classes to the org from local files",
+		Long: `Save Apex classes to the org from local .cls files, each named after its class.
+
+Changes to existing classes are saved together through a MetadataContainer,
+so they compile against each other and are saved only if all of them compile.
+Classes that don't exist yet are created first. Compile errors are printed
+with the file, line, and column of each problem.
+
+Examples:
+  sfdc apex push MyController.cls
+  sfdc apex push force-app/main/default/classes/*.cls`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			sources := make([]*classSource, 0, len(args))
+			for _, file := range args {
+				name := strings.TrimSuffix(filepath.Base(file), ".cls")
+				if filepath.Ext(file) != ".cls" || !apexIdentifier.MatchString(name) {
+					return fmt.Errorf("invalid class file %s: must be an Apex class named <Class>.cls", file)
+				}
+				source, err := readClassSource(name, file)
+				if err != nil {
+					return err
+				}
+				sources = append(sources, source)
+			}
+			return runPush(cmd.Context(), opts, sources)
+		},
+	}
+
+	cmd.Flags().DurationVar(&opts.WaitTimeout, "wait-timeout", root.DefaultWaitTimeout, "How long to wait for classes to compile (0 for no limit)")
+
+	return cmd
+}
+
+func newCreateCommand(opts *root.Options) *cobra.Command {
+	var name, file string
+
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create an Apex class in the org",
+		Long: `Create an Apex class in the org from a local file. Salesforce compiles the
+class first; it is not created if it doesn't compile. Use 'sfdc apex push' to
+change an existing class.
+
+Examples:
+  sfdc apex create --name Foo --file Foo.cls`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if name == "" || file == "" {
+				return fmt.Errorf("--name and --file are required")
+			}
+			if !apexIdentifier.MatchString(name) {
+				return fmt.Errorf("invalid name %q: must start with a letter and contain only letters, digits, and underscores", name)
+			}
+			source, err := readClassSource(name, file)
+			if err != nil {
+				return err
+			}
+			return runCreate(cmd.Context(), opts, source)
+		},
+	}
+
+	cmd.Flags().StringVar(&name, "name", "", "Name of the class (required)")
+	cmd.Flags().StringVar(&file, "file", "", "File with the class's source (required)")
+
+	return cmd
+}
+
+// readClassSource reads the source of the class name from file.
+func readClassSource(name, file string) (*classSource, error) {
+	body, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	return &classSource{name: name, file: file, body: string(body)}, nil
+}
+
+func runCreate(ctx context.Context, opts *root.Options, source *classSource) error {
+	client, err := opts.ToolingClient()
+	if err != nil {
+		return fmt.Errorf("failed to create tooling client: %w", err)
+	}
+
+	ids, err := client.GetApexClassIDs(ctx, "", []string{source.name})
+	if err != nil {
+		return fmt.Errorf("failed to look up class: %w", err)
+	}
+	if _, ok := ids[source.name]; ok {
+		return fmt.Errorf("apex class %s already exists; use 'sfdc apex push %s' to change it", source.name, source.file)
+	}
+
+	if opts.DryRun {
+		return opts.PrintDryRun(root.DryRunRequest{
+			Operation: "apex create",
+			Object:    "ApexClass",
+			Method:    http.MethodPost,
+			URL:       client.ResourceURL("/sobjects/ApexClass"),
+			Details:   map[string]interface{}{"Class": source.name, "File": source.file},
+		})
+	}
+
+	if err := createClass(ctx, client, source); err != nil {
+		return err
+	}
+	return renderSaved(opts, []*classSource{source}, map[string]bool{source.name: true})
+}
+
+func runPush(ctx context.Context, opts *root.Options, sources []*classSource) error {
+	client, err := opts.ToolingClient()
+	if err != nil {
+		return fmt.Errorf("failed to create tooling client: %w", err)
+	}
+
+	names := make([]string, 0, len(sources))
+	for _, source := range sources {
+		names = append(names, source.name)
+	}
+	ids, err := client.GetApexClassIDs(ctx, "", names)
+	if err != nil {
+		return fmt.Errorf("failed to look up classes: %w", err)
+	}
+
+	var existing, missing []*classSource
+	for _, source := range sources {
+		if id, ok := ids[source.name]; ok {
+			source.id = id
+			existing = append(existing, source)
+		} else {
+			missing = append(missing, source)
+		}
+	}
+
+	if opts.DryRun {
+		return opts.PrintDryRun(root.DryRunRequest{
+			Operation: "apex push",
+			Object:    "ApexClassMember",
+			Method:    http.MethodPost,
+			URL:       client.ResourceURL("/sobjects/ContainerAsyncRequest"),
+			Details: map[string]interface{}{
+				"Update": classNames(existing),
+				"Create": classNames(missing),
+			},
+		})
+	}
+
+	created := make(map[string]bool, len(missing))
+	for _, source := range missing {
+		if err := createClass(ctx, client, source); err != nil {
+			return err
+		}
+		created[source.name] = true
+	}
+
+	if len(existing) > 0 {
+		if err := saveClasses(ctx, client, opts, existing); err != nil {
+			return err
+		}
+	}
+
+	return renderSaved(opts, sources, created)
+}
+
+// createClass creates a class that doesn't exist yet, setting its ID.
+func createClass(ctx context.Context, client *tooling.Client, source *classSource) error {
+	id, err := client.CreateApexClass(ctx, source.body)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", source.name, err)
+	}
+	source.id = id
+	return nil
+}
+
+// saveClasses saves new bodies of existing classes through a
+// MetadataContainer, which is deleted afterwards.
+func saveClasses(ctx context.Context, client *tooling.Client, opts *root.Options, sources []*classSource) error {
+	v := opts.View()
+	v.Info("Compiling %s...", strings.Join(classNames(sources), ", "))
+
+	// Container names must be unique and at most 32 characters
+	containerID, err := client.CreateMetadataContainer(ctx, fmt.Sprintf("sfdc-%d", time.Now().UnixNano()))
+	if err != nil {
+		return fmt.Errorf("failed to create metadata container: %w", err)
+	}
+	defer func() {
+		if err := client.DeleteMetadataContainer(context.WithoutCancel(ctx), containerID); err != nil {
+			v.Warning("Failed to delete metadata container %s: %v", containerID, err)
+		}
+	}()
+
+	for _, source := range sources {
+		if _, err := client.AddApexClassMember(ctx, containerID, source.id, source.body); err != nil {
+			return fmt.Errorf("failed to add %s to metadata container: %w", source.name, err)
+		}
+	}
+
+	requestID, err := client.DeployContainer(ctx, containerID, false)
+	if err != nil {
+		return fmt.Errorf("failed to request compile: %w", err)
+	}
+
+	var req *tooling.ContainerAsyncRequest
+	err = root.Poll(ctx, time.Second, opts.WaitTimeout, func() (bool, error) {
+		req, err = client.GetContainerAsyncRequest(ctx, requestID)
+		if err != nil {
+			return false, fmt.Errorf("failed to get compile status: %w", err)
+		}
+		return req.State != "Queued", nil
+	})
+	if errors.Is(err, root.ErrWaitTimeout) {
+		return fmt.Errorf("%w waiting for classes to compile (request %s)", err, requestID)
+	}
+	if err != nil {
+		return err
+	}
+
+	switch req.State {
+	case "Completed":
+		return nil
+	case "Failed":
+		return compileErrors(opts, sources, req.DeployDetails)
+	default:
+		if req.ErrorMsg != "" {
+			return fmt.Errorf("compile request %s %s: %s", requestID, strings.ToLower(req.State), req.ErrorMsg)
+		}
+		return fmt.Errorf("compile request %s %s", requestID, strings.ToLower(req.State))
+	}
+}
+
+// compileErrors prints the problems of a failed compile, as file:line:column:
+// problem, and returns an error counting them.
+func compileErrors(opts *root.Options, sources []*classSource, details *tooling.DeployDetails) error {
+	files := make(map[string]string, len(sources))
+	for _, source := range sources {
+		files[source.name] = source.file
+	}
+
+	count := 0
+	if details != nil {
+		for _, f := range details.ComponentFailures {
+			if f.ProblemType == "Warning" {
+				continue
+			}
+			file := files[f.FullName]
+			if file == "" {
+				file = f.FullName
+			}
+			fmt.Fprintf(opts.Stderr, "%s:%d:%d: %s\n", file, f.LineNumber, f.ColumnNumber, f.Problem)
+			count++
+		}
+	}
+	if count == 0 {
+		return fmt.Errorf("classes failed to compile")
+	}
+	return fmt.Errorf("classes failed to compile: %d error(s)", count)
+}
+
+// renderSaved reports the classes saved, and which were created.
+func renderSaved(opts *root.Options, sources []*classSource, created map[string]bool) error {
+	v := opts.View()
+
+	if opts.Output == "json" {
+		saved := make([]savedClass, 0, len(sources))
+		for _, source := range sources {
+			saved = append(saved, savedClass{Name: source.name, ID: source.id, Created: created[source.name]})
+		}
+		return v.JSON(saved)
+	}
+
+	for _, source := range sources {
+		if created[source.name] {
+			v.Success("Created %s (%s)", source.name, source.id)
+		} else {
+			v.Success("Saved %s (%s)", source.name, source.id)
+		}
+	}
+	return nil
+}
+
+// classNames returns the names of classes.
+func classNames(sources []*classSource) []string {
+	names := make([]string, 0, len(sources))
+	for _, source := range sources {
+		names = append(names, source.name)
+	}
+	return names
+}
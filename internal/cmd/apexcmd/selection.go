@@ -0,0 +1,177 @@
+package apexcmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/open-cli-collective/salesforce-cli/api/tooling"
+)
+
+// testSpec is a test class named by --class or --tests, with the test
+// methods to run; none means all of them.
+type testSpec struct {
+	className string
+	methods   []string
+}
+
+// parseTestSpecs returns the test classes the flags name, in the order they
+// are first named. --tests entries are Class or Class.method; a class named
+// without a method runs all of its tests, however else it is named.
+func parseTestSpecs(flags testFlags) ([]testSpec, error) {
+	var specs []testSpec
+	index := make(map[string]int)
+	add := func(className, method string) {
+		i, ok := index[className]
+		if !ok {
+			i = len(specs)
+			index[className] = i
+			specs = append(specs, testSpec{className: className})
+			if method == "" {
+				return
+			}
+		} else if specs[i].methods == nil {
+			// The whole class already runs
+			return
+		}
+		if method == "" {
+			specs[i].methods = nil
+			return
+		}
+		for _, m := range specs[i].methods {
+			if m == method {
+				return
+			}
+		}
+		specs[i].methods = append(specs[i].methods, method)
+	}
+
+	for _, className := range flags.classNames {
+		add(className, flags.methodName)
+	}
+	for _, test := range flags.tests {
+		className, method, hasMethod := strings.Cut(strings.TrimSpace(test), ".")
+		if className == "" || (hasMethod && method == "") || strings.Contains(method, ".") {
+			return nil, fmt.Errorf("invalid test %q (must be Class or Class.method)", test)
+		}
+		add(className, method)
+	}
+	return specs, nil
+}
+
+// hasMethods reports whether any of specs runs only some of its class's
+// test methods.
+func hasMethods(specs []testSpec) bool {
+	for _, spec := range specs {
+		if len(spec.methods) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// testSelection is what a test run runs, resolved to IDs in the org.
+type testSelection struct {
+	names    []string // classes and suites, for messages
+	tests    []tooling.TestItem
+	suiteIDs []string
+}
+
+// request returns the request that runs the selection. Classes go in
+// ClassIDs unless some run only some of their methods, which only Tests can
+// say.
+func (s *testSelection) request() tooling.RunTestsRequest {
+	for _, test := range s.tests {
+		if len(test.TestMethods) > 0 {
+			return tooling.RunTestsRequest{Tests: s.tests}
+		}
+	}
+
+	req := tooling.RunTestsRequest{SuiteIDs: s.suiteIDs}
+	for _, test := range s.tests {
+		req.ClassIDs = append(req.ClassIDs, test.ClassID)
+	}
+	return req
+}
+
+// resolveTests looks up the IDs of the test classes and suites the flags
+// select, failing if any of them doesn't exist. --class-pattern, or
+// --namespace alone, adds the matching classes to those named.
+func resolveTests(ctx context.Context, client *tooling.Client, flags testFlags, specs []testSpec) (*testSelection, error) {
+	sel := &testSelection{}
+
+	ids := make(map[string]string)
+	if flags.classPattern != "" || (flags.namespace != "" && len(specs) == 0 && len(flags.suites) == 0) {
+		classes, err := client.FindApexClasses(ctx, flags.namespace, flags.classPattern)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find test classes: %w", err)
+		}
+		if len(classes) == 0 {
+			return nil, fmt.Errorf("no Apex classes match %s", describePattern(flags))
+		}
+		named := make(map[string]bool, len(specs))
+		for _, spec := range specs {
+			named[spec.className] = true
+		}
+		for _, class := range classes {
+			ids[class.Name] = class.ID
+			if !named[class.Name] {
+				specs = append(specs, testSpec{className: class.Name})
+			}
+		}
+	}
+
+	var lookup []string
+	for _, spec := range specs {
+		if _, ok := ids[spec.className]; !ok {
+			lookup = append(lookup, spec.className)
+		}
+	}
+	if len(lookup) > 0 {
+		found, err := client.GetApexClassIDs(ctx, flags.namespace, lookup)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find test classes: %w", err)
+		}
+		var missing []string
+		for _, name := range lookup {
+			id, ok := found[name]
+			if !ok {
+				missing = append(missing, name)
+				continue
+			}
+			ids[name] = id
+		}
+		if len(missing) > 0 {
+			return nil, fmt.Errorf("test classes not found: %s", strings.Join(missing, ", "))
+		}
+	}
+
+	for _, spec := range specs {
+		sel.names = append(sel.names, spec.className)
+		sel.tests = append(sel.tests, tooling.TestItem{ClassID: ids[spec.className], TestMethods: spec.methods})
+	}
+
+	for _, suite := range flags.suites {
+		id, err := client.GetApexTestSuiteID(ctx, suite)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find test suite: %w", err)
+		}
+		sel.names = append(sel.names, "suite "+suite)
+		sel.suiteIDs = append(sel.suiteIDs, id)
+	}
+
+	return sel, nil
+}
+
+// describePattern describes the classes --class-pattern and --namespace
+// select, for messages.
+func describePattern(flags testFlags) string {
+	switch {
+	case flags.namespace == "":
+		return flags.classPattern
+	case flags.classPattern == "":
+		return "namespace " + flags.namespace
+	default:
+		return fmt.Sprintf("%s in namespace %s", flags.classPattern, flags.namespace)
+	}
+}
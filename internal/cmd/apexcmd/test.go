@@ -2,74 +2,184 @@ package apexcmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
 
 	"github.com/open-cli-collective/salesforce-cli/api/tooling"
+	"github.com/open-cli-collective/salesforce-cli/internal/cmd/coveragecmd"
 	"github.com/open-cli-collective/salesforce-cli/internal/cmd/root"
 )
 
 func newTestCommand(opts *root.Options) *cobra.Command {
-	var (
-		className  string
-		methodName string
-		wait       bool
-	)
+	var flags testFlags
 
 	cmd := &cobra.Command{
 		Use:   "test",
 		Short: "Run Apex tests",
 		Long: `Run Apex tests asynchronously.
 
+The tests to run are selected by any combination of:
+  --class         test classes, all of their tests (--method picks one)
+  --tests         Class or Class.method entries, such as AccountTest.testInsert
+  --suite         Apex test suites
+  --class-pattern classes whose names match a pattern, such as '*_Test'
+  --namespace     classes of a namespace; with the flags above, it only
+                  looks for their classes in the namespace
+Every named class and suite must exist, or nothing runs. Suites can't be
+combined with individual test methods.
+
+With --wait, the command waits for the tests to finish, showing how many test
+classes have completed, then prints each test's class, method, outcome,
+runtime, and message, a summary of passed, failed, and skipped tests, and the
+coverage the run produced for the classes and triggers it exercised. It exits
+non-zero if any test failed or the run did not complete, so it can gate a CI
+pipeline.
+
+With --result-format junit, the command waits for the tests and writes the
+results as JUnit XML, a test suite per class with failures' messages and stack
+traces, for CI systems such as Jenkins or GitHub Actions to display. The
+report goes to stdout, or with --out to a file, the results table then being
+printed as with --wait.
+
+With --sync, the tests of a single class run synchronously: one request runs
+them and returns their results with the coverage they produced, which are shown
+as with --wait. It suits a small test class, where polling is overkill; long
+runs are better left asynchronous.
+
+With --coverage, the command waits for the tests and, after the results,
+shows the lines each test class covers in each class or trigger its tests
+exercise, then the line numbers that none of them cover.
+
+With --coverage-only, the command waits for the tests to finish and reports
+only the code coverage they produced for the classes and triggers they
+exercise, instead of pass/fail detail.
+
+Waiting gives up after --wait-timeout (default 30m); the tests keep running.
+
 Examples:
   sfdc apex test --class MyControllerTest
   sfdc apex test --class MyControllerTest --method testCreate
+  sfdc apex test --tests AccountTest.testInsert,ContactTest
+  sfdc apex test --suite Smoke --wait
+  sfdc apex test --class-pattern '*_Test' --namespace acme
   sfdc apex test --class MyTest --wait
-  sfdc apex test --class MyTest -o json`,
+  sfdc apex test --class MyTest --sync
+  sfdc apex test --class MyTest -o json
+  sfdc apex test --class MyTest --result-format junit --out results.xml
+  sfdc apex test --class AccountTest --class ContactTest --coverage
+  sfdc apex test --class AccountTest --class ContactTest --coverage-only`,
 		Args: cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			if className == "" {
-				return fmt.Errorf("--class is required")
+			if len(flags.classNames) == 0 && len(flags.tests) == 0 && len(flags.suites) == 0 && flags.classPattern == "" && flags.namespace == "" {
+				return fmt.Errorf("--class, --tests, --suite, --class-pattern, or --namespace is required")
+			}
+			if flags.methodName != "" && len(flags.classNames) != 1 {
+				return fmt.Errorf("--method can only be used with a single --class")
+			}
+			specs, err := parseTestSpecs(flags)
+			if err != nil {
+				return err
+			}
+			if len(flags.suites) > 0 && hasMethods(specs) {
+				return fmt.Errorf("--suite cannot be combined with test methods")
+			}
+			switch flags.resultFormat {
+			case "":
+				if flags.out != "" {
+					return fmt.Errorf("--out requires --result-format")
+				}
+			case "junit":
+				if flags.coverageOnly {
+					return fmt.Errorf("--result-format cannot be used with --coverage-only")
+				}
+				flags.wait = true
+			default:
+				return fmt.Errorf("invalid result format: %s (must be junit)", flags.resultFormat)
+			}
+			if flags.coverage && flags.coverageOnly {
+				return fmt.Errorf("--coverage cannot be used with --coverage-only")
+			}
+			if flags.coverageOnly || flags.coverage {
+				flags.wait = true
 			}
-			return runTest(cmd.Context(), opts, className, methodName, wait)
+			if flags.sync && (len(specs) != 1 || len(flags.suites) > 0 || flags.classPattern != "") {
+				return fmt.Errorf("--sync can only be used with a single --class")
+			}
+			return runTest(cmd.Context(), opts, flags, specs)
 		},
 	}
 
-	cmd.Flags().StringVar(&className, "class", "", "Test class name (required)")
-	cmd.Flags().StringVar(&methodName, "method", "", "Specific test method to run")
-	cmd.Flags().BoolVar(&wait, "wait", false, "Wait for tests to complete")
+	cmd.Flags().StringSliceVar(&flags.classNames, "class", nil, "Test class name (repeatable)")
+	cmd.Flags().StringVar(&flags.methodName, "method", "", "Specific test method to run")
+	cmd.Flags().StringSliceVar(&flags.tests, "tests", nil, "Tests to run as Class or Class.method (comma-separated, repeatable)")
+	cmd.Flags().StringSliceVar(&flags.suites, "suite", nil, "Apex test suite to run (repeatable)")
+	cmd.Flags().StringVar(&flags.classPattern, "class-pattern", "", "Run the classes whose names match this pattern, e.g. '*_Test'")
+	cmd.Flags().StringVar(&flags.namespace, "namespace", "", "Only look for test classes in this namespace")
+	cmd.Flags().BoolVar(&flags.wait, "wait", false, "Wait for tests to complete")
+	cmd.Flags().BoolVar(&flags.sync, "sync", false, "Run the tests of a single class synchronously, in one request")
+	cmd.Flags().BoolVar(&flags.coverage, "coverage", false, "Wait for tests and also show the coverage each test class contributes")
+	cmd.Flags().BoolVar(&flags.coverageOnly, "coverage-only", false, "Wait for tests and report only the coverage they produced")
+	cmd.Flags().StringVar(&flags.resultFormat, "result-format", "", "Wait for tests and write the results in this format: junit")
+	cmd.Flags().StringVar(&flags.out, "out", "", "Write the --result-format report to a file instead of stdout")
+	cmd.Flags().DurationVar(&opts.WaitTimeout, "wait-timeout", root.DefaultWaitTimeout, "How long --wait waits for the tests (0 for no limit)")
+	cmd.Flags().Float64Var(&opts.MaxRPS, "max-rps", 0, "Send at most this many API requests per second (0 for the max_rps setting)")
 
 	return cmd
 }
 
-func runTest(ctx context.Context, opts *root.Options, className, methodName string, wait bool) error {
+// testFlags holds the test command's flags.
+type testFlags struct {
+	classNames   []string
+	methodName   string
+	tests        []string
+	suites       []string
+	classPattern string
+	namespace    string
+	wait         bool
+	sync         bool
+	coverage     bool
+	coverageOnly bool
+	resultFormat string
+	out          string
+}
+
+func runTest(ctx context.Context, opts *root.Options, flags testFlags, specs []testSpec) error {
 	client, err := opts.ToolingClient()
 	if err != nil {
 		return fmt.Errorf("failed to create tooling client: %w", err)
 	}
 
 	v := opts.View()
+	if flags.resultFormat != "" && flags.out == "" {
+		// Keep stdout a valid report
+		v.SetOutput(opts.Stderr)
+	}
 
-	// Get the class ID for the test class
-	classID, err := client.GetApexClassID(ctx, className)
+	sel, err := resolveTests(ctx, client, flags, specs)
 	if err != nil {
-		return fmt.Errorf("failed to find test class: %w", err)
+		return err
 	}
 
-	v.Info("Running tests for %s...", className)
+	v.Info("Running tests for %s...", strings.Join(sel.names, ", "))
+
+	if flags.sync {
+		return runTestSync(ctx, client, opts, sel.tests[0], flags)
+	}
 
 	// Enqueue the test run
-	jobID, err := client.RunTestsAsync(ctx, []string{classID})
+	jobID, err := client.RunTests(ctx, sel.request())
 	if err != nil {
 		return fmt.Errorf("failed to enqueue tests: %w", err)
 	}
 
 	v.Info("Test job ID: %s", jobID)
 
-	if !wait {
+	if !flags.wait {
 		v.Info("Tests enqueued. Use 'sfdc apex test-status %s' to check results.", jobID)
 		return nil
 	}
@@ -77,28 +187,61 @@ func runTest(ctx context.Context, opts *root.Options, className, methodName stri
 	// Poll for completion
 	v.Info("Waiting for tests to complete...")
 
-	for {
+	var (
+		status   string
+		progress string
+	)
+	err = root.Poll(ctx, 2*time.Second, opts.WaitTimeout, func() (bool, error) {
 		job, err := client.GetAsyncJobStatus(ctx, jobID)
 		if err != nil {
-			return fmt.Errorf("failed to get job status: %w", err)
+			return false, fmt.Errorf("failed to get job status: %w", err)
+		}
+		status = job.Status
+
+		if job.TotalJobItems > 0 {
+			if p := fmt.Sprintf("%d of %d test classes completed", job.JobItemsProcessed, job.TotalJobItems); p != progress {
+				v.Info("  %s", p)
+				progress = p
+			}
 		}
 
 		switch job.Status {
 		case "Completed", "Aborted", "Failed":
-			return displayTestResults(ctx, client, opts, jobID, methodName)
+			return true, nil
 		case "Queued", "Processing", "Preparing", "Holding":
-			time.Sleep(2 * time.Second)
+			return false, nil
 		default:
-			return fmt.Errorf("unexpected job status: %s", job.Status)
+			return false, fmt.Errorf("unexpected job status: %s", job.Status)
 		}
+	})
+	if errors.Is(err, root.ErrWaitTimeout) {
+		return fmt.Errorf("%w waiting for test job %s; use 'sfdc apex test-status %s' to check results", err, jobID, jobID)
+	}
+	if err != nil {
+		return err
 	}
+
+	if flags.coverageOnly {
+		err = displayTestCoverage(ctx, client, opts, jobID, flags.methodName)
+	} else {
+		err = displayTestResults(ctx, client, opts, jobID, flags)
+	}
+	if err != nil {
+		return err
+	}
+
+	if status != "Completed" {
+		return fmt.Errorf("test job %s %s", jobID, strings.ToLower(status))
+	}
+	return nil
 }
 
-func displayTestResults(ctx context.Context, client *tooling.Client, opts *root.Options, jobID, filterMethod string) error {
+func displayTestResults(ctx context.Context, client *tooling.Client, opts *root.Options, jobID string, flags testFlags) error {
 	results, err := client.GetTestResults(ctx, jobID)
 	if err != nil {
 		return fmt.Errorf("failed to get test results: %w", err)
 	}
+	filterMethod := flags.methodName
 
 	// Filter by method if specified
 	if filterMethod != "" {
@@ -111,8 +254,63 @@ func displayTestResults(ctx context.Context, client *tooling.Client, opts *root.
 		results = filtered
 	}
 
+	var (
+		rows    []tooling.ApexCodeCoverage
+		rowsErr error
+		fetched bool
+	)
+	fetch := func() ([]tooling.ApexCodeCoverage, error) {
+		if !fetched {
+			rows, rowsErr = client.GetTestCoverage(ctx, testClassIDs(results))
+			fetched = true
+		}
+		return rows, rowsErr
+	}
+
+	return renderTestResults(opts, results, runCoverage{
+		classes: func() ([]testedClassCoverage, error) {
+			rows, err := fetch()
+			if err != nil {
+				return nil, err
+			}
+			return coverageForResults(results, rows, filterMethod), nil
+		},
+		byTest: func() ([]tooling.ApexCodeCoverage, error) {
+			rows, err := fetch()
+			if err != nil {
+				return nil, err
+			}
+			return ranCoverage(results, rows, filterMethod), nil
+		},
+	}, flags)
+}
+
+// runCoverage gets the coverage of a test run when it's shown: its totals
+// by class or trigger, and for --coverage, the coverage of each test method
+// that ran.
+type runCoverage struct {
+	classes func() ([]testedClassCoverage, error)
+	byTest  func() ([]tooling.ApexCodeCoverage, error)
+}
+
+// renderTestResults prints test results as the flags ask, with the coverage
+// of the run, which is only fetched for the results table.
+func renderTestResults(opts *root.Options, results []tooling.ApexTestResult, cov runCoverage, flags testFlags) error {
 	v := opts.View()
 
+	if flags.resultFormat == "junit" {
+		if flags.out == "" {
+			if err := writeJUnit(opts.Stdout, results); err != nil {
+				return err
+			}
+			return testFailures(results)
+		}
+		if err := writeJUnitFile(flags.out, results); err != nil {
+			return err
+		}
+		v.Success("JUnit results written to %s", flags.out)
+	}
+
 	if len(results) == 0 {
 		v.Info("No test results found")
 		return nil
@@ -127,6 +325,7 @@ func displayTestResults(ctx context.Context, client *tooling.Client, opts *root.
 
 	passCount := 0
 	failCount := 0
+	skipCount := 0
 	totalTime := 0
 
 	for _, r := range results {
@@ -149,6 +348,8 @@ func displayTestResults(ctx context.Context, client *tooling.Client, opts *root.
 			passCount++
 		case "Fail", "CompileFail":
 			failCount++
+		case "Skip":
+			skipCount++
 		}
 	}
 
@@ -162,10 +363,39 @@ func displayTestResults(ctx context.Context, client *tooling.Client, opts *root.
 	if failCount > 0 {
 		summaryParts = append(summaryParts, fmt.Sprintf("%d failed", failCount))
 	}
+	if skipCount > 0 {
+		summaryParts = append(summaryParts, fmt.Sprintf("%d skipped", skipCount))
+	}
 	summaryParts = append(summaryParts, fmt.Sprintf("%dms total", totalTime))
 
 	v.Info("\n%s", strings.Join(summaryParts, ", "))
 
+	// Coverage is a summary; failing to get it doesn't fail the run
+	coverage, err := cov.classes()
+	if err != nil {
+		v.Warning("Failed to get coverage: %v", err)
+	} else if len(coverage) > 0 {
+		covered, uncovered := 0, 0
+		for _, c := range coverage {
+			covered += c.NumLinesCovered
+			uncovered += c.NumLinesUncovered
+		}
+		v.Info("Coverage: %d/%d lines covered (%.1f%%) in %d classes and triggers", covered, covered+uncovered, coveragePercent(covered, uncovered), len(coverage))
+	}
+
+	if flags.coverage {
+		if rows, err := cov.byTest(); err != nil {
+			v.Warning("Failed to get coverage by test: %v", err)
+		} else if len(rows) == 0 {
+			v.Info("\nNo coverage data produced by these tests")
+		} else {
+			v.Info("")
+			if err := coveragecmd.RenderByTest(opts, rows); err != nil {
+				return err
+			}
+		}
+	}
+
 	// Show failure details
 	for _, r := range results {
 		if r.Outcome == "Fail" || r.Outcome == "CompileFail" {
@@ -180,9 +410,276 @@ func displayTestResults(ctx context.Context, client *tooling.Client, opts *root.
 		}
 	}
 
+	return testFailures(results)
+}
+
+// testFailures returns an error if any of the tests failed.
+func testFailures(results []tooling.ApexTestResult) error {
+	failCount := 0
+	for _, r := range results {
+		if r.Outcome == "Fail" || r.Outcome == "CompileFail" {
+			failCount++
+		}
+	}
 	if failCount > 0 {
 		return fmt.Errorf("%d test(s) failed", failCount)
 	}
+	return nil
+}
+
+// runTestSync runs the tests of one class synchronously and renders their
+// results, and the coverage that comes with them, as a waited-for run's.
+func runTestSync(ctx context.Context, client *tooling.Client, opts *root.Options, test tooling.TestItem, flags testFlags) error {
+	run, err := client.RunTestsSync(ctx, test.ClassID, test.TestMethods)
+	if err != nil {
+		return fmt.Errorf("failed to run tests: %w", err)
+	}
+
+	v := opts.View()
+	for _, w := range run.CodeCoverageWarnings {
+		v.Warning("%s", w.Message)
+	}
+
+	results := syncTestResults(run)
+	coverage := syncCoverage(run)
+	if flags.coverageOnly {
+		return renderTestCoverage(opts, results, coverage)
+	}
+	return renderTestResults(opts, results, runCoverage{
+		classes: func() ([]testedClassCoverage, error) {
+			return coverage, nil
+		},
+		// A synchronous run's response only totals coverage by class
+		byTest: func() ([]tooling.ApexCodeCoverage, error) {
+			rows, err := client.GetTestCoverage(ctx, testClassIDs(results))
+			if err != nil {
+				return nil, err
+			}
+			return ranCoverage(results, rows, flags.methodName), nil
+		},
+	}, flags)
+}
+
+// syncTestResults returns the test results of a synchronous run, failures
+// first, as ApexTestResult records.
+func syncTestResults(run *tooling.RunTestsSyncResult) []tooling.ApexTestResult {
+	results := make([]tooling.ApexTestResult, 0, len(run.Failures)+len(run.Successes))
+	for _, f := range run.Failures {
+		results = append(results, tooling.ApexTestResult{
+			ApexClassID: f.ID,
+			ClassName:   f.Name,
+			MethodName:  f.MethodName,
+			Outcome:     "Fail",
+			Message:     f.Message,
+			StackTrace:  f.StackTrace,
+			RunTime:     int(f.Time),
+		})
+	}
+	for _, s := range run.Successes {
+		results = append(results, tooling.ApexTestResult{
+			ApexClassID: s.ID,
+			ClassName:   s.Name,
+			MethodName:  s.MethodName,
+			Outcome:     "Pass",
+			RunTime:     int(s.Time),
+		})
+	}
+	return results
+}
+
+// syncCoverage returns the coverage a synchronous run produced, by class or
+// trigger name.
+func syncCoverage(run *tooling.RunTestsSyncResult) []testedClassCoverage {
+	coverage := make([]testedClassCoverage, 0, len(run.CodeCoverage))
+	for _, c := range run.CodeCoverage {
+		covered := c.NumLocations - c.NumLocationsNotCovered
+		coverage = append(coverage, testedClassCoverage{
+			ID:                c.ID,
+			Name:              c.Name,
+			NumLinesCovered:   covered,
+			NumLinesUncovered: c.NumLocationsNotCovered,
+			Percent:           coveragePercent(covered, c.NumLocationsNotCovered),
+		})
+	}
+
+	sort.Slice(coverage, func(i, j int) bool {
+		return coverage[i].Name < coverage[j].Name
+	})
+
+	return coverage
+}
+
+// testClassIDs returns the IDs of the test classes that produced results,
+// in the order they first appear. A suite's classes are only known this way.
+func testClassIDs(results []tooling.ApexTestResult) []string {
+	var ids []string
+	seen := make(map[string]bool)
+	for _, r := range results {
+		if !seen[r.ApexClassID] {
+			seen[r.ApexClassID] = true
+			ids = append(ids, r.ApexClassID)
+		}
+	}
+	return ids
+}
+
+// testedClassCoverage is the coverage a test run produced for one class or trigger.
+type testedClassCoverage struct {
+	ID                string  `json:"id"`
+	Name              string  `json:"name"`
+	NumLinesCovered   int     `json:"numLinesCovered"`
+	NumLinesUncovered int     `json:"numLinesUncovered"`
+	Percent           float64 `json:"percent"`
+}
+
+func displayTestCoverage(ctx context.Context, client *tooling.Client, opts *root.Options, jobID string, filterMethod string) error {
+	results, err := client.GetTestResults(ctx, jobID)
+	if err != nil {
+		return fmt.Errorf("failed to get test results: %w", err)
+	}
+
+	rows, err := client.GetTestCoverage(ctx, testClassIDs(results))
+	if err != nil {
+		return fmt.Errorf("failed to get coverage: %w", err)
+	}
+
+	return renderTestCoverage(opts, results, coverageForResults(results, rows, filterMethod))
+}
+
+// renderTestCoverage prints the coverage a test run produced, warning if any
+// of its tests failed.
+func renderTestCoverage(opts *root.Options, results []tooling.ApexTestResult, coverage []testedClassCoverage) error {
+	v := opts.View()
+
+	failCount := 0
+	for _, r := range results {
+		if r.Outcome == "Fail" || r.Outcome == "CompileFail" {
+			failCount++
+		}
+	}
+	if failCount > 0 {
+		v.Warning("%d test(s) failed; run without --coverage-only for details", failCount)
+	}
+
+	if len(coverage) == 0 {
+		v.Info("No coverage data produced by these tests")
+		return nil
+	}
+
+	if opts.Output == "json" {
+		return v.JSON(coverage)
+	}
+
+	headers := []string{"Class/Trigger", "Lines Covered", "Lines Uncovered", "Coverage %"}
+	tableRows := make([][]string, 0, len(coverage))
+	covered, uncovered := 0, 0
+	for _, c := range coverage {
+		tableRows = append(tableRows, []string{
+			c.Name,
+			fmt.Sprintf("%d", c.NumLinesCovered),
+			fmt.Sprintf("%d", c.NumLinesUncovered),
+			fmt.Sprintf("%.1f%%", c.Percent),
+		})
+		covered += c.NumLinesCovered
+		uncovered += c.NumLinesUncovered
+	}
+
+	if err := v.Table(headers, tableRows); err != nil {
+		return err
+	}
+
+	v.Info("\nOverall: %d/%d lines covered (%.1f%%)", covered, covered+uncovered, coveragePercent(covered, uncovered))
 
 	return nil
 }
+
+// coverageForResults correlates per-test-method coverage rows with the test
+// methods of a job and merges them per covered class. ApexCodeCoverage has no
+// job ID, so rows are matched on test class and method; a line counts as
+// covered if any of the job's test methods covered it.
+func coverageForResults(results []tooling.ApexTestResult, rows []tooling.ApexCodeCoverage, filterMethod string) []testedClassCoverage {
+	type lines struct {
+		name      string
+		covered   map[int]bool
+		all       map[int]bool
+		numCover  int
+		numTotal  int
+		hasDetail bool
+	}
+
+	byClass := make(map[string]*lines)
+	for _, row := range ranCoverage(results, rows, filterMethod) {
+		l, ok := byClass[row.ApexClassOrTriggerID]
+		if !ok {
+			l = &lines{name: row.ApexClassOrTrigger.Name, covered: make(map[int]bool), all: make(map[int]bool)}
+			byClass[row.ApexClassOrTriggerID] = l
+		}
+
+		if len(row.Coverage.CoveredLines)+len(row.Coverage.UncoveredLines) > 0 {
+			l.hasDetail = true
+			for _, n := range row.Coverage.CoveredLines {
+				l.covered[n] = true
+				l.all[n] = true
+			}
+			for _, n := range row.Coverage.UncoveredLines {
+				l.all[n] = true
+			}
+		}
+
+		// Without line detail, the best single method is a lower bound
+		if row.NumLinesCovered > l.numCover {
+			l.numCover = row.NumLinesCovered
+			l.numTotal = row.NumLinesCovered + row.NumLinesUncovered
+		}
+	}
+
+	coverage := make([]testedClassCoverage, 0, len(byClass))
+	for id, l := range byClass {
+		covered, total := l.numCover, l.numTotal
+		if l.hasDetail {
+			covered, total = len(l.covered), len(l.all)
+		}
+		coverage = append(coverage, testedClassCoverage{
+			ID:                id,
+			Name:              l.name,
+			NumLinesCovered:   covered,
+			NumLinesUncovered: total - covered,
+			Percent:           coveragePercent(covered, total-covered),
+		})
+	}
+
+	sort.Slice(coverage, func(i, j int) bool {
+		return coverage[i].Name < coverage[j].Name
+	})
+
+	return coverage
+}
+
+// ranCoverage returns the coverage rows of the test methods that produced
+// results, only of filterMethod if it isn't empty. Rows of other methods are
+// left from earlier runs.
+func ranCoverage(results []tooling.ApexTestResult, rows []tooling.ApexCodeCoverage, filterMethod string) []tooling.ApexCodeCoverage {
+	ran := make(map[string]bool, len(results))
+	for _, r := range results {
+		if filterMethod != "" && r.MethodName != filterMethod {
+			continue
+		}
+		ran[r.ApexClassID+"."+r.MethodName] = true
+	}
+
+	filtered := make([]tooling.ApexCodeCoverage, 0, len(rows))
+	for _, row := range rows {
+		if ran[row.ApexTestClassID+"."+row.TestMethodName] {
+			filtered = append(filtered, row)
+		}
+	}
+	return filtered
+}
+
+func coveragePercent(covered, uncovered int) float64 {
+	total := covered + uncovered
+	if total == 0 {
+		return 0
+	}
+	return float64(covered) / float64(total) * 100
+}
@@ -0,0 +1,26 @@
+// Package apicmd provides commands for sending raw REST API requests.
+package apicmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/salesforce-cli/internal/cmd/root"
+)
+
+// Register registers the api command with the root command.
+func Register(parent *cobra.Command, opts *root.Options) {
+	parent.AddCommand(NewCommand(opts))
+}
+
+// NewCommand creates the api command with subcommands.
+func NewCommand(opts *root.Options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "api",
+		Short: "Send raw REST API requests",
+		Long:  "Send REST API requests that no other command covers.",
+	}
+
+	cmd.AddCommand(newBatchCommand(opts))
+
+	return cmd
+}
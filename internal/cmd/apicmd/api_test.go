@@ -0,0 +1,115 @@
+package apicmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/salesforce-cli/api"
+	"github.com/open-cli-collective/salesforce-cli/internal/cmd/root"
+)
+
+func TestBatchCommand(t *testing.T) {
+	tests := []struct {
+		name     string
+		file     string
+		args     []string
+		response string
+		wantErr  string
+		want     []string
+		wantHalt bool
+	}{
+		{
+			name:     "object with all succeeding",
+			file:     `{"batchRequests":[{"method":"GET","url":"/limits"},{"method":"GET","url":"/sobjects/Account/001xx000001"}]}`,
+			response: `{"hasErrors":false,"results":[{"statusCode":200,"result":{}},{"statusCode":200,"result":{}}]}`,
+			want:     []string{"/limits", "/sobjects/Account/001xx000001", "200"},
+		},
+		{
+			name:     "list with a failure",
+			file:     `[{"method":"DELETE","url":"/sobjects/Account/001xx000001"},{"method":"GET","url":"/limits"}]`,
+			args:     []string{"--halt-on-error"},
+			response: `{"hasErrors":true,"results":[{"statusCode":404,"result":[{"errorCode":"NOT_FOUND","message":"The requested resource does not exist"}]},{"statusCode":412,"result":[{"errorCode":"BATCH_PROCESSING_HALTED","message":"Batch processing halted per request"}]}]}`,
+			wantErr:  "2 of 2 requests failed",
+			want:     []string{"404", "NOT_FOUND: The requested resource does not exist", "BATCH_PROCESSING_HALTED"},
+			wantHalt: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/services/data/v62.0/composite/batch", r.URL.Path)
+				var req api.BatchRequest
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+				assert.Equal(t, tt.wantHalt, req.HaltOnError)
+
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(tt.response))
+			}))
+			defer server.Close()
+
+			client, err := api.New(api.ClientConfig{InstanceURL: server.URL, HTTPClient: server.Client()})
+			require.NoError(t, err)
+
+			file := filepath.Join(t.TempDir(), "requests.json")
+			require.NoError(t, os.WriteFile(file, []byte(tt.file), 0644))
+
+			stdout := &bytes.Buffer{}
+			opts := &root.Options{
+				Output:  "table",
+				NoColor: true,
+				Stdout:  stdout,
+				Stderr:  &bytes.Buffer{},
+			}
+			opts.SetAPIClient(client)
+
+			cmd := NewCommand(opts)
+			cmd.SetArgs(append([]string{"batch", "--file", file}, tt.args...))
+			cmd.SetOut(stdout)
+
+			err = cmd.Execute()
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			for _, want := range tt.want {
+				assert.Contains(t, stdout.String(), want)
+			}
+		})
+	}
+}
+
+func TestBatchCommand_DryRun(t *testing.T) {
+	client, err := api.New(api.ClientConfig{InstanceURL: "https://test.salesforce.com", HTTPClient: http.DefaultClient})
+	require.NoError(t, err)
+
+	file := filepath.Join(t.TempDir(), "requests.json")
+	require.NoError(t, os.WriteFile(file, []byte(`[{"method":"DELETE","url":"/sobjects/Account/001xx000001"}]`), 0644))
+
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	opts := &root.Options{
+		Output:  "table",
+		NoColor: true,
+		DryRun:  true,
+		Stdout:  stdout,
+		Stderr:  stderr,
+	}
+	opts.SetAPIClient(client)
+
+	require.NoError(t, runBatch(context.Background(), opts, file, false))
+	assert.Contains(t, stderr.String(), "Dry run: no changes were made")
+	assert.Contains(t, stdout.String(), "POST https://test.salesforce.com/services/data/v62.0/composite/batch")
+	assert.Contains(t, stdout.String(), `"method": "DELETE"`)
+}
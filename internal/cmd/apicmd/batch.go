@@ -0,0 +1,145 @@
+package apicmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/salesforce-cli/api"
+	"github.com/open-cli-collective/salesforce-cli/internal/cmd/root"
+)
+
+func newBatchCommand(opts *root.Options) *cobra.Command {
+	var (
+		file        string
+		haltOnError bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "batch",
+		Short: "Send up to 25 independent requests in one call",
+		Long: `Send up to 25 REST API requests in one call with the composite batch API.
+
+The file holds the requests, either as a list or as a batch request object:
+
+  {"haltOnError": false, "batchRequests": [
+    {"method": "GET", "url": "/sobjects/Account/001xx000003DGbYAAW"},
+    {"method": "PATCH", "url": "/sobjects/Contact/003xx000001abcd",
+     "richInput": {"Phone": "555-0100"}}
+  ]}
+
+URLs may be relative to the REST API base (as above), start with the API
+version (v62.0/limits), or be full paths (/services/data/v62.0/limits).
+
+Each request succeeds or fails on its own, and the command fails if any did.
+With --halt-on-error, the requests after a failure are not run.
+
+Examples:
+  sfdc api batch --file requests.json
+  sfdc api batch --file requests.json --halt-on-error
+  sfdc api batch --file requests.json -o json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runBatch(cmd.Context(), opts, file, haltOnError)
+		},
+	}
+
+	cmd.Flags().StringVarP(&file, "file", "f", "", "Path to a JSON file of requests (required)")
+	cmd.Flags().BoolVar(&haltOnError, "halt-on-error", false, "Skip the requests after a failed one")
+
+	_ = cmd.MarkFlagRequired("file")
+
+	return cmd
+}
+
+func runBatch(ctx context.Context, opts *root.Options, file string, haltOnError bool) error {
+	req, err := readBatchFile(file)
+	if err != nil {
+		return err
+	}
+	if haltOnError {
+		req.HaltOnError = true
+	}
+
+	client, err := opts.APIClient()
+	if err != nil {
+		return fmt.Errorf("failed to create API client: %w", err)
+	}
+
+	if opts.DryRun {
+		return opts.PrintDryRun(root.DryRunRequest{
+			Operation: "batch",
+			Method:    http.MethodPost,
+			URL:       client.ResourceURL("/composite/batch"),
+			Payload:   req,
+		})
+	}
+
+	resp, err := client.Batch(ctx, req)
+	if err != nil {
+		return fmt.Errorf("failed to send batch: %w", err)
+	}
+
+	v := opts.View()
+
+	if opts.Output == "json" {
+		if err := v.JSON(resp); err != nil {
+			return err
+		}
+	} else {
+		rows := make([][]string, 0, len(resp.Results))
+		for i, result := range resp.Results {
+			if i >= len(req.BatchRequests) {
+				break
+			}
+			sub := req.BatchRequests[i]
+			message := ""
+			if err := result.Err(); err != nil {
+				message = err.Error()
+			}
+			rows = append(rows, []string{strconv.Itoa(i + 1), sub.Method, sub.URL, strconv.Itoa(result.StatusCode), message})
+		}
+		if err := v.Table([]string{"#", "Method", "URL", "Status", "Error"}, rows); err != nil {
+			return err
+		}
+	}
+
+	if resp.HasErrors {
+		failed := 0
+		for _, result := range resp.Results {
+			if !result.Succeeded() {
+				failed++
+			}
+		}
+		return fmt.Errorf("%d of %d requests failed", failed, len(req.BatchRequests))
+	}
+
+	return nil
+}
+
+// readBatchFile reads a batch request, or a list of subrequests, from file.
+func readBatchFile(file string) (*api.BatchRequest, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read batch file: %w", err)
+	}
+
+	var req api.BatchRequest
+	data = bytes.TrimSpace(data)
+	if bytes.HasPrefix(data, []byte("[")) {
+		err = json.Unmarshal(data, &req.BatchRequests)
+	} else {
+		err = json.Unmarshal(data, &req)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", file, err)
+	}
+
+	return &req, nil
+}
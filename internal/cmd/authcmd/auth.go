@@ -0,0 +1,39 @@
+// Package authcmd provides the auth command group for logging in and out.
+package authcmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/salesforce-cli/internal/cmd/root"
+)
+
+// Register registers the auth command with the root command.
+func Register(parent *cobra.Command, opts *root.Options) {
+	cmd := &cobra.Command{
+		Use:   "auth",
+		Short: "Log in and out of orgs",
+		Long: `Commands to log in and out of orgs and to inspect the stored logins.
+
+'sfdc auth login' without a flow flag is the guided browser login, also
+available as 'sfdc init'. Its other flows log in without a browser, for
+scripts and CI pipelines.
+
+Examples:
+  sfdc auth login
+  sfdc auth login --jwt-key server.key --client-id <key> --username ci@example.com
+  sfdc auth list
+  sfdc auth token
+  sfdc auth logout
+  sfdc auth import-sfdx-url --file auth.json
+  sfdc auth export-sfdx-url`,
+	}
+
+	cmd.AddCommand(newLoginCommand(opts))
+	cmd.AddCommand(newLogoutCommand(opts))
+	cmd.AddCommand(newListCommand(opts))
+	cmd.AddCommand(newTokenCommand(opts))
+	cmd.AddCommand(newImportSFDXURLCommand(opts))
+	cmd.AddCommand(newExportSFDXURLCommand(opts))
+
+	parent.AddCommand(cmd)
+}
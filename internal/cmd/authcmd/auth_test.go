@@ -0,0 +1,607 @@
+package authcmd
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+
+	"github.com/open-cli-collective/salesforce-cli/internal/cmd/root"
+	"github.com/open-cli-collective/salesforce-cli/internal/config"
+	"github.com/open-cli-collective/salesforce-cli/internal/keychain"
+	"github.com/open-cli-collective/salesforce-cli/internal/sfcli"
+)
+
+func writeTestKey(t *testing.T) string {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "server.key")
+	data := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	require.NoError(t, os.WriteFile(path, data, 0600))
+	return path
+}
+
+func TestLoginCommand_JWT(t *testing.T) {
+	config.SetConfigDir(t.TempDir())
+	defer config.SetConfigDir("")
+	defer config.SetOrg("")
+	t.Setenv(config.OrgEnvVar, "")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/services/oauth2/token", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"access_token": "access-token",
+			"instance_url": "https://mycompany.my.salesforce.com",
+			"token_type":   "Bearer",
+		})
+	}))
+	defer server.Close()
+
+	keyFile := writeTestKey(t)
+
+	stdout := &bytes.Buffer{}
+	opts := &root.Options{
+		Output:  "table",
+		NoColor: true,
+		Stdout:  stdout,
+		Stderr:  &bytes.Buffer{},
+	}
+
+	cmd := newLoginCommand(opts)
+	cmd.SetArgs([]string{
+		"--jwt-key", keyFile,
+		"--client-id", "client-id",
+		"--username", "ci@example.com",
+		"--instance-url", server.URL,
+		"--alias", "ci",
+	})
+	require.NoError(t, cmd.Execute())
+
+	assert.Contains(t, stdout.String(), "Logged in as ci@example.com to https://mycompany.my.salesforce.com")
+
+	cfg, err := config.Load()
+	require.NoError(t, err)
+	assert.Equal(t, "ci", cfg.DefaultOrg)
+	assert.Equal(t, "ci", cfg.OrgAlias())
+	assert.Equal(t, config.AuthFlowJWT, cfg.AuthFlow)
+	assert.Equal(t, "https://mycompany.my.salesforce.com", cfg.InstanceURL)
+	assert.Equal(t, "client-id", cfg.ClientID)
+	assert.Equal(t, "ci@example.com", cfg.Username)
+	assert.Equal(t, keyFile, cfg.JWTKeyFile)
+	assert.Equal(t, "https://login.salesforce.com", cfg.JWTAudience)
+}
+
+func TestLoginCommand_ClientCredentials(t *testing.T) {
+	config.SetConfigDir(t.TempDir())
+	defer config.SetConfigDir("")
+	t.Setenv(config.OrgEnvVar, "")
+	t.Setenv("SFDC_CLIENT_SECRET", "")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/services/oauth2/token", r.URL.Path)
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "client_credentials", r.PostForm.Get("grant_type"))
+		assert.Equal(t, "client-id", r.PostForm.Get("client_id"))
+		assert.Equal(t, "client-secret", r.PostForm.Get("client_secret"))
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"access_token": "access-token",
+			"instance_url": "https://mycompany.my.salesforce.com",
+			"token_type":   "Bearer",
+		})
+	}))
+	defer server.Close()
+
+	stdout := &bytes.Buffer{}
+	opts := &root.Options{
+		Output:  "table",
+		NoColor: true,
+		Stdout:  stdout,
+		Stderr:  &bytes.Buffer{},
+	}
+
+	cmd := newLoginCommand(opts)
+	cmd.SetArgs([]string{
+		"--client-credentials",
+		"--client-id", "client-id",
+		"--client-secret", "client-secret",
+		"--instance-url", server.URL,
+	})
+	require.NoError(t, cmd.Execute())
+
+	assert.Contains(t, stdout.String(), "Logged in to https://mycompany.my.salesforce.com")
+
+	cfg, err := config.Load()
+	require.NoError(t, err)
+	assert.Equal(t, config.AuthFlowClientCredentials, cfg.AuthFlow)
+	assert.Empty(t, cfg.Username)
+
+	// The secret is stored with the token, not in the config file
+	path, err := config.GetConfigPath()
+	require.NoError(t, err)
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "client-secret")
+	secret, err := keychain.GetClientSecret()
+	require.NoError(t, err)
+	assert.Equal(t, "client-secret", secret)
+
+	token, err := keychain.GetToken()
+	require.NoError(t, err)
+	assert.Equal(t, "access-token", token.AccessToken)
+	assert.False(t, token.Expiry.IsZero())
+}
+
+func TestLoginCommand_Rejected(t *testing.T) {
+	config.SetConfigDir(t.TempDir())
+	defer config.SetConfigDir("")
+	t.Setenv(config.OrgEnvVar, "")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error":"invalid_grant","error_description":"user hasn't approved this consumer"}`))
+	}))
+	defer server.Close()
+
+	opts := &root.Options{Stdout: &bytes.Buffer{}, Stderr: &bytes.Buffer{}}
+
+	cmd := newLoginCommand(opts)
+	cmd.SetArgs([]string{
+		"--jwt-key", writeTestKey(t),
+		"--client-id", "client-id",
+		"--username", "ci@example.com",
+		"--instance-url", server.URL,
+	})
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "JWT login failed")
+
+	cfg, err := config.Load()
+	require.NoError(t, err)
+	assert.Empty(t, cfg.InstanceURL)
+}
+
+func TestLoginCommand_RequiresFlags(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"from sf with jwt", []string{"--from-sf", "dev", "--jwt-key", "server.key"}, "--from-sf cannot be used with"},
+		{"device with jwt", []string{"--device", "--jwt-key", "server.key", "--client-id", "x"}, "--device cannot be used with"},
+		{"both flows", []string{"--jwt-key", "server.key", "--client-credentials", "--client-id", "x"}, "cannot be used together"},
+		{"no secret", []string{"--client-credentials", "--client-id", "x", "--instance-url", "mycompany.my.salesforce.com"}, "--client-secret or SFDC_CLIENT_SECRET is required"},
+		{"login URL", []string{"--client-credentials", "--client-id", "x", "--client-secret", "s"}, "My Domain URL"},
+		{"no client id", []string{"--jwt-key", "server.key"}, "--client-id is required"},
+		{"no username", []string{"--jwt-key", "server.key", "--client-id", "x"}, "--username is required"},
+		{"password without username", []string{"--username-password"}, "--username is required with --username-password"},
+		{"no password", []string{"--username-password", "--username", "admin@example.com"}, "--password or SFDC_PASSWORD is required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config.SetConfigDir(t.TempDir())
+			defer config.SetConfigDir("")
+			t.Setenv("SFDC_CLIENT_SECRET", "")
+			t.Setenv("SFDC_PASSWORD", "")
+
+			cmd := newLoginCommand(&root.Options{Stdout: &bytes.Buffer{}, Stderr: &bytes.Buffer{}})
+			cmd.SetArgs(tt.args)
+			err := cmd.Execute()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
+
+func TestImportExportSFDXURL(t *testing.T) {
+	config.SetConfigDir(t.TempDir())
+	defer config.SetConfigDir("")
+	defer config.SetOrg("")
+	t.Setenv(config.OrgEnvVar, "")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/services/oauth2/token", r.URL.Path)
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "refresh_token", r.PostForm.Get("grant_type"))
+		assert.Equal(t, "5Aep861refresh", r.PostForm.Get("refresh_token"))
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"access_token": "access-token",
+			"instance_url": "https://mycompany.my.salesforce.com",
+			"token_type":   "Bearer",
+		})
+	}))
+	defer server.Close()
+
+	authFile := filepath.Join(t.TempDir(), "auth.json")
+	display := `{"status":0,"result":{"sfdxAuthUrl":"force://PlatformCLI::5Aep861refresh@` + server.URL + `"}}`
+	require.NoError(t, os.WriteFile(authFile, []byte(display), 0600))
+
+	stdout := &bytes.Buffer{}
+	opts := &root.Options{
+		Output:  "table",
+		NoColor: true,
+		Stdout:  stdout,
+		Stderr:  &bytes.Buffer{},
+	}
+
+	cmd := newImportSFDXURLCommand(opts)
+	cmd.SetArgs([]string{"--file", authFile, "--alias", "dev"})
+	require.NoError(t, cmd.Execute())
+	assert.Contains(t, stdout.String(), "Logged in to https://mycompany.my.salesforce.com")
+
+	cfg, err := config.Load()
+	require.NoError(t, err)
+	assert.Equal(t, "dev", cfg.DefaultOrg)
+	assert.Equal(t, "https://mycompany.my.salesforce.com", cfg.InstanceURL)
+	assert.Equal(t, "PlatformCLI", cfg.ClientID)
+	assert.Empty(t, cfg.AuthFlow)
+
+	token, err := keychain.GetToken()
+	require.NoError(t, err)
+	assert.Equal(t, "access-token", token.AccessToken)
+	assert.Equal(t, "5Aep861refresh", token.RefreshToken)
+
+	stdout.Reset()
+	cmd = newExportSFDXURLCommand(opts)
+	cmd.SetArgs([]string{})
+	require.NoError(t, cmd.Execute())
+	assert.Equal(t, "force://PlatformCLI::5Aep861refresh@mycompany.my.salesforce.com\n", stdout.String())
+}
+
+func TestImportSFDXURL_Rejected(t *testing.T) {
+	config.SetConfigDir(t.TempDir())
+	defer config.SetConfigDir("")
+	t.Setenv(config.OrgEnvVar, "")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error":"invalid_grant","error_description":"expired access/refresh token"}`))
+	}))
+	defer server.Close()
+
+	opts := &root.Options{
+		Stdin:  bytes.NewBufferString("force://PlatformCLI::revoked@" + server.URL + "\n"),
+		Stdout: &bytes.Buffer{},
+		Stderr: &bytes.Buffer{},
+	}
+
+	cmd := newImportSFDXURLCommand(opts)
+	cmd.SetArgs([]string{"--file", "-"})
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to validate refresh token")
+
+	cfg, err := config.Load()
+	require.NoError(t, err)
+	assert.Empty(t, cfg.InstanceURL)
+}
+
+func TestExportSFDXURL_JWT(t *testing.T) {
+	config.SetConfigDir(t.TempDir())
+	defer config.SetConfigDir("")
+	t.Setenv(config.OrgEnvVar, "")
+
+	require.NoError(t, config.Save(&config.Config{
+		InstanceURL: "https://mycompany.my.salesforce.com",
+		ClientID:    "client-id",
+		AuthFlow:    config.AuthFlowJWT,
+	}))
+
+	cmd := newExportSFDXURLCommand(&root.Options{Stdout: &bytes.Buffer{}, Stderr: &bytes.Buffer{}})
+	cmd.SetArgs([]string{})
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot be exported")
+}
+
+func TestLoginCommand_FromSF(t *testing.T) {
+	config.SetConfigDir(t.TempDir())
+	defer config.SetConfigDir("")
+	defer config.SetOrg("")
+	t.Setenv(config.OrgEnvVar, "")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "refresh_token", r.PostForm.Get("grant_type"))
+		assert.Equal(t, "5Aep861refresh", r.PostForm.Get("refresh_token"))
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"access_token": "access-token",
+			"instance_url": "https://mycompany.my.salesforce.com",
+			"token_type":   "Bearer",
+		})
+	}))
+	defer server.Close()
+
+	home := t.TempDir()
+	sfcli.SetHomeDir(home)
+	defer sfcli.SetHomeDir("")
+
+	// An auth file written with encryption disabled
+	dir := filepath.Join(home, ".sfdx")
+	require.NoError(t, os.MkdirAll(dir, 0700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "alias.json"), []byte(`{"orgs":{"dev":"admin@example.com"}}`), 0600))
+	authFile := `{"username":"admin@example.com","instanceUrl":"` + server.URL + `","clientId":"PlatformCLI","refreshToken":"5Aep861refresh"}`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "admin@example.com.json"), []byte(authFile), 0600))
+
+	stdout := &bytes.Buffer{}
+	opts := &root.Options{
+		Output:  "table",
+		NoColor: true,
+		Stdout:  stdout,
+		Stderr:  &bytes.Buffer{},
+	}
+
+	cmd := newLoginCommand(opts)
+	cmd.SetArgs([]string{"--from-sf", "dev"})
+	require.NoError(t, cmd.Execute())
+	assert.Contains(t, stdout.String(), "Imported admin@example.com from the sf CLI")
+
+	cfg, err := config.Load()
+	require.NoError(t, err)
+	assert.Equal(t, "dev", cfg.DefaultOrg)
+	assert.Equal(t, "https://mycompany.my.salesforce.com", cfg.InstanceURL)
+	assert.Equal(t, "PlatformCLI", cfg.ClientID)
+
+	token, err := keychain.GetToken()
+	require.NoError(t, err)
+	assert.Equal(t, "5Aep861refresh", token.RefreshToken)
+}
+
+func TestLoginCommand_UsernamePassword(t *testing.T) {
+	config.SetConfigDir(t.TempDir())
+	defer config.SetConfigDir("")
+	t.Setenv(config.OrgEnvVar, "")
+	t.Setenv("SFDC_PASSWORD", "secret")
+	t.Setenv("SFDC_SECURITY_TOKEN", "")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/services/Soap/u/62.0", r.URL.Path)
+		body, _ := io.ReadAll(r.Body)
+		assert.Contains(t, string(body), "<urn:password>secretTOKEN</urn:password>")
+
+		w.Header().Set("Content-Type", "text/xml")
+		_, _ = w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?>
+<soapenv:Envelope xmlns:soapenv="http://schemas.xmlsoap.org/soap/envelope/" xmlns="urn:partner.soap.sforce.com">
+<soapenv:Body><loginResponse><result>
+<passwordExpired>false</passwordExpired>
+<serverUrl>https://mycompany--uat.sandbox.my.salesforce.com/services/Soap/u/62.0/00D000000000001</serverUrl>
+<sessionId>00D!session</sessionId>
+<userInfo><sessionSecondsValid>7200</sessionSecondsValid></userInfo>
+</result></loginResponse></soapenv:Body></soapenv:Envelope>`))
+	}))
+	defer server.Close()
+
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	opts := &root.Options{
+		Output:  "table",
+		NoColor: true,
+		Stdout:  stdout,
+		Stderr:  stderr,
+	}
+
+	cmd := newLoginCommand(opts)
+	cmd.SetArgs([]string{
+		"--username-password",
+		"--username", "admin@example.com.uat",
+		"--security-token", "TOKEN",
+		"--instance-url", server.URL,
+	})
+	require.NoError(t, cmd.Execute())
+
+	assert.Contains(t, stderr.String(), "discouraged")
+	assert.Contains(t, stdout.String(), "Logged in as admin@example.com.uat to https://mycompany--uat.sandbox.my.salesforce.com")
+
+	cfg, err := config.Load()
+	require.NoError(t, err)
+	assert.Equal(t, config.AuthFlowPassword, cfg.AuthFlow)
+	assert.Equal(t, "admin@example.com.uat", cfg.Username)
+	assert.Empty(t, cfg.ClientID)
+	assert.True(t, cfg.Configured())
+
+	path, err := config.GetConfigPath()
+	require.NoError(t, err)
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "secret")
+
+	token, err := keychain.GetToken()
+	require.NoError(t, err)
+	assert.Equal(t, "00D!session", token.AccessToken)
+}
+
+func TestLogoutCommand(t *testing.T) {
+	config.SetConfigDir(t.TempDir())
+	defer config.SetConfigDir("")
+	t.Setenv(config.OrgEnvVar, "")
+
+	var revoked string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/services/oauth2/revoke", r.URL.Path)
+		require.NoError(t, r.ParseForm())
+		revoked = r.PostForm.Get("token")
+	}))
+	defer server.Close()
+
+	require.NoError(t, config.Save(&config.Config{InstanceURL: server.URL, ClientID: "client-id"}))
+	require.NoError(t, keychain.SetToken(&oauth2.Token{AccessToken: "access-token", RefreshToken: "refresh-token"}))
+
+	stdout := &bytes.Buffer{}
+	opts := &root.Options{Output: "table", NoColor: true, Stdout: stdout, Stderr: &bytes.Buffer{}}
+
+	cmd := newLogoutCommand(opts)
+	cmd.SetArgs([]string{})
+	require.NoError(t, cmd.Execute())
+	assert.Equal(t, "refresh-token", revoked)
+	assert.Contains(t, stdout.String(), "Logged out of "+server.URL)
+	assert.False(t, keychain.HasStoredToken())
+
+	// The org's settings are kept
+	cfg, err := config.Load()
+	require.NoError(t, err)
+	assert.Equal(t, server.URL, cfg.InstanceURL)
+
+	stdout.Reset()
+	cmd = newLogoutCommand(opts)
+	cmd.SetArgs([]string{})
+	require.NoError(t, cmd.Execute())
+	assert.Contains(t, stdout.String(), "already logged out")
+}
+
+func TestLogoutCommand_RevokeFails(t *testing.T) {
+	config.SetConfigDir(t.TempDir())
+	defer config.SetConfigDir("")
+	t.Setenv(config.OrgEnvVar, "")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error":"unsupported_token_type"}`))
+	}))
+	defer server.Close()
+
+	require.NoError(t, config.Save(&config.Config{InstanceURL: server.URL, ClientID: "client-id"}))
+	require.NoError(t, keychain.SetToken(&oauth2.Token{AccessToken: "access-token"}))
+
+	stderr := &bytes.Buffer{}
+	opts := &root.Options{Output: "table", NoColor: true, Stdout: &bytes.Buffer{}, Stderr: stderr}
+
+	cmd := newLogoutCommand(opts)
+	cmd.SetArgs([]string{})
+	require.NoError(t, cmd.Execute())
+	assert.Contains(t, stderr.String(), "Could not revoke token")
+	assert.False(t, keychain.HasStoredToken())
+}
+
+func TestListCommand(t *testing.T) {
+	config.SetConfigDir(t.TempDir())
+	defer config.SetConfigDir("")
+	defer config.SetOrg("")
+	t.Setenv(config.OrgEnvVar, "")
+
+	config.SetOrg("dev")
+	cfg, err := config.Load()
+	require.NoError(t, err)
+	cfg.InstanceURL = "https://dev.my.salesforce.com"
+	cfg.ClientID = "client-id"
+	cfg.DefaultOrg = "dev"
+	require.NoError(t, config.Save(cfg))
+	require.NoError(t, keychain.SetToken(&oauth2.Token{AccessToken: "dev-token", Expiry: time.Now().Add(-time.Minute)}))
+
+	config.SetOrg("ci")
+	cfg, err = config.Load()
+	require.NoError(t, err)
+	cfg.InstanceURL = "https://ci.my.salesforce.com"
+	cfg.ClientID = "client-id"
+	cfg.AuthFlow = config.AuthFlowJWT
+	cfg.Username = "ci@example.com"
+	require.NoError(t, config.Save(cfg))
+	config.SetOrg("")
+
+	stdout := &bytes.Buffer{}
+	opts := &root.Options{Output: "table", NoColor: true, Stdout: stdout, Stderr: &bytes.Buffer{}}
+
+	cmd := newListCommand(opts)
+	cmd.SetArgs([]string{})
+	require.NoError(t, cmd.Execute())
+
+	lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	require.Len(t, lines, 3)
+	assert.Contains(t, lines[1], "  ci ")
+	assert.Contains(t, lines[1], "ci@example.com")
+	assert.Contains(t, lines[1], "not stored")
+	assert.Contains(t, lines[2], "* dev ")
+	assert.Contains(t, lines[2], "refresh_token")
+	assert.Contains(t, lines[2], "expired")
+
+	// The selected org is restored
+	assert.Equal(t, "dev", config.ActiveOrg())
+
+	stdout.Reset()
+	opts.Output = "json"
+	cmd = newListCommand(opts)
+	cmd.SetArgs([]string{})
+	require.NoError(t, cmd.Execute())
+
+	var logins []map[string]interface{}
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &logins))
+	require.Len(t, logins, 2)
+	assert.Equal(t, "ci", logins[0]["alias"])
+	assert.Equal(t, false, logins[0]["hasToken"])
+	assert.Equal(t, true, logins[1]["default"])
+	assert.Equal(t, true, logins[1]["hasToken"])
+	assert.Contains(t, logins[1], "expiresAt")
+}
+
+func TestTokenCommand(t *testing.T) {
+	config.SetConfigDir(t.TempDir())
+	defer config.SetConfigDir("")
+	t.Setenv(config.OrgEnvVar, "")
+	t.Setenv("SFDC_INSTANCE_URL", "")
+	t.Setenv("SFDC_CLIENT_ID", "")
+
+	refreshes := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/services/oauth2/token", r.URL.Path)
+		refreshes++
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"access_token": "new-token",
+			"token_type":   "Bearer",
+		})
+	}))
+	defer server.Close()
+
+	require.NoError(t, config.Save(&config.Config{InstanceURL: server.URL, ClientID: "client-id"}))
+	require.NoError(t, keychain.SetToken(&oauth2.Token{
+		AccessToken:  "stored-token",
+		RefreshToken: "refresh-token",
+		Expiry:       time.Now().Add(time.Hour),
+	}))
+
+	stdout := &bytes.Buffer{}
+	opts := &root.Options{Output: "table", NoColor: true, Stdout: stdout, Stderr: &bytes.Buffer{}}
+
+	cmd := newTokenCommand(opts)
+	cmd.SetArgs([]string{})
+	require.NoError(t, cmd.Execute())
+	assert.Equal(t, "stored-token\n", stdout.String())
+	assert.Equal(t, 0, refreshes)
+
+	stdout.Reset()
+	cmd = newTokenCommand(opts)
+	cmd.SetArgs([]string{"--refresh"})
+	require.NoError(t, cmd.Execute())
+	assert.Equal(t, "new-token\n", stdout.String())
+	assert.Equal(t, 1, refreshes)
+
+	token, err := keychain.GetToken()
+	require.NoError(t, err)
+	assert.Equal(t, "new-token", token.AccessToken)
+	assert.Equal(t, "refresh-token", token.RefreshToken)
+}
@@ -0,0 +1,151 @@
+package authcmd
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/oauth2"
+
+	"github.com/open-cli-collective/salesforce-cli/internal/cmd/root"
+	"github.com/open-cli-collective/salesforce-cli/internal/config"
+	"github.com/open-cli-collective/salesforce-cli/internal/keychain"
+)
+
+// loginStatus describes a login in the output of the list command.
+type loginStatus struct {
+	Alias       string     `json:"alias,omitempty"`
+	Default     bool       `json:"default"`
+	InstanceURL string     `json:"instanceUrl"`
+	Username    string     `json:"username,omitempty"`
+	AuthFlow    string     `json:"authFlow"`
+	HasToken    bool       `json:"hasToken"`
+	ExpiresAt   *time.Time `json:"expiresAt,omitempty"`
+}
+
+func newListCommand(opts *root.Options) *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List logins and their tokens",
+		Long: `List the org profiles, or the single login of 'sfdc init' if there are
+none, with their auth flow and stored token. The default org is marked
+with *.
+
+Salesforce does not report when tokens from the browser login or an
+imported login expire, so their expiry is shown as unknown; they are
+refreshed when Salesforce rejects them. The JWT bearer flow stores no
+token.
+
+Examples:
+  sfdc auth list
+  sfdc auth list -o json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runList(opts)
+		},
+	}
+}
+
+func runList(opts *root.Options) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	var logins []loginStatus
+	if len(cfg.Orgs) == 0 {
+		if cfg.Configured() {
+			logins = append(logins, newLoginStatus("", cfg.InstanceURL, cfg.Username, cfg.AuthFlow, false))
+		}
+	} else {
+		aliases := make([]string, 0, len(cfg.Orgs))
+		for alias := range cfg.Orgs {
+			aliases = append(aliases, alias)
+		}
+		sort.Strings(aliases)
+
+		// Tokens are stored per org, so select each org to read its token
+		defer config.SetOrg(opts.Org)
+		for _, alias := range aliases {
+			p := cfg.Orgs[alias]
+			config.SetOrg(alias)
+			logins = append(logins, newLoginStatus(alias, p.InstanceURL, p.Username, p.AuthFlow, alias == cfg.DefaultOrg))
+		}
+	}
+
+	v := opts.View()
+	if opts.Output == "json" {
+		if logins == nil {
+			logins = []loginStatus{}
+		}
+		return v.JSON(logins)
+	}
+
+	if len(logins) == 0 {
+		v.Info("Not logged in. Run 'sfdc auth login' to log in.")
+		return nil
+	}
+
+	rows := make([][]string, 0, len(logins))
+	for _, l := range logins {
+		alias := l.Alias
+		if alias == "" {
+			alias = "-"
+		}
+		marker := " "
+		if l.Default {
+			marker = "*"
+		}
+		rows = append(rows, []string{marker + " " + alias, l.InstanceURL, l.Username, l.AuthFlow, tokenStatus(l)})
+	}
+	return v.Table([]string{"  ALIAS", "INSTANCE URL", "USERNAME", "AUTH", "TOKEN"}, rows)
+}
+
+// newLoginStatus describes a login, reading the token of the selected org.
+func newLoginStatus(alias, instanceURL, username, authFlow string, isDefault bool) loginStatus {
+	l := loginStatus{
+		Alias:       alias,
+		Default:     isDefault,
+		InstanceURL: instanceURL,
+		Username:    username,
+		AuthFlow:    authFlow,
+	}
+	if l.AuthFlow == "" {
+		l.AuthFlow = "refresh_token"
+	}
+
+	if authFlow == config.AuthFlowJWT {
+		return l
+	}
+	if token, err := keychain.GetToken(); err == nil {
+		l.HasToken = true
+		l.ExpiresAt = tokenExpiry(token)
+	}
+	return l
+}
+
+// tokenExpiry returns the expiry of token, or nil if it is unknown.
+func tokenExpiry(token *oauth2.Token) *time.Time {
+	if token.Expiry.IsZero() {
+		return nil
+	}
+	expiry := token.Expiry
+	return &expiry
+}
+
+// tokenStatus describes the stored token of a login for the table output.
+func tokenStatus(l loginStatus) string {
+	switch {
+	case l.AuthFlow == config.AuthFlowJWT:
+		return "not stored"
+	case !l.HasToken:
+		return "none"
+	case l.ExpiresAt == nil:
+		return "expiry unknown"
+	case l.ExpiresAt.Before(time.Now()):
+		return "expired"
+	default:
+		return "expires " + l.ExpiresAt.Local().Format("2006-01-02 15:04")
+	}
+}
@@ -0,0 +1,368 @@
+package authcmd
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/oauth2"
+
+	"github.com/open-cli-collective/salesforce-cli/internal/auth"
+	"github.com/open-cli-collective/salesforce-cli/internal/cmd/initcmd"
+	"github.com/open-cli-collective/salesforce-cli/internal/cmd/root"
+	"github.com/open-cli-collective/salesforce-cli/internal/config"
+	"github.com/open-cli-collective/salesforce-cli/internal/keychain"
+	"github.com/open-cli-collective/salesforce-cli/internal/sfcli"
+)
+
+// loginFlags holds the flags of the login command.
+type loginFlags struct {
+	instanceURL       string
+	clientID          string
+	username          string
+	jwtKey            string
+	audience          string
+	clientCredentials bool
+	clientSecret      string
+	usernamePassword  bool
+	password          string
+	securityToken     string
+	fromSF            string
+	alias             string
+	device            bool
+	noVerify          bool
+	callbackPort      int
+}
+
+func newLoginCommand(opts *root.Options) *cobra.Command {
+	var flags loginFlags
+
+	cmd := &cobra.Command{
+		Use:   "login",
+		Short: "Log in to an org",
+		Long: `Log in to Salesforce.
+
+Without a flow flag, this is the guided browser login of 'sfdc init': the
+form is pre-filled from --instance-url and --client-id, and --device logs in
+with a code entered on another device instead.
+
+The other flows log in without a browser, e.g. in a CI pipeline.
+
+With --jwt-key, the OAuth 2.0 JWT bearer flow is used: the CLI signs an
+assertion for --username with the private key, and the Connected App
+(--client-id) must have the matching certificate uploaded and the user
+pre-authorized. No token is stored; a new one is requested with the key
+whenever the last expires, so the key file must stay in place.
+
+The assertion audience is https://test.salesforce.com for sandbox instance
+URLs and https://login.salesforce.com otherwise; use --audience to override
+it (e.g. for Experience Cloud sites).
+
+With --client-credentials, the OAuth 2.0 client credentials flow is used:
+the Connected App must have the flow enabled with a "Run As" user, and
+--instance-url must be the org's My Domain URL. The client secret is read
+from --client-secret or SFDC_CLIENT_SECRET and saved in the config file so
+that a new token can be requested when the stored one expires.
+
+With --username-password, the user logs in with a password and security
+token through the SOAP API, which needs no Connected App. This is
+discouraged: passwords are weaker than keys and often blocked by org
+policy. The password is read from --password or SFDC_PASSWORD and the
+security token from --security-token or SFDC_SECURITY_TOKEN. Neither is
+saved; when the session expires, sfdc logs in again if SFDC_PASSWORD is
+set, and otherwise asks for a new login.
+
+With --from-sf, an org already authorized in the official sf CLI is
+imported by its sf alias or username: the refresh token is read from the sf
+CLI's auth files (decrypting it with the sf CLI's key where needed), checked
+against the org, and stored in the keychain. The sf alias is used as the org
+profile name unless --alias is given.
+
+Use --alias to save the login as a named org profile.
+
+Examples:
+  sfdc auth login
+  sfdc auth login --device --alias dev1
+  sfdc auth login --jwt-key server.key --client-id <key> --username ci@example.com
+  sfdc auth login --jwt-key server.key --client-id <key> --username ci@example.com.uat \
+    --instance-url test.salesforce.com --alias uat
+  sfdc auth login --client-credentials --client-id <key> --client-secret <secret> \
+    --instance-url mycompany.my.salesforce.com
+  SFDC_PASSWORD=<password> SFDC_SECURITY_TOKEN=<token> sfdc auth login \
+    --username-password --username admin@example.com.uat --instance-url test.salesforce.com
+  sfdc auth login --from-sf myorg`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runLogin(cmd.Context(), opts, flags)
+		},
+	}
+
+	cmd.Flags().StringVar(&flags.instanceURL, "instance-url", "", "Salesforce login or My Domain URL (default: login.salesforce.com)")
+	cmd.Flags().StringVar(&flags.clientID, "client-id", "", "Connected App Consumer Key")
+	cmd.Flags().StringVar(&flags.username, "username", "", "Salesforce username to log in as")
+	cmd.Flags().StringVar(&flags.jwtKey, "jwt-key", "", "Private key file for the JWT bearer flow")
+	cmd.Flags().StringVar(&flags.audience, "audience", "", "JWT audience (default: login or test.salesforce.com)")
+	cmd.Flags().BoolVar(&flags.clientCredentials, "client-credentials", false, "Use the client credentials flow")
+	cmd.Flags().StringVar(&flags.clientSecret, "client-secret", "", "Connected App Consumer Secret (default: SFDC_CLIENT_SECRET)")
+	cmd.Flags().BoolVar(&flags.usernamePassword, "username-password", false, "Log in with a username, password, and security token (discouraged)")
+	cmd.Flags().StringVar(&flags.password, "password", "", "Password for --username-password (default: SFDC_PASSWORD)")
+	cmd.Flags().StringVar(&flags.securityToken, "security-token", "", "Security token for --username-password (default: SFDC_SECURITY_TOKEN)")
+	cmd.Flags().StringVar(&flags.fromSF, "from-sf", "", "Import an org authorized in the sf CLI, by alias or username")
+	cmd.Flags().StringVar(&flags.alias, "alias", "", "Save the login as a named org profile")
+	cmd.Flags().BoolVar(&flags.device, "device", false, "Log in with a code entered on another device (browser login only)")
+	cmd.Flags().BoolVar(&flags.noVerify, "no-verify", false, "Skip connectivity verification (browser login only)")
+	cmd.Flags().IntVar(&flags.callbackPort, "callback-port", auth.DefaultCallbackPort, "Localhost port of the OAuth callback URL (browser login only)")
+
+	return cmd
+}
+
+func runLogin(ctx context.Context, opts *root.Options, flags loginFlags) error {
+	flows := 0
+	for _, set := range []bool{flags.jwtKey != "", flags.clientCredentials, flags.usernamePassword} {
+		if set {
+			flows++
+		}
+	}
+
+	if flags.device && (flows > 0 || flags.fromSF != "") {
+		return fmt.Errorf("--device cannot be used with --jwt-key, --client-credentials, --username-password, or --from-sf")
+	}
+
+	if flags.fromSF != "" {
+		if flows > 0 {
+			return fmt.Errorf("--from-sf cannot be used with --jwt-key, --client-credentials, or --username-password")
+		}
+		return loginFromSF(ctx, opts, flags)
+	}
+
+	if flows == 0 {
+		return initcmd.Login(ctx, initcmd.LoginOptions{
+			InstanceURL:  flags.instanceURL,
+			ClientID:     flags.clientID,
+			Alias:        flags.alias,
+			Device:       flags.device,
+			NoVerify:     flags.noVerify,
+			CallbackPort: flags.callbackPort,
+		})
+	}
+	if flows > 1 {
+		return fmt.Errorf("--jwt-key, --client-credentials, and --username-password cannot be used together")
+	}
+	if flags.clientID == "" && !flags.usernamePassword {
+		return fmt.Errorf("--client-id is required")
+	}
+	if flags.jwtKey != "" && flags.username == "" {
+		return fmt.Errorf("--username is required with --jwt-key")
+	}
+	if flags.usernamePassword && flags.username == "" {
+		return fmt.Errorf("--username is required with --username-password")
+	}
+
+	if flags.alias != "" {
+		if err := config.ValidateOrgAlias(flags.alias); err != nil {
+			return err
+		}
+		config.SetOrg(flags.alias)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	// SFDC_CLIENT_SECRET is applied by Load; logging in again reuses the
+	// stored secret
+	clientSecret := flags.clientSecret
+	if clientSecret == "" {
+		clientSecret = auth.ClientSecret(cfg)
+	}
+
+	cfg.InstanceURL = flags.instanceURL
+	if cfg.InstanceURL == "" {
+		cfg.InstanceURL = "login.salesforce.com"
+	}
+	cfg.ClientID = flags.clientID
+	cfg.ClientSecret = ""
+	cfg.Username = ""
+	cfg.JWTKeyFile = ""
+	cfg.JWTAudience = ""
+
+	var token *oauth2.Token
+	switch {
+	case flags.clientCredentials:
+		token, err = loginClientCredentials(ctx, cfg, clientSecret)
+	case flags.usernamePassword:
+		token, err = loginPassword(ctx, opts, cfg, flags)
+	default:
+		token, err = loginJWT(ctx, cfg, flags)
+	}
+	if err != nil {
+		return err
+	}
+
+	// API requests must go to the org's own instance, not the login URL
+	if instanceURL, ok := token.Extra("instance_url").(string); ok && instanceURL != "" {
+		cfg.InstanceURL = instanceURL
+	}
+
+	if flags.alias != "" && !cfg.HasDefault() {
+		cfg.DefaultOrg = flags.alias
+	}
+	if err := config.Save(cfg); err != nil {
+		return fmt.Errorf("failed to save configuration: %w", err)
+	}
+
+	if cfg.AuthFlow == config.AuthFlowClientCredentials || cfg.AuthFlow == config.AuthFlowPassword {
+		if err := keychain.SetToken(token); err != nil {
+			return fmt.Errorf("failed to save token: %w", err)
+		}
+	}
+	// The secret is kept with the tokens, never in the config file
+	if cfg.AuthFlow == config.AuthFlowClientCredentials {
+		err = keychain.SetClientSecret(clientSecret)
+	} else {
+		err = keychain.DeleteClientSecret()
+	}
+	if err != nil {
+		return fmt.Errorf("failed to save client secret: %w", err)
+	}
+
+	v := opts.View()
+	if opts.Output == "json" {
+		return v.JSON(map[string]interface{}{
+			"org":         cfg.OrgAlias(),
+			"instanceUrl": cfg.InstanceURL,
+			"username":    cfg.Username,
+			"authFlow":    cfg.AuthFlow,
+		})
+	}
+
+	if cfg.Username != "" {
+		v.Success("Logged in as %s to %s", cfg.Username, cfg.InstanceURL)
+	} else {
+		v.Success("Logged in to %s", cfg.InstanceURL)
+	}
+	if flags.alias != "" && cfg.DefaultOrg != flags.alias {
+		v.Info("Use --org %s to run commands against this org, or 'sfdc config use-org %s' to make it the default.", flags.alias, flags.alias)
+	}
+	return nil
+}
+
+// loginJWT sets up cfg for the JWT bearer flow and requests a token.
+func loginJWT(ctx context.Context, cfg *config.Config, flags loginFlags) (*oauth2.Token, error) {
+	keyFile, err := filepath.Abs(flags.jwtKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve key path: %w", err)
+	}
+
+	cfg.AuthFlow = config.AuthFlowJWT
+	cfg.Username = flags.username
+	cfg.JWTKeyFile = keyFile
+	cfg.JWTAudience = flags.audience
+	if cfg.JWTAudience == "" {
+		cfg.JWTAudience = auth.JWTAudience(cfg.InstanceURL)
+	}
+
+	jwtConfig, err := auth.JWTConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	token, err := auth.ExchangeJWT(ctx, jwtConfig)
+	if err != nil {
+		return nil, fmt.Errorf("JWT login failed: %w", err)
+	}
+	return token, nil
+}
+
+// loginClientCredentials sets up cfg for the client credentials flow and
+// requests a token.
+func loginClientCredentials(ctx context.Context, cfg *config.Config, clientSecret string) (*oauth2.Token, error) {
+	if clientSecret == "" {
+		return nil, fmt.Errorf("--client-secret or SFDC_CLIENT_SECRET is required with --client-credentials")
+	}
+	if auth.IsProductionURL(cfg.InstanceURL) || auth.IsSandboxURL(cfg.InstanceURL) {
+		return nil, fmt.Errorf("the client credentials flow requires the org's My Domain URL as --instance-url")
+	}
+
+	cfg.AuthFlow = config.AuthFlowClientCredentials
+	cfg.ClientSecret = clientSecret
+
+	ccConfig, err := auth.ClientCredentialsConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	token, err := auth.ExchangeClientCredentials(ctx, ccConfig)
+	if err != nil {
+		return nil, fmt.Errorf("client credentials login failed: %w", err)
+	}
+	return token, nil
+}
+
+// loginPassword sets up cfg for the password flow and logs in.
+func loginPassword(ctx context.Context, opts *root.Options, cfg *config.Config, flags loginFlags) (*oauth2.Token, error) {
+	// SFDC_PASSWORD and SFDC_SECURITY_TOKEN are applied by Load
+	password := flags.password
+	if password == "" {
+		password = cfg.Password
+	}
+	securityToken := flags.securityToken
+	if securityToken == "" {
+		securityToken = cfg.SecurityToken
+	}
+	if password == "" {
+		return nil, fmt.Errorf("--password or SFDC_PASSWORD is required with --username-password")
+	}
+
+	opts.View().Warning("The username-password flow is discouraged; use the JWT bearer flow where a Connected App is available")
+
+	cfg.AuthFlow = config.AuthFlowPassword
+	cfg.Username = flags.username
+
+	token, err := auth.LoginPassword(ctx, cfg.InstanceURL, cfg.Username, password, securityToken)
+	if err != nil {
+		return nil, fmt.Errorf("username-password login failed: %w", err)
+	}
+	return token, nil
+}
+
+// loginFromSF imports an org authorized in the sf CLI.
+func loginFromSF(ctx context.Context, opts *root.Options, flags loginFlags) error {
+	info, err := sfcli.LoadAuth(flags.fromSF)
+	if err != nil {
+		return err
+	}
+	if info.RefreshToken == "" {
+		return fmt.Errorf("the sf CLI has no refresh token for %s (orgs authorized with a JWT or access token cannot be imported)", info.Username)
+	}
+
+	alias := flags.alias
+	if alias == "" && config.ValidateOrgAlias(info.Alias) == nil {
+		alias = info.Alias
+	}
+
+	cfg, err := importRefreshToken(ctx, &auth.SFDXAuthURL{
+		ClientID:     info.ClientID,
+		ClientSecret: info.ClientSecret,
+		RefreshToken: info.RefreshToken,
+		InstanceURL:  info.InstanceURL,
+	}, alias)
+	if err != nil {
+		return err
+	}
+
+	v := opts.View()
+	if opts.Output == "json" {
+		return v.JSON(map[string]interface{}{
+			"org":         cfg.OrgAlias(),
+			"instanceUrl": cfg.InstanceURL,
+			"username":    info.Username,
+		})
+	}
+
+	v.Success("Imported %s from the sf CLI (%s)", info.Username, cfg.InstanceURL)
+	if alias != "" && cfg.DefaultOrg != alias {
+		v.Info("Use --org %s to run commands against this org, or 'sfdc config use-org %s' to make it the default.", alias, alias)
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package authcmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/salesforce-cli/internal/auth"
+	"github.com/open-cli-collective/salesforce-cli/internal/cmd/root"
+	"github.com/open-cli-collective/salesforce-cli/internal/config"
+	"github.com/open-cli-collective/salesforce-cli/internal/keychain"
+)
+
+func newLogoutCommand(opts *root.Options) *cobra.Command {
+	var noRevoke bool
+
+	cmd := &cobra.Command{
+		Use:   "logout",
+		Short: "Revoke and delete the stored token",
+		Long: `Log out of the current org: the stored token is revoked at Salesforce,
+which also ends the sessions issued with it, and deleted from the keychain.
+
+The org's settings are kept, so 'sfdc auth login' can log in again; use
+'sfdc config clear' to remove them too. If the token cannot be revoked
+(e.g. the org is unreachable), it is deleted anyway with a warning.
+
+Examples:
+  sfdc auth logout
+  sfdc auth logout --org uat
+  sfdc auth logout --no-revoke`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runLogout(cmd.Context(), opts, noRevoke)
+		},
+	}
+
+	cmd.Flags().BoolVar(&noRevoke, "no-revoke", false, "Delete the stored token without revoking it")
+
+	return cmd
+}
+
+func runLogout(ctx context.Context, opts *root.Options, noRevoke bool) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	v := opts.View()
+
+	token, err := keychain.GetToken()
+	if err != nil {
+		if opts.Output == "json" {
+			return v.JSON(map[string]interface{}{
+				"org":      cfg.OrgAlias(),
+				"loggedIn": false,
+			})
+		}
+		v.Info("No stored token - already logged out")
+		return nil
+	}
+
+	revoked := false
+	if !noRevoke && cfg.InstanceURL != "" {
+		// Revoking the refresh token also revokes its access tokens
+		revoke := token.RefreshToken
+		if revoke == "" {
+			revoke = token.AccessToken
+		}
+		if err := auth.RevokeToken(ctx, cfg.InstanceURL, revoke); err != nil {
+			v.Warning("Could not revoke token, deleting it anyway: %v", err)
+		} else {
+			revoked = true
+		}
+	}
+
+	if err := keychain.DeleteToken(); err != nil {
+		return fmt.Errorf("failed to delete token: %w", err)
+	}
+
+	if opts.Output == "json" {
+		return v.JSON(map[string]interface{}{
+			"org":         cfg.OrgAlias(),
+			"instanceUrl": cfg.InstanceURL,
+			"loggedIn":    false,
+			"revoked":     revoked,
+		})
+	}
+
+	v.Success("Logged out of %s", cfg.InstanceURL)
+	return nil
+}
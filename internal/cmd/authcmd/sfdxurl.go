@@ -0,0 +1,255 @@
+package authcmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/salesforce-cli/internal/auth"
+	"github.com/open-cli-collective/salesforce-cli/internal/cmd/root"
+	"github.com/open-cli-collective/salesforce-cli/internal/config"
+	"github.com/open-cli-collective/salesforce-cli/internal/keychain"
+)
+
+// importFlags holds the flags of the import-sfdx-url command.
+type importFlags struct {
+	file  string
+	alias string
+}
+
+func newImportSFDXURLCommand(opts *root.Options) *cobra.Command {
+	var flags importFlags
+
+	cmd := &cobra.Command{
+		Use:   "import-sfdx-url [url]",
+		Short: "Log in with an SFDX auth URL from the sf CLI",
+		Long: `Log in with an SFDX auth URL, as printed by 'sf org display --verbose'.
+
+The URL has the form force://<clientId>:<clientSecret>:<refreshToken>@<instance>
+and is read from the argument, or from --file ("-" for stdin). The file may
+also be the JSON output of 'sf org display --verbose --json'.
+
+The refresh token is checked against the org before anything is saved, and
+is then stored in the keychain like a token from 'sfdc init'. Pass the URL
+with --file rather than as an argument to keep it out of shell history.
+
+Examples:
+  sf org display --target-org myorg --verbose --json > auth.json
+  sfdc auth import-sfdx-url --file auth.json
+  echo "$SFDX_AUTH_URL" | sfdc auth import-sfdx-url --file - --alias ci`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var url string
+			if len(args) > 0 {
+				url = args[0]
+			}
+			return runImportSFDXURL(cmd.Context(), opts, url, flags)
+		},
+	}
+
+	cmd.Flags().StringVar(&flags.file, "file", "", "File containing the auth URL (\"-\" for stdin)")
+	cmd.Flags().StringVar(&flags.alias, "alias", "", "Save the login as a named org profile")
+
+	return cmd
+}
+
+func runImportSFDXURL(ctx context.Context, opts *root.Options, url string, flags importFlags) error {
+	if (url == "") == (flags.file == "") {
+		return fmt.Errorf("provide the auth URL as an argument or with --file")
+	}
+
+	if flags.file != "" {
+		var err error
+		url, err = readSFDXAuthURL(opts, flags.file)
+		if err != nil {
+			return err
+		}
+	}
+
+	authURL, err := auth.ParseSFDXAuthURL(url)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := importRefreshToken(ctx, authURL, flags.alias)
+	if err != nil {
+		return err
+	}
+
+	v := opts.View()
+	if opts.Output == "json" {
+		return v.JSON(map[string]interface{}{
+			"org":         cfg.OrgAlias(),
+			"instanceUrl": cfg.InstanceURL,
+			"clientId":    cfg.ClientID,
+		})
+	}
+
+	v.Success("Logged in to %s", cfg.InstanceURL)
+	if flags.alias != "" && cfg.DefaultOrg != flags.alias {
+		v.Info("Use --org %s to run commands against this org, or 'sfdc config use-org %s' to make it the default.", flags.alias, flags.alias)
+	}
+	return nil
+}
+
+// importRefreshToken validates the refresh token of authURL against the org
+// and saves it as the login, under alias if set.
+func importRefreshToken(ctx context.Context, authURL *auth.SFDXAuthURL, alias string) (*config.Config, error) {
+	if alias != "" {
+		if err := config.ValidateOrgAlias(alias); err != nil {
+			return nil, err
+		}
+		config.SetOrg(alias)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	oauthConfig := auth.GetOAuthConfig(authURL.InstanceURL, authURL.ClientID)
+	oauthConfig.ClientSecret = authURL.ClientSecret
+
+	token, err := auth.ExchangeRefreshToken(ctx, oauthConfig, authURL.RefreshToken)
+	if err != nil {
+		return nil, fmt.Errorf("failed to validate refresh token: %w", err)
+	}
+	// Salesforce does not return the refresh token again on refresh
+	if token.RefreshToken == "" {
+		token.RefreshToken = authURL.RefreshToken
+	}
+
+	cfg.InstanceURL = authURL.InstanceURL
+	if instanceURL, ok := token.Extra("instance_url").(string); ok && instanceURL != "" {
+		cfg.InstanceURL = instanceURL
+	}
+	cfg.ClientID = authURL.ClientID
+	cfg.AuthFlow = ""
+	cfg.Username = ""
+	cfg.JWTKeyFile = ""
+	cfg.JWTAudience = ""
+
+	if alias != "" && !cfg.HasDefault() {
+		cfg.DefaultOrg = alias
+	}
+	if err := config.Save(cfg); err != nil {
+		return nil, fmt.Errorf("failed to save configuration: %w", err)
+	}
+	if err := keychain.SetToken(token); err != nil {
+		return nil, fmt.Errorf("failed to save token: %w", err)
+	}
+	// An empty secret removes the one of an earlier login
+	if err := keychain.SetClientSecret(authURL.ClientSecret); err != nil {
+		return nil, fmt.Errorf("failed to save client secret: %w", err)
+	}
+
+	return cfg, nil
+}
+
+// readSFDXAuthURL reads an auth URL from a file, or from stdin if path is
+// "-". JSON from 'sf org display --verbose --json' is also accepted.
+func readSFDXAuthURL(opts *root.Options, path string) (string, error) {
+	var (
+		data []byte
+		err  error
+	)
+	if path == "-" {
+		data, err = io.ReadAll(opts.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read auth URL: %w", err)
+	}
+
+	content := strings.TrimSpace(string(data))
+	if !strings.HasPrefix(content, "{") {
+		return content, nil
+	}
+
+	var display struct {
+		SFDXAuthURL string `json:"sfdxAuthUrl"`
+		Result      struct {
+			SFDXAuthURL string `json:"sfdxAuthUrl"`
+		} `json:"result"`
+	}
+	if err := json.Unmarshal(data, &display); err != nil {
+		return "", fmt.Errorf("failed to parse auth file: %w", err)
+	}
+	if display.Result.SFDXAuthURL != "" {
+		return display.Result.SFDXAuthURL, nil
+	}
+	if display.SFDXAuthURL != "" {
+		return display.SFDXAuthURL, nil
+	}
+	return "", fmt.Errorf("no sfdxAuthUrl found in %s", path)
+}
+
+func newExportSFDXURLCommand(opts *root.Options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export-sfdx-url",
+		Short: "Print the current login as an SFDX auth URL",
+		Long: `Print the current login as an SFDX auth URL, for use with
+'sf org login sfdx-url' or as a CI secret.
+
+Only logins with a refresh token can be exported: those from 'sfdc init' or
+'sfdc auth import-sfdx-url'. The URL grants access to the org, so treat it
+like a password.
+
+Examples:
+  sfdc auth export-sfdx-url > auth.txt
+  sf org login sfdx-url --sfdx-url-file auth.txt --alias myorg
+  sfdc auth export-sfdx-url --org uat`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runExportSFDXURL(opts)
+		},
+	}
+
+	return cmd
+}
+
+func runExportSFDXURL(opts *root.Options) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	if cfg.InstanceURL == "" || cfg.ClientID == "" {
+		return fmt.Errorf("not configured - please run 'sfdc init' first")
+	}
+	if cfg.AuthFlow != "" {
+		return fmt.Errorf("logins with the %s flow have no refresh token and cannot be exported", cfg.AuthFlow)
+	}
+
+	token, err := keychain.GetToken()
+	if err != nil {
+		return fmt.Errorf("no OAuth token found - please run 'sfdc init' first: %w", err)
+	}
+	if token.RefreshToken == "" {
+		return fmt.Errorf("the stored token has no refresh token - please run 'sfdc init' again")
+	}
+
+	authURL := &auth.SFDXAuthURL{
+		ClientID:     cfg.ClientID,
+		ClientSecret: auth.ClientSecret(cfg),
+		RefreshToken: token.RefreshToken,
+		InstanceURL:  cfg.InstanceURL,
+	}
+
+	v := opts.View()
+	if opts.Output == "json" {
+		return v.JSON(map[string]interface{}{
+			"org":         cfg.OrgAlias(),
+			"instanceUrl": cfg.InstanceURL,
+			"sfdxAuthUrl": authURL.String(),
+		})
+	}
+
+	v.Println("%s", authURL.String())
+	return nil
+}
@@ -0,0 +1,68 @@
+package authcmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/salesforce-cli/internal/auth"
+	"github.com/open-cli-collective/salesforce-cli/internal/cmd/root"
+	"github.com/open-cli-collective/salesforce-cli/internal/config"
+)
+
+func newTokenCommand(opts *root.Options) *cobra.Command {
+	var refresh bool
+
+	cmd := &cobra.Command{
+		Use:   "token",
+		Short: "Print the access token of the current org",
+		Long: `Print the access token of the current org, for use by scripts and other
+tools. The token is refreshed first if it has expired; use --refresh to
+request a new one regardless, e.g. after Salesforce rejected it.
+
+The JSON output also includes the instance URL to send requests to. The
+token grants access to the org, so avoid logging it.
+
+Examples:
+  curl -H "Authorization: Bearer $(sfdc auth token)" \
+    https://mycompany.my.salesforce.com/services/data/
+  sfdc auth token --refresh
+  sfdc auth token --org uat -o json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runToken(cmd.Context(), opts, refresh)
+		},
+	}
+
+	cmd.Flags().BoolVar(&refresh, "refresh", false, "Request a new token even if the stored one has not expired")
+
+	return cmd
+}
+
+func runToken(ctx context.Context, opts *root.Options, refresh bool) error {
+	token, err := auth.Token(ctx, refresh)
+	if err != nil {
+		return fmt.Errorf("failed to get token: %w", err)
+	}
+
+	v := opts.View()
+	if opts.Output == "json" {
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load configuration: %w", err)
+		}
+		result := map[string]interface{}{
+			"org":         cfg.OrgAlias(),
+			"instanceUrl": cfg.InstanceURL,
+			"accessToken": token.AccessToken,
+		}
+		if !token.Expiry.IsZero() {
+			result["expiresAt"] = token.Expiry
+		}
+		return v.JSON(result)
+	}
+
+	v.Println("%s", token.AccessToken)
+	return nil
+}
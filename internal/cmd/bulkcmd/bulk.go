@@ -4,6 +4,7 @@ package bulkcmd
 import (
 	"github.com/spf13/cobra"
 
+	"github.com/open-cli-collective/salesforce-cli/api/bulk"
 	"github.com/open-cli-collective/salesforce-cli/internal/cmd/root"
 )
 
@@ -18,15 +19,35 @@ Use bulk operations when working with thousands or millions of records.
 For smaller datasets, use the record command instead.
 
 Examples:
+  sfdc bulk validate Account --file accounts.csv
   sfdc bulk import Account --file accounts.csv --operation insert
   sfdc bulk export "SELECT Id, Name FROM Account" --output accounts.csv
+  sfdc bulk delete Account --file ids.csv
+  sfdc bulk retry 750xx000000001 --wait
+  sfdc bulk resume
   sfdc bulk job list
   sfdc bulk job status 750xx000000001`,
 	}
 
+	cmd.AddCommand(newValidateCommand(opts))
 	cmd.AddCommand(newImportCommand(opts))
 	cmd.AddCommand(newExportCommand(opts))
+	cmd.AddCommand(newDeleteCommand(opts))
+	cmd.AddCommand(newRetryCommand(opts))
+	cmd.AddCommand(newResumeCommand(opts))
 	cmd.AddCommand(newJobCommand(opts))
 
 	parent.AddCommand(cmd)
 }
+
+// pollInterval is how often commands check on jobs; tests shorten it.
+var pollInterval = bulk.DefaultPollConfig().Interval
+
+// pollConfig returns how commands poll jobs: every pollInterval, for up to
+// --wait-timeout.
+func pollConfig(opts *root.Options) bulk.PollConfig {
+	return bulk.PollConfig{
+		Interval: pollInterval,
+		Timeout:  opts.WaitTimeout,
+	}
+}
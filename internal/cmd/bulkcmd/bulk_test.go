@@ -2,20 +2,47 @@ package bulkcmd
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"path"
 	"path/filepath"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
+	parquet "github.com/parquet-go/parquet-go"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
 
+	"github.com/open-cli-collective/salesforce-cli/api"
 	"github.com/open-cli-collective/salesforce-cli/api/bulk"
+	"github.com/open-cli-collective/salesforce-cli/api/bulkv1"
 	"github.com/open-cli-collective/salesforce-cli/internal/cmd/root"
+	"github.com/open-cli-collective/salesforce-cli/internal/config"
+	"github.com/open-cli-collective/salesforce-cli/internal/keychain"
 )
 
+func TestMain(m *testing.M) {
+	// Imports record their open jobs in the config directory; keep the
+	// records of tests out of the real one
+	home, err := os.MkdirTemp("", "sfdc-bulk-test")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	os.Setenv(config.HomeEnvVar, home)
+	code := m.Run()
+	os.RemoveAll(home)
+	os.Exit(code)
+}
+
 func TestImportCommand(t *testing.T) {
 	expectedJob := bulk.JobInfo{
 		ID:        "750xx000000001",
@@ -67,7 +94,7 @@ func TestImportCommand(t *testing.T) {
 	opts.SetBulkClient(client)
 
 	cmd := newImportCommand(opts)
-	cmd.SetArgs([]string{"Account", "--file", csvFile, "--operation", "insert"})
+	cmd.SetArgs([]string{"Account", "--file", csvFile, "--operation", "insert", "--skip-validation"})
 	cmd.SetOut(stdout)
 	cmd.SetErr(stderr)
 
@@ -79,6 +106,117 @@ func TestImportCommand(t *testing.T) {
 	assert.Contains(t, output, "750xx000000001")
 }
 
+func TestImportCommand_ValidateHeaders(t *testing.T) {
+	describe := api.SObjectDescribe{
+		Name: "Contact",
+		Fields: []api.Field{
+			{Name: "Id", Createable: false},
+			{Name: "LastName", Createable: true},
+			{Name: "AccountId", Createable: true, RelationshipName: "Account"},
+			{Name: "OwnerId", Createable: true, DefaultedOnCreate: true},
+			{Name: "Email", Createable: true, Nillable: true},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		csv         string
+		strict      bool
+		wantErr     string
+		wantJob     bool
+		wantWarning string
+	}{
+		{
+			name:    "all required fields present",
+			csv:     "LastName,Account.External_Id__c\nDoe,A-1",
+			wantJob: true,
+		},
+		{
+			name:        "missing field warns",
+			csv:         "Email\ndoe@example.com",
+			wantJob:     true,
+			wantWarning: "missing required fields for Contact: LastName, AccountId",
+		},
+		{
+			name:    "missing field fails in strict mode",
+			csv:     "Email\ndoe@example.com",
+			strict:  true,
+			wantErr: "missing required fields for Contact: LastName, AccountId",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jobCreated := false
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+
+				switch {
+				case r.URL.Path == "/services/data/v62.0/sobjects/Contact/describe":
+					_ = json.NewEncoder(w).Encode(describe)
+				case r.Method == http.MethodPost && r.URL.Path == "/services/data/v62.0/jobs/ingest":
+					jobCreated = true
+					_ = json.NewEncoder(w).Encode(bulk.JobInfo{ID: "750xx000000001", State: bulk.StateOpen})
+				case r.Method == http.MethodPut:
+					w.WriteHeader(http.StatusCreated)
+				case r.Method == http.MethodPatch:
+					_ = json.NewEncoder(w).Encode(bulk.JobInfo{ID: "750xx000000001", State: bulk.StateUploadComplete})
+				}
+			}))
+			defer server.Close()
+
+			apiClient, err := api.New(api.ClientConfig{
+				InstanceURL: server.URL,
+				HTTPClient:  server.Client(),
+			})
+			require.NoError(t, err)
+
+			bulkClient, err := bulk.New(bulk.ClientConfig{
+				InstanceURL: server.URL,
+				HTTPClient:  server.Client(),
+			})
+			require.NoError(t, err)
+
+			csvFile := filepath.Join(t.TempDir(), "contacts.csv")
+			require.NoError(t, os.WriteFile(csvFile, []byte(tt.csv), 0644))
+
+			stdout := &bytes.Buffer{}
+			stderr := &bytes.Buffer{}
+			opts := &root.Options{
+				Output:  "table",
+				NoColor: true,
+				Stdout:  stdout,
+				Stderr:  stderr,
+			}
+			opts.SetAPIClient(apiClient)
+			opts.SetBulkClient(bulkClient)
+
+			args := []string{"Contact", "--file", csvFile}
+			if tt.strict {
+				args = append(args, "--strict")
+			}
+
+			cmd := newImportCommand(opts)
+			cmd.SetArgs(args)
+			cmd.SetOut(stdout)
+			cmd.SetErr(stderr)
+
+			err = cmd.Execute()
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.wantJob, jobCreated)
+			if tt.wantWarning != "" {
+				assert.Contains(t, stderr.String(), tt.wantWarning)
+			}
+		})
+	}
+}
+
 func TestImportCommand_UpsertRequiresExternalID(t *testing.T) {
 	stdout := &bytes.Buffer{}
 	stderr := &bytes.Buffer{}
@@ -159,6 +297,68 @@ func TestExportCommand(t *testing.T) {
 	assert.Contains(t, output, "Acme")
 }
 
+func TestExportCommand_WaitOnRateLimit(t *testing.T) {
+	// The clients are built from a saved login, with the CLI's retry policy
+	config.SetConfigDir(t.TempDir())
+	t.Cleanup(func() { config.SetConfigDir("") })
+	t.Setenv(config.OrgEnvVar, "")
+	t.Setenv("SFDC_INSTANCE_URL", "")
+	t.Setenv("SFDC_CLIENT_ID", "")
+
+	job := bulk.QueryJobInfo{
+		ID:        "750xx000000001",
+		Operation: bulk.OperationQuery,
+		State:     bulk.StateJobComplete,
+	}
+
+	var creates int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/services/data/v62.0/jobs/query":
+			creates++
+			if creates == 1 {
+				w.Header().Set("Retry-After", "0")
+				w.WriteHeader(http.StatusTooManyRequests)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(job)
+		case r.Method == http.MethodGet && r.URL.Path == "/services/data/v62.0/jobs/query/750xx000000001":
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(job)
+		case r.Method == http.MethodGet && r.URL.Path == "/services/data/v62.0/jobs/query/750xx000000001/results":
+			w.Header().Set("Content-Type", "text/csv")
+			_, _ = w.Write([]byte("Id,Name\n001xx000001,Acme\n"))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	require.NoError(t, config.Save(&config.Config{InstanceURL: server.URL, ClientID: "client-id"}))
+	require.NoError(t, keychain.SetToken(&oauth2.Token{
+		AccessToken:  "access-token",
+		RefreshToken: "refresh-token",
+		Expiry:       time.Now().Add(time.Hour),
+	}))
+
+	stdout := &bytes.Buffer{}
+	opts := &root.Options{
+		Output: "table",
+		Stdout: stdout,
+		Stderr: &bytes.Buffer{},
+	}
+
+	cmd := newExportCommand(opts)
+	cmd.SetArgs([]string{"SELECT Id, Name FROM Account", "--wait-on-rate-limit"})
+
+	err := cmd.Execute()
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, creates)
+	assert.Contains(t, stdout.String(), "Acme")
+}
+
 func TestJobListCommand(t *testing.T) {
 	expected := bulk.JobsResponse{
 		Done: true,
@@ -283,6 +483,42 @@ func TestJobAbortCommand(t *testing.T) {
 	assert.Contains(t, output, "aborted")
 }
 
+func TestJobAbortCommand_DryRun(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request in dry run: %s %s", r.Method, r.URL.Path)
+	}))
+	defer server.Close()
+
+	client, err := bulk.New(bulk.ClientConfig{
+		InstanceURL: server.URL,
+		HTTPClient:  server.Client(),
+	})
+	require.NoError(t, err)
+
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	opts := &root.Options{
+		Output:  "table",
+		NoColor: true,
+		DryRun:  true,
+		Stdout:  stdout,
+		Stderr:  stderr,
+	}
+	opts.SetBulkClient(client)
+
+	cmd := newJobAbortCommand(opts)
+	cmd.SetArgs([]string{"750xx000000001"})
+
+	err = cmd.Execute()
+	require.NoError(t, err)
+
+	output := stdout.String()
+	assert.Contains(t, stderr.String(), "Dry run: no changes were made")
+	assert.Contains(t, output, "PATCH "+server.URL+"/services/data/v62.0/jobs/ingest/750xx000000001")
+	assert.Contains(t, output, `"state": "Aborted"`)
+	assert.NotContains(t, output, "aborted")
+}
+
 func TestJobResultsCommand(t *testing.T) {
 	csvData := "sf__Id,sf__Created,Name\n001xx000001,true,Acme"
 
@@ -352,6 +588,46 @@ func TestJobErrorsCommand(t *testing.T) {
 	assert.Contains(t, output, "REQUIRED_FIELD_MISSING")
 }
 
+func TestJobErrorsCommand_Retryable(t *testing.T) {
+	csvData := "\"sf__Id\",\"sf__Error\",Name,Description\n\"\",\"REQUIRED_FIELD_MISSING:Required fields are missing: [Industry]\",Acme,\"Line one, with comma\"\n"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/failedResults") {
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(bulk.JobInfo{ID: "750xx000000001", State: bulk.StateJobComplete})
+			return
+		}
+		w.Header().Set("Content-Type", "text/csv")
+		_, _ = w.Write([]byte(csvData))
+	}))
+	defer server.Close()
+
+	client, err := bulk.New(bulk.ClientConfig{
+		InstanceURL: server.URL,
+		HTTPClient:  server.Client(),
+	})
+	require.NoError(t, err)
+
+	outFile := filepath.Join(t.TempDir(), "retry.csv")
+
+	opts := &root.Options{
+		Output: "table",
+		Stdout: &bytes.Buffer{},
+		Stderr: &bytes.Buffer{},
+	}
+	opts.SetBulkClient(client)
+
+	cmd := newJobErrorsCommand(opts)
+	cmd.SetArgs([]string{"750xx000000001", "--retryable", "--output", outFile})
+
+	err = cmd.Execute()
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(outFile)
+	require.NoError(t, err)
+	assert.Equal(t, "Name,Description\nAcme,\"Line one, with comma\"\n", string(data))
+}
+
 func TestImportCommand_InvalidOperation(t *testing.T) {
 	stdout := &bytes.Buffer{}
 	stderr := &bytes.Buffer{}
@@ -566,3 +842,2073 @@ func TestJobResultsCommand_ToFile(t *testing.T) {
 	assert.Contains(t, string(data), "Acme")
 	assert.Contains(t, stdout.String(), "Results written to")
 }
+
+func TestPrepareDeleteData(t *testing.T) {
+	tests := []struct {
+		name        string
+		data        string
+		want        string
+		wantErr     string
+		wantWarning string
+	}{
+		{
+			name: "id column only",
+			data: "Id\n001xx000003DGbYAAW\n",
+			want: "Id\n001xx000003DGbYAAW\n",
+		},
+		{
+			name:        "extra columns warn",
+			data:        "Id,Name\n001xx000003DGbYAAW,Acme\n",
+			want:        "Id,Name\n001xx000003DGbYAAW,Acme\n",
+			wantWarning: "ignores columns other than Id for delete: Name",
+		},
+		{
+			name: "plain id list",
+			data: "001xx000003DGbYAAW\n001xx000003DGbZ\n",
+			want: "Id\n001xx000003DGbYAAW\n001xx000003DGbZ\n",
+		},
+		{
+			name: "single id",
+			data: "001xx000003DGbYAAW\n",
+			want: "Id\n001xx000003DGbYAAW\n",
+		},
+		{
+			name:    "missing id column",
+			data:    "Name\nAcme\n",
+			wantErr: "requires an Id column",
+		},
+		{
+			name:    "id-shaped header",
+			data:    "AccountNumber15\nAN-0001\nAN-0002\n",
+			wantErr: "requires an Id column",
+		},
+		{
+			name:    "id-shaped header over ids",
+			data:    "AccountNumber15\n001xx000003DGbYAAW\n",
+			wantErr: "requires an Id column",
+		},
+		{
+			name:    "ids of different objects",
+			data:    "001xx000003DGbYAAW\n003xx000004TmiQAAS\n",
+			wantErr: "requires an Id column",
+		},
+		{
+			name:    "single id without checksum",
+			data:    "001xx000003DGbY\n",
+			wantErr: "requires an Id column",
+		},
+		{
+			name:    "single id with wrong checksum",
+			data:    "001xx000003DGbYAAA\n",
+			wantErr: "requires an Id column",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stderr := &bytes.Buffer{}
+			opts := &root.Options{
+				NoColor: true,
+				Stdout:  &bytes.Buffer{},
+				Stderr:  stderr,
+			}
+
+			got, err := prepareDeleteData(opts, []byte(tt.data), csvFormat{})
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+			if tt.wantWarning != "" {
+				assert.Contains(t, stderr.String(), tt.wantWarning)
+			} else {
+				assert.Empty(t, stderr.String())
+			}
+		})
+	}
+}
+
+func TestIsRecordID(t *testing.T) {
+	assert.True(t, isRecordID("001xx000003DGbY"))
+	assert.True(t, isRecordID("001xx000003DGbYAAW"))
+	assert.True(t, isRecordID("a0B5g00000XyZ12EAF"))
+	assert.False(t, isRecordID("001xx000003DGbYAAA"))
+	assert.False(t, isRecordID("001xx000003DGb"))
+	assert.False(t, isRecordID("001xx-00003DGbY"))
+}
+
+func TestImportCommand_HardDelete(t *testing.T) {
+	var created bulk.CreateJobRequest
+	var uploaded []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.Method {
+		case http.MethodPost:
+			_ = json.NewDecoder(r.Body).Decode(&created)
+			_ = json.NewEncoder(w).Encode(bulk.JobInfo{ID: "750xx000000001", State: bulk.StateOpen})
+		case http.MethodPut:
+			uploaded, _ = io.ReadAll(r.Body)
+			w.WriteHeader(http.StatusCreated)
+		case http.MethodPatch:
+			_ = json.NewEncoder(w).Encode(bulk.JobInfo{ID: "750xx000000001", State: bulk.StateUploadComplete})
+		}
+	}))
+	defer server.Close()
+
+	client, err := bulk.New(bulk.ClientConfig{
+		InstanceURL: server.URL,
+		HTTPClient:  server.Client(),
+	})
+	require.NoError(t, err)
+
+	idFile := filepath.Join(t.TempDir(), "ids.txt")
+	require.NoError(t, os.WriteFile(idFile, []byte("001xx000003DGbYAAW\n"), 0644))
+
+	opts := &root.Options{
+		Output: "table",
+		Stdout: &bytes.Buffer{},
+		Stderr: &bytes.Buffer{},
+	}
+	opts.SetBulkClient(client)
+
+	cmd := newImportCommand(opts)
+	cmd.SetArgs([]string{"Account", "--file", idFile, "--operation", "hardDelete", "--yes"})
+
+	err = cmd.Execute()
+	require.NoError(t, err)
+
+	assert.Equal(t, bulk.OperationHardDelete, created.Operation)
+	assert.Equal(t, "Id\n001xx000003DGbYAAW\n", string(uploaded))
+}
+
+func TestExportCommand_JSON(t *testing.T) {
+	csvData := "\"Id\",\"Name\",\"Phone\"\n\"001xx000001\",\"Acme, Inc.\",\"\"\n\"001xx000002\",\"Test\",\"555-0100\"\n"
+	expectedJob := bulk.QueryJobInfo{
+		ID:                     "750xx000000001",
+		Operation:              bulk.OperationQuery,
+		State:                  bulk.StateJobComplete,
+		NumberRecordsProcessed: 2,
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost:
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(expectedJob)
+		case r.URL.Path == "/services/data/v62.0/jobs/query/750xx000000001":
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(expectedJob)
+		case r.URL.Path == "/services/data/v62.0/jobs/query/750xx000000001/results":
+			w.Header().Set("Content-Type", "text/csv")
+			_, _ = w.Write([]byte(csvData))
+		}
+	}))
+	defer server.Close()
+
+	client, err := bulk.New(bulk.ClientConfig{
+		InstanceURL: server.URL,
+		HTTPClient:  server.Client(),
+	})
+	require.NoError(t, err)
+
+	want := []map[string]interface{}{
+		{"Id": "001xx000001", "Name": "Acme, Inc.", "Phone": nil},
+		{"Id": "001xx000002", "Name": "Test", "Phone": "555-0100"},
+	}
+
+	t.Run("stdout", func(t *testing.T) {
+		stdout := &bytes.Buffer{}
+		opts := &root.Options{
+			Output: "table",
+			Stdout: stdout,
+			Stderr: &bytes.Buffer{},
+		}
+		opts.SetBulkClient(client)
+
+		cmd := newExportCommand(opts)
+		cmd.SetArgs([]string{"SELECT Id, Name, Phone FROM Account", "--format", "json"})
+
+		require.NoError(t, cmd.Execute())
+
+		var got []map[string]interface{}
+		require.NoError(t, json.Unmarshal(stdout.Bytes(), &got))
+		assert.Equal(t, want, got)
+	})
+
+	t.Run("file", func(t *testing.T) {
+		outputFile := filepath.Join(t.TempDir(), "accounts.json")
+		opts := &root.Options{
+			Output: "table",
+			Stdout: &bytes.Buffer{},
+			Stderr: &bytes.Buffer{},
+		}
+		opts.SetBulkClient(client)
+
+		cmd := newExportCommand(opts)
+		cmd.SetArgs([]string{"SELECT Id, Name, Phone FROM Account", "--output", outputFile})
+
+		require.NoError(t, cmd.Execute())
+
+		data, err := os.ReadFile(outputFile)
+		require.NoError(t, err)
+		var got []map[string]interface{}
+		require.NoError(t, json.Unmarshal(data, &got))
+		assert.Equal(t, want, got)
+	})
+
+	t.Run("format name as output", func(t *testing.T) {
+		opts := &root.Options{
+			Output: "table",
+			Stdout: &bytes.Buffer{},
+			Stderr: &bytes.Buffer{},
+		}
+		opts.SetBulkClient(client)
+
+		cmd := newExportCommand(opts)
+		cmd.SetArgs([]string{"SELECT Id, Name, Phone FROM Account", "-o", "json"})
+
+		err := cmd.Execute()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "use --format")
+		_, statErr := os.Stat("json")
+		assert.True(t, os.IsNotExist(statErr))
+	})
+}
+
+func TestImportCommand_DryRun(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request in dry run: %s %s", r.Method, r.URL.Path)
+	}))
+	defer server.Close()
+
+	client, err := bulk.New(bulk.ClientConfig{
+		InstanceURL: server.URL,
+		HTTPClient:  server.Client(),
+	})
+	require.NoError(t, err)
+
+	csvFile := filepath.Join(t.TempDir(), "accounts.csv")
+	require.NoError(t, os.WriteFile(csvFile, []byte("Name,Industry\nAcme,Technology\nGlobex,Energy\n"), 0644))
+
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	opts := &root.Options{
+		Output:  "table",
+		NoColor: true,
+		DryRun:  true,
+		Stdout:  stdout,
+		Stderr:  stderr,
+	}
+	opts.SetBulkClient(client)
+
+	cmd := newImportCommand(opts)
+	cmd.SetArgs([]string{"Account", "--file", csvFile, "--operation", "insert", "--skip-validation"})
+
+	err = cmd.Execute()
+	require.NoError(t, err)
+
+	output := stdout.String()
+	assert.Contains(t, stderr.String(), "Dry run: no changes were made")
+	assert.Contains(t, output, "Operation: bulk insert")
+	assert.Contains(t, output, "POST "+server.URL+"/services/data/v62.0/jobs/ingest")
+	assert.Contains(t, output, "Rows:      2")
+	assert.Contains(t, output, `"operation": "insert"`)
+}
+
+func TestImportCommand_DryRunInvalidCSV(t *testing.T) {
+	client, err := bulk.New(bulk.ClientConfig{
+		InstanceURL: "https://example.my.salesforce.com",
+		HTTPClient:  http.DefaultClient,
+	})
+	require.NoError(t, err)
+
+	csvFile := filepath.Join(t.TempDir(), "accounts.csv")
+	require.NoError(t, os.WriteFile(csvFile, []byte("Name,Industry\nAcme\n"), 0644))
+
+	opts := &root.Options{
+		Output: "table",
+		DryRun: true,
+		Stdout: &bytes.Buffer{},
+		Stderr: &bytes.Buffer{},
+	}
+	opts.SetBulkClient(client)
+
+	cmd := newImportCommand(opts)
+	cmd.SetArgs([]string{"Account", "--file", csvFile, "--operation", "insert", "--skip-validation"})
+
+	err = cmd.Execute()
+	assert.Error(t, err)
+}
+
+func TestRenderCreatedRecordLinks(t *testing.T) {
+	results := "\"sf__Id\",\"sf__Created\",\"Name\"\n" +
+		"\"001xx000001\",\"true\",\"Acme\"\n" +
+		"\"001xx000002\",\"false\",\"Globex\"\n" +
+		"\"001xx000003\",\"true\",\"Initech\"\n"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/services/data/v62.0/jobs/ingest/750xx000000001/successfulResults", r.URL.Path)
+		w.Header().Set("Content-Type", "text/csv")
+		_, _ = w.Write([]byte(results))
+	}))
+	defer server.Close()
+
+	bulkClient, err := bulk.New(bulk.ClientConfig{InstanceURL: server.URL, HTTPClient: server.Client()})
+	require.NoError(t, err)
+	apiClient, err := api.New(api.ClientConfig{InstanceURL: server.URL, HTTPClient: server.Client()})
+	require.NoError(t, err)
+
+	stdout := &bytes.Buffer{}
+	opts := &root.Options{
+		Output: "table",
+		Stdout: stdout,
+		Stderr: &bytes.Buffer{},
+	}
+	opts.SetAPIClient(apiClient)
+
+	job := &bulk.JobInfo{ID: "750xx000000001", NumberRecordsProcessed: 3}
+	err = renderCreatedRecordLinks(context.Background(), opts, bulkClient, job)
+	require.NoError(t, err)
+
+	output := stdout.String()
+	assert.Contains(t, output, "Created records:")
+	assert.Contains(t, output, server.URL+"/001xx000001")
+	assert.Contains(t, output, server.URL+"/001xx000003")
+	assert.NotContains(t, output, "001xx000002")
+}
+
+// fastPolling makes commands poll jobs without waiting.
+func fastPolling(t *testing.T) {
+	t.Helper()
+	interval := pollInterval
+	pollInterval = time.Millisecond
+	t.Cleanup(func() { pollInterval = interval })
+}
+
+func TestDeleteCommand(t *testing.T) {
+	fastPolling(t)
+
+	var created bulk.CreateJobRequest
+	var uploaded []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.Method == http.MethodPost:
+			_ = json.NewDecoder(r.Body).Decode(&created)
+			_ = json.NewEncoder(w).Encode(bulk.JobInfo{ID: "750xx000000001", State: bulk.StateOpen})
+		case r.Method == http.MethodPut:
+			uploaded, _ = io.ReadAll(r.Body)
+			w.WriteHeader(http.StatusCreated)
+		case r.Method == http.MethodPatch:
+			_ = json.NewEncoder(w).Encode(bulk.JobInfo{ID: "750xx000000001", State: bulk.StateUploadComplete})
+		case r.URL.Path == "/services/data/v62.0/jobs/ingest/750xx000000001":
+			_ = json.NewEncoder(w).Encode(bulk.JobInfo{
+				ID:                     "750xx000000001",
+				Object:                 "Account",
+				State:                  bulk.StateJobComplete,
+				NumberRecordsProcessed: 2,
+				NumberRecordsFailed:    1,
+			})
+		case r.URL.Path == "/services/data/v62.0/jobs/ingest/750xx000000001/failedResults":
+			w.Header().Set("Content-Type", "text/csv")
+			_, _ = w.Write([]byte("\"sf__Id\",\"sf__Error\",Id\n\"\",\"ENTITY_IS_DELETED:entity is deleted:--\",001xx000003DGbZAAW\n"))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client, err := bulk.New(bulk.ClientConfig{InstanceURL: server.URL, HTTPClient: server.Client()})
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	idFile := filepath.Join(dir, "ids.txt")
+	require.NoError(t, os.WriteFile(idFile, []byte("001xx000003DGbYAAW\n001xx000003DGbZAAW\n"), 0644))
+	errorsFile := filepath.Join(dir, "failed.csv")
+
+	stdout := &bytes.Buffer{}
+	opts := &root.Options{
+		Output:  "table",
+		NoColor: true,
+		Stdout:  stdout,
+		Stderr:  &bytes.Buffer{},
+	}
+	opts.SetBulkClient(client)
+
+	cmd := newDeleteCommand(opts)
+	cmd.SetArgs([]string{"Account", "--file", idFile, "--hard", "--yes", "--errors-output", errorsFile})
+
+	err = cmd.Execute()
+	assert.EqualError(t, err, "1 of 2 records failed to delete")
+
+	assert.Equal(t, bulk.OperationHardDelete, created.Operation)
+	assert.Equal(t, "Account", created.Object)
+	assert.Equal(t, "Id\n001xx000003DGbYAAW\n001xx000003DGbZAAW\n", string(uploaded))
+
+	output := stdout.String()
+	assert.Contains(t, output, "Deleted: 1")
+	assert.Contains(t, output, "Failed:  1")
+	assert.Contains(t, output, "001xx000003DGbZAAW")
+	assert.Contains(t, output, "ENTITY_IS_DELETED")
+	assert.Contains(t, output, "Failed records written to "+errorsFile)
+
+	data, err := os.ReadFile(errorsFile)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "ENTITY_IS_DELETED")
+}
+
+func TestDeleteCommand_Declined(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+	}))
+	defer server.Close()
+
+	client, err := bulk.New(bulk.ClientConfig{InstanceURL: server.URL, HTTPClient: server.Client()})
+	require.NoError(t, err)
+
+	idFile := filepath.Join(t.TempDir(), "ids.csv")
+	require.NoError(t, os.WriteFile(idFile, []byte("Id\n001xx000003DGbYAAW\n"), 0644))
+
+	stdout := &bytes.Buffer{}
+	opts := &root.Options{
+		Output: "table",
+		Stdin:  strings.NewReader("n\n"),
+		Stdout: stdout,
+		Stderr: &bytes.Buffer{},
+	}
+	opts.SetBulkClient(client)
+
+	cmd := newDeleteCommand(opts)
+	cmd.SetArgs([]string{"Account", "--file", idFile})
+
+	require.NoError(t, cmd.Execute())
+	assert.Contains(t, stdout.String(), "Delete 1 Account records? [y/N]: ")
+	assert.Contains(t, stdout.String(), "Cancelled")
+}
+
+func TestImportCommand_Mapping(t *testing.T) {
+	var uploaded []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.Method {
+		case http.MethodPost:
+			_ = json.NewEncoder(w).Encode(bulk.JobInfo{ID: "750xx000000001", State: bulk.StateOpen})
+		case http.MethodPut:
+			uploaded, _ = io.ReadAll(r.Body)
+			w.WriteHeader(http.StatusCreated)
+		case http.MethodPatch:
+			_ = json.NewEncoder(w).Encode(bulk.JobInfo{ID: "750xx000000001", State: bulk.StateUploadComplete})
+		}
+	}))
+	defer server.Close()
+
+	client, err := bulk.New(bulk.ClientConfig{InstanceURL: server.URL, HTTPClient: server.Client()})
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	csvFile := filepath.Join(dir, "export.csv")
+	require.NoError(t, os.WriteFile(csvFile, []byte("company,since\nAcme,31/12/2019\n"), 0644))
+	mappingFile := filepath.Join(dir, "mapping.json")
+	require.NoError(t, os.WriteFile(mappingFile, []byte(`{"fields": [
+		{"field": "Name", "column": "company"},
+		{"field": "Customer_Since__c", "column": "since", "dateFormat": "DD/MM/YYYY"},
+		{"field": "Type", "value": "Customer"}
+	]}`), 0644))
+
+	opts := &root.Options{
+		Output: "table",
+		Stdout: &bytes.Buffer{},
+		Stderr: &bytes.Buffer{},
+	}
+	opts.SetBulkClient(client)
+
+	cmd := newImportCommand(opts)
+	cmd.SetArgs([]string{"Account", "--file", csvFile, "--mapping", mappingFile, "--skip-validation"})
+
+	require.NoError(t, cmd.Execute())
+	assert.Equal(t, "Name,Customer_Since__c,Type\nAcme,2019-12-31,Customer\n", string(uploaded))
+}
+
+func TestJobProgress(t *testing.T) {
+	jobs := []bulk.JobInfo{
+		{State: bulk.StateUploadComplete},
+		{State: bulk.StateInProgress, NumberRecordsProcessed: 500},
+		{State: bulk.StateInProgress, NumberRecordsProcessed: 500},
+		{State: bulk.StateJobComplete, NumberRecordsProcessed: 1000, NumberRecordsFailed: 3},
+	}
+
+	t.Run("log lines", func(t *testing.T) {
+		out := &bytes.Buffer{}
+		p := &jobProgress{out: out, total: 1000, start: time.Now()}
+		for i := range jobs {
+			p.update(&jobs[i])
+		}
+		p.finish()
+
+		assert.Equal(t, "[0:00] UploadComplete\n"+
+			"[0:00] InProgress: 500 of 1000 records processed (50%)\n"+
+			"[0:00] JobComplete: 1000 of 1000 records processed (100%), 3 failed\n", out.String())
+	})
+
+	t.Run("live", func(t *testing.T) {
+		out := &bytes.Buffer{}
+		p := &jobProgress{out: out, live: true, total: 1000, start: time.Now()}
+		for i := range jobs {
+			p.update(&jobs[i])
+		}
+		p.finish()
+
+		output := out.String()
+		assert.Contains(t, output, "\r\033[K[0:00] UploadComplete\n")
+		assert.Contains(t, output, "[████████████░░░░░░░░░░░░] 0:00 InProgress: 500 of 1000 records processed (50%)")
+		assert.Contains(t, output, "\r\033[K[0:00] InProgress: 500 of 1000 records processed (50%)\n")
+		assert.True(t, strings.HasSuffix(output, "\r\033[K"), "progress line should be cleared")
+	})
+
+	t.Run("unknown total", func(t *testing.T) {
+		p := &jobProgress{}
+		assert.Equal(t, "InProgress: 42 records processed", p.status(&bulk.JobInfo{State: bulk.StateInProgress, NumberRecordsProcessed: 42}))
+		assert.Empty(t, p.bar(&bulk.JobInfo{}))
+	})
+}
+
+func TestFormatElapsed(t *testing.T) {
+	assert.Equal(t, "0:05", formatElapsed(5*time.Second))
+	assert.Equal(t, "12:34", formatElapsed(12*time.Minute+34*time.Second))
+	assert.Equal(t, "1:02:03", formatElapsed(time.Hour+2*time.Minute+3*time.Second))
+}
+
+func TestImportCommand_WaitProgress(t *testing.T) {
+	fastPolling(t)
+
+	polls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.Method {
+		case http.MethodPost:
+			_ = json.NewEncoder(w).Encode(bulk.JobInfo{ID: "750xx000000001", State: bulk.StateOpen})
+		case http.MethodPut:
+			w.WriteHeader(http.StatusCreated)
+		case http.MethodPatch:
+			_ = json.NewEncoder(w).Encode(bulk.JobInfo{ID: "750xx000000001", State: bulk.StateUploadComplete})
+		case http.MethodGet:
+			polls++
+			job := bulk.JobInfo{ID: "750xx000000001", Object: "Account", Operation: bulk.OperationUpdate, State: bulk.StateInProgress, NumberRecordsProcessed: 1}
+			if polls > 1 {
+				job.State = bulk.StateJobComplete
+				job.NumberRecordsProcessed = 2
+			}
+			_ = json.NewEncoder(w).Encode(job)
+		}
+	}))
+	defer server.Close()
+
+	client, err := bulk.New(bulk.ClientConfig{InstanceURL: server.URL, HTTPClient: server.Client()})
+	require.NoError(t, err)
+
+	csvFile := filepath.Join(t.TempDir(), "accounts.csv")
+	require.NoError(t, os.WriteFile(csvFile, []byte("Id,Name\n001xx000003DGbYAAW,A\n001xx000003DGbZAAW,B\n"), 0644))
+
+	stdout := &bytes.Buffer{}
+	opts := &root.Options{
+		Output: "table",
+		Stdout: stdout,
+		Stderr: &bytes.Buffer{},
+	}
+	opts.SetBulkClient(client)
+
+	cmd := newImportCommand(opts)
+	cmd.SetArgs([]string{"Account", "--file", csvFile, "--operation", "update", "--wait", "--skip-validation"})
+
+	require.NoError(t, cmd.Execute())
+
+	output := stdout.String()
+	assert.Contains(t, output, "] InProgress: 1 of 2 records processed (50%)\n")
+	assert.Contains(t, output, "] JobComplete: 2 of 2 records processed (100%)\n")
+	assert.Contains(t, output, "Records Processed: 2")
+}
+
+func TestImportCommand_Split(t *testing.T) {
+	fastPolling(t)
+	limit := uploadLimit
+	uploadLimit = 40
+	t.Cleanup(func() { uploadLimit = limit })
+
+	uploads := map[string]string{}
+	created := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		id := path.Base(strings.TrimSuffix(r.URL.Path, "/batches"))
+		switch r.Method {
+		case http.MethodPost:
+			created++
+			_ = json.NewEncoder(w).Encode(bulk.JobInfo{ID: fmt.Sprintf("750xx00000000%d", created), State: bulk.StateOpen})
+		case http.MethodPut:
+			body, _ := io.ReadAll(r.Body)
+			uploads[id] = string(body)
+			w.WriteHeader(http.StatusCreated)
+		case http.MethodPatch:
+			_ = json.NewEncoder(w).Encode(bulk.JobInfo{ID: id, State: bulk.StateUploadComplete})
+		case http.MethodGet:
+			rows := strings.Count(uploads[id], "\n") - 1
+			job := bulk.JobInfo{ID: id, Object: "Account", Operation: bulk.OperationInsert, State: bulk.StateJobComplete, NumberRecordsProcessed: rows}
+			if id == "750xx000000002" {
+				job.NumberRecordsFailed = 1
+			}
+			_ = json.NewEncoder(w).Encode(job)
+		}
+	}))
+	defer server.Close()
+
+	client, err := bulk.New(bulk.ClientConfig{InstanceURL: server.URL, HTTPClient: server.Client()})
+	require.NoError(t, err)
+
+	csvFile := filepath.Join(t.TempDir(), "accounts.csv")
+	require.NoError(t, os.WriteFile(csvFile, []byte("Name,Industry\nAcme,Tech\nGlobex,Energy\nInitech,Tech\nUmbrella,Pharma\n"), 0644))
+
+	stdout := &bytes.Buffer{}
+	opts := &root.Options{
+		Output: "table",
+		Stdout: stdout,
+		Stderr: &bytes.Buffer{},
+	}
+	opts.SetBulkClient(client)
+
+	cmd := newImportCommand(opts)
+	cmd.SetArgs([]string{"Account", "--file", csvFile, "--wait", "--skip-validation"})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+
+	err = cmd.Execute()
+	require.Error(t, err)
+	assert.Equal(t, "1 of 4 records failed", err.Error())
+
+	assert.Equal(t, map[string]string{
+		"750xx000000001": "Name,Industry\nAcme,Tech\nGlobex,Energy\n",
+		"750xx000000002": "Name,Industry\nInitech,Tech\n",
+		"750xx000000003": "Name,Industry\nUmbrella,Pharma\n",
+	}, uploads)
+
+	output := stdout.String()
+	assert.Contains(t, output, "splitting it into 3 jobs")
+	assert.Contains(t, output, "accounts.csv (part 1 of 3): 750xx000000001 started (2 records)")
+	assert.Contains(t, output, "JobComplete: 4 of 4 records processed (100%), 1 failed")
+	assert.Contains(t, output, "Records Processed: 4")
+	assert.Contains(t, output, "Records Failed:    1")
+	assert.Contains(t, output, "sfdc bulk job errors 750xx000000002")
+}
+
+func TestCombineJobs(t *testing.T) {
+	job := func(state bulk.State, processed, failed int) *bulk.JobInfo {
+		return &bulk.JobInfo{State: state, NumberRecordsProcessed: processed, NumberRecordsFailed: failed}
+	}
+
+	tests := []struct {
+		name string
+		jobs []*bulk.JobInfo
+		want bulk.State
+	}{
+		{"waiting", []*bulk.JobInfo{job(bulk.StateUploadComplete, 0, 0), job(bulk.StateUploadComplete, 0, 0)}, bulk.StateUploadComplete},
+		{"one started", []*bulk.JobInfo{job(bulk.StateJobComplete, 5, 1), job(bulk.StateUploadComplete, 0, 0)}, bulk.StateInProgress},
+		{"complete", []*bulk.JobInfo{job(bulk.StateJobComplete, 5, 1), job(bulk.StateJobComplete, 3, 0)}, bulk.StateJobComplete},
+		{"aborted", []*bulk.JobInfo{job(bulk.StateAborted, 0, 0), job(bulk.StateJobComplete, 3, 0)}, bulk.StateAborted},
+		{"failed", []*bulk.JobInfo{job(bulk.StateAborted, 0, 0), job(bulk.StateFailed, 3, 0)}, bulk.StateFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, combineJobs(tt.jobs).State)
+		})
+	}
+
+	combined := combineJobs([]*bulk.JobInfo{job(bulk.StateJobComplete, 5, 1), job(bulk.StateInProgress, 3, 2)})
+	assert.Equal(t, 8, combined.NumberRecordsProcessed)
+	assert.Equal(t, 3, combined.NumberRecordsFailed)
+}
+
+func TestImportCommand_Concurrency(t *testing.T) {
+	fastPolling(t)
+
+	var mu sync.Mutex
+	created, active, maxActive := 0, 0, 0
+	polls := map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+
+		id := path.Base(strings.TrimSuffix(r.URL.Path, "/batches"))
+		switch r.Method {
+		case http.MethodPost:
+			created++
+			_ = json.NewEncoder(w).Encode(bulk.JobInfo{ID: fmt.Sprintf("750xx00000000%d", created), State: bulk.StateOpen})
+		case http.MethodPut:
+			w.WriteHeader(http.StatusCreated)
+		case http.MethodPatch:
+			active++
+			maxActive = max(maxActive, active)
+			_ = json.NewEncoder(w).Encode(bulk.JobInfo{ID: id, State: bulk.StateUploadComplete})
+		case http.MethodGet:
+			polls[id]++
+			job := bulk.JobInfo{ID: id, Object: "Account", Operation: bulk.OperationInsert, State: bulk.StateInProgress}
+			if polls[id] > 1 {
+				job.State = bulk.StateJobComplete
+				job.NumberRecordsProcessed = 1
+				active--
+			}
+			_ = json.NewEncoder(w).Encode(job)
+		}
+	}))
+	defer server.Close()
+
+	client, err := bulk.New(bulk.ClientConfig{InstanceURL: server.URL, HTTPClient: server.Client()})
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	for _, name := range []string{"a.csv", "b.csv", "c.csv"} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte("Name\n"+name+"\n"), 0644))
+	}
+
+	stdout := &bytes.Buffer{}
+	opts := &root.Options{
+		Output: "table",
+		Stdout: stdout,
+		Stderr: &bytes.Buffer{},
+	}
+	opts.SetBulkClient(client)
+
+	cmd := newImportCommand(opts)
+	cmd.SetArgs([]string{"Account", "--file", filepath.Join(dir, "*.csv"), "--concurrency", "2", "--wait", "--skip-validation"})
+
+	require.NoError(t, cmd.Execute())
+
+	assert.Equal(t, 3, created)
+	assert.Equal(t, 2, maxActive)
+	assert.Equal(t, 0, active)
+
+	output := stdout.String()
+	assert.Contains(t, output, "Running 3 bulk insert jobs for Account, 2 at a time...")
+	assert.Contains(t, output, "c.csv: 750xx000000003 started (1 records)")
+	assert.Contains(t, output, " JobComplete: 1 of 1 records processed (100%)\n")
+	assert.Contains(t, output, "Records Processed: 3")
+}
+
+func TestImportCommand_ConcurrencyFailure(t *testing.T) {
+	fastPolling(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.Method {
+		case http.MethodPost:
+			_ = json.NewEncoder(w).Encode(bulk.JobInfo{ID: "750xx000000001", State: bulk.StateOpen})
+		case http.MethodPut:
+			w.WriteHeader(http.StatusCreated)
+		case http.MethodPatch:
+			_ = json.NewEncoder(w).Encode(bulk.JobInfo{ID: "750xx000000001", State: bulk.StateUploadComplete})
+		case http.MethodGet:
+			_ = json.NewEncoder(w).Encode(bulk.JobInfo{ID: "750xx000000001", State: bulk.StateFailed, ErrorMessage: "InvalidBatch"})
+		}
+	}))
+	defer server.Close()
+
+	client, err := bulk.New(bulk.ClientConfig{InstanceURL: server.URL, HTTPClient: server.Client()})
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	fileA, fileB := filepath.Join(dir, "a.csv"), filepath.Join(dir, "b.csv")
+	require.NoError(t, os.WriteFile(fileA, []byte("Name\nA\n"), 0644))
+	require.NoError(t, os.WriteFile(fileB, []byte("Name\nB\n"), 0644))
+
+	stdout := &bytes.Buffer{}
+	opts := &root.Options{
+		Output: "json",
+		Stdout: stdout,
+		Stderr: &bytes.Buffer{},
+	}
+	opts.SetBulkClient(client)
+
+	cmd := newImportCommand(opts)
+	cmd.SetArgs([]string{"Account", "--file", fileA, "--file", fileB, "--wait", "--skip-validation"})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+
+	err = cmd.Execute()
+	require.Error(t, err)
+	assert.Equal(t, "2 of 2 jobs did not complete", err.Error())
+
+	var result struct {
+		Jobs []struct {
+			File string       `json:"file"`
+			Job  bulk.JobInfo `json:"job"`
+		} `json:"jobs"`
+		State bulk.State `json:"state"`
+	}
+	output := stdout.String()
+	require.NoError(t, json.Unmarshal([]byte(output[strings.Index(output, "{"):]), &result))
+	require.Len(t, result.Jobs, 2)
+	assert.Equal(t, fileA, result.Jobs[0].File)
+	assert.Equal(t, bulk.StateFailed, result.Jobs[0].Job.State)
+	assert.Equal(t, bulk.StateFailed, result.State)
+}
+
+func TestImportCommand_NoFilesMatch(t *testing.T) {
+	opts := &root.Options{Stdout: &bytes.Buffer{}, Stderr: &bytes.Buffer{}}
+
+	cmd := newImportCommand(opts)
+	cmd.SetArgs([]string{"Account", "--file", filepath.Join(t.TempDir(), "*.csv")})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no files match")
+}
+
+func TestExportCommand_Out(t *testing.T) {
+	fastPolling(t)
+
+	pages := map[string]struct{ data, next string }{
+		"":      {"Id,Name\n001xx000001,Acme\n", "page2"},
+		"page2": {"Id,Name\n001xx000002,Globex\n", "null"},
+	}
+	failPage2 := false
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost || r.URL.Path == "/services/data/v62.0/jobs/query/750xx000000001":
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(bulk.QueryJobInfo{ID: "750xx000000001", State: bulk.StateJobComplete, NumberRecordsProcessed: 2})
+		case r.URL.Path == "/services/data/v62.0/jobs/query/750xx000000001/results":
+			locator := r.URL.Query().Get("locator")
+			if locator == "page2" && failPage2 {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`[{"errorCode":"INVALID_LOCATOR"}]`))
+				return
+			}
+			w.Header().Set("Content-Type", "text/csv")
+			w.Header().Set("Sforce-Locator", pages[locator].next)
+			w.Header().Set("Sforce-NumberOfRecords", "1")
+			_, _ = w.Write([]byte(pages[locator].data))
+		}
+	}))
+	defer server.Close()
+
+	client, err := bulk.New(bulk.ClientConfig{InstanceURL: server.URL, HTTPClient: server.Client()})
+	require.NoError(t, err)
+
+	run := func(outFile string) (string, error) {
+		stdout := &bytes.Buffer{}
+		opts := &root.Options{Output: "table", Stdout: stdout, Stderr: &bytes.Buffer{}}
+		opts.SetBulkClient(client)
+
+		cmd := newExportCommand(opts)
+		cmd.SetArgs([]string{"SELECT Id, Name FROM Account", "--output", outFile})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+		err := cmd.Execute()
+		return stdout.String(), err
+	}
+
+	t.Run("streams every page", func(t *testing.T) {
+		outFile := filepath.Join(t.TempDir(), "accounts.csv")
+		output, err := run(outFile)
+		require.NoError(t, err)
+
+		data, err := os.ReadFile(outFile)
+		require.NoError(t, err)
+		assert.Equal(t, "Id,Name\n001xx000001,Acme\n001xx000002,Globex\n", string(data))
+		assert.Contains(t, output, "Exported 2 records to "+outFile)
+		assert.NotContains(t, output, "Globex")
+	})
+
+	t.Run("streams JSON", func(t *testing.T) {
+		outFile := filepath.Join(t.TempDir(), "accounts.json")
+		output, err := run(outFile)
+		require.NoError(t, err)
+
+		data, err := os.ReadFile(outFile)
+		require.NoError(t, err)
+		assert.Equal(t, `[
+  {
+    "Id": "001xx000001",
+    "Name": "Acme"
+  },
+  {
+    "Id": "001xx000002",
+    "Name": "Globex"
+  }
+]
+`, string(data))
+		assert.Contains(t, output, "Exported 2 records to "+outFile)
+	})
+
+	t.Run("out is an alias", func(t *testing.T) {
+		outFile := filepath.Join(t.TempDir(), "accounts.json")
+		opts := &root.Options{Output: "table", Stdout: &bytes.Buffer{}, Stderr: &bytes.Buffer{}}
+		opts.SetBulkClient(client)
+
+		cmd := newExportCommand(opts)
+		cmd.SetArgs([]string{"SELECT Id, Name FROM Account", "--out", outFile})
+		require.NoError(t, cmd.Execute())
+
+		data, err := os.ReadFile(outFile)
+		require.NoError(t, err)
+		var got []map[string]interface{}
+		require.NoError(t, json.Unmarshal(data, &got))
+		assert.Len(t, got, 2)
+	})
+
+	t.Run("removes a partial file", func(t *testing.T) {
+		failPage2 = true
+		outFile := filepath.Join(t.TempDir(), "accounts.csv")
+		_, err := run(outFile)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "INVALID_LOCATOR")
+
+		_, statErr := os.Stat(outFile)
+		assert.True(t, os.IsNotExist(statErr))
+	})
+}
+
+func TestExportCommand_Format(t *testing.T) {
+	fastPolling(t)
+
+	csvData := "Id,Amount,IsWon,CloseDate,CreatedDate,Owner.Name,Owner.IsActive,expr0\n" +
+		"006xx000001,1500.5,true,2024-03-01,2024-01-15T10:30:00.000+0000,Ann,true,3\n" +
+		"006xx000002,,false,,,,,\n"
+	opportunity := api.SObjectDescribe{
+		Name: "Opportunity",
+		Fields: []api.Field{
+			{Name: "Id", Type: "id"},
+			{Name: "Amount", Type: "currency"},
+			{Name: "IsWon", Type: "boolean"},
+			{Name: "CloseDate", Type: "date"},
+			{Name: "CreatedDate", Type: "datetime"},
+			{Name: "OwnerId", Type: "reference", RelationshipName: "Owner", ReferenceTo: []string{"User"}},
+		},
+	}
+	user := api.SObjectDescribe{
+		Name: "User",
+		Fields: []api.Field{
+			{Name: "Name", Type: "string"},
+			{Name: "IsActive", Type: "boolean"},
+		},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/services/data/v62.0/jobs/query", "/services/data/v62.0/jobs/query/750xx000000001":
+			_ = json.NewEncoder(w).Encode(bulk.QueryJobInfo{ID: "750xx000000001", State: bulk.StateJobComplete, NumberRecordsProcessed: 2})
+		case "/services/data/v62.0/jobs/query/750xx000000001/results":
+			w.Header().Set("Content-Type", "text/csv")
+			_, _ = w.Write([]byte(csvData))
+		case "/services/data/v62.0/sobjects/Opportunity/describe":
+			_ = json.NewEncoder(w).Encode(opportunity)
+		case "/services/data/v62.0/sobjects/User/describe":
+			_ = json.NewEncoder(w).Encode(user)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	run := func(t *testing.T, args ...string) (string, string, error) {
+		t.Helper()
+		apiClient, err := api.New(api.ClientConfig{InstanceURL: server.URL, HTTPClient: server.Client()})
+		require.NoError(t, err)
+		bulkClient, err := bulk.New(bulk.ClientConfig{InstanceURL: server.URL, HTTPClient: server.Client()})
+		require.NoError(t, err)
+
+		stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+		opts := &root.Options{Output: "table", Stdout: stdout, Stderr: stderr}
+		opts.SetAPIClient(apiClient)
+		opts.SetBulkClient(bulkClient)
+
+		cmd := newExportCommand(opts)
+		cmd.SetArgs(append([]string{"SELECT Id, Amount, IsWon, CloseDate, CreatedDate, Owner.Name, Owner.IsActive, COUNT(Id) FROM Opportunity"}, args...))
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+		err = cmd.Execute()
+		return stdout.String(), stderr.String(), err
+	}
+
+	t.Run("jsonl to stdout", func(t *testing.T) {
+		stdout, stderr, err := run(t, "--format", "jsonl")
+		require.NoError(t, err)
+		assert.Equal(t,
+			`{"Id":"006xx000001","Amount":1500.5,"IsWon":true,"CloseDate":"2024-03-01","CreatedDate":"2024-01-15T10:30:00.000Z","Owner.Name":"Ann","Owner.IsActive":true,"expr0":"3"}`+"\n"+
+				`{"Id":"006xx000002","Amount":null,"IsWon":false,"CloseDate":null,"CreatedDate":null,"Owner.Name":null,"Owner.IsActive":null,"expr0":null}`+"\n",
+			stdout)
+		assert.Contains(t, stderr, "Downloading results...")
+	})
+
+	t.Run("parquet from extension", func(t *testing.T) {
+		outFile := filepath.Join(t.TempDir(), "opps.parquet")
+		stdout, _, err := run(t, "--output", outFile)
+		require.NoError(t, err)
+		assert.Contains(t, stdout, "Exported 2 records to "+outFile)
+
+		data, err := os.ReadFile(outFile)
+		require.NoError(t, err)
+		f, err := parquet.OpenFile(bytes.NewReader(data), int64(len(data)))
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), f.NumRows())
+		var names []string
+		for _, field := range f.Schema().Fields() {
+			names = append(names, field.Name())
+		}
+		assert.Equal(t, []string{"Id", "Amount", "IsWon", "CloseDate", "CreatedDate", "Owner.Name", "Owner.IsActive", "expr0"}, names)
+	})
+
+	t.Run("parquet needs a file", func(t *testing.T) {
+		_, _, err := run(t, "--format", "parquet")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "parquet results must be written to a file")
+	})
+
+	t.Run("invalid format", func(t *testing.T) {
+		_, _, err := run(t, "--format", "xml")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid format: xml")
+	})
+}
+
+func TestTypedValue(t *testing.T) {
+	tests := []struct {
+		kind    fieldKind
+		value   string
+		want    interface{}
+		wantErr bool
+	}{
+		{kindString, "Acme", "Acme", false},
+		{kindString, "", nil, false},
+		{kindBoolean, "false", false, false},
+		{kindInteger, "42", int64(42), false},
+		{kindNumber, "1.5E7", 1.5e7, false},
+		{kindDate, "2024-03-01", time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), false},
+		{kindDateTime, "2024-01-15T12:30:00.000+0200", time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC), false},
+		{kindDateTime, "2024-01-15T10:30:00Z", time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC), false},
+		{kindInteger, "4.5", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := typedValue(tt.kind, tt.value)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestExportCommand_PKChunking(t *testing.T) {
+	fastPolling(t)
+
+	const batchList = `<batchInfoList xmlns="http://www.force.com/2009/06/asyncapi/dataload">
+ <batchInfo><id>751xx000000001</id><jobId>750xx000000001</jobId><state>NotProcessed</state><numberRecordsProcessed>0</numberRecordsProcessed></batchInfo>
+ <batchInfo><id>751xx000000002</id><jobId>750xx000000001</jobId><state>Completed</state><numberRecordsProcessed>1</numberRecordsProcessed></batchInfo>
+ <batchInfo><id>751xx000000003</id><jobId>750xx000000001</jobId><state>%s</state><stateMessage>%s</stateMessage><numberRecordsProcessed>1</numberRecordsProcessed></batchInfo>
+</batchInfoList>`
+
+	var (
+		chunkHeader string
+		query       string
+		lastState   = "Completed"
+		lastMessage = ""
+		polls       int
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		job := "/services/async/62.0/job"
+		switch r.URL.Path {
+		case job:
+			chunkHeader = r.Header.Get("Sforce-Enable-PKChunking")
+			_, _ = w.Write([]byte(`<jobInfo><id>750xx000000001</id><object>Lead</object><state>Open</state></jobInfo>`))
+		case job + "/750xx000000001":
+			_, _ = w.Write([]byte(`<jobInfo><id>750xx000000001</id><state>Closed</state></jobInfo>`))
+		case job + "/750xx000000001/batch":
+			if r.Method == http.MethodPost {
+				body, _ := io.ReadAll(r.Body)
+				query = string(body)
+				_, _ = w.Write([]byte(`<batchInfo><id>751xx000000001</id><state>Queued</state></batchInfo>`))
+				return
+			}
+			polls++
+			state := lastState
+			if polls == 1 {
+				state = "InProgress"
+			}
+			_, _ = fmt.Fprintf(w, batchList, state, lastMessage)
+		case job + "/750xx000000001/batch/751xx000000002/result":
+			_, _ = w.Write([]byte(`<result-list><result>752xx000000001</result></result-list>`))
+		case job + "/750xx000000001/batch/751xx000000003/result":
+			_, _ = w.Write([]byte(`<result-list><result>752xx000000002</result></result-list>`))
+		case job + "/750xx000000001/batch/751xx000000002/result/752xx000000001":
+			_, _ = w.Write([]byte("Id,Name\n00Qxx000001,Ann\n"))
+		case job + "/750xx000000001/batch/751xx000000003/result/752xx000000002":
+			_, _ = w.Write([]byte("Id,Name\n00Qxx000002,Bob\n"))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	run := func(t *testing.T, args ...string) (string, error) {
+		t.Helper()
+		polls = 0
+		client, err := bulkv1.New(bulkv1.ClientConfig{InstanceURL: server.URL, HTTPClient: server.Client()})
+		require.NoError(t, err)
+
+		stdout := &bytes.Buffer{}
+		opts := &root.Options{Output: "table", Stdout: stdout, Stderr: &bytes.Buffer{}}
+		opts.SetBulkV1Client(client)
+
+		cmd := newExportCommand(opts)
+		cmd.SetArgs(append([]string{"SELECT Id, Name FROM Lead"}, args...))
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+		err = cmd.Execute()
+		return stdout.String(), err
+	}
+
+	t.Run("merges the chunks", func(t *testing.T) {
+		outFile := filepath.Join(t.TempDir(), "leads.csv")
+		output, err := run(t, "--output", outFile, "--chunk-size", "50000")
+		require.NoError(t, err)
+
+		assert.Equal(t, "chunkSize=50000", chunkHeader)
+		assert.Equal(t, "SELECT Id, Name FROM Lead", query)
+		assert.Contains(t, output, "Batches: 1 of 2 completed, 2 records")
+		assert.Contains(t, output, "Batches: 2 of 2 completed, 2 records")
+		assert.Contains(t, output, "Exported 2 records to "+outFile)
+
+		data, err := os.ReadFile(outFile)
+		require.NoError(t, err)
+		assert.Equal(t, "Id,Name\n00Qxx000001,Ann\n00Qxx000002,Bob\n", string(data))
+	})
+
+	t.Run("default chunk size", func(t *testing.T) {
+		_, err := run(t, "--pk-chunking")
+		require.NoError(t, err)
+		assert.Equal(t, "true", chunkHeader)
+	})
+
+	t.Run("failed batch", func(t *testing.T) {
+		lastState, lastMessage = "Failed", "QUERY_TIMEOUT"
+		defer func() { lastState, lastMessage = "Completed", "" }()
+
+		_, err := run(t, "--pk-chunking")
+		require.Error(t, err)
+		assert.Equal(t, "1 of 2 batches of job 750xx000000001 failed: QUERY_TIMEOUT", err.Error())
+	})
+
+	t.Run("chunk size too large", func(t *testing.T) {
+		_, err := run(t, "--chunk-size", "300000")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "--chunk-size must be between 1 and 250000")
+	})
+}
+
+func TestImportCommand_Serial(t *testing.T) {
+	fastPolling(t)
+
+	var (
+		created string
+		batches []string
+		closed  bool
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		job := "/services/async/62.0/job"
+		switch r.URL.Path {
+		case job:
+			body, _ := io.ReadAll(r.Body)
+			created = string(body)
+			_, _ = w.Write([]byte(`<jobInfo><id>750xx000000001</id><object>Account</object><state>Open</state></jobInfo>`))
+		case job + "/750xx000000001":
+			if r.Method == http.MethodPost {
+				closed = true
+			}
+			_, _ = w.Write([]byte(`<jobInfo><id>750xx000000001</id><state>Closed</state><numberBatchesCompleted>1</numberBatchesCompleted><numberBatchesTotal>1</numberBatchesTotal><numberRecordsProcessed>2</numberRecordsProcessed><numberRecordsFailed>1</numberRecordsFailed></jobInfo>`))
+		case job + "/750xx000000001/batch":
+			if r.Method == http.MethodPost {
+				body, _ := io.ReadAll(r.Body)
+				batches = append(batches, string(body))
+				_, _ = w.Write([]byte(`<batchInfo><id>751xx000000001</id><state>Queued</state></batchInfo>`))
+				return
+			}
+			_, _ = w.Write([]byte(`<batchInfoList><batchInfo><id>751xx000000001</id><state>Completed</state><numberRecordsProcessed>2</numberRecordsProcessed><numberRecordsFailed>1</numberRecordsFailed></batchInfo></batchInfoList>`))
+		case job + "/750xx000000001/batch/751xx000000001/result":
+			_, _ = w.Write([]byte("\"Id\",\"Success\",\"Created\",\"Error\"\n\"001xx000001\",\"true\",\"true\",\"\"\n\"\",\"false\",\"false\",\"UNABLE_TO_LOCK_ROW:unable to obtain exclusive access to this record\"\n"))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	csvFile := filepath.Join(t.TempDir(), "accounts.csv")
+	require.NoError(t, os.WriteFile(csvFile, []byte("Name\nAcme\nGlobex\n"), 0644))
+
+	run := func(t *testing.T, args ...string) (string, error) {
+		t.Helper()
+		client, err := bulkv1.New(bulkv1.ClientConfig{InstanceURL: server.URL, HTTPClient: server.Client()})
+		require.NoError(t, err)
+
+		stdout := &bytes.Buffer{}
+		opts := &root.Options{Output: "table", Stdout: stdout, Stderr: &bytes.Buffer{}}
+		opts.SetBulkV1Client(client)
+
+		cmd := newImportCommand(opts)
+		cmd.SetArgs(append([]string{"Account", "--file", csvFile, "--serial", "--skip-validation"}, args...))
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+		err = cmd.Execute()
+		return stdout.String(), err
+	}
+
+	t.Run("imports serially", func(t *testing.T) {
+		output, err := run(t, "--wait")
+		require.NoError(t, err)
+
+		assert.Contains(t, created, "<operation>insert</operation><object>Account</object><concurrencyMode>Serial</concurrencyMode>")
+		assert.Equal(t, []string{"Name\nAcme\nGlobex\n"}, batches)
+		assert.True(t, closed)
+		assert.Contains(t, output, "Batches: 1 of 1 completed, 2 records")
+		assert.Contains(t, output, "Records Failed:    1")
+		assert.Contains(t, output, "Row 2: UNABLE_TO_LOCK_ROW:unable to obtain exclusive access to this record")
+	})
+
+	t.Run("rejects other delimiters", func(t *testing.T) {
+		_, err := run(t, "--delimiter", "semicolon")
+		assert.ErrorContains(t, err, "--serial only supports comma-delimited CSV")
+	})
+
+	t.Run("rejects concurrency", func(t *testing.T) {
+		_, err := run(t, "--concurrency", "2")
+		assert.ErrorContains(t, err, "--concurrency cannot be used with --serial")
+	})
+}
+
+func TestSplitBatches(t *testing.T) {
+	var data strings.Builder
+	data.WriteString("Name\n")
+	for i := 0; i < bulkv1.MaxBatchRecords+1; i++ {
+		fmt.Fprintf(&data, "Account %d\n", i)
+	}
+
+	batches, err := splitBatches([]byte(data.String()), csvFormat{})
+	require.NoError(t, err)
+	require.Len(t, batches, 2)
+	assert.Equal(t, bulkv1.MaxBatchRecords, batches[0].rows)
+	assert.Equal(t, 1, batches[1].rows)
+	assert.True(t, strings.HasPrefix(string(batches[0].data), "Name\nAccount 0\n"))
+	assert.Equal(t, fmt.Sprintf("Name\nAccount %d\n", bulkv1.MaxBatchRecords), string(batches[1].data))
+
+	batches, err = splitBatches([]byte("Name\n"), csvFormat{})
+	require.NoError(t, err)
+	assert.Empty(t, batches)
+}
+
+func TestJobWatchCommand(t *testing.T) {
+	fastPolling(t)
+
+	polls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		polls++
+		job := bulk.JobInfo{ID: "750xx000000001", State: bulk.StateInProgress, NumberRecordsProcessed: 1}
+		if polls > 2 {
+			job.State = bulk.StateJobComplete
+			job.NumberRecordsProcessed = 2
+			job.TotalProcessingTime = 1500
+		}
+		_ = json.NewEncoder(w).Encode(job)
+	}))
+	defer server.Close()
+
+	client, err := bulk.New(bulk.ClientConfig{InstanceURL: server.URL, HTTPClient: server.Client()})
+	require.NoError(t, err)
+
+	stdout := &bytes.Buffer{}
+	opts := &root.Options{
+		Output: "table",
+		Stdout: stdout,
+		Stderr: &bytes.Buffer{},
+	}
+	opts.SetBulkClient(client)
+
+	cmd := newJobWatchCommand(opts)
+	cmd.SetArgs([]string{"750xx000000001"})
+
+	require.NoError(t, cmd.Execute())
+
+	output := stdout.String()
+	assert.Contains(t, output, "] InProgress: 1 records processed\n")
+	assert.Contains(t, output, "] JobComplete: 2 records processed in 1.5s\n")
+	assert.Contains(t, output, "Records Processed: 2")
+}
+
+func TestJobWatchCommand_QueryJob(t *testing.T) {
+	fastPolling(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Path, "/jobs/ingest/") {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`[{"errorCode":"NOT_FOUND","message":"The requested resource does not exist"}]`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(bulk.QueryJobInfo{ID: "750xx000000002", Object: "Account", State: bulk.StateAborted})
+	}))
+	defer server.Close()
+
+	client, err := bulk.New(bulk.ClientConfig{InstanceURL: server.URL, HTTPClient: server.Client()})
+	require.NoError(t, err)
+
+	stdout := &bytes.Buffer{}
+	opts := &root.Options{
+		Output: "table",
+		Stdout: stdout,
+		Stderr: &bytes.Buffer{},
+	}
+	opts.SetBulkClient(client)
+
+	cmd := newJobWatchCommand(opts)
+	cmd.SetArgs([]string{"750xx000000002"})
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+
+	err = cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "job 750xx000000002 Aborted")
+	assert.Contains(t, stdout.String(), "Query job finished:")
+}
+
+func TestRetryCommand(t *testing.T) {
+	fastPolling(t)
+
+	var (
+		created  bulk.CreateJobRequest
+		uploaded []byte
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.URL.Path == "/services/data/v62.0/jobs/ingest/750xx000000001":
+			_ = json.NewEncoder(w).Encode(bulk.JobInfo{ID: "750xx000000001", Object: "Contact", Operation: bulk.OperationUpsert, ExternalIDFieldName: "Email", State: bulk.StateJobComplete, NumberRecordsProcessed: 3, NumberRecordsFailed: 1})
+		case strings.HasSuffix(r.URL.Path, "/failedResults"):
+			w.Header().Set("Content-Type", "text/csv")
+			_, _ = w.Write([]byte("\"sf__Id\",\"sf__Error\",Email,LastName\n\"\",\"REQUIRED_FIELD_MISSING\",a@example.com,\n"))
+		case r.Method == http.MethodPost:
+			_ = json.NewDecoder(r.Body).Decode(&created)
+			_ = json.NewEncoder(w).Encode(bulk.JobInfo{ID: "750xx000000002", State: bulk.StateOpen})
+		case r.Method == http.MethodPut:
+			uploaded, _ = io.ReadAll(r.Body)
+			w.WriteHeader(http.StatusCreated)
+		case r.Method == http.MethodPatch:
+			_ = json.NewEncoder(w).Encode(bulk.JobInfo{ID: "750xx000000002", State: bulk.StateUploadComplete})
+		default:
+			_ = json.NewEncoder(w).Encode(bulk.JobInfo{ID: "750xx000000002", Object: "Contact", Operation: bulk.OperationUpsert, State: bulk.StateJobComplete, NumberRecordsProcessed: 1})
+		}
+	}))
+	defer server.Close()
+
+	client, err := bulk.New(bulk.ClientConfig{InstanceURL: server.URL, HTTPClient: server.Client()})
+	require.NoError(t, err)
+
+	mapping := filepath.Join(t.TempDir(), "fix.json")
+	require.NoError(t, os.WriteFile(mapping, []byte(`{"fields": [
+		{"field": "Email", "column": "Email"},
+		{"field": "LastName", "value": "Unknown"}
+	]}`), 0644))
+
+	stdout := &bytes.Buffer{}
+	opts := &root.Options{
+		Output: "table",
+		Stdout: stdout,
+		Stderr: &bytes.Buffer{},
+	}
+	opts.SetBulkClient(client)
+
+	cmd := newRetryCommand(opts)
+	cmd.SetArgs([]string{"750xx000000001", "--mapping", mapping, "--wait"})
+
+	require.NoError(t, cmd.Execute())
+
+	assert.Equal(t, "Contact", created.Object)
+	assert.Equal(t, bulk.OperationUpsert, created.Operation)
+	assert.Equal(t, "Email", created.ExternalIDFieldName)
+	assert.Equal(t, "Email,LastName\na@example.com,Unknown\n", string(uploaded))
+
+	output := stdout.String()
+	assert.Contains(t, output, "Retrying 1 failed records of job 750xx000000001")
+	assert.Contains(t, output, "Job chain: 750xx000000001 -> 750xx000000002")
+	assert.Contains(t, output, "Records Processed: 1")
+}
+
+func TestRetryCommand_NoFailedRecords(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/failedResults") {
+			w.Header().Set("Content-Type", "text/csv")
+			_, _ = w.Write([]byte("\"sf__Id\",\"sf__Error\",Name\n"))
+			return
+		}
+		if r.Method != http.MethodGet {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(bulk.JobInfo{ID: "750xx000000001", Object: "Account", Operation: bulk.OperationInsert, State: bulk.StateJobComplete})
+	}))
+	defer server.Close()
+
+	client, err := bulk.New(bulk.ClientConfig{InstanceURL: server.URL, HTTPClient: server.Client()})
+	require.NoError(t, err)
+
+	stdout := &bytes.Buffer{}
+	opts := &root.Options{
+		Output: "table",
+		Stdout: stdout,
+		Stderr: &bytes.Buffer{},
+	}
+	opts.SetBulkClient(client)
+
+	cmd := newRetryCommand(opts)
+	cmd.SetArgs([]string{"750xx000000001"})
+
+	require.NoError(t, cmd.Execute())
+	assert.Contains(t, stdout.String(), "Job 750xx000000001 has no failed records to retry")
+}
+
+func TestImportCommand_JSON(t *testing.T) {
+	var (
+		created     bulk.CreateJobRequest
+		uploaded    []byte
+		contentType string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.Method {
+		case http.MethodPost:
+			_ = json.NewDecoder(r.Body).Decode(&created)
+			_ = json.NewEncoder(w).Encode(bulk.JobInfo{ID: "750xx000000001", State: bulk.StateOpen})
+		case http.MethodPut:
+			contentType = r.Header.Get("Content-Type")
+			uploaded, _ = io.ReadAll(r.Body)
+			w.WriteHeader(http.StatusCreated)
+		case http.MethodPatch:
+			_ = json.NewEncoder(w).Encode(bulk.JobInfo{ID: "750xx000000001", State: bulk.StateUploadComplete})
+		}
+	}))
+	defer server.Close()
+
+	client, err := bulk.New(bulk.ClientConfig{InstanceURL: server.URL, HTTPClient: server.Client()})
+	require.NoError(t, err)
+
+	jsonFile := filepath.Join(t.TempDir(), "accounts.jsonl")
+	require.NoError(t, os.WriteFile(jsonFile, []byte("{\"Name\":\"Acme\",\"NumberOfEmployees\":250}\n{\"Name\":\"Test\",\"IsActive__c\":true}\n"), 0644))
+
+	opts := &root.Options{
+		Output: "table",
+		Stdout: &bytes.Buffer{},
+		Stderr: &bytes.Buffer{},
+	}
+	opts.SetBulkClient(client)
+
+	cmd := newImportCommand(opts)
+	cmd.SetArgs([]string{"Account", "--file", jsonFile, "--skip-validation"})
+
+	require.NoError(t, cmd.Execute())
+	assert.Equal(t, bulk.ContentTypeJSON, created.ContentType)
+	assert.Equal(t, "application/json", contentType)
+	assert.JSONEq(t, `[{"Name":"Acme","NumberOfEmployees":250},{"Name":"Test","IsActive__c":true}]`, string(uploaded))
+}
+
+func TestImportContentType(t *testing.T) {
+	ct, err := importContentType("", []string{"a.csv"})
+	require.NoError(t, err)
+	assert.Equal(t, bulk.ContentTypeCSV, ct)
+
+	ct, err = importContentType("", []string{"a.json", "b.NDJSON"})
+	require.NoError(t, err)
+	assert.Equal(t, bulk.ContentTypeJSON, ct)
+
+	ct, err = importContentType("json", []string{"records.txt"})
+	require.NoError(t, err)
+	assert.Equal(t, bulk.ContentTypeJSON, ct)
+
+	_, err = importContentType("", []string{"a.json", "b.csv"})
+	assert.Error(t, err)
+
+	_, err = importContentType("xml", []string{"a.xml"})
+	assert.Error(t, err)
+}
+
+func TestImportCommand_Delimiter(t *testing.T) {
+	var (
+		created  bulk.CreateJobRequest
+		uploaded []byte
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.Method {
+		case http.MethodPost:
+			_ = json.NewDecoder(r.Body).Decode(&created)
+			_ = json.NewEncoder(w).Encode(bulk.JobInfo{ID: "750xx000000001", State: bulk.StateOpen})
+		case http.MethodPut:
+			uploaded, _ = io.ReadAll(r.Body)
+			w.WriteHeader(http.StatusCreated)
+		case http.MethodPatch:
+			_ = json.NewEncoder(w).Encode(bulk.JobInfo{ID: "750xx000000001", State: bulk.StateUploadComplete})
+		}
+	}))
+	defer server.Close()
+
+	client, err := bulk.New(bulk.ClientConfig{InstanceURL: server.URL, HTTPClient: server.Client()})
+	require.NoError(t, err)
+
+	data := "Name;Description\r\nAcme;\"Big; important\"\r\nGlobex;\r\n"
+	csvFile := filepath.Join(t.TempDir(), "konten.csv")
+	require.NoError(t, os.WriteFile(csvFile, []byte(data), 0644))
+
+	opts := &root.Options{
+		Output: "table",
+		Stdout: &bytes.Buffer{},
+		Stderr: &bytes.Buffer{},
+	}
+	opts.SetBulkClient(client)
+
+	cmd := newImportCommand(opts)
+	cmd.SetArgs([]string{"Account", "--file", csvFile, "--delimiter", "semicolon", "--line-ending", "CRLF", "--skip-validation"})
+	require.NoError(t, cmd.Execute())
+	assert.Equal(t, bulk.DelimiterSemicolon, created.ColumnDelimiter)
+	assert.Equal(t, bulk.LineEndingCRLF, created.LineEnding)
+	assert.Equal(t, data, string(uploaded))
+
+	cmd = newImportCommand(opts)
+	cmd.SetArgs([]string{"Account", "--file", csvFile, "--delimiter", "colon"})
+	assert.ErrorContains(t, cmd.Execute(), "invalid column delimiter")
+}
+
+func TestExportCommand_Delimiter(t *testing.T) {
+	fastPolling(t)
+
+	var created bulk.CreateQueryJobRequest
+	job := bulk.QueryJobInfo{ID: "750xx000000001", Operation: bulk.OperationQuery, State: bulk.StateJobComplete, NumberRecordsProcessed: 1}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost:
+			_ = json.NewDecoder(r.Body).Decode(&created)
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(job)
+		case strings.HasSuffix(r.URL.Path, "/results"):
+			w.Header().Set("Content-Type", "text/csv")
+			_, _ = w.Write([]byte("\"Id\"|\"Name\"\r\n\"001xx000001\"|\"Acme\"\r\n"))
+		default:
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(job)
+		}
+	}))
+	defer server.Close()
+
+	client, err := bulk.New(bulk.ClientConfig{InstanceURL: server.URL, HTTPClient: server.Client()})
+	require.NoError(t, err)
+
+	stdout := &bytes.Buffer{}
+	opts := &root.Options{
+		Output: "table",
+		Stdout: stdout,
+		Stderr: &bytes.Buffer{},
+	}
+	opts.SetBulkClient(client)
+
+	cmd := newExportCommand(opts)
+	cmd.SetArgs([]string{"SELECT Id, Name FROM Account", "--delimiter", "PIPE", "--line-ending", "CRLF"})
+	require.NoError(t, cmd.Execute())
+	assert.Equal(t, bulk.DelimiterPipe, created.ColumnDelimiter)
+	assert.Equal(t, bulk.LineEndingCRLF, created.LineEnding)
+	assert.Contains(t, stdout.String(), "\"Id\"|\"Name\"\r\n\"001xx000001\"|\"Acme\"\r\n")
+
+	cmd = newExportCommand(opts)
+	cmd.SetArgs([]string{"SELECT Id, Name FROM Account", "--delimiter", "PIPE", "--format", "jsonl"})
+	assert.ErrorContains(t, cmd.Execute(), "only apply to CSV results")
+}
+
+func TestImportCommand_Stdin(t *testing.T) {
+	var (
+		created  bulk.CreateJobRequest
+		uploaded []byte
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.Method {
+		case http.MethodPost:
+			_ = json.NewDecoder(r.Body).Decode(&created)
+			_ = json.NewEncoder(w).Encode(bulk.JobInfo{ID: "750xx000000001", State: bulk.StateOpen})
+		case http.MethodPut:
+			uploaded, _ = io.ReadAll(r.Body)
+			w.WriteHeader(http.StatusCreated)
+		case http.MethodPatch:
+			_ = json.NewEncoder(w).Encode(bulk.JobInfo{ID: "750xx000000001", State: bulk.StateUploadComplete})
+		}
+	}))
+	defer server.Close()
+
+	client, err := bulk.New(bulk.ClientConfig{InstanceURL: server.URL, HTTPClient: server.Client()})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name  string
+		args  []string
+		stdin string
+		want  string
+	}{
+		{
+			name:  "insert",
+			args:  []string{"Account", "--file", "-", "--skip-validation"},
+			stdin: "Name,Industry\nAcme,Technology\nGlobex,Energy\n",
+			want:  "Name,Industry\nAcme,Technology\nGlobex,Energy\n",
+		},
+		{
+			name:  "delete a list of Ids",
+			args:  []string{"Account", "--file", "-", "--operation", "delete", "--yes"},
+			stdin: "001xx000003DGb1AAG\n001xx000003DGb2AAG\n",
+			want:  "Id\n001xx000003DGb1AAG\n001xx000003DGb2AAG\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uploaded = nil
+			opts := &root.Options{
+				Output: "table",
+				Stdin:  strings.NewReader(tt.stdin),
+				Stdout: &bytes.Buffer{},
+				Stderr: &bytes.Buffer{},
+			}
+			opts.SetBulkClient(client)
+
+			cmd := newImportCommand(opts)
+			cmd.SetArgs(tt.args)
+			require.NoError(t, cmd.Execute())
+			assert.Equal(t, tt.want, string(uploaded))
+		})
+	}
+}
+
+func TestImportCommand_StdinMapping(t *testing.T) {
+	var uploaded []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.Method {
+		case http.MethodPost:
+			_ = json.NewEncoder(w).Encode(bulk.JobInfo{ID: "750xx000000001", State: bulk.StateOpen})
+		case http.MethodPut:
+			uploaded, _ = io.ReadAll(r.Body)
+			w.WriteHeader(http.StatusCreated)
+		case http.MethodPatch:
+			_ = json.NewEncoder(w).Encode(bulk.JobInfo{ID: "750xx000000001", State: bulk.StateUploadComplete})
+		}
+	}))
+	defer server.Close()
+
+	client, err := bulk.New(bulk.ClientConfig{InstanceURL: server.URL, HTTPClient: server.Client()})
+	require.NoError(t, err)
+
+	mapping := filepath.Join(t.TempDir(), "mapping.json")
+	require.NoError(t, os.WriteFile(mapping, []byte(`{"fields": [{"field": "Name", "column": "company"}]}`), 0644))
+
+	opts := &root.Options{
+		Output: "table",
+		Stdin:  strings.NewReader("company,region\nAcme,EMEA\n"),
+		Stdout: &bytes.Buffer{},
+		Stderr: &bytes.Buffer{},
+	}
+	opts.SetBulkClient(client)
+
+	cmd := newImportCommand(opts)
+	cmd.SetArgs([]string{"Account", "--file", "-", "--mapping", mapping, "--skip-validation"})
+	require.NoError(t, cmd.Execute())
+	assert.Equal(t, "Name\nAcme\n", string(uploaded))
+
+	cmd = newImportCommand(opts)
+	cmd.SetArgs([]string{"Account", "--file", "-", "--file", "more.csv"})
+	assert.ErrorContains(t, cmd.Execute(), "cannot be combined")
+}
+
+func TestResumeCommand(t *testing.T) {
+	t.Setenv(config.HomeEnvVar, t.TempDir())
+
+	var (
+		mu       sync.Mutex
+		failPut  = true
+		uploaded = map[string]string{}
+		closed   []string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+
+		id := strings.TrimPrefix(r.URL.Path, "/services/data/v62.0/jobs/ingest/")
+		id = strings.TrimSuffix(id, "/batches")
+		switch r.Method {
+		case http.MethodPost:
+			_ = json.NewEncoder(w).Encode(bulk.JobInfo{ID: "750xx000000001", State: bulk.StateOpen})
+		case http.MethodPut:
+			if failPut {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			data, _ := io.ReadAll(r.Body)
+			uploaded[id] = string(data)
+			w.WriteHeader(http.StatusCreated)
+		case http.MethodPatch:
+			closed = append(closed, id)
+			_ = json.NewEncoder(w).Encode(bulk.JobInfo{ID: id, State: bulk.StateUploadComplete})
+		case http.MethodGet:
+			state := bulk.StateOpen
+			if id == "750xx000000003" {
+				state = bulk.StateJobComplete
+			}
+			_ = json.NewEncoder(w).Encode(bulk.JobInfo{ID: id, State: state})
+		}
+	}))
+	defer server.Close()
+
+	client, err := bulk.New(bulk.ClientConfig{InstanceURL: server.URL, HTTPClient: server.Client()})
+	require.NoError(t, err)
+
+	// An import whose upload fails leaves its job open and recorded
+	data := "Name;Description\r\nAcme;\"a;b\"\r\nGlobex;x\r\n"
+	csvFile := filepath.Join(t.TempDir(), "accounts.csv")
+	require.NoError(t, os.WriteFile(csvFile, []byte(data), 0644))
+
+	opts := &root.Options{
+		Output: "table",
+		Stdout: &bytes.Buffer{},
+		Stderr: &bytes.Buffer{},
+	}
+	opts.SetBulkClient(client)
+
+	cmd := newImportCommand(opts)
+	cmd.SetArgs([]string{"Account", "--file", csvFile, "--delimiter", ";", "--line-ending", "CRLF", "--skip-validation"})
+	require.Error(t, cmd.Execute())
+
+	pending, err := config.LoadPendingBulkJobs()
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Equal(t, "750xx000000001", pending[0].JobID)
+	assert.False(t, pending[0].Uploaded)
+	assert.Equal(t, "SEMICOLON", pending[0].ColumnDelimiter)
+
+	require.NoError(t, config.SavePendingBulkJob(config.PendingBulkJob{JobID: "750xx000000002", InstanceURL: server.URL, Object: "Account", Uploaded: true}))
+	require.NoError(t, config.SavePendingBulkJob(config.PendingBulkJob{JobID: "750xx000000003", InstanceURL: server.URL, Object: "Account"}))
+	require.NoError(t, config.SavePendingBulkJob(config.PendingBulkJob{JobID: "750xx000000004", InstanceURL: server.URL, Object: "Account"}))
+	require.NoError(t, config.SavePendingBulkJob(config.PendingBulkJob{JobID: "750xx000000005", InstanceURL: "https://other.my.salesforce.com", Object: "Account"}))
+
+	failPut = false
+	stdout := &bytes.Buffer{}
+	opts.Stdout = stdout
+	cmd = newResumeCommand(opts)
+	cmd.SetArgs([]string{})
+	err = cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "1 of 4 jobs could not be resumed")
+
+	assert.Equal(t, data, uploaded["750xx000000001"])
+	assert.Equal(t, []string{"750xx000000001", "750xx000000002"}, closed)
+	assert.Contains(t, stdout.String(), "uploaded and closed")
+	assert.Contains(t, stdout.String(), "already JobComplete")
+	assert.Contains(t, stdout.String(), "use --abort")
+
+	// The job that could not be resumed, and the other org's, are kept
+	pending, err = config.LoadPendingBulkJobs()
+	require.NoError(t, err)
+	require.Len(t, pending, 2)
+	assert.Equal(t, "750xx000000004", pending[0].JobID)
+	assert.Equal(t, "750xx000000005", pending[1].JobID)
+
+	cmd = newResumeCommand(opts)
+	cmd.SetArgs([]string{"750xx000000004", "--forget"})
+	require.NoError(t, cmd.Execute())
+	pending, err = config.LoadPendingBulkJobs()
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+}
+
+func TestPendingJobData(t *testing.T) {
+	limit := uploadLimit
+	uploadLimit = 30
+	t.Cleanup(func() { uploadLimit = limit })
+
+	dir := t.TempDir()
+	csvFile := filepath.Join(dir, "accounts.csv")
+	require.NoError(t, os.WriteFile(csvFile, []byte("Name,Description\nAcme,short\nGlobex,\"two\nlines\"\nInitech,short\n"), 0644))
+	jsonFile := filepath.Join(dir, "accounts.jsonl")
+	require.NoError(t, os.WriteFile(jsonFile, []byte("{\"Name\":\"Acme\"}\n{\"Name\":\"Globex\"}\n{\"Name\":\"Initech\"}\n"), 0644))
+
+	data, err := readImportFile(csvFile, "", csvFormat{})
+	require.NoError(t, err)
+	csvParts, err := splitImportFile(jobSource{file: csvFile}, data, csvFormat{})
+	require.NoError(t, err)
+	jsonParts, err := prepareJSONImport(context.Background(), bulk.OperationInsert, jsonFile, nil)
+	require.NoError(t, err)
+	require.Greater(t, len(csvParts), 1)
+	require.Greater(t, len(jsonParts), 1)
+
+	opts := &root.Options{Output: "table", Stdout: &bytes.Buffer{}, Stderr: &bytes.Buffer{}}
+	client, err := bulk.New(bulk.ClientConfig{InstanceURL: "https://example.my.salesforce.com", HTTPClient: http.DefaultClient})
+	require.NoError(t, err)
+
+	tests := []struct {
+		contentType bulk.ContentType
+		parts       []*importPart
+	}{
+		{bulk.ContentTypeCSV, csvParts},
+		{bulk.ContentTypeJSON, jsonParts},
+	}
+	for _, tt := range tests {
+		for _, part := range tt.parts {
+			cfg := bulk.JobConfig{Object: "Account", Operation: bulk.OperationInsert, ContentType: tt.contentType}
+			pending := trackJob(client, cfg, "750xx000000001", part.rows, part.source)
+			untrackJob("750xx000000001")
+
+			got, err := pendingJobData(opts, pending, cfg)
+			require.NoError(t, err, part.name)
+			assert.Equal(t, string(part.data), string(got), part.name)
+		}
+	}
+
+	// A changed file is not uploaded
+	require.NoError(t, os.WriteFile(csvFile, []byte("Name\nAcme\n"), 0644))
+	cfg := bulk.JobConfig{Object: "Account", Operation: bulk.OperationInsert}
+	pending := trackJob(client, cfg, "750xx000000001", 1, csvParts[1].source)
+	untrackJob("750xx000000001")
+	pending.FileSize = 1000
+	_, err = pendingJobData(opts, pending, cfg)
+	assert.ErrorContains(t, err, "has changed since the import")
+}
+
+func TestJobPruneCommand(t *testing.T) {
+	old := time.Now().AddDate(0, 0, -10).UTC().Format("2006-01-02T15:04:05.000+0000")
+	recent := time.Now().Add(-time.Hour).UTC().Format("2006-01-02T15:04:05.000+0000")
+
+	var (
+		mu      sync.Mutex
+		deleted []string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.Method == http.MethodDelete:
+			mu.Lock()
+			deleted = append(deleted, r.URL.Path)
+			mu.Unlock()
+			w.WriteHeader(http.StatusNoContent)
+		case strings.HasSuffix(r.URL.Path, "/jobs/ingest"):
+			_ = json.NewEncoder(w).Encode(bulk.JobsResponse{Done: true, Records: []bulk.JobInfo{
+				{ID: "750xx000000001", Object: "Account", Operation: bulk.OperationInsert, State: bulk.StateJobComplete, CreatedDate: old},
+				{ID: "750xx000000002", Object: "Account", Operation: bulk.OperationInsert, State: bulk.StateJobComplete, CreatedDate: recent},
+				{ID: "750xx000000003", Object: "Contact", Operation: bulk.OperationUpdate, State: bulk.StateFailed, CreatedDate: old},
+			}})
+		case strings.HasSuffix(r.URL.Path, "/jobs/query"):
+			_ = json.NewEncoder(w).Encode(bulk.QueryJobsResponse{Done: true, Records: []bulk.QueryJobInfo{
+				{ID: "750xx000000004", Object: "Lead", Operation: bulk.OperationQuery, State: bulk.StateJobComplete, CreatedDate: old},
+			}})
+		}
+	}))
+	defer server.Close()
+
+	client, err := bulk.New(bulk.ClientConfig{InstanceURL: server.URL, HTTPClient: server.Client()})
+	require.NoError(t, err)
+
+	t.Run("deletes matching jobs", func(t *testing.T) {
+		deleted = nil
+		stdout := &bytes.Buffer{}
+		opts := &root.Options{
+			Output: "table",
+			Stdin:  strings.NewReader("y\n"),
+			Stdout: stdout,
+			Stderr: &bytes.Buffer{},
+		}
+		opts.SetBulkClient(client)
+
+		cmd := newJobPruneCommand(opts)
+		cmd.SetArgs([]string{"--older-than", "7d", "--state", "JobComplete"})
+
+		require.NoError(t, cmd.Execute())
+		assert.Equal(t, []string{
+			"/services/data/v62.0/jobs/ingest/750xx000000001",
+			"/services/data/v62.0/jobs/query/750xx000000004",
+		}, deleted)
+		assert.Contains(t, stdout.String(), "Delete 2 bulk jobs? [y/N]: ")
+		assert.Contains(t, stdout.String(), "Deleted 2 of 2 jobs")
+	})
+
+	t.Run("dry run", func(t *testing.T) {
+		deleted = nil
+		stdout := &bytes.Buffer{}
+		opts := &root.Options{
+			Output: "table",
+			DryRun: true,
+			Stdout: stdout,
+			Stderr: &bytes.Buffer{},
+		}
+		opts.SetBulkClient(client)
+
+		cmd := newJobPruneCommand(opts)
+		cmd.SetArgs([]string{"--older-than", "7d", "--type", "ingest"})
+
+		require.NoError(t, cmd.Execute())
+		assert.Empty(t, deleted)
+		assert.Contains(t, stdout.String(), "750xx000000001")
+		assert.Contains(t, stdout.String(), "750xx000000003")
+		assert.NotContains(t, stdout.String(), "750xx000000002")
+		assert.NotContains(t, stdout.String(), "750xx000000004")
+	})
+
+	t.Run("rejects running states", func(t *testing.T) {
+		opts := &root.Options{Output: "table", Stdout: &bytes.Buffer{}, Stderr: &bytes.Buffer{}}
+		opts.SetBulkClient(client)
+
+		cmd := newJobPruneCommand(opts)
+		cmd.SetArgs([]string{"--older-than", "7d", "--state", "InProgress"})
+		cmd.SilenceUsage = true
+		cmd.SilenceErrors = true
+
+		err := cmd.Execute()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid state: InProgress")
+	})
+}
+
+func TestValidateRows(t *testing.T) {
+	desc := &api.SObjectDescribe{
+		Name: "Opportunity",
+		Fields: []api.Field{
+			{Name: "Id", Type: "id"},
+			{Name: "Name", Type: "string", Createable: true, Updateable: true},
+			{Name: "Amount", Type: "currency", Createable: true, Updateable: true, Nillable: true},
+			{Name: "CloseDate", Type: "date", Createable: true, Updateable: true},
+			{Name: "IsPrivate", Type: "boolean", Createable: true, Updateable: true, DefaultedOnCreate: true},
+			{Name: "AccountId", Type: "reference", Createable: true, Updateable: true, Nillable: true, RelationshipName: "Account"},
+			{Name: "ExpectedRevenue", Type: "currency", Nillable: true},
+			{Name: "External_Id__c", Type: "string", Createable: true, Nillable: true},
+		},
+	}
+
+	t.Run("insert", func(t *testing.T) {
+		header := []string{"Name", "Amount", "CloseDate", "IsPrivate", "Account.External_Id__c", "ExpectedRevenue", "Stage"}
+		rows := [][]string{
+			{"A", "100.50", "2024-01-15", "true", "A-1", "", "Open"},
+			{"B", "lots", "15/01/2024", "yes", "A-2", "", "Open"},
+			{"C", "#N/A", "", "false", "A-3", "", "Open"},
+		}
+
+		result := validateRows(desc, bulk.OperationInsert, "", header, rows)
+		assert.Equal(t, 3, result.Rows)
+		assert.Equal(t, 5, result.Errors)
+		assert.Equal(t, 0, result.Warnings)
+		assert.Equal(t, []string{
+			"ExpectedRevenue: field is not createable",
+			"Stage: not a field of Opportunity",
+			`row 2, Amount: "lots" is not a number`,
+			`row 2, CloseDate: "15/01/2024" is not a date (YYYY-MM-DD)`,
+			`row 2, IsPrivate: "yes" is not a boolean (true or false)`,
+		}, issueStrings(result.Issues))
+	})
+
+	t.Run("update", func(t *testing.T) {
+		result := validateRows(desc, bulk.OperationUpdate, "", []string{"Id", "External_Id__c"}, [][]string{{"006xx000001", "X"}})
+		assert.Equal(t, []string{"External_Id__c: field is not updateable"}, issueStrings(result.Issues))
+	})
+
+	t.Run("upsert missing required", func(t *testing.T) {
+		result := validateRows(desc, bulk.OperationUpsert, "External_Id__c", []string{"External_Id__c", "Amount"}, nil)
+		assert.Equal(t, 0, result.Errors)
+		assert.Equal(t, []string{"missing required fields for Opportunity: Name, CloseDate"}, issueStrings(result.Issues))
+		assert.NoError(t, result.err(false))
+		assert.EqualError(t, result.err(true), "validation failed: missing required fields for Opportunity: Name, CloseDate")
+	})
+}
+
+func issueStrings(issues []validationIssue) []string {
+	s := make([]string, len(issues))
+	for i, issue := range issues {
+		s[i] = issue.String()
+	}
+	return s
+}
+
+func TestValidateCommand(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/services/data/v62.0/sobjects/Account/describe" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(api.SObjectDescribe{
+			Name: "Account",
+			Fields: []api.Field{
+				{Name: "Name", Type: "string", Createable: true},
+				{Name: "NumberOfEmployees", Type: "int", Createable: true, Nillable: true},
+			},
+		})
+	}))
+	defer server.Close()
+
+	apiClient, err := api.New(api.ClientConfig{InstanceURL: server.URL, HTTPClient: server.Client()})
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	valid := filepath.Join(dir, "valid.csv")
+	require.NoError(t, os.WriteFile(valid, []byte("Name,NumberOfEmployees\nAcme,12\n"), 0644))
+	invalid := filepath.Join(dir, "invalid.jsonl")
+	require.NoError(t, os.WriteFile(invalid, []byte("{\"Name\":\"Acme\",\"NumberOfEmployees\":\"many\"}\n"), 0644))
+
+	run := func(file string) (string, error) {
+		stdout := &bytes.Buffer{}
+		opts := &root.Options{
+			Output:  "table",
+			NoColor: true,
+			Stdout:  stdout,
+			Stderr:  stdout,
+		}
+		opts.SetAPIClient(apiClient)
+
+		cmd := newValidateCommand(opts)
+		cmd.SetArgs([]string{"Account", "--file", file})
+		cmd.SilenceUsage = true
+		cmd.SilenceErrors = true
+		err := cmd.Execute()
+		return stdout.String(), err
+	}
+
+	output, err := run(valid)
+	require.NoError(t, err)
+	assert.Contains(t, output, "1 rows are valid for insert on Account")
+
+	output, err = run(invalid)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `row 1, NumberOfEmployees: "many" is not a whole number`)
+	assert.Contains(t, output, "1 rows checked: 1 errors, 0 warnings")
+}
@@ -0,0 +1,70 @@
+package bulkcmd
+
+import (
+	"bytes"
+	"encoding/csv"
+	"io"
+
+	"github.com/open-cli-collective/salesforce-cli/api/bulk"
+)
+
+// csvFormat is the column delimiter and line ending of CSV job data. The
+// zero value is Salesforce's default, commas and LF, and leaves both out of
+// job requests.
+type csvFormat struct {
+	delimiter  bulk.ColumnDelimiter
+	lineEnding bulk.LineEnding
+}
+
+// parseCSVFormat returns the format named by the --delimiter and
+// --line-ending flags; either may be empty for the default.
+func parseCSVFormat(delimiter, lineEnding string) (csvFormat, error) {
+	var f csvFormat
+	var err error
+	if delimiter != "" {
+		if f.delimiter, err = bulk.ParseColumnDelimiter(delimiter); err != nil {
+			return csvFormat{}, err
+		}
+	}
+	if lineEnding != "" {
+		if f.lineEnding, err = bulk.ParseLineEnding(lineEnding); err != nil {
+			return csvFormat{}, err
+		}
+	}
+	return f, nil
+}
+
+// jobCSVFormat returns the format of an ingest job's data, which its
+// results are written in too.
+func jobCSVFormat(job *bulk.JobInfo) csvFormat {
+	return csvFormat{delimiter: job.ColumnDelimiter, lineEnding: job.LineEnding}
+}
+
+// isDefault reports whether the format is commas and LF.
+func (f csvFormat) isDefault() bool {
+	return f.delimiter.Rune() == ',' && f.lineEnding != bulk.LineEndingCRLF
+}
+
+// reader returns a CSV reader of data in this format. Either line ending is
+// read.
+func (f csvFormat) reader(data []byte) *csv.Reader {
+	r := csv.NewReader(bytes.NewReader(data))
+	r.Comma = f.delimiter.Rune()
+	return r
+}
+
+// writer returns a CSV writer of data in this format.
+func (f csvFormat) writer(w io.Writer) *csv.Writer {
+	cw := csv.NewWriter(w)
+	cw.Comma = f.delimiter.Rune()
+	cw.UseCRLF = f.lineEnding == bulk.LineEndingCRLF
+	return cw
+}
+
+// newline returns the line ending as text.
+func (f csvFormat) newline() string {
+	if f.lineEnding == bulk.LineEndingCRLF {
+		return "\r\n"
+	}
+	return "\n"
+}
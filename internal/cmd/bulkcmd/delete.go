@@ -0,0 +1,245 @@
+package bulkcmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/salesforce-cli/api/bulk"
+	"github.com/open-cli-collective/salesforce-cli/internal/cmd/root"
+)
+
+// maxFailedRows is the most failed records listed after a job; the rest
+// are only counted.
+const maxFailedRows = 20
+
+func newDeleteCommand(opts *root.Options) *cobra.Command {
+	var (
+		file         string
+		hard         bool
+		yes          bool
+		errorsOutput string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "delete <object>",
+		Short: "Delete records listed in a file using Bulk API 2.0",
+		Long: `Delete the records listed in a file using a Bulk API 2.0 delete job.
+
+The file is a CSV file with an Id column, or a plain list of record Ids, one
+per line. Deleted records go to the Recycle Bin; with --hard they are deleted
+permanently, which requires the "Bulk API Hard Delete" permission.
+
+The command asks for confirmation before creating the job, and names the org
+and asks again in a production org. Use --yes to skip both prompts (e.g. in
+scripts).
+
+It then waits for the job to complete, up to --wait-timeout (default 30m),
+and lists the records that failed to delete. Use --errors-output to save all
+of them as CSV. The command fails if any record failed.
+
+With --dry-run, the file is checked and the job that would be created is
+shown, without creating it.
+
+Examples:
+  sfdc bulk delete Account --file ids.csv
+  sfdc bulk delete Lead --file leads.txt --yes
+  sfdc bulk delete Account --file ids.csv --hard
+  sfdc bulk delete Contact --file ids.csv --errors-output failed.csv`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDelete(cmd.Context(), opts, args[0], file, hard, yes, errorsOutput)
+		},
+	}
+
+	cmd.Flags().StringVarP(&file, "file", "f", "", "CSV file with an Id column, or a list of Ids (required)")
+	cmd.Flags().BoolVar(&hard, "hard", false, "Delete permanently, bypassing the Recycle Bin (hardDelete)")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Skip the confirmation prompts")
+	cmd.Flags().StringVar(&errorsOutput, "errors-output", "", "Write the records that failed to delete to this CSV file")
+	cmd.Flags().BoolVar(&opts.WaitOnRateLimit, "wait-on-rate-limit", false, "Wait and retry when rate limited instead of failing")
+	cmd.Flags().DurationVar(&opts.WaitTimeout, "wait-timeout", root.DefaultWaitTimeout, "How long to wait for the job to complete (0 for no limit)")
+	cmd.Flags().Float64Var(&opts.MaxRPS, "max-rps", 0, "Send at most this many API requests per second (0 for the max_rps setting)")
+
+	_ = cmd.MarkFlagRequired("file")
+
+	return cmd
+}
+
+func runDelete(ctx context.Context, opts *root.Options, object, file string, hard, yes bool, errorsOutput string) error {
+	op := bulk.OperationDelete
+	if hard {
+		op = bulk.OperationHardDelete
+	}
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return fmt.Errorf("failed to read file: %w", err)
+	}
+	data, err = prepareDeleteData(opts, data, csvFormat{})
+	if err != nil {
+		return err
+	}
+	rows, err := countCSVRows(data, csvFormat{})
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return fmt.Errorf("no record Ids in %s", file)
+	}
+
+	client, err := opts.BulkClient()
+	if err != nil {
+		return fmt.Errorf("failed to create bulk client: %w", err)
+	}
+
+	jobConfig := bulk.JobConfig{
+		Object:    object,
+		Operation: op,
+	}
+
+	if opts.DryRun {
+		return opts.PrintDryRun(root.DryRunRequest{
+			Operation: "bulk " + string(op),
+			Object:    object,
+			Method:    http.MethodPost,
+			URL:       client.IngestJobsURL(),
+			Payload:   jobConfig.Request(),
+			Details: map[string]interface{}{
+				"File": file,
+				"Rows": rows,
+			},
+		})
+	}
+
+	v := opts.View()
+
+	if !yes {
+		what := "Delete"
+		if hard {
+			what = "Permanently delete"
+		}
+		proceed, err := opts.Confirm(fmt.Sprintf("%s %d %s records? [y/N]: ", what, rows, object))
+		if err != nil {
+			return err
+		}
+		if !proceed {
+			v.Info("Cancelled")
+			return nil
+		}
+	}
+
+	proceed, err := opts.ConfirmProduction(ctx, fmt.Sprintf("bulk %s on %s", op, object), yes)
+	if err != nil {
+		return err
+	}
+	if !proceed {
+		v.Info("Cancelled")
+		return nil
+	}
+
+	v.Info("Creating bulk %s job for %s...", op, object)
+	job, err := client.CreateJob(ctx, jobConfig)
+	if err != nil {
+		return fmt.Errorf("failed to create job: %w", err)
+	}
+
+	v.Info("Job created: %s", job.ID)
+
+	v.Info("Uploading %d record Ids...", rows)
+	if err := client.UploadJobData(ctx, job.ID, data); err != nil {
+		return fmt.Errorf("failed to upload data: %w", err)
+	}
+
+	job, err = client.CloseJob(ctx, job.ID)
+	if err != nil {
+		return fmt.Errorf("failed to close job: %w", err)
+	}
+
+	jobID := job.ID
+	v.Info("Waiting for job to complete...")
+	job, err = waitForJob(ctx, opts, client, jobID, rows)
+	if err != nil {
+		return fmt.Errorf("failed waiting for job %s: %w; use 'sfdc bulk job status %s' to check on it", jobID, err, jobID)
+	}
+
+	var failed []map[string]string
+	if job.NumberRecordsFailed > 0 {
+		failedData, err := client.GetFailedResults(ctx, job.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get failed results: %w", err)
+		}
+		if errorsOutput != "" {
+			if err := os.WriteFile(errorsOutput, failedData, 0644); err != nil {
+				return fmt.Errorf("failed to write errors file: %w", err)
+			}
+		}
+		failed, err = bulk.ParseCSVRecords(failedData)
+		if err != nil {
+			return err
+		}
+	}
+
+	if opts.Output == "json" {
+		if err := v.JSON(map[string]interface{}{
+			"job":           job,
+			"failedRecords": failed,
+		}); err != nil {
+			return err
+		}
+	} else {
+		renderDeleteResult(opts, job, failed, errorsOutput)
+	}
+
+	if job.State != bulk.StateJobComplete {
+		return fmt.Errorf("job %s ended in state %s", job.ID, job.State)
+	}
+	if job.NumberRecordsFailed > 0 {
+		return fmt.Errorf("%d of %d records failed to delete", job.NumberRecordsFailed, job.NumberRecordsProcessed)
+	}
+	return nil
+}
+
+// renderDeleteResult prints the outcome of a delete job and the first
+// maxFailedRows records that failed.
+func renderDeleteResult(opts *root.Options, job *bulk.JobInfo, failed []map[string]string, errorsOutput string) {
+	v := opts.View()
+
+	deleted := job.NumberRecordsProcessed - job.NumberRecordsFailed
+	if job.State == bulk.StateJobComplete && job.NumberRecordsFailed == 0 {
+		v.Success("Deleted %d %s records", deleted, job.Object)
+	} else {
+		v.Info("Job %s: %s", job.ID, job.State)
+		v.Info("  Deleted: %d", deleted)
+		v.Info("  Failed:  %d", job.NumberRecordsFailed)
+	}
+	if job.ErrorMessage != "" {
+		v.Error("%s", job.ErrorMessage)
+	}
+
+	if len(failed) == 0 {
+		return
+	}
+
+	rows := make([][]string, 0, min(len(failed), maxFailedRows))
+	for _, rec := range failed[:min(len(failed), maxFailedRows)] {
+		id := rec["sf__Id"]
+		if id == "" {
+			id = rec["Id"]
+		}
+		rows = append(rows, []string{id, rec["sf__Error"]})
+	}
+	v.Info("\nFailed records:")
+	_ = v.Table([]string{"Id", "Error"}, rows)
+
+	if len(failed) > maxFailedRows {
+		v.Info("... and %d more", len(failed)-maxFailedRows)
+	}
+	if errorsOutput != "" {
+		v.Info("Failed records written to %s", errorsOutput)
+	} else {
+		v.Info("Use 'sfdc bulk job errors %s' to get all failed records.", job.ID)
+	}
+}
@@ -1,19 +1,37 @@
 package bulkcmd
 
 import (
+	"bufio"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 
 	"github.com/spf13/cobra"
 
+	"github.com/open-cli-collective/salesforce-cli/api"
 	"github.com/open-cli-collective/salesforce-cli/api/bulk"
+	"github.com/open-cli-collective/salesforce-cli/api/bulkv1"
 	"github.com/open-cli-collective/salesforce-cli/internal/cmd/root"
+	"github.com/open-cli-collective/salesforce-cli/internal/view"
 )
 
+// exportFlags holds the flags of the export command.
+type exportFlags struct {
+	output     string
+	format     string
+	pkChunking bool
+	chunkSize  int
+	delimiter  string
+	lineEnding string
+}
+
 func newExportCommand(opts *root.Options) *cobra.Command {
 	var (
-		output string
+		flags exportFlags
+		out   string
 	)
 
 	cmd := &cobra.Command{
@@ -22,70 +40,311 @@ func newExportCommand(opts *root.Options) *cobra.Command {
 		Long: `Export data from Salesforce using Bulk API 2.0 query.
 
 Use this for exporting large datasets. For smaller queries, use the query command.
+The export gives up waiting for the query job after --wait-timeout (default 30m).
+Large results are downloaded page by page until every record has been fetched.
+
+Results are CSV. Use --format json to print them as JSON records instead,
+or an output file ending in .json to write JSON. Empty values become null
+in JSON. -o/--output always names a file: the global output formats do not
+apply to this command.
+
+Use --format jsonl or --format parquet (or an output file ending in .jsonl,
+.ndjson, or .parquet) to convert the results as they are downloaded. The
+object's fields are described so that numbers, booleans, dates, and
+datetimes get their own types instead of being strings, including fields
+of related objects such as Owner.Name. Parquet needs an output file.
+
+Results are streamed to stdout or the output file as each page is
+downloaded, so exports of any size can be written without holding them in
+memory. With an output file, only a summary is printed. If the download
+fails part way, the incomplete file is removed. --out is an alias of
+--output.
+
+CSV results use commas and LF line endings unless --delimiter (COMMA,
+SEMICOLON, TAB, PIPE, CARET, or BACKQUOTE) and --line-ending (LF or CRLF)
+ask for others, e.g. for spreadsheets with European or Windows settings.
+They only apply to CSV results of Bulk API 2.0 queries.
+
+For objects with tens of millions of records, use --pk-chunking to run the
+query as a Bulk API 1.0 job that Salesforce splits into batches by record
+ID, --chunk-size records each (default 100,000, at most 250,000). Every
+batch is tracked until it finishes, and their results are downloaded as
+one export. The query must select from a single object that supports PK
+chunking, such as Account, Contact, or a custom object.
+
+With --wait-on-rate-limit, requests rejected by Salesforce rate limits are
+retried after the wait the server asks for (up to 15 minutes in total per
+request), instead of failing the export.
 
 Examples:
   sfdc bulk export "SELECT Id, Name, Industry FROM Account"
   sfdc bulk export "SELECT Id, Name FROM Account" --output accounts.csv
-  sfdc bulk export "SELECT * FROM Contact" --output contacts.csv`,
+  sfdc bulk export "SELECT Id, Name FROM Account" --format json
+  sfdc bulk export "SELECT Id, Name FROM Account" --output accounts.json
+  sfdc bulk export "SELECT Id FROM Contact" --output contacts.csv --wait-on-rate-limit
+  sfdc bulk export "SELECT Id, Amount, CloseDate FROM Opportunity" --format jsonl
+  sfdc bulk export "SELECT Id, Name FROM Account" --output accounts.csv --delimiter semicolon --line-ending CRLF
+  sfdc bulk export "SELECT Id, Amount, CloseDate FROM Opportunity" --output opps.parquet
+  sfdc bulk export "SELECT Id, Name FROM Lead" --output leads.csv --pk-chunking --chunk-size 250000`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runExport(cmd.Context(), opts, args[0], output)
+			if out != "" {
+				flags.output = out
+			}
+			if cmd.Flags().Changed("chunk-size") {
+				flags.pkChunking = true
+			}
+			return runExport(cmd.Context(), opts, args[0], flags)
 		},
 	}
 
-	cmd.Flags().StringVarP(&output, "output", "o", "", "Output file path (prints to stdout if not specified)")
+	cmd.Flags().StringVarP(&flags.output, "output", "o", "", "Output file path (prints to stdout if not specified)")
+	cmd.Flags().StringVar(&out, "out", "", "Alias of --output")
+	cmd.Flags().StringVar(&flags.format, "format", "", "Results format: csv, json, jsonl, or parquet (default from the output file extension, else csv)")
+	cmd.Flags().StringVar(&flags.delimiter, "delimiter", "", "CSV column delimiter: COMMA, SEMICOLON, TAB, PIPE, CARET, or BACKQUOTE (default COMMA)")
+	cmd.Flags().StringVar(&flags.lineEnding, "line-ending", "", "CSV line ending: LF or CRLF (default LF)")
+	cmd.Flags().BoolVar(&flags.pkChunking, "pk-chunking", false, "Split the query into batches by record ID with a Bulk API 1.0 job")
+	cmd.Flags().IntVar(&flags.chunkSize, "chunk-size", 0, "Records in each PK chunking batch (implies --pk-chunking; default 100000)")
+	cmd.Flags().BoolVar(&opts.WaitOnRateLimit, "wait-on-rate-limit", false, "Wait and retry when rate limited instead of failing")
+	cmd.Flags().DurationVar(&opts.WaitTimeout, "wait-timeout", root.DefaultWaitTimeout, "How long to wait for the query job to complete (0 for no limit)")
+	cmd.Flags().Float64Var(&opts.MaxRPS, "max-rps", 0, "Send at most this many API requests per second (0 for the max_rps setting)")
+
+	_ = cmd.Flags().MarkHidden("out")
+	cmd.MarkFlagsMutuallyExclusive("output", "out")
 
 	return cmd
 }
 
-func runExport(ctx context.Context, opts *root.Options, soql, output string) error {
-	client, err := opts.BulkClient()
+// queryResults are the results of a completed query job, for download.
+type queryResults struct {
+	// object is the object queried, whose fields type converted results
+	object string
+	// download writes the results to w as CSV, returning the number of
+	// records
+	download func(w io.Writer) (int, error)
+}
+
+// runExport runs a query job and writes its results to flags.output, a
+// file, or stdout if empty, in flags.format. An empty format is taken from
+// the output file.
+func runExport(ctx context.Context, opts *root.Options, soql string, flags exportFlags) error {
+	output := flags.output
+	if isFormatName(output) {
+		// -o here names a file, not one of the global output formats
+		return fmt.Errorf("--output names the file to write, not %q: use --format to choose csv, json, jsonl, or parquet results", output)
+	}
+	format, err := exportFormat(flags.format, output)
+	if err != nil {
+		return err
+	}
+	if format == formatParquet && output == "" {
+		return fmt.Errorf("parquet results must be written to a file (use --output)")
+	}
+	if flags.chunkSize < 0 || flags.chunkSize > bulkv1.MaxChunkSize {
+		return fmt.Errorf("--chunk-size must be between 1 and %d", bulkv1.MaxChunkSize)
+	}
+	csvFmt, err := parseCSVFormat(flags.delimiter, flags.lineEnding)
 	if err != nil {
-		return fmt.Errorf("failed to create bulk client: %w", err)
+		return err
+	}
+	if !csvFmt.isDefault() {
+		// Conversions and Bulk API 1.0 read the results as plain CSV
+		if format != formatCSV {
+			return fmt.Errorf("--delimiter and --line-ending only apply to CSV results")
+		}
+		if flags.pkChunking {
+			return fmt.Errorf("--delimiter and --line-ending cannot be used with --pk-chunking")
+		}
 	}
 
 	v := opts.View()
+	if output == "" && (format == formatJSON || format == formatJSONL) {
+		// Keep stdout valid JSON
+		v.SetOutput(opts.Stderr)
+	}
+
+	var results *queryResults
+	if flags.pkChunking {
+		results, err = runChunkedQuery(ctx, opts, v, soql, flags.chunkSize)
+	} else {
+		results, err = runQueryJob(ctx, opts, v, soql, csvFmt)
+	}
+	if err != nil {
+		return err
+	}
+
+	// Get results
+	v.Info("Downloading results...")
+	if output == "" {
+		_, err := streamResults(ctx, opts, results, format, opts.Stdout)
+		return err
+	}
+
+	records, err := writeResultsFile(output, func(w io.Writer) (int, error) {
+		return streamResults(ctx, opts, results, format, w)
+	})
+	if err != nil {
+		return err
+	}
+	v.Success("Exported %d records to %s", records, output)
+
+	return nil
+}
+
+// runQueryJob runs soql as a Bulk API 2.0 query job, with CSV results in
+// format, and waits for it to complete.
+func runQueryJob(ctx context.Context, opts *root.Options, v *view.View, soql string, format csvFormat) (*queryResults, error) {
+	client, err := opts.BulkClient()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create bulk client: %w", err)
+	}
 
 	// Create query job
 	v.Info("Creating bulk query job...")
 	job, err := client.CreateQueryJob(ctx, bulk.QueryConfig{
-		Query: soql,
+		Query:           soql,
+		LineEnding:      format.lineEnding,
+		ColumnDelimiter: format.delimiter,
 	})
 	if err != nil {
-		return fmt.Errorf("failed to create query job: %w", err)
+		return nil, fmt.Errorf("failed to create query job: %w", err)
 	}
 
 	v.Info("Job created: %s", job.ID)
 
 	// Poll until complete
 	v.Info("Waiting for query to complete...")
-	job, err = client.PollQueryJob(ctx, job.ID, bulk.DefaultPollConfig())
+	job, err = client.PollQueryJob(ctx, job.ID, pollConfig(opts))
 	if err != nil {
-		return fmt.Errorf("failed waiting for query job: %w", err)
+		return nil, fmt.Errorf("failed waiting for query job: %w", err)
 	}
 
 	if job.State != bulk.StateJobComplete {
-		return fmt.Errorf("query job failed with state: %s", job.State)
+		return nil, fmt.Errorf("query job failed with state: %s", job.State)
 	}
 
 	v.Info("Query completed. Records: %d", job.NumberRecordsProcessed)
 
-	// Get results
-	v.Info("Downloading results...")
-	data, err := client.GetQueryResults(ctx, job.ID)
-	if err != nil {
-		return fmt.Errorf("failed to get query results: %w", err)
+	return &queryResults{
+		object: queryObject(job, soql),
+		download: func(w io.Writer) (int, error) {
+			return client.WriteQueryResults(ctx, job.ID, w, bulk.QueryResultsOptions{})
+		},
+	}, nil
+}
+
+// streamResults writes query results to w as they are downloaded: as CSV,
+// as JSON records of strings, or converted to JSONL or Parquet with values
+// typed by the fields of the queried object. It returns the number of
+// records written.
+func streamResults(ctx context.Context, opts *root.Options, results *queryResults, format string, w io.Writer) (int, error) {
+	if format == formatCSV {
+		records, err := results.download(w)
+		if err != nil {
+			return 0, fmt.Errorf("failed to get query results: %w", err)
+		}
+		return records, nil
 	}
 
-	// Write to file or stdout
-	if output != "" {
-		if err := os.WriteFile(output, data, 0644); err != nil {
-			return fmt.Errorf("failed to write output file: %w", err)
+	describe := func(ctx context.Context, objectName string) (*api.SObjectDescribe, error) {
+		apiClient, err := opts.APIClient()
+		if err != nil {
+			return nil, fmt.Errorf("failed to create API client: %w", err)
 		}
-		v.Info("Results written to %s", output)
+		return apiClient.DescribeSObject(ctx, objectName)
+	}
+
+	pr, pw := io.Pipe()
+	downloaded := make(chan error, 1)
+	go func() {
+		_, err := results.download(pw)
+		_ = pw.CloseWithError(err)
+		downloaded <- err
+	}()
+
+	var (
+		records int
+		err     error
+	)
+	if format == formatJSON {
+		records, err = writeJSONRecords(pr, w)
 	} else {
-		fmt.Fprintln(opts.Stdout, string(data))
+		records, err = convertResults(ctx, describe, results.object, pr, w, format)
+	}
+	// Stop the download if the conversion gave up part way
+	_ = pr.Close()
+	if downloadErr := <-downloaded; downloadErr != nil && !errors.Is(downloadErr, io.ErrClosedPipe) {
+		return 0, fmt.Errorf("failed to get query results: %w", downloadErr)
 	}
+	if err != nil {
+		return 0, err
+	}
+	return records, nil
+}
 
-	return nil
+// writeResultsFile creates a file and writes query results to it with
+// write, removing the file if that fails so that a partial export is not
+// mistaken for a complete one.
+func writeResultsFile(path string, write func(w io.Writer) (int, error)) (int, error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create output file: %w", err)
+	}
+
+	records, err := write(f)
+	if closeErr := f.Close(); err == nil && closeErr != nil {
+		err = fmt.Errorf("failed to write output file: %w", closeErr)
+	}
+	if err != nil {
+		_ = os.Remove(path)
+		return 0, err
+	}
+	return records, nil
+}
+
+// writeJSONRecords reads CSV query results from r and writes them to w as
+// an indented JSON array of records, one record at a time. Empty values
+// become null. It returns the number of records written.
+func writeJSONRecords(r io.Reader, w io.Writer) (int, error) {
+	reader := bulk.NewCSVRecordReader(r)
+	bw := bufio.NewWriter(w)
+
+	records := 0
+	for {
+		rec, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return records, fmt.Errorf("failed to parse query results: %w", err)
+		}
+
+		record := make(map[string]interface{}, len(rec))
+		for name, value := range rec {
+			if value == "" {
+				record[name] = nil
+			} else {
+				record[name] = value
+			}
+		}
+		data, err := json.MarshalIndent(record, "  ", "  ")
+		if err != nil {
+			return records, fmt.Errorf("failed to encode records: %w", err)
+		}
+
+		sep := ",\n  "
+		if records == 0 {
+			sep = "[\n  "
+		}
+		_, _ = bw.WriteString(sep)
+		_, _ = bw.Write(data)
+		records++
+	}
+
+	end := "\n]\n"
+	if records == 0 {
+		end = "[]\n"
+	}
+	_, _ = bw.WriteString(end)
+	return records, bw.Flush()
 }
@@ -0,0 +1,324 @@
+package bulkcmd
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/open-cli-collective/salesforce-cli/api"
+	"github.com/open-cli-collective/salesforce-cli/api/bulk"
+	"github.com/open-cli-collective/salesforce-cli/internal/parquet"
+)
+
+// Export formats.
+const (
+	formatCSV     = "csv"
+	formatJSON    = "json"
+	formatJSONL   = "jsonl"
+	formatParquet = "parquet"
+)
+
+// exportFormat returns the format to export in: format if set, otherwise
+// the one the output file's extension names, otherwise CSV.
+func exportFormat(format, output string) (string, error) {
+	switch strings.ToLower(format) {
+	case formatCSV, formatJSON, formatJSONL, formatParquet:
+		return strings.ToLower(format), nil
+	case "":
+	default:
+		return "", fmt.Errorf("invalid format: %s (must be csv, json, jsonl, or parquet)", format)
+	}
+
+	switch strings.ToLower(filepath.Ext(output)) {
+	case ".json":
+		return formatJSON, nil
+	case ".jsonl", ".ndjson":
+		return formatJSONL, nil
+	case ".parquet":
+		return formatParquet, nil
+	}
+	return formatCSV, nil
+}
+
+// isFormatName reports whether an output file name is really the name of an
+// output format, as in -o json, which the global --output flag takes.
+func isFormatName(output string) bool {
+	switch strings.ToLower(output) {
+	case "table", "plain", formatCSV, formatJSON, formatJSONL, formatParquet:
+		return true
+	}
+	return false
+}
+
+// describeFunc describes an object.
+type describeFunc func(ctx context.Context, objectName string) (*api.SObjectDescribe, error)
+
+// fieldKind is the kind of value an exported column holds.
+type fieldKind int
+
+const (
+	kindString fieldKind = iota
+	kindBoolean
+	kindInteger
+	kindNumber
+	kindDate
+	kindDateTime
+)
+
+// kindOf returns the kind of values of a Salesforce field type.
+func kindOf(fieldType string) fieldKind {
+	switch fieldType {
+	case "boolean":
+		return kindBoolean
+	case "int", "long":
+		return kindInteger
+	case "double", "currency", "percent":
+		return kindNumber
+	case "date":
+		return kindDate
+	case "datetime":
+		return kindDateTime
+	}
+	return kindString
+}
+
+// parquetType returns the Parquet column type for a kind.
+func (k fieldKind) parquetType() parquet.Type {
+	switch k {
+	case kindBoolean:
+		return parquet.Boolean
+	case kindInteger:
+		return parquet.Int64
+	case kindNumber:
+		return parquet.Double
+	case kindDate:
+		return parquet.Date
+	case kindDateTime:
+		return parquet.Timestamp
+	}
+	return parquet.String
+}
+
+// fromPattern finds the object a query selects from.
+var fromPattern = regexp.MustCompile(`(?i)\bFROM\s+([A-Za-z0-9_]+)`)
+
+// queryObject returns the object a query job running soql selects from.
+func queryObject(job *bulk.QueryJobInfo, soql string) string {
+	if job.Object != "" {
+		return job.Object
+	}
+	if m := fromPattern.FindStringSubmatch(soql); m != nil {
+		return m[1]
+	}
+	return ""
+}
+
+// columnKinds returns the kind of each column of query results, from the
+// describe of the object and of the objects relationship columns such as
+// Owner.Name lead to. Columns that are not fields, such as aggregates, are
+// strings.
+func columnKinds(ctx context.Context, describe describeFunc, object string, header []string) ([]fieldKind, error) {
+	described := map[string]map[string]api.Field{}
+	fields := func(object string) (map[string]api.Field, error) {
+		key := strings.ToLower(object)
+		if f, ok := described[key]; ok {
+			return f, nil
+		}
+		desc, err := describe(ctx, object)
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe %s: %w", object, err)
+		}
+		f := make(map[string]api.Field, len(desc.Fields))
+		for _, field := range desc.Fields {
+			f[strings.ToLower(field.Name)] = field
+			if field.RelationshipName != "" {
+				f["rel:"+strings.ToLower(field.RelationshipName)] = field
+			}
+		}
+		described[key] = f
+		return f, nil
+	}
+
+	kinds := make([]fieldKind, len(header))
+	for i, column := range header {
+		path := strings.Split(column, ".")
+		current := object
+		for j, name := range path {
+			f, err := fields(current)
+			if err != nil {
+				return nil, err
+			}
+			if j == len(path)-1 {
+				kinds[i] = kindOf(f[strings.ToLower(name)].Type)
+				break
+			}
+			rel, ok := f["rel:"+strings.ToLower(name)]
+			if !ok || len(rel.ReferenceTo) == 0 {
+				break
+			}
+			current = rel.ReferenceTo[0]
+		}
+	}
+	return kinds, nil
+}
+
+// Formats of date and datetime values in Bulk API results.
+const (
+	bulkDate     = "2006-01-02"
+	bulkDateTime = "2006-01-02T15:04:05.000Z0700"
+)
+
+// typedValue converts a CSV value to its column's kind: nil if empty, or a
+// bool, int64, float64, time.Time, or string.
+func typedValue(kind fieldKind, s string) (interface{}, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	switch kind {
+	case kindBoolean:
+		return strconv.ParseBool(s)
+	case kindInteger:
+		return strconv.ParseInt(s, 10, 64)
+	case kindNumber:
+		return strconv.ParseFloat(s, 64)
+	case kindDate:
+		return time.Parse(bulkDate, s)
+	case kindDateTime:
+		t, err := time.Parse(bulkDateTime, s)
+		if err != nil {
+			t, err = time.Parse(time.RFC3339Nano, s)
+		}
+		return t.UTC(), err
+	}
+	return s, nil
+}
+
+// rowWriter writes typed rows in an export format.
+type rowWriter interface {
+	Write(row []interface{}) error
+	Close() error
+}
+
+// convertResults reads CSV query results from r and writes them to w in
+// format, JSONL or Parquet, with values typed by the fields of object. It
+// returns the number of records written.
+func convertResults(ctx context.Context, describe describeFunc, object string, r io.Reader, w io.Writer, format string) (int, error) {
+	cr := csv.NewReader(r)
+	cr.ReuseRecord = true
+
+	header, err := cr.Read()
+	if err != nil && !errors.Is(err, io.EOF) {
+		return 0, fmt.Errorf("failed to read query results: %w", err)
+	}
+	header = append([]string(nil), header...)
+
+	kinds := make([]fieldKind, len(header))
+	if len(header) > 0 && object != "" {
+		if kinds, err = columnKinds(ctx, describe, object, header); err != nil {
+			return 0, err
+		}
+	}
+
+	var out rowWriter
+	if format == formatParquet {
+		columns := make([]parquet.Column, len(header))
+		for i, name := range header {
+			columns[i] = parquet.Column{Name: name, Type: kinds[i].parquetType()}
+		}
+		out = parquet.NewWriter(w, columns)
+	} else {
+		out = newJSONLWriter(w, header, kinds)
+	}
+
+	records := 0
+	values := make([]interface{}, len(header))
+	for len(header) > 0 {
+		row, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return records, fmt.Errorf("failed to read query results: %w", err)
+		}
+
+		line, _ := cr.FieldPos(0)
+		for i, s := range row {
+			if values[i], err = typedValue(kinds[i], s); err != nil {
+				return records, fmt.Errorf("line %d: column %s: invalid value %q", line, header[i], s)
+			}
+		}
+		if err := out.Write(values); err != nil {
+			return records, err
+		}
+		records++
+	}
+
+	return records, out.Close()
+}
+
+// jsonlWriter writes rows as JSON objects, one per line, with keys in
+// column order.
+type jsonlWriter struct {
+	w     *bufio.Writer
+	keys  [][]byte
+	kinds []fieldKind
+	buf   []byte
+}
+
+func newJSONLWriter(w io.Writer, header []string, kinds []fieldKind) *jsonlWriter {
+	keys := make([][]byte, len(header))
+	for i, name := range header {
+		keys[i], _ = json.Marshal(name)
+	}
+	return &jsonlWriter{w: bufio.NewWriter(w), keys: keys, kinds: kinds}
+}
+
+func (j *jsonlWriter) Write(row []interface{}) error {
+	b := append(j.buf[:0], '{')
+	for i, value := range row {
+		if i > 0 {
+			b = append(b, ',')
+		}
+		b = append(b, j.keys[i]...)
+		b = append(b, ':')
+
+		switch v := value.(type) {
+		case nil:
+			b = append(b, "null"...)
+		case bool:
+			b = strconv.AppendBool(b, v)
+		case int64:
+			b = strconv.AppendInt(b, v, 10)
+		case float64:
+			b = strconv.AppendFloat(b, v, 'f', -1, 64)
+		case time.Time:
+			layout := "2006-01-02T15:04:05.000Z07:00"
+			if j.kinds[i] == kindDate {
+				layout = bulkDate
+			}
+			b = strconv.AppendQuote(b, v.Format(layout))
+		default:
+			s, _ := json.Marshal(v)
+			b = append(b, s...)
+		}
+	}
+	b = append(b, '}', '\n')
+	j.buf = b
+
+	_, err := j.w.Write(b)
+	return err
+}
+
+func (j *jsonlWriter) Close() error {
+	return j.w.Flush()
+}
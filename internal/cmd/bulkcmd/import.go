@@ -1,24 +1,30 @@
 package bulkcmd
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"net/http"
 	"os"
+	"slices"
 	"strings"
 
 	"github.com/spf13/cobra"
 
+	"github.com/open-cli-collective/salesforce-cli/api"
 	"github.com/open-cli-collective/salesforce-cli/api/bulk"
 	"github.com/open-cli-collective/salesforce-cli/internal/cmd/root"
+	"github.com/open-cli-collective/salesforce-cli/internal/csvmap"
 )
 
+// maxRecordLinks is the most record URLs printed after an import; above it,
+// only the number of created records is shown.
+const maxRecordLinks = 10
+
 func newImportCommand(opts *root.Options) *cobra.Command {
-	var (
-		file       string
-		operation  string
-		externalID string
-		wait       bool
-	)
+	var flags importFlags
 
 	cmd := &cobra.Command{
 		Use:   "import <object>",
@@ -28,47 +34,297 @@ func newImportCommand(opts *root.Options) *cobra.Command {
 The CSV file must have a header row with field names matching the Salesforce object.
 
 Operations:
-  insert  - Create new records
-  update  - Update existing records (requires Id column)
-  upsert  - Insert or update based on external ID field
-  delete  - Delete records (requires Id column)
+  insert      - Create new records
+  update      - Update existing records (requires Id column)
+  upsert      - Insert or update based on external ID field
+  delete      - Delete records (requires Id column)
+  hardDelete  - Permanently delete records, bypassing the Recycle Bin (requires Id column)
+
+For delete operations the file may also be a plain list of record Ids, one per
+line, without a header row.
+
+With --mapping, the file is transformed before it is uploaded, as described by
+a JSON mapping file that lists the fields to import and where their values come
+from: a column of the file, a constant value, or several columns joined
+together. Dates can be reformatted to the Salesforce format on the way:
+
+  {
+    "fields": [
+      {"field": "Name", "column": "company_name"},
+      {"field": "Type", "value": "Customer"},
+      {"field": "CloseDate", "column": "closed_on", "dateFormat": "MM/DD/YYYY"},
+      {"field": "Description", "concat": ["notes", "region"], "separator": " - "}
+    ]
+  }
+
+Date formats use YYYY, MM, DD, HH, mm, and ss; "dateTimeFormat" converts dates
+with times, taken as UTC unless the format has a zone (Z). Columns not mapped
+are left out.
+
+JSON data is imported as a JSON ingest job, so nested and typed values don't
+have to be flattened into CSV. Files ending in .json (an array of records),
+.jsonl, or .ndjson (one record per line) are read as JSON; use --content-type
+to choose otherwise. --mapping only applies to CSV.
+
+CSV files are read with commas between columns and LF line endings unless
+--delimiter and --line-ending say otherwise: --delimiter takes COMMA,
+SEMICOLON, TAB, PIPE, CARET, or BACKQUOTE (or the character itself), and
+--line-ending takes LF or CRLF. The job is created with the same settings,
+so files exported from Excel on Windows or with European settings can be
+imported as they are.
+
+Before any job is created, each file is checked against the object's describe,
+as 'sfdc bulk validate' does: for columns that are not fields or can't be
+written, values of the wrong type, and missing required fields. Problems fail
+the import; missing required fields are only a warning, since defaults or
+automation may still supply them, unless --strict is given. Use
+--skip-validation to import without checking. Delete operations are not
+checked.
+
+With --wait, the job's progress is shown until it completes: a progress bar
+when stdout is a terminal, or a line for each change otherwise. The URLs of
+records created by an insert or upsert are then printed (or their count, for
+more than 10 records). The wait gives up after --wait-timeout (default 30m);
+the job keeps running.
+
+Files over 100 MB, more than Salesforce accepts in one upload, are split on
+row boundaries and imported as several jobs. Data that is already split can be
+imported by repeating --file, or with a quoted glob pattern such as
+'parts/*.csv'; each file becomes a job of its own.
+
+With --file -, CSV data is read from stdin and streamed to Salesforce as it
+is read, so the output of another tool can be imported without a temporary
+file. Only the header row can be checked before the upload starts, so values
+are not validated, and the data is imported as one job, which must fit in a
+single upload (150 MB). Prompts can't be answered on stdin either, so a
+delete in a production org needs --yes.
+
+When there are several jobs, they run one at a time by default. With
+--concurrency N, up to N run at once: uploads happen in parallel, and with
+--wait a single loop polls all the running jobs, printing a line as each one
+starts and changes state, and starts the next as each finishes. Jobs on the
+same object in parallel can contend for record locks, so raise it gradually.
+A summary of every job follows, and the command fails if any job did not
+complete or any record failed.
+
+Bulk API 2.0 always processes a job's records in parallel. If that fails with
+record lock errors (UNABLE_TO_LOCK_ROW), use --serial to import through a
+Bulk API 1.0 job in serial concurrency mode instead: the data is uploaded in
+batches of up to 10,000 records, which Salesforce processes one at a time.
+It is slower, and only takes comma-delimited CSV files; with --wait, the
+first failed records are listed with their errors.
+
+With --wait-on-rate-limit, requests rejected by Salesforce rate limits are
+retried after the wait the server asks for (up to 15 minutes in total per
+request), instead of failing the import.
+
+With --dry-run, the file is checked and the job that would be created is
+shown, without creating it.
+
+Before a delete or hardDelete in a production org, the command names the org
+and asks for confirmation. Use --yes to skip the prompt (e.g. in scripts).
 
 Examples:
   sfdc bulk import Account --file accounts.csv --operation insert
   sfdc bulk import Contact --file contacts.csv --operation upsert --external-id Email
   sfdc bulk import Account --file accounts.csv --operation update --wait
-  sfdc bulk import Account --file delete-ids.csv --operation delete`,
+  sfdc bulk import Account --file delete-ids.csv --operation delete
+  sfdc bulk import Account --file delete-ids.csv --operation hardDelete --yes
+  sfdc bulk import Contact --file contacts.csv --strict
+  sfdc bulk import Account --file export.csv --mapping mapping.json
+  sfdc bulk import Account --file accounts.jsonl --operation insert
+  sfdc bulk import Account --file konten.csv --delimiter semicolon --line-ending CRLF
+  sfdc bulk import Account --file 'parts/*.csv' --concurrency 4 --wait
+  sfdc bulk import Opportunity --file opportunities.csv --operation update --serial --wait
+  psql -c "\copy accounts TO STDOUT CSV HEADER" | sfdc bulk import Account --file -`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runImport(cmd.Context(), opts, args[0], file, operation, externalID, wait)
+			return runImport(cmd.Context(), opts, args[0], flags)
 		},
 	}
 
-	cmd.Flags().StringVarP(&file, "file", "f", "", "Path to CSV file (required)")
-	cmd.Flags().StringVar(&operation, "operation", "insert", "Operation: insert, update, upsert, delete")
-	cmd.Flags().StringVar(&externalID, "external-id", "", "External ID field for upsert operation")
-	cmd.Flags().BoolVar(&wait, "wait", false, "Wait for job to complete")
+	cmd.Flags().StringArrayVarP(&flags.files, "file", "f", nil, "Path to CSV file, a glob pattern, or - for stdin; repeat for several files (required)")
+	cmd.Flags().StringVar(&flags.mapping, "mapping", "", "JSON file mapping the CSV columns to fields")
+	cmd.Flags().StringVar(&flags.contentType, "content-type", "", "Data format: csv or json (default from the file extension)")
+	cmd.Flags().StringVar(&flags.delimiter, "delimiter", "", "CSV column delimiter: COMMA, SEMICOLON, TAB, PIPE, CARET, or BACKQUOTE (default COMMA)")
+	cmd.Flags().StringVar(&flags.lineEnding, "line-ending", "", "CSV line ending: LF or CRLF (default LF)")
+	cmd.Flags().StringVar(&flags.operation, "operation", "insert", "Operation: insert, update, upsert, delete, hardDelete")
+	cmd.Flags().StringVar(&flags.externalID, "external-id", "", "External ID field for upsert operation")
+	cmd.Flags().BoolVar(&flags.wait, "wait", false, "Wait for job to complete")
+	cmd.Flags().BoolVar(&flags.skipValidation, "skip-validation", false, "Import without checking the data against the object first")
+	cmd.Flags().BoolVar(&flags.strict, "strict", false, "Fail validation on warnings too, such as missing required fields")
+	cmd.Flags().Bool("validate-headers", false, "Check the data against the object before creating the job")
+	cmd.Flags().BoolVarP(&flags.yes, "yes", "y", false, "Skip the production org confirmation for delete operations")
+	cmd.Flags().IntVar(&flags.concurrency, "concurrency", 1, "Run up to this many jobs at once when importing several files")
+	cmd.Flags().BoolVar(&flags.serial, "serial", false, "Import with a Bulk API 1.0 job that processes its batches one at a time")
+	cmd.Flags().BoolVar(&opts.WaitOnRateLimit, "wait-on-rate-limit", false, "Wait and retry when rate limited instead of failing")
+	cmd.Flags().DurationVar(&opts.WaitTimeout, "wait-timeout", root.DefaultWaitTimeout, "How long --wait waits for the job to complete (0 for no limit)")
+	cmd.Flags().Float64Var(&opts.MaxRPS, "max-rps", 0, "Send at most this many API requests per second (0 for the max_rps setting)")
 
 	_ = cmd.MarkFlagRequired("file")
+	_ = cmd.Flags().MarkDeprecated("validate-headers", "validation now runs by default; use --skip-validation to turn it off")
 
 	return cmd
 }
 
-func runImport(ctx context.Context, opts *root.Options, object, file, operation, externalID string, wait bool) error {
-	op := bulk.Operation(strings.ToLower(operation))
+// importFlags holds the import command's flags.
+type importFlags struct {
+	files          []string
+	mapping        string
+	contentType    string
+	delimiter      string
+	lineEnding     string
+	operation      string
+	externalID     string
+	wait           bool
+	skipValidation bool
+	strict         bool
+	yes            bool
+	concurrency    int
+	serial         bool
+}
+
+func runImport(ctx context.Context, opts *root.Options, object string, flags importFlags) error {
+	op := bulk.Operation(strings.ToLower(flags.operation))
 	switch op {
 	case bulk.OperationInsert, bulk.OperationUpdate, bulk.OperationUpsert, bulk.OperationDelete:
+	case "harddelete":
+		op = bulk.OperationHardDelete
 	default:
-		return fmt.Errorf("invalid operation: %s (must be insert, update, upsert, or delete)", operation)
+		return fmt.Errorf("invalid operation: %s (must be insert, update, upsert, delete, or hardDelete)", flags.operation)
 	}
 
-	if op == bulk.OperationUpsert && externalID == "" {
+	if op == bulk.OperationUpsert && flags.externalID == "" {
 		return fmt.Errorf("--external-id is required for upsert operation")
 	}
+	if flags.concurrency < 1 {
+		return fmt.Errorf("--concurrency must be at least 1")
+	}
 
-	data, err := os.ReadFile(file)
+	files, err := expandFiles(flags.files)
 	if err != nil {
-		return fmt.Errorf("failed to read file: %w", err)
+		return err
+	}
+
+	contentType, err := importContentType(flags.contentType, files)
+	if err != nil {
+		return err
+	}
+	if contentType == bulk.ContentTypeJSON && flags.mapping != "" {
+		return fmt.Errorf("--mapping only applies to CSV files")
+	}
+	format, err := parseCSVFormat(flags.delimiter, flags.lineEnding)
+	if err != nil {
+		return err
+	}
+	if contentType == bulk.ContentTypeJSON && format != (csvFormat{}) {
+		return fmt.Errorf("--delimiter and --line-ending only apply to CSV files")
+	}
+	if flags.serial {
+		// Bulk API 1.0 jobs take CSV with commas, and run as one job
+		switch {
+		case contentType == bulk.ContentTypeJSON:
+			return fmt.Errorf("--serial only applies to CSV files")
+		case format.delimiter.Rune() != ',':
+			return fmt.Errorf("--serial only supports comma-delimited CSV")
+		case flags.concurrency > 1:
+			return fmt.Errorf("--concurrency cannot be used with --serial")
+		case slices.Contains(files, "-"):
+			return fmt.Errorf("--serial cannot read from stdin")
+		}
+	}
+
+	var validator *importValidator
+	if !flags.skipValidation && op != bulk.OperationDelete && op != bulk.OperationHardDelete {
+		validator = &importValidator{
+			opts:       opts,
+			object:     object,
+			op:         op,
+			externalID: flags.externalID,
+			strict:     flags.strict,
+		}
+	}
+
+	jobConfig := bulk.JobConfig{
+		Object:          object,
+		Operation:       op,
+		ExternalID:      flags.externalID,
+		ContentType:     contentType,
+		LineEnding:      format.lineEnding,
+		ColumnDelimiter: format.delimiter,
+	}
+
+	if slices.Contains(files, "-") {
+		if len(files) > 1 {
+			return fmt.Errorf("--file - cannot be combined with other files")
+		}
+		if contentType == bulk.ContentTypeJSON {
+			return fmt.Errorf("only CSV can be read from stdin")
+		}
+		return runStdinImport(ctx, opts, jobConfig, flags, format, validator)
+	}
+
+	v := opts.View()
+
+	var parts []*importPart
+	for _, file := range files {
+		if contentType == bulk.ContentTypeJSON {
+			fileParts, err := prepareJSONImport(ctx, op, file, validator)
+			if err != nil {
+				return err
+			}
+			if len(fileParts) > 1 && !opts.DryRun {
+				v.Info("%s is over %d MB; splitting it into %d jobs", file, uploadLimit/(1024*1024), len(fileParts))
+			}
+			parts = append(parts, fileParts...)
+			continue
+		}
+
+		data, err := readImportFile(file, flags.mapping, format)
+		if err != nil {
+			return err
+		}
+
+		if op == bulk.OperationDelete || op == bulk.OperationHardDelete {
+			data, err = prepareDeleteData(opts, data, format)
+			if err != nil {
+				return err
+			}
+		}
+
+		if validator != nil {
+			header, rows, err := csvRows(data, format)
+			if err != nil {
+				return err
+			}
+			if err := validator.check(ctx, file, header, rows); err != nil {
+				return err
+			}
+		}
+
+		fileParts, err := splitImportFile(jobSource{file: file, mapping: flags.mapping}, data, format)
+		if err != nil {
+			return err
+		}
+		if len(fileParts) > 1 && !opts.DryRun {
+			v.Info("%s is over %d MB; splitting it into %d jobs", file, uploadLimit/(1024*1024), len(fileParts))
+		}
+		parts = append(parts, fileParts...)
+	}
+
+	if (op == bulk.OperationDelete || op == bulk.OperationHardDelete) && !opts.DryRun {
+		proceed, err := opts.ConfirmProduction(ctx, fmt.Sprintf("bulk %s on %s", op, object), flags.yes)
+		if err != nil {
+			return err
+		}
+		if !proceed {
+			v.Info("Cancelled")
+			return nil
+		}
+	}
+
+	if flags.serial {
+		return runSerialImport(ctx, opts, jobConfig, files, parts, flags, format)
 	}
 
 	client, err := opts.BulkClient()
@@ -76,30 +332,58 @@ func runImport(ctx context.Context, opts *root.Options, object, file, operation,
 		return fmt.Errorf("failed to create bulk client: %w", err)
 	}
 
-	v := opts.View()
+	if opts.DryRun {
+		details := importDetails(files, flags.mapping, totalRows(parts))
+		if len(parts) > 1 {
+			details["Jobs"] = len(parts)
+			details["Concurrency"] = min(flags.concurrency, len(parts))
+		}
+		return opts.PrintDryRun(root.DryRunRequest{
+			Operation: "bulk " + string(op),
+			Object:    object,
+			Method:    http.MethodPost,
+			URL:       client.IngestJobsURL(),
+			Payload:   jobConfig.Request(),
+			Details:   details,
+		})
+	}
+
+	if len(parts) > 1 {
+		return runImportJobs(ctx, opts, client, jobConfig, parts, flags.concurrency, flags.wait)
+	}
+	data := parts[0].data
 
-	v.Info("Creating bulk %s job for %s...", operation, object)
-	job, err := client.CreateJob(ctx, bulk.JobConfig{
-		Object:     object,
-		Operation:  op,
-		ExternalID: externalID,
-	})
+	v.Info("Creating bulk %s job for %s...", flags.operation, object)
+	job, err := client.CreateJob(ctx, jobConfig)
 	if err != nil {
 		return fmt.Errorf("failed to create job: %w", err)
 	}
 
 	v.Info("Job created: %s", job.ID)
+	pending := trackJob(client, jobConfig, job.ID, parts[0].rows, parts[0].source)
 
 	v.Info("Uploading data...")
-	if err := client.UploadJobData(ctx, job.ID, data); err != nil {
+	if err := uploadJobData(ctx, client, jobConfig, job.ID, data); err != nil {
+		v.Info("Job %s is still open. Use 'sfdc bulk resume %s' to retry the upload, or --abort to abort it.", job.ID, job.ID)
 		return fmt.Errorf("failed to upload data: %w", err)
 	}
+	trackUploaded(pending)
+
+	return finishImportJob(ctx, opts, client, job.ID, op, parts[0].rows, flags.wait)
+}
+
+// finishImportJob starts processing a job whose data has been uploaded and,
+// with wait, waits for it and shows the result. rows is the number of
+// records uploaded, or zero if unknown.
+func finishImportJob(ctx context.Context, opts *root.Options, client *bulk.Client, jobID string, op bulk.Operation, rows int, wait bool) error {
+	v := opts.View()
 
 	v.Info("Starting job processing...")
-	job, err = client.CloseJob(ctx, job.ID)
+	job, err := client.CloseJob(ctx, jobID)
 	if err != nil {
 		return fmt.Errorf("failed to close job: %w", err)
 	}
+	untrackJob(job.ID)
 
 	if !wait {
 		v.Info("Job %s is processing. Use 'sfdc bulk job status %s' to check progress.", job.ID, job.ID)
@@ -107,12 +391,275 @@ func runImport(ctx context.Context, opts *root.Options, object, file, operation,
 	}
 
 	v.Info("Waiting for job to complete...")
-	job, err = client.PollJob(ctx, job.ID, bulk.DefaultPollConfig())
+	job, err = waitForJob(ctx, opts, client, job.ID, rows)
 	if err != nil {
 		return fmt.Errorf("failed waiting for job: %w", err)
 	}
 
-	return renderJobResult(opts, job)
+	if err := renderJobResult(opts, job); err != nil {
+		return err
+	}
+
+	if opts.Output == "json" || (op != bulk.OperationInsert && op != bulk.OperationUpsert) {
+		return nil
+	}
+	return renderCreatedRecordLinks(ctx, opts, client, job)
+}
+
+// readImportFile reads the CSV file to import, transformed by the mapping
+// file if one is given.
+func readImportFile(file, mapping string, format csvFormat) ([]byte, error) {
+	if mapping == "" {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read file: %w", err)
+		}
+		return data, nil
+	}
+
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	defer f.Close()
+
+	return mapCSV(mapping, file, f, format)
+}
+
+// mapCSV transforms the CSV data read from r, named name in errors, as the
+// mapping file describes. The data is read and written in format.
+func mapCSV(mapping, name string, r io.Reader, format csvFormat) ([]byte, error) {
+	m, err := loadMapping(mapping, format)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := m.Transform(&buf, r); err != nil {
+		return nil, fmt.Errorf("failed to map %s: %w", name, err)
+	}
+	return buf.Bytes(), nil
+}
+
+// loadMapping reads a mapping file, to map CSV data in format.
+func loadMapping(mapping string, format csvFormat) (*csvmap.Mapping, error) {
+	m, err := csvmap.Load(mapping)
+	if err != nil {
+		return nil, err
+	}
+	m.Comma = format.delimiter.Rune()
+	m.UseCRLF = format.lineEnding == bulk.LineEndingCRLF
+	return m, nil
+}
+
+// importDetails returns the dry-run details of an import.
+func importDetails(files []string, mapping string, rows int) map[string]interface{} {
+	details := map[string]interface{}{
+		"Rows": rows,
+	}
+	if len(files) == 1 {
+		details["File"] = files[0]
+	} else {
+		details["Files"] = strings.Join(files, ", ")
+	}
+	if mapping != "" {
+		details["Mapping"] = mapping
+	}
+	return details
+}
+
+// renderCreatedRecordLinks prints the URL of each record a completed job
+// created, or just their count if there are more than maxRecordLinks.
+func renderCreatedRecordLinks(ctx context.Context, opts *root.Options, client *bulk.Client, job *bulk.JobInfo) error {
+	if job.NumberRecordsProcessed-job.NumberRecordsFailed <= 0 {
+		return nil
+	}
+
+	data, err := client.GetSuccessfulResults(ctx, job.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get job results: %w", err)
+	}
+	records, err := bulk.ParseCSVRecords(data)
+	if err != nil {
+		return err
+	}
+
+	var ids []string
+	for _, rec := range records {
+		if rec["sf__Created"] == "true" && rec["sf__Id"] != "" {
+			ids = append(ids, rec["sf__Id"])
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	v := opts.View()
+	if len(ids) > maxRecordLinks {
+		v.Info("\n%d records created. Use 'sfdc bulk job results %s' to list their Ids.", len(ids), job.ID)
+		return nil
+	}
+
+	apiClient, err := opts.APIClient()
+	if err != nil {
+		return fmt.Errorf("failed to create API client: %w", err)
+	}
+
+	v.Info("\nCreated records:")
+	for _, id := range ids {
+		v.Info("  %s", v.Link(apiClient.RecordURL(id)))
+	}
+	return nil
+}
+
+// csvHeaderLength returns the length of the header row of CSV data in
+// format, with its line ending.
+func csvHeaderLength(data []byte, format csvFormat) (int, error) {
+	r := format.reader(data)
+	if _, err := r.Read(); err != nil && !errors.Is(err, io.EOF) {
+		return 0, fmt.Errorf("failed to read CSV header: %w", err)
+	}
+	return int(r.InputOffset()), nil
+}
+
+// countCSVRows parses CSV data in format to check it is well formed and
+// returns the number of rows after the header.
+func countCSVRows(data []byte, format csvFormat) (int, error) {
+	reader := format.reader(data)
+	reader.ReuseRecord = true
+	rows := -1
+	for {
+		_, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return max(rows, 0), nil
+		}
+		if err != nil {
+			return 0, fmt.Errorf("invalid CSV file: %w", err)
+		}
+		rows++
+	}
+}
+
+// prepareDeleteData checks that delete data has an Id column, warning about
+// any other columns since Bulk API ignores them. A plain list of Ids without
+// a header row is accepted and given an Id header.
+func prepareDeleteData(opts *root.Options, data []byte, format csvFormat) ([]byte, error) {
+	reader := format.reader(data)
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV header: %w", err)
+	}
+
+	var extra []string
+	hasID := false
+	for _, h := range header {
+		if strings.EqualFold(strings.TrimSpace(h), "Id") {
+			hasID = true
+			continue
+		}
+		extra = append(extra, h)
+	}
+
+	if !hasID {
+		if len(header) == 1 {
+			// A read error means there is no usable second row
+			next, _ := reader.Read()
+			if headerlessIDs(header[0], next) {
+				return append([]byte("Id"+format.newline()), data...), nil
+			}
+		}
+		return nil, fmt.Errorf("delete requires an Id column in the CSV header")
+	}
+
+	if len(extra) > 0 {
+		opts.View().Warning("Bulk API ignores columns other than Id for delete: %s", strings.Join(extra, ", "))
+	}
+
+	return data, nil
+}
+
+// headerlessIDs reports whether first, the only column of the first CSV
+// row, is a record Id rather than a header, judging by the values: it must
+// be a record Id and next, the second row, must hold one with the same key
+// prefix (the first three characters, which identify the object). Without
+// a second row, only an 18 character Id is accepted, since its checksum
+// tells it apart from a header.
+func headerlessIDs(first string, next []string) bool {
+	first = strings.TrimSpace(first)
+	if !isRecordID(first) {
+		return false
+	}
+	if len(next) == 0 {
+		return len(first) == 18
+	}
+
+	second := strings.TrimSpace(next[0])
+	return len(next) == 1 && isRecordID(second) && first[:3] == second[:3]
+}
+
+// isRecordID reports whether s is a 15 or 18 character Salesforce record
+// Id. The last three characters of an 18 character Id are a checksum of the
+// case of the first 15, and must match.
+func isRecordID(s string) bool {
+	if len(s) != 15 && len(s) != 18 {
+		return false
+	}
+	for _, r := range s {
+		if (r < '0' || r > '9') && (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') {
+			return false
+		}
+	}
+	return len(s) == 15 || s[15:] == recordIDChecksum(s[:15])
+}
+
+// recordIDChecksum returns the three character suffix that turns a 15
+// character record Id into its case-insensitive 18 character form. Each
+// character encodes which letters of five characters of the Id are upper
+// case.
+func recordIDChecksum(id string) string {
+	const alphabet = "ABCDEFGHIJKLMNOPQRSTUVWXYZ012345"
+
+	suffix := make([]byte, 3)
+	for chunk := 0; chunk < 3; chunk++ {
+		bits := 0
+		for i := 0; i < 5; i++ {
+			if c := id[chunk*5+i]; c >= 'A' && c <= 'Z' {
+				bits |= 1 << i
+			}
+		}
+		suffix[chunk] = alphabet[bits]
+	}
+	return string(suffix)
+}
+
+// missingRequiredFields returns the names of fields required on insert that
+// have no matching CSV column. Relationship columns such as
+// "Account.External_Id__c" satisfy the corresponding lookup field.
+func missingRequiredFields(fields []api.Field, header []string) []string {
+	columns := make(map[string]bool, len(header))
+	for _, h := range header {
+		name := strings.ToLower(strings.TrimSpace(h))
+		if idx := strings.Index(name, "."); idx >= 0 {
+			name = name[:idx]
+		}
+		columns[name] = true
+	}
+
+	var missing []string
+	for _, f := range fields {
+		if !f.IsRequiredOnCreate() {
+			continue
+		}
+		if columns[strings.ToLower(f.Name)] {
+			continue
+		}
+		if f.RelationshipName != "" && columns[strings.ToLower(f.RelationshipName)] {
+			continue
+		}
+		missing = append(missing, f.Name)
+	}
+
+	return missing
 }
 
 func renderJobResult(opts *root.Options, job *bulk.JobInfo) error {
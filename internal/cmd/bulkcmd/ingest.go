@@ -0,0 +1,360 @@
+package bulkcmd
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/open-cli-collective/salesforce-cli/api/bulk"
+	"github.com/open-cli-collective/salesforce-cli/internal/cmd/root"
+)
+
+// uploadLimit is the size above which an import file is split into several
+// jobs. It is a variable so tests can lower it.
+var uploadLimit = bulk.SplitUploadBytes
+
+// importPart is the data of one job of an import: a whole file, or part of
+// a file too large to upload at once. job and err record how it went.
+type importPart struct {
+	name   string
+	data   []byte
+	rows   int
+	source jobSource
+
+	job *bulk.JobInfo
+	err error
+}
+
+// expandFiles returns the files named by --file, expanding glob patterns.
+// A pattern that matches nothing is an error, so a typo is not mistaken for
+// an empty import.
+func expandFiles(patterns []string) ([]string, error) {
+	var files []string
+	for _, pattern := range patterns {
+		if !strings.ContainsAny(pattern, "*?[") {
+			files = append(files, pattern)
+			continue
+		}
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid file pattern %q: %w", pattern, err)
+		}
+		if len(matches) == 0 {
+			return nil, fmt.Errorf("no files match %s", pattern)
+		}
+		files = append(files, matches...)
+	}
+	return files, nil
+}
+
+// splitImportFile checks the CSV data of src's file, in format, and splits
+// it into parts small enough to upload.
+func splitImportFile(src jobSource, data []byte, format csvFormat) ([]*importPart, error) {
+	chunks, err := bulk.SplitCSV(data, uploadLimit, format.delimiter)
+	if err != nil {
+		return nil, err
+	}
+	header, err := csvHeaderLength(data, format)
+	if err != nil {
+		return nil, err
+	}
+
+	parts := make([]*importPart, len(chunks))
+	offset := header
+	for i, chunk := range chunks {
+		rows, err := countCSVRows(chunk, format)
+		if err != nil {
+			return nil, err
+		}
+		name := src.file
+		if len(chunks) > 1 {
+			name = fmt.Sprintf("%s (part %d of %d)", src.file, i+1, len(chunks))
+		}
+		// Each part is the header followed by the next rows of the data
+		source := src
+		source.offset, source.length = offset, len(chunk)-header
+		offset += source.length
+		parts[i] = &importPart{name: name, data: chunk, rows: rows, source: source}
+	}
+	return parts, nil
+}
+
+// totalRows returns the number of records in all the parts.
+func totalRows(parts []*importPart) int {
+	total := 0
+	for _, part := range parts {
+		total += part.rows
+	}
+	return total
+}
+
+// runImportJobs imports each part as a job of its own, with up to
+// concurrency jobs running at once. Uploads happen in parallel; with wait,
+// one loop polls every running job and starts the next part whenever one
+// finishes, so the number of requests does not grow with concurrency.
+func runImportJobs(ctx context.Context, opts *root.Options, client *bulk.Client, cfg bulk.JobConfig, parts []*importPart, concurrency int, wait bool) error {
+	v := opts.View()
+	concurrency = min(concurrency, len(parts))
+	v.Info("Running %d bulk %s jobs for %s, %d at a time...", len(parts), cfg.Operation, cfg.Object, concurrency)
+
+	progress := newJobProgress(opts, totalRows(parts))
+	defer progress.finish()
+
+	// Without --wait a job only holds its slot while it is being started
+	interval, timeout := pollInterval, opts.WaitTimeout
+	if !wait {
+		interval, timeout = 0, 0
+	}
+
+	queue := parts
+	var active []*importPart
+	err := root.Poll(ctx, interval, timeout, func() (bool, error) {
+		running := active[:0]
+		for _, part := range active {
+			job, err := client.GetJob(ctx, part.job.ID)
+			if err != nil {
+				return false, fmt.Errorf("failed to get job %s: %w", part.job.ID, err)
+			}
+			if status := jobStatus(job, part.rows); status != jobStatus(part.job, part.rows) {
+				progress.log(fmt.Sprintf("%s: %s %s", part.name, job.ID, status))
+			}
+			part.job = job
+			if !jobFinished(job.State) {
+				running = append(running, part)
+			}
+		}
+		active = running
+
+		n := min(concurrency-len(active), len(queue))
+		startJobs(ctx, client, cfg, queue[:n])
+		for _, part := range queue[:n] {
+			if part.err != nil {
+				progress.log(fmt.Sprintf("%s: %v", part.name, part.err))
+				continue
+			}
+			progress.log(fmt.Sprintf("%s: %s started (%d records)", part.name, part.job.ID, part.rows))
+			if wait {
+				active = append(active, part)
+			}
+		}
+		queue = queue[n:]
+
+		if wait {
+			progress.update(combineJobs(partJobs(parts)))
+		}
+		return len(queue) == 0 && len(active) == 0, nil
+	})
+	progress.finish()
+	if err != nil {
+		return fmt.Errorf("failed waiting for jobs: %w", err)
+	}
+
+	if err := renderImportJobsResult(opts, parts, wait); err != nil {
+		return err
+	}
+	return importJobsError(parts, wait)
+}
+
+// startJobs starts a job for each part at the same time, recording the job
+// or the error on the part.
+func startJobs(ctx context.Context, client *bulk.Client, cfg bulk.JobConfig, parts []*importPart) {
+	var wg sync.WaitGroup
+	for _, part := range parts {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			part.job, part.err = startJob(ctx, client, cfg, part.data, part.rows, part.source)
+		}()
+	}
+	wg.Wait()
+}
+
+// startJob creates a job, uploads its rows of data, and closes it so
+// Salesforce starts processing it. Until it is closed, the job is recorded
+// for 'sfdc bulk resume', with src saying where its data came from.
+func startJob(ctx context.Context, client *bulk.Client, cfg bulk.JobConfig, data []byte, rows int, src jobSource) (*bulk.JobInfo, error) {
+	job, err := client.CreateJob(ctx, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create job: %w", err)
+	}
+	pending := trackJob(client, cfg, job.ID, rows, src)
+
+	if err := uploadJobData(ctx, client, cfg, job.ID, data); err != nil {
+		return nil, fmt.Errorf("failed to upload data to job %s: %w", job.ID, err)
+	}
+	trackUploaded(pending)
+
+	job, err = client.CloseJob(ctx, job.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to close job: %w", err)
+	}
+	untrackJob(job.ID)
+	return job, nil
+}
+
+// uploadJobData uploads data to a job in the job's content type.
+func uploadJobData(ctx context.Context, client *bulk.Client, cfg bulk.JobConfig, jobID string, data []byte) error {
+	if cfg.ContentType == bulk.ContentTypeJSON {
+		return client.UploadJobJSON(ctx, jobID, data)
+	}
+	return client.UploadJobData(ctx, jobID, data)
+}
+
+// partJobs returns the job of each part for combineJobs, standing in Open
+// jobs for parts not started yet and Failed ones for parts that could not
+// be started.
+func partJobs(parts []*importPart) []*bulk.JobInfo {
+	jobs := make([]*bulk.JobInfo, len(parts))
+	for i, part := range parts {
+		switch {
+		case part.err != nil:
+			jobs[i] = &bulk.JobInfo{State: bulk.StateFailed}
+		case part.job == nil:
+			jobs[i] = &bulk.JobInfo{State: bulk.StateOpen}
+		default:
+			jobs[i] = part.job
+		}
+	}
+	return jobs
+}
+
+// importJobResult is the outcome of one part in JSON output.
+type importJobResult struct {
+	File  string        `json:"file"`
+	Job   *bulk.JobInfo `json:"job,omitempty"`
+	Error string        `json:"error,omitempty"`
+}
+
+// renderImportJobsResult prints the outcome of each job of an import and,
+// once they have finished, their combined record counts.
+func renderImportJobsResult(opts *root.Options, parts []*importPart, wait bool) error {
+	v := opts.View()
+	combined := combineJobs(partJobs(parts))
+
+	if opts.Output == "json" {
+		results := make([]importJobResult, len(parts))
+		for i, part := range parts {
+			results[i] = importJobResult{File: part.name, Job: part.job}
+			if part.err != nil {
+				results[i].Error = part.err.Error()
+			}
+		}
+		out := map[string]interface{}{"jobs": results}
+		if wait {
+			out["state"] = combined.State
+			out["numberRecordsProcessed"] = combined.NumberRecordsProcessed
+			out["numberRecordsFailed"] = combined.NumberRecordsFailed
+		}
+		return v.JSON(out)
+	}
+
+	if !wait {
+		v.Info("Jobs are processing. Use 'sfdc bulk job status <id>' to check progress.")
+		return nil
+	}
+
+	rows := make([][]string, len(parts))
+	var failed []string
+	for i, part := range parts {
+		if part.err != nil {
+			rows[i] = []string{part.name, "-", "NotStarted", "-", "-"}
+			continue
+		}
+		job := part.job
+		rows[i] = []string{
+			part.name,
+			job.ID,
+			string(job.State),
+			strconv.Itoa(job.NumberRecordsProcessed),
+			strconv.Itoa(job.NumberRecordsFailed),
+		}
+		if job.NumberRecordsFailed > 0 {
+			failed = append(failed, job.ID)
+		}
+	}
+
+	v.Info("Jobs completed:")
+	if err := v.Table([]string{"File", "Job", "State", "Processed", "Failed"}, rows); err != nil {
+		return err
+	}
+	v.Info("  Records Processed: %d", combined.NumberRecordsProcessed)
+	v.Info("  Records Failed:    %d", combined.NumberRecordsFailed)
+
+	for _, id := range failed {
+		v.Info("Use 'sfdc bulk job errors %s' to see failed records.", id)
+	}
+
+	return nil
+}
+
+// importJobsError returns an error if any job could not be started or,
+// with wait, did not complete or had records fail, so scripts can tell a
+// partial import from a complete one.
+func importJobsError(parts []*importPart, wait bool) error {
+	notStarted, notCompleted, failedRecords := 0, 0, 0
+	for _, part := range parts {
+		switch {
+		case part.err != nil:
+			notStarted++
+		case wait && part.job.State != bulk.StateJobComplete:
+			notCompleted++
+		case wait:
+			failedRecords += part.job.NumberRecordsFailed
+		}
+	}
+
+	switch {
+	case notStarted > 0 && !wait:
+		return fmt.Errorf("%d of %d jobs could not be started", notStarted, len(parts))
+	case notStarted > 0 || notCompleted > 0:
+		return fmt.Errorf("%d of %d jobs did not complete", notStarted+notCompleted, len(parts))
+	case failedRecords > 0:
+		return fmt.Errorf("%d of %d records failed", failedRecords, totalRows(parts))
+	}
+	return nil
+}
+
+// combineJobs sums the record counts of several jobs of the same import,
+// with the state of the import as a whole: in progress until every job has
+// finished, then complete only if every job completed. A single job is
+// returned as it is.
+func combineJobs(jobs []*bulk.JobInfo) *bulk.JobInfo {
+	if len(jobs) == 1 {
+		return jobs[0]
+	}
+
+	combined := &bulk.JobInfo{State: bulk.StateJobComplete}
+	started, running := false, false
+	for _, job := range jobs {
+		if combined.Object == "" {
+			combined.Object, combined.Operation = job.Object, job.Operation
+		}
+		combined.NumberRecordsProcessed += job.NumberRecordsProcessed
+		combined.NumberRecordsFailed += job.NumberRecordsFailed
+
+		switch job.State {
+		case bulk.StateOpen, bulk.StateUploadComplete:
+			running = true
+		case bulk.StateInProgress:
+			started, running = true, true
+		case bulk.StateJobComplete:
+			started = true
+		default:
+			started = true
+			if combined.State != bulk.StateFailed {
+				combined.State = job.State
+			}
+		}
+	}
+
+	switch {
+	case running && started:
+		combined.State = bulk.StateInProgress
+	case running:
+		combined.State = bulk.StateUploadComplete
+	}
+	return combined
+}
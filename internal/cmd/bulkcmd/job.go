@@ -1,12 +1,16 @@
 package bulkcmd
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"net/http"
 	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 
+	"github.com/open-cli-collective/salesforce-cli/api/bulk"
 	"github.com/open-cli-collective/salesforce-cli/internal/cmd/root"
 )
 
@@ -19,16 +23,20 @@ func newJobCommand(opts *root.Options) *cobra.Command {
 Examples:
   sfdc bulk job list
   sfdc bulk job status 750xx000000001
+  sfdc bulk job watch 750xx000000001
   sfdc bulk job results 750xx000000001
   sfdc bulk job errors 750xx000000001
-  sfdc bulk job abort 750xx000000001`,
+  sfdc bulk job abort 750xx000000001
+  sfdc bulk job prune --older-than 7d`,
 	}
 
 	cmd.AddCommand(newJobListCommand(opts))
 	cmd.AddCommand(newJobStatusCommand(opts))
+	cmd.AddCommand(newJobWatchCommand(opts))
 	cmd.AddCommand(newJobResultsCommand(opts))
 	cmd.AddCommand(newJobErrorsCommand(opts))
 	cmd.AddCommand(newJobAbortCommand(opts))
+	cmd.AddCommand(newJobPruneCommand(opts))
 
 	return cmd
 }
@@ -168,28 +176,36 @@ func runJobResults(ctx context.Context, opts *root.Options, jobID, output string
 }
 
 func newJobErrorsCommand(opts *root.Options) *cobra.Command {
-	var output string
+	var (
+		output    string
+		retryable bool
+	)
 
 	cmd := &cobra.Command{
 		Use:   "errors <job-id>",
 		Short: "Get failed records from a bulk job",
 		Long: `Get the failed records from a completed bulk ingest job.
 
+With --retryable, the sf__Id and sf__Error columns are removed so the output
+contains only the original data columns and can be fixed and re-imported.
+
 Examples:
   sfdc bulk job errors 750xx000000001
-  sfdc bulk job errors 750xx000000001 --output errors.csv`,
+  sfdc bulk job errors 750xx000000001 --output errors.csv
+  sfdc bulk job errors 750xx000000001 --retryable --output retry.csv`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runJobErrors(cmd.Context(), opts, args[0], output)
+			return runJobErrors(cmd.Context(), opts, args[0], output, retryable)
 		},
 	}
 
 	cmd.Flags().StringVarP(&output, "output", "o", "", "Output file path")
+	cmd.Flags().BoolVar(&retryable, "retryable", false, "Strip sf__ columns to produce a re-importable CSV")
 
 	return cmd
 }
 
-func runJobErrors(ctx context.Context, opts *root.Options, jobID, output string) error {
+func runJobErrors(ctx context.Context, opts *root.Options, jobID, output string, retryable bool) error {
 	client, err := opts.BulkClient()
 	if err != nil {
 		return fmt.Errorf("failed to create bulk client: %w", err)
@@ -200,6 +216,18 @@ func runJobErrors(ctx context.Context, opts *root.Options, jobID, output string)
 		return fmt.Errorf("failed to get failed results: %w", err)
 	}
 
+	if retryable {
+		// Results are written in the job's delimiter and line ending
+		job, err := client.GetJob(ctx, jobID)
+		if err != nil {
+			return fmt.Errorf("failed to get job: %w", err)
+		}
+		data, err = retryableCSV(data, jobCSVFormat(job))
+		if err != nil {
+			return fmt.Errorf("failed to build retryable CSV: %w", err)
+		}
+	}
+
 	if output != "" {
 		if err := os.WriteFile(output, data, 0644); err != nil {
 			return fmt.Errorf("failed to write output file: %w", err)
@@ -212,6 +240,43 @@ func runJobErrors(ctx context.Context, opts *root.Options, jobID, output string)
 	return nil
 }
 
+// retryableCSV removes the sf__ result columns from failed results in
+// format, leaving the original data columns so the file can be re-imported.
+func retryableCSV(data []byte, format csvFormat) ([]byte, error) {
+	records, err := format.reader(data).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return data, nil
+	}
+
+	keep := make([]int, 0, len(records[0]))
+	for i, col := range records[0] {
+		if !strings.HasPrefix(col, "sf__") {
+			keep = append(keep, i)
+		}
+	}
+
+	var buf bytes.Buffer
+	w := format.writer(&buf)
+	for _, record := range records {
+		row := make([]string, 0, len(keep))
+		for _, i := range keep {
+			row = append(row, record[i])
+		}
+		if err := w.Write(row); err != nil {
+			return nil, err
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
 func newJobAbortCommand(opts *root.Options) *cobra.Command {
 	return &cobra.Command{
 		Use:   "abort <job-id>",
@@ -233,6 +298,15 @@ func runJobAbort(ctx context.Context, opts *root.Options, jobID string) error {
 		return fmt.Errorf("failed to create bulk client: %w", err)
 	}
 
+	if opts.DryRun {
+		return opts.PrintDryRun(root.DryRunRequest{
+			Operation: "bulk job abort",
+			Method:    http.MethodPatch,
+			URL:       client.IngestJobsURL() + "/" + jobID,
+			Payload:   bulk.UpdateJobRequest{State: bulk.StateAborted},
+		})
+	}
+
 	job, err := client.AbortJob(ctx, jobID)
 	if err != nil {
 		return fmt.Errorf("failed to abort job: %w", err)
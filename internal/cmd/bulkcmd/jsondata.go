@@ -0,0 +1,124 @@
+package bulkcmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/open-cli-collective/salesforce-cli/api/bulk"
+)
+
+// importContentType returns the content type to import files as: the one
+// --content-type names if set, otherwise JSON if the files' extensions say
+// so, otherwise CSV. A job has one content type, so the files must agree.
+func importContentType(flag string, files []string) (bulk.ContentType, error) {
+	switch strings.ToLower(flag) {
+	case "csv":
+		return bulk.ContentTypeCSV, nil
+	case "json":
+		return bulk.ContentTypeJSON, nil
+	case "":
+	default:
+		return "", fmt.Errorf("invalid content type: %s (must be csv or json)", flag)
+	}
+
+	jsonFiles := 0
+	for _, file := range files {
+		if isJSONFile(file) {
+			jsonFiles++
+		}
+	}
+	switch jsonFiles {
+	case 0:
+		return bulk.ContentTypeCSV, nil
+	case len(files):
+		return bulk.ContentTypeJSON, nil
+	}
+	return "", fmt.Errorf("cannot import JSON and CSV files together; use --content-type to choose one")
+}
+
+// isJSONFile reports whether a file's extension names JSON records.
+func isJSONFile(path string) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json", ".jsonl", ".ndjson":
+		return true
+	}
+	return false
+}
+
+// prepareJSONImport reads a file of JSON records, checks them for the
+// operation, validating them if validator is set, and splits them into
+// parts small enough to upload.
+func prepareJSONImport(ctx context.Context, op bulk.Operation, file string, validator *importValidator) ([]*importPart, error) {
+	records, err := readJSONFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	if op == bulk.OperationDelete || op == bulk.OperationHardDelete {
+		for i, record := range records {
+			if _, ok := record["Id"]; !ok {
+				return nil, fmt.Errorf("%s: delete requires an Id in every record; record %d has none", file, i+1)
+			}
+		}
+	}
+
+	if validator != nil {
+		header, rows := jsonRows(records)
+		if err := validator.check(ctx, file, header, rows); err != nil {
+			return nil, err
+		}
+	}
+
+	chunks, counts, err := bulk.SplitJSONRecords(records, uploadLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	parts := make([]*importPart, len(chunks))
+	offset := 0
+	for i, chunk := range chunks {
+		name := file
+		if len(chunks) > 1 {
+			name = fmt.Sprintf("%s (part %d of %d)", file, i+1, len(chunks))
+		}
+		source := jobSource{file: file, offset: offset, length: counts[i]}
+		offset += counts[i]
+		parts[i] = &importPart{name: name, data: chunk, rows: counts[i], source: source}
+	}
+	return parts, nil
+}
+
+// readJSONFile reads the records of a JSON or JSON Lines file.
+func readJSONFile(file string) ([]map[string]interface{}, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	defer f.Close()
+
+	records, err := bulk.ReadJSONRecords(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", file, err)
+	}
+	return records, nil
+}
+
+// recordFields returns the fields set in any of the records, sorted.
+func recordFields(records []map[string]interface{}) []string {
+	seen := map[string]bool{}
+	var fields []string
+	for _, record := range records {
+		for field := range record {
+			if !seen[field] {
+				seen[field] = true
+				fields = append(fields, field)
+			}
+		}
+	}
+	sort.Strings(fields)
+	return fields
+}
@@ -0,0 +1,134 @@
+package bulkcmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/open-cli-collective/salesforce-cli/api/bulk"
+	"github.com/open-cli-collective/salesforce-cli/api/bulkv1"
+	"github.com/open-cli-collective/salesforce-cli/internal/cmd/root"
+	"github.com/open-cli-collective/salesforce-cli/internal/view"
+)
+
+// runChunkedQuery runs soql as a Bulk API 1.0 query job with PK chunking,
+// and waits for Salesforce to process every chunk's batch. A chunk size of
+// zero leaves it to Salesforce.
+func runChunkedQuery(ctx context.Context, opts *root.Options, v *view.View, soql string, chunkSize int) (*queryResults, error) {
+	object := queryObject(&bulk.QueryJobInfo{}, soql)
+	if object == "" {
+		return nil, fmt.Errorf("PK chunking needs a query that selects FROM an object")
+	}
+
+	client, err := opts.BulkV1Client()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create bulk client: %w", err)
+	}
+
+	v.Info("Creating PK chunked query job for %s...", object)
+	job, err := client.CreateJob(ctx, bulkv1.JobConfig{
+		Object:     object,
+		Operation:  bulkv1.OperationQuery,
+		PKChunking: &bulkv1.PKChunking{ChunkSize: chunkSize},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create query job: %w", err)
+	}
+
+	v.Info("Job created: %s", job.ID)
+
+	if _, err := client.AddQueryBatch(ctx, job.ID, soql); err != nil {
+		return nil, fmt.Errorf("failed to add query to job %s: %w", job.ID, err)
+	}
+	// Salesforce still adds the chunks' batches to a closed job
+	if _, err := client.CloseJob(ctx, job.ID); err != nil {
+		return nil, fmt.Errorf("failed to close job: %w", err)
+	}
+
+	v.Info("Waiting for chunks to complete...")
+	var (
+		batches []bulkv1.BatchInfo
+		last    string
+	)
+	err = root.Poll(ctx, pollInterval, opts.WaitTimeout, func() (bool, error) {
+		batches, err = client.ListBatches(ctx, job.ID)
+		if err != nil {
+			return false, fmt.Errorf("failed to list batches of job %s: %w", job.ID, err)
+		}
+		progress := chunkProgress(batches)
+		if status := progress.String(); status != last {
+			v.Info("  %s", status)
+			last = status
+		}
+		return progress.finished(), nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed waiting for query job: %w", err)
+	}
+
+	progress := chunkProgress(batches)
+	if progress.failed > 0 {
+		return nil, fmt.Errorf("%d of %d batches of job %s failed: %s", progress.failed, progress.total, job.ID, progress.failure)
+	}
+
+	v.Info("Query completed. Records: %d", progress.records)
+
+	return &queryResults{
+		object: object,
+		download: func(w io.Writer) (int, error) {
+			return client.WriteQueryResults(ctx, job.ID, w)
+		},
+	}, nil
+}
+
+// chunks summarizes the batches of a PK chunked query job.
+type chunks struct {
+	batches   int
+	total     int
+	completed int
+	failed    int
+	pending   int
+	records   int
+	// failure is the state message of the first failed batch
+	failure string
+}
+
+// chunkProgress summarizes a PK chunked job's batches. The batch holding
+// the query itself is left out once it is NotProcessed, its records being
+// in the chunks; it fails instead if Salesforce cannot chunk the query.
+func chunkProgress(batches []bulkv1.BatchInfo) chunks {
+	c := chunks{batches: len(batches)}
+	for _, batch := range batches {
+		if batch.State == bulkv1.BatchNotProcessed {
+			continue
+		}
+		c.total++
+		c.records += batch.NumberRecordsProcessed
+		switch batch.State {
+		case bulkv1.BatchCompleted:
+			c.completed++
+		case bulkv1.BatchFailed:
+			c.failed++
+			if c.failure == "" {
+				c.failure = batch.StateMessage
+			}
+		default:
+			c.pending++
+		}
+	}
+	return c
+}
+
+// finished reports whether every batch has stopped processing. The query's
+// batch becomes NotProcessed once its chunks are queued.
+func (c chunks) finished() bool {
+	return c.batches > 0 && c.pending == 0
+}
+
+func (c chunks) String() string {
+	s := fmt.Sprintf("Batches: %d of %d completed, %d records", c.completed, c.total, c.records)
+	if c.failed > 0 {
+		s += fmt.Sprintf(", %d failed", c.failed)
+	}
+	return s
+}
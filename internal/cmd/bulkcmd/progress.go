@@ -0,0 +1,203 @@
+package bulkcmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/open-cli-collective/salesforce-cli/api/bulk"
+	"github.com/open-cli-collective/salesforce-cli/internal/cmd/root"
+)
+
+// progressBarWidth is the number of cells in the progress bar.
+const progressBarWidth = 24
+
+// spinnerFrames animate the progress line on a terminal.
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// waitForJob polls an ingest job until it finishes, up to --wait-timeout,
+// showing its progress. total is the number of records uploaded, or zero if
+// unknown.
+func waitForJob(ctx context.Context, opts *root.Options, client *bulk.Client, jobID string, total int) (*bulk.JobInfo, error) {
+	jobs, err := waitForJobs(ctx, opts, client, []string{jobID}, total)
+	if err != nil {
+		return nil, err
+	}
+	return jobs[0], nil
+}
+
+// waitForJobs polls ingest jobs until they have all finished, up to
+// --wait-timeout, showing their combined progress. total is the number of
+// records uploaded to all of them, or zero if unknown.
+func waitForJobs(ctx context.Context, opts *root.Options, client *bulk.Client, jobIDs []string, total int) ([]*bulk.JobInfo, error) {
+	progress := newJobProgress(opts, total)
+	defer progress.finish()
+
+	jobs := make([]*bulk.JobInfo, len(jobIDs))
+	err := root.Poll(ctx, pollInterval, opts.WaitTimeout, func() (bool, error) {
+		for i, id := range jobIDs {
+			if jobs[i] != nil && jobFinished(jobs[i].State) {
+				continue
+			}
+			job, err := client.GetJob(ctx, id)
+			if err != nil {
+				return false, err
+			}
+			jobs[i] = job
+		}
+
+		combined := combineJobs(jobs)
+		progress.update(combined)
+		return jobFinished(combined.State), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return jobs, nil
+}
+
+// jobFinished reports whether a job has stopped processing.
+func jobFinished(state bulk.State) bool {
+	switch state {
+	case bulk.StateJobComplete, bulk.StateFailed, bulk.StateAborted:
+		return true
+	}
+	return false
+}
+
+// jobProgress shows how far a job has got. On a terminal it redraws one
+// line with a spinner and progress bar; otherwise it logs a line whenever
+// the state or counts change, so logs of scripted runs stay readable.
+type jobProgress struct {
+	out   io.Writer
+	live  bool
+	total int
+	start time.Time
+	// processingTime adds the job's processing time to its status
+	processingTime bool
+
+	state  bulk.State
+	last   string
+	frame  int
+	drawn  bool
+	silent bool
+}
+
+func newJobProgress(opts *root.Options, total int) *jobProgress {
+	return &jobProgress{
+		out:    opts.Stdout,
+		live:   root.IsTerminal(opts.Stdout),
+		total:  total,
+		start:  time.Now(),
+		silent: opts.Output == "json",
+	}
+}
+
+// update shows the job's latest state.
+func (p *jobProgress) update(job *bulk.JobInfo) {
+	if p.silent {
+		return
+	}
+
+	elapsed := formatElapsed(time.Since(p.start))
+	status := p.status(job)
+
+	if !p.live {
+		if status != p.last {
+			fmt.Fprintf(p.out, "[%s] %s\n", elapsed, status)
+			p.last = status
+		}
+		return
+	}
+
+	// Keep state transitions on screen as lines of their own
+	if p.state != "" && job.State != p.state {
+		fmt.Fprintf(p.out, "\r\033[K[%s] %s\n", elapsed, p.last)
+	}
+	p.state = job.State
+	p.last = status
+
+	frame := spinnerFrames[p.frame%len(spinnerFrames)]
+	p.frame++
+	fmt.Fprintf(p.out, "\r\033[K%s %s%s %s", frame, p.bar(job), elapsed, status)
+	p.drawn = true
+}
+
+// finish clears the progress line so results can be printed.
+func (p *jobProgress) finish() {
+	if p.drawn {
+		fmt.Fprint(p.out, "\r\033[K")
+		p.drawn = false
+	}
+}
+
+// log prints a line about one of several jobs, above the progress line,
+// which is redrawn on the next update.
+func (p *jobProgress) log(line string) {
+	if p.silent {
+		return
+	}
+	p.finish()
+	fmt.Fprintf(p.out, "[%s] %s\n", formatElapsed(time.Since(p.start)), line)
+}
+
+// status describes the job's state and record counts.
+func (p *jobProgress) status(job *bulk.JobInfo) string {
+	status := jobStatus(job, p.total)
+	if p.processingTime && job.TotalProcessingTime > 0 {
+		d := time.Duration(job.TotalProcessingTime) * time.Millisecond
+		status += fmt.Sprintf(" in %s", d.Round(time.Second/10))
+	}
+	return status
+}
+
+// jobStatus describes a job's state and record counts, out of total records
+// if that is known.
+func jobStatus(job *bulk.JobInfo, total int) string {
+	var b strings.Builder
+	b.WriteString(string(job.State))
+
+	switch job.State {
+	case bulk.StateOpen, bulk.StateUploadComplete:
+		return b.String()
+	}
+
+	if total > 0 {
+		fmt.Fprintf(&b, ": %d of %d records processed (%d%%)", job.NumberRecordsProcessed, total, percent(job.NumberRecordsProcessed, total))
+	} else {
+		fmt.Fprintf(&b, ": %d records processed", job.NumberRecordsProcessed)
+	}
+	if job.NumberRecordsFailed > 0 {
+		fmt.Fprintf(&b, ", %d failed", job.NumberRecordsFailed)
+	}
+	return b.String()
+}
+
+// bar returns a progress bar followed by a space, or nothing if the number
+// of records is unknown.
+func (p *jobProgress) bar(job *bulk.JobInfo) string {
+	if p.total <= 0 {
+		return ""
+	}
+	filled := percent(job.NumberRecordsProcessed, p.total) * progressBarWidth / 100
+	return "[" + strings.Repeat("█", filled) + strings.Repeat("░", progressBarWidth-filled) + "] "
+}
+
+// percent returns n as a percentage of total, at most 100.
+func percent(n, total int) int {
+	if total <= 0 {
+		return 0
+	}
+	return min(100, n*100/total)
+}
+
+// formatElapsed formats a duration as m:ss, or h:mm:ss from an hour.
+func formatElapsed(d time.Duration) string {
+	s := int(d.Seconds())
+	if s >= 3600 {
+		return fmt.Sprintf("%d:%02d:%02d", s/3600, s/60%60, s%60)
+	}
+	return fmt.Sprintf("%d:%02d", s/60, s%60)
+}
@@ -0,0 +1,250 @@
+package bulkcmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/salesforce-cli/api/bulk"
+	"github.com/open-cli-collective/salesforce-cli/internal/cmd/root"
+)
+
+// jobDateLayout is the format of the dates of bulk jobs.
+const jobDateLayout = "2006-01-02T15:04:05.000-0700"
+
+func newJobPruneCommand(opts *root.Options) *cobra.Command {
+	var flags pruneFlags
+
+	cmd := &cobra.Command{
+		Use:   "prune",
+		Short: "Delete old bulk jobs",
+		Long: `Delete finished bulk ingest and query jobs created before a cutoff.
+
+--older-than takes a duration before now (e.g. 12h or 7d) or a date. Only
+jobs in the given --state are deleted: by default every finished state
+(JobComplete, Failed, and Aborted). Jobs still running can't be deleted.
+
+The matching jobs are listed and the command asks for confirmation before
+deleting them; use --yes to skip it. With --dry-run, the jobs are listed and
+nothing is deleted.
+
+Salesforce returns up to 1000 jobs of each type per listing, so run the
+command again to prune more.
+
+Examples:
+  sfdc bulk job prune --older-than 7d
+  sfdc bulk job prune --older-than 7d --state JobComplete
+  sfdc bulk job prune --older-than 2024-01-01 --type query --yes
+  sfdc bulk job prune --older-than 30d --dry-run`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runJobPrune(cmd.Context(), opts, flags)
+		},
+	}
+
+	cmd.Flags().StringVar(&flags.olderThan, "older-than", "", "Delete jobs created before this duration ago (e.g. 7d) or date (required)")
+	cmd.Flags().StringSliceVar(&flags.states, "state", []string{string(bulk.StateJobComplete), string(bulk.StateFailed), string(bulk.StateAborted)}, "Delete jobs in these states")
+	cmd.Flags().StringVar(&flags.jobType, "type", "all", "Job type: ingest, query, or all")
+	cmd.Flags().BoolVarP(&flags.yes, "yes", "y", false, "Skip the confirmation prompt")
+
+	_ = cmd.MarkFlagRequired("older-than")
+
+	return cmd
+}
+
+// pruneFlags holds the prune command's flags.
+type pruneFlags struct {
+	olderThan string
+	states    []string
+	jobType   string
+	yes       bool
+}
+
+// prunableJob is a job that prune deletes.
+type prunableJob struct {
+	Type      string     `json:"type"`
+	ID        string     `json:"id"`
+	Object    string     `json:"object"`
+	Operation string     `json:"operation"`
+	State     bulk.State `json:"state"`
+	Created   time.Time  `json:"createdDate"`
+}
+
+func runJobPrune(ctx context.Context, opts *root.Options, flags pruneFlags) error {
+	cutoff, err := root.ParseTimeFlag(flags.olderThan, time.Now())
+	if err != nil {
+		return fmt.Errorf("invalid --older-than: %w", err)
+	}
+	states, err := pruneStates(flags.states)
+	if err != nil {
+		return err
+	}
+	jobType := strings.ToLower(flags.jobType)
+	switch jobType {
+	case "ingest", "query", "all":
+	default:
+		return fmt.Errorf("invalid type: %s (must be ingest, query, or all)", flags.jobType)
+	}
+
+	client, err := opts.BulkClient()
+	if err != nil {
+		return fmt.Errorf("failed to create bulk client: %w", err)
+	}
+
+	var jobs []prunableJob
+	if jobType != "query" {
+		resp, err := client.ListJobs(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to list jobs: %w", err)
+		}
+		for _, job := range resp.Records {
+			jobs = appendPrunable(jobs, "ingest", job.ID, job.Object, string(job.Operation), job.State, job.CreatedDate, states, cutoff)
+		}
+	}
+	if jobType != "ingest" {
+		resp, err := client.ListQueryJobs(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to list query jobs: %w", err)
+		}
+		for _, job := range resp.Records {
+			jobs = appendPrunable(jobs, "query", job.ID, job.Object, string(job.Operation), job.State, job.CreatedDate, states, cutoff)
+		}
+	}
+
+	v := opts.View()
+
+	if len(jobs) == 0 {
+		v.Info("No bulk jobs to prune")
+		return nil
+	}
+
+	if opts.DryRun {
+		if opts.Output != "json" {
+			if err := renderPrunableJobs(opts, jobs); err != nil {
+				return err
+			}
+		}
+		ids := make([]string, len(jobs))
+		for i, job := range jobs {
+			ids[i] = job.ID
+		}
+		return opts.PrintDryRun(root.DryRunRequest{
+			Operation: "bulk job prune",
+			Method:    http.MethodDelete,
+			URL:       client.IngestJobsURL() + "/{id}",
+			Details: map[string]interface{}{
+				"Jobs":       len(jobs),
+				"Older Than": cutoff.Format(time.RFC3339),
+				"IDs":        strings.Join(ids, ", "),
+			},
+		})
+	}
+
+	if opts.Output != "json" {
+		if err := renderPrunableJobs(opts, jobs); err != nil {
+			return err
+		}
+	}
+
+	if !flags.yes {
+		proceed, err := opts.Confirm(fmt.Sprintf("Delete %d bulk jobs? [y/N]: ", len(jobs)))
+		if err != nil {
+			return err
+		}
+		if !proceed {
+			v.Info("Cancelled")
+			return nil
+		}
+	}
+
+	deleted := make([]prunableJob, 0, len(jobs))
+	var failed []string
+	for _, job := range jobs {
+		var err error
+		if job.Type == "query" {
+			err = client.DeleteQueryJob(ctx, job.ID)
+		} else {
+			err = client.DeleteJob(ctx, job.ID)
+		}
+		if err != nil {
+			failed = append(failed, fmt.Sprintf("%s: %v", job.ID, err))
+			continue
+		}
+		deleted = append(deleted, job)
+	}
+
+	if opts.Output == "json" {
+		if err := v.JSON(map[string]interface{}{"deleted": deleted, "failed": failed}); err != nil {
+			return err
+		}
+	} else {
+		v.Info("Deleted %d of %d jobs", len(deleted), len(jobs))
+		for _, f := range failed {
+			v.Warning("Failed to delete %s", f)
+		}
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("%d of %d jobs could not be deleted", len(failed), len(jobs))
+	}
+	return nil
+}
+
+// pruneStates returns the job states named by --state. Salesforce only
+// deletes finished jobs, so other states are rejected.
+func pruneStates(names []string) (map[bulk.State]bool, error) {
+	states := make(map[bulk.State]bool, len(names))
+	for _, name := range names {
+		found := false
+		for _, state := range []bulk.State{bulk.StateJobComplete, bulk.StateFailed, bulk.StateAborted} {
+			if strings.EqualFold(name, string(state)) {
+				states[state] = true
+				found = true
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("invalid state: %s (must be JobComplete, Failed, or Aborted)", name)
+		}
+	}
+	return states, nil
+}
+
+// appendPrunable appends a job to jobs if it is in one of states and was
+// created before cutoff. Jobs whose date can't be read are left alone.
+func appendPrunable(jobs []prunableJob, jobType, id, object, operation string, state bulk.State, created string, states map[bulk.State]bool, cutoff time.Time) []prunableJob {
+	if !states[state] {
+		return jobs
+	}
+	t, err := time.Parse(jobDateLayout, created)
+	if err != nil || !t.Before(cutoff) {
+		return jobs
+	}
+	return append(jobs, prunableJob{
+		Type:      jobType,
+		ID:        id,
+		Object:    object,
+		Operation: operation,
+		State:     state,
+		Created:   t,
+	})
+}
+
+// renderPrunableJobs lists the jobs prune is about to delete.
+func renderPrunableJobs(opts *root.Options, jobs []prunableJob) error {
+	rows := make([][]string, len(jobs))
+	for i, job := range jobs {
+		rows[i] = []string{
+			job.ID,
+			job.Type,
+			job.Object,
+			job.Operation,
+			string(job.State),
+			job.Created.Local().Format("2006-01-02 15:04"),
+		}
+	}
+	return opts.View().Table([]string{"ID", "Type", "Object", "Operation", "State", "Created"}, rows)
+}
@@ -0,0 +1,416 @@
+package bulkcmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/salesforce-cli/api/bulk"
+	"github.com/open-cli-collective/salesforce-cli/internal/cmd/root"
+	"github.com/open-cli-collective/salesforce-cli/internal/config"
+)
+
+// jobSource is where the data of an import job was read from, so that it
+// can be read again to resume the job. The zero value is data that can't be
+// read again, such as stdin.
+type jobSource struct {
+	file    string
+	mapping string
+	// offset and length locate the job's records in the file's data: bytes
+	// after the header row for CSV, or record indexes for JSON
+	offset int
+	length int
+}
+
+// trackJob records a job that has been created but not closed, so that
+// 'sfdc bulk resume' can finish it if the command is interrupted. Recording
+// is best effort: an import does not fail because the record can't be
+// written.
+func trackJob(client *bulk.Client, cfg bulk.JobConfig, jobID string, rows int, src jobSource) config.PendingBulkJob {
+	pending := config.PendingBulkJob{
+		JobID:           jobID,
+		InstanceURL:     client.InstanceURL(),
+		Object:          cfg.Object,
+		Operation:       string(cfg.Operation),
+		ExternalID:      cfg.ExternalID,
+		ContentType:     string(cfg.ContentType),
+		LineEnding:      string(cfg.LineEnding),
+		ColumnDelimiter: string(cfg.ColumnDelimiter),
+		Mapping:         src.mapping,
+		Offset:          src.offset,
+		Length:          src.length,
+		Rows:            rows,
+		Created:         time.Now().UTC(),
+	}
+	if src.file != "" {
+		if info, err := os.Stat(src.file); err == nil {
+			if abs, err := filepath.Abs(src.file); err == nil {
+				pending.File, pending.FileSize = abs, info.Size()
+			}
+		}
+	}
+	_ = config.SavePendingBulkJob(pending)
+	return pending
+}
+
+// trackUploaded records that a tracked job's data has all been uploaded.
+func trackUploaded(pending config.PendingBulkJob) {
+	pending.Uploaded = true
+	_ = config.SavePendingBulkJob(pending)
+}
+
+// untrackJob forgets a tracked job once it has been closed or aborted.
+func untrackJob(jobID string) {
+	_ = config.RemovePendingBulkJob(jobID)
+}
+
+func newResumeCommand(opts *root.Options) *cobra.Command {
+	var flags resumeFlags
+
+	cmd := &cobra.Command{
+		Use:   "resume [job-id...]",
+		Short: "Finish bulk imports that were interrupted",
+		Long: `Finish bulk import jobs left open by an interrupted import.
+
+An import records each job it creates until the job is closed, so that a
+job orphaned by a crash or Ctrl-C can be finished. resume picks up every
+recorded job of the current org, or only the given ones:
+
+  - if the job's data was uploaded, the job is closed so Salesforce starts
+    processing it
+  - otherwise the job's rows are read again from the imported file, with
+    the same mapping, delimiter, and line ending, uploaded, and the job is
+    closed; the file must not have changed since the import
+  - jobs already closed, aborted, or processed are just forgotten
+
+Data read from stdin can't be read again, so such jobs can only be closed
+if their upload had finished. With --abort, the jobs are aborted instead.
+With --forget, the records are dropped without contacting Salesforce, e.g.
+for jobs that were deleted.
+
+With --wait, the command waits for the resumed jobs to complete, up to
+--wait-timeout (default 30m). With --dry-run, the recorded jobs are listed
+and nothing is changed.
+
+Examples:
+  sfdc bulk resume
+  sfdc bulk resume 750xx000000001 --wait
+  sfdc bulk resume --abort
+  sfdc bulk resume --dry-run`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runResume(cmd.Context(), opts, args, flags)
+		},
+	}
+
+	cmd.Flags().BoolVar(&flags.abort, "abort", false, "Abort the jobs instead of finishing them")
+	cmd.Flags().BoolVar(&flags.forget, "forget", false, "Drop the records of the jobs without changing them")
+	cmd.Flags().BoolVar(&flags.wait, "wait", false, "Wait for the resumed jobs to complete")
+	cmd.Flags().DurationVar(&opts.WaitTimeout, "wait-timeout", root.DefaultWaitTimeout, "How long --wait waits for the jobs to complete (0 for no limit)")
+
+	cmd.MarkFlagsMutuallyExclusive("abort", "forget")
+
+	return cmd
+}
+
+// resumeFlags holds the resume command's flags.
+type resumeFlags struct {
+	abort  bool
+	forget bool
+	wait   bool
+}
+
+// resumeResult is what resume did with a recorded job.
+type resumeResult struct {
+	JobID  string        `json:"jobId"`
+	Object string        `json:"object"`
+	File   string        `json:"file,omitempty"`
+	Action string        `json:"action"`
+	Job    *bulk.JobInfo `json:"job,omitempty"`
+	Error  string        `json:"error,omitempty"`
+}
+
+func runResume(ctx context.Context, opts *root.Options, jobIDs []string, flags resumeFlags) error {
+	client, err := opts.BulkClient()
+	if err != nil {
+		return fmt.Errorf("failed to create bulk client: %w", err)
+	}
+
+	recorded, err := config.LoadPendingBulkJobs()
+	if err != nil {
+		return fmt.Errorf("failed to read interrupted imports: %w", err)
+	}
+	var pending []config.PendingBulkJob
+	for _, p := range recorded {
+		if p.InstanceURL == client.InstanceURL() && (len(jobIDs) == 0 || slices.Contains(jobIDs, p.JobID)) {
+			pending = append(pending, p)
+		}
+	}
+	for _, id := range jobIDs {
+		if !slices.ContainsFunc(pending, func(p config.PendingBulkJob) bool { return p.JobID == id }) {
+			return fmt.Errorf("no interrupted import of job %s is recorded for this org", id)
+		}
+	}
+
+	v := opts.View()
+
+	if len(pending) == 0 {
+		v.Info("No interrupted bulk imports to resume")
+		return nil
+	}
+
+	if opts.DryRun {
+		if opts.Output != "json" {
+			if err := renderPendingJobs(opts, pending); err != nil {
+				return err
+			}
+		}
+		ids := make([]string, len(pending))
+		for i, p := range pending {
+			ids[i] = p.JobID
+		}
+		return opts.PrintDryRun(root.DryRunRequest{
+			Operation: "bulk resume",
+			Method:    http.MethodPatch,
+			URL:       client.IngestJobsURL() + "/{id}",
+			Details: map[string]interface{}{
+				"Jobs":   len(pending),
+				"Action": resumeAction(flags),
+				"IDs":    strings.Join(ids, ", "),
+			},
+		})
+	}
+
+	results := make([]*resumeResult, len(pending))
+	var closed []string
+	rows := 0
+	for i, p := range pending {
+		result := &resumeResult{JobID: p.JobID, Object: p.Object, File: p.File}
+		results[i] = result
+
+		if flags.forget {
+			untrackJob(p.JobID)
+			result.Action = "forgotten"
+			continue
+		}
+
+		result.Job, result.Action, err = resumeJob(ctx, opts, client, p, flags.abort)
+		if err != nil {
+			result.Error = err.Error()
+			continue
+		}
+		if result.Action == "closed" || result.Action == "uploaded and closed" {
+			closed = append(closed, p.JobID)
+			rows += p.Rows
+		}
+	}
+
+	if flags.wait && len(closed) > 0 {
+		v.Info("Waiting for %d resumed jobs to complete...", len(closed))
+		jobs, err := waitForJobs(ctx, opts, client, closed, rows)
+		if err != nil {
+			return fmt.Errorf("failed waiting for jobs: %w", err)
+		}
+		for _, job := range jobs {
+			for _, result := range results {
+				if result.JobID == job.ID {
+					result.Job = job
+				}
+			}
+		}
+	}
+
+	if err := renderResumeResults(opts, results); err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, result := range results {
+		if result.Error != "" {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d jobs could not be resumed", failed, len(results))
+	}
+	return nil
+}
+
+// resumeAction describes what resume does with the jobs, for dry runs.
+func resumeAction(flags resumeFlags) string {
+	switch {
+	case flags.abort:
+		return "abort"
+	case flags.forget:
+		return "forget"
+	}
+	return "upload and close"
+}
+
+// resumeJob finishes or, with abort, aborts a recorded job, returning the
+// job and what was done with it. Jobs that are no longer open are
+// forgotten.
+func resumeJob(ctx context.Context, opts *root.Options, client *bulk.Client, p config.PendingBulkJob, abort bool) (*bulk.JobInfo, string, error) {
+	job, err := client.GetJob(ctx, p.JobID)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get job: %w", err)
+	}
+	if job.State != bulk.StateOpen {
+		untrackJob(p.JobID)
+		return job, "already " + string(job.State), nil
+	}
+
+	if abort {
+		job, err = client.AbortJob(ctx, p.JobID)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to abort job: %w", err)
+		}
+		untrackJob(p.JobID)
+		return job, "aborted", nil
+	}
+
+	action := "closed"
+	if !p.Uploaded {
+		if p.File == "" {
+			return job, "", fmt.Errorf("the job's data can't be read again (e.g. it came from stdin); use --abort")
+		}
+		cfg := pendingJobConfig(p)
+		data, err := pendingJobData(opts, p, cfg)
+		if err != nil {
+			return job, "", err
+		}
+		if err := uploadJobData(ctx, client, cfg, p.JobID, data); err != nil {
+			return job, "", fmt.Errorf("failed to upload data: %w", err)
+		}
+		trackUploaded(p)
+		action = "uploaded and closed"
+	}
+
+	job, err = client.CloseJob(ctx, p.JobID)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to close job: %w", err)
+	}
+	untrackJob(p.JobID)
+	return job, action, nil
+}
+
+// pendingJobConfig returns the config a recorded job was created with.
+func pendingJobConfig(p config.PendingBulkJob) bulk.JobConfig {
+	return bulk.JobConfig{
+		Object:          p.Object,
+		Operation:       bulk.Operation(p.Operation),
+		ExternalID:      p.ExternalID,
+		ContentType:     bulk.ContentType(p.ContentType),
+		LineEnding:      bulk.LineEnding(p.LineEnding),
+		ColumnDelimiter: bulk.ColumnDelimiter(p.ColumnDelimiter),
+	}
+}
+
+// pendingJobData reads a recorded job's data from its file again, prepared
+// as the import prepared it.
+func pendingJobData(opts *root.Options, p config.PendingBulkJob, cfg bulk.JobConfig) ([]byte, error) {
+	info, err := os.Stat(p.File)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	changed := fmt.Errorf("%s has changed since the import; use --abort", p.File)
+	if info.Size() != p.FileSize {
+		return nil, changed
+	}
+	end := p.Offset + p.Length
+
+	if cfg.ContentType == bulk.ContentTypeJSON {
+		records, err := readJSONFile(p.File)
+		if err != nil {
+			return nil, err
+		}
+		if end > len(records) {
+			return nil, changed
+		}
+		data, err := json.Marshal(records[p.Offset:end])
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode JSON records: %w", err)
+		}
+		return data, nil
+	}
+
+	format := csvFormat{delimiter: cfg.ColumnDelimiter, lineEnding: cfg.LineEnding}
+	data, err := readImportFile(p.File, p.Mapping, format)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Operation == bulk.OperationDelete || cfg.Operation == bulk.OperationHardDelete {
+		data, err = prepareDeleteData(opts, data, format)
+		if err != nil {
+			return nil, err
+		}
+	}
+	header, err := csvHeaderLength(data, format)
+	if err != nil {
+		return nil, err
+	}
+	if p.Offset < header || end > len(data) {
+		return nil, changed
+	}
+	return joinRows(data[:header], data[p.Offset:end]), nil
+}
+
+// joinRows returns the header followed by rows, in a new slice.
+func joinRows(header, rows []byte) []byte {
+	data := make([]byte, 0, len(header)+len(rows))
+	data = append(data, header...)
+	return append(data, rows...)
+}
+
+// renderPendingJobs lists the recorded jobs resume would act on.
+func renderPendingJobs(opts *root.Options, pending []config.PendingBulkJob) error {
+	rows := make([][]string, len(pending))
+	for i, p := range pending {
+		file := p.File
+		if file == "" {
+			file = "-"
+		}
+		uploaded := "no"
+		if p.Uploaded {
+			uploaded = "yes"
+		}
+		rows[i] = []string{
+			p.JobID,
+			p.Object,
+			p.Operation,
+			file,
+			uploaded,
+			p.Created.Local().Format("2006-01-02 15:04"),
+		}
+	}
+	return opts.View().Table([]string{"ID", "Object", "Operation", "File", "Uploaded", "Created"}, rows)
+}
+
+// renderResumeResults shows what resume did with each job.
+func renderResumeResults(opts *root.Options, results []*resumeResult) error {
+	v := opts.View()
+	if opts.Output == "json" {
+		return v.JSON(results)
+	}
+
+	rows := make([][]string, len(results))
+	for i, result := range results {
+		state := "-"
+		if result.Job != nil {
+			state = string(result.Job.State)
+		}
+		outcome := result.Action
+		if result.Error != "" {
+			outcome = "failed: " + result.Error
+		}
+		rows[i] = []string{result.JobID, result.Object, state, outcome}
+	}
+	return v.Table([]string{"ID", "Object", "State", "Result"}, rows)
+}
@@ -0,0 +1,184 @@
+package bulkcmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/salesforce-cli/api/bulk"
+	"github.com/open-cli-collective/salesforce-cli/internal/cmd/root"
+)
+
+func newRetryCommand(opts *root.Options) *cobra.Command {
+	var flags retryFlags
+
+	cmd := &cobra.Command{
+		Use:   "retry <job-id>",
+		Short: "Retry the failed records of a bulk job in a new job",
+		Long: `Retry the records that failed in a bulk ingest job.
+
+The job's failed records are downloaded, the sf__Id and sf__Error columns are
+removed, and the remaining data is uploaded to a new job with the same object,
+operation, and external ID field. The new job's ID is printed alongside the
+original's, so a chain of retries can be followed.
+
+With --mapping, the failed records are transformed before they are uploaded,
+as described by a mapping file (see 'sfdc bulk import --help'). Use it to fix
+the values that made the records fail.
+
+With --wait, the new job's progress is shown until it completes, up to
+--wait-timeout (default 30m).
+
+With --dry-run, the failed records are downloaded and checked, and the job
+that would be created is shown, without creating it.
+
+Before retrying a delete or hardDelete in a production org, the command names
+the org and asks for confirmation. Use --yes to skip the prompt.
+
+Examples:
+  sfdc bulk retry 750xx000000001
+  sfdc bulk retry 750xx000000001 --wait
+  sfdc bulk retry 750xx000000001 --mapping fix.json --wait`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runRetry(cmd.Context(), opts, args[0], flags)
+		},
+	}
+
+	cmd.Flags().StringVar(&flags.mapping, "mapping", "", "JSON file mapping the failed records' columns to fields")
+	cmd.Flags().BoolVar(&flags.wait, "wait", false, "Wait for the new job to complete")
+	cmd.Flags().BoolVarP(&flags.yes, "yes", "y", false, "Skip the production org confirmation for delete operations")
+	cmd.Flags().BoolVar(&opts.WaitOnRateLimit, "wait-on-rate-limit", false, "Wait and retry when rate limited instead of failing")
+	cmd.Flags().DurationVar(&opts.WaitTimeout, "wait-timeout", root.DefaultWaitTimeout, "How long --wait waits for the job to complete (0 for no limit)")
+
+	return cmd
+}
+
+// retryFlags holds the retry command's flags.
+type retryFlags struct {
+	mapping string
+	wait    bool
+	yes     bool
+}
+
+// retryResult is the outcome of a retry in JSON output.
+type retryResult struct {
+	RetryOf string        `json:"retryOf"`
+	Rows    int           `json:"rows"`
+	Job     *bulk.JobInfo `json:"job"`
+}
+
+func runRetry(ctx context.Context, opts *root.Options, jobID string, flags retryFlags) error {
+	client, err := opts.BulkClient()
+	if err != nil {
+		return fmt.Errorf("failed to create bulk client: %w", err)
+	}
+
+	original, err := client.GetJob(ctx, jobID)
+	if err != nil {
+		return fmt.Errorf("failed to get job: %w", err)
+	}
+	if !jobFinished(original.State) {
+		return fmt.Errorf("job %s is %s; wait for it to finish before retrying", original.ID, original.State)
+	}
+
+	failed, err := client.GetFailedResults(ctx, jobID)
+	if err != nil {
+		return fmt.Errorf("failed to get failed results: %w", err)
+	}
+	format := jobCSVFormat(original)
+	data, err := retryableCSV(failed, format)
+	if err != nil {
+		return fmt.Errorf("failed to build retryable CSV: %w", err)
+	}
+	if flags.mapping != "" {
+		data, err = mapCSV(flags.mapping, "failed records of "+jobID, bytes.NewReader(data), format)
+		if err != nil {
+			return err
+		}
+	}
+
+	rows, err := countCSVRows(data, format)
+	if err != nil {
+		return err
+	}
+
+	v := opts.View()
+	if rows == 0 {
+		v.Info("Job %s has no failed records to retry", jobID)
+		return nil
+	}
+
+	jobConfig := bulk.JobConfig{
+		Object:          original.Object,
+		Operation:       original.Operation,
+		ExternalID:      original.ExternalIDFieldName,
+		LineEnding:      original.LineEnding,
+		ColumnDelimiter: original.ColumnDelimiter,
+	}
+
+	if opts.DryRun {
+		details := map[string]interface{}{
+			"Retry Of": jobID,
+			"Rows":     rows,
+		}
+		if flags.mapping != "" {
+			details["Mapping"] = flags.mapping
+		}
+		return opts.PrintDryRun(root.DryRunRequest{
+			Operation: "bulk " + string(jobConfig.Operation),
+			Object:    jobConfig.Object,
+			Method:    http.MethodPost,
+			URL:       client.IngestJobsURL(),
+			Payload:   jobConfig.Request(),
+			Details:   details,
+		})
+	}
+
+	if jobConfig.Operation == bulk.OperationDelete || jobConfig.Operation == bulk.OperationHardDelete {
+		proceed, err := opts.ConfirmProduction(ctx, fmt.Sprintf("bulk %s on %s", jobConfig.Operation, jobConfig.Object), flags.yes)
+		if err != nil {
+			return err
+		}
+		if !proceed {
+			v.Info("Cancelled")
+			return nil
+		}
+	}
+
+	v.Info("Retrying %d failed records of job %s...", rows, jobID)
+	job, err := startJob(ctx, client, jobConfig, data, rows, jobSource{})
+	if err != nil {
+		return err
+	}
+	v.Info("Job created: %s", job.ID)
+
+	if flags.wait {
+		v.Info("Waiting for job to complete...")
+		job, err = waitForJob(ctx, opts, client, job.ID, rows)
+		if err != nil {
+			return fmt.Errorf("failed waiting for job: %w", err)
+		}
+	}
+
+	if opts.Output == "json" {
+		return v.JSON(retryResult{RetryOf: jobID, Rows: rows, Job: job})
+	}
+
+	v.Info("Job chain: %s -> %s", jobID, job.ID)
+	if !flags.wait {
+		v.Info("Job %s is processing. Use 'sfdc bulk job status %s' to check progress.", job.ID, job.ID)
+		return nil
+	}
+
+	if err := renderJobResult(opts, job); err != nil {
+		return err
+	}
+	if job.NumberRecordsFailed > 0 {
+		v.Info("Use 'sfdc bulk retry %s' to retry them again.", job.ID)
+	}
+	return nil
+}
@@ -0,0 +1,229 @@
+package bulkcmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/open-cli-collective/salesforce-cli/api/bulk"
+	"github.com/open-cli-collective/salesforce-cli/api/bulkv1"
+	"github.com/open-cli-collective/salesforce-cli/internal/cmd/root"
+)
+
+// maxSerialErrors is the most failed records listed after a serial import.
+const maxSerialErrors = 10
+
+// serialBatch is the CSV data of one batch of a serial import, with its
+// header row.
+type serialBatch struct {
+	data []byte
+	rows int
+}
+
+// runSerialImport imports the parts as one Bulk API 1.0 job in serial
+// concurrency mode, which processes one batch at a time so that batches
+// don't contend for record locks. Bulk API 2.0 has no such mode.
+func runSerialImport(ctx context.Context, opts *root.Options, cfg bulk.JobConfig, files []string, parts []*importPart, flags importFlags, format csvFormat) error {
+	var batches []serialBatch
+	for _, part := range parts {
+		partBatches, err := splitBatches(part.data, format)
+		if err != nil {
+			return err
+		}
+		batches = append(batches, partBatches...)
+	}
+	if len(batches) == 0 {
+		return fmt.Errorf("no records to import")
+	}
+
+	client, err := opts.BulkV1Client()
+	if err != nil {
+		return fmt.Errorf("failed to create bulk client: %w", err)
+	}
+
+	jobConfig := bulkv1.JobConfig{
+		Object:          cfg.Object,
+		Operation:       bulkv1.Operation(cfg.Operation),
+		ExternalID:      cfg.ExternalID,
+		ConcurrencyMode: bulkv1.ConcurrencySerial,
+	}
+
+	if opts.DryRun {
+		details := importDetails(files, flags.mapping, totalRows(parts))
+		details["Batches"] = len(batches)
+		return opts.PrintDryRun(root.DryRunRequest{
+			Operation: "bulk " + string(cfg.Operation),
+			Object:    cfg.Object,
+			Method:    http.MethodPost,
+			URL:       client.JobsURL(),
+			Payload:   jobConfig.Request(),
+			Details:   details,
+		})
+	}
+
+	v := opts.View()
+	v.Info("Creating serial Bulk API 1.0 %s job for %s...", cfg.Operation, cfg.Object)
+	job, err := client.CreateJob(ctx, jobConfig)
+	if err != nil {
+		return fmt.Errorf("failed to create job: %w", err)
+	}
+
+	v.Info("Job created: %s", job.ID)
+	v.Info("Uploading %d batches...", len(batches))
+	// Batches are matched to their rows of data by the order they were added
+	batchIDs := make([]string, len(batches))
+	for i, batch := range batches {
+		info, err := client.AddBatch(ctx, job.ID, batch.data)
+		if err != nil {
+			// Batches already added are processed even while the job is open
+			if _, abortErr := client.AbortJob(ctx, job.ID); abortErr != nil {
+				v.Warning("Failed to abort job %s: %v", job.ID, abortErr)
+			}
+			return fmt.Errorf("failed to add batch %d of %d to job %s: %w", i+1, len(batches), job.ID, err)
+		}
+		batchIDs[i] = info.ID
+	}
+
+	v.Info("Starting job processing...")
+	if _, err := client.CloseJob(ctx, job.ID); err != nil {
+		return fmt.Errorf("failed to close job: %w", err)
+	}
+
+	if !flags.wait {
+		v.Info("Job %s is processing one batch at a time. Use --wait to follow it, or see Bulk Data Load Jobs in Setup.", job.ID)
+		return nil
+	}
+
+	v.Info("Waiting for batches to complete...")
+	var (
+		infos []bulkv1.BatchInfo
+		last  string
+	)
+	err = root.Poll(ctx, pollInterval, opts.WaitTimeout, func() (bool, error) {
+		infos, err = client.ListBatches(ctx, job.ID)
+		if err != nil {
+			return false, fmt.Errorf("failed to list batches of job %s: %w", job.ID, err)
+		}
+		// The batches are summarized as those of a PK chunked query are
+		progress := chunkProgress(infos)
+		if status := progress.String(); status != last {
+			v.Info("  %s", status)
+			last = status
+		}
+		return progress.finished(), nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed waiting for job: %w", err)
+	}
+
+	finished, err := client.GetJob(ctx, job.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get job %s: %w", job.ID, err)
+	}
+	job = finished
+	if err := renderSerialJobResult(ctx, opts, client, job, batches, batchIDs); err != nil {
+		return err
+	}
+
+	progress := chunkProgress(infos)
+	if progress.failed > 0 {
+		return fmt.Errorf("%d of %d batches of job %s failed: %s", progress.failed, progress.total, job.ID, progress.failure)
+	}
+	return nil
+}
+
+// renderSerialJobResult prints the outcome of a finished serial import job,
+// listing the first of any records that failed.
+func renderSerialJobResult(ctx context.Context, opts *root.Options, client *bulkv1.Client, job *bulkv1.JobInfo, batches []serialBatch, batchIDs []string) error {
+	v := opts.View()
+
+	if opts.Output == "json" {
+		return v.JSON(job)
+	}
+
+	v.Info("Job completed:")
+	v.Info("  ID:                %s", job.ID)
+	v.Info("  State:             %s", job.State)
+	v.Info("  Batches:           %d of %d completed", job.NumberBatchesCompleted, job.NumberBatchesTotal)
+	v.Info("  Records Processed: %d", job.NumberRecordsProcessed)
+	v.Info("  Records Failed:    %d", job.NumberRecordsFailed)
+
+	if job.NumberRecordsFailed == 0 {
+		return nil
+	}
+
+	v.Info("\nFailed records:")
+	listed, row := 0, 0
+	for i, batch := range batches {
+		first := row
+		row += batch.rows
+		if listed == maxSerialErrors {
+			break
+		}
+
+		data, err := client.GetBatchResults(ctx, job.ID, batchIDs[i])
+		if err != nil {
+			return fmt.Errorf("failed to get results of batch %s: %w", batchIDs[i], err)
+		}
+		results, err := bulk.ParseCSVRecords(data)
+		if err != nil {
+			return err
+		}
+		for j, result := range results {
+			if result["Success"] == "true" || listed == maxSerialErrors {
+				continue
+			}
+			v.Info("  Row %d: %s", first+j+1, result["Error"])
+			listed++
+		}
+	}
+	if job.NumberRecordsFailed > listed {
+		v.Info("  ...and %d more", job.NumberRecordsFailed-listed)
+	}
+	return nil
+}
+
+// splitBatches splits CSV data in format into batches within Bulk API 1.0's
+// limits of records and bytes, each starting with the header row.
+func splitBatches(data []byte, format csvFormat) ([]serialBatch, error) {
+	r := format.reader(data)
+	r.ReuseRecord = true
+	if _, err := r.Read(); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("invalid CSV file: %w", err)
+	}
+	header := data[:r.InputOffset()]
+
+	var batches []serialBatch
+	add := func(rows []byte, n int) {
+		batch := make([]byte, 0, len(header)+len(rows))
+		batch = append(append(batch, header...), rows...)
+		batches = append(batches, serialBatch{data: batch, rows: n})
+	}
+
+	start, end, rows := len(header), len(header), 0
+	for {
+		_, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid CSV file: %w", err)
+		}
+		offset := int(r.InputOffset())
+		if rows == bulkv1.MaxBatchRecords || (rows > 0 && len(header)+offset-start > bulkv1.MaxBatchBytes) {
+			add(data[start:end], rows)
+			start, rows = end, 0
+		}
+		end = offset
+		rows++
+	}
+	if rows > 0 {
+		add(data[start:end], rows)
+	}
+	return batches, nil
+}
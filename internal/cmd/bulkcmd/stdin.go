@@ -0,0 +1,149 @@
+package bulkcmd
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/open-cli-collective/salesforce-cli/api/bulk"
+	"github.com/open-cli-collective/salesforce-cli/internal/cmd/root"
+)
+
+// stdinName names stdin in messages and dry-run details.
+const stdinName = "stdin"
+
+// runStdinImport imports CSV data read from stdin as one job, streaming it
+// to Salesforce as it is read instead of holding it in memory. Only the
+// header row is checked before the upload starts.
+func runStdinImport(ctx context.Context, opts *root.Options, cfg bulk.JobConfig, flags importFlags, format csvFormat, validator *importValidator) error {
+	if opts.Stdin == nil {
+		return fmt.Errorf("no data on stdin")
+	}
+	stdin := opts.Stdin
+	// The data comes from stdin, so a prompt must not read it
+	opts.Stdin = nil
+	defer func() { opts.Stdin = stdin }()
+
+	var r io.Reader = stdin
+	if flags.mapping != "" {
+		m, err := loadMapping(flags.mapping, format)
+		if err != nil {
+			return err
+		}
+		pr, pw := io.Pipe()
+		defer pr.Close()
+		go func() {
+			if err := m.Transform(pw, stdin); err != nil {
+				pw.CloseWithError(fmt.Errorf("failed to map %s: %w", stdinName, err))
+				return
+			}
+			pw.Close()
+		}()
+		r = pr
+	}
+
+	body := bufio.NewReader(r)
+	header, err := readHeaderLine(body)
+	if err != nil {
+		return err
+	}
+
+	if cfg.Operation == bulk.OperationDelete || cfg.Operation == bulk.OperationHardDelete {
+		// The second row tells a plain list of Ids from a header
+		next, err := body.ReadBytes('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return fmt.Errorf("failed to read %s: %w", stdinName, err)
+		}
+		header = append(header, next...)
+
+		header, err = prepareDeleteData(opts, header, format)
+		if err != nil {
+			return err
+		}
+	}
+
+	if validator != nil {
+		columns, _, err := csvRows(header, format)
+		if err != nil {
+			return err
+		}
+		if err := validator.check(ctx, stdinName, columns, nil); err != nil {
+			return err
+		}
+	}
+
+	data := io.MultiReader(bytes.NewReader(header), body)
+
+	client, err := opts.BulkClient()
+	if err != nil {
+		return fmt.Errorf("failed to create bulk client: %w", err)
+	}
+
+	if opts.DryRun {
+		// Nothing is uploaded, so the rows can be read and counted
+		all, err := io.ReadAll(data)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", stdinName, err)
+		}
+		rows, err := countCSVRows(all, format)
+		if err != nil {
+			return err
+		}
+		return opts.PrintDryRun(root.DryRunRequest{
+			Operation: "bulk " + string(cfg.Operation),
+			Object:    cfg.Object,
+			Method:    http.MethodPost,
+			URL:       client.IngestJobsURL(),
+			Payload:   cfg.Request(),
+			Details:   importDetails([]string{stdinName}, flags.mapping, rows),
+		})
+	}
+
+	v := opts.View()
+
+	if cfg.Operation == bulk.OperationDelete || cfg.Operation == bulk.OperationHardDelete {
+		proceed, err := opts.ConfirmProduction(ctx, fmt.Sprintf("bulk %s on %s", cfg.Operation, cfg.Object), flags.yes)
+		if err != nil {
+			return err
+		}
+		if !proceed {
+			v.Info("Cancelled")
+			return nil
+		}
+	}
+
+	v.Info("Creating bulk %s job for %s...", cfg.Operation, cfg.Object)
+	job, err := client.CreateJob(ctx, cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create job: %w", err)
+	}
+
+	v.Info("Job created: %s", job.ID)
+	// Data read from stdin can't be uploaded again, but the job can
+	// still be closed or aborted
+	pending := trackJob(client, cfg, job.ID, 0, jobSource{})
+
+	v.Info("Uploading data from %s...", stdinName)
+	if err := client.UploadJobDataFrom(ctx, job.ID, data); err != nil {
+		return fmt.Errorf("failed to upload data: %w", err)
+	}
+	trackUploaded(pending)
+
+	return finishImportJob(ctx, opts, client, job.ID, cfg.Operation, 0, flags.wait)
+}
+
+// readHeaderLine reads the header row of CSV data, with its line ending.
+func readHeaderLine(r *bufio.Reader) ([]byte, error) {
+	line, err := r.ReadBytes('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to read %s: %w", stdinName, err)
+	}
+	if len(bytes.TrimSpace(line)) == 0 {
+		return nil, fmt.Errorf("no data on %s", stdinName)
+	}
+	return line, nil
+}
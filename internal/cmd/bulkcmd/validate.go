@@ -0,0 +1,447 @@
+package bulkcmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/salesforce-cli/api"
+	"github.com/open-cli-collective/salesforce-cli/api/bulk"
+	"github.com/open-cli-collective/salesforce-cli/internal/cmd/root"
+)
+
+// maxIssuesPerColumn is the most invalid values of one column that are
+// listed; the rest are only counted.
+const maxIssuesPerColumn = 5
+
+func newValidateCommand(opts *root.Options) *cobra.Command {
+	var flags validateFlags
+
+	cmd := &cobra.Command{
+		Use:   "validate <object>",
+		Short: "Check a file against an object before importing it",
+		Long: `Check a CSV or JSON file against the object's describe before importing it,
+without creating a job.
+
+The file is checked for:
+  - columns that are not fields of the object, or relationships of it
+  - fields the operation can't write (not createable for insert, not
+    updateable for update)
+  - fields required on insert that have no column (a warning, since defaults
+    or automation may still set them)
+  - values of the wrong type, such as text in number, boolean, or date columns
+
+bulk import runs the same checks before creating its jobs, unless
+--skip-validation is given. The command exits with an error if any problem
+other than a warning is found; with --strict, warnings fail it too. Use
+--delimiter for CSV files whose columns are not separated by commas.
+
+Examples:
+  sfdc bulk validate Account --file accounts.csv
+  sfdc bulk validate Contact --file contacts.csv --operation upsert --external-id Email
+  sfdc bulk validate Account --file export.csv --mapping mapping.json
+  sfdc bulk validate Account --file accounts.jsonl -o json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runValidate(cmd.Context(), opts, args[0], flags)
+		},
+	}
+
+	cmd.Flags().StringVarP(&flags.file, "file", "f", "", "Path to the CSV or JSON file (required)")
+	cmd.Flags().StringVar(&flags.mapping, "mapping", "", "JSON file mapping the CSV columns to fields")
+	cmd.Flags().StringVar(&flags.contentType, "content-type", "", "Data format: csv or json (default from the file extension)")
+	cmd.Flags().StringVar(&flags.delimiter, "delimiter", "", "CSV column delimiter: COMMA, SEMICOLON, TAB, PIPE, CARET, or BACKQUOTE (default COMMA)")
+	cmd.Flags().StringVar(&flags.operation, "operation", "insert", "Operation: insert, update, or upsert")
+	cmd.Flags().StringVar(&flags.externalID, "external-id", "", "External ID field for upsert operation")
+	cmd.Flags().BoolVar(&flags.strict, "strict", false, "Fail on warnings too, such as missing required fields")
+
+	_ = cmd.MarkFlagRequired("file")
+
+	return cmd
+}
+
+// validateFlags holds the validate command's flags.
+type validateFlags struct {
+	file        string
+	mapping     string
+	contentType string
+	delimiter   string
+	operation   string
+	externalID  string
+	strict      bool
+}
+
+func runValidate(ctx context.Context, opts *root.Options, object string, flags validateFlags) error {
+	op := bulk.Operation(strings.ToLower(flags.operation))
+	switch op {
+	case bulk.OperationInsert, bulk.OperationUpdate, bulk.OperationUpsert:
+	default:
+		return fmt.Errorf("invalid operation: %s (must be insert, update, or upsert)", flags.operation)
+	}
+
+	contentType, err := importContentType(flags.contentType, []string{flags.file})
+	if err != nil {
+		return err
+	}
+	if contentType == bulk.ContentTypeJSON && flags.mapping != "" {
+		return fmt.Errorf("--mapping only applies to CSV files")
+	}
+
+	var header []string
+	var rows [][]string
+	if contentType == bulk.ContentTypeJSON {
+		records, err := readJSONFile(flags.file)
+		if err != nil {
+			return err
+		}
+		header, rows = jsonRows(records)
+	} else {
+		format, err := parseCSVFormat(flags.delimiter, "")
+		if err != nil {
+			return err
+		}
+		data, err := readImportFile(flags.file, flags.mapping, format)
+		if err != nil {
+			return err
+		}
+		header, rows, err = csvRows(data, format)
+		if err != nil {
+			return err
+		}
+	}
+
+	client, err := opts.APIClient()
+	if err != nil {
+		return fmt.Errorf("failed to create API client: %w", err)
+	}
+	desc, err := client.DescribeSObject(ctx, object)
+	if err != nil {
+		return fmt.Errorf("failed to describe object: %w", err)
+	}
+
+	result := validateRows(desc, op, flags.externalID, header, rows)
+
+	v := opts.View()
+	if opts.Output == "json" {
+		if err := v.JSON(result); err != nil {
+			return err
+		}
+	} else if len(result.Issues) == 0 {
+		v.Success("%s: %d rows are valid for %s on %s", flags.file, result.Rows, op, object)
+	} else {
+		tableRows := make([][]string, len(result.Issues))
+		for i, issue := range result.Issues {
+			severity := "error"
+			if issue.Warning {
+				severity = "warning"
+			}
+			row := "-"
+			if issue.Row > 0 {
+				row = strconv.Itoa(issue.Row)
+			}
+			tableRows[i] = []string{row, issue.Column, severity, issue.Problem}
+		}
+		if err := v.Table([]string{"Row", "Column", "Severity", "Problem"}, tableRows); err != nil {
+			return err
+		}
+		v.Info("\n%d rows checked: %d errors, %d warnings", result.Rows, result.Errors, result.Warnings)
+	}
+
+	return result.err(flags.strict)
+}
+
+// validationIssue is a problem found in data to import.
+type validationIssue struct {
+	// Row is the record the problem is in, from 1; zero for the header
+	Row     int    `json:"row,omitempty"`
+	Column  string `json:"column,omitempty"`
+	Problem string `json:"problem"`
+	// Warning marks problems that may not make records fail
+	Warning bool `json:"warning,omitempty"`
+}
+
+func (i validationIssue) String() string {
+	switch {
+	case i.Row > 0:
+		return fmt.Sprintf("row %d, %s: %s", i.Row, i.Column, i.Problem)
+	case i.Column != "":
+		return fmt.Sprintf("%s: %s", i.Column, i.Problem)
+	}
+	return i.Problem
+}
+
+// validationResult is the outcome of checking data against an object.
+type validationResult struct {
+	Object    string            `json:"object"`
+	Operation bulk.Operation    `json:"operation"`
+	Rows      int               `json:"rows"`
+	Errors    int               `json:"errors"`
+	Warnings  int               `json:"warnings"`
+	Issues    []validationIssue `json:"issues"`
+}
+
+func (r *validationResult) add(issue validationIssue) {
+	r.Issues = append(r.Issues, issue)
+	if issue.Warning {
+		r.Warnings++
+	} else {
+		r.Errors++
+	}
+}
+
+// err returns an error naming the first problem if the data failed
+// validation: if there are errors or, when strict, warnings.
+func (r *validationResult) err(strict bool) error {
+	failed := r.Errors
+	if strict {
+		failed += r.Warnings
+	}
+	if failed == 0 {
+		return nil
+	}
+
+	for _, issue := range r.Issues {
+		if issue.Warning && !strict {
+			continue
+		}
+		if failed == 1 {
+			return fmt.Errorf("validation failed: %s", issue)
+		}
+		return fmt.Errorf("validation failed: %s (and %d more problems)", issue, failed-1)
+	}
+	return nil
+}
+
+// validateRows checks the columns and values of data to import against the
+// object's describe.
+func validateRows(desc *api.SObjectDescribe, op bulk.Operation, externalID string, header []string, rows [][]string) *validationResult {
+	result := &validationResult{
+		Object:    desc.Name,
+		Operation: op,
+		Rows:      len(rows),
+		Issues:    []validationIssue{},
+	}
+
+	fields := make(map[string]api.Field, len(desc.Fields))
+	relationships := make(map[string]api.Field)
+	for _, f := range desc.Fields {
+		fields[strings.ToLower(f.Name)] = f
+		if f.RelationshipName != "" {
+			relationships[strings.ToLower(f.RelationshipName)] = f
+		}
+	}
+
+	kinds := make([]fieldKind, len(header))
+	for i, column := range header {
+		name := strings.TrimSpace(column)
+
+		// Relationship columns such as Account.External_Id__c, or
+		// Owner:User.Email for polymorphic ones, set the reference field
+		if rel, _, ok := strings.Cut(name, "."); ok {
+			rel, _, _ = strings.Cut(rel, ":")
+			f, ok := relationships[strings.ToLower(rel)]
+			if !ok {
+				result.add(validationIssue{Column: column, Problem: fmt.Sprintf("%s is not a relationship of %s", rel, desc.Name)})
+				continue
+			}
+			if problem := writeProblem(f, op, externalID); problem != "" {
+				result.add(validationIssue{Column: column, Problem: problem})
+			}
+			continue
+		}
+
+		f, ok := fields[strings.ToLower(name)]
+		if !ok {
+			result.add(validationIssue{Column: column, Problem: fmt.Sprintf("not a field of %s", desc.Name)})
+			continue
+		}
+		if problem := writeProblem(f, op, externalID); problem != "" {
+			result.add(validationIssue{Column: column, Problem: problem})
+			continue
+		}
+		kinds[i] = kindOf(f.Type)
+	}
+
+	if op == bulk.OperationInsert || op == bulk.OperationUpsert {
+		if missing := missingRequiredFields(desc.Fields, header); len(missing) > 0 {
+			result.add(validationIssue{
+				Problem: fmt.Sprintf("missing required fields for %s: %s", desc.Name, strings.Join(missing, ", ")),
+				Warning: true,
+			})
+		}
+	}
+
+	for i, column := range header {
+		if kinds[i] == kindString {
+			continue
+		}
+		invalid := 0
+		for r, row := range rows {
+			if i >= len(row) || row[i] == "" || row[i] == bulk.NullValue {
+				continue
+			}
+			if _, err := typedValue(kinds[i], row[i]); err == nil {
+				continue
+			}
+			invalid++
+			if invalid <= maxIssuesPerColumn {
+				result.add(validationIssue{Row: r + 1, Column: column, Problem: fmt.Sprintf("%q is %s", row[i], kindProblem(kinds[i]))})
+			}
+		}
+		if invalid > maxIssuesPerColumn {
+			result.add(validationIssue{Column: column, Problem: fmt.Sprintf("and %d more invalid values", invalid-maxIssuesPerColumn)})
+		}
+	}
+
+	return result
+}
+
+// writeProblem describes why the operation can't write a field, or returns
+// "" if it can. Id identifies the records to update, and the external ID
+// field the records to upsert.
+func writeProblem(f api.Field, op bulk.Operation, externalID string) string {
+	if strings.EqualFold(f.Name, "Id") && (op == bulk.OperationUpdate || op == bulk.OperationUpsert) {
+		return ""
+	}
+	if op == bulk.OperationUpsert && strings.EqualFold(f.Name, externalID) {
+		return ""
+	}
+
+	switch op {
+	case bulk.OperationInsert:
+		if !f.Createable {
+			return "field is not createable"
+		}
+	case bulk.OperationUpdate:
+		if !f.Updateable {
+			return "field is not updateable"
+		}
+	case bulk.OperationUpsert:
+		if !f.Createable && !f.Updateable {
+			return "field is not createable or updateable"
+		}
+	}
+	return ""
+}
+
+// kindProblem describes a value that is not of a kind.
+func kindProblem(kind fieldKind) string {
+	switch kind {
+	case kindBoolean:
+		return "not a boolean (true or false)"
+	case kindInteger:
+		return "not a whole number"
+	case kindNumber:
+		return "not a number"
+	case kindDate:
+		return "not a date (YYYY-MM-DD)"
+	case kindDateTime:
+		return "not a date and time (e.g. 2024-01-15T10:00:00Z)"
+	}
+	return "not valid"
+}
+
+// csvRows parses CSV data in format into its header and rows.
+func csvRows(data []byte, format csvFormat) ([]string, [][]string, error) {
+	r := format.reader(data)
+	header, err := r.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, nil, nil
+		}
+		return nil, nil, fmt.Errorf("failed to read CSV header: %w", err)
+	}
+	rows, err := r.ReadAll()
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid CSV file: %w", err)
+	}
+	return header, rows, nil
+}
+
+// jsonRows returns the fields of JSON records and their values as strings,
+// for validation. Nested values, such as related records matched by an
+// external ID, are left empty so their type is not checked.
+func jsonRows(records []map[string]interface{}) ([]string, [][]string) {
+	header := recordFields(records)
+	rows := make([][]string, len(records))
+	for r, record := range records {
+		row := make([]string, len(header))
+		for i, field := range header {
+			switch value := record[field].(type) {
+			case string:
+				row[i] = value
+			case json.Number, bool:
+				row[i] = fmt.Sprint(value)
+			}
+		}
+		rows[r] = row
+	}
+	return header, rows
+}
+
+// importValidator checks the files of an import against the object's
+// describe, which it gets once, when the first file is checked.
+type importValidator struct {
+	opts       *root.Options
+	object     string
+	op         bulk.Operation
+	externalID string
+	strict     bool
+
+	desc    *api.SObjectDescribe
+	skipped bool
+}
+
+// check validates one file's data, printing any problems, and returns an
+// error if it failed. If the object can't be described, a warning is shown
+// and the import goes ahead unchecked.
+func (iv *importValidator) check(ctx context.Context, name string, header []string, rows [][]string) error {
+	if iv.skipped {
+		return nil
+	}
+	v := iv.opts.View()
+
+	if iv.desc == nil {
+		desc, err := iv.describe(ctx)
+		if err != nil {
+			v.Warning("Skipping validation: %v", err)
+			iv.skipped = true
+			return nil
+		}
+		iv.desc = desc
+	}
+
+	result := validateRows(iv.desc, iv.op, iv.externalID, header, rows)
+	for _, issue := range result.Issues {
+		if issue.Warning {
+			v.Warning("%s: %s", name, issue)
+		} else {
+			v.Error("%s: %s", name, issue)
+		}
+	}
+	if err := result.err(iv.strict); err != nil {
+		return fmt.Errorf("%s: %w; fix the data or use --skip-validation", name, err)
+	}
+	if len(result.Issues) == 0 {
+		v.Info("%s: %d rows passed validation against %s", name, result.Rows, iv.object)
+	}
+	return nil
+}
+
+func (iv *importValidator) describe(ctx context.Context) (*api.SObjectDescribe, error) {
+	client, err := iv.opts.APIClient()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create API client: %w", err)
+	}
+	desc, err := client.DescribeSObject(ctx, iv.object)
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe %s: %w", iv.object, err)
+	}
+	return desc, nil
+}
@@ -0,0 +1,149 @@
+package bulkcmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/salesforce-cli/api/bulk"
+	"github.com/open-cli-collective/salesforce-cli/internal/cmd/root"
+)
+
+func newJobWatchCommand(opts *root.Options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "watch <job-id>",
+		Short: "Watch a bulk job until it finishes",
+		Long: `Watch a bulk ingest or query job until it finishes, showing its state,
+processed and failed record counts, and processing time as they change.
+
+On a terminal the progress line is redrawn in place; otherwise a line is
+logged whenever the job changes. Waits up to --wait-timeout, and exits
+non-zero if the job fails or is aborted.
+
+Examples:
+  sfdc bulk job watch 750xx000000001
+  sfdc bulk job watch 750xx000000001 --wait-timeout 2h
+  sfdc bulk job watch 750xx000000001 -o json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runJobWatch(cmd.Context(), opts, args[0])
+		},
+	}
+
+	cmd.Flags().DurationVar(&opts.WaitTimeout, "wait-timeout", root.DefaultWaitTimeout, "How long to watch the job (0 for no limit)")
+
+	return cmd
+}
+
+func runJobWatch(ctx context.Context, opts *root.Options, jobID string) error {
+	client, err := opts.BulkClient()
+	if err != nil {
+		return fmt.Errorf("failed to create bulk client: %w", err)
+	}
+
+	// Job IDs don't say what kind of job they are, so look for an ingest
+	// job first and fall back to a query job
+	job, err := client.GetJob(ctx, jobID)
+	if err != nil {
+		queryJob, qerr := client.GetQueryJob(ctx, jobID)
+		if qerr != nil {
+			return fmt.Errorf("failed to get job: %w", err)
+		}
+		return watchQueryJob(ctx, opts, client, queryJob)
+	}
+
+	return watchIngestJob(ctx, opts, client, job)
+}
+
+// watchIngestJob shows an ingest job's progress until it finishes.
+func watchIngestJob(ctx context.Context, opts *root.Options, client *bulk.Client, job *bulk.JobInfo) error {
+	progress := newJobProgress(opts, 0)
+	progress.processingTime = true
+	progress.update(job)
+
+	if !jobFinished(job.State) {
+		cfg := pollConfig(opts)
+		cfg.OnJob = progress.update
+
+		var err error
+		job, err = client.PollJob(ctx, job.ID, cfg)
+		if err != nil {
+			progress.finish()
+			return fmt.Errorf("failed waiting for job: %w", err)
+		}
+	}
+	progress.finish()
+
+	if err := renderJobResult(opts, job); err != nil {
+		return err
+	}
+	return watchedJobError(job.ID, job.State, job.ErrorMessage)
+}
+
+// watchQueryJob shows a query job's progress until it finishes.
+func watchQueryJob(ctx context.Context, opts *root.Options, client *bulk.Client, job *bulk.QueryJobInfo) error {
+	progress := newJobProgress(opts, 0)
+	progress.processingTime = true
+	progress.update(queryJobProgress(job))
+
+	if !jobFinished(job.State) {
+		cfg := pollConfig(opts)
+		cfg.OnQueryJob = func(job *bulk.QueryJobInfo) {
+			progress.update(queryJobProgress(job))
+		}
+
+		var err error
+		job, err = client.PollQueryJob(ctx, job.ID, cfg)
+		if err != nil {
+			progress.finish()
+			return fmt.Errorf("failed waiting for query job: %w", err)
+		}
+	}
+	progress.finish()
+
+	if err := renderQueryJobResult(opts, job); err != nil {
+		return err
+	}
+	return watchedJobError(job.ID, job.State, "")
+}
+
+// queryJobProgress returns the parts of a query job's status that job
+// progress shows.
+func queryJobProgress(job *bulk.QueryJobInfo) *bulk.JobInfo {
+	return &bulk.JobInfo{
+		ID:                     job.ID,
+		State:                  job.State,
+		NumberRecordsProcessed: job.NumberRecordsProcessed,
+		TotalProcessingTime:    job.TotalProcessingTime,
+	}
+}
+
+func renderQueryJobResult(opts *root.Options, job *bulk.QueryJobInfo) error {
+	v := opts.View()
+
+	if opts.Output == "json" {
+		return v.JSON(job)
+	}
+
+	v.Info("Query job finished:")
+	v.Info("  ID:                %s", job.ID)
+	v.Info("  Object:            %s", job.Object)
+	v.Info("  State:             %s", job.State)
+	v.Info("  Records Processed: %d", job.NumberRecordsProcessed)
+
+	return nil
+}
+
+// watchedJobError returns an error if a watched job failed or was aborted,
+// so scripts can wait on a job and tell whether it succeeded.
+func watchedJobError(jobID string, state bulk.State, message string) error {
+	switch state {
+	case bulk.StateFailed, bulk.StateAborted:
+		if message != "" {
+			return fmt.Errorf("job %s %s: %s", jobID, state, message)
+		}
+		return fmt.Errorf("job %s %s", jobID, state)
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+// Package cachecmd provides commands for managing the local cache.
+package cachecmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/salesforce-cli/internal/cmd/root"
+	"github.com/open-cli-collective/salesforce-cli/internal/config"
+)
+
+// Register registers the cache command with the root command.
+func Register(parent *cobra.Command, opts *root.Options) {
+	parent.AddCommand(NewCommand(opts))
+}
+
+// NewCommand creates the cache command with subcommands.
+func NewCommand(opts *root.Options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cache",
+		Short: "Manage the local cache",
+		Long: `Manage the local cache of object describes.
+
+Describes are cached per org and API version in the cache directory under the
+configuration directory. Cached describes are used for 24 hours by default;
+set "describe_cache_ttl" in config.json or SFDC_DESCRIBE_CACHE_TTL to change
+this (e.g. 1h, or 0 to turn the cache off). After that, Salesforce is asked
+whether the object changed before it is downloaded again. Use --no-cache on
+any command to skip the cache.`,
+	}
+
+	cmd.AddCommand(newClearCommand(opts))
+
+	return cmd
+}
+
+func newClearCommand(opts *root.Options) *cobra.Command {
+	return &cobra.Command{
+		Use:   "clear",
+		Short: "Remove all cached data",
+		Long: `Remove all cached object describes, for every org.
+
+Examples:
+  sfdc cache clear`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runClear(opts)
+		},
+	}
+}
+
+func runClear(opts *root.Options) error {
+	dir, err := config.GetCacheDir()
+	if err != nil {
+		return fmt.Errorf("failed to find cache directory: %w", err)
+	}
+
+	if err := config.ClearCache(); err != nil {
+		return fmt.Errorf("failed to clear cache: %w", err)
+	}
+
+	opts.View().Success("Cleared cache (%s)", config.ShortenPath(dir))
+	return nil
+}
@@ -0,0 +1,40 @@
+package cachecmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/salesforce-cli/internal/cmd/root"
+	"github.com/open-cli-collective/salesforce-cli/internal/config"
+)
+
+func TestClearCommand(t *testing.T) {
+	config.SetConfigDir(t.TempDir())
+	defer config.SetConfigDir("")
+
+	dir, err := config.GetCacheDir()
+	require.NoError(t, err)
+	entry := filepath.Join(dir, "describe", "example.my.salesforce.com", "v62.0", "Account.json")
+	require.NoError(t, os.MkdirAll(filepath.Dir(entry), 0700))
+	require.NoError(t, os.WriteFile(entry, []byte("{}"), 0600))
+
+	stdout := &bytes.Buffer{}
+	opts := &root.Options{
+		Output:  "table",
+		NoColor: true,
+		Stdout:  stdout,
+		Stderr:  &bytes.Buffer{},
+	}
+
+	cmd := NewCommand(opts)
+	cmd.SetArgs([]string{"clear"})
+
+	require.NoError(t, cmd.Execute())
+	assert.NoDirExists(t, dir)
+	assert.Contains(t, stdout.String(), "Cleared cache")
+}
@@ -0,0 +1,37 @@
+// Package chattercmd provides commands for posting to Chatter.
+package chattercmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/salesforce-cli/api/chatter"
+	"github.com/open-cli-collective/salesforce-cli/internal/cmd/root"
+)
+
+// Register registers the chatter command with the root command.
+func Register(parent *cobra.Command, opts *root.Options) {
+	parent.AddCommand(NewCommand(opts))
+}
+
+// NewCommand creates the chatter command with subcommands.
+func NewCommand(opts *root.Options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "chatter",
+		Short: "Post to Chatter",
+		Long:  "Post messages and comments to Chatter feeds, for example to announce CI results to a group.",
+	}
+
+	cmd.AddCommand(newPostCommand(opts))
+	cmd.AddCommand(newCommentCommand(opts))
+
+	return cmd
+}
+
+// buildMessage returns text followed by a mention of each ID.
+func buildMessage(text string, mentions []string) chatter.Message {
+	message := chatter.NewMessage(text)
+	for _, id := range mentions {
+		message = message.Text(" ").Mention(id)
+	}
+	return message
+}
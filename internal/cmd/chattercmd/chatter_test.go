@@ -0,0 +1,147 @@
+package chattercmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/salesforce-cli/api"
+	"github.com/open-cli-collective/salesforce-cli/api/chatter"
+	"github.com/open-cli-collective/salesforce-cli/internal/cmd/root"
+)
+
+// chatterServer accepts posts and comments, recording the request bodies.
+func chatterServer(t *testing.T, received *[]map[string]interface{}) *api.Client {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		*received = append(*received, body)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		switch r.URL.Path {
+		case "/services/data/v62.0/chatter/feed-elements":
+			_, _ = w.Write([]byte(`{"id": "0D5xx0000000001", "parent": {"id": "0F9xx0000000001", "name": "Releases"}}`))
+		case "/services/data/v62.0/chatter/feed-elements/0D5xx0000000001/capabilities/comments/items":
+			_, _ = w.Write([]byte(`{"id": "0D7xx0000000001"}`))
+		default:
+			t.Errorf("unexpected request: %s", r.URL.Path)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := api.New(api.ClientConfig{InstanceURL: server.URL, HTTPClient: server.Client()})
+	require.NoError(t, err)
+	return client
+}
+
+func TestPostCommand(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		wantSubject string
+	}{
+		{
+			name:        "group",
+			args:        []string{"--group", "0F9xx0000000001"},
+			wantSubject: "0F9xx0000000001",
+		},
+		{
+			name:        "own feed",
+			wantSubject: "me",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var received []map[string]interface{}
+			stdout := &bytes.Buffer{}
+			opts := &root.Options{
+				Output:  "table",
+				NoColor: true,
+				Stdout:  stdout,
+				Stderr:  &bytes.Buffer{},
+			}
+			opts.SetAPIClient(chatterServer(t, &received))
+
+			cmd := NewCommand(opts)
+			cmd.SetArgs(append([]string{"post", "--message", "Deploy complete"}, tt.args...))
+
+			require.NoError(t, cmd.Execute())
+			require.Len(t, received, 1)
+			assert.Equal(t, tt.wantSubject, received[0]["subjectId"])
+			assert.Contains(t, stdout.String(), "Posted to Releases: 0D5xx0000000001")
+		})
+	}
+}
+
+func TestPostCommand_Conflict(t *testing.T) {
+	opts := &root.Options{
+		Output: "table",
+		Stdout: &bytes.Buffer{},
+		Stderr: &bytes.Buffer{},
+	}
+
+	cmd := NewCommand(opts)
+	cmd.SetArgs([]string{"post", "--message", "hi", "--group", "0F9xx1", "--record", "001xx1"})
+
+	err := cmd.Execute()
+	assert.EqualError(t, err, "only one of --group, --record, and --user can be used")
+}
+
+func TestPostCommand_DryRun(t *testing.T) {
+	var received []map[string]interface{}
+	stdout := &bytes.Buffer{}
+	opts := &root.Options{
+		Output: "json",
+		DryRun: true,
+		Stdout: stdout,
+		Stderr: &bytes.Buffer{},
+	}
+	opts.SetAPIClient(chatterServer(t, &received))
+
+	cmd := NewCommand(opts)
+	cmd.SetArgs([]string{"post", "--group", "0F9xx1", "--message", "Deploy failed", "--mention", "005xx1"})
+
+	require.NoError(t, cmd.Execute())
+	assert.Empty(t, received)
+
+	var out struct {
+		Request struct {
+			Payload chatter.FeedItemInput `json:"payload"`
+		} `json:"request"`
+	}
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &out))
+	assert.Equal(t, chatter.NewFeedItemInput("0F9xx1", chatter.NewMessage("Deploy failed").Text(" ").Mention("005xx1")), out.Request.Payload)
+}
+
+func TestCommentCommand(t *testing.T) {
+	var received []map[string]interface{}
+	stdout := &bytes.Buffer{}
+	opts := &root.Options{
+		Output:  "table",
+		NoColor: true,
+		Stdout:  stdout,
+		Stderr:  &bytes.Buffer{},
+	}
+	opts.SetAPIClient(chatterServer(t, &received))
+
+	cmd := NewCommand(opts)
+	cmd.SetArgs([]string{"comment", "0D5xx0000000001", "-m", "Looks good", "--mention", "005xx1"})
+
+	require.NoError(t, cmd.Execute())
+	require.Len(t, received, 1)
+	assert.Equal(t, map[string]interface{}{"messageSegments": []interface{}{
+		map[string]interface{}{"type": "Text", "text": "Looks good"},
+		map[string]interface{}{"type": "Text", "text": " "},
+		map[string]interface{}{"type": "Mention", "id": "005xx1"},
+	}}, received[0]["body"])
+	assert.Contains(t, stdout.String(), "Commented on 0D5xx0000000001: 0D7xx0000000001")
+}
@@ -0,0 +1,70 @@
+package chattercmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/salesforce-cli/api/chatter"
+	"github.com/open-cli-collective/salesforce-cli/internal/cmd/root"
+)
+
+func newCommentCommand(opts *root.Options) *cobra.Command {
+	var (
+		message  string
+		mentions []string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "comment <feed-element-id>",
+		Short: "Comment on a post",
+		Long: `Add a comment to a Chatter post (feed element).
+
+Examples:
+  sfdc chatter comment 0D5xx0000000001 --message "Fixed in the next deploy"
+  sfdc chatter comment 0D5xx0000000001 --message "Can you take a look?" --mention 005xx000001abcd`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runComment(cmd.Context(), opts, args[0], buildMessage(message, mentions))
+		},
+	}
+
+	cmd.Flags().StringVarP(&message, "message", "m", "", "Comment text (required)")
+	cmd.Flags().StringArrayVar(&mentions, "mention", nil, "ID of a user or group to @mention (repeatable)")
+
+	_ = cmd.MarkFlagRequired("message")
+
+	return cmd
+}
+
+func runComment(ctx context.Context, opts *root.Options, feedElementID string, message chatter.Message) error {
+	client, err := opts.APIClient()
+	if err != nil {
+		return fmt.Errorf("failed to create API client: %w", err)
+	}
+
+	if opts.DryRun {
+		return opts.PrintDryRun(root.DryRunRequest{
+			Operation: "chatter comment",
+			Method:    http.MethodPost,
+			URL:       client.ResourceURL(chatter.CommentsPath(feedElementID)),
+			Payload:   chatter.CommentInput{Body: message},
+		})
+	}
+
+	comment, err := chatter.New(client).Comment(ctx, feedElementID, message)
+	if err != nil {
+		return fmt.Errorf("failed to comment: %w", err)
+	}
+
+	v := opts.View()
+
+	if opts.Output == "json" {
+		return v.JSON(comment)
+	}
+
+	v.Success("Commented on %s: %s", feedElementID, comment.ID)
+	return nil
+}
@@ -0,0 +1,108 @@
+package chattercmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/salesforce-cli/api/chatter"
+	"github.com/open-cli-collective/salesforce-cli/internal/cmd/root"
+)
+
+func newPostCommand(opts *root.Options) *cobra.Command {
+	var (
+		group    string
+		record   string
+		user     string
+		message  string
+		mentions []string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "post",
+		Short: "Post a message to a feed",
+		Long: `Post a message to the feed of a group, a record, or a user. Without
+--group, --record, or --user, the message is posted to your own feed.
+
+--mention adds an @mention of a user or group after the message; mentioned
+users are notified.
+
+Examples:
+  sfdc chatter post --group 0F9xx0000000001 --message "Deploy complete"
+  sfdc chatter post --record 006xx000001abcd --message "Contract signed"
+  sfdc chatter post --group 0F9xx0000000001 --message "Deploy failed, see" --mention 005xx000001abcd
+  sfdc chatter post --message "Note to self"`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			subject, err := postSubject(group, record, user)
+			if err != nil {
+				return err
+			}
+			return runPost(cmd.Context(), opts, subject, buildMessage(message, mentions))
+		},
+	}
+
+	cmd.Flags().StringVar(&group, "group", "", "ID of the group to post to")
+	cmd.Flags().StringVar(&record, "record", "", "ID of the record to post to")
+	cmd.Flags().StringVar(&user, "user", "", "ID of the user to post to")
+	cmd.Flags().StringVarP(&message, "message", "m", "", "Message text (required)")
+	cmd.Flags().StringArrayVar(&mentions, "mention", nil, "ID of a user or group to @mention (repeatable)")
+
+	_ = cmd.MarkFlagRequired("message")
+
+	return cmd
+}
+
+// postSubject returns the ID of the feed to post to: the one given, or the
+// user's own feed if none is.
+func postSubject(group, record, user string) (string, error) {
+	subject := chatter.SubjectMe
+	set := 0
+	for _, id := range []string{group, record, user} {
+		if id != "" {
+			subject = id
+			set++
+		}
+	}
+	if set > 1 {
+		return "", fmt.Errorf("only one of --group, --record, and --user can be used")
+	}
+	return subject, nil
+}
+
+func runPost(ctx context.Context, opts *root.Options, subject string, message chatter.Message) error {
+	client, err := opts.APIClient()
+	if err != nil {
+		return fmt.Errorf("failed to create API client: %w", err)
+	}
+
+	if opts.DryRun {
+		return opts.PrintDryRun(root.DryRunRequest{
+			Operation: "chatter post",
+			Method:    http.MethodPost,
+			URL:       client.ResourceURL(chatter.FeedElementsPath),
+			Payload:   chatter.NewFeedItemInput(subject, message),
+		})
+	}
+
+	element, err := chatter.New(client).Post(ctx, subject, message)
+	if err != nil {
+		return fmt.Errorf("failed to post to Chatter: %w", err)
+	}
+
+	v := opts.View()
+
+	if opts.Output == "json" {
+		return v.JSON(element)
+	}
+
+	if element.Parent.Name != "" {
+		v.Success("Posted to %s: %s", element.Parent.Name, element.ID)
+	} else {
+		v.Success("Posted: %s", element.ID)
+	}
+	v.Info("URL: %s", v.Link(client.RecordURL(element.ID)))
+	return nil
+}
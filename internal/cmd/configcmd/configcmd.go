@@ -2,10 +2,12 @@
 package configcmd
 
 import (
-	"context"
+	"errors"
 	"fmt"
-	"net/http"
+	"os"
+	"sort"
 	"strings"
+	"text/tabwriter"
 
 	"github.com/spf13/cobra"
 
@@ -31,6 +33,8 @@ func NewCommand() *cobra.Command {
 	cmd.AddCommand(newShowCommand())
 	cmd.AddCommand(newTestCommand())
 	cmd.AddCommand(newClearCommand())
+	cmd.AddCommand(newOrgsCommand())
+	cmd.AddCommand(newUseOrgCommand())
 
 	return cmd
 }
@@ -73,6 +77,35 @@ func newClearCommand() *cobra.Command {
 	return cmd
 }
 
+func newOrgsCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "orgs",
+		Short: "List org profiles",
+		Long: `List the named org profiles added with 'sfdc init --alias'.
+
+The default org is marked with *. Select another org for a single command
+with --org, or for a shell session with SFDC_ORG.`,
+		Args: cobra.NoArgs,
+		RunE: runOrgs,
+	}
+}
+
+func newUseOrgCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "use-org <alias>",
+		Short: "Set the default org profile",
+		Long: `Set the org profile that commands use when --org is not given.
+
+Examples:
+  sfdc config use-org dev1
+  sfdc config use-org prod`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runUseOrg(args[0])
+		},
+	}
+}
+
 func runShow(cmd *cobra.Command, args []string) error {
 	cfg, err := config.Load()
 	if err != nil {
@@ -83,6 +116,10 @@ func runShow(cmd *cobra.Command, args []string) error {
 	fmt.Println("============================")
 	fmt.Println()
 
+	if alias := cfg.OrgAlias(); alias != "" {
+		fmt.Printf("Org:             %s\n", alias)
+	}
+
 	if cfg.InstanceURL != "" {
 		fmt.Printf("Instance URL:    %s\n", cfg.InstanceURL)
 	} else {
@@ -97,9 +134,19 @@ func runShow(cmd *cobra.Command, args []string) error {
 	}
 
 	fmt.Println()
-	if keychain.HasStoredToken() {
+	switch {
+	case cfg.AuthFlow == config.AuthFlowJWT:
+		// No token is stored for the JWT bearer flow
+		fmt.Printf("Auth:            JWT bearer as %s\n", cfg.Username)
+		fmt.Printf("Private key:     %s\n", config.ShortenPath(cfg.JWTKeyFile))
+	case keychain.HasStoredToken():
+		if cfg.AuthFlow == config.AuthFlowClientCredentials {
+			fmt.Println("Auth:            Client credentials")
+		} else if cfg.AuthFlow == config.AuthFlowPassword {
+			fmt.Printf("Auth:            Username-password as %s\n", cfg.Username)
+		}
 		fmt.Printf("Token:           Found (stored in %s)\n", keychain.GetStorageBackend())
-	} else {
+	default:
 		fmt.Println("Token:           Not found")
 	}
 
@@ -119,38 +166,37 @@ func runTest(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to load configuration: %w", err)
 	}
 
-	if cfg.InstanceURL == "" || cfg.ClientID == "" {
+	if !cfg.Configured() {
 		return fmt.Errorf("not configured - please run 'sfdc init' first")
 	}
 
 	fmt.Println("Testing Salesforce connection...")
 	fmt.Println()
 
-	if !keychain.HasStoredToken() {
+	if cfg.AuthFlow == config.AuthFlowJWT {
+		fmt.Println("  Auth:        JWT bearer")
+	} else if cfg.AuthFlow == config.AuthFlowClientCredentials {
+		fmt.Println("  Auth:        Client credentials")
+	} else if cfg.AuthFlow == config.AuthFlowPassword {
+		fmt.Println("  Auth:        Username-password")
+	} else if !keychain.HasStoredToken() {
 		fmt.Println("  Token:       NOT FOUND")
 		return fmt.Errorf("no OAuth token found - please run 'sfdc init' first")
+	} else {
+		fmt.Println("  Token:       Found")
 	}
-	fmt.Println("  Token:       Found")
 
-	ctx := context.Background()
-	client, err := auth.GetHTTPClient(ctx)
-	if err != nil {
+	_, err = auth.VerifyConnection(cmd.Context(), cfg.InstanceURL)
+	var verifyErr *auth.VerifyError
+	if errors.As(err, &verifyErr) && verifyErr.Stage == auth.StageToken {
 		fmt.Println("  OAuth:       FAILED")
-		return fmt.Errorf("failed to create OAuth client: %w", err)
+		return fmt.Errorf("failed to get OAuth token: %w", err)
 	}
 	fmt.Println("  OAuth:       OK")
 
-	normalizedURL := normalizeURL(cfg.InstanceURL)
-	resp, err := client.Get(normalizedURL + "/services/data/")
 	if err != nil {
 		fmt.Println("  API:         FAILED")
-		return fmt.Errorf("failed to access Salesforce API: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		fmt.Println("  API:         FAILED")
-		return fmt.Errorf("API returned status %d", resp.StatusCode)
+		return err
 	}
 	fmt.Println("  API:         OK")
 
@@ -184,8 +230,16 @@ func runClear(force bool) error {
 		hadConfig = true
 		cfg.InstanceURL = ""
 		cfg.ClientID = ""
+		cfg.AuthFlow = ""
+		cfg.Username = ""
+		cfg.JWTKeyFile = ""
+		cfg.JWTAudience = ""
 		configErr = config.Save(cfg)
 	}
+	// The client secret is stored with the token, and goes with the config
+	if err := keychain.DeleteClientSecret(); err != nil && configErr == nil {
+		configErr = err
+	}
 
 	if tokenErr != nil {
 		fmt.Printf("Warning: failed to remove token: %v\n", tokenErr)
@@ -195,6 +249,8 @@ func runClear(force bool) error {
 
 	if configErr != nil {
 		fmt.Printf("Warning: failed to clear config: %v\n", configErr)
+	} else if hadConfig && cfg.OrgAlias() != "" {
+		fmt.Printf("Org profile %q removed.\n", cfg.OrgAlias())
 	} else if hadConfig {
 		fmt.Println("Configuration cleared.")
 	}
@@ -209,6 +265,54 @@ func runClear(force bool) error {
 	return nil
 }
 
+func runOrgs(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	if len(cfg.Orgs) == 0 {
+		fmt.Println("No org profiles. Add one with: sfdc init --alias <name>")
+		return nil
+	}
+
+	aliases := make([]string, 0, len(cfg.Orgs))
+	for alias := range cfg.Orgs {
+		aliases = append(aliases, alias)
+	}
+	sort.Strings(aliases)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "  ALIAS\tINSTANCE URL")
+	for _, alias := range aliases {
+		marker := " "
+		if alias == cfg.DefaultOrg {
+			marker = "*"
+		}
+		fmt.Fprintf(w, "%s %s\t%s\n", marker, alias, cfg.Orgs[alias].InstanceURL)
+	}
+	return w.Flush()
+}
+
+func runUseOrg(alias string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	if !cfg.HasOrg(alias) {
+		return fmt.Errorf("unknown org %q: run 'sfdc config orgs' to list org profiles", alias)
+	}
+
+	cfg.DefaultOrg = alias
+	if err := config.Save(cfg); err != nil {
+		return fmt.Errorf("failed to save configuration: %w", err)
+	}
+
+	fmt.Printf("Default org set to %s (%s)\n", alias, cfg.Orgs[alias].InstanceURL)
+	return nil
+}
+
 // maskClientID masks a client ID for display, showing only first and last 4 chars.
 func maskClientID(clientID string) string {
 	if clientID == "" {
@@ -219,12 +323,3 @@ func maskClientID(clientID string) string {
 	}
 	return clientID[:4] + "********" + clientID[len(clientID)-4:]
 }
-
-// normalizeURL ensures the URL has https:// prefix.
-func normalizeURL(url string) string {
-	url = strings.TrimSpace(url)
-	if !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") {
-		url = "https://" + url
-	}
-	return strings.TrimSuffix(url, "/")
-}
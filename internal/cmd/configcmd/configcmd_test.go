@@ -4,6 +4,9 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/salesforce-cli/internal/config"
 )
 
 func TestNewCommand(t *testing.T) {
@@ -22,6 +25,34 @@ func TestNewCommand(t *testing.T) {
 	assert.Contains(t, subNames, "show")
 	assert.Contains(t, subNames, "test")
 	assert.Contains(t, subNames, "clear")
+	assert.Contains(t, subNames, "orgs")
+	assert.Contains(t, subNames, "use-org <alias>")
+}
+
+func TestRunUseOrg(t *testing.T) {
+	config.SetConfigDir(t.TempDir())
+	defer config.SetConfigDir("")
+	t.Setenv(config.OrgEnvVar, "")
+
+	require.NoError(t, config.Save(&config.Config{
+		Orgs: map[string]config.OrgProfile{
+			"prod": {InstanceURL: "https://prod.my.salesforce.com", ClientID: "prod-client"},
+			"dev1": {InstanceURL: "https://dev1.sandbox.my.salesforce.com", ClientID: "dev1-client"},
+		},
+		DefaultOrg: "prod",
+	}))
+
+	require.NoError(t, runUseOrg("dev1"))
+
+	cfg, err := config.Load()
+	require.NoError(t, err)
+	assert.Equal(t, "dev1", cfg.DefaultOrg)
+	assert.Equal(t, "https://dev1.sandbox.my.salesforce.com", cfg.InstanceURL)
+	assert.True(t, cfg.HasOrg("prod"))
+
+	err = runUseOrg("nope")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown org "nope"`)
 }
 
 func TestMaskClientID(t *testing.T) {
@@ -64,23 +95,3 @@ func TestMaskClientID(t *testing.T) {
 		})
 	}
 }
-
-func TestNormalizeURL(t *testing.T) {
-	tests := []struct {
-		input    string
-		expected string
-	}{
-		{"login.salesforce.com", "https://login.salesforce.com"},
-		{"https://login.salesforce.com", "https://login.salesforce.com"},
-		{"https://login.salesforce.com/", "https://login.salesforce.com"},
-		{"  login.salesforce.com  ", "https://login.salesforce.com"},
-		{"test.salesforce.com", "https://test.salesforce.com"},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.input, func(t *testing.T) {
-			got := normalizeURL(tt.input)
-			assert.Equal(t, tt.expected, got)
-		})
-	}
-}
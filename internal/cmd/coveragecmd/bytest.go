@@ -0,0 +1,114 @@
+package coveragecmd
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/open-cli-collective/salesforce-cli/api/tooling"
+	"github.com/open-cli-collective/salesforce-cli/internal/cmd/root"
+)
+
+// byTestReport is the JSON form of coverage by test class.
+type byTestReport struct {
+	Tests   []tooling.TestClassCoverage `json:"tests"`
+	Classes []tooling.LineCoverage      `json:"classes"`
+}
+
+func runByTest(ctx context.Context, opts *root.Options, className string, minCover int) error {
+	client, err := opts.ToolingClient()
+	if err != nil {
+		return fmt.Errorf("failed to create tooling client: %w", err)
+	}
+
+	rows, err := client.GetCoverageByTest(ctx, className)
+	if err != nil {
+		return fmt.Errorf("failed to get coverage: %w", err)
+	}
+
+	if len(rows) == 0 {
+		opts.View().Info("No per-test coverage data found")
+		return nil
+	}
+
+	if err := RenderByTest(opts, rows); err != nil {
+		return err
+	}
+
+	covered, total := 0, 0
+	for _, cov := range tooling.CombineCoverage(rows) {
+		covered += cov.NumLinesCovered
+		total += cov.NumLines
+	}
+	overallPct := percent(covered, total-covered)
+
+	if minCover > 0 && int(overallPct) < minCover {
+		return fmt.Errorf("overall coverage %.1f%% is below minimum %d%%", overallPct, minCover)
+	}
+
+	return nil
+}
+
+// RenderByTest prints the coverage each test class contributes to the
+// classes and triggers it exercises, from per-test-method coverage, then the
+// lines of each class or trigger that none of the tests cover.
+func RenderByTest(opts *root.Options, rows []tooling.ApexCodeCoverage) error {
+	v := opts.View()
+	report := byTestReport{
+		Tests:   tooling.CoverageByTestClass(rows),
+		Classes: tooling.CombineCoverage(rows),
+	}
+
+	if opts.Output == "json" {
+		return v.JSON(report)
+	}
+
+	headers := []string{"Test Class", "Class/Trigger", "Lines Covered", "Coverage %"}
+	tableRows := make([][]string, 0, len(report.Tests))
+	for _, cov := range report.Tests {
+		tableRows = append(tableRows, []string{
+			cov.TestClassName,
+			cov.ClassOrTriggerName,
+			fmt.Sprintf("%d/%d", cov.NumLinesCovered, cov.NumLines),
+			fmt.Sprintf("%.1f%%", cov.Percent),
+		})
+	}
+	if err := v.Table(headers, tableRows); err != nil {
+		return err
+	}
+
+	var uncovered []tooling.LineCoverage
+	for _, cov := range report.Classes {
+		if len(cov.UncoveredLines) > 0 {
+			uncovered = append(uncovered, cov)
+		}
+	}
+	if len(uncovered) > 0 {
+		v.Info("\nUncovered lines:")
+		for _, cov := range uncovered {
+			v.Info("  %s: %s", cov.ClassOrTriggerName, lineRanges(cov.UncoveredLines))
+		}
+	}
+
+	return nil
+}
+
+// lineRanges formats sorted line numbers compactly, with runs of
+// consecutive lines as ranges: 3-5, 9, 12-13.
+func lineRanges(lines []int) string {
+	var parts []string
+	for i := 0; i < len(lines); {
+		j := i
+		for j+1 < len(lines) && lines[j+1] == lines[j]+1 {
+			j++
+		}
+		if j == i {
+			parts = append(parts, strconv.Itoa(lines[i]))
+		} else {
+			parts = append(parts, fmt.Sprintf("%d-%d", lines[i], lines[j]))
+		}
+		i = j + 1
+	}
+	return strings.Join(parts, ", ")
+}
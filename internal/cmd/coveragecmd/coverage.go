@@ -2,11 +2,15 @@
 package coveragecmd
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"os"
+	"time"
 
 	"github.com/spf13/cobra"
 
+	"github.com/open-cli-collective/salesforce-cli/api/tooling"
 	"github.com/open-cli-collective/salesforce-cli/internal/cmd/root"
 )
 
@@ -20,6 +24,10 @@ func NewCommand(opts *root.Options) *cobra.Command {
 	var (
 		className string
 		minCover  int
+		byTest    bool
+		format    string
+		out       string
+		sourceDir string
 	)
 
 	cmd := &cobra.Command{
@@ -27,19 +35,60 @@ func NewCommand(opts *root.Options) *cobra.Command {
 		Short: "Show code coverage",
 		Long: `Show Apex code coverage for the org.
 
+Use --by-test to see which tests cover which classes: the lines each test
+class covers in each class or trigger its tests exercise, then the line
+numbers of each class or trigger that no test covers. With --class, only that
+class or trigger's coverage is shown.
+
+Use --format html to write a self-contained HTML report (sortable table with
+coverage bars and the overall percentage) suitable for publishing as a CI artifact.
+Use 'sfdc coverage report --html DIR' for a report with each class's annotated
+source.
+
+Use --format lcov or --format cobertura to export line coverage for CI
+coverage services such as Codecov or SonarQube. Classes and triggers are given
+the paths of their source in a Salesforce DX project, classes/Name.cls and
+triggers/Name.trigger under --source-dir (default force-app/main/default).
+
 Examples:
   sfdc coverage                       # Show all coverage
   sfdc coverage --class MyController  # Show coverage for specific class
   sfdc coverage --min 75              # Fail if overall coverage < 75%
-  sfdc coverage -o json               # Output as JSON`,
+  sfdc coverage --by-test             # Show coverage by test class
+  sfdc coverage -o json               # Output as JSON
+  sfdc coverage --format html --out coverage.html
+  sfdc coverage --format lcov --out coverage.lcov
+  sfdc coverage --format cobertura --out coverage.xml`,
 		Args: cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runCoverage(cmd.Context(), opts, className, minCover)
+			if byTest && format != "" {
+				return fmt.Errorf("--by-test cannot be used with --format")
+			}
+			switch format {
+			case "":
+				if out != "" {
+					return fmt.Errorf("--out requires --format")
+				}
+				if byTest {
+					return runByTest(cmd.Context(), opts, className, minCover)
+				}
+				return runCoverage(cmd.Context(), opts, className, minCover)
+			case "html", "lcov", "cobertura":
+				return runReport(cmd.Context(), opts, className, format, out, sourceDir, minCover)
+			default:
+				return fmt.Errorf("invalid format: %s (must be html, lcov, or cobertura)", format)
+			}
 		},
 	}
 
 	cmd.Flags().StringVar(&className, "class", "", "Show coverage for specific class")
 	cmd.Flags().IntVar(&minCover, "min", 0, "Minimum coverage percentage (exit 1 if below)")
+	cmd.Flags().BoolVar(&byTest, "by-test", false, "Show the coverage each test class contributes, and uncovered lines")
+	cmd.Flags().StringVar(&format, "format", "", "Report format: html, lcov, cobertura")
+	cmd.Flags().StringVar(&out, "out", "", "Write the report to a file instead of stdout")
+	cmd.Flags().StringVar(&sourceDir, "source-dir", defaultSourceDir, "Directory lcov and cobertura source paths are under")
+
+	cmd.AddCommand(newReportCommand(opts))
 
 	return cmd
 }
@@ -148,3 +197,70 @@ func runCoverage(ctx context.Context, opts *root.Options, className string, minC
 
 	return nil
 }
+
+func runReport(ctx context.Context, opts *root.Options, className, format, out, sourceDir string, minCover int) error {
+	client, err := opts.ToolingClient()
+	if err != nil {
+		return fmt.Errorf("failed to create tooling client: %w", err)
+	}
+
+	var coverage []tooling.ApexCodeCoverageAggregate
+	if format != "html" {
+		// Exports need covered and uncovered line numbers
+		coverage, err = client.GetCodeCoverageLines(ctx, className)
+		if err != nil {
+			return fmt.Errorf("failed to get coverage: %w", err)
+		}
+		if className != "" && len(coverage) == 0 {
+			return fmt.Errorf("no coverage data found for: %s", className)
+		}
+	} else if className != "" {
+		cov, err := client.GetCodeCoverageForClass(ctx, className)
+		if err != nil {
+			return fmt.Errorf("failed to get coverage: %w", err)
+		}
+		coverage = append(coverage, *cov)
+	} else {
+		coverage, err = client.GetCodeCoverage(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get coverage: %w", err)
+		}
+	}
+
+	var buf bytes.Buffer
+	switch format {
+	case "lcov":
+		err = writeLCOV(&buf, coverage, sourceDir)
+	case "cobertura":
+		err = writeCobertura(&buf, coverage, sourceDir, time.Now())
+	default:
+		err = writeHTMLReport(&buf, coverage, time.Now())
+	}
+	if err != nil {
+		return fmt.Errorf("failed to render report: %w", err)
+	}
+
+	v := opts.View()
+
+	if out == "" {
+		v.Print("%s", buf.String())
+	} else {
+		if err := os.WriteFile(out, buf.Bytes(), 0644); err != nil {
+			return fmt.Errorf("failed to write file: %w", err)
+		}
+		v.Success("Coverage report written to %s", out)
+	}
+
+	covered, uncovered := 0, 0
+	for _, cov := range coverage {
+		covered += cov.NumLinesCovered
+		uncovered += cov.NumLinesUncovered
+	}
+	overallPct := percent(covered, uncovered)
+
+	if minCover > 0 && int(overallPct) < minCover {
+		return fmt.Errorf("overall coverage %.1f%% is below minimum %d%%", overallPct, minCover)
+	}
+
+	return nil
+}
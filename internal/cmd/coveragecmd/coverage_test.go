@@ -5,8 +5,11 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -326,3 +329,328 @@ func TestCoverageClassNotFound(t *testing.T) {
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "no coverage data found")
 }
+
+func TestCoverageHTMLReport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		response := tooling.QueryResult{
+			TotalSize: 2,
+			Done:      true,
+			Records: []tooling.Record{
+				{
+					"ApexClassOrTrigger": map[string]interface{}{"Name": "MyController"},
+					"NumLinesCovered":    float64(90),
+					"NumLinesUncovered":  float64(10),
+				},
+				{
+					"ApexClassOrTrigger": map[string]interface{}{"Name": "<Legacy>"},
+					"NumLinesCovered":    float64(10),
+					"NumLinesUncovered":  float64(90),
+				},
+			},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(response)
+	}))
+	defer server.Close()
+
+	client, err := tooling.New(tooling.ClientConfig{
+		InstanceURL: server.URL,
+		HTTPClient:  server.Client(),
+	})
+	require.NoError(t, err)
+
+	outFile := filepath.Join(t.TempDir(), "coverage.html")
+
+	stdout := &bytes.Buffer{}
+	opts := &root.Options{
+		Output:  "table",
+		NoColor: true,
+		Stdout:  stdout,
+		Stderr:  &bytes.Buffer{},
+	}
+	opts.SetToolingClient(client)
+
+	cmd := NewCommand(opts)
+	cmd.SetArgs([]string{"--format", "html", "--out", outFile})
+	cmd.SetOut(stdout)
+
+	err = cmd.Execute()
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), "Coverage report written to")
+
+	data, err := os.ReadFile(outFile)
+	require.NoError(t, err)
+
+	html := string(data)
+	assert.Contains(t, html, "<!DOCTYPE html>")
+	assert.Contains(t, html, "MyController")
+	assert.Contains(t, html, "&lt;Legacy&gt;")
+	assert.Contains(t, html, "50.0%")
+	assert.Contains(t, html, "fill pass")
+	assert.Contains(t, html, "fill fail")
+	assert.NotContains(t, html, "<link")
+}
+
+func TestCoverageInvalidFormat(t *testing.T) {
+	opts := &root.Options{
+		Output: "table",
+		Stdout: &bytes.Buffer{},
+		Stderr: &bytes.Buffer{},
+	}
+
+	cmd := NewCommand(opts)
+	cmd.SetArgs([]string{"--format", "pdf"})
+
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid format")
+}
+
+func TestCoverageByTest(t *testing.T) {
+	var query string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query().Get("q")
+		response := tooling.QueryResult{
+			TotalSize: 2,
+			Done:      true,
+			Records: []tooling.Record{
+				{
+					"ApexClassOrTriggerId": "01pA", "ApexClassOrTrigger": map[string]interface{}{"Name": "AccountService"},
+					"ApexTestClassId": "01pT1", "ApexTestClass": map[string]interface{}{"Name": "AccountTest"}, "TestMethodName": "testInsert",
+					"NumLinesCovered": 2.0, "NumLinesUncovered": 4.0,
+					"Coverage": map[string]interface{}{"coveredLines": []interface{}{1.0, 2.0}, "uncoveredLines": []interface{}{3.0, 4.0, 5.0, 8.0}},
+				},
+				{
+					"ApexClassOrTriggerId": "01pA", "ApexClassOrTrigger": map[string]interface{}{"Name": "AccountService"},
+					"ApexTestClassId": "01pT2", "ApexTestClass": map[string]interface{}{"Name": "ContactTest"}, "TestMethodName": "testUpdate",
+					"NumLinesCovered": 1.0, "NumLinesUncovered": 5.0,
+					"Coverage": map[string]interface{}{"coveredLines": []interface{}{5.0}, "uncoveredLines": []interface{}{1.0, 2.0, 3.0, 4.0, 8.0}},
+				},
+			},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(response)
+	}))
+	defer server.Close()
+
+	client, err := tooling.New(tooling.ClientConfig{
+		InstanceURL: server.URL,
+		HTTPClient:  server.Client(),
+	})
+	require.NoError(t, err)
+
+	stdout := &bytes.Buffer{}
+	opts := &root.Options{
+		Output: "table",
+		Stdout: stdout,
+		Stderr: &bytes.Buffer{},
+	}
+	opts.SetToolingClient(client)
+
+	cmd := NewCommand(opts)
+	cmd.SetArgs([]string{"--by-test", "--class", "AccountService", "--min", "75"})
+	cmd.SetOut(stdout)
+	cmd.SetErr(&bytes.Buffer{})
+
+	err = cmd.Execute()
+	require.Error(t, err)
+	assert.Equal(t, "overall coverage 50.0% is below minimum 75%", err.Error())
+
+	assert.Contains(t, query, "FROM ApexCodeCoverage WHERE ApexClassOrTrigger.Name = 'AccountService'")
+	output := stdout.String()
+	assert.Contains(t, output, "AccountTest")
+	assert.Contains(t, output, "2/6")
+	assert.Contains(t, output, "ContactTest")
+	assert.Contains(t, output, "1/6")
+	assert.Contains(t, output, "AccountService: 3-4, 8")
+}
+
+func TestLineRanges(t *testing.T) {
+	assert.Equal(t, "", lineRanges(nil))
+	assert.Equal(t, "7", lineRanges([]int{7}))
+	assert.Equal(t, "3-5, 9, 12-13", lineRanges([]int{3, 4, 5, 9, 12, 13}))
+}
+
+func TestCoverageSourceReport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query().Get("q")
+		var response tooling.QueryResult
+
+		switch {
+		case strings.Contains(query, "FROM ApexCodeCoverageAggregate"):
+			assert.Contains(t, query, "Coverage")
+			response = tooling.QueryResult{TotalSize: 3, Done: true, Records: []tooling.Record{
+				{
+					"ApexClassOrTriggerId": "01pA", "ApexClassOrTrigger": map[string]interface{}{"Name": "AccountService"},
+					"NumLinesCovered": 1.0, "NumLinesUncovered": 1.0,
+					"Coverage": map[string]interface{}{"coveredLines": []interface{}{2.0}, "uncoveredLines": []interface{}{3.0}},
+				},
+				{
+					"ApexClassOrTriggerId": "01pH", "ApexClassOrTrigger": map[string]interface{}{"Name": "ManagedHelper"},
+					"NumLinesCovered": 4.0, "NumLinesUncovered": 0.0,
+				},
+				{
+					"ApexClassOrTriggerId": "01qT", "ApexClassOrTrigger": map[string]interface{}{"Name": "AccountTrigger"},
+					"NumLinesCovered": 1.0, "NumLinesUncovered": 0.0,
+					"Coverage": map[string]interface{}{"coveredLines": []interface{}{1.0}, "uncoveredLines": []interface{}{}},
+				},
+			}}
+		case strings.Contains(query, "FROM ApexClass WHERE Id IN ('01pA','01pH')"):
+			response = tooling.QueryResult{TotalSize: 2, Done: true, Records: []tooling.Record{
+				{"Id": "01pA", "Body": "public class AccountService {\r\n  static Integer x = 1;\r\n  static Boolean b = x < 2;\r\n}"},
+				{"Id": "01pH", "Body": "(hidden)"},
+			}}
+		case strings.Contains(query, "FROM ApexTrigger WHERE Id IN ('01qT')"):
+			response = tooling.QueryResult{TotalSize: 1, Done: true, Records: []tooling.Record{
+				{"Id": "01qT", "Body": "trigger AccountTrigger on Account (before insert) {}"},
+			}}
+		default:
+			t.Errorf("unexpected query: %s", query)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(response)
+	}))
+	defer server.Close()
+
+	client, err := tooling.New(tooling.ClientConfig{
+		InstanceURL: server.URL,
+		HTTPClient:  server.Client(),
+	})
+	require.NoError(t, err)
+
+	dir := filepath.Join(t.TempDir(), "coverage")
+
+	stdout := &bytes.Buffer{}
+	opts := &root.Options{
+		Output:  "table",
+		NoColor: true,
+		Stdout:  stdout,
+		Stderr:  &bytes.Buffer{},
+	}
+	opts.SetToolingClient(client)
+
+	cmd := NewCommand(opts)
+	cmd.SetArgs([]string{"report", "--html", dir})
+	cmd.SetOut(stdout)
+
+	err = cmd.Execute()
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), "Coverage report written to")
+
+	index, err := os.ReadFile(filepath.Join(dir, "index.html"))
+	require.NoError(t, err)
+	assert.Contains(t, string(index), `<a href="AccountService.cls.html">AccountService</a>`)
+	assert.Contains(t, string(index), `<a href="AccountTrigger.trigger.html">AccountTrigger</a>`)
+	assert.NotContains(t, string(index), `href="ManagedHelper`)
+
+	page, err := os.ReadFile(filepath.Join(dir, "AccountService.cls.html"))
+	require.NoError(t, err)
+	html := string(page)
+	assert.Contains(t, html, `<tr id="L1"><td class="line">`)
+	assert.Contains(t, html, `<tr id="L2" class="covered">`)
+	assert.Contains(t, html, `<tr id="L3" class="uncovered">`)
+	assert.Contains(t, html, "x &lt; 2;</td>")
+	assert.Contains(t, html, "50.0%")
+
+	_, err = os.Stat(filepath.Join(dir, "AccountTrigger.trigger.html"))
+	assert.NoError(t, err)
+	_, err = os.Stat(filepath.Join(dir, "ManagedHelper.cls.html"))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func exportCoverage() []tooling.ApexCodeCoverageAggregate {
+	service := tooling.ApexCodeCoverageAggregate{
+		ApexClassOrTriggerID: "01pA",
+		NumLinesCovered:      2,
+		NumLinesUncovered:    1,
+		Coverage:             &tooling.CoverageDetail{CoveredLines: []int{5, 2}, UncoveredLines: []int{3}},
+	}
+	service.ApexClassOrTrigger.Name = "AccountService"
+	trigger := tooling.ApexCodeCoverageAggregate{
+		ApexClassOrTriggerID: "01qT",
+		NumLinesCovered:      1,
+		Coverage:             &tooling.CoverageDetail{CoveredLines: []int{1}},
+	}
+	trigger.ApexClassOrTrigger.Name = "AccountTrigger"
+	return []tooling.ApexCodeCoverageAggregate{trigger, service}
+}
+
+func TestWriteLCOV(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, writeLCOV(&buf, exportCoverage(), "force-app/main/default"))
+
+	assert.Equal(t, `TN:
+SF:force-app/main/default/classes/AccountService.cls
+DA:2,1
+DA:3,0
+DA:5,1
+LF:3
+LH:2
+end_of_record
+TN:
+SF:force-app/main/default/triggers/AccountTrigger.trigger
+DA:1,1
+LF:1
+LH:1
+end_of_record
+`, buf.String())
+}
+
+func TestWriteCobertura(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, writeCobertura(&buf, exportCoverage(), "src", time.Unix(1700000000, 0)))
+
+	out := buf.String()
+	assert.True(t, strings.HasPrefix(out, "<?xml"))
+	assert.Contains(t, out, `<coverage line-rate="0.7500" branch-rate="0" lines-covered="3" lines-valid="4"`)
+	assert.Contains(t, out, `timestamp="1700000000"`)
+	assert.Contains(t, out, "<source>src</source>")
+	assert.Contains(t, out, `<package name="classes" line-rate="0.6667"`)
+	assert.Contains(t, out, `<class name="AccountService" filename="classes/AccountService.cls" line-rate="0.6667"`)
+	assert.Contains(t, out, `<line number="3" hits="0"></line>`)
+	assert.Contains(t, out, `<package name="triggers" line-rate="1.0000"`)
+	assert.Contains(t, out, `filename="triggers/AccountTrigger.trigger"`)
+}
+
+func TestCoverageLCOVExport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query().Get("q")
+		assert.Contains(t, query, "Coverage FROM ApexCodeCoverageAggregate")
+
+		response := tooling.QueryResult{TotalSize: 1, Done: true, Records: []tooling.Record{
+			{
+				"ApexClassOrTriggerId": "01pA", "ApexClassOrTrigger": map[string]interface{}{"Name": "AccountService"},
+				"NumLinesCovered": 1.0, "NumLinesUncovered": 1.0,
+				"Coverage": map[string]interface{}{"coveredLines": []interface{}{2.0}, "uncoveredLines": []interface{}{3.0}},
+			},
+		}}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(response)
+	}))
+	defer server.Close()
+
+	client, err := tooling.New(tooling.ClientConfig{
+		InstanceURL: server.URL,
+		HTTPClient:  server.Client(),
+	})
+	require.NoError(t, err)
+
+	stdout := &bytes.Buffer{}
+	opts := &root.Options{
+		Output: "table",
+		Stdout: stdout,
+		Stderr: &bytes.Buffer{},
+	}
+	opts.SetToolingClient(client)
+
+	cmd := NewCommand(opts)
+	cmd.SetArgs([]string{"--format", "lcov", "--source-dir", "src"})
+	cmd.SetOut(stdout)
+
+	err = cmd.Execute()
+	require.NoError(t, err)
+
+	output := stdout.String()
+	assert.Contains(t, output, "SF:src/classes/AccountService.cls\nDA:2,1\nDA:3,0\nLF:2\nLH:1\nend_of_record")
+}
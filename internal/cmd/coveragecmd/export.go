@@ -0,0 +1,199 @@
+package coveragecmd
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/open-cli-collective/salesforce-cli/api/tooling"
+)
+
+// defaultSourceDir is where a Salesforce DX project keeps the source of
+// Apex classes and triggers, under classes/ and triggers/.
+const defaultSourceDir = "force-app/main/default"
+
+// exportedFile is the line coverage of a class or trigger under the pseudo
+// path of its source file.
+type exportedFile struct {
+	name    string
+	dir     string // classes or triggers
+	path    string // relative to the source directory
+	lines   []exportedLine
+	covered int
+	total   int
+}
+
+// exportedLine is a line tests track, with its hit count. Salesforce only
+// records whether a line ran, so hits are 0 or 1.
+type exportedLine struct {
+	number int
+	hits   int
+}
+
+// exportedFiles maps coverage to the source files of its classes and
+// triggers, ordered by path. Triggers are told from classes by their ID's
+// key prefix.
+func exportedFiles(coverage []tooling.ApexCodeCoverageAggregate) []exportedFile {
+	files := make([]exportedFile, 0, len(coverage))
+	for _, cov := range coverage {
+		f := exportedFile{
+			name:    cov.ApexClassOrTrigger.Name,
+			dir:     "classes",
+			covered: cov.NumLinesCovered,
+			total:   cov.NumLinesCovered + cov.NumLinesUncovered,
+		}
+		ext := ".cls"
+		if strings.HasPrefix(cov.ApexClassOrTriggerID, "01q") {
+			f.dir, ext = "triggers", ".trigger"
+		}
+		f.path = path.Join(f.dir, f.name+ext)
+
+		if cov.Coverage != nil {
+			for _, line := range cov.Coverage.CoveredLines {
+				f.lines = append(f.lines, exportedLine{number: line, hits: 1})
+			}
+			for _, line := range cov.Coverage.UncoveredLines {
+				f.lines = append(f.lines, exportedLine{number: line})
+			}
+			sort.Slice(f.lines, func(i, j int) bool {
+				return f.lines[i].number < f.lines[j].number
+			})
+		}
+		files = append(files, f)
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].path < files[j].path
+	})
+	return files
+}
+
+// writeLCOV writes coverage as an LCOV tracefile, with a record for each
+// class and trigger under sourceDir.
+func writeLCOV(w io.Writer, coverage []tooling.ApexCodeCoverageAggregate, sourceDir string) error {
+	var b strings.Builder
+	for _, f := range exportedFiles(coverage) {
+		b.WriteString("TN:\n")
+		fmt.Fprintf(&b, "SF:%s\n", path.Join(sourceDir, f.path))
+		for _, line := range f.lines {
+			fmt.Fprintf(&b, "DA:%d,%d\n", line.number, line.hits)
+		}
+		fmt.Fprintf(&b, "LF:%d\n", f.total)
+		fmt.Fprintf(&b, "LH:%d\n", f.covered)
+		b.WriteString("end_of_record\n")
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// coberturaCoverage is the root element of a Cobertura XML report.
+type coberturaCoverage struct {
+	XMLName         xml.Name           `xml:"coverage"`
+	LineRate        string             `xml:"line-rate,attr"`
+	BranchRate      string             `xml:"branch-rate,attr"`
+	LinesCovered    int                `xml:"lines-covered,attr"`
+	LinesValid      int                `xml:"lines-valid,attr"`
+	BranchesCovered int                `xml:"branches-covered,attr"`
+	BranchesValid   int                `xml:"branches-valid,attr"`
+	Complexity      string             `xml:"complexity,attr"`
+	Version         string             `xml:"version,attr"`
+	Timestamp       int64              `xml:"timestamp,attr"`
+	Sources         []string           `xml:"sources>source"`
+	Packages        []coberturaPackage `xml:"packages>package"`
+}
+
+// coberturaPackage holds the classes or the triggers of a report.
+type coberturaPackage struct {
+	Name       string           `xml:"name,attr"`
+	LineRate   string           `xml:"line-rate,attr"`
+	BranchRate string           `xml:"branch-rate,attr"`
+	Complexity string           `xml:"complexity,attr"`
+	Classes    []coberturaClass `xml:"classes>class"`
+}
+
+// coberturaClass is the line coverage of one class or trigger.
+type coberturaClass struct {
+	Name       string          `xml:"name,attr"`
+	Filename   string          `xml:"filename,attr"`
+	LineRate   string          `xml:"line-rate,attr"`
+	BranchRate string          `xml:"branch-rate,attr"`
+	Complexity string          `xml:"complexity,attr"`
+	Methods    struct{}        `xml:"methods"`
+	Lines      []coberturaLine `xml:"lines>line"`
+}
+
+// coberturaLine is a tracked line and its hit count.
+type coberturaLine struct {
+	Number int `xml:"number,attr"`
+	Hits   int `xml:"hits,attr"`
+}
+
+// writeCobertura writes coverage as a Cobertura XML report, with a package
+// for classes and one for triggers, their file names relative to sourceDir.
+func writeCobertura(w io.Writer, coverage []tooling.ApexCodeCoverageAggregate, sourceDir string, now time.Time) error {
+	report := coberturaCoverage{
+		BranchRate: "0",
+		Complexity: "0",
+		Version:    "sfdc",
+		Timestamp:  now.Unix(),
+		Sources:    []string{sourceDir},
+	}
+
+	packages := make(map[string]int)
+	// Covered and total lines, per package
+	var covered, total []int
+	for _, f := range exportedFiles(coverage) {
+		i, ok := packages[f.dir]
+		if !ok {
+			i = len(report.Packages)
+			packages[f.dir] = i
+			report.Packages = append(report.Packages, coberturaPackage{Name: f.dir, BranchRate: "0", Complexity: "0"})
+			covered, total = append(covered, 0), append(total, 0)
+		}
+
+		class := coberturaClass{
+			Name:       f.name,
+			Filename:   f.path,
+			LineRate:   lineRate(f.covered, f.total),
+			BranchRate: "0",
+			Complexity: "0",
+			Lines:      make([]coberturaLine, 0, len(f.lines)),
+		}
+		for _, line := range f.lines {
+			class.Lines = append(class.Lines, coberturaLine{Number: line.number, Hits: line.hits})
+		}
+		report.Packages[i].Classes = append(report.Packages[i].Classes, class)
+		covered[i] += f.covered
+		total[i] += f.total
+		report.LinesCovered += f.covered
+		report.LinesValid += f.total
+	}
+	for i := range report.Packages {
+		report.Packages[i].LineRate = lineRate(covered[i], total[i])
+	}
+	report.LineRate = lineRate(report.LinesCovered, report.LinesValid)
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(report); err != nil {
+		return fmt.Errorf("failed to write Cobertura report: %w", err)
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// lineRate formats covered lines as Cobertura's fraction of all lines.
+func lineRate(covered, total int) string {
+	if total == 0 {
+		return "0"
+	}
+	return strconv.FormatFloat(float64(covered)/float64(total), 'f', 4, 64)
+}
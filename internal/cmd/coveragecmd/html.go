@@ -0,0 +1,88 @@
+package coveragecmd
+
+import (
+	"embed"
+	"html/template"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/open-cli-collective/salesforce-cli/api/tooling"
+)
+
+// passingCoverage is the per-class percentage Salesforce requires for
+// production deployments; classes below it are drawn with a red bar.
+const passingCoverage = 75.0
+
+//go:embed templates/*.html.tmpl
+var templateFS embed.FS
+
+var (
+	reportTemplate = template.Must(template.ParseFS(templateFS, "templates/report.html.tmpl"))
+	sourceTemplate = template.Must(template.ParseFS(templateFS, "templates/source.html.tmpl"))
+)
+
+// htmlReport is the data rendered by the HTML coverage template.
+type htmlReport struct {
+	GeneratedAt string
+	Classes     []htmlClassCoverage
+	Covered     int
+	Total       int
+	Percent     float64
+}
+
+// htmlClassCoverage is a single row in the HTML coverage report.
+type htmlClassCoverage struct {
+	ID        string
+	Name      string
+	Link      string // page of annotated source, if any
+	Covered   int
+	Uncovered int
+	Percent   float64
+	Passing   bool
+}
+
+// writeHTMLReport renders a self-contained HTML coverage report to w.
+func writeHTMLReport(w io.Writer, coverage []tooling.ApexCodeCoverageAggregate, now time.Time) error {
+	return reportTemplate.Execute(w, newHTMLReport(coverage, now))
+}
+
+// newHTMLReport returns the rows and totals of an HTML coverage report,
+// ordered by name.
+func newHTMLReport(coverage []tooling.ApexCodeCoverageAggregate, now time.Time) htmlReport {
+	report := htmlReport{
+		GeneratedAt: now.Format(time.RFC1123),
+		Classes:     make([]htmlClassCoverage, 0, len(coverage)),
+	}
+
+	for _, cov := range coverage {
+		pct := percent(cov.NumLinesCovered, cov.NumLinesUncovered)
+		report.Classes = append(report.Classes, htmlClassCoverage{
+			ID:        cov.ApexClassOrTriggerID,
+			Name:      cov.ApexClassOrTrigger.Name,
+			Covered:   cov.NumLinesCovered,
+			Uncovered: cov.NumLinesUncovered,
+			Percent:   pct,
+			Passing:   pct >= passingCoverage,
+		})
+		report.Covered += cov.NumLinesCovered
+		report.Total += cov.NumLinesCovered + cov.NumLinesUncovered
+	}
+
+	sort.Slice(report.Classes, func(i, j int) bool {
+		return report.Classes[i].Name < report.Classes[j].Name
+	})
+
+	report.Percent = percent(report.Covered, report.Total-report.Covered)
+
+	return report
+}
+
+// percent returns covered lines as a percentage of all lines.
+func percent(covered, uncovered int) float64 {
+	total := covered + uncovered
+	if total == 0 {
+		return 0
+	}
+	return float64(covered) / float64(total) * 100
+}
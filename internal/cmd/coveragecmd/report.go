@@ -0,0 +1,182 @@
+package coveragecmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/salesforce-cli/api/tooling"
+	"github.com/open-cli-collective/salesforce-cli/internal/cmd/root"
+)
+
+func newReportCommand(opts *root.Options) *cobra.Command {
+	var (
+		className string
+		minCover  int
+		dir       string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "report",
+		Short: "Write an HTML coverage report with annotated source",
+		Long: `Write an HTML coverage report to a directory: an index of every class and
+trigger with its coverage, linking to a page of each one's source with covered
+lines in green and uncovered lines in red, like 'go tool cover -html'.
+
+Classes whose source is hidden, such as those of managed packages, are listed
+in the index without a source page.
+
+Examples:
+  sfdc coverage report --html coverage/
+  sfdc coverage report --html coverage/ --class MyController
+  sfdc coverage report --html coverage/ --min 75`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if dir == "" {
+				return fmt.Errorf("--html is required")
+			}
+			return runSourceReport(cmd.Context(), opts, className, dir, minCover)
+		},
+	}
+
+	cmd.Flags().StringVar(&dir, "html", "", "Directory to write the HTML report to (required)")
+	cmd.Flags().StringVar(&className, "class", "", "Report coverage for specific class")
+	cmd.Flags().IntVar(&minCover, "min", 0, "Minimum coverage percentage (exit 1 if below)")
+
+	return cmd
+}
+
+// sourcePage is the data rendered by the annotated source template.
+type sourcePage struct {
+	Name        string
+	Kind        string
+	GeneratedAt string
+	Covered     int
+	Total       int
+	Percent     float64
+	Lines       []sourceLine
+}
+
+// sourceLine is a line of source with its coverage status: covered,
+// uncovered, or empty for lines coverage doesn't track.
+type sourceLine struct {
+	Number int
+	Text   string
+	Status string
+}
+
+func runSourceReport(ctx context.Context, opts *root.Options, className, dir string, minCover int) error {
+	client, err := opts.ToolingClient()
+	if err != nil {
+		return fmt.Errorf("failed to create tooling client: %w", err)
+	}
+
+	coverage, err := client.GetCodeCoverageLines(ctx, className)
+	if err != nil {
+		return fmt.Errorf("failed to get coverage: %w", err)
+	}
+	if len(coverage) == 0 {
+		if className != "" {
+			return fmt.Errorf("no coverage data found for: %s", className)
+		}
+		opts.View().Info("No code coverage data found")
+		return nil
+	}
+
+	ids := make([]string, 0, len(coverage))
+	for _, cov := range coverage {
+		ids = append(ids, cov.ApexClassOrTriggerID)
+	}
+	sources, err := client.GetApexSources(ctx, ids)
+	if err != nil {
+		return fmt.Errorf("failed to get source: %w", err)
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	now := time.Now()
+	report := newHTMLReport(coverage, now)
+	links := make(map[string]string, len(coverage))
+	for _, cov := range coverage {
+		body, ok := sources[cov.ApexClassOrTriggerID]
+		if !ok || body == "" || body == "(hidden)" {
+			continue
+		}
+
+		kind, ext := "Class", ".cls"
+		if strings.HasPrefix(cov.ApexClassOrTriggerID, "01q") {
+			kind, ext = "Trigger", ".trigger"
+		}
+		name := cov.ApexClassOrTrigger.Name + ext + ".html"
+
+		var buf bytes.Buffer
+		if err := writeSourcePage(&buf, cov, kind, body, now); err != nil {
+			return fmt.Errorf("failed to render %s: %w", cov.ApexClassOrTrigger.Name, err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, name), buf.Bytes(), 0644); err != nil {
+			return fmt.Errorf("failed to write file: %w", err)
+		}
+		links[cov.ApexClassOrTriggerID] = name
+	}
+	for i := range report.Classes {
+		report.Classes[i].Link = links[report.Classes[i].ID]
+	}
+
+	var buf bytes.Buffer
+	if err := reportTemplate.Execute(&buf, report); err != nil {
+		return fmt.Errorf("failed to render report: %w", err)
+	}
+	index := filepath.Join(dir, "index.html")
+	if err := os.WriteFile(index, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+
+	opts.View().Success("Coverage report written to %s", index)
+
+	if minCover > 0 && int(report.Percent) < minCover {
+		return fmt.Errorf("overall coverage %.1f%% is below minimum %d%%", report.Percent, minCover)
+	}
+
+	return nil
+}
+
+// writeSourcePage renders the source of a class or trigger to w, with each
+// line marked by whether tests cover it.
+func writeSourcePage(w io.Writer, cov tooling.ApexCodeCoverageAggregate, kind, body string, now time.Time) error {
+	status := make(map[int]string)
+	if cov.Coverage != nil {
+		for _, line := range cov.Coverage.UncoveredLines {
+			status[line] = "uncovered"
+		}
+		for _, line := range cov.Coverage.CoveredLines {
+			status[line] = "covered"
+		}
+	}
+
+	page := sourcePage{
+		Name:        cov.ApexClassOrTrigger.Name,
+		Kind:        kind,
+		GeneratedAt: now.Format(time.RFC1123),
+		Covered:     cov.NumLinesCovered,
+		Total:       cov.NumLinesCovered + cov.NumLinesUncovered,
+		Percent:     percent(cov.NumLinesCovered, cov.NumLinesUncovered),
+	}
+	for i, text := range strings.Split(body, "\n") {
+		page.Lines = append(page.Lines, sourceLine{
+			Number: i + 1,
+			Text:   strings.TrimSuffix(text, "\r"),
+			Status: status[i+1],
+		})
+	}
+
+	return sourceTemplate.Execute(w, page)
+}
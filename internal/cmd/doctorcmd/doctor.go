@@ -0,0 +1,349 @@
+// Package doctorcmd provides the doctor command for diagnosing auth and
+// configuration problems.
+package doctorcmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/salesforce-cli/api"
+	"github.com/open-cli-collective/salesforce-cli/internal/auth"
+	"github.com/open-cli-collective/salesforce-cli/internal/cmd/root"
+	"github.com/open-cli-collective/salesforce-cli/internal/config"
+	"github.com/open-cli-collective/salesforce-cli/internal/keychain"
+)
+
+// maxClockSkew is the difference from the server's clock above which the
+// clock check warns. Token requests and JWT assertions carry timestamps
+// that Salesforce rejects when the clocks disagree by a few minutes.
+const maxClockSkew = time.Minute
+
+// Check results
+const (
+	statusOK      = "ok"
+	statusWarn    = "warn"
+	statusFail    = "fail"
+	statusSkipped = "skipped"
+)
+
+// check is the result of one diagnostic check.
+type check struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Detail string `json:"detail,omitempty"`
+	Fix    string `json:"fix,omitempty"`
+}
+
+// Register registers the doctor command with the root command.
+func Register(parent *cobra.Command, opts *root.Options) {
+	parent.AddCommand(NewCommand(opts))
+}
+
+// NewCommand creates the doctor command.
+func NewCommand(opts *root.Options) *cobra.Command {
+	return &cobra.Command{
+		Use:   "doctor",
+		Short: "Diagnose auth and configuration problems",
+		Long: `Check the configuration and login of the current org and print how to fix
+any problem found:
+
+  - the config file can be read and the org is logged in
+  - tokens are stored securely
+  - the stored token (or JWT private key) is usable
+  - an access token can be obtained and the REST API reached
+  - the API version (--api-version) is supported by the org
+  - the local clock agrees with Salesforce's
+
+The command exits with an error if any check fails.
+
+Examples:
+  sfdc doctor
+  sfdc doctor --org uat
+  sfdc doctor -o json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDoctor(cmd.Context(), opts)
+		},
+	}
+}
+
+func runDoctor(ctx context.Context, opts *root.Options) error {
+	cfgCheck, cfg := checkConfig()
+	checks := []check{cfgCheck, checkStorage(cfg)}
+
+	if cfgCheck.Status == statusFail {
+		checks = append(checks,
+			skipped("Token"), skipped("Connection"), skipped("API version"), skipped("Clock"))
+	} else {
+		checks = append(checks, checkToken(cfg))
+
+		connCheck, conn := checkConnection(ctx, cfg)
+		checks = append(checks, connCheck)
+		if conn == nil {
+			checks = append(checks, skipped("API version"), skipped("Clock"))
+		} else {
+			checks = append(checks, checkAPIVersion(opts.APIVersion, conn), checkClock(conn, time.Now()))
+		}
+	}
+
+	v := opts.View()
+	if opts.Output == "json" {
+		if err := v.JSON(checks); err != nil {
+			return err
+		}
+	} else {
+		for _, c := range checks {
+			v.Println("%s %-13s %s", symbol(c.Status), c.Name, c.Detail)
+			if c.Fix != "" {
+				v.Println("  %-13s Fix: %s", "", c.Fix)
+			}
+		}
+	}
+
+	failed := 0
+	for _, c := range checks {
+		if c.Status == statusFail {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d checks failed", failed, len(checks))
+	}
+	return nil
+}
+
+// symbol returns the marker printed before a check of the given status.
+func symbol(status string) string {
+	switch status {
+	case statusOK:
+		return "✓"
+	case statusWarn:
+		return "⚠"
+	case statusFail:
+		return "✗"
+	default:
+		return "-"
+	}
+}
+
+func skipped(name string) check {
+	return check{Name: name, Status: statusSkipped, Detail: "skipped"}
+}
+
+// checkConfig checks that the config file is valid and the selected org is
+// logged in. The config is nil if it could not be loaded.
+func checkConfig() (check, *config.Config) {
+	c := check{Name: "Config"}
+
+	path, err := config.GetConfigPath()
+	if err != nil {
+		c.Status = statusFail
+		c.Detail = fmt.Sprintf("cannot locate the config directory: %v", err)
+		c.Fix = "Set SFDC_HOME or --config-dir to a writable directory"
+		return c, nil
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		c.Status = statusFail
+		c.Detail = fmt.Sprintf("%s is invalid: %v", config.ShortenPath(path), err)
+		c.Fix = fmt.Sprintf("Correct or delete %s, then run 'sfdc auth login'", config.ShortenPath(path))
+		return c, nil
+	}
+
+	if alias := cfg.OrgAlias(); alias != "" && !cfg.HasOrg(alias) {
+		c.Status = statusFail
+		c.Detail = fmt.Sprintf("unknown org %q", alias)
+		c.Fix = fmt.Sprintf("Run 'sfdc auth list' to see org profiles, or 'sfdc auth login --alias %s' to add it", alias)
+		return c, cfg
+	}
+
+	if !cfg.Configured() {
+		c.Status = statusFail
+		c.Detail = "not logged in"
+		c.Fix = "Run 'sfdc auth login'"
+		return c, cfg
+	}
+
+	c.Status = statusOK
+	c.Detail = config.ShortenPath(path)
+	if alias := cfg.OrgAlias(); alias != "" {
+		c.Detail += fmt.Sprintf(" (org %s)", alias)
+	}
+	return c, cfg
+}
+
+// checkStorage checks that tokens are stored securely.
+func checkStorage(cfg *config.Config) check {
+	c := check{Name: "Token storage"}
+	backend := keychain.GetStorageBackend()
+	c.Detail = string(backend)
+
+	// Nothing is stored for the JWT bearer flow
+	if keychain.IsSecureStorage() || (cfg != nil && cfg.AuthFlow == config.AuthFlowJWT) {
+		c.Status = statusOK
+		return c
+	}
+
+	c.Status = statusWarn
+	c.Detail += " (plaintext)"
+	c.Fix = `Set "token_storage": "encrypted_file" in config.json, or SFDC_TOKEN_STORAGE=encrypted_file`
+	if runtime.GOOS == "linux" {
+		c.Fix = `Install secret-tool (libsecret-tools), or set "token_storage": "encrypted_file" in config.json`
+	}
+	return c
+}
+
+// checkToken checks that the stored token, or the JWT private key, can be
+// used to authenticate.
+func checkToken(cfg *config.Config) check {
+	c := check{Name: "Token"}
+
+	if cfg.AuthFlow == config.AuthFlowJWT {
+		if _, err := auth.JWTConfig(cfg); err != nil {
+			c.Status = statusFail
+			c.Detail = err.Error()
+			c.Fix = "Check the private key file, or log in again with 'sfdc auth login --jwt-key'"
+			return c
+		}
+		c.Status = statusOK
+		c.Detail = "JWT bearer with " + config.ShortenPath(cfg.JWTKeyFile)
+		return c
+	}
+
+	// Flows that can obtain a new token without the stored one
+	renewable := cfg.AuthFlow == config.AuthFlowClientCredentials ||
+		(cfg.AuthFlow == config.AuthFlowPassword && cfg.Password != "")
+
+	token, err := keychain.GetToken()
+	if err != nil {
+		if renewable {
+			c.Status = statusOK
+			c.Detail = "none stored; a new one is requested on use"
+			return c
+		}
+		c.Status = statusFail
+		c.Detail = "no token stored"
+		c.Fix = "Run 'sfdc auth login'"
+		if errors.Is(err, keychain.ErrPassphraseRequired) {
+			c.Detail = err.Error()
+			c.Fix = "Set " + keychain.TokenKeyEnvVar + " or run the command in a terminal"
+		}
+		return c
+	}
+
+	switch {
+	case token.Expiry.IsZero():
+		c.Status = statusOK
+		c.Detail = "stored; expiry not reported by Salesforce"
+	case token.Expiry.After(time.Now()):
+		c.Status = statusOK
+		c.Detail = "expires " + token.Expiry.Local().Format("2006-01-02 15:04")
+	case token.RefreshToken != "" || renewable:
+		c.Status = statusOK
+		c.Detail = "expired; a new one is requested on use"
+	case cfg.AuthFlow == config.AuthFlowPassword:
+		c.Status = statusFail
+		c.Detail = "session expired"
+		c.Fix = "Set SFDC_PASSWORD (and SFDC_SECURITY_TOKEN) or run 'sfdc auth login --username-password' again"
+	default:
+		c.Status = statusFail
+		c.Detail = "expired, with no refresh token"
+		c.Fix = "Run 'sfdc auth login'"
+	}
+	return c
+}
+
+// checkConnection gets a token and calls the REST API. The connection is
+// nil if either failed.
+func checkConnection(ctx context.Context, cfg *config.Config) (check, *auth.Connection) {
+	c := check{Name: "Connection"}
+
+	conn, err := auth.VerifyConnection(ctx, cfg.InstanceURL)
+	if err != nil {
+		c.Status = statusFail
+		c.Detail = err.Error()
+		var verifyErr *auth.VerifyError
+		if errors.As(err, &verifyErr) && verifyErr.Stage == auth.StageToken {
+			c.Detail = "could not get an access token: " + c.Detail
+			c.Fix = "Run 'sfdc auth login' to log in again"
+		} else {
+			c.Fix = fmt.Sprintf("Check the instance URL (%s) and your network or proxy settings", cfg.InstanceURL)
+		}
+		return c, nil
+	}
+
+	c.Status = statusOK
+	c.Detail = cfg.InstanceURL
+	return c, conn
+}
+
+// checkAPIVersion checks that the API version in use is supported.
+func checkAPIVersion(requested string, conn *auth.Connection) check {
+	c := check{Name: "API version"}
+
+	if requested == "" {
+		requested = api.DefaultAPIVersion
+	}
+	want := strings.TrimPrefix(requested, "v")
+
+	latest := ""
+	supported := false
+	for _, v := range conn.Versions {
+		if v.Version == want {
+			supported = true
+		}
+		latest = v.Version
+	}
+
+	if !supported {
+		c.Status = statusFail
+		c.Detail = fmt.Sprintf("v%s is not supported by the org", want)
+		if latest != "" {
+			c.Detail += fmt.Sprintf(" (latest v%s)", latest)
+			c.Fix = fmt.Sprintf("Use --api-version v%s", latest)
+		}
+		return c
+	}
+
+	c.Status = statusOK
+	c.Detail = "v" + want
+	if latest != "" && latest != want {
+		c.Detail += fmt.Sprintf(" (latest v%s)", latest)
+	}
+	return c
+}
+
+// checkClock compares the local clock at now with the server's.
+func checkClock(conn *auth.Connection, now time.Time) check {
+	c := check{Name: "Clock"}
+
+	if conn.ServerTime.IsZero() {
+		c.Status = statusSkipped
+		c.Detail = "server time not reported"
+		return c
+	}
+
+	skew := now.Sub(conn.ServerTime)
+	if skew < 0 {
+		skew = -skew
+	}
+	skew = skew.Round(time.Second)
+
+	if skew > maxClockSkew {
+		c.Status = statusWarn
+		c.Detail = fmt.Sprintf("local clock is %s off from Salesforce", skew)
+		c.Fix = "Sync the system clock (e.g. enable NTP); logins fail when it is a few minutes off"
+		return c
+	}
+
+	c.Status = statusOK
+	c.Detail = "in sync with Salesforce"
+	return c
+}
@@ -0,0 +1,138 @@
+package doctorcmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+
+	"github.com/open-cli-collective/salesforce-cli/api"
+	"github.com/open-cli-collective/salesforce-cli/internal/auth"
+	"github.com/open-cli-collective/salesforce-cli/internal/cmd/root"
+	"github.com/open-cli-collective/salesforce-cli/internal/config"
+	"github.com/open-cli-collective/salesforce-cli/internal/keychain"
+)
+
+// setupLogin saves a browser login for a server that lists API versions.
+func setupLogin(t *testing.T) {
+	t.Helper()
+
+	config.SetConfigDir(t.TempDir())
+	t.Cleanup(func() { config.SetConfigDir("") })
+	t.Setenv(config.OrgEnvVar, "")
+	t.Setenv("SFDC_INSTANCE_URL", "")
+	t.Setenv("SFDC_CLIENT_ID", "")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/services/data/", r.URL.Path)
+		assert.Equal(t, "Bearer access-token", r.Header.Get("Authorization"))
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode([]api.APIVersion{
+			{Label: "Spring '24", Version: "60.0"},
+			{Label: "Summer '24", Version: "61.0"},
+			{Label: "Winter '25", Version: "62.0"},
+		})
+	}))
+	t.Cleanup(server.Close)
+
+	require.NoError(t, config.Save(&config.Config{InstanceURL: server.URL, ClientID: "client-id"}))
+	require.NoError(t, keychain.SetToken(&oauth2.Token{
+		AccessToken:  "access-token",
+		RefreshToken: "refresh-token",
+		Expiry:       time.Now().Add(time.Hour),
+	}))
+}
+
+func TestDoctor_Healthy(t *testing.T) {
+	setupLogin(t)
+
+	stdout := &bytes.Buffer{}
+	opts := &root.Options{Output: "table", Stdout: stdout, Stderr: &bytes.Buffer{}}
+
+	cmd := NewCommand(opts)
+	cmd.SetArgs([]string{})
+	require.NoError(t, cmd.Execute())
+
+	output := stdout.String()
+	assert.Contains(t, output, "✓ Config")
+	assert.Contains(t, output, "✓ Token ")
+	assert.Contains(t, output, "✓ Connection")
+	assert.Contains(t, output, "✓ API version   v62.0")
+	assert.Contains(t, output, "✓ Clock")
+}
+
+func TestDoctor_NotConfigured(t *testing.T) {
+	config.SetConfigDir(t.TempDir())
+	defer config.SetConfigDir("")
+	t.Setenv(config.OrgEnvVar, "")
+	t.Setenv("SFDC_INSTANCE_URL", "")
+	t.Setenv("SFDC_CLIENT_ID", "")
+
+	stdout := &bytes.Buffer{}
+	opts := &root.Options{Output: "table", Stdout: stdout, Stderr: &bytes.Buffer{}}
+
+	cmd := NewCommand(opts)
+	cmd.SetArgs([]string{})
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "1 of 6 checks failed")
+
+	output := stdout.String()
+	assert.Contains(t, output, "✗ Config        not logged in")
+	assert.Contains(t, output, "Fix: Run 'sfdc auth login'")
+	assert.Contains(t, output, "- Connection    skipped")
+}
+
+func TestDoctor_UnsupportedAPIVersion(t *testing.T) {
+	setupLogin(t)
+
+	stdout := &bytes.Buffer{}
+	opts := &root.Options{Output: "json", APIVersion: "v99.0", Stdout: stdout, Stderr: &bytes.Buffer{}}
+
+	cmd := NewCommand(opts)
+	cmd.SetArgs([]string{})
+	require.Error(t, cmd.Execute())
+
+	var checks []check
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &checks))
+	require.Len(t, checks, 6)
+	assert.Equal(t, "API version", checks[4].Name)
+	assert.Equal(t, statusFail, checks[4].Status)
+	assert.Equal(t, "v99.0 is not supported by the org (latest v62.0)", checks[4].Detail)
+	assert.Equal(t, "Use --api-version v62.0", checks[4].Fix)
+}
+
+func TestDoctor_MissingToken(t *testing.T) {
+	setupLogin(t)
+	require.NoError(t, keychain.DeleteToken())
+
+	stdout := &bytes.Buffer{}
+	opts := &root.Options{Output: "table", Stdout: stdout, Stderr: &bytes.Buffer{}}
+
+	cmd := NewCommand(opts)
+	cmd.SetArgs([]string{})
+	require.Error(t, cmd.Execute())
+
+	output := stdout.String()
+	assert.Contains(t, output, "✗ Token         no token stored")
+	assert.Contains(t, output, "✗ Connection    could not get an access token")
+}
+
+func TestCheckClock(t *testing.T) {
+	server := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	conn := &auth.Connection{ServerTime: server}
+
+	assert.Equal(t, statusOK, checkClock(conn, server.Add(20*time.Second)).Status)
+
+	c := checkClock(conn, server.Add(-5*time.Minute))
+	assert.Equal(t, statusWarn, c.Status)
+	assert.Equal(t, "local clock is 5m0s off from Salesforce", c.Detail)
+
+	assert.Equal(t, statusSkipped, checkClock(&auth.Connection{}, server).Status)
+}
@@ -0,0 +1,27 @@
+// Package filecmd provides commands for working with Salesforce Files.
+package filecmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/salesforce-cli/internal/cmd/root"
+)
+
+// Register registers the file command with the root command.
+func Register(parent *cobra.Command, opts *root.Options) {
+	parent.AddCommand(NewCommand(opts))
+}
+
+// NewCommand creates the file command with subcommands.
+func NewCommand(opts *root.Options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "file",
+		Short: "Work with Salesforce Files",
+		Long:  "Upload and download Salesforce Files (ContentDocument records).",
+	}
+
+	cmd.AddCommand(newUploadCommand(opts))
+	cmd.AddCommand(newDownloadCommand(opts))
+
+	return cmd
+}
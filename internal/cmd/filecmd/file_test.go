@@ -0,0 +1,230 @@
+package filecmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/salesforce-cli/api"
+	"github.com/open-cli-collective/salesforce-cli/internal/cmd/root"
+)
+
+// fileServer serves a ContentVersion lookup and its content. With no
+// version, the lookup returns no records.
+func fileServer(t *testing.T, version map[string]interface{}, content []byte) *api.Client {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/services/data/v62.0/query":
+			assert.Equal(t, "SELECT Id, Title, FileExtension FROM ContentVersion WHERE ContentDocumentId = '069xx0000001' AND IsLatest = true", r.URL.Query().Get("q"))
+			records := []interface{}{}
+			if version != nil {
+				records = append(records, version)
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"totalSize": len(records), "done": true, "records": records})
+		case "/services/data/v62.0/sobjects/ContentVersion/068xx0000001/VersionData":
+			_, _ = w.Write(content)
+		default:
+			t.Errorf("unexpected request: %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := api.New(api.ClientConfig{InstanceURL: server.URL, HTTPClient: server.Client()})
+	require.NoError(t, err)
+	return client
+}
+
+func TestDownloadCommand(t *testing.T) {
+	content := []byte("%PDF-1.7\x00\xff binary")
+	version := map[string]interface{}{
+		"attributes":    map[string]interface{}{"type": "ContentVersion"},
+		"Id":            "068xx0000001",
+		"Title":         "Q3 Report",
+		"FileExtension": "pdf",
+	}
+
+	tests := []struct {
+		name     string
+		args     []string
+		wantFile string
+	}{
+		{
+			name:     "named by title",
+			wantFile: "Q3 Report.pdf",
+		},
+		{
+			name:     "with --out",
+			args:     []string{"--out", "report.pdf"},
+			wantFile: "report.pdf",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Chdir(t.TempDir())
+
+			stdout := &bytes.Buffer{}
+			opts := &root.Options{
+				Output:  "table",
+				NoColor: true,
+				Stdout:  stdout,
+				Stderr:  &bytes.Buffer{},
+			}
+			opts.SetAPIClient(fileServer(t, version, content))
+
+			cmd := NewCommand(opts)
+			cmd.SetArgs(append([]string{"download", "069xx0000001"}, tt.args...))
+
+			require.NoError(t, cmd.Execute())
+
+			data, err := os.ReadFile(tt.wantFile)
+			require.NoError(t, err)
+			assert.Equal(t, content, data)
+			assert.Contains(t, stdout.String(), "Saved 17 bytes to "+tt.wantFile)
+		})
+	}
+}
+
+func TestDownloadCommand_NotFound(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "report.pdf")
+
+	opts := &root.Options{
+		Output: "table",
+		Stdout: &bytes.Buffer{},
+		Stderr: &bytes.Buffer{},
+	}
+	opts.SetAPIClient(fileServer(t, nil, nil))
+
+	cmd := NewCommand(opts)
+	cmd.SetArgs([]string{"download", "069xx0000001", "--out", out})
+
+	err := cmd.Execute()
+	assert.EqualError(t, err, "file not found: 069xx0000001")
+	assert.NoFileExists(t, out)
+}
+
+func TestFileName(t *testing.T) {
+	tests := []struct {
+		version contentVersion
+		want    string
+	}{
+		{contentVersion{ID: "068xx1", Title: "Q3 Report", FileExtension: "pdf"}, "Q3 Report.pdf"},
+		{contentVersion{ID: "068xx1", Title: "logo.PNG", FileExtension: "png"}, "logo.PNG"},
+		{contentVersion{ID: "068xx1", Title: "../etc/passwd", FileExtension: ""}, ".._etc_passwd"},
+		{contentVersion{ID: "068xx1", Title: "  ", FileExtension: "txt"}, "068xx1.txt"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			assert.Equal(t, tt.want, fileName(tt.version))
+		})
+	}
+}
+
+func TestUploadCommand(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "report.pdf")
+	require.NoError(t, os.WriteFile(path, []byte("%PDF-1.7"), 0644))
+
+	var link map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/services/data/v62.0/sobjects/ContentVersion":
+			_, _ = io.Copy(io.Discard, r.Body)
+			_, _ = w.Write([]byte(`{"id":"068xx0000001","success":true,"errors":[]}`))
+		case "/services/data/v62.0/sobjects/ContentVersion/068xx0000001":
+			_, _ = w.Write([]byte(`{"Id":"068xx0000001","ContentDocumentId":"069xx0000001"}`))
+		case "/services/data/v62.0/sobjects/ContentDocumentLink/":
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&link))
+			_, _ = w.Write([]byte(`{"id":"06Axx0000001","success":true,"errors":[]}`))
+		default:
+			t.Errorf("unexpected request: %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := api.New(api.ClientConfig{InstanceURL: server.URL, HTTPClient: server.Client()})
+	require.NoError(t, err)
+
+	stdout := &bytes.Buffer{}
+	opts := &root.Options{
+		Output:  "table",
+		NoColor: true,
+		Stdout:  stdout,
+		Stderr:  &bytes.Buffer{},
+	}
+	opts.SetAPIClient(client)
+
+	cmd := NewCommand(opts)
+	cmd.SetArgs([]string{"upload", path, "--link-to", "001xx000003DGbYAAW", "--share-type", "C"})
+
+	require.NoError(t, cmd.Execute())
+
+	assert.Equal(t, "001xx000003DGbYAAW", link["LinkedEntityId"])
+	assert.Equal(t, "C", link["ShareType"])
+	assert.Contains(t, stdout.String(), "Uploaded "+path+": 069xx0000001")
+	assert.Contains(t, stdout.String(), "Linked to 001xx000003DGbYAAW")
+	assert.Contains(t, stdout.String(), server.URL+"/069xx0000001")
+}
+
+func TestUploadCommand_DryRun(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "report.pdf")
+	require.NoError(t, os.WriteFile(path, []byte("%PDF-1.7"), 0644))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request in dry run: %s %s", r.Method, r.URL.Path)
+	}))
+	defer server.Close()
+
+	client, err := api.New(api.ClientConfig{InstanceURL: server.URL, HTTPClient: server.Client()})
+	require.NoError(t, err)
+
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	opts := &root.Options{
+		Output:  "table",
+		NoColor: true,
+		DryRun:  true,
+		Stdout:  stdout,
+		Stderr:  stderr,
+	}
+	opts.SetAPIClient(client)
+
+	cmd := NewCommand(opts)
+	cmd.SetArgs([]string{"upload", path, "--title", "Q3 Report", "--link-to", "001xx000003DGbYAAW"})
+
+	require.NoError(t, cmd.Execute())
+
+	output := stdout.String()
+	assert.Contains(t, stderr.String(), "Dry run: no changes were made")
+	assert.Contains(t, output, "POST "+server.URL+"/services/data/v62.0/sobjects/ContentVersion")
+	assert.Contains(t, output, `"Title": "Q3 Report"`)
+	assert.Contains(t, output, "Bytes:     8")
+	assert.Contains(t, output, "LinkTo:    001xx000003DGbYAAW (share type V)")
+}
+
+func TestUploadCommand_InvalidShareType(t *testing.T) {
+	opts := &root.Options{
+		Output: "table",
+		Stdout: &bytes.Buffer{},
+		Stderr: &bytes.Buffer{},
+	}
+
+	cmd := NewCommand(opts)
+	cmd.SetArgs([]string{"upload", "report.pdf", "--share-type", "X"})
+
+	err := cmd.Execute()
+	assert.EqualError(t, err, `invalid --share-type "X" (expected V, C, or I)`)
+}
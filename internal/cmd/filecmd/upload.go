@@ -0,0 +1,120 @@
+package filecmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/salesforce-cli/api"
+	"github.com/open-cli-collective/salesforce-cli/internal/cmd/root"
+)
+
+// uploadFlags holds the upload command's flags.
+type uploadFlags struct {
+	title       string
+	description string
+	linkTo      string
+	shareType   string
+}
+
+func newUploadCommand(opts *root.Options) *cobra.Command {
+	var flags uploadFlags
+
+	cmd := &cobra.Command{
+		Use:   "upload <file>",
+		Short: "Upload a file",
+		Long: `Upload a local file as a new Salesforce File (ContentVersion).
+
+The content is streamed as a multipart request, so files of any size the org
+allows can be uploaded. With --link-to, the file is shared with a record,
+such as an account or case, so it shows in the record's Files list.
+
+Examples:
+  sfdc file upload report.pdf
+  sfdc file upload report.pdf --title "Q3 Report" --link-to 001xx000003DGbYAAW
+  sfdc file upload notes.txt --link-to 500xx000001abcd --share-type C`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			switch flags.shareType {
+			case "V", "C", "I":
+			default:
+				return fmt.Errorf("invalid --share-type %q (expected V, C, or I)", flags.shareType)
+			}
+			return runUpload(cmd.Context(), opts, args[0], flags)
+		},
+	}
+
+	cmd.Flags().StringVar(&flags.title, "title", "", "Title of the file (default: the file name)")
+	cmd.Flags().StringVar(&flags.description, "description", "", "Description of the file")
+	cmd.Flags().StringVar(&flags.linkTo, "link-to", "", "ID of a record to share the file with")
+	cmd.Flags().StringVar(&flags.shareType, "share-type", "V", "Permission for --link-to: V (viewer), C (collaborator), or I (inferred)")
+
+	return cmd
+}
+
+func runUpload(ctx context.Context, opts *root.Options, path string, flags uploadFlags) error {
+	client, err := opts.APIClient()
+	if err != nil {
+		return fmt.Errorf("failed to create API client: %w", err)
+	}
+
+	if opts.DryRun {
+		info, err := os.Stat(path)
+		if err != nil {
+			return fmt.Errorf("failed to open file: %w", err)
+		}
+		title := flags.title
+		if title == "" {
+			title = filepath.Base(path)
+		}
+		entity := map[string]interface{}{
+			"Title":        title,
+			"PathOnClient": filepath.Base(path),
+		}
+		if flags.description != "" {
+			entity["Description"] = flags.description
+		}
+		details := map[string]interface{}{
+			"File":  path,
+			"Bytes": info.Size(),
+		}
+		if flags.linkTo != "" {
+			details["LinkTo"] = fmt.Sprintf("%s (share type %s)", flags.linkTo, flags.shareType)
+		}
+		return opts.PrintDryRun(root.DryRunRequest{
+			Operation: "file upload",
+			Object:    "ContentVersion",
+			Method:    http.MethodPost,
+			URL:       client.ResourceURL("/sobjects/ContentVersion"),
+			Payload:   entity,
+			Details:   details,
+		})
+	}
+
+	result, err := client.UploadFile(ctx, flags.title, path, api.UploadOptions{
+		Description: flags.description,
+		LinkTo:      flags.linkTo,
+		ShareType:   flags.shareType,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to upload file: %w", err)
+	}
+
+	v := opts.View()
+
+	if opts.Output == "json" {
+		return v.JSON(result)
+	}
+
+	v.Success("Uploaded %s: %s", path, result.ContentDocumentID)
+	if result.ContentDocumentLinkID != "" {
+		v.Info("Linked to %s", flags.linkTo)
+	}
+	v.Info("URL: %s", v.Link(client.RecordURL(result.ContentDocumentID)))
+
+	return nil
+}
@@ -4,14 +4,15 @@ package initcmd
 import (
 	"bufio"
 	"context"
+	"errors"
 	"fmt"
-	"net/http"
 	"net/url"
 	"os"
 	"strings"
 
 	"github.com/charmbracelet/huh"
 	"github.com/spf13/cobra"
+	"golang.org/x/oauth2"
 
 	"github.com/open-cli-collective/salesforce-cli/internal/auth"
 	"github.com/open-cli-collective/salesforce-cli/internal/config"
@@ -19,9 +20,15 @@ import (
 )
 
 var (
-	instanceURL string
-	clientID    string
-	noVerify    bool
+	instanceURL  string
+	clientID     string
+	noVerify     bool
+	callbackPort int
+	showSetup    bool
+	setupDir     string
+	contactEmail string
+	alias        string
+	device       bool
 )
 
 // Register registers the init command with the parent command.
@@ -38,37 +45,113 @@ func NewCommand() *cobra.Command {
 		Long: `Guided setup for Salesforce OAuth 2.0 authentication.
 
 This command walks you through the OAuth flow with clear instructions.
-After setup, you can use commands like 'sfdc query', etc.
+After setup, you can use commands like 'sfdc query', etc. It is the same
+login as 'sfdc auth login' without a flow flag.
 
 Prerequisites:
   1. Create a Connected App in Salesforce Setup
   2. Enable OAuth Settings
   3. Set Callback URL: http://localhost:8080/callback
   4. Select scopes: api, refresh_token, offline_access
-  5. Note the Consumer Key (Client ID)`,
+  5. Note the Consumer Key (Client ID)
+
+On a remote host without a browser, use --device: the CLI prints a code to
+enter at a Salesforce URL on any other device, and waits for approval. The
+Connected App must have "Enable for Device Flow" checked.
+
+Use --alias to save the login as a named org profile, so several orgs
+(e.g. production and sandboxes) can be used side by side with the global
+--org flag. The first profile becomes the default org if there is no other.
+
+Use --show-setup for step-by-step Connected App instructions with the exact
+callback URL and scopes. Add --setup-dir to also write the Connected App as
+metadata that can be deployed with 'sfdc metadata deploy'.
+
+Examples:
+  sfdc init
+  sfdc init --instance-url mycompany.my.salesforce.com --client-id <key>
+  sfdc init --alias dev1 --instance-url test.salesforce.com
+  sfdc init --device
+  sfdc init --show-setup
+  sfdc init --show-setup --callback-port 1717
+  sfdc init --show-setup --setup-dir ./connected-app --contact-email admin@example.com`,
 		Args: cobra.NoArgs,
-		RunE: runInit,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if setupDir != "" && !showSetup {
+				return fmt.Errorf("--setup-dir requires --show-setup")
+			}
+			if setupDir != "" && contactEmail == "" {
+				return fmt.Errorf("--contact-email is required with --setup-dir")
+			}
+			if showSetup {
+				return runShowSetup()
+			}
+			return Login(cmd.Context(), LoginOptions{
+				InstanceURL:  instanceURL,
+				ClientID:     clientID,
+				Alias:        alias,
+				Device:       device,
+				NoVerify:     noVerify,
+				CallbackPort: callbackPort,
+			})
+		},
 	}
 
 	cmd.Flags().StringVar(&instanceURL, "instance-url", "", "Salesforce instance URL (e.g., login.salesforce.com)")
 	cmd.Flags().StringVar(&clientID, "client-id", "", "Connected App Consumer Key")
 	cmd.Flags().BoolVar(&noVerify, "no-verify", false, "Skip connectivity verification after setup")
+	cmd.Flags().IntVar(&callbackPort, "callback-port", auth.DefaultCallbackPort, "Localhost port of the OAuth callback URL")
+	cmd.Flags().BoolVar(&showSetup, "show-setup", false, "Show Connected App setup instructions and exit")
+	cmd.Flags().StringVar(&setupDir, "setup-dir", "", "Write deployable Connected App metadata to this directory")
+	cmd.Flags().StringVar(&contactEmail, "contact-email", "", "Contact email for the generated Connected App")
+	cmd.Flags().StringVar(&alias, "alias", "", "Save the login as a named org profile")
+	cmd.Flags().BoolVar(&device, "device", false, "Log in with a code entered on another device (for SSH and remote hosts)")
 
 	return cmd
 }
 
-func runInit(cmd *cobra.Command, args []string) error {
+// LoginOptions configures the guided login.
+type LoginOptions struct {
+	// InstanceURL and ClientID pre-fill the form, overriding saved values
+	InstanceURL string
+	ClientID    string
+	// Alias saves the login as a named org profile
+	Alias string
+	// Device logs in with a code entered on another device
+	Device bool
+	// NoVerify skips the connectivity check after login
+	NoVerify bool
+	// CallbackPort is the localhost port of the OAuth callback URL
+	CallbackPort int
+}
+
+// Login runs the guided browser or device login of 'sfdc init', which is
+// also used by 'sfdc auth login' when no other flow is selected.
+func Login(ctx context.Context, o LoginOptions) error {
+	if o.Alias != "" {
+		if err := config.ValidateOrgAlias(o.Alias); err != nil {
+			return err
+		}
+		config.SetOrg(o.Alias)
+	}
+	if o.CallbackPort == 0 {
+		o.CallbackPort = auth.DefaultCallbackPort
+	}
+
 	reader := bufio.NewReader(os.Stdin)
 
 	fmt.Println("Checking existing configuration...")
 	cfg, _ := config.Load()
 
+	if o.Alias != "" {
+		fmt.Printf("Org:          %s\n", o.Alias)
+	}
 	if keychain.HasStoredToken() {
 		fmt.Printf("Instance URL: %s\n", cfg.InstanceURL)
 		fmt.Printf("Token:        Found (stored in %s)\n", keychain.GetStorageBackend())
 
-		if !noVerify {
-			if err := verifyConnectivity(cfg.InstanceURL); err == nil {
+		if !o.NoVerify {
+			if err := verifyConnectivity(ctx, cfg.InstanceURL); err == nil {
 				fmt.Println()
 				fmt.Println("Already configured and working.")
 				fmt.Println("Use 'sfdc config clear' to reset.")
@@ -107,14 +190,14 @@ func runInit(cmd *cobra.Command, args []string) error {
 	formInstanceURL := ""
 	formClientID := ""
 
-	if instanceURL != "" {
-		formInstanceURL = instanceURL
+	if o.InstanceURL != "" {
+		formInstanceURL = o.InstanceURL
 	} else if cfg.InstanceURL != "" {
 		formInstanceURL = cfg.InstanceURL
 	}
 
-	if clientID != "" {
-		formClientID = clientID
+	if o.ClientID != "" {
+		formClientID = o.ClientID
 	} else if cfg.ClientID != "" {
 		formClientID = cfg.ClientID
 	}
@@ -152,12 +235,63 @@ func runInit(cmd *cobra.Command, args []string) error {
 
 	cfg.InstanceURL = formInstanceURL
 	cfg.ClientID = formClientID
+	// A browser or device login replaces any non-interactive login
+	cfg.AuthFlow = ""
+	cfg.Username = ""
+	cfg.JWTKeyFile = ""
+	cfg.JWTAudience = ""
+	if o.Alias != "" && !cfg.HasDefault() {
+		cfg.DefaultOrg = o.Alias
+	}
 	if err := config.Save(cfg); err != nil {
 		return fmt.Errorf("failed to save configuration: %w", err)
 	}
 
 	oauthConfig := auth.GetOAuthConfig(formInstanceURL, formClientID)
-	authURL := auth.GetAuthURL(oauthConfig)
+
+	var (
+		token *oauth2.Token
+		err   error
+	)
+	if o.Device {
+		token, err = deviceLogin(ctx, oauthConfig)
+	} else {
+		oauthConfig.RedirectURL = auth.CallbackURLForPort(o.CallbackPort)
+		token, err = browserLogin(ctx, reader, oauthConfig)
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := keychain.SetToken(token); err != nil {
+		return fmt.Errorf("failed to save token: %w", err)
+	}
+	if err := keychain.DeleteClientSecret(); err != nil {
+		return fmt.Errorf("failed to remove client secret: %w", err)
+	}
+	fmt.Printf("Token saved to: %s\n", keychain.GetStorageBackend())
+
+	if !o.NoVerify {
+		fmt.Println()
+		if err := verifyConnectivity(ctx, formInstanceURL); err != nil {
+			return err
+		}
+	}
+
+	fmt.Println()
+	if o.Alias != "" && cfg.DefaultOrg != o.Alias {
+		fmt.Printf("Setup complete! Use --org %s to run commands against this org, or\n", o.Alias)
+		fmt.Printf("'sfdc config use-org %s' to make it the default.\n", o.Alias)
+		return nil
+	}
+	fmt.Println("Setup complete! Try: sfdc query \"SELECT Id, Name FROM Account LIMIT 5\"")
+	return nil
+}
+
+// browserLogin has the user approve access in a browser and paste back the
+// redirect URL, then exchanges the authorization code for a token.
+func browserLogin(ctx context.Context, reader *bufio.Reader, oauthConfig *oauth2.Config) (*oauth2.Token, error) {
+	authURL, verifier := auth.GetAuthURL(oauthConfig)
 
 	fmt.Println()
 	fmt.Println("Open this URL in your browser:")
@@ -174,37 +308,76 @@ func runInit(cmd *cobra.Command, args []string) error {
 
 	input, err := reader.ReadString('\n')
 	if err != nil {
-		return fmt.Errorf("failed to read input: %w", err)
+		return nil, fmt.Errorf("failed to read input: %w", err)
 	}
 
 	code := extractAuthCode(strings.TrimSpace(input))
 	if code == "" {
-		return fmt.Errorf("no authorization code received")
+		return nil, fmt.Errorf("no authorization code received")
 	}
 
 	fmt.Println()
 	fmt.Println("Exchanging authorization code for tokens...")
 
-	ctx := context.Background()
-	token, err := auth.ExchangeAuthCode(ctx, oauthConfig, code)
+	token, err := auth.ExchangeAuthCode(ctx, oauthConfig, code, verifier)
 	if err != nil {
-		return fmt.Errorf("failed to exchange authorization code: %w", err)
+		return nil, fmt.Errorf("failed to exchange authorization code: %w", err)
 	}
+	return token, nil
+}
 
-	if err := keychain.SetToken(token); err != nil {
-		return fmt.Errorf("failed to save token: %w", err)
+// deviceLogin has the user approve access by entering a code on any device
+// with a browser, polling until they do.
+func deviceLogin(ctx context.Context, oauthConfig *oauth2.Config) (*oauth2.Token, error) {
+	dc, err := auth.RequestDeviceCode(ctx, oauthConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start device login: %w", err)
 	}
-	fmt.Printf("Token saved to: %s\n", keychain.GetStorageBackend())
 
-	if !noVerify {
-		fmt.Println()
-		if err := verifyConnectivity(formInstanceURL); err != nil {
-			return err
+	fmt.Println()
+	fmt.Println("On any device with a browser, open:")
+	fmt.Println()
+	fmt.Printf("  %s\n", dc.VerificationURI)
+	fmt.Println()
+	fmt.Printf("and enter the code: %s\n", dc.UserCode)
+	fmt.Println()
+	fmt.Println("Waiting for approval (Ctrl-C to cancel)...")
+
+	token, err := auth.PollDeviceToken(ctx, oauthConfig, dc)
+	if err != nil {
+		return nil, fmt.Errorf("device login failed: %w", err)
+	}
+	return token, nil
+}
+
+// runShowSetup prints Connected App setup instructions and optionally writes
+// the Connected App metadata.
+func runShowSetup() error {
+	loginURL := instanceURL
+	if loginURL == "" {
+		if cfg, err := config.Load(); err == nil && cfg.InstanceURL != "" {
+			loginURL = cfg.InstanceURL
+		} else {
+			loginURL = "login.salesforce.com"
 		}
 	}
 
+	writeSetupInstructions(os.Stdout, loginURL, callbackPort)
+
+	if setupDir == "" {
+		return nil
+	}
+
+	if err := writeSetupPackage(setupDir, contactEmail, auth.CallbackURLForPort(callbackPort)); err != nil {
+		return err
+	}
+
 	fmt.Println()
-	fmt.Println("Setup complete! Try: sfdc query \"SELECT Id, Name FROM Account LIMIT 5\"")
+	fmt.Printf("Connected App metadata written to %s. To create the app, run:\n", setupDir)
+	fmt.Println()
+	fmt.Printf("  sfdc metadata deploy --source %s --wait\n", setupDir)
+	fmt.Println()
+	fmt.Println("This requires an existing login with permission to deploy metadata.")
 	return nil
 }
 
@@ -224,28 +397,20 @@ func extractAuthCode(input string) string {
 }
 
 // verifyConnectivity tests the Salesforce API connection.
-func verifyConnectivity(instanceURL string) error {
+func verifyConnectivity(ctx context.Context, instanceURL string) error {
 	fmt.Println("Verifying Salesforce API connection...")
 
-	ctx := context.Background()
-	client, err := auth.GetHTTPClient(ctx)
-	if err != nil {
+	_, err := auth.VerifyConnection(ctx, instanceURL)
+	var verifyErr *auth.VerifyError
+	if errors.As(err, &verifyErr) && verifyErr.Stage == auth.StageToken {
 		fmt.Println("  OAuth token: FAILED")
-		return fmt.Errorf("failed to create client: %w", err)
+		return fmt.Errorf("failed to get token: %w", err)
 	}
 	fmt.Println("  OAuth token: OK")
 
-	normalizedURL := "https://" + strings.TrimPrefix(strings.TrimPrefix(instanceURL, "https://"), "http://")
-	resp, err := client.Get(normalizedURL + "/services/data/")
 	if err != nil {
 		fmt.Println("  API access:  FAILED")
-		return fmt.Errorf("failed to access Salesforce API: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		fmt.Println("  API access:  FAILED")
-		return fmt.Errorf("API returned status %d", resp.StatusCode)
+		return err
 	}
 	fmt.Println("  API access:  OK")
 
@@ -1,9 +1,14 @@
 package initcmd
 
 import (
+	"bytes"
+	"encoding/xml"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestExtractAuthCode(t *testing.T) {
@@ -83,7 +88,100 @@ func TestNewCommand(t *testing.T) {
 	assert.NotNil(t, cmd.Flags().Lookup("instance-url"))
 	assert.NotNil(t, cmd.Flags().Lookup("client-id"))
 	assert.NotNil(t, cmd.Flags().Lookup("no-verify"))
+	assert.NotNil(t, cmd.Flags().Lookup("callback-port"))
+	assert.NotNil(t, cmd.Flags().Lookup("show-setup"))
+	assert.NotNil(t, cmd.Flags().Lookup("alias"))
+	assert.NotNil(t, cmd.Flags().Lookup("device"))
 
 	// --no-browser flag was removed (no more callback server or auto-browser-opening)
 	assert.Nil(t, cmd.Flags().Lookup("no-browser"))
 }
+
+func TestWriteSetupInstructions(t *testing.T) {
+	tests := []struct {
+		name        string
+		loginURL    string
+		port        int
+		wantContain []string
+		wantMissing []string
+	}{
+		{
+			name:     "default port",
+			loginURL: "login.salesforce.com",
+			port:     8080,
+			wantContain: []string{
+				"https://login.salesforce.com",
+				"Callback URL:    http://localhost:8080/callback",
+				"(api)",
+				"(refresh_token, offline_access)",
+				"Require Proof Key for Code Exchange (PKCE)",
+				"sfdc init --instance-url login.salesforce.com --client-id <consumer-key>",
+			},
+			wantMissing: []string{"--callback-port"},
+		},
+		{
+			name:     "custom port and domain",
+			loginURL: "https://mycompany.my.salesforce.com",
+			port:     1717,
+			wantContain: []string{
+				"Callback URL:    http://localhost:1717/callback",
+				"--instance-url mycompany.my.salesforce.com --client-id <consumer-key> --callback-port 1717",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			writeSetupInstructions(&buf, tt.loginURL, tt.port)
+
+			output := buf.String()
+			for _, want := range tt.wantContain {
+				assert.Contains(t, output, want)
+			}
+			for _, missing := range tt.wantMissing {
+				assert.NotContains(t, output, missing)
+			}
+		})
+	}
+}
+
+func TestWriteSetupPackage(t *testing.T) {
+	dir := t.TempDir()
+
+	err := writeSetupPackage(dir, "admin@example.com", "http://localhost:1717/callback")
+	require.NoError(t, err)
+
+	pkg, err := os.ReadFile(filepath.Join(dir, "package.xml"))
+	require.NoError(t, err)
+	assert.Contains(t, string(pkg), "<members>sfdc_cli</members>")
+	assert.Contains(t, string(pkg), "<name>ConnectedApp</name>")
+
+	data, err := os.ReadFile(filepath.Join(dir, "connectedApps", "sfdc_cli.connectedApp"))
+	require.NoError(t, err)
+
+	var app struct {
+		ContactEmail string   `xml:"contactEmail"`
+		CallbackURL  string   `xml:"oauthConfig>callbackUrl"`
+		Optional     bool     `xml:"oauthConfig>isConsumerSecretOptional"`
+		PKCE         bool     `xml:"oauthConfig>isPkceRequired"`
+		Scopes       []string `xml:"oauthConfig>scopes"`
+	}
+	require.NoError(t, xml.Unmarshal(data, &app))
+	assert.Equal(t, "admin@example.com", app.ContactEmail)
+	assert.Equal(t, "http://localhost:1717/callback", app.CallbackURL)
+	assert.True(t, app.Optional)
+	assert.True(t, app.PKCE)
+	assert.Equal(t, []string{"Api", "RefreshToken"}, app.Scopes)
+}
+
+func TestShowSetupRequiresContactEmail(t *testing.T) {
+	cmd := NewCommand()
+	cmd.SetArgs([]string{"--show-setup", "--setup-dir", t.TempDir()})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+
+	err := cmd.Execute()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "--contact-email is required")
+}
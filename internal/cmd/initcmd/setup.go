@@ -0,0 +1,182 @@
+package initcmd
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/open-cli-collective/salesforce-cli/internal/auth"
+)
+
+const (
+	// connectedAppName is the API name of the Connected App in the generated metadata
+	connectedAppName = "sfdc_cli"
+
+	// connectedAppLabel is the display name of the generated Connected App
+	connectedAppLabel = "sfdc CLI"
+
+	// setupAPIVersion is the package.xml version of the generated metadata
+	setupAPIVersion = "62.0"
+)
+
+// metadataScopes maps OAuth scope names to ConnectedApp metadata scope values.
+var metadataScopes = map[string]string{
+	"api":            "Api",
+	"refresh_token":  "RefreshToken",
+	"offline_access": "RefreshToken",
+}
+
+// writeSetupInstructions prints step-by-step instructions for creating the
+// Connected App the CLI authenticates with.
+func writeSetupInstructions(w io.Writer, loginURL string, port int) {
+	callbackURL := auth.CallbackURLForPort(port)
+	loginURL = "https://" + strings.TrimPrefix(strings.TrimPrefix(loginURL, "https://"), "http://")
+
+	fmt.Fprintln(w, "Connected App setup")
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "1. Log in to %s and open Setup → App Manager.\n", loginURL)
+	fmt.Fprintln(w, "2. Click 'New Connected App' and enter a name and contact email.")
+	fmt.Fprintln(w, "3. Check 'Enable OAuth Settings' and set:")
+	fmt.Fprintf(w, "     Callback URL:    %s\n", callbackURL)
+	fmt.Fprintf(w, "     Selected Scopes: %s\n", strings.Join(scopeLabels(), ", "))
+	fmt.Fprintln(w, "4. Uncheck 'Require Secret for Web Server Flow' and")
+	fmt.Fprintln(w, "   'Require Secret for Refresh Token Flow' (the CLI has no client secret),")
+	fmt.Fprintln(w, "   and check 'Require Proof Key for Code Exchange (PKCE)', which the CLI")
+	fmt.Fprintln(w, "   uses in its place.")
+	fmt.Fprintln(w, "5. Save, then wait 2-10 minutes for the app to become active.")
+	fmt.Fprintln(w, "6. Click 'Manage Consumer Details' and copy the Consumer Key.")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Then run:")
+	fmt.Fprintln(w)
+
+	initCmd := fmt.Sprintf("  sfdc init --instance-url %s --client-id <consumer-key>", strings.TrimPrefix(loginURL, "https://"))
+	if port != auth.DefaultCallbackPort {
+		initCmd += fmt.Sprintf(" --callback-port %d", port)
+	}
+	fmt.Fprintln(w, initCmd)
+}
+
+// scopeLabels returns the scopes as they are labeled in the Connected App UI.
+func scopeLabels() []string {
+	labels := make([]string, 0, len(auth.Scopes))
+	for _, scope := range auth.Scopes {
+		switch scope {
+		case "api":
+			labels = append(labels, "Manage user data via APIs (api)")
+		case "refresh_token", "offline_access":
+			label := "Perform requests at any time (refresh_token, offline_access)"
+			if len(labels) == 0 || labels[len(labels)-1] != label {
+				labels = append(labels, label)
+			}
+		default:
+			labels = append(labels, scope)
+		}
+	}
+	return labels
+}
+
+// connectedAppXML returns ConnectedApp metadata for the CLI.
+func connectedAppXML(contactEmail, callbackURL string) ([]byte, error) {
+	type oauthConfig struct {
+		CallbackURL                     string   `xml:"callbackUrl"`
+		IsConsumerSecretOptional        bool     `xml:"isConsumerSecretOptional"`
+		IsPkceRequired                  bool     `xml:"isPkceRequired"`
+		IsSecretRequiredForRefreshToken bool     `xml:"isSecretRequiredForRefreshToken"`
+		Scopes                          []string `xml:"scopes"`
+	}
+	type connectedApp struct {
+		XMLName      xml.Name    `xml:"ConnectedApp"`
+		Xmlns        string      `xml:"xmlns,attr"`
+		ContactEmail string      `xml:"contactEmail"`
+		Label        string      `xml:"label"`
+		OauthConfig  oauthConfig `xml:"oauthConfig"`
+	}
+
+	var scopes []string
+	seen := make(map[string]bool)
+	for _, scope := range auth.Scopes {
+		value, ok := metadataScopes[scope]
+		if !ok || seen[value] {
+			continue
+		}
+		seen[value] = true
+		scopes = append(scopes, value)
+	}
+
+	return marshalMetadata(connectedApp{
+		Xmlns:        "http://soap.sforce.com/2006/04/metadata",
+		ContactEmail: contactEmail,
+		Label:        connectedAppLabel,
+		OauthConfig: oauthConfig{
+			CallbackURL:              callbackURL,
+			IsConsumerSecretOptional: true,
+			IsPkceRequired:           true,
+			Scopes:                   scopes,
+		},
+	})
+}
+
+// packageXML returns a package.xml that deploys the Connected App.
+func packageXML() ([]byte, error) {
+	type packageType struct {
+		Members string `xml:"members"`
+		Name    string `xml:"name"`
+	}
+	type pkg struct {
+		XMLName xml.Name    `xml:"Package"`
+		Xmlns   string      `xml:"xmlns,attr"`
+		Types   packageType `xml:"types"`
+		Version string      `xml:"version"`
+	}
+
+	return marshalMetadata(pkg{
+		Xmlns:   "http://soap.sforce.com/2006/04/metadata",
+		Types:   packageType{Members: connectedAppName, Name: "ConnectedApp"},
+		Version: setupAPIVersion,
+	})
+}
+
+func marshalMetadata(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+
+	enc := xml.NewEncoder(&buf)
+	enc.Indent("", "    ")
+	if err := enc.Encode(v); err != nil {
+		return nil, fmt.Errorf("failed to generate metadata: %w", err)
+	}
+	buf.WriteString("\n")
+
+	return buf.Bytes(), nil
+}
+
+// writeSetupPackage writes a metadata package containing the Connected App
+// to dir, ready for 'sfdc metadata deploy --source dir'.
+func writeSetupPackage(dir, contactEmail, callbackURL string) error {
+	appXML, err := connectedAppXML(contactEmail, callbackURL)
+	if err != nil {
+		return err
+	}
+	pkgXML, err := packageXML()
+	if err != nil {
+		return err
+	}
+
+	appDir := filepath.Join(dir, "connectedApps")
+	if err := os.MkdirAll(appDir, 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "package.xml"), pkgXML, 0644); err != nil {
+		return fmt.Errorf("failed to write package.xml: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(appDir, connectedAppName+".connectedApp"), appXML, 0644); err != nil {
+		return fmt.Errorf("failed to write connected app: %w", err)
+	}
+
+	return nil
+}
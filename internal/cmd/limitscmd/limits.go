@@ -5,6 +5,7 @@ import (
 	"context"
 	"fmt"
 	"sort"
+	"strings"
 
 	"github.com/spf13/cobra"
 
@@ -19,29 +20,50 @@ func Register(parent *cobra.Command, opts *root.Options) {
 
 // NewCommand creates the limits command.
 func NewCommand(opts *root.Options) *cobra.Command {
-	var show string
+	var (
+		show      string
+		names     []string
+		failUnder float64
+	)
 
 	cmd := &cobra.Command{
 		Use:   "limits",
 		Short: "Display org API limits",
 		Long: `Display the current Salesforce org's API limits and usage.
 
+Use --fail-under to turn the command into a monitoring check: it exits with
+an error if any shown limit has less than the given percentage remaining.
+JSON output includes each limit's remainingPercent for filtering in scripts.
+
 Examples:
   sfdc limits
   sfdc limits -o json
-  sfdc limits --show DailyApiRequests`,
+  sfdc limits --show DailyApiRequests
+  sfdc limits --names DailyApiRequests,DailyBulkApiRequests
+  sfdc limits --names DailyApiRequests --fail-under 10`,
 		Args: cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runLimits(cmd.Context(), opts, show)
+			if show != "" && len(names) > 0 {
+				return fmt.Errorf("--show and --names cannot be used together")
+			}
+			if failUnder < 0 || failUnder > 100 {
+				return fmt.Errorf("--fail-under must be between 0 and 100")
+			}
+			if show != "" {
+				names = []string{show}
+			}
+			return runLimits(cmd.Context(), opts, show != "", names, failUnder)
 		},
 	}
 
 	cmd.Flags().StringVar(&show, "show", "", "Show only a specific limit by name")
+	cmd.Flags().StringSliceVar(&names, "names", nil, "Show only these limits (comma-separated)")
+	cmd.Flags().Float64Var(&failUnder, "fail-under", 0, "Exit with an error if any shown limit has less than this percentage remaining")
 
 	return cmd
 }
 
-func runLimits(ctx context.Context, opts *root.Options, show string) error {
+func runLimits(ctx context.Context, opts *root.Options, single bool, names []string, failUnder float64) error {
 	client, err := opts.APIClient()
 	if err != nil {
 		return fmt.Errorf("failed to create API client: %w", err)
@@ -52,12 +74,58 @@ func runLimits(ctx context.Context, opts *root.Options, show string) error {
 		return fmt.Errorf("failed to get limits: %w", err)
 	}
 
+	if len(names) > 0 {
+		selected := make(api.Limits, len(names))
+		for _, name := range names {
+			limit, ok := limits[name]
+			if !ok {
+				return fmt.Errorf("limit %q not found", name)
+			}
+			selected[name] = limit
+		}
+		limits = selected
+	}
+
 	// If showing a specific limit
-	if show != "" {
-		return renderSingleLimit(opts, limits, show)
+	if single {
+		err = renderSingleLimit(opts, limits, names[0])
+	} else {
+		err = renderLimits(opts, limits)
+	}
+	if err != nil {
+		return err
 	}
 
-	return renderLimits(opts, limits)
+	if failUnder > 0 {
+		return checkRemaining(limits, failUnder)
+	}
+
+	return nil
+}
+
+// remainingPercent returns the percentage of a limit that is still available.
+func remainingPercent(limit api.LimitInfo) float64 {
+	if limit.Max <= 0 {
+		return 100
+	}
+	return float64(limit.Remaining) / float64(limit.Max) * 100
+}
+
+// checkRemaining returns an error listing the limits with less than
+// threshold percent remaining.
+func checkRemaining(limits api.Limits, threshold float64) error {
+	var low []string
+	for name, limit := range limits {
+		if pct := remainingPercent(limit); pct < threshold {
+			low = append(low, fmt.Sprintf("%s (%.1f%%)", name, pct))
+		}
+	}
+	if len(low) == 0 {
+		return nil
+	}
+
+	sort.Strings(low)
+	return fmt.Errorf("%d limit(s) below %g%% remaining: %s", len(low), threshold, strings.Join(low, ", "))
 }
 
 func renderSingleLimit(opts *root.Options, limits api.Limits, name string) error {
@@ -70,10 +138,11 @@ func renderSingleLimit(opts *root.Options, limits api.Limits, name string) error
 
 	if opts.Output == "json" {
 		return v.JSON(map[string]interface{}{
-			"name":      name,
-			"max":       limit.Max,
-			"remaining": limit.Remaining,
-			"used":      limit.Max - limit.Remaining,
+			"name":             name,
+			"max":              limit.Max,
+			"remaining":        limit.Remaining,
+			"used":             limit.Max - limit.Remaining,
+			"remainingPercent": remainingPercent(limit),
 		})
 	}
 
@@ -87,15 +156,32 @@ func renderSingleLimit(opts *root.Options, limits api.Limits, name string) error
 	v.Info("  Max:       %d", limit.Max)
 	v.Info("  Remaining: %d", limit.Remaining)
 	v.Info("  Used:      %d (%.1f%%)", used, pct)
+	v.Info("  Available: %.1f%%", remainingPercent(limit))
 
 	return nil
 }
 
+// limitRecord is a limit in JSON output: the API's fields, plus the
+// percentage remaining so scripts can filter on it.
+type limitRecord struct {
+	Max              int     `json:"Max"`
+	Remaining        int     `json:"Remaining"`
+	RemainingPercent float64 `json:"remainingPercent"`
+}
+
 func renderLimits(opts *root.Options, limits api.Limits) error {
 	v := opts.View()
 
 	if opts.Output == "json" {
-		return v.JSON(limits)
+		records := make(map[string]limitRecord, len(limits))
+		for name, limit := range limits {
+			records[name] = limitRecord{
+				Max:              limit.Max,
+				Remaining:        limit.Remaining,
+				RemainingPercent: remainingPercent(limit),
+			}
+		}
+		return v.JSON(records)
 	}
 
 	// Sort limit names for consistent output
@@ -105,7 +191,7 @@ func renderLimits(opts *root.Options, limits api.Limits) error {
 	}
 	sort.Strings(names)
 
-	headers := []string{"Limit", "Max", "Remaining", "Used", "Usage %"}
+	headers := []string{"Limit", "Max", "Remaining", "Used", "Usage %", "Remaining %"}
 	rows := make([][]string, 0, len(names))
 
 	for _, name := range names {
@@ -122,6 +208,7 @@ func renderLimits(opts *root.Options, limits api.Limits) error {
 			fmt.Sprintf("%d", limit.Remaining),
 			fmt.Sprintf("%d", used),
 			fmt.Sprintf("%.1f%%", pct),
+			fmt.Sprintf("%.1f%%", remainingPercent(limit)),
 		})
 	}
 
@@ -156,8 +156,112 @@ func TestLimitsCommand_JSONOutput(t *testing.T) {
 	err = cmd.Execute()
 	require.NoError(t, err)
 
-	var result api.Limits
+	var result map[string]limitRecord
 	err = json.Unmarshal(stdout.Bytes(), &result)
 	require.NoError(t, err)
 	assert.Equal(t, 100000, result["DailyApiRequests"].Max)
+	assert.Equal(t, 99500, result["DailyApiRequests"].Remaining)
+	assert.InDelta(t, 99.5, result["DailyApiRequests"].RemainingPercent, 0.001)
+}
+
+func TestLimitsCommand_NamesAndFailUnder(t *testing.T) {
+	limits := api.Limits{
+		"DailyApiRequests":     api.LimitInfo{Max: 100000, Remaining: 5000},
+		"DailyBulkApiRequests": api.LimitInfo{Max: 10000, Remaining: 9000},
+		"DataStorageMB":        api.LimitInfo{Max: 1024, Remaining: 10},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(limits)
+	}))
+	defer server.Close()
+
+	client, err := api.New(api.ClientConfig{
+		InstanceURL: server.URL,
+		HTTPClient:  server.Client(),
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		args        []string
+		output      string
+		wantErr     string
+		wantContain []string
+		wantMissing []string
+	}{
+		{
+			name:        "names filter",
+			args:        []string{"--names", "DailyApiRequests,DailyBulkApiRequests"},
+			output:      "table",
+			wantContain: []string{"DailyApiRequests", "DailyBulkApiRequests", "Remaining %", "5.0%", "90.0%"},
+			wantMissing: []string{"DataStorageMB"},
+		},
+		{
+			name:        "fail under passes",
+			args:        []string{"--names", "DailyBulkApiRequests", "--fail-under", "50"},
+			output:      "table",
+			wantContain: []string{"DailyBulkApiRequests"},
+		},
+		{
+			name:        "fail under fails",
+			args:        []string{"--names", "DailyApiRequests,DailyBulkApiRequests", "--fail-under", "10"},
+			output:      "table",
+			wantErr:     "1 limit(s) below 10% remaining: DailyApiRequests (5.0%)",
+			wantContain: []string{"DailyApiRequests"},
+		},
+		{
+			name:        "fail under with json still writes output",
+			args:        []string{"--fail-under", "10"},
+			output:      "json",
+			wantErr:     "2 limit(s) below 10% remaining: DailyApiRequests (5.0%), DataStorageMB (1.0%)",
+			wantContain: []string{`"DataStorageMB"`},
+		},
+		{
+			name:    "unknown name",
+			args:    []string{"--names", "Nope"},
+			output:  "table",
+			wantErr: `limit "Nope" not found`,
+		},
+		{
+			name:    "show and names conflict",
+			args:    []string{"--show", "DailyApiRequests", "--names", "DataStorageMB"},
+			output:  "table",
+			wantErr: "cannot be used together",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stdout := &bytes.Buffer{}
+			opts := &root.Options{
+				Output: tt.output,
+				Stdout: stdout,
+				Stderr: &bytes.Buffer{},
+			}
+			opts.SetAPIClient(client)
+
+			cmd := NewCommand(opts)
+			cmd.SetArgs(tt.args)
+			cmd.SetOut(stdout)
+			cmd.SetErr(&bytes.Buffer{})
+
+			err := cmd.Execute()
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+
+			output := stdout.String()
+			for _, want := range tt.wantContain {
+				assert.Contains(t, output, want)
+			}
+			for _, missing := range tt.wantMissing {
+				assert.NotContains(t, output, missing)
+			}
+		})
+	}
 }
@@ -0,0 +1,78 @@
+package listviewcmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/salesforce-cli/internal/cmd/root"
+)
+
+func newDescribeCommand(opts *root.Options) *cobra.Command {
+	return &cobra.Command{
+		Use:   "describe <object> <listview>",
+		Short: "Show the columns and query of a list view",
+		Long: `Show the columns of a list view and the SOQL query it runs.
+
+The query can be passed to 'sfdc query', e.g. to export every record the list
+view shows.
+
+Examples:
+  sfdc listview describe Account AllAccounts
+  sfdc listview describe Account 00Bxx0000001abcEAA -o json`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDescribe(cmd.Context(), opts, args[0], args[1])
+		},
+	}
+}
+
+func runDescribe(ctx context.Context, opts *root.Options, objectName, idOrName string) error {
+	client, err := opts.APIClient()
+	if err != nil {
+		return fmt.Errorf("failed to create API client: %w", err)
+	}
+
+	id, err := listViewID(ctx, client, objectName, idOrName)
+	if err != nil {
+		return err
+	}
+
+	desc, err := client.DescribeListView(ctx, objectName, id)
+	if err != nil {
+		return fmt.Errorf("failed to describe list view: %w", err)
+	}
+
+	v := opts.View()
+
+	if opts.Output == "json" {
+		return v.JSON(desc)
+	}
+
+	v.Info("Query: %s", desc.Query)
+	if desc.Scope != "" {
+		v.Info("Scope: %s", desc.Scope)
+	}
+	if len(desc.OrderBy) > 0 {
+		order := make([]string, 0, len(desc.OrderBy))
+		for _, o := range desc.OrderBy {
+			order = append(order, o.FieldNameOrPath+" "+o.SortDirection)
+		}
+		v.Info("Order: %s", strings.Join(order, ", "))
+	}
+	v.Info("")
+
+	headers := []string{"Field", "Label", "Type", "Hidden"}
+	rows := make([][]string, 0, len(desc.Columns))
+	for _, col := range desc.Columns {
+		hidden := ""
+		if col.Hidden {
+			hidden = "yes"
+		}
+		rows = append(rows, []string{col.FieldNameOrPath, col.Label, col.Type, hidden})
+	}
+
+	return v.Table(headers, rows)
+}
@@ -0,0 +1,65 @@
+package listviewcmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/salesforce-cli/internal/cmd/root"
+)
+
+func newListCommand(opts *root.Options) *cobra.Command {
+	return &cobra.Command{
+		Use:   "list <object>",
+		Short: "List the list views of an object",
+		Long: `List the list views of an object that you can see.
+
+Examples:
+  sfdc listview list Account
+  sfdc listview list Opportunity -o json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runList(cmd.Context(), opts, args[0])
+		},
+	}
+}
+
+func runList(ctx context.Context, opts *root.Options, objectName string) error {
+	client, err := opts.APIClient()
+	if err != nil {
+		return fmt.Errorf("failed to create API client: %w", err)
+	}
+
+	views, err := client.ListViews(ctx, objectName)
+	if err != nil {
+		return fmt.Errorf("failed to list list views: %w", err)
+	}
+
+	v := opts.View()
+
+	if opts.Output == "json" {
+		return v.JSON(views)
+	}
+
+	if len(views) == 0 {
+		v.Info("No list views found for %s", objectName)
+		return nil
+	}
+
+	headers := []string{"ID", "Developer Name", "Label", "SOQL Compatible"}
+	rows := make([][]string, 0, len(views))
+	for _, view := range views {
+		soqlCompatible := "no"
+		if view.SOQLCompatible {
+			soqlCompatible = "yes"
+		}
+		rows = append(rows, []string{view.ID, view.DeveloperName, view.Label, soqlCompatible})
+	}
+
+	if err := v.Table(headers, rows); err != nil {
+		return err
+	}
+	v.Info("\n%d list view(s)", len(views))
+	return nil
+}
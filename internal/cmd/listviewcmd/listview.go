@@ -0,0 +1,48 @@
+// Package listviewcmd provides commands for working with list views.
+package listviewcmd
+
+import (
+	"context"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/salesforce-cli/api"
+	"github.com/open-cli-collective/salesforce-cli/internal/cmd/root"
+)
+
+// Register registers the listview command with the root command.
+func Register(parent *cobra.Command, opts *root.Options) {
+	parent.AddCommand(NewCommand(opts))
+}
+
+// NewCommand creates the listview command with subcommands.
+func NewCommand(opts *root.Options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "listview",
+		Short: "Work with list views",
+		Long: `List, describe, and run the list views of an object.
+
+List views are referred to by ID or developer name (e.g. AllAccounts).`,
+	}
+
+	cmd.AddCommand(newListCommand(opts))
+	cmd.AddCommand(newDescribeCommand(opts))
+	cmd.AddCommand(newRunCommand(opts))
+
+	return cmd
+}
+
+// listViewID returns the ID of the list view given by ID or developer name,
+// looking developer names up.
+func listViewID(ctx context.Context, client *api.Client, objectName, idOrName string) (string, error) {
+	if strings.HasPrefix(idOrName, "00B") && (len(idOrName) == 15 || len(idOrName) == 18) {
+		return idOrName, nil
+	}
+
+	view, err := client.FindListView(ctx, objectName, idOrName)
+	if err != nil {
+		return "", err
+	}
+	return view.ID, nil
+}
@@ -0,0 +1,171 @@
+package listviewcmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/salesforce-cli/api"
+	"github.com/open-cli-collective/salesforce-cli/internal/cmd/root"
+)
+
+const listViewsResponse = `{
+	"done": true,
+	"listviews": [
+		{"id": "00Bxx0000001abcEAA", "developerName": "AllAccounts", "label": "All Accounts", "soqlCompatible": true},
+		{"id": "00Bxx0000002abcEAA", "developerName": "MyAccounts", "label": "My Accounts", "soqlCompatible": true}
+	],
+	"size": 2,
+	"sobjectType": "Account"
+}`
+
+const listViewResultsResponse = `{
+	"columns": [
+		{"fieldNameOrPath": "Name", "label": "Account Name", "type": "string", "hidden": false},
+		{"fieldNameOrPath": "BillingState", "label": "Billing State/Province", "type": "string", "hidden": false},
+		{"fieldNameOrPath": "Id", "label": "Account ID", "type": "id", "hidden": true},
+		{"fieldNameOrPath": "SystemModstamp", "label": "System Modstamp", "type": "datetime", "hidden": true}
+	],
+	"developerName": "AllAccounts",
+	"done": true,
+	"id": "00Bxx0000001abcEAA",
+	"label": "All Accounts",
+	"records": [
+		{"columns": [
+			{"fieldNameOrPath": "Name", "value": "Acme"},
+			{"fieldNameOrPath": "BillingState", "value": null},
+			{"fieldNameOrPath": "Id", "value": "001xx000003DGbYAAW"},
+			{"fieldNameOrPath": "SystemModstamp", "value": "Mon Jan 15 10:30:00 GMT 2024"}
+		]}
+	],
+	"size": 1
+}`
+
+// listViewServer serves the list views of Account and the results of
+// AllAccounts.
+func listViewServer(t *testing.T) *api.Client {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/services/data/v62.0/sobjects/Account/listviews":
+			_, _ = w.Write([]byte(listViewsResponse))
+		case "/services/data/v62.0/sobjects/Account/listviews/00Bxx0000001abcEAA/results":
+			assert.Equal(t, "50", r.URL.Query().Get("limit"))
+			_, _ = w.Write([]byte(listViewResultsResponse))
+		case "/services/data/v62.0/sobjects/Account/listviews/00Bxx0000001abcEAA/describe":
+			_, _ = w.Write([]byte(`{
+				"id": "00Bxx0000001abcEAA",
+				"query": "SELECT Name, BillingState, Id FROM Account ORDER BY Name ASC NULLS FIRST, Id ASC NULLS FIRST",
+				"scope": "everything",
+				"sobjectType": "Account",
+				"columns": [{"fieldNameOrPath": "Name", "label": "Account Name", "type": "string"}],
+				"orderBy": [{"fieldNameOrPath": "Name", "sortDirection": "ascending", "nullsPosition": "first"}]
+			}`))
+		default:
+			t.Errorf("unexpected request: %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := api.New(api.ClientConfig{InstanceURL: server.URL, HTTPClient: server.Client()})
+	require.NoError(t, err)
+	return client
+}
+
+func newTestOptions(t *testing.T, output string) (*root.Options, *bytes.Buffer) {
+	t.Helper()
+
+	stdout := &bytes.Buffer{}
+	opts := &root.Options{
+		Output:  output,
+		NoColor: true,
+		Stdout:  stdout,
+		Stderr:  &bytes.Buffer{},
+	}
+	opts.SetAPIClient(listViewServer(t))
+	return opts, stdout
+}
+
+func TestListCommand(t *testing.T) {
+	opts, stdout := newTestOptions(t, "table")
+
+	cmd := NewCommand(opts)
+	cmd.SetArgs([]string{"list", "Account"})
+	require.NoError(t, cmd.Execute())
+
+	out := stdout.String()
+	assert.Contains(t, out, "00Bxx0000001abcEAA")
+	assert.Contains(t, out, "AllAccounts")
+	assert.Contains(t, out, "My Accounts")
+	assert.Contains(t, out, "2 list view(s)")
+}
+
+func TestDescribeCommand(t *testing.T) {
+	opts, stdout := newTestOptions(t, "table")
+
+	cmd := NewCommand(opts)
+	cmd.SetArgs([]string{"describe", "Account", "AllAccounts"})
+	require.NoError(t, cmd.Execute())
+
+	out := stdout.String()
+	assert.Contains(t, out, "Query: SELECT Name, BillingState, Id FROM Account ORDER BY Name ASC NULLS FIRST")
+	assert.Contains(t, out, "Order: Name ascending")
+	assert.Contains(t, out, "Account Name")
+}
+
+func TestRunCommand(t *testing.T) {
+	opts, stdout := newTestOptions(t, "table")
+
+	cmd := NewCommand(opts)
+	cmd.SetArgs([]string{"run", "Account", "allaccounts", "--limit", "50"})
+	require.NoError(t, cmd.Execute())
+
+	out := stdout.String()
+	assert.Contains(t, out, "Account Name")
+	assert.Contains(t, out, "Billing State/Province")
+	assert.NotContains(t, out, "System Modstamp")
+	assert.Contains(t, out, "001xx000003DGbYAAW")
+	assert.Contains(t, out, "Acme")
+	assert.Contains(t, out, "1 record(s)")
+}
+
+func TestRunCommand_JSON(t *testing.T) {
+	opts, stdout := newTestOptions(t, "json")
+
+	cmd := NewCommand(opts)
+	cmd.SetArgs([]string{"run", "Account", "00Bxx0000001abcEAA", "--limit", "50"})
+	require.NoError(t, cmd.Execute())
+
+	var result api.QueryResult
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &result))
+	require.Len(t, result.Records, 1)
+	assert.Equal(t, "001xx000003DGbYAAW", result.Records[0].ID)
+	assert.Equal(t, "Account", result.Records[0].Attributes.Type)
+	assert.Equal(t, "Acme", result.Records[0].GetString("Name"))
+	assert.Contains(t, result.Records[0].Fields, "BillingState")
+	assert.NotContains(t, result.Records[0].Fields, "SystemModstamp")
+}
+
+func TestRunCommand_UnknownListView(t *testing.T) {
+	opts, _ := newTestOptions(t, "table")
+
+	cmd := NewCommand(opts)
+	cmd.SetArgs([]string{"run", "Account", "Nope"})
+	assert.EqualError(t, cmd.Execute(), `list view "Nope" not found on Account`)
+}
+
+func TestRunCommand_InvalidLimit(t *testing.T) {
+	opts := &root.Options{Output: "table", Stdout: &bytes.Buffer{}, Stderr: &bytes.Buffer{}}
+
+	cmd := NewCommand(opts)
+	cmd.SetArgs([]string{"run", "Account", "AllAccounts", "--limit", "5000"})
+	assert.EqualError(t, cmd.Execute(), "--limit must be between 1 and 2000")
+}
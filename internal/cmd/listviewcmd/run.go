@@ -0,0 +1,120 @@
+package listviewcmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/salesforce-cli/api"
+	"github.com/open-cli-collective/salesforce-cli/internal/cmd/root"
+)
+
+// runFlags holds the run command's flags.
+type runFlags struct {
+	limit  int
+	offset int
+}
+
+func newRunCommand(opts *root.Options) *cobra.Command {
+	var flags runFlags
+
+	cmd := &cobra.Command{
+		Use:   "run <object> <listview>",
+		Short: "Show the records of a list view",
+		Long: `Run a list view and show its records, with the list view's columns in
+its order. JSON output has the same shape as 'sfdc query -o json'.
+
+Up to 2,000 records are returned per run; use --offset to get the next ones.
+
+Examples:
+  sfdc listview run Account AllAccounts
+  sfdc listview run Case MyOpenCases --limit 100
+  sfdc listview run Account AllAccounts --limit 2000 --offset 2000 -o json`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if flags.limit < 1 || flags.limit > 2000 {
+				return fmt.Errorf("--limit must be between 1 and 2000")
+			}
+			if flags.offset < 0 {
+				return fmt.Errorf("--offset must not be negative")
+			}
+			return runRun(cmd.Context(), opts, args[0], args[1], flags)
+		},
+	}
+
+	cmd.Flags().IntVar(&flags.limit, "limit", 25, "Maximum number of records to return (up to 2000)")
+	cmd.Flags().IntVar(&flags.offset, "offset", 0, "Number of records to skip")
+
+	return cmd
+}
+
+func runRun(ctx context.Context, opts *root.Options, objectName, idOrName string, flags runFlags) error {
+	client, err := opts.APIClient()
+	if err != nil {
+		return fmt.Errorf("failed to create API client: %w", err)
+	}
+
+	id, err := listViewID(ctx, client, objectName, idOrName)
+	if err != nil {
+		return err
+	}
+
+	results, err := client.GetListViewResults(ctx, objectName, id, flags.limit, flags.offset)
+	if err != nil {
+		return fmt.Errorf("failed to run list view: %w", err)
+	}
+
+	v := opts.View()
+	result := results.QueryResult(objectName)
+
+	if len(result.Records) == 0 {
+		v.Info("No records found")
+		return nil
+	}
+
+	if opts.Output == "json" {
+		return v.JSON(result)
+	}
+
+	headers, fields := visibleColumns(results.Columns)
+	rows := make([][]string, 0, len(result.Records))
+	for _, rec := range result.Records {
+		row := make([]string, len(fields))
+		for i, field := range fields {
+			if field == "Id" {
+				row[i] = rec.ID
+			} else {
+				row[i] = rec.GetString(field)
+			}
+		}
+		rows = append(rows, row)
+	}
+
+	if err := v.Table(headers, rows); err != nil {
+		return err
+	}
+
+	if !result.Done {
+		v.Info("\nShowing %d record(s) from offset %d (use --offset %d for more)", len(result.Records), flags.offset, flags.offset+len(result.Records))
+	} else {
+		v.Info("\n%d record(s)", len(result.Records))
+	}
+
+	return nil
+}
+
+// visibleColumns returns the labels and field names of the columns a list
+// view shows, in order, with Id first.
+func visibleColumns(columns []api.ListViewColumn) (labels, fields []string) {
+	labels = []string{"Id"}
+	fields = []string{"Id"}
+	for _, col := range columns {
+		if col.Hidden || col.FieldNameOrPath == "Id" {
+			continue
+		}
+		labels = append(labels, col.Label)
+		fields = append(fields, col.FieldNameOrPath)
+	}
+	return labels, fields
+}
@@ -3,16 +3,21 @@ package logcmd
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/spf13/cobra"
 
+	"github.com/open-cli-collective/salesforce-cli/api/tooling"
 	"github.com/open-cli-collective/salesforce-cli/internal/cmd/root"
 )
 
 func newListCommand(opts *root.Options) *cobra.Command {
 	var (
-		userID string
-		limit  int
+		userID       string
+		limit        int
+		since        string
+		until        string
+		durationOver int
 	)
 
 	cmd := &cobra.Command{
@@ -20,30 +25,61 @@ func newListCommand(opts *root.Options) *cobra.Command {
 		Short: "List debug logs",
 		Long: `List debug logs from the org.
 
+--since and --until accept a duration relative to now (e.g. 15m, 2h) or a
+timestamp (RFC 3339, "2006-01-02 15:04", or "2006-01-02" in local time).
+
 Examples:
   sfdc log list                        # List recent logs
   sfdc log list --limit 20             # List last 20 logs
   sfdc log list --user 005xxx          # Filter by user ID
+  sfdc log list --since 15m            # Logs from the last 15 minutes
+  sfdc log list --since 2024-01-15 --until 2024-01-16
+  sfdc log list --duration-over 2000   # Requests slower than 2 seconds
   sfdc log list -o json                # Output as JSON`,
 		Args: cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runLogList(cmd.Context(), opts, userID, limit)
+			now := time.Now()
+			filter := tooling.ApexLogFilter{
+				UserID:        userID,
+				MinDurationMS: durationOver,
+				Limit:         limit,
+			}
+
+			var err error
+			if since != "" {
+				if filter.Since, err = root.ParseTimeFlag(since, now); err != nil {
+					return fmt.Errorf("invalid --since: %w", err)
+				}
+			}
+			if until != "" {
+				if filter.Until, err = root.ParseTimeFlag(until, now); err != nil {
+					return fmt.Errorf("invalid --until: %w", err)
+				}
+			}
+			if !filter.Since.IsZero() && !filter.Until.IsZero() && filter.Until.Before(filter.Since) {
+				return fmt.Errorf("--until must not be before --since")
+			}
+
+			return runLogList(cmd.Context(), opts, filter)
 		},
 	}
 
 	cmd.Flags().StringVar(&userID, "user", "", "Filter by user ID")
 	cmd.Flags().IntVar(&limit, "limit", 10, "Maximum number of logs to return")
+	cmd.Flags().StringVar(&since, "since", "", "Only logs started at or after this time")
+	cmd.Flags().StringVar(&until, "until", "", "Only logs started at or before this time")
+	cmd.Flags().IntVar(&durationOver, "duration-over", 0, "Only logs for requests that took longer than this many milliseconds")
 
 	return cmd
 }
 
-func runLogList(ctx context.Context, opts *root.Options, userID string, limit int) error {
+func runLogList(ctx context.Context, opts *root.Options, filter tooling.ApexLogFilter) error {
 	client, err := opts.ToolingClient()
 	if err != nil {
 		return fmt.Errorf("failed to create tooling client: %w", err)
 	}
 
-	logs, err := client.ListApexLogs(ctx, userID, limit)
+	logs, err := client.ListApexLogsFiltered(ctx, filter)
 	if err != nil {
 		return fmt.Errorf("failed to list logs: %w", err)
 	}
@@ -301,6 +301,69 @@ func TestLogTailContextCancellation(t *testing.T) {
 	assert.GreaterOrEqual(t, callCount, 1)
 }
 
+func TestLogListFilters(t *testing.T) {
+	var soql string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		soql = r.URL.Query().Get("q")
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(tooling.QueryResult{Done: true, Records: []tooling.Record{}})
+	}))
+	defer server.Close()
+
+	client, err := tooling.New(tooling.ClientConfig{
+		InstanceURL: server.URL,
+		HTTPClient:  server.Client(),
+	})
+	require.NoError(t, err)
+
+	opts := &root.Options{
+		Output: "table",
+		Stdout: &bytes.Buffer{},
+		Stderr: &bytes.Buffer{},
+	}
+	opts.SetToolingClient(client)
+
+	cmd := NewCommand(opts)
+	cmd.SetArgs([]string{"list", "--since", "2024-01-15T10:00:00Z", "--until", "2024-01-15T12:00:00Z", "--duration-over", "500"})
+
+	err = cmd.Execute()
+	require.NoError(t, err)
+
+	assert.Contains(t, soql, "StartTime >= 2024-01-15T10:00:00Z")
+	assert.Contains(t, soql, "StartTime <= 2024-01-15T12:00:00Z")
+	assert.Contains(t, soql, "DurationMilliseconds > 500")
+}
+
+func TestLogListInvalidWindow(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{"bad since", []string{"list", "--since", "yesterday"}, "invalid --since"},
+		{"until before since", []string{"list", "--since", "1h", "--until", "2h"}, "--until must not be before --since"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := &root.Options{
+				Output: "table",
+				Stdout: &bytes.Buffer{},
+				Stderr: &bytes.Buffer{},
+			}
+
+			cmd := NewCommand(opts)
+			cmd.SetArgs(tt.args)
+			cmd.SetOut(&bytes.Buffer{})
+			cmd.SetErr(&bytes.Buffer{})
+
+			err := cmd.Execute()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
 func TestFormatSize(t *testing.T) {
 	tests := []struct {
 		bytes int
@@ -2,6 +2,7 @@ package metadatacmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -14,10 +15,13 @@ import (
 
 func newDeployCommand(opts *root.Options) *cobra.Command {
 	var (
-		sourceDir string
-		checkOnly bool
-		testLevel string
-		wait      bool
+		sourceDir   string
+		checkOnly   bool
+		testLevel   string
+		wait        bool
+		onlyErrors  bool
+		onlyChanges bool
+		yes         bool
 	)
 
 	cmd := &cobra.Command{
@@ -30,17 +34,36 @@ The source directory should be in the standard Salesforce metadata format
 
 For complex deployments, use the official Salesforce CLI (sf).
 
+With --wait, the result lists each component that was created, changed, or
+deleted, and each failure as file:line:column: problem so editors and CI can
+parse them. Use --only-errors or --only-changes to narrow the list on large
+deploys, or -o json for the full result details. The wait gives up after
+--wait-timeout (default 30m); the deployment keeps running.
+
+With --dry-run, the deployment is validated as with --check-only.
+
+Before deploying (without --check-only) to a production org, the command names
+the org and asks for confirmation. Use --yes to skip the prompt (e.g. in CI).
+
 Examples:
   sfdc metadata deploy --source ./src
   sfdc metadata deploy --source ./src --check-only
   sfdc metadata deploy --source ./src --test-level RunLocalTests
-  sfdc metadata deploy --source ./src --wait`,
+  sfdc metadata deploy --source ./src --wait
+  sfdc metadata deploy --source ./src --wait --only-errors
+  sfdc metadata deploy --source ./src --wait --yes`,
 		Args: cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if sourceDir == "" {
 				return fmt.Errorf("--source is required")
 			}
-			return runDeploy(cmd.Context(), opts, sourceDir, checkOnly, testLevel, wait)
+			if onlyErrors && onlyChanges {
+				return fmt.Errorf("--only-errors and --only-changes cannot be used together")
+			}
+			if (onlyErrors || onlyChanges) && !wait {
+				return fmt.Errorf("--only-errors and --only-changes require --wait")
+			}
+			return runDeploy(cmd.Context(), opts, sourceDir, checkOnly, testLevel, wait, onlyErrors, onlyChanges, yes)
 		},
 	}
 
@@ -48,18 +71,38 @@ Examples:
 	cmd.Flags().BoolVar(&checkOnly, "check-only", false, "Validate without deploying")
 	cmd.Flags().StringVar(&testLevel, "test-level", "", "Test level: NoTestRun, RunLocalTests, RunAllTestsInOrg")
 	cmd.Flags().BoolVar(&wait, "wait", false, "Wait for deployment to complete")
+	cmd.Flags().BoolVar(&onlyErrors, "only-errors", false, "Only show component failures")
+	cmd.Flags().BoolVar(&onlyChanges, "only-changes", false, "Only show created, changed, or deleted components")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Skip the production org confirmation")
+	cmd.Flags().DurationVar(&opts.WaitTimeout, "wait-timeout", root.DefaultWaitTimeout, "How long --wait waits for the deployment (0 for no limit)")
 
 	return cmd
 }
 
-func runDeploy(ctx context.Context, opts *root.Options, sourceDir string, checkOnly bool, testLevel string, wait bool) error {
+func runDeploy(ctx context.Context, opts *root.Options, sourceDir string, checkOnly bool, testLevel string, wait, onlyErrors, onlyChanges, yes bool) error {
+	v := opts.View()
+
+	if opts.DryRun && !checkOnly {
+		v.Info("Dry run: validating the deployment without saving changes (check-only)")
+		checkOnly = true
+	}
+
+	if !checkOnly {
+		proceed, err := opts.ConfirmProduction(ctx, fmt.Sprintf("metadata deploy from %s", sourceDir), yes)
+		if err != nil {
+			return err
+		}
+		if !proceed {
+			v.Info("Cancelled")
+			return nil
+		}
+	}
+
 	client, err := opts.MetadataClient()
 	if err != nil {
 		return fmt.Errorf("failed to create metadata client: %w", err)
 	}
 
-	v := opts.View()
-
 	// Create zip from source directory
 	v.Info("Creating deployment package from %s...", sourceDir)
 	zipData, err := metadata.CreateZipFromDirectory(sourceDir)
@@ -99,26 +142,31 @@ func runDeploy(ctx context.Context, opts *root.Options, sourceDir string, checkO
 	// Poll for completion
 	v.Info("Waiting for deployment to complete...")
 
-	for {
-		status, err := client.GetDeployStatus(ctx, result.ID, true)
+	var status *metadata.DeployResult
+	err = root.Poll(ctx, 3*time.Second, opts.WaitTimeout, func() (bool, error) {
+		status, err = client.GetDeployStatus(ctx, result.ID, true)
 		if err != nil {
-			return fmt.Errorf("failed to get deployment status: %w", err)
+			return false, fmt.Errorf("failed to get deployment status: %w", err)
 		}
-
-		if status.Done {
-			return displayDeployResult(opts, status)
+		if !status.Done {
+			v.Info("Status: %s (%d/%d components)...",
+				status.Status,
+				status.NumberComponentsDeployed,
+				status.NumberComponentsTotal)
 		}
-
-		v.Info("Status: %s (%d/%d components)...",
-			status.Status,
-			status.NumberComponentsDeployed,
-			status.NumberComponentsTotal)
-
-		time.Sleep(3 * time.Second)
+		return status.Done, nil
+	})
+	if errors.Is(err, root.ErrWaitTimeout) {
+		return fmt.Errorf("%w waiting for deployment %s; use 'sfdc metadata deploy-status %s' to check status", err, result.ID, result.ID)
+	}
+	if err != nil {
+		return err
 	}
+
+	return displayDeployResult(opts, status, onlyErrors, onlyChanges)
 }
 
-func displayDeployResult(opts *root.Options, result *metadata.DeployResult) error {
+func displayDeployResult(opts *root.Options, result *metadata.DeployResult, onlyErrors, onlyChanges bool) error {
 	v := opts.View()
 
 	if opts.Output == "json" {
@@ -142,18 +190,19 @@ func displayDeployResult(opts *root.Options, result *metadata.DeployResult) erro
 			result.NumberTestErrors)
 	}
 
-	// Show component failures
-	if result.DeployDetails != nil && len(result.DeployDetails.ComponentFailures) > 0 {
-		v.Error("\nComponent failures:")
-		for _, failure := range result.DeployDetails.ComponentFailures {
-			fmt.Fprintf(opts.Stderr, "  %s.%s: %s\n",
-				failure.ComponentType,
-				failure.FullName,
-				failure.Problem)
-			if failure.LineNumber > 0 {
-				fmt.Fprintf(opts.Stderr, "    at line %d, column %d\n",
-					failure.LineNumber,
-					failure.ColumnNumber)
+	if result.DeployDetails != nil {
+		// Show changed components
+		if !onlyErrors {
+			if err := displayComponentChanges(opts, result.DeployDetails.ComponentSuccesses); err != nil {
+				return err
+			}
+		}
+
+		// Show component failures
+		if !onlyChanges && len(result.DeployDetails.ComponentFailures) > 0 {
+			v.Error("\nComponent failures:")
+			for _, failure := range result.DeployDetails.ComponentFailures {
+				fmt.Fprintln(opts.Stderr, formatComponentFailure(failure))
 			}
 		}
 	}
@@ -176,3 +225,52 @@ func displayDeployResult(opts *root.Options, result *metadata.DeployResult) erro
 
 	return nil
 }
+
+// displayComponentChanges renders a table of components that were created,
+// changed, or deleted by the deployment.
+func displayComponentChanges(opts *root.Options, successes []metadata.ComponentResult) error {
+	var rows [][]string
+	for _, c := range successes {
+		action := componentAction(c)
+		if action == "" {
+			continue
+		}
+		rows = append(rows, []string{action, c.ComponentType, c.FullName, c.FileName})
+	}
+
+	if len(rows) == 0 {
+		return nil
+	}
+
+	v := opts.View()
+	v.Info("\nChanged components:")
+	return v.Table([]string{"Action", "Type", "Name", "File"}, rows)
+}
+
+// componentAction describes what a deployment did to a component, or returns
+// an empty string if the component was unchanged.
+func componentAction(c metadata.ComponentResult) string {
+	switch {
+	case c.Created:
+		return "Created"
+	case c.Deleted:
+		return "Deleted"
+	case c.Changed:
+		return "Changed"
+	default:
+		return ""
+	}
+}
+
+// formatComponentFailure formats a failure as file:line:column: problem,
+// falling back to Type.Name when the file is unknown.
+func formatComponentFailure(f metadata.ComponentResult) string {
+	location := f.FileName
+	if location == "" {
+		location = f.ComponentType + "." + f.FullName
+	}
+	if f.LineNumber > 0 {
+		location = fmt.Sprintf("%s:%d:%d", location, f.LineNumber, f.ColumnNumber)
+	}
+	return fmt.Sprintf("%s: %s", location, f.Problem)
+}
@@ -1,8 +1,10 @@
 package metadatacmd
 
 import (
+	"archive/zip"
 	"bytes"
 	"encoding/json"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"os"
@@ -232,6 +234,64 @@ func TestMetadataRetrieve(t *testing.T) {
 	assert.Equal(t, "public class MyController { }", string(content))
 }
 
+func TestMetadataRetrieveZip(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		response := struct {
+			Records []map[string]interface{} `json:"records"`
+		}{
+			Records: []map[string]interface{}{
+				{
+					"Id":   "01p000000000001",
+					"Name": "MyController",
+					"Body": "public class MyController { }",
+				},
+			},
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(response)
+	}))
+	defer server.Close()
+
+	client, err := metadata.New(metadata.ClientConfig{
+		InstanceURL: server.URL,
+		HTTPClient:  server.Client(),
+	})
+	require.NoError(t, err)
+
+	zipPath := filepath.Join(t.TempDir(), "classes.zip")
+
+	stdout := &bytes.Buffer{}
+	opts := &root.Options{
+		Output: "table",
+		Stdout: stdout,
+		Stderr: &bytes.Buffer{},
+	}
+	opts.SetMetadataClient(client)
+
+	cmd := NewCommand(opts)
+	cmd.SetArgs([]string{"retrieve", "--type", "ApexClass", "--name", "MyController", "--zip", zipPath})
+	cmd.SetOut(stdout)
+
+	err = cmd.Execute()
+	require.NoError(t, err)
+
+	reader, err := zip.OpenReader(zipPath)
+	require.NoError(t, err)
+	defer reader.Close()
+
+	require.Len(t, reader.File, 1)
+	assert.Equal(t, "MyController.cls", reader.File[0].Name)
+
+	rc, err := reader.File[0].Open()
+	require.NoError(t, err)
+	defer rc.Close()
+
+	content, err := io.ReadAll(rc)
+	require.NoError(t, err)
+	assert.Equal(t, "public class MyController { }", string(content))
+}
+
 func TestMetadataRetrieveMissingFlags(t *testing.T) {
 	client, err := metadata.New(metadata.ClientConfig{
 		InstanceURL: "https://test.salesforce.com",
@@ -254,6 +314,11 @@ func TestMetadataRetrieveMissingFlags(t *testing.T) {
 			args:    []string{"retrieve", "--type", "ApexClass"},
 			wantErr: "--output is required",
 		},
+		{
+			name:    "output and zip",
+			args:    []string{"retrieve", "--type", "ApexClass", "--output", "./src", "--zip", "src.zip"},
+			wantErr: "cannot be used together",
+		},
 	}
 
 	for _, tt := range tests {
@@ -316,7 +381,7 @@ func TestMetadataDeploy(t *testing.T) {
 	opts.SetMetadataClient(client)
 
 	cmd := NewCommand(opts)
-	cmd.SetArgs([]string{"deploy", "--source", tmpDir})
+	cmd.SetArgs([]string{"deploy", "--source", tmpDir, "--yes"})
 	cmd.SetOut(stdout)
 
 	err = cmd.Execute()
@@ -380,6 +445,57 @@ func TestMetadataDeployCheckOnly(t *testing.T) {
 	assert.Contains(t, output, "Validating")
 }
 
+func TestMetadataDeployDryRun(t *testing.T) {
+	var checkOnly bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			var request metadata.DeployRequest
+			_ = json.NewDecoder(r.Body).Decode(&request)
+			checkOnly = request.DeployOptions.CheckOnly
+
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(metadata.DeployResult{
+				ID:        "0Af000000000001",
+				Status:    "Pending",
+				CheckOnly: true,
+			})
+		}
+	}))
+	defer server.Close()
+
+	client, err := metadata.New(metadata.ClientConfig{
+		InstanceURL: server.URL,
+		HTTPClient:  server.Client(),
+	})
+	require.NoError(t, err)
+
+	tmpDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(tmpDir, "classes"), 0755))
+	require.NoError(t, os.WriteFile(
+		filepath.Join(tmpDir, "classes", "MyClass.cls"),
+		[]byte("public class MyClass {}"),
+		0644,
+	))
+
+	stdout := &bytes.Buffer{}
+	opts := &root.Options{
+		Output: "table",
+		DryRun: true,
+		Stdout: stdout,
+		Stderr: &bytes.Buffer{},
+	}
+	opts.SetMetadataClient(client)
+
+	cmd := NewCommand(opts)
+	cmd.SetArgs([]string{"deploy", "--source", tmpDir})
+
+	err = cmd.Execute()
+	require.NoError(t, err)
+
+	assert.True(t, checkOnly)
+	assert.Contains(t, stdout.String(), "Dry run")
+}
+
 func TestMetadataDeployMissingSource(t *testing.T) {
 	client, err := metadata.New(metadata.ClientConfig{
 		InstanceURL: "https://test.salesforce.com",
@@ -423,3 +539,136 @@ func TestGetFileExtension(t *testing.T) {
 		})
 	}
 }
+
+func TestDisplayDeployResult_Filters(t *testing.T) {
+	result := &metadata.DeployResult{
+		ID:                       "0Af000000000001",
+		Done:                     true,
+		Success:                  false,
+		NumberComponentsDeployed: 2,
+		NumberComponentErrors:    1,
+		DeployDetails: &metadata.DeployDetails{
+			ComponentSuccesses: []metadata.ComponentResult{
+				{ComponentType: "ApexClass", FullName: "NewClass", FileName: "classes/NewClass.cls", Success: true, Created: true, Changed: true},
+				{ComponentType: "ApexClass", FullName: "SameClass", FileName: "classes/SameClass.cls", Success: true},
+			},
+			ComponentFailures: []metadata.ComponentResult{
+				{ComponentType: "ApexClass", FullName: "BrokenClass", FileName: "classes/BrokenClass.cls", Problem: "Variable does not exist: x", LineNumber: 12, ColumnNumber: 5},
+			},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		onlyErrors  bool
+		onlyChanges bool
+		wantStdout  []string
+		wantStderr  []string
+		notStdout   []string
+		notStderr   []string
+	}{
+		{
+			name:       "all components",
+			wantStdout: []string{"Created", "NewClass"},
+			wantStderr: []string{"classes/BrokenClass.cls:12:5: Variable does not exist: x"},
+			notStdout:  []string{"SameClass"},
+		},
+		{
+			name:       "only errors",
+			onlyErrors: true,
+			wantStderr: []string{"classes/BrokenClass.cls:12:5"},
+			notStdout:  []string{"NewClass"},
+		},
+		{
+			name:        "only changes",
+			onlyChanges: true,
+			wantStdout:  []string{"NewClass"},
+			notStderr:   []string{"BrokenClass"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stdout := &bytes.Buffer{}
+			stderr := &bytes.Buffer{}
+			opts := &root.Options{
+				Output:  "table",
+				NoColor: true,
+				Stdout:  stdout,
+				Stderr:  stderr,
+			}
+
+			err := displayDeployResult(opts, result, tt.onlyErrors, tt.onlyChanges)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "1 component error(s)")
+
+			for _, want := range tt.wantStdout {
+				assert.Contains(t, stdout.String(), want)
+			}
+			for _, want := range tt.wantStderr {
+				assert.Contains(t, stderr.String(), want)
+			}
+			for _, missing := range tt.notStdout {
+				assert.NotContains(t, stdout.String(), missing)
+			}
+			for _, missing := range tt.notStderr {
+				assert.NotContains(t, stderr.String(), missing)
+			}
+		})
+	}
+}
+
+func TestFormatComponentFailure(t *testing.T) {
+	tests := []struct {
+		name    string
+		failure metadata.ComponentResult
+		want    string
+	}{
+		{
+			name:    "with file and line",
+			failure: metadata.ComponentResult{FileName: "classes/A.cls", LineNumber: 3, ColumnNumber: 7, Problem: "Unexpected token"},
+			want:    "classes/A.cls:3:7: Unexpected token",
+		},
+		{
+			name:    "without file",
+			failure: metadata.ComponentResult{ComponentType: "CustomObject", FullName: "Invoice__c", Problem: "Field is required"},
+			want:    "CustomObject.Invoice__c: Field is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, formatComponentFailure(tt.failure))
+		})
+	}
+}
+
+func TestMetadataDeployFilterFlags(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{"both filters", []string{"deploy", "--source", "./src", "--wait", "--only-errors", "--only-changes"}, "cannot be used together"},
+		{"filter without wait", []string{"deploy", "--source", "./src", "--only-errors"}, "require --wait"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := &root.Options{
+				Output: "table",
+				Stdout: &bytes.Buffer{},
+				Stderr: &bytes.Buffer{},
+			}
+
+			cmd := NewCommand(opts)
+			cmd.SetArgs(tt.args)
+			cmd.SetOut(&bytes.Buffer{})
+			cmd.SetErr(&bytes.Buffer{})
+
+			err := cmd.Execute()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
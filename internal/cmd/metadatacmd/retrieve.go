@@ -1,10 +1,12 @@
 package metadatacmd
 
 import (
+	"archive/zip"
 	"context"
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 
 	"github.com/spf13/cobra"
 
@@ -16,6 +18,7 @@ func newRetrieveCommand(opts *root.Options) *cobra.Command {
 		metadataType string
 		name         string
 		outputDir    string
+		zipFile      string
 	)
 
 	cmd := &cobra.Command{
@@ -28,17 +31,27 @@ Supported types for direct retrieve:
 
 For complex retrieves with package.xml, use the official Salesforce CLI (sf).
 
+Use --zip instead of --output to write the retrieved files into a zip archive
+rather than a directory, e.g. for backups.
+
 Examples:
   sfdc metadata retrieve --type ApexClass --name MyController --output ./src
   sfdc metadata retrieve --type ApexClass --output ./src  # all classes
-  sfdc metadata retrieve --type ApexTrigger --output ./src`,
+  sfdc metadata retrieve --type ApexTrigger --output ./src
+  sfdc metadata retrieve --type ApexClass --zip classes.zip`,
 		Args: cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if metadataType == "" {
 				return fmt.Errorf("--type is required")
 			}
+			if outputDir != "" && zipFile != "" {
+				return fmt.Errorf("--output and --zip cannot be used together")
+			}
+			if zipFile != "" {
+				return runRetrieveZip(cmd.Context(), opts, metadataType, name, zipFile)
+			}
 			if outputDir == "" {
-				return fmt.Errorf("--output is required")
+				return fmt.Errorf("--output is required (or --zip to write a zip archive)")
 			}
 			return runRetrieve(cmd.Context(), opts, metadataType, name, outputDir)
 		},
@@ -46,7 +59,9 @@ Examples:
 
 	cmd.Flags().StringVar(&metadataType, "type", "", "Metadata type (required)")
 	cmd.Flags().StringVar(&name, "name", "", "Component name (optional, retrieves all if not specified)")
-	cmd.Flags().StringVarP(&outputDir, "output", "f", "", "Output directory (required)")
+	cmd.Flags().StringVarP(&outputDir, "output", "f", "", "Output directory (required unless --zip is set)")
+	cmd.Flags().StringVar(&zipFile, "zip", "", "Write retrieved files to a zip archive instead of a directory")
+	cmd.Flags().Float64Var(&opts.MaxRPS, "max-rps", 0, "Send at most this many API requests per second (0 for the max_rps setting)")
 
 	return cmd
 }
@@ -110,6 +125,79 @@ func runRetrieve(ctx context.Context, opts *root.Options, metadataType, name, ou
 	return nil
 }
 
+func runRetrieveZip(ctx context.Context, opts *root.Options, metadataType, name, zipFile string) error {
+	client, err := opts.MetadataClient()
+	if err != nil {
+		return fmt.Errorf("failed to create metadata client: %w", err)
+	}
+
+	v := opts.View()
+
+	var components map[string][]byte
+	if name != "" {
+		v.Info("Retrieving %s: %s", metadataType, name)
+
+		content, err := client.Retrieve(ctx, metadataType, name)
+		if err != nil {
+			return fmt.Errorf("failed to retrieve: %w", err)
+		}
+		components = map[string][]byte{name: content}
+	} else {
+		v.Info("Retrieving all %s components...", metadataType)
+
+		components, err = client.RetrieveAll(ctx, metadataType)
+		if err != nil {
+			return fmt.Errorf("failed to retrieve: %w", err)
+		}
+		if len(components) == 0 {
+			v.Info("No %s components found to retrieve", metadataType)
+			return nil
+		}
+	}
+
+	if err := writeComponentsZip(zipFile, components, getFileExtension(metadataType)); err != nil {
+		return fmt.Errorf("failed to write zip: %w", err)
+	}
+
+	v.Success("Retrieved %d component(s) to %s", len(components), zipFile)
+	return nil
+}
+
+// writeComponentsZip writes each component as an entry in a new zip archive,
+// streaming directly to the file.
+func writeComponentsZip(path string, components map[string][]byte, ext string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(components))
+	for name := range components {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	zw := zip.NewWriter(f)
+	for _, name := range names {
+		w, err := zw.Create(name + ext)
+		if err != nil {
+			_ = f.Close()
+			return err
+		}
+		if _, err := w.Write(components[name]); err != nil {
+			_ = f.Close()
+			return err
+		}
+	}
+
+	if err := zw.Close(); err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
 func getFileExtension(metadataType string) string {
 	switch metadataType {
 	case "ApexClass":
@@ -11,29 +11,78 @@ import (
 )
 
 func newFieldsCommand(opts *root.Options) *cobra.Command {
-	var requiredOnly bool
+	var (
+		filter   fieldFilter
+		helpText bool
+	)
 
 	cmd := &cobra.Command{
 		Use:   "fields <object>",
 		Short: "List fields for an object",
 		Long: `List all fields for a Salesforce object.
 
+Use --sensitive-only to list fields that have a data classification
+(security classification or compliance category) set, for data-privacy audits.
+
+Use --filterable, --sortable, or --groupable to list only fields that can be
+used in a SOQL WHERE, ORDER BY, or GROUP BY clause. Long text areas and some
+compound fields, for example, cannot be filtered.
+
 Examples:
   sfdc object fields Account
   sfdc object fields Account --required-only
+  sfdc object fields Contact --help-text
+  sfdc object fields Contact --sensitive-only
+  sfdc object fields Account --filterable
+  sfdc object fields Opportunity --groupable --sortable
   sfdc object fields Contact -o json`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runFields(cmd.Context(), opts, args[0], requiredOnly)
+			return runFields(cmd.Context(), opts, args[0], filter, helpText)
 		},
 	}
 
-	cmd.Flags().BoolVar(&requiredOnly, "required-only", false, "Show only required fields")
+	cmd.Flags().BoolVar(&filter.requiredOnly, "required-only", false, "Show only required fields")
+	cmd.Flags().BoolVar(&filter.sensitiveOnly, "sensitive-only", false, "Show only fields with a data classification set")
+	cmd.Flags().BoolVar(&filter.filterable, "filterable", false, "Show only fields usable in a WHERE clause")
+	cmd.Flags().BoolVar(&filter.sortable, "sortable", false, "Show only fields usable in an ORDER BY clause")
+	cmd.Flags().BoolVar(&filter.groupable, "groupable", false, "Show only fields usable in a GROUP BY clause")
+	cmd.Flags().BoolVar(&helpText, "help-text", false, "Show inline help text for each field")
 
 	return cmd
 }
 
-func runFields(ctx context.Context, opts *root.Options, objectName string, requiredOnly bool) error {
+// fieldFilter selects which fields are listed; all set criteria must match.
+type fieldFilter struct {
+	requiredOnly  bool
+	sensitiveOnly bool
+	filterable    bool
+	sortable      bool
+	groupable     bool
+}
+
+// matches reports whether the field satisfies every enabled criterion.
+func (ff fieldFilter) matches(f api.Field) bool {
+	// Required = not nillable AND createable (can be set on create)
+	if ff.requiredOnly && (f.Nillable || !f.Createable) {
+		return false
+	}
+	if ff.sensitiveOnly && !f.IsSensitive() {
+		return false
+	}
+	if ff.filterable && !f.Filterable {
+		return false
+	}
+	if ff.sortable && !f.Sortable {
+		return false
+	}
+	if ff.groupable && !f.Groupable {
+		return false
+	}
+	return true
+}
+
+func runFields(ctx context.Context, opts *root.Options, objectName string, filter fieldFilter, helpText bool) error {
 	client, err := opts.APIClient()
 	if err != nil {
 		return fmt.Errorf("failed to create API client: %w", err)
@@ -47,16 +96,11 @@ func runFields(ctx context.Context, opts *root.Options, objectName string, requi
 	v := opts.View()
 
 	// Filter fields if needed
-	fields := desc.Fields
-	if requiredOnly {
-		filtered := make([]api.Field, 0)
-		for _, f := range fields {
-			// Required = not nillable AND createable (can be set on create)
-			if !f.Nillable && f.Createable {
-				filtered = append(filtered, f)
-			}
+	fields := make([]api.Field, 0, len(desc.Fields))
+	for _, f := range desc.Fields {
+		if filter.matches(f) {
+			fields = append(fields, f)
 		}
-		fields = filtered
 	}
 
 	if opts.Output == "json" {
@@ -69,6 +113,12 @@ func runFields(ctx context.Context, opts *root.Options, objectName string, requi
 	}
 
 	headers := []string{"Name", "Label", "Type", "Length", "Required", "Custom"}
+	if filter.sensitiveOnly {
+		headers = append(headers, "Classification", "Compliance")
+	}
+	if helpText {
+		headers = append(headers, "Help Text")
+	}
 	rows := make([][]string, 0, len(fields))
 
 	for _, f := range fields {
@@ -78,14 +128,21 @@ func runFields(ctx context.Context, opts *root.Options, objectName string, requi
 			length = fmt.Sprintf("%d", f.Length)
 		}
 
-		rows = append(rows, []string{
+		row := []string{
 			f.Name,
 			f.Label,
 			f.Type,
 			length,
 			boolToYesNo(isRequired),
 			boolToYesNo(f.Custom),
-		})
+		}
+		if filter.sensitiveOnly {
+			row = append(row, f.SecurityClassification, f.ComplianceGroup)
+		}
+		if helpText {
+			row = append(row, f.InlineHelpText)
+		}
+		rows = append(rows, row)
 	}
 
 	if err := v.Table(headers, rows); err != nil {
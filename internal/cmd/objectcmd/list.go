@@ -3,36 +3,48 @@ package objectcmd
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strconv"
 
 	"github.com/spf13/cobra"
 
+	"github.com/open-cli-collective/salesforce-cli/api"
 	"github.com/open-cli-collective/salesforce-cli/internal/cmd/root"
 )
 
 func newListCommand(opts *root.Options) *cobra.Command {
-	var customOnly bool
+	var (
+		customOnly bool
+		withCounts bool
+	)
 
 	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "List all objects in the org",
 		Long: `List all Salesforce objects (SObjects) in the org.
 
+With --with-counts, each queryable object is shown with its approximate record
+count, largest first, for an overview of where the org's data is. Counts come
+from storage statistics and may lag recent changes.
+
 Examples:
   sfdc object list
   sfdc object list --custom-only
+  sfdc object list --with-counts
   sfdc object list -o json`,
 		Args: cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runList(cmd.Context(), opts, customOnly)
+			return runList(cmd.Context(), opts, customOnly, withCounts)
 		},
 	}
 
 	cmd.Flags().BoolVar(&customOnly, "custom-only", false, "Show only custom objects")
+	cmd.Flags().BoolVar(&withCounts, "with-counts", false, "Show approximate record counts, largest first")
 
 	return cmd
 }
 
-func runList(ctx context.Context, opts *root.Options, customOnly bool) error {
+func runList(ctx context.Context, opts *root.Options, customOnly, withCounts bool) error {
 	client, err := opts.APIClient()
 	if err != nil {
 		return fmt.Errorf("failed to create API client: %w", err)
@@ -62,6 +74,10 @@ func runList(ctx context.Context, opts *root.Options, customOnly bool) error {
 		}
 	}
 
+	if withCounts {
+		return renderObjectCounts(ctx, opts, client, objects)
+	}
+
 	if opts.Output == "json" {
 		return v.JSON(objects)
 	}
@@ -93,6 +109,71 @@ func runList(ctx context.Context, opts *root.Options, customOnly bool) error {
 	return nil
 }
 
+// objectCount is an object with its approximate record count.
+type objectCount struct {
+	Name        string `json:"name"`
+	Label       string `json:"label"`
+	Custom      bool   `json:"custom"`
+	RecordCount int    `json:"recordCount"`
+}
+
+// renderObjectCounts shows the queryable objects with their record counts,
+// largest first.
+func renderObjectCounts(ctx context.Context, opts *root.Options, client *api.Client, objects []api.SObjectDescribe) error {
+	names := make([]string, 0, len(objects))
+	for _, obj := range objects {
+		if obj.Queryable {
+			names = append(names, obj.Name)
+		}
+	}
+
+	counts, err := client.GetRecordCounts(ctx, names)
+	if err != nil {
+		return fmt.Errorf("failed to get record counts: %w", err)
+	}
+
+	result := make([]objectCount, 0, len(names))
+	total := 0
+	for _, obj := range objects {
+		if !obj.Queryable {
+			continue
+		}
+		result = append(result, objectCount{
+			Name:        obj.Name,
+			Label:       obj.Label,
+			Custom:      obj.Custom,
+			RecordCount: counts[obj.Name],
+		})
+		total += counts[obj.Name]
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].RecordCount != result[j].RecordCount {
+			return result[i].RecordCount > result[j].RecordCount
+		}
+		return result[i].Name < result[j].Name
+	})
+
+	v := opts.View()
+
+	if opts.Output == "json" {
+		return v.JSON(result)
+	}
+
+	headers := []string{"Name", "Label", "Custom", "Records"}
+	rows := make([][]string, 0, len(result))
+	for _, oc := range result {
+		rows = append(rows, []string{oc.Name, oc.Label, boolToYesNo(oc.Custom), strconv.Itoa(oc.RecordCount)})
+	}
+
+	if err := v.Table(headers, rows); err != nil {
+		return err
+	}
+
+	v.Info("\n%d queryable object(s), about %d record(s)", len(result), total)
+	return nil
+}
+
 // boolToYesNo converts a boolean to "Yes" or "No" for display.
 func boolToYesNo(b bool) string {
 	if b {
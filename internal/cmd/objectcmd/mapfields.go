@@ -0,0 +1,388 @@
+package objectcmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/salesforce-cli/api"
+	"github.com/open-cli-collective/salesforce-cli/internal/cmd/root"
+	"github.com/open-cli-collective/salesforce-cli/internal/csvmap"
+)
+
+// Field mapping statuses
+const (
+	mappingOK             = "ok"
+	mappingTypeMismatch   = "type mismatch"
+	mappingLengthMismatch = "length mismatch"
+	mappingReadOnly       = "target read-only"
+	mappingSourceOnly     = "source only"
+	mappingTargetOnly     = "target only"
+)
+
+// typeFamilies groups field types whose values can be loaded into each other.
+var typeFamilies = map[string]string{
+	"string":          "text",
+	"textarea":        "text",
+	"email":           "text",
+	"phone":           "text",
+	"url":             "text",
+	"picklist":        "text",
+	"combobox":        "text",
+	"encryptedstring": "text",
+	"double":          "number",
+	"currency":        "number",
+	"percent":         "number",
+	"int":             "number",
+	"long":            "number",
+	"id":              "reference",
+	"reference":       "reference",
+}
+
+// fieldMapping is one row of a field mapping between two objects.
+type fieldMapping struct {
+	SourceField  string `json:"sourceField,omitempty"`
+	TargetField  string `json:"targetField,omitempty"`
+	Status       string `json:"status"`
+	SourceType   string `json:"sourceType,omitempty"`
+	TargetType   string `json:"targetType,omitempty"`
+	SourceLength int    `json:"sourceLength,omitempty"`
+	TargetLength int    `json:"targetLength,omitempty"`
+}
+
+func newMapFieldsCommand(opts *root.Options) *cobra.Command {
+	var out string
+
+	cmd := &cobra.Command{
+		Use:   "map-fields <source-object>[@org] <target-object>[@org]",
+		Short: "Map fields between two objects",
+		Long: `Compare the fields of two objects side by side, for planning a data migration.
+
+Each object is described in the org of the command, or in another org
+profile named after an @, so objects can be compared across orgs.
+
+Fields are matched by API name, then by label. Each row has a status:
+  ok               Types are compatible
+  type mismatch    Types cannot be loaded into each other
+  length mismatch  The target field is shorter than the source field
+  target read-only The target field cannot be set on insert
+  source only      No matching field on the target object
+  target only      No matching field on the source object
+
+With --out, the mapping is written to a file. A .json file is a mapping for
+'sfdc bulk import --mapping': it loads each source field's column (as
+exported from the source object) into the matching target field, leaving
+out Id and fields with another status than ok or length mismatch. Any other
+file is written as CSV, with a row for every field, to review or edit.
+
+Examples:
+  sfdc object map-fields Account Lead
+  sfdc object map-fields Account@prod Lead@dev1
+  sfdc object map-fields Account@prod Lead@dev1 --out map.json
+  sfdc object map-fields Account Lead --out map.csv
+  sfdc object map-fields Contact MyContact__c -o json`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			source, err := parseObjectOrg(args[0])
+			if err != nil {
+				return err
+			}
+			target, err := parseObjectOrg(args[1])
+			if err != nil {
+				return err
+			}
+			return runMapFields(cmd.Context(), opts, source, target, out)
+		},
+	}
+
+	cmd.Flags().StringVar(&out, "out", "", "Write the mapping to a file: bulk import mapping (.json) or CSV")
+
+	return cmd
+}
+
+// objectOrg is an object and the org profile it is described in, empty for
+// the command's org.
+type objectOrg struct {
+	object string
+	org    string
+}
+
+// parseObjectOrg parses Object or Object@org.
+func parseObjectOrg(arg string) (objectOrg, error) {
+	i := strings.LastIndex(arg, "@")
+	if i < 0 {
+		return objectOrg{object: arg}, nil
+	}
+	o := objectOrg{object: arg[:i], org: arg[i+1:]}
+	if o.object == "" || o.org == "" {
+		return objectOrg{}, fmt.Errorf("invalid object %q: expected Object or Object@org", arg)
+	}
+	return o, nil
+}
+
+// describeIn describes an object in its org.
+func describeIn(ctx context.Context, opts *root.Options, o objectOrg) (*api.SObjectDescribe, error) {
+	var describe *api.SObjectDescribe
+	err := opts.WithOrg(o.org, func() error {
+		client, err := opts.APIClient()
+		if err != nil {
+			return fmt.Errorf("failed to create API client: %w", err)
+		}
+		describe, err = client.DescribeSObject(ctx, o.object)
+		if err != nil {
+			return fmt.Errorf("failed to describe %s: %w", o.object, err)
+		}
+		return nil
+	})
+	return describe, err
+}
+
+func runMapFields(ctx context.Context, opts *root.Options, sourceObj, targetObj objectOrg, out string) error {
+	source, err := describeIn(ctx, opts, sourceObj)
+	if err != nil {
+		return err
+	}
+	target, err := describeIn(ctx, opts, targetObj)
+	if err != nil {
+		return err
+	}
+
+	mappings := mapFields(source.Fields, target.Fields)
+
+	v := opts.View()
+
+	if out != "" {
+		if strings.EqualFold(filepath.Ext(out), ".json") {
+			return writeImportMapping(opts, mappings, out)
+		}
+		data, err := mappingsCSV(mappings)
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(out, data, 0644); err != nil {
+			return fmt.Errorf("failed to write file: %w", err)
+		}
+		v.Success("Wrote %d field mapping(s) to %s", len(mappings), out)
+		return nil
+	}
+
+	if opts.Output == "json" {
+		return v.JSON(mappings)
+	}
+
+	headers := []string{"Source Field", "Target Field", "Status", "Source Type", "Target Type", "Source Length", "Target Length"}
+	rows := make([][]string, 0, len(mappings))
+	for _, m := range mappings {
+		rows = append(rows, m.row())
+	}
+
+	if err := v.Table(headers, rows); err != nil {
+		return err
+	}
+
+	mismatches := 0
+	for _, m := range mappings {
+		if m.Status != mappingOK {
+			mismatches++
+		}
+	}
+	v.Info("\n%d field(s), %d need attention", len(mappings), mismatches)
+
+	return nil
+}
+
+// importMapping returns the bulk import mapping of the fields whose values
+// can be loaded into the target: matched fields other than Id that are ok
+// or may be truncated.
+func importMapping(mappings []fieldMapping) *csvmap.Mapping {
+	m := &csvmap.Mapping{}
+	for _, fm := range mappings {
+		if fm.SourceField == "" || fm.TargetField == "" || strings.EqualFold(fm.TargetField, "Id") {
+			continue
+		}
+		if fm.Status != mappingOK && fm.Status != mappingLengthMismatch {
+			continue
+		}
+		m.Fields = append(m.Fields, csvmap.Field{Field: fm.TargetField, Column: fm.SourceField})
+	}
+	return m
+}
+
+// writeImportMapping writes the bulk import mapping of mappings to out.
+func writeImportMapping(opts *root.Options, mappings []fieldMapping, out string) error {
+	m := importMapping(mappings)
+	if err := m.Validate(); err != nil {
+		return fmt.Errorf("no fields can be mapped for import: %w", err)
+	}
+
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode mapping: %w", err)
+	}
+	if err := os.WriteFile(out, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+
+	v := opts.View()
+	v.Success("Wrote a bulk import mapping of %d field(s) to %s", len(m.Fields), out)
+	truncated := 0
+	for _, fm := range mappings {
+		if fm.Status == mappingLengthMismatch {
+			truncated++
+		}
+	}
+	if truncated > 0 {
+		v.Warning("%d mapped field(s) are shorter in the target; longer values will fail to load", truncated)
+	}
+	return nil
+}
+
+// mapFields matches source fields to target fields by name, then by label.
+// Matched fields come first in source order, followed by unmatched target
+// fields sorted by name.
+func mapFields(source, target []api.Field) []fieldMapping {
+	byName := make(map[string]int, len(target))
+	byLabel := make(map[string]int, len(target))
+	for i, f := range target {
+		byName[strings.ToLower(f.Name)] = i
+		if _, ok := byLabel[strings.ToLower(f.Label)]; !ok {
+			byLabel[strings.ToLower(f.Label)] = i
+		}
+	}
+
+	matched := make(map[int]bool, len(target))
+	find := func(index map[string]int, key string) (int, bool) {
+		i, ok := index[strings.ToLower(key)]
+		if !ok || matched[i] {
+			return 0, false
+		}
+		return i, true
+	}
+
+	mappings := make([]fieldMapping, 0, len(source)+len(target))
+	var unmatchedSource []api.Field
+	for _, sf := range source {
+		i, ok := find(byName, sf.Name)
+		if !ok {
+			unmatchedSource = append(unmatchedSource, sf)
+			continue
+		}
+		matched[i] = true
+		mappings = append(mappings, compareFields(sf, target[i]))
+	}
+
+	// Label matches only for fields without a name match
+	for _, sf := range unmatchedSource {
+		i, ok := find(byLabel, sf.Label)
+		if !ok {
+			mappings = append(mappings, fieldMapping{
+				SourceField:  sf.Name,
+				Status:       mappingSourceOnly,
+				SourceType:   sf.Type,
+				SourceLength: sf.Length,
+			})
+			continue
+		}
+		matched[i] = true
+		mappings = append(mappings, compareFields(sf, target[i]))
+	}
+
+	var targetOnly []fieldMapping
+	for i, tf := range target {
+		if matched[i] {
+			continue
+		}
+		targetOnly = append(targetOnly, fieldMapping{
+			TargetField:  tf.Name,
+			Status:       mappingTargetOnly,
+			TargetType:   tf.Type,
+			TargetLength: tf.Length,
+		})
+	}
+	sort.Slice(targetOnly, func(i, j int) bool {
+		return targetOnly[i].TargetField < targetOnly[j].TargetField
+	})
+
+	return append(mappings, targetOnly...)
+}
+
+// compareFields returns the mapping of a source field onto a target field.
+func compareFields(source, target api.Field) fieldMapping {
+	m := fieldMapping{
+		SourceField:  source.Name,
+		TargetField:  target.Name,
+		Status:       mappingOK,
+		SourceType:   source.Type,
+		TargetType:   target.Type,
+		SourceLength: source.Length,
+		TargetLength: target.Length,
+	}
+
+	switch {
+	case !compatibleTypes(source.Type, target.Type):
+		m.Status = mappingTypeMismatch
+	case !target.Createable && target.Name != "Id":
+		m.Status = mappingReadOnly
+	case source.Length > 0 && target.Length > 0 && target.Length < source.Length:
+		m.Status = mappingLengthMismatch
+	}
+
+	return m
+}
+
+// compatibleTypes reports whether values of one field type can be loaded
+// into a field of the other type.
+func compatibleTypes(a, b string) bool {
+	if a == b {
+		return true
+	}
+	familyA, okA := typeFamilies[a]
+	familyB, okB := typeFamilies[b]
+	return okA && okB && familyA == familyB
+}
+
+// row returns the mapping as table or CSV cells.
+func (m fieldMapping) row() []string {
+	return []string{
+		m.SourceField,
+		m.TargetField,
+		m.Status,
+		m.SourceType,
+		m.TargetType,
+		formatLength(m.SourceLength),
+		formatLength(m.TargetLength),
+	}
+}
+
+func formatLength(n int) string {
+	if n == 0 {
+		return ""
+	}
+	return strconv.Itoa(n)
+}
+
+// mappingsCSV renders the mappings as CSV.
+func mappingsCSV(mappings []fieldMapping) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+
+	records := [][]string{{"SourceField", "TargetField", "Status", "SourceType", "TargetType", "SourceLength", "TargetLength"}}
+	for _, m := range mappings {
+		records = append(records, m.row())
+	}
+
+	if err := w.WriteAll(records); err != nil {
+		return nil, fmt.Errorf("failed to write CSV: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
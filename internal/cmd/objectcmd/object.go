@@ -17,12 +17,14 @@ func NewCommand(opts *root.Options) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "object",
 		Short: "Work with Salesforce objects",
-		Long:  "List, describe, and inspect Salesforce objects and their fields.",
+		Long:  "List, describe, and inspect Salesforce objects, their fields, and their picklist values, and map fields between objects.",
 	}
 
 	cmd.AddCommand(newListCommand(opts))
 	cmd.AddCommand(newDescribeCommand(opts))
 	cmd.AddCommand(newFieldsCommand(opts))
+	cmd.AddCommand(newMapFieldsCommand(opts))
+	cmd.AddCommand(newPicklistsCommand(opts))
 
 	return cmd
 }
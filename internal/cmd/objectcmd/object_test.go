@@ -5,6 +5,9 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -12,6 +15,7 @@ import (
 
 	"github.com/open-cli-collective/salesforce-cli/api"
 	"github.com/open-cli-collective/salesforce-cli/internal/cmd/root"
+	"github.com/open-cli-collective/salesforce-cli/internal/csvmap"
 )
 
 func TestListCommand(t *testing.T) {
@@ -76,6 +80,61 @@ func TestListCommand(t *testing.T) {
 	})
 }
 
+func TestListCommand_WithCounts(t *testing.T) {
+	sobjectsResp := api.SObjectsResponse{
+		SObjects: []api.SObjectDescribe{
+			{Name: "Account", Label: "Account", Queryable: true},
+			{Name: "AccountFeed", Label: "Account Feed", Queryable: false},
+			{Name: "Contact", Label: "Contact", Queryable: true},
+			{Name: "MyCustom__c", Label: "My Custom", Custom: true, Queryable: true},
+		},
+	}
+
+	var countQuery string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if strings.HasSuffix(r.URL.Path, "/limits/recordCount") {
+			countQuery = r.URL.Query().Get("sObjects")
+			_ = json.NewEncoder(w).Encode(api.RecordCountResponse{
+				SObjects: []api.RecordCount{
+					{Name: "Account", Count: 120},
+					{Name: "Contact", Count: 4500},
+				},
+			})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(sobjectsResp)
+	}))
+	defer server.Close()
+
+	client, err := api.New(api.ClientConfig{
+		InstanceURL: server.URL,
+		HTTPClient:  server.Client(),
+	})
+	require.NoError(t, err)
+
+	stdout := &bytes.Buffer{}
+	opts := &root.Options{
+		Output: "json",
+		Stdout: stdout,
+		Stderr: &bytes.Buffer{},
+	}
+	opts.SetAPIClient(client)
+
+	cmd := newListCommand(opts)
+	cmd.SetArgs([]string{"--with-counts"})
+	require.NoError(t, cmd.Execute())
+
+	assert.Equal(t, "Account,Contact,MyCustom__c", countQuery)
+
+	var got []objectCount
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &got))
+	assert.Equal(t, []objectCount{
+		{Name: "Contact", Label: "Contact", RecordCount: 4500},
+		{Name: "Account", Label: "Account", RecordCount: 120},
+		{Name: "MyCustom__c", Label: "My Custom", Custom: true, RecordCount: 0},
+	}, got)
+}
 func TestDescribeCommand(t *testing.T) {
 	describe := api.SObjectDescribe{
 		Name:        "Account",
@@ -238,3 +297,437 @@ func TestFieldsCommand_JSONOutput(t *testing.T) {
 	require.NoError(t, err)
 	assert.Len(t, fields, 2)
 }
+
+func TestFieldsCommand_Sensitive(t *testing.T) {
+	describe := api.SObjectDescribe{
+		Name: "Contact",
+		Fields: []api.Field{
+			{Name: "Id", Label: "Contact ID", Type: "id"},
+			{Name: "Email", Label: "Email", Type: "email", InlineHelpText: "Primary work email", SecurityClassification: "Confidential", ComplianceGroup: "PII;GDPR"},
+			{Name: "Title", Label: "Title", Type: "string", InlineHelpText: "Job title"},
+		},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(describe)
+	}))
+	defer server.Close()
+
+	client, err := api.New(api.ClientConfig{
+		InstanceURL: server.URL,
+		HTTPClient:  server.Client(),
+	})
+	require.NoError(t, err)
+
+	t.Run("sensitive only", func(t *testing.T) {
+		stdout := &bytes.Buffer{}
+		opts := &root.Options{
+			Output: "table",
+			Stdout: stdout,
+			Stderr: &bytes.Buffer{},
+		}
+		opts.SetAPIClient(client)
+
+		cmd := newFieldsCommand(opts)
+		cmd.SetArgs([]string{"Contact", "--sensitive-only"})
+		cmd.SetOut(stdout)
+
+		err := cmd.Execute()
+		require.NoError(t, err)
+
+		output := stdout.String()
+		assert.Contains(t, output, "Email")
+		assert.Contains(t, output, "Confidential")
+		assert.Contains(t, output, "PII;GDPR")
+		assert.NotContains(t, output, "Title")
+		assert.Contains(t, output, "1 field")
+	})
+
+	t.Run("help text", func(t *testing.T) {
+		stdout := &bytes.Buffer{}
+		opts := &root.Options{
+			Output: "table",
+			Stdout: stdout,
+			Stderr: &bytes.Buffer{},
+		}
+		opts.SetAPIClient(client)
+
+		cmd := newFieldsCommand(opts)
+		cmd.SetArgs([]string{"Contact", "--help-text"})
+		cmd.SetOut(stdout)
+
+		err := cmd.Execute()
+		require.NoError(t, err)
+
+		output := stdout.String()
+		assert.Contains(t, output, "Help Text")
+		assert.Contains(t, output, "Primary work email")
+		assert.Contains(t, output, "Job title")
+	})
+}
+
+func TestFieldsCommand_QueryCapabilities(t *testing.T) {
+	describe := api.SObjectDescribe{
+		Name: "Account",
+		Fields: []api.Field{
+			{Name: "Name", Type: "string", Filterable: true, Sortable: true, Groupable: true},
+			{Name: "Description", Type: "textarea"},
+			{Name: "AnnualRevenue", Type: "currency", Filterable: true, Sortable: true},
+		},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(describe)
+	}))
+	defer server.Close()
+
+	client, err := api.New(api.ClientConfig{
+		InstanceURL: server.URL,
+		HTTPClient:  server.Client(),
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		flags       []string
+		wantContain []string
+		wantMissing []string
+	}{
+		{
+			name:        "filterable",
+			flags:       []string{"--filterable"},
+			wantContain: []string{"Name", "AnnualRevenue", "2 field(s)"},
+			wantMissing: []string{"Description"},
+		},
+		{
+			name:        "groupable and sortable",
+			flags:       []string{"--groupable", "--sortable"},
+			wantContain: []string{"Name", "1 field(s)"},
+			wantMissing: []string{"Description", "AnnualRevenue"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stdout := &bytes.Buffer{}
+			opts := &root.Options{
+				Output: "table",
+				Stdout: stdout,
+				Stderr: &bytes.Buffer{},
+			}
+			opts.SetAPIClient(client)
+
+			cmd := newFieldsCommand(opts)
+			cmd.SetArgs(append([]string{"Account"}, tt.flags...))
+			cmd.SetOut(stdout)
+
+			err := cmd.Execute()
+			require.NoError(t, err)
+
+			output := stdout.String()
+			for _, want := range tt.wantContain {
+				assert.Contains(t, output, want)
+			}
+			for _, missing := range tt.wantMissing {
+				assert.NotContains(t, output, missing)
+			}
+		})
+	}
+}
+
+func TestMapFields(t *testing.T) {
+	source := []api.Field{
+		{Name: "Id", Label: "Account ID", Type: "id", Length: 18},
+		{Name: "Name", Label: "Account Name", Type: "string", Length: 255, Createable: true},
+		{Name: "Phone", Label: "Phone", Type: "phone", Length: 40, Createable: true},
+		{Name: "Industry", Label: "Industry", Type: "picklist", Length: 255, Createable: true},
+		{Name: "AnnualRevenue", Label: "Annual Revenue", Type: "currency", Createable: true},
+		{Name: "Rating__c", Label: "Score", Type: "double", Createable: true},
+		{Name: "Site", Label: "Account Site", Type: "string", Length: 80, Createable: true},
+	}
+	target := []api.Field{
+		{Name: "Id", Label: "Lead ID", Type: "id", Length: 18},
+		{Name: "Name", Label: "Full Name", Type: "string", Length: 121},
+		{Name: "Phone", Label: "Phone", Type: "string", Length: 40, Createable: true},
+		{Name: "Industry", Label: "Industry", Type: "picklist", Length: 255, Createable: true},
+		{Name: "AnnualRevenue", Label: "Annual Revenue", Type: "boolean", Createable: true},
+		{Name: "Score__c", Label: "Score", Type: "double", Createable: true},
+		{Name: "Company", Label: "Company", Type: "string", Length: 255, Createable: true},
+	}
+
+	got := mapFields(source, target)
+
+	want := []fieldMapping{
+		{SourceField: "Id", TargetField: "Id", Status: mappingOK, SourceType: "id", TargetType: "id", SourceLength: 18, TargetLength: 18},
+		{SourceField: "Name", TargetField: "Name", Status: mappingReadOnly, SourceType: "string", TargetType: "string", SourceLength: 255, TargetLength: 121},
+		{SourceField: "Phone", TargetField: "Phone", Status: mappingOK, SourceType: "phone", TargetType: "string", SourceLength: 40, TargetLength: 40},
+		{SourceField: "Industry", TargetField: "Industry", Status: mappingOK, SourceType: "picklist", TargetType: "picklist", SourceLength: 255, TargetLength: 255},
+		{SourceField: "AnnualRevenue", TargetField: "AnnualRevenue", Status: mappingTypeMismatch, SourceType: "currency", TargetType: "boolean"},
+		{SourceField: "Rating__c", TargetField: "Score__c", Status: mappingOK, SourceType: "double", TargetType: "double"},
+		{SourceField: "Site", Status: mappingSourceOnly, SourceType: "string", SourceLength: 80},
+		{TargetField: "Company", Status: mappingTargetOnly, TargetType: "string", TargetLength: 255},
+	}
+	assert.Equal(t, want, got)
+}
+
+func TestMapFieldsCommand(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.Contains(r.URL.Path, "/sobjects/Account/describe"):
+			_ = json.NewEncoder(w).Encode(api.SObjectDescribe{Name: "Account", Fields: []api.Field{
+				{Name: "Name", Label: "Account Name", Type: "string", Length: 255, Createable: true},
+				{Name: "Site", Label: "Account Site", Type: "string", Length: 80, Createable: true},
+				{Name: "Ref__c", Label: "Reference", Type: "string", Length: 80, Createable: true},
+			}})
+		case strings.Contains(r.URL.Path, "/sobjects/Lead/describe"):
+			_ = json.NewEncoder(w).Encode(api.SObjectDescribe{Name: "Lead", Fields: []api.Field{
+				{Name: "Name", Label: "Full Name", Type: "string", Length: 121, Createable: true},
+				// An auto-number field: read-only and shorter than the source
+				{Name: "Ref__c", Label: "Reference", Type: "string", Length: 30},
+			}})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := api.New(api.ClientConfig{
+		InstanceURL: server.URL,
+		HTTPClient:  server.Client(),
+	})
+	require.NoError(t, err)
+
+	t.Run("table", func(t *testing.T) {
+		stdout := &bytes.Buffer{}
+		opts := &root.Options{
+			Output: "table",
+			Stdout: stdout,
+			Stderr: &bytes.Buffer{},
+		}
+		opts.SetAPIClient(client)
+
+		cmd := NewCommand(opts)
+		cmd.SetArgs([]string{"map-fields", "Account", "Lead"})
+		cmd.SetOut(stdout)
+
+		err := cmd.Execute()
+		require.NoError(t, err)
+
+		output := stdout.String()
+		assert.Contains(t, output, "length mismatch")
+		assert.Contains(t, output, "source only")
+		assert.Contains(t, output, "target read-only")
+		assert.Contains(t, output, "3 field(s), 3 need attention")
+	})
+
+	t.Run("csv file", func(t *testing.T) {
+		out := filepath.Join(t.TempDir(), "map.csv")
+		opts := &root.Options{
+			Output: "table",
+			Stdout: &bytes.Buffer{},
+			Stderr: &bytes.Buffer{},
+		}
+		opts.SetAPIClient(client)
+
+		cmd := NewCommand(opts)
+		cmd.SetArgs([]string{"map-fields", "Account", "Lead", "--out", out})
+
+		err := cmd.Execute()
+		require.NoError(t, err)
+
+		data, err := os.ReadFile(out)
+		require.NoError(t, err)
+		assert.Equal(t, "SourceField,TargetField,Status,SourceType,TargetType,SourceLength,TargetLength\n"+
+			"Name,Name,length mismatch,string,string,255,121\n"+
+			"Ref__c,Ref__c,target read-only,string,string,80,30\n"+
+			"Site,,source only,string,,80,\n", string(data))
+	})
+
+	t.Run("bulk import mapping", func(t *testing.T) {
+		out := filepath.Join(t.TempDir(), "map.json")
+		stderr := &bytes.Buffer{}
+		opts := &root.Options{
+			Output: "table",
+			Stdout: &bytes.Buffer{},
+			Stderr: stderr,
+		}
+		opts.SetAPIClient(client)
+
+		cmd := NewCommand(opts)
+		cmd.SetArgs([]string{"map-fields", "Account", "Lead", "--out", out})
+
+		err := cmd.Execute()
+		require.NoError(t, err)
+
+		data, err := os.ReadFile(out)
+		require.NoError(t, err)
+		m, err := csvmap.Parse(data)
+		require.NoError(t, err)
+		assert.Equal(t, []csvmap.Field{{Field: "Name", Column: "Name"}}, m.Fields)
+		assert.Contains(t, stderr.String(), "1 mapped field(s) are shorter in the target")
+	})
+
+	t.Run("across orgs", func(t *testing.T) {
+		// Each org only knows its own object
+		newOrg := func(object string, fields []api.Field) *api.Client {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if !strings.Contains(r.URL.Path, "/sobjects/"+object+"/describe") {
+					t.Errorf("unexpected request: %s", r.URL.Path)
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				w.Header().Set("Content-Type", "application/json")
+				_ = json.NewEncoder(w).Encode(api.SObjectDescribe{Name: object, Fields: fields})
+			}))
+			t.Cleanup(server.Close)
+
+			c, err := api.New(api.ClientConfig{InstanceURL: server.URL, HTTPClient: server.Client()})
+			require.NoError(t, err)
+			return c
+		}
+
+		stdout := &bytes.Buffer{}
+		opts := &root.Options{
+			Output: "table",
+			Stdout: stdout,
+			Stderr: &bytes.Buffer{},
+		}
+		opts.SetAPIClient(client)
+		opts.SetOrgAPIClient("prod", newOrg("Account", []api.Field{
+			{Name: "Phone", Label: "Phone", Type: "phone", Length: 40, Createable: true},
+		}))
+		opts.SetOrgAPIClient("dev1", newOrg("Account", []api.Field{
+			{Name: "Phone", Label: "Phone", Type: "phone", Length: 40, Createable: true},
+			{Name: "Rating", Label: "Rating", Type: "picklist", Length: 255, Createable: true},
+		}))
+
+		cmd := NewCommand(opts)
+		cmd.SetArgs([]string{"map-fields", "Account@prod", "Account@dev1"})
+
+		err := cmd.Execute()
+		require.NoError(t, err)
+
+		output := stdout.String()
+		assert.Contains(t, output, "Phone")
+		assert.Contains(t, output, "target only")
+		assert.Contains(t, output, "2 field(s), 1 need attention")
+	})
+
+	t.Run("invalid object", func(t *testing.T) {
+		opts := &root.Options{
+			Output: "table",
+			Stdout: &bytes.Buffer{},
+			Stderr: &bytes.Buffer{},
+		}
+		opts.SetAPIClient(client)
+
+		cmd := NewCommand(opts)
+		cmd.SetArgs([]string{"map-fields", "Account@", "Lead"})
+
+		err := cmd.Execute()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "expected Object or Object@org")
+	})
+}
+
+func TestPicklistsCommand(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/services/data/v62.0/ui-api/object-info/Account":
+			_, _ = w.Write([]byte(`{
+				"apiName": "Account",
+				"defaultRecordTypeId": "012000000000000AAA",
+				"fields": {
+					"Industry": {"apiName": "Industry", "dataType": "Picklist"},
+					"Rating": {"apiName": "Rating", "dataType": "Picklist", "controllerName": "Industry"},
+					"Name": {"apiName": "Name", "dataType": "String"}
+				},
+				"recordTypeInfos": {
+					"012000000000000AAA": {"recordTypeId": "012000000000000AAA", "name": "Master", "master": true},
+					"012xx0000000001AAA": {"recordTypeId": "012xx0000000001AAA", "name": "Partner"}
+				}
+			}`))
+		case "/services/data/v62.0/ui-api/object-info/Account/picklist-values/012xx0000000001AAA/Rating":
+			_, _ = w.Write([]byte(`{
+				"controllerValues": {"Agriculture": 0, "Banking": 1},
+				"defaultValue": {"label": "Warm", "value": "Warm", "validFor": [0, 1]},
+				"values": [
+					{"label": "Hot", "value": "Hot", "validFor": [1]},
+					{"label": "Warm", "value": "Warm", "validFor": [0, 1]}
+				]
+			}`))
+		case "/services/data/v62.0/ui-api/object-info/Account/picklist-values/012000000000000AAA":
+			_, _ = w.Write([]byte(`{"picklistFieldValues": {
+				"Industry": {"controllerValues": {}, "values": [{"label": "Banking", "value": "Banking", "validFor": []}]}
+			}}`))
+		default:
+			t.Errorf("unexpected request: %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := api.New(api.ClientConfig{
+		InstanceURL: server.URL,
+		HTTPClient:  server.Client(),
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		args    []string
+		want    []string
+		wantErr string
+	}{
+		{
+			name: "dependent field by record type name",
+			args: []string{"Account.Rating", "--record-type", "Partner"},
+			want: []string{"Partner (012xx0000000001AAA)", "Valid For Industry", "Agriculture, Banking", "2 value(s)"},
+		},
+		{
+			name: "all fields with default record type",
+			args: []string{"Account"},
+			want: []string{"Master (012000000000000AAA)", "Industry", "Banking", "1 field(s)"},
+		},
+		{
+			name:    "not a picklist",
+			args:    []string{"Account.Name"},
+			wantErr: "Account.Name is not a picklist field",
+		},
+		{
+			name:    "unknown record type",
+			args:    []string{"Account.Rating", "--record-type", "Reseller"},
+			wantErr: `record type "Reseller" not found on Account`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stdout := &bytes.Buffer{}
+			opts := &root.Options{
+				Output: "table",
+				Stdout: stdout,
+				Stderr: &bytes.Buffer{},
+			}
+			opts.SetAPIClient(client)
+
+			cmd := NewCommand(opts)
+			cmd.SetArgs(append([]string{"picklists"}, tt.args...))
+
+			err := cmd.Execute()
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			for _, want := range tt.want {
+				assert.Contains(t, stdout.String(), want)
+			}
+		})
+	}
+}
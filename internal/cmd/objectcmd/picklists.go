@@ -0,0 +1,174 @@
+package objectcmd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/salesforce-cli/api/uiapi"
+	"github.com/open-cli-collective/salesforce-cli/internal/cmd/root"
+)
+
+func newPicklistsCommand(opts *root.Options) *cobra.Command {
+	var recordType string
+
+	cmd := &cobra.Command{
+		Use:   "picklists <object>[.<field>]",
+		Short: "Show picklist values available for a record type",
+		Long: `Show the picklist values users can pick for a record type, using the User
+Interface API. Unlike describe, which lists every value, this applies the
+record type's value filtering, and shows which controlling field values each
+dependent picklist value is valid for.
+
+Give Object.Field for one field, or just the object for all of its picklist
+fields. --record-type takes a record type ID or name; without it, the
+user's default record type is used.
+
+Examples:
+  sfdc object picklists Account.Industry
+  sfdc object picklists Account.Industry --record-type Partner
+  sfdc object picklists Case --record-type 012xx0000004ABCAAA
+  sfdc object picklists Opportunity.StageName -o json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			objectName, fieldName, _ := strings.Cut(args[0], ".")
+			if objectName == "" {
+				return fmt.Errorf("invalid picklist %q (expected Object or Object.Field)", args[0])
+			}
+			return runPicklists(cmd.Context(), opts, objectName, fieldName, recordType)
+		},
+	}
+
+	cmd.Flags().StringVar(&recordType, "record-type", "", "Record type ID or name (default: the user's default record type)")
+
+	return cmd
+}
+
+func runPicklists(ctx context.Context, opts *root.Options, objectName, fieldName, recordType string) error {
+	client, err := opts.APIClient()
+	if err != nil {
+		return fmt.Errorf("failed to create API client: %w", err)
+	}
+
+	ui := uiapi.New(client)
+
+	info, err := ui.ObjectInfo(ctx, objectName)
+	if err != nil {
+		return fmt.Errorf("failed to get object info: %w", err)
+	}
+
+	recordTypeID, err := info.RecordTypeID(recordType)
+	if err != nil {
+		return err
+	}
+
+	if fieldName != "" {
+		field, ok := info.Fields[fieldName]
+		if !ok {
+			return fmt.Errorf("field %q not found on %s", fieldName, info.APIName)
+		}
+		if !field.IsPicklist() {
+			return fmt.Errorf("%s.%s is not a picklist field", info.APIName, field.APIName)
+		}
+
+		values, err := ui.PicklistValues(ctx, info.APIName, recordTypeID, field.APIName)
+		if err != nil {
+			return fmt.Errorf("failed to get picklist values: %w", err)
+		}
+		return renderPicklist(opts, info, recordTypeID, field, values)
+	}
+
+	all, err := ui.AllPicklistValues(ctx, info.APIName, recordTypeID)
+	if err != nil {
+		return fmt.Errorf("failed to get picklist values: %w", err)
+	}
+	return renderAllPicklists(opts, info, recordTypeID, all)
+}
+
+func renderPicklist(opts *root.Options, info *uiapi.ObjectInfo, recordTypeID string, field uiapi.Field, values *uiapi.PicklistValues) error {
+	v := opts.View()
+
+	if opts.Output == "json" {
+		return v.JSON(values)
+	}
+
+	v.Info("Record type: %s", recordTypeLabel(info, recordTypeID))
+
+	if len(values.Values) == 0 {
+		v.Info("No values available for %s.%s", info.APIName, field.APIName)
+		return nil
+	}
+
+	dependent := len(values.ControllerValues) > 0
+	headers := []string{"Value", "Label", "Default"}
+	if dependent {
+		headers = append(headers, "Valid For "+field.ControllerName)
+	}
+
+	rows := make([][]string, 0, len(values.Values))
+	for _, value := range values.Values {
+		row := []string{value.Value, value.Label, isDefault(values, value)}
+		if dependent {
+			row = append(row, strings.Join(values.ValidFor(value), ", "))
+		}
+		rows = append(rows, row)
+	}
+
+	if err := v.Table(headers, rows); err != nil {
+		return err
+	}
+
+	v.Info("\n%d value(s)", len(values.Values))
+	return nil
+}
+
+func renderAllPicklists(opts *root.Options, info *uiapi.ObjectInfo, recordTypeID string, all map[string]uiapi.PicklistValues) error {
+	v := opts.View()
+
+	if opts.Output == "json" {
+		return v.JSON(all)
+	}
+
+	v.Info("Record type: %s", recordTypeLabel(info, recordTypeID))
+
+	fields := make([]string, 0, len(all))
+	for name := range all {
+		fields = append(fields, name)
+	}
+	sort.Strings(fields)
+
+	var rows [][]string
+	for _, name := range fields {
+		values := all[name]
+		for _, value := range values.Values {
+			rows = append(rows, []string{name, value.Value, value.Label, isDefault(&values, value)})
+		}
+	}
+
+	if len(rows) == 0 {
+		v.Info("No picklist values found on %s", info.APIName)
+		return nil
+	}
+
+	if err := v.Table([]string{"Field", "Value", "Label", "Default"}, rows); err != nil {
+		return err
+	}
+
+	v.Info("\n%d field(s)", len(fields))
+	return nil
+}
+
+// recordTypeLabel returns the name and ID of a record type.
+func recordTypeLabel(info *uiapi.ObjectInfo, recordTypeID string) string {
+	if rt, ok := info.RecordTypeInfos[recordTypeID]; ok && rt.Name != "" {
+		return fmt.Sprintf("%s (%s)", rt.Name, recordTypeID)
+	}
+	return recordTypeID
+}
+
+func isDefault(values *uiapi.PicklistValues, value uiapi.PicklistValue) string {
+	return boolToYesNo(values.DefaultValue != nil && values.DefaultValue.Value == value.Value)
+}
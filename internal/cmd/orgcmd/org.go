@@ -0,0 +1,26 @@
+// Package orgcmd provides commands for inspecting the connected org.
+package orgcmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/salesforce-cli/internal/cmd/root"
+)
+
+// Register registers the org command with the root command.
+func Register(parent *cobra.Command, opts *root.Options) {
+	parent.AddCommand(NewCommand(opts))
+}
+
+// NewCommand creates the org command with subcommands.
+func NewCommand(opts *root.Options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "org",
+		Short: "Inspect the connected org",
+		Long:  "Show details of the org and user the CLI is logged in as.",
+	}
+
+	cmd.AddCommand(newWhoamiCommand(opts))
+
+	return cmd
+}
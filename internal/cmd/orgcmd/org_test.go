@@ -0,0 +1,98 @@
+package orgcmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/salesforce-cli/api"
+	"github.com/open-cli-collective/salesforce-cli/internal/cmd/root"
+)
+
+func newWhoamiServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/services/oauth2/userinfo":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"user_id":            "005xx000001Sv6eAAC",
+				"organization_id":    "00Dxx0000001gPLEAY",
+				"preferred_username": "admin@example.com.uat",
+				"name":               "Ada Admin",
+				"locale":             "en_US",
+				"zoneinfo":           "Europe/London",
+			})
+		case strings.HasSuffix(r.URL.Path, "/query"):
+			assert.Contains(t, r.URL.Query().Get("q"), "FROM Organization")
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"totalSize": 1,
+				"done":      true,
+				"records": []map[string]interface{}{{
+					"attributes":       map[string]string{"type": "Organization"},
+					"Id":               "00Dxx0000001gPLEAY",
+					"Name":             "Acme",
+					"IsSandbox":        true,
+					"OrganizationType": "Enterprise Edition",
+				}},
+			})
+		default:
+			t.Errorf("unexpected request: %s", r.URL.Path)
+		}
+	}))
+}
+
+func TestWhoamiCommand(t *testing.T) {
+	server := newWhoamiServer(t)
+	defer server.Close()
+
+	client, err := api.New(api.ClientConfig{InstanceURL: server.URL, HTTPClient: server.Client()})
+	require.NoError(t, err)
+
+	stdout := &bytes.Buffer{}
+	opts := &root.Options{Output: "table", NoColor: true, Stdout: stdout, Stderr: &bytes.Buffer{}}
+	opts.SetAPIClient(client)
+
+	cmd := NewCommand(opts)
+	cmd.SetArgs([]string{"whoami"})
+	require.NoError(t, cmd.Execute())
+
+	output := stdout.String()
+	assert.Contains(t, output, "admin@example.com.uat")
+	assert.Contains(t, output, "005xx000001Sv6eAAC")
+	assert.Contains(t, output, "Acme (00Dxx0000001gPLEAY)")
+	assert.Contains(t, output, "Enterprise Edition (sandbox)")
+	assert.Contains(t, output, api.DefaultAPIVersion)
+	assert.Contains(t, output, "en_US")
+}
+
+func TestWhoamiCommand_JSON(t *testing.T) {
+	server := newWhoamiServer(t)
+	defer server.Close()
+
+	client, err := api.New(api.ClientConfig{InstanceURL: server.URL, HTTPClient: server.Client()})
+	require.NoError(t, err)
+
+	stdout := &bytes.Buffer{}
+	opts := &root.Options{Output: "json", Stdout: stdout, Stderr: &bytes.Buffer{}}
+	opts.SetAPIClient(client)
+
+	cmd := NewCommand(opts)
+	cmd.SetArgs([]string{"whoami"})
+	require.NoError(t, cmd.Execute())
+
+	var result map[string]interface{}
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &result))
+	assert.Equal(t, "admin@example.com.uat", result["username"])
+	assert.Equal(t, "00Dxx0000001gPLEAY", result["orgId"])
+	assert.Equal(t, server.URL, result["instanceUrl"])
+	assert.Equal(t, true, result["isSandbox"])
+	assert.Equal(t, "Europe/London", result["timeZone"])
+}
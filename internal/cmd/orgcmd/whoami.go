@@ -0,0 +1,106 @@
+package orgcmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/salesforce-cli/internal/cmd/root"
+)
+
+// whoami is the output of the whoami command.
+type whoami struct {
+	Username         string `json:"username"`
+	Name             string `json:"name,omitempty"`
+	UserID           string `json:"userId"`
+	OrgID            string `json:"orgId"`
+	OrgName          string `json:"orgName,omitempty"`
+	OrganizationType string `json:"organizationType,omitempty"`
+	IsSandbox        bool   `json:"isSandbox"`
+	InstanceURL      string `json:"instanceUrl"`
+	APIVersion       string `json:"apiVersion"`
+	Locale           string `json:"locale,omitempty"`
+	Language         string `json:"language,omitempty"`
+	TimeZone         string `json:"timeZone,omitempty"`
+}
+
+func newWhoamiCommand(opts *root.Options) *cobra.Command {
+	return &cobra.Command{
+		Use:   "whoami",
+		Short: "Show the logged-in user and org",
+		Long: `Show the user and org the CLI is logged in as, from the Identity API:
+username, user ID, org ID and type, instance URL, API version, and locale.
+
+Use -o json to read the details in scripts.
+
+Examples:
+  sfdc org whoami
+  sfdc org whoami --org uat
+  sfdc org whoami -o json | jq -r .orgId`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runWhoami(cmd.Context(), opts)
+		},
+	}
+}
+
+func runWhoami(ctx context.Context, opts *root.Options) error {
+	client, err := opts.APIClient()
+	if err != nil {
+		return fmt.Errorf("failed to create API client: %w", err)
+	}
+
+	user, err := client.GetUserInfo(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get user info: %w", err)
+	}
+
+	org, err := client.GetOrgInfo(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get org info: %w", err)
+	}
+
+	result := whoami{
+		Username:         user.PreferredUsername,
+		Name:             user.Name,
+		UserID:           user.UserID,
+		OrgID:            user.OrganizationID,
+		OrgName:          org.Name,
+		OrganizationType: org.OrganizationType,
+		IsSandbox:        org.IsSandbox,
+		InstanceURL:      client.InstanceURL,
+		APIVersion:       client.APIVersion,
+		Locale:           user.Locale,
+		Language:         user.Language,
+		TimeZone:         user.ZoneInfo,
+	}
+
+	v := opts.View()
+	if opts.Output == "json" {
+		return v.JSON(result)
+	}
+
+	orgType := result.OrganizationType
+	if result.IsSandbox {
+		orgType += " (sandbox)"
+	}
+
+	v.Info("Username:    %s", result.Username)
+	if result.Name != "" {
+		v.Info("Name:        %s", result.Name)
+	}
+	v.Info("User ID:     %s", result.UserID)
+	v.Info("Org:         %s (%s)", result.OrgName, result.OrgID)
+	v.Info("Org type:    %s", orgType)
+	v.Info("Instance:    %s", result.InstanceURL)
+	v.Info("API version: %s", result.APIVersion)
+	if result.Locale != "" {
+		v.Info("Locale:      %s", result.Locale)
+	}
+	if result.TimeZone != "" {
+		v.Info("Time zone:   %s", result.TimeZone)
+	}
+
+	return nil
+}
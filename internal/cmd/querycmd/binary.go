@@ -0,0 +1,165 @@
+package querycmd
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"regexp"
+
+	"github.com/open-cli-collective/salesforce-cli/api"
+)
+
+// describeFunc returns the describe for an object.
+type describeFunc func(ctx context.Context, objectName string) (*api.SObjectDescribe, error)
+
+// fetchFunc returns the raw response body for a path.
+type fetchFunc func(ctx context.Context, path string) ([]byte, error)
+
+// blobURLPattern matches the relative URL returned for base64 fields in
+// query results, e.g. /services/data/v62.0/sobjects/Document/015.../Body.
+var blobURLPattern = regexp.MustCompile(`^/services/data/v[\d.]+(/tooling)?/sobjects/\w+/\w+/\w+$`)
+
+// minInlineBase64 is the shortest string treated as possible inline base64
+// content. Shorter values are rendered as-is.
+const minInlineBase64 = 64
+
+// binaryFields returns the fields of the records that have the base64 type.
+// The object is only described if a value looks like binary content, so
+// ordinary queries don't pay for an extra API call.
+func binaryFields(ctx context.Context, describe describeFunc, records []api.SObject) (map[string]bool, error) {
+	if len(records) == 0 || !hasBinaryCandidate(records) {
+		return nil, nil
+	}
+
+	desc, err := describeRecords(ctx, describe, records)
+	if err != nil || desc == nil {
+		return nil, err
+	}
+
+	return base64Fields(desc), nil
+}
+
+// describeRecords describes the object type of the records. It returns nil
+// if the records have no describable type, e.g. aggregate query results.
+func describeRecords(ctx context.Context, describe describeFunc, records []api.SObject) (*api.SObjectDescribe, error) {
+	objectName := records[0].Attributes.Type
+	if objectName == "" || objectName == "AggregateResult" {
+		return nil, nil
+	}
+
+	desc, err := describe(ctx, objectName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe %s: %w", objectName, err)
+	}
+
+	return desc, nil
+}
+
+// base64Fields returns the names of the object's base64 fields.
+func base64Fields(desc *api.SObjectDescribe) map[string]bool {
+	fields := make(map[string]bool)
+	for _, f := range desc.Fields {
+		if f.Type == "base64" {
+			fields[f.Name] = true
+		}
+	}
+	return fields
+}
+
+// hasBinaryCandidate reports whether any value is a blob URL or a long
+// base64 string.
+func hasBinaryCandidate(records []api.SObject) bool {
+	for _, rec := range records {
+		for _, value := range rec.Fields {
+			s, ok := value.(string)
+			if !ok {
+				continue
+			}
+			if blobURLPattern.MatchString(s) {
+				return true
+			}
+			if len(s) >= minInlineBase64 {
+				if _, err := base64.StdEncoding.DecodeString(s); err == nil {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// binaryPlaceholder returns the table value shown instead of binary content.
+func binaryPlaceholder(rec api.SObject, field string) string {
+	s, ok := rec.Fields[field].(string)
+	if !ok || s == "" {
+		return ""
+	}
+
+	if !blobURLPattern.MatchString(s) {
+		if data, err := base64.StdEncoding.DecodeString(s); err == nil {
+			return fmt.Sprintf("<%d bytes>", len(data))
+		}
+	}
+
+	// Blob content isn't inlined; use the size field if it was queried
+	for _, sizeField := range []string{field + "Length", "ContentSize"} {
+		if size, ok := rec.Fields[sizeField].(float64); ok {
+			return fmt.Sprintf("<%.0f bytes>", size)
+		}
+	}
+
+	return "<binary>"
+}
+
+// saveBinaryField decodes a base64 field of a single-record result and writes
+// it to a file. Blob URLs are downloaded with fetch.
+func saveBinaryField(ctx context.Context, describe describeFunc, fetch fetchFunc, result *api.QueryResult, field, out string) (int, error) {
+	if len(result.Records) != 1 {
+		return 0, fmt.Errorf("--decode-field requires a query that returns exactly one record (got %d)", len(result.Records))
+	}
+	rec := result.Records[0]
+
+	value, ok := rec.Fields[field]
+	if !ok {
+		return 0, fmt.Errorf("field %s is not in the query results", field)
+	}
+	s, _ := value.(string)
+	if s == "" {
+		return 0, fmt.Errorf("field %s is empty", field)
+	}
+
+	desc, err := describe(ctx, rec.Attributes.Type)
+	if err != nil {
+		return 0, fmt.Errorf("failed to describe %s: %w", rec.Attributes.Type, err)
+	}
+	isBinary := false
+	for _, f := range desc.Fields {
+		if f.Name == field {
+			isBinary = f.Type == "base64"
+			break
+		}
+	}
+	if !isBinary {
+		return 0, fmt.Errorf("field %s is not a base64 field", field)
+	}
+
+	var data []byte
+	if blobURLPattern.MatchString(s) {
+		data, err = fetch(ctx, s)
+		if err != nil {
+			return 0, fmt.Errorf("failed to download %s: %w", field, err)
+		}
+	} else {
+		data, err = base64.StdEncoding.DecodeString(s)
+		if err != nil {
+			return 0, fmt.Errorf("failed to decode %s: %w", field, err)
+		}
+	}
+
+	if err := os.WriteFile(out, data, 0644); err != nil {
+		return 0, fmt.Errorf("failed to write file: %w", err)
+	}
+
+	return len(data), nil
+}
@@ -0,0 +1,78 @@
+package querycmd
+
+import (
+	"context"
+
+	"github.com/open-cli-collective/salesforce-cli/api"
+	"github.com/open-cli-collective/salesforce-cli/internal/view"
+)
+
+// cellFormatter renders field values as table cells. Binary content is
+// replaced with a placeholder and, with --typed, values are formatted by
+// field type.
+type cellFormatter struct {
+	binary map[string]bool
+	typed  *view.TypedFormat
+	fields map[string]api.Field // only set with --typed
+}
+
+// newCellFormatter creates a formatter for the records. With typed, the
+// object is always described; otherwise only if binary content is likely.
+func newCellFormatter(ctx context.Context, describe describeFunc, records []api.SObject, typed *view.TypedFormat) (*cellFormatter, error) {
+	if typed == nil {
+		binary, err := binaryFields(ctx, describe, records)
+		if err != nil {
+			return nil, err
+		}
+		return &cellFormatter{binary: binary}, nil
+	}
+
+	if len(records) == 0 {
+		return &cellFormatter{}, nil
+	}
+
+	desc, err := describeRecords(ctx, describe, records)
+	if err != nil || desc == nil {
+		return &cellFormatter{}, err
+	}
+
+	fields := make(map[string]api.Field, len(desc.Fields))
+	for _, f := range desc.Fields {
+		fields[f.Name] = f
+	}
+
+	return &cellFormatter{binary: base64Fields(desc), typed: typed, fields: fields}, nil
+}
+
+// format returns the display value of a record's field.
+func (c *cellFormatter) format(rec api.SObject, field string) string {
+	if c.binary[field] {
+		return binaryPlaceholder(rec, field)
+	}
+
+	value := rec.Fields[field]
+	if f, ok := c.fields[field]; ok && value != nil {
+		currencyCode, _ := rec.Fields["CurrencyIsoCode"].(string)
+		if s, ok := c.typed.Format(value, f.Type, f.Scale, currencyCode); ok {
+			return s
+		}
+	}
+
+	return formatFieldValue(value)
+}
+
+// legend returns the legend of the typed columns, or "" without --typed.
+func (c *cellFormatter) legend(headers []string) string {
+	if c.typed == nil {
+		return ""
+	}
+
+	var names, types []string
+	for _, h := range headers {
+		if f, ok := c.fields[h]; ok {
+			names = append(names, h)
+			types = append(types, f.Type)
+		}
+	}
+	return c.typed.Legend(names, types)
+}
@@ -0,0 +1,155 @@
+package querycmd
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/open-cli-collective/salesforce-cli/api"
+)
+
+// selectClause captures the field list of a SOQL query.
+var selectClause = regexp.MustCompile(`(?is)^\s*SELECT\s+(.+?)\s+FROM\s`)
+
+// exportCSV writes all pages of a query result as CSV to out, or to w if out
+// is empty. Each page is written as it is fetched, so large results are
+// never held in memory at once.
+func exportCSV(ctx context.Context, w io.Writer, queryMore queryMoreFunc, soql string, result *api.QueryResult, out string) (int, error) {
+	if out != "" {
+		f, err := os.Create(out)
+		if err != nil {
+			return 0, fmt.Errorf("failed to create file: %w", err)
+		}
+		defer f.Close()
+		w = f
+	}
+
+	cw := csv.NewWriter(w)
+
+	columns := selectColumns(soql)
+	if columns == nil {
+		columns = recordColumns(result.Records)
+	}
+	if err := cw.Write(columns); err != nil {
+		return 0, fmt.Errorf("failed to write CSV: %w", err)
+	}
+
+	count := 0
+	for {
+		for _, rec := range result.Records {
+			row := make([]string, len(columns))
+			for i, column := range columns {
+				row[i] = csvValue(rec, column)
+			}
+			if err := cw.Write(row); err != nil {
+				return count, fmt.Errorf("failed to write CSV: %w", err)
+			}
+			count++
+		}
+
+		cw.Flush()
+		if err := cw.Error(); err != nil {
+			return count, fmt.Errorf("failed to write CSV: %w", err)
+		}
+
+		if result.Done || result.NextRecordsURL == "" {
+			return count, nil
+		}
+
+		next, err := queryMore(ctx, result.NextRecordsURL)
+		if err != nil {
+			return count, fmt.Errorf("query failed: %w", err)
+		}
+		result = next
+	}
+}
+
+// selectColumns returns the fields of a SOQL SELECT clause in order, or nil
+// if the clause has functions, subqueries, or anything other than plain
+// field paths.
+func selectColumns(soql string) []string {
+	m := selectClause.FindStringSubmatch(soql)
+	if m == nil || strings.ContainsAny(m[1], "()") {
+		return nil
+	}
+
+	var columns []string
+	for _, field := range strings.Split(m[1], ",") {
+		field = strings.TrimSpace(field)
+		if field == "" || strings.ContainsAny(field, " \t\n") {
+			return nil
+		}
+		columns = append(columns, field)
+	}
+	return columns
+}
+
+// recordColumns derives columns from the first record: Id first, then the
+// remaining fields sorted, with relationship fields flattened to dotted paths.
+func recordColumns(records []api.SObject) []string {
+	if len(records) == 0 {
+		return []string{"Id"}
+	}
+
+	var names []string
+	var walk func(prefix string, fields map[string]interface{})
+	walk = func(prefix string, fields map[string]interface{}) {
+		for name, value := range fields {
+			if name == "attributes" || (prefix == "" && name == "Id") {
+				continue
+			}
+			if nested, ok := value.(map[string]interface{}); ok {
+				walk(prefix+name+".", nested)
+				continue
+			}
+			names = append(names, prefix+name)
+		}
+	}
+	walk("", records[0].Fields)
+	sort.Strings(names)
+
+	return append([]string{"Id"}, names...)
+}
+
+// csvValue returns the value of a field path (e.g. ApexClass.Name) on a
+// record. SOQL field names are case-insensitive, so keys are matched that way.
+func csvValue(rec api.SObject, path string) string {
+	if strings.EqualFold(path, "Id") {
+		return rec.ID
+	}
+
+	var value interface{} = rec.Fields
+	for _, part := range strings.Split(path, ".") {
+		fields, ok := value.(map[string]interface{})
+		if !ok {
+			return ""
+		}
+		value = lookupField(fields, part)
+	}
+
+	if value == nil {
+		return ""
+	}
+	if _, ok := value.(map[string]interface{}); ok {
+		return ""
+	}
+	return formatFieldValue(value)
+}
+
+// lookupField returns a field value, matching the name case-insensitively.
+func lookupField(fields map[string]interface{}, name string) interface{} {
+	if v, ok := fields[name]; ok {
+		return v
+	}
+	for key, v := range fields {
+		if strings.EqualFold(key, name) {
+			return v
+		}
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package querycmd
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/open-cli-collective/salesforce-cli/internal/cmd/root"
+)
+
+// explainConflict returns the flag that cannot be combined with --explain,
+// if one is set.
+func explainConflict(output string, flags queryFlags) string {
+	switch {
+	case flags.all:
+		return "--all"
+	case flags.noLimit:
+		return "--no-limit"
+	case flags.page:
+		return "--page"
+	case flags.tooling:
+		return "--tooling"
+	case flags.typed:
+		return "--typed"
+	case flags.decodeField != "":
+		return "--decode-field"
+	case flags.out != "":
+		return "--out"
+	case output == "csv":
+		return "-o csv"
+	}
+	return ""
+}
+
+// runExplain shows the query plans for soql instead of running it.
+func runExplain(ctx context.Context, opts *root.Options, soql string) error {
+	client, err := opts.APIClient()
+	if err != nil {
+		return fmt.Errorf("failed to create API client: %w", err)
+	}
+
+	result, err := client.Explain(ctx, soql)
+	if err != nil {
+		return fmt.Errorf("explain failed: %w", err)
+	}
+
+	v := opts.View()
+
+	if opts.Output == "json" {
+		return v.JSON(result)
+	}
+
+	if len(result.Plans) == 0 {
+		v.Info("No query plans returned")
+		return nil
+	}
+
+	headers := []string{"Cardinality", "Leading Operation", "Relative Cost", "Object", "Object Cardinality", "Fields"}
+	rows := make([][]string, 0, len(result.Plans))
+	for _, plan := range result.Plans {
+		rows = append(rows, []string{
+			strconv.FormatInt(plan.Cardinality, 10),
+			plan.LeadingOperationType,
+			formatCost(plan.RelativeCost),
+			plan.SObjectType,
+			strconv.FormatInt(plan.SObjectCardinality, 10),
+			strings.Join(plan.Fields, ", "),
+		})
+	}
+	if err := v.Render(headers, rows, result); err != nil {
+		return err
+	}
+
+	for _, note := range result.Plans[0].Notes {
+		v.Info("Note: %s (%s)", note.Description, strings.Join(note.Fields, ", "))
+	}
+
+	if !result.Selective() {
+		v.Warning("Query is not selective (relative cost %s): filter on an indexed field to avoid timeouts on large objects", formatCost(result.Plans[0].RelativeCost))
+	}
+
+	return nil
+}
+
+// formatCost formats a plan's relative cost rounded to three decimals.
+func formatCost(cost float64) string {
+	return strconv.FormatFloat(math.Round(cost*1000)/1000, 'f', -1, 64)
+}
@@ -2,16 +2,21 @@
 package querycmd
 
 import (
+	"bufio"
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/url"
 	"sort"
+	"strings"
 
 	"github.com/spf13/cobra"
 
 	"github.com/open-cli-collective/salesforce-cli/api"
+	"github.com/open-cli-collective/salesforce-cli/api/tooling"
 	"github.com/open-cli-collective/salesforce-cli/internal/cmd/root"
+	"github.com/open-cli-collective/salesforce-cli/internal/view"
 )
 
 // Register registers the query command with the root command.
@@ -21,33 +26,124 @@ func Register(parent *cobra.Command, opts *root.Options) {
 
 // NewCommand creates the query command.
 func NewCommand(opts *root.Options) *cobra.Command {
-	var (
-		all     bool
-		noLimit bool
-	)
+	var flags queryFlags
 
 	cmd := &cobra.Command{
 		Use:   "query <soql>",
 		Short: "Execute a SOQL query",
 		Long: `Execute a SOQL query against Salesforce and display the results.
 
+With --tooling, the query is sent to the Tooling API instead of the REST API.
+Some objects are only available there, including ApexClassMember,
+ApexCodeCoverage, ApexCodeCoverageAggregate, ApexOrgWideCoverage, CustomField,
+CustomObject, DebugLevel, MetadataContainer, TraceFlag, and ValidationRule.
+
+Binary (base64) fields such as Document.Body or Attachment.Body are shown as
+a "<N bytes>" placeholder in table and plain output. Use --decode-field with
+--out to save the content of one record's binary field to a file.
+
+With --no-limit, every page of results is fetched. JSON and plain output are
+written as each page arrives, so only one page is held in memory at a time.
+
+With -o csv, all pages are fetched and written as CSV, one page at a time, to
+stdout or the --out file. Columns follow the SELECT clause; relationship
+fields such as ApexClass.Name become their own columns.
+
+With --typed, values are formatted according to their field type: currency
+with thousands separators, decimals, and the record's currency (or the org's
+default currency), percent with %, datetimes as their date in local time,
+and checkboxes as yes/no unless --raw-booleans is given. A legend of the
+formatted fields follows the results. This needs an extra describe call.
+
+With --explain, the query is not run. Instead, the plans the query optimizer
+considers are shown, cheapest first: estimated cardinality, leading
+operation type (Index, Sharing, TableScan, or Other), and relative cost. A
+relative cost above 1 means the query is not selective and may time out on
+large objects.
+
 Examples:
   sfdc query "SELECT Id, Name FROM Account LIMIT 10"
   sfdc query "SELECT Id, Name FROM Account" --all
-  sfdc query "SELECT Id, Name, Phone FROM Contact" -o json`,
-		Args: cobra.ExactArgs(1),
+  sfdc query "SELECT Id, Name FROM Contact" --page
+  sfdc query "SELECT Id, LogType, ExpirationDate FROM TraceFlag" --tooling
+  sfdc query "SELECT Id, DeveloperName, MasterLabel FROM FlexiPage" --tooling -o csv --out flexipages.csv
+  sfdc query "SELECT Id, Name, Phone FROM Contact" -o json
+  sfdc query "SELECT Name, AnnualRevenue, CreatedDate FROM Account" --typed
+  sfdc query "SELECT Id FROM Account WHERE Industry = 'Energy'" --explain
+  sfdc query "SELECT Id, Body FROM Document WHERE Name = 'Logo'" --decode-field Body --out logo.png`,
+		Args:        cobra.ExactArgs(1),
+		Annotations: map[string]string{root.CSVOutputAnnotation: "true"},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runQuery(cmd.Context(), opts, args[0], all, noLimit)
+			if flags.explain {
+				if conflict := explainConflict(opts.Output, flags); conflict != "" {
+					return fmt.Errorf("--explain cannot be combined with %s", conflict)
+				}
+				return runExplain(cmd.Context(), opts, args[0])
+			}
+			if flags.page {
+				if flags.noLimit {
+					return fmt.Errorf("--page cannot be combined with --no-limit")
+				}
+				if opts.Output == "json" || opts.Output == "csv" {
+					return fmt.Errorf("--page is only supported for table and plain output")
+				}
+			}
+			if flags.tooling && flags.all {
+				return fmt.Errorf("--all is not supported with --tooling")
+			}
+			if flags.rawBooleans && !flags.typed {
+				return fmt.Errorf("--raw-booleans requires --typed")
+			}
+			if flags.decodeField != "" && flags.out == "" {
+				return fmt.Errorf("--decode-field and --out must be used together")
+			}
+			if flags.out != "" && flags.decodeField == "" && opts.Output != "csv" {
+				return fmt.Errorf("--out requires --decode-field or -o csv")
+			}
+			if flags.decodeField != "" && opts.Output == "csv" {
+				return fmt.Errorf("--decode-field cannot be combined with -o csv")
+			}
+			if flags.decodeField != "" && flags.page {
+				return fmt.Errorf("--decode-field cannot be combined with --page")
+			}
+			return runQuery(cmd.Context(), opts, args[0], flags)
 		},
 	}
 
-	cmd.Flags().BoolVar(&all, "all", false, "Include deleted and archived records (queryAll)")
-	cmd.Flags().BoolVar(&noLimit, "no-limit", false, "Fetch all pages of results (may be slow for large datasets)")
+	cmd.Flags().BoolVar(&flags.all, "all", false, "Include deleted and archived records (queryAll)")
+	cmd.Flags().BoolVar(&flags.noLimit, "no-limit", false, "Fetch all pages of results (may be slow for large datasets)")
+	cmd.Flags().BoolVar(&flags.page, "page", false, "Page through results interactively, one batch at a time")
+	cmd.Flags().BoolVar(&flags.tooling, "tooling", false, "Query Tooling API objects instead of standard objects")
+	cmd.Flags().BoolVar(&flags.typed, "typed", false, "Format values by field type (currency, percent, date, datetime, checkbox)")
+	cmd.Flags().BoolVar(&flags.rawBooleans, "raw-booleans", false, "With --typed, show checkboxes as true/false instead of yes/no")
+	cmd.Flags().StringVar(&flags.decodeField, "decode-field", "", "Binary (base64) field to decode and save (requires --out)")
+	cmd.Flags().StringVar(&flags.out, "out", "", "File to write the decoded field or CSV export to")
+	cmd.Flags().BoolVar(&flags.explain, "explain", false, "Show the query plan instead of running the query")
+	cmd.Flags().Float64Var(&opts.MaxRPS, "max-rps", 0, "Send at most this many API requests per second (0 for the max_rps setting)")
 
 	return cmd
 }
 
-func runQuery(ctx context.Context, opts *root.Options, soql string, all, noLimit bool) error {
+// queryFlags holds the query command's flags.
+type queryFlags struct {
+	all     bool
+	noLimit bool
+	page    bool
+	tooling bool
+	typed   bool
+	explain bool
+
+	rawBooleans bool
+
+	decodeField string
+	out         string
+}
+
+func runQuery(ctx context.Context, opts *root.Options, soql string, flags queryFlags) error {
+	if flags.tooling {
+		return runToolingQuery(ctx, opts, soql, flags)
+	}
+
 	client, err := opts.APIClient()
 	if err != nil {
 		return fmt.Errorf("failed to create API client: %w", err)
@@ -55,9 +151,9 @@ func runQuery(ctx context.Context, opts *root.Options, soql string, all, noLimit
 
 	var result *api.QueryResult
 
-	if all {
+	if flags.all {
 		result, err = queryAllRecords(ctx, client, soql)
-	} else if noLimit {
+	} else if flags.noLimit && !streamsPages(opts.Output, flags) {
 		result, err = client.QueryAll(ctx, soql)
 	} else {
 		result, err = client.Query(ctx, soql)
@@ -67,7 +163,197 @@ func runQuery(ctx context.Context, opts *root.Options, soql string, all, noLimit
 		return fmt.Errorf("query failed: %w", err)
 	}
 
-	return renderQueryResult(opts, result)
+	if opts.Output == "csv" {
+		return runCSVExport(ctx, opts, client.QueryMore, soql, result, flags.out)
+	}
+
+	if flags.decodeField != "" {
+		return saveDecodedField(ctx, opts, client.DescribeSObject, client.Get, result, flags.decodeField, flags.out)
+	}
+
+	if flags.page {
+		return pageQueryResults(ctx, opts, client.QueryMore, client.DescribeSObject, result, typedFormat(ctx, opts, flags))
+	}
+
+	if flags.noLimit && !flags.all && !result.Done {
+		return streamQueryResult(ctx, opts, client.DescribeSObject, client.QueryMore, result, typedFormat(ctx, opts, flags))
+	}
+
+	return renderQueryResult(ctx, opts, client.DescribeSObject, result, typedFormat(ctx, opts, flags))
+}
+
+// runToolingQuery executes the query against the Tooling API, converting
+// the results so they render the same way as REST API queries.
+func runToolingQuery(ctx context.Context, opts *root.Options, soql string, flags queryFlags) error {
+	client, err := opts.ToolingClient()
+	if err != nil {
+		return fmt.Errorf("failed to create tooling client: %w", err)
+	}
+
+	var toolingResult *tooling.QueryResult
+	if flags.noLimit && !streamsPages(opts.Output, flags) {
+		toolingResult, err = client.QueryAll(ctx, soql)
+	} else {
+		toolingResult, err = client.Query(ctx, soql)
+	}
+	if err != nil {
+		return fmt.Errorf("query failed: %w", err)
+	}
+
+	result, err := fromToolingResult(toolingResult)
+	if err != nil {
+		return err
+	}
+
+	describe := func(ctx context.Context, objectName string) (*api.SObjectDescribe, error) {
+		body, err := client.Get(ctx, "/sobjects/"+objectName+"/describe")
+		if err != nil {
+			return nil, err
+		}
+		var desc api.SObjectDescribe
+		if err := parseJSON(body, &desc); err != nil {
+			return nil, fmt.Errorf("failed to parse describe result: %w", err)
+		}
+		return &desc, nil
+	}
+
+	queryMore := func(ctx context.Context, nextRecordsURL string) (*api.QueryResult, error) {
+		next, err := client.QueryMore(ctx, nextRecordsURL)
+		if err != nil {
+			return nil, err
+		}
+		return fromToolingResult(next)
+	}
+
+	if opts.Output == "csv" {
+		return runCSVExport(ctx, opts, queryMore, soql, result, flags.out)
+	}
+
+	if flags.decodeField != "" {
+		return saveDecodedField(ctx, opts, describe, client.Get, result, flags.decodeField, flags.out)
+	}
+
+	if flags.page {
+		return pageQueryResults(ctx, opts, queryMore, describe, result, typedFormat(ctx, opts, flags))
+	}
+
+	if flags.noLimit && !result.Done {
+		return streamQueryResult(ctx, opts, describe, queryMore, result, typedFormat(ctx, opts, flags))
+	}
+
+	return renderQueryResult(ctx, opts, describe, result, typedFormat(ctx, opts, flags))
+}
+
+// runCSVExport writes every page of the result as CSV.
+func runCSVExport(ctx context.Context, opts *root.Options, queryMore queryMoreFunc, soql string, result *api.QueryResult, out string) error {
+	n, err := exportCSV(ctx, opts.Stdout, queryMore, soql, result, out)
+	if err != nil {
+		return err
+	}
+
+	if out != "" {
+		opts.View().Success("Wrote %d record(s) to %s", n, out)
+	}
+	return nil
+}
+
+// saveDecodedField writes the content of a binary field to a file.
+func saveDecodedField(ctx context.Context, opts *root.Options, describe describeFunc, fetch fetchFunc, result *api.QueryResult, field, out string) error {
+	n, err := saveBinaryField(ctx, describe, fetch, result, field, out)
+	if err != nil {
+		return err
+	}
+
+	opts.View().Success("Saved %d bytes to %s", n, out)
+	return nil
+}
+
+// fromToolingResult converts a Tooling API query result into an
+// api.QueryResult so records get the same Id/attributes handling.
+func fromToolingResult(result *tooling.QueryResult) (*api.QueryResult, error) {
+	data, err := json.Marshal(result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert tooling result: %w", err)
+	}
+
+	var converted api.QueryResult
+	if err := parseJSON(data, &converted); err != nil {
+		return nil, fmt.Errorf("failed to convert tooling result: %w", err)
+	}
+
+	return &converted, nil
+}
+
+// typedFormat returns the --typed settings, or nil without --typed or with
+// JSON output, which is never formatted.
+func typedFormat(ctx context.Context, opts *root.Options, flags queryFlags) *view.TypedFormat {
+	if !flags.typed || opts.Output == "json" {
+		return nil
+	}
+
+	// Without the org's default currency, amounts of records that don't
+	// select CurrencyIsoCode are just shown without a currency
+	currency, _ := opts.DefaultCurrency(ctx)
+
+	return &view.TypedFormat{DefaultCurrency: currency, YesNo: !flags.rawBooleans}
+}
+
+// queryMoreFunc fetches the next batch of records for a query.
+type queryMoreFunc func(ctx context.Context, nextRecordsURL string) (*api.QueryResult, error)
+
+// pageQueryResults displays one batch of records at a time, prompting on
+// opts.Stdin before fetching the next batch with queryMore.
+func pageQueryResults(ctx context.Context, opts *root.Options, queryMore queryMoreFunc, describe describeFunc, result *api.QueryResult, typed *view.TypedFormat) error {
+	v := opts.View()
+
+	if len(result.Records) == 0 {
+		v.Info("No records found (totalSize: %d)", result.TotalSize)
+		return nil
+	}
+
+	reader := bufio.NewReader(opts.Stdin)
+	headers := extractHeaders(result.Records)
+	shown := 0
+
+	cells, err := newCellFormatter(ctx, describe, result.Records, typed)
+	if err != nil {
+		return err
+	}
+
+	legend := cells.legend(headers)
+
+	for {
+		if err := v.Table(headers, extractRows(result.Records, headers, cells)); err != nil {
+			return err
+		}
+		if shown == 0 && legend != "" {
+			v.Info("\n%s", legend)
+		}
+
+		start := shown + 1
+		shown += len(result.Records)
+		v.Info("\nShowing %d–%d of %d", start, shown, result.TotalSize)
+
+		if result.Done || result.NextRecordsURL == "" {
+			return nil
+		}
+
+		v.Print("Next page? [Y/n]: ")
+		response, err := reader.ReadString('\n')
+		if err != nil && err != io.EOF {
+			return fmt.Errorf("failed to read input: %w", err)
+		}
+		response = strings.TrimSpace(strings.ToLower(response))
+		if (err == io.EOF && response == "") || (response != "" && response != "y" && response != "yes") {
+			v.Info("")
+			return nil
+		}
+
+		result, err = queryMore(ctx, result.NextRecordsURL)
+		if err != nil {
+			return fmt.Errorf("query failed: %w", err)
+		}
+	}
 }
 
 // queryAllRecords uses the /queryAll endpoint to include deleted/archived records.
@@ -87,7 +373,7 @@ func queryAllRecords(ctx context.Context, client *api.Client, soql string) (*api
 	return &result, nil
 }
 
-func renderQueryResult(opts *root.Options, result *api.QueryResult) error {
+func renderQueryResult(ctx context.Context, opts *root.Options, describe describeFunc, result *api.QueryResult, typed *view.TypedFormat) error {
 	v := opts.View()
 
 	if len(result.Records) == 0 {
@@ -99,12 +385,20 @@ func renderQueryResult(opts *root.Options, result *api.QueryResult) error {
 		return v.JSON(result)
 	}
 
+	cells, err := newCellFormatter(ctx, describe, result.Records, typed)
+	if err != nil {
+		return err
+	}
+
 	headers := extractHeaders(result.Records)
-	rows := extractRows(result.Records, headers)
+	rows := extractRows(result.Records, headers, cells)
 
 	if err := v.Table(headers, rows); err != nil {
 		return err
 	}
+	if legend := cells.legend(headers); legend != "" {
+		v.Info("\n%s", legend)
+	}
 
 	if !result.Done {
 		v.Info("\nShowing %d of %d records (use --no-limit to fetch all)", len(result.Records), result.TotalSize)
@@ -135,8 +429,8 @@ func extractHeaders(records []api.SObject) []string {
 	return append(headers, fieldNames...)
 }
 
-// extractRows converts records to string rows for table output
-func extractRows(records []api.SObject, headers []string) [][]string {
+// extractRows converts records to string rows for table output.
+func extractRows(records []api.SObject, headers []string, cells *cellFormatter) [][]string {
 	rows := make([][]string, 0, len(records))
 
 	for _, rec := range records {
@@ -145,7 +439,7 @@ func extractRows(records []api.SObject, headers []string) [][]string {
 			if header == "Id" {
 				row[i] = rec.ID
 			} else {
-				row[i] = formatFieldValue(rec.Fields[header])
+				row[i] = cells.format(rec, header)
 			}
 		}
 		rows = append(rows, row)
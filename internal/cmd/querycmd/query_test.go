@@ -2,16 +2,24 @@ package querycmd
 
 import (
 	"bytes"
+	"context"
+	"encoding/base64"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/open-cli-collective/salesforce-cli/api"
+	"github.com/open-cli-collective/salesforce-cli/api/tooling"
 	"github.com/open-cli-collective/salesforce-cli/internal/cmd/root"
+	"github.com/open-cli-collective/salesforce-cli/internal/config"
 )
 
 func TestQueryCommand(t *testing.T) {
@@ -191,6 +199,695 @@ func TestQueryCommand_AllFlag(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestQueryCommand_Page(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if strings.Contains(r.URL.Path, "/query/01gxx0000000001-2000") {
+			_ = json.NewEncoder(w).Encode(api.QueryResult{
+				TotalSize: 3,
+				Done:      true,
+				Records: []api.SObject{
+					{ID: "001xx000003", Fields: map[string]interface{}{"Name": "Third"}},
+				},
+			})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(api.QueryResult{
+			TotalSize:      3,
+			Done:           false,
+			NextRecordsURL: "/services/data/v62.0/query/01gxx0000000001-2000",
+			Records: []api.SObject{
+				{ID: "001xx000001", Fields: map[string]interface{}{"Name": "First"}},
+				{ID: "001xx000002", Fields: map[string]interface{}{"Name": "Second"}},
+			},
+		})
+	}))
+	defer server.Close()
+
+	client, err := api.New(api.ClientConfig{
+		InstanceURL: server.URL,
+		HTTPClient:  server.Client(),
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		input       string
+		wantContain []string
+		wantMissing []string
+	}{
+		{
+			name:        "continue to next page",
+			input:       "\n",
+			wantContain: []string{"First", "Showing 1–2 of 3", "Third", "Showing 3–3 of 3"},
+		},
+		{
+			name:        "stop after first page",
+			input:       "n\n",
+			wantContain: []string{"First", "Showing 1–2 of 3"},
+			wantMissing: []string{"Third"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stdout := &bytes.Buffer{}
+			opts := &root.Options{
+				Output: "table",
+				Stdin:  strings.NewReader(tt.input),
+				Stdout: stdout,
+				Stderr: &bytes.Buffer{},
+			}
+			opts.SetAPIClient(client)
+
+			cmd := NewCommand(opts)
+			cmd.SetArgs([]string{"SELECT Id, Name FROM Account", "--page"})
+			cmd.SetOut(stdout)
+
+			err := cmd.Execute()
+			require.NoError(t, err)
+
+			output := stdout.String()
+			for _, want := range tt.wantContain {
+				assert.Contains(t, output, want)
+			}
+			for _, missing := range tt.wantMissing {
+				assert.NotContains(t, output, missing)
+			}
+		})
+	}
+}
+
+// twoPageQueryServer serves a query result split over two pages.
+func twoPageQueryServer(t *testing.T) *api.Client {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/services/data/v62.0/query/01gxx0000000001-2000" {
+			_ = json.NewEncoder(w).Encode(api.QueryResult{
+				TotalSize: 3,
+				Done:      true,
+				Records: []api.SObject{
+					{Attributes: api.SObjectAttributes{Type: "Account"}, ID: "001xx000003", Fields: map[string]interface{}{"Name": "Globex <Ltd>"}},
+				},
+			})
+			return
+		}
+		assert.Equal(t, "/services/data/v62.0/query", r.URL.Path)
+		_ = json.NewEncoder(w).Encode(api.QueryResult{
+			TotalSize:      3,
+			Done:           false,
+			NextRecordsURL: "/services/data/v62.0/query/01gxx0000000001-2000",
+			Records: []api.SObject{
+				{Attributes: api.SObjectAttributes{Type: "Account"}, ID: "001xx000001", Fields: map[string]interface{}{"Name": "Acme Corp"}},
+				{Attributes: api.SObjectAttributes{Type: "Account"}, ID: "001xx000002", Fields: map[string]interface{}{"Name": nil}},
+			},
+		})
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := api.New(api.ClientConfig{
+		InstanceURL: server.URL,
+		HTTPClient:  server.Client(),
+	})
+	require.NoError(t, err)
+	return client
+}
+
+func TestQueryCommand_NoLimitStreamsJSON(t *testing.T) {
+	client := twoPageQueryServer(t)
+
+	stdout := &bytes.Buffer{}
+	opts := &root.Options{
+		Output: "json",
+		Stdout: stdout,
+		Stderr: &bytes.Buffer{},
+	}
+	opts.SetAPIClient(client)
+
+	cmd := NewCommand(opts)
+	cmd.SetArgs([]string{"SELECT Id, Name FROM Account", "--no-limit"})
+
+	err := cmd.Execute()
+	require.NoError(t, err)
+
+	// The streamed output matches what encoding the collected result gives.
+	all, err := client.QueryAll(context.Background(), "SELECT Id, Name FROM Account")
+	require.NoError(t, err)
+	want := &bytes.Buffer{}
+	wantOpts := &root.Options{Output: "json", Stdout: want}
+	require.NoError(t, wantOpts.View().JSON(all))
+
+	assert.Equal(t, want.String(), stdout.String())
+}
+
+func TestQueryCommand_NoLimitStreamsPlain(t *testing.T) {
+	client := twoPageQueryServer(t)
+
+	stdout := &bytes.Buffer{}
+	opts := &root.Options{
+		Output: "plain",
+		Stdout: stdout,
+		Stderr: &bytes.Buffer{},
+	}
+	opts.SetAPIClient(client)
+
+	cmd := NewCommand(opts)
+	cmd.SetArgs([]string{"SELECT Id, Name FROM Account", "--no-limit"})
+
+	err := cmd.Execute()
+	require.NoError(t, err)
+
+	assert.Equal(t, "001xx000001\tAcme Corp\n001xx000002\t\n001xx000003\tGlobex <Ltd>\n", stdout.String())
+}
+
+func TestQueryCommand_PageRejectsJSON(t *testing.T) {
+	opts := &root.Options{
+		Output: "json",
+		Stdout: &bytes.Buffer{},
+		Stderr: &bytes.Buffer{},
+	}
+
+	cmd := NewCommand(opts)
+	cmd.SetArgs([]string{"SELECT Id FROM Account", "--page"})
+
+	err := cmd.Execute()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "table and plain")
+}
+
+func TestQueryCommand_Explain(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "SELECT Id FROM Account WHERE Industry = 'Energy'", r.URL.Query().Get("explain"))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"plans":[
+			{"cardinality":1200,"fields":[],"leadingOperationType":"TableScan",
+			 "notes":[{"description":"Not considering filter for optimization because unindexed","fields":["Industry"],"tableEnumOrId":"Account"}],
+			 "relativeCost":2.1534,"sobjectCardinality":40000,"sobjectType":"Account"}
+		]}`))
+	}))
+	defer server.Close()
+
+	client, err := api.New(api.ClientConfig{InstanceURL: server.URL, HTTPClient: server.Client()})
+	require.NoError(t, err)
+
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	opts := &root.Options{
+		Output:  "table",
+		NoColor: true,
+		Stdout:  stdout,
+		Stderr:  stderr,
+	}
+	opts.SetAPIClient(client)
+
+	cmd := NewCommand(opts)
+	cmd.SetArgs([]string{"SELECT Id FROM Account WHERE Industry = 'Energy'", "--explain"})
+
+	require.NoError(t, cmd.Execute())
+
+	out := stdout.String()
+	assert.Contains(t, out, "Leading Operation")
+	assert.Contains(t, out, "TableScan")
+	assert.Contains(t, out, "2.153")
+	assert.Contains(t, out, "40000")
+	assert.Contains(t, out, "Note: Not considering filter for optimization because unindexed (Industry)")
+	assert.Contains(t, stderr.String(), "Query is not selective (relative cost 2.153)")
+}
+
+func TestQueryCommand_ExplainConflicts(t *testing.T) {
+	opts := &root.Options{
+		Output: "table",
+		Stdout: &bytes.Buffer{},
+		Stderr: &bytes.Buffer{},
+	}
+
+	cmd := NewCommand(opts)
+	cmd.SetArgs([]string{"SELECT Id FROM Account", "--explain", "--tooling"})
+
+	err := cmd.Execute()
+	assert.EqualError(t, err, "--explain cannot be combined with --tooling")
+}
+
+func TestQueryCommand_Tooling(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/services/data/v62.0/tooling/query/01gxx0000000001-2000" {
+			_ = json.NewEncoder(w).Encode(tooling.QueryResult{
+				TotalSize: 2,
+				Done:      true,
+				Records: []tooling.Record{
+					{"attributes": map[string]interface{}{"type": "TraceFlag"}, "Id": "7tf000000000002", "LogType": "CLASS_TRACING"},
+				},
+			})
+			return
+		}
+		assert.Equal(t, "/services/data/v62.0/tooling/query", r.URL.Path)
+		_ = json.NewEncoder(w).Encode(tooling.QueryResult{
+			TotalSize:      2,
+			Done:           false,
+			NextRecordsURL: "/services/data/v62.0/tooling/query/01gxx0000000001-2000",
+			Records: []tooling.Record{
+				{"attributes": map[string]interface{}{"type": "TraceFlag"}, "Id": "7tf000000000001", "LogType": "USER_DEBUG"},
+			},
+		})
+	}))
+	defer server.Close()
+
+	client, err := tooling.New(tooling.ClientConfig{
+		InstanceURL: server.URL,
+		HTTPClient:  server.Client(),
+	})
+	require.NoError(t, err)
+
+	stdout := &bytes.Buffer{}
+	opts := &root.Options{
+		Output: "table",
+		Stdout: stdout,
+		Stderr: &bytes.Buffer{},
+	}
+	opts.SetToolingClient(client)
+
+	cmd := NewCommand(opts)
+	cmd.SetArgs([]string{"SELECT Id, LogType FROM TraceFlag", "--tooling", "--no-limit"})
+	cmd.SetOut(stdout)
+
+	err = cmd.Execute()
+	require.NoError(t, err)
+
+	output := stdout.String()
+	assert.Contains(t, output, "7tf000000000001")
+	assert.Contains(t, output, "USER_DEBUG")
+	assert.Contains(t, output, "7tf000000000002")
+	assert.Contains(t, output, "CLASS_TRACING")
+	assert.Contains(t, output, "2 record(s)")
+	assert.NotContains(t, output, "attributes")
+}
+
+func TestQueryCommand_ToolingRejectsAll(t *testing.T) {
+	opts := &root.Options{
+		Output: "table",
+		Stdout: &bytes.Buffer{},
+		Stderr: &bytes.Buffer{},
+	}
+
+	cmd := NewCommand(opts)
+	cmd.SetArgs([]string{"SELECT Id FROM TraceFlag", "--tooling", "--all"})
+
+	err := cmd.Execute()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "--all is not supported with --tooling")
+}
+
+func newDocumentServer(t *testing.T, body interface{}) *httptest.Server {
+	t.Helper()
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/services/data/v62.0/query":
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"totalSize": 1,
+				"done":      true,
+				"records": []map[string]interface{}{{
+					"attributes": map[string]interface{}{"type": "Document"},
+					"Id":         "015xx000001",
+					"Name":       "Logo",
+					"Body":       body,
+					"BodyLength": 4,
+				}},
+			})
+		case "/services/data/v62.0/sobjects/Document/describe":
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(api.SObjectDescribe{
+				Name: "Document",
+				Fields: []api.Field{
+					{Name: "Id", Type: "id"},
+					{Name: "Name", Type: "string"},
+					{Name: "Body", Type: "base64"},
+					{Name: "BodyLength", Type: "int"},
+				},
+			})
+		case "/services/data/v62.0/sobjects/Document/015xx000001/Body":
+			_, _ = w.Write([]byte{0x89, 'P', 'N', 'G'})
+		default:
+			t.Errorf("unexpected request: %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestQueryCommand_BinaryPlaceholder(t *testing.T) {
+	server := newDocumentServer(t, "/services/data/v62.0/sobjects/Document/015xx000001/Body")
+	defer server.Close()
+
+	client, err := api.New(api.ClientConfig{
+		InstanceURL: server.URL,
+		HTTPClient:  server.Client(),
+	})
+	require.NoError(t, err)
+
+	stdout := &bytes.Buffer{}
+	opts := &root.Options{
+		Output: "table",
+		Stdout: stdout,
+		Stderr: &bytes.Buffer{},
+	}
+	opts.SetAPIClient(client)
+
+	cmd := NewCommand(opts)
+	cmd.SetArgs([]string{"SELECT Id, Name, Body, BodyLength FROM Document"})
+	cmd.SetOut(stdout)
+
+	err = cmd.Execute()
+	require.NoError(t, err)
+
+	output := stdout.String()
+	assert.Contains(t, output, "<4 bytes>")
+	assert.NotContains(t, output, "/sobjects/Document/015xx000001/Body")
+}
+
+func TestQueryCommand_DecodeField(t *testing.T) {
+	server := newDocumentServer(t, "/services/data/v62.0/sobjects/Document/015xx000001/Body")
+	defer server.Close()
+
+	client, err := api.New(api.ClientConfig{
+		InstanceURL: server.URL,
+		HTTPClient:  server.Client(),
+	})
+	require.NoError(t, err)
+
+	out := filepath.Join(t.TempDir(), "logo.png")
+	stdout := &bytes.Buffer{}
+	opts := &root.Options{
+		Output: "table",
+		Stdout: stdout,
+		Stderr: &bytes.Buffer{},
+	}
+	opts.SetAPIClient(client)
+
+	cmd := NewCommand(opts)
+	cmd.SetArgs([]string{"SELECT Id, Body FROM Document", "--decode-field", "Body", "--out", out})
+	cmd.SetOut(stdout)
+
+	err = cmd.Execute()
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(out)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x89, 'P', 'N', 'G'}, data)
+	assert.Contains(t, stdout.String(), "Saved 4 bytes")
+}
+
+func TestQueryCommand_DecodeFieldNotBinary(t *testing.T) {
+	server := newDocumentServer(t, "/services/data/v62.0/sobjects/Document/015xx000001/Body")
+	defer server.Close()
+
+	client, err := api.New(api.ClientConfig{
+		InstanceURL: server.URL,
+		HTTPClient:  server.Client(),
+	})
+	require.NoError(t, err)
+
+	opts := &root.Options{
+		Output: "table",
+		Stdout: &bytes.Buffer{},
+		Stderr: &bytes.Buffer{},
+	}
+	opts.SetAPIClient(client)
+
+	cmd := NewCommand(opts)
+	cmd.SetArgs([]string{"SELECT Id, Name FROM Document", "--decode-field", "Name", "--out", filepath.Join(t.TempDir(), "x")})
+
+	err = cmd.Execute()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "not a base64 field")
+}
+
+func TestQueryCommand_DecodeFieldRequiresOut(t *testing.T) {
+	opts := &root.Options{
+		Output: "table",
+		Stdout: &bytes.Buffer{},
+		Stderr: &bytes.Buffer{},
+	}
+
+	cmd := NewCommand(opts)
+	cmd.SetArgs([]string{"SELECT Id, Body FROM Document", "--decode-field", "Body"})
+
+	err := cmd.Execute()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "must be used together")
+}
+
+func TestQueryCommand_Typed(t *testing.T) {
+	t.Setenv(config.HomeEnvVar, t.TempDir())
+
+	describeCalls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if strings.HasSuffix(r.URL.Path, "/describe") {
+			describeCalls++
+			_ = json.NewEncoder(w).Encode(api.SObjectDescribe{
+				Name: "Account",
+				Fields: []api.Field{
+					{Name: "Id", Type: "id"},
+					{Name: "AnnualRevenue", Type: "currency", Scale: 2},
+					{Name: "IsPartner", Type: "boolean"},
+					{Name: "CreatedDate", Type: "datetime"},
+				},
+			})
+			return
+		}
+		if strings.Contains(r.URL.RawQuery, "FROM+Organization") {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"totalSize": 1,
+				"done":      true,
+				"records": []map[string]interface{}{
+					{"attributes": map[string]interface{}{"type": "Organization"}, "Id": "00Dxx0000001gPL", "Name": "Acme", "DefaultCurrencyIsoCode": "USD"},
+				},
+			})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"totalSize": 2,
+			"done":      true,
+			"records": []map[string]interface{}{
+				{"attributes": map[string]interface{}{"type": "Account"}, "Id": "001xx000001", "AnnualRevenue": 5000000.0, "IsPartner": true, "CreatedDate": "2024-01-15T12:00:00.000+0000"},
+				{"attributes": map[string]interface{}{"type": "Account"}, "Id": "001xx000002", "AnnualRevenue": 250.0, "IsPartner": false, "CreatedDate": nil},
+			},
+		})
+	}))
+	defer server.Close()
+
+	client, err := api.New(api.ClientConfig{
+		InstanceURL: server.URL,
+		HTTPClient:  server.Client(),
+	})
+	require.NoError(t, err)
+
+	run := func(args ...string) (string, error) {
+		stdout := &bytes.Buffer{}
+		opts := &root.Options{
+			Output: "table",
+			Stdout: stdout,
+			Stderr: &bytes.Buffer{},
+		}
+		opts.SetAPIClient(client)
+
+		cmd := NewCommand(opts)
+		cmd.SetArgs(append([]string{"SELECT Id, AnnualRevenue, IsPartner, CreatedDate FROM Account"}, args...))
+		cmd.SetOut(stdout)
+
+		err := cmd.Execute()
+		return stdout.String(), err
+	}
+
+	output, err := run("--typed")
+	require.NoError(t, err)
+	assert.Contains(t, output, "USD 5,000,000.00")
+	assert.Contains(t, output, "USD 250.00")
+	assert.Contains(t, output, "yes")
+	assert.Contains(t, output, "no")
+	assert.Contains(t, output, time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC).Local().Format("2006-01-02"))
+	assert.NotContains(t, output, "12:00")
+	assert.Contains(t, output, "Legend: AnnualRevenue: currency (USD unless the record has a CurrencyIsoCode), "+
+		"CreatedDate: datetime (local date, time omitted), IsPartner: checkbox (yes/no)")
+	assert.Equal(t, 1, describeCalls)
+
+	output, err = run("--typed", "--raw-booleans")
+	require.NoError(t, err)
+	assert.Contains(t, output, "true")
+	assert.Contains(t, output, "false")
+	assert.NotContains(t, output, "IsPartner: checkbox")
+
+	_, err = run("--raw-booleans")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--raw-booleans requires --typed")
+}
+
+func TestBinaryPlaceholder(t *testing.T) {
+	inline := base64.StdEncoding.EncodeToString([]byte("hello world"))
+
+	tests := []struct {
+		name   string
+		fields map[string]interface{}
+		want   string
+	}{
+		{"inline base64", map[string]interface{}{"Body": inline}, "<11 bytes>"},
+		{"blob URL with length", map[string]interface{}{"Body": "/services/data/v62.0/sobjects/Attachment/00Pxx/Body", "BodyLength": float64(2048)}, "<2048 bytes>"},
+		{"blob URL with content size", map[string]interface{}{"VersionData": "/services/data/v62.0/sobjects/ContentVersion/068xx/VersionData", "ContentSize": float64(10)}, "<10 bytes>"},
+		{"blob URL without size", map[string]interface{}{"Body": "/services/data/v62.0/sobjects/Attachment/00Pxx/Body"}, "<binary>"},
+		{"null", map[string]interface{}{"Body": nil}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			field := "Body"
+			if _, ok := tt.fields["VersionData"]; ok {
+				field = "VersionData"
+			}
+			got := binaryPlaceholder(api.SObject{Fields: tt.fields}, field)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestQueryCommand_ToolingCSV(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/services/data/v62.0/tooling/query/01gxx0000000001-2000" {
+			_ = json.NewEncoder(w).Encode(tooling.QueryResult{
+				TotalSize: 2,
+				Done:      true,
+				Records: []tooling.Record{
+					{
+						"attributes": map[string]interface{}{"type": "ApexTestResult"},
+						"Id":         "07M000000000002",
+						"MethodName": "testUpdate",
+						"Outcome":    "Fail",
+						"ApexClass":  map[string]interface{}{"attributes": map[string]interface{}{"type": "ApexClass"}, "Name": "MyTest"},
+						"RunTime":    float64(12),
+					},
+				},
+			})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(tooling.QueryResult{
+			TotalSize:      2,
+			Done:           false,
+			NextRecordsURL: "/services/data/v62.0/tooling/query/01gxx0000000001-2000",
+			Records: []tooling.Record{
+				{
+					"attributes": map[string]interface{}{"type": "ApexTestResult"},
+					"Id":         "07M000000000001",
+					"MethodName": "testCreate, with comma",
+					"Outcome":    "Pass",
+					"ApexClass":  map[string]interface{}{"attributes": map[string]interface{}{"type": "ApexClass"}, "Name": "MyTest"},
+					"RunTime":    float64(5),
+				},
+			},
+		})
+	}))
+	defer server.Close()
+
+	client, err := tooling.New(tooling.ClientConfig{
+		InstanceURL: server.URL,
+		HTTPClient:  server.Client(),
+	})
+	require.NoError(t, err)
+
+	want := "Id,ApexClass.Name,MethodName,Outcome\n" +
+		"07M000000000001,MyTest,\"testCreate, with comma\",Pass\n" +
+		"07M000000000002,MyTest,testUpdate,Fail\n"
+
+	t.Run("stdout", func(t *testing.T) {
+		stdout := &bytes.Buffer{}
+		opts := &root.Options{
+			Output: "csv",
+			Stdout: stdout,
+			Stderr: &bytes.Buffer{},
+		}
+		opts.SetToolingClient(client)
+
+		cmd := NewCommand(opts)
+		cmd.SetArgs([]string{"SELECT Id, ApexClass.Name, MethodName, Outcome FROM ApexTestResult", "--tooling"})
+		cmd.SetOut(stdout)
+
+		err := cmd.Execute()
+		require.NoError(t, err)
+		assert.Equal(t, want, stdout.String())
+	})
+
+	t.Run("file", func(t *testing.T) {
+		out := filepath.Join(t.TempDir(), "results.csv")
+		stdout := &bytes.Buffer{}
+		opts := &root.Options{
+			Output: "csv",
+			Stdout: stdout,
+			Stderr: &bytes.Buffer{},
+		}
+		opts.SetToolingClient(client)
+
+		cmd := NewCommand(opts)
+		cmd.SetArgs([]string{"SELECT Id, ApexClass.Name, MethodName, Outcome FROM ApexTestResult", "--tooling", "--out", out})
+		cmd.SetOut(stdout)
+
+		err := cmd.Execute()
+		require.NoError(t, err)
+
+		data, err := os.ReadFile(out)
+		require.NoError(t, err)
+		assert.Equal(t, want, string(data))
+		assert.Contains(t, stdout.String(), "Wrote 2 record(s)")
+	})
+}
+
+func TestQueryCommand_OutRequiresCSV(t *testing.T) {
+	opts := &root.Options{
+		Output: "table",
+		Stdout: &bytes.Buffer{},
+		Stderr: &bytes.Buffer{},
+	}
+
+	cmd := NewCommand(opts)
+	cmd.SetArgs([]string{"SELECT Id FROM Account", "--out", "accounts.csv"})
+
+	err := cmd.Execute()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "--out requires --decode-field or -o csv")
+}
+
+func TestSelectColumns(t *testing.T) {
+	tests := []struct {
+		soql string
+		want []string
+	}{
+		{"SELECT Id, Name FROM Account", []string{"Id", "Name"}},
+		{"select id,ApexClass.Name from ApexTestResult where Outcome = 'Fail'", []string{"id", "ApexClass.Name"}},
+		{"SELECT COUNT() FROM Account", nil},
+		{"SELECT Id, (SELECT Id FROM Contacts) FROM Account", nil},
+		{"SELECT Industry, COUNT(Id) cnt FROM Account GROUP BY Industry", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.soql, func(t *testing.T) {
+			assert.Equal(t, tt.want, selectColumns(tt.soql))
+		})
+	}
+}
+
+func TestRecordColumns(t *testing.T) {
+	records := []api.SObject{{
+		ID: "001",
+		Fields: map[string]interface{}{
+			"Name":  "Acme",
+			"Owner": map[string]interface{}{"attributes": map[string]interface{}{"type": "User"}, "Name": "Jane", "Email": "jane@example.com"},
+		},
+	}}
+
+	assert.Equal(t, []string{"Id", "Name", "Owner.Email", "Owner.Name"}, recordColumns(records))
+}
+
 func TestFormatFieldValue(t *testing.T) {
 	tests := []struct {
 		name  string
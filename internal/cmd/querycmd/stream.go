@@ -0,0 +1,89 @@
+package querycmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/open-cli-collective/salesforce-cli/api"
+	"github.com/open-cli-collective/salesforce-cli/internal/cmd/root"
+	"github.com/open-cli-collective/salesforce-cli/internal/view"
+)
+
+// streamsPages reports whether --no-limit output can be written as each
+// page arrives instead of collecting every page first. Table output needs
+// all rows up front to align its columns.
+func streamsPages(output string, flags queryFlags) bool {
+	if flags.decodeField != "" {
+		return false
+	}
+	return output == "json" || output == "plain" || output == "csv"
+}
+
+// streamQueryResult writes the records of result and every page after it,
+// holding only one page in memory. JSON output has the same shape as a
+// fully collected result.
+func streamQueryResult(ctx context.Context, opts *root.Options, describe describeFunc, queryMore queryMoreFunc, result *api.QueryResult, typed *view.TypedFormat) error {
+	if len(result.Records) == 0 {
+		opts.View().Info("No records found (totalSize: %d)", result.TotalSize)
+		return nil
+	}
+
+	it := api.NewQueryIterator(ctx, result, queryMore)
+
+	if opts.Output == "json" {
+		return streamJSON(opts.Stdout, it)
+	}
+
+	cells, err := newCellFormatter(ctx, describe, result.Records, typed)
+	if err != nil {
+		return err
+	}
+
+	v := opts.View()
+	headers := extractHeaders(result.Records)
+	for it.Next() {
+		if err := v.Plain(extractRows([]api.SObject{it.Record()}, headers, cells)); err != nil {
+			return err
+		}
+	}
+	if err := it.Err(); err != nil {
+		return fmt.Errorf("query failed: %w", err)
+	}
+
+	return nil
+}
+
+// streamJSON writes the iterator's records as an indented query result,
+// encoding one record at a time.
+func streamJSON(w io.Writer, it *api.QueryIterator) error {
+	count := 0
+	for it.Next() {
+		if count == 0 {
+			if _, err := fmt.Fprintf(w, "{\n  \"totalSize\": %d,\n  \"done\": true,\n  \"records\": [\n", it.TotalSize()); err != nil {
+				return err
+			}
+		} else if _, err := io.WriteString(w, ",\n"); err != nil {
+			return err
+		}
+
+		data, err := json.MarshalIndent(it.Record(), "    ", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode record: %w", err)
+		}
+		if _, err := fmt.Fprintf(w, "    %s", data); err != nil {
+			return err
+		}
+		count++
+	}
+	if err := it.Err(); err != nil {
+		if count > 0 {
+			_, _ = io.WriteString(w, "\n")
+		}
+		return fmt.Errorf("query failed: %w", err)
+	}
+
+	_, err := io.WriteString(w, "\n  ]\n}\n")
+	return err
+}
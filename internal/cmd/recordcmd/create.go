@@ -3,25 +3,35 @@ package recordcmd
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"strings"
 
 	"github.com/spf13/cobra"
 
+	"github.com/open-cli-collective/salesforce-cli/api"
 	"github.com/open-cli-collective/salesforce-cli/internal/cmd/root"
 )
 
 func newCreateCommand(opts *root.Options) *cobra.Command {
-	var setFlags []string
+	var (
+		setFlags []string
+		call     callFlags
+	)
 
 	cmd := &cobra.Command{
 		Use:   "create <object>",
 		Short: "Create a new record",
 		Long: `Create a new Salesforce record.
 
+With --allow-duplicates, the record is saved even if a duplicate rule that
+allows it would otherwise block it. With --no-auto-assign, lead and case
+assignment rules are not run.
+
 Examples:
   sfdc record create Account --set Name="Acme Corp"
   sfdc record create Contact --set FirstName=John --set LastName=Doe --set Email=john@example.com
-  sfdc record create Account --set Name="Test" -o json`,
+  sfdc record create Account --set Name="Test" -o json
+  sfdc record create Lead --set LastName=Doe --set Company=Acme --allow-duplicates --no-auto-assign`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			fields, err := parseSetFlags(setFlags)
@@ -31,22 +41,34 @@ Examples:
 			if len(fields) == 0 {
 				return fmt.Errorf("at least one --set flag is required")
 			}
-			return runCreate(cmd.Context(), opts, args[0], fields)
+			return runCreate(cmd.Context(), opts, args[0], fields, call.options())
 		},
 	}
 
 	cmd.Flags().StringArrayVar(&setFlags, "set", nil, "Set field value (format: Field=Value)")
+	call.register(cmd)
 
 	return cmd
 }
 
-func runCreate(ctx context.Context, opts *root.Options, objectName string, fields map[string]interface{}) error {
+func runCreate(ctx context.Context, opts *root.Options, objectName string, fields map[string]interface{}, callOpts api.CallOptions) error {
 	client, err := opts.APIClient()
 	if err != nil {
 		return fmt.Errorf("failed to create API client: %w", err)
 	}
 
-	result, err := client.CreateRecord(ctx, objectName, fields)
+	if opts.DryRun {
+		return opts.PrintDryRun(root.DryRunRequest{
+			Operation: "create",
+			Object:    objectName,
+			Method:    http.MethodPost,
+			URL:       client.ResourceURL(fmt.Sprintf("/sobjects/%s/", objectName)),
+			Payload:   fields,
+			Details:   headerDetails(nil, callOpts.Header()),
+		})
+	}
+
+	result, err := client.CreateRecord(api.WithCallOptions(ctx, callOpts), objectName, fields)
 	if err != nil {
 		return fmt.Errorf("failed to create record: %w", err)
 	}
@@ -59,7 +81,7 @@ func runCreate(ctx context.Context, opts *root.Options, objectName string, field
 
 	if result.Success {
 		v.Success("Created %s record: %s", objectName, result.ID)
-		v.Info("URL: %s", client.RecordURL(result.ID))
+		v.Info("URL: %s", v.Link(client.RecordURL(result.ID)))
 	} else {
 		v.Error("Failed to create record")
 		for _, e := range result.Errors {
@@ -70,6 +92,33 @@ func runCreate(ctx context.Context, opts *root.Options, objectName string, field
 	return nil
 }
 
+// callFlags are the flags of record writes that set Salesforce call options.
+type callFlags struct {
+	allowDuplicates bool
+	noAutoAssign    bool
+}
+
+func (f *callFlags) register(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&f.allowDuplicates, "allow-duplicates", false, "Save even if a duplicate rule would block the record")
+	cmd.Flags().BoolVar(&f.noAutoAssign, "no-auto-assign", false, "Do not run lead and case assignment rules")
+}
+
+func (f callFlags) options() api.CallOptions {
+	return api.CallOptions{AllowDuplicates: f.allowDuplicates, NoAutoAssign: f.noAutoAssign}
+}
+
+// headerDetails adds request headers to dry-run details, keyed by their
+// lowercase names. It returns nil if there are neither.
+func headerDetails(details map[string]interface{}, header http.Header) map[string]interface{} {
+	for name := range header {
+		if details == nil {
+			details = make(map[string]interface{})
+		}
+		details[strings.ToLower(name)] = header.Get(name)
+	}
+	return details
+}
+
 // parseSetFlags parses --set flags into a map of field values
 func parseSetFlags(flags []string) (map[string]interface{}, error) {
 	result := make(map[string]interface{})
@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"context"
 	"fmt"
+	"net/http"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -36,6 +37,19 @@ Examples:
 func runDelete(ctx context.Context, opts *root.Options, objectName, recordID string, confirm bool) error {
 	v := opts.View()
 
+	if opts.DryRun {
+		client, err := opts.APIClient()
+		if err != nil {
+			return fmt.Errorf("failed to create API client: %w", err)
+		}
+		return opts.PrintDryRun(root.DryRunRequest{
+			Operation: "delete",
+			Object:    objectName,
+			Method:    http.MethodDelete,
+			URL:       client.ResourceURL(fmt.Sprintf("/sobjects/%s/%s", objectName, recordID)),
+		})
+	}
+
 	// Prompt for confirmation if not confirmed
 	if !confirm {
 		v.Print("Delete %s record %s? [y/N]: ", objectName, recordID)
@@ -8,23 +8,41 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/open-cli-collective/salesforce-cli/api"
 	"github.com/open-cli-collective/salesforce-cli/internal/cmd/root"
+	"github.com/open-cli-collective/salesforce-cli/internal/view"
 )
 
 func newGetCommand(opts *root.Options) *cobra.Command {
-	var fields string
+	var (
+		fields      string
+		withHistory bool
+		typed       bool
+		rawBooleans bool
+	)
 
 	cmd := &cobra.Command{
 		Use:   "get <object> <id>",
 		Short: "Get a record by ID",
 		Long: `Retrieve a Salesforce record by its ID.
 
+With --typed, values are formatted according to their field type: currency
+with thousands separators, decimals, and the record's currency (or the org's
+default currency), percent with %, datetimes as their date in local time,
+and checkboxes as yes/no unless --raw-booleans is given. A legend of the
+formatted fields follows the record.
+
 Examples:
   sfdc record get Account 001xx000003DGbYAAW
   sfdc record get Contact 003xx000001abcd --fields Name,Email,Phone
+  sfdc record get Account 001xx000003DGbYAAW --with-history
+  sfdc record get Opportunity 006xx000001abcd --typed
   sfdc record get Account 001xx000003DGbYAAW -o json`,
 		Args: cobra.ExactArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if rawBooleans && !typed {
+				return fmt.Errorf("--raw-booleans requires --typed")
+			}
 			var fieldList []string
 			if fields != "" {
 				fieldList = strings.Split(fields, ",")
@@ -32,16 +50,23 @@ Examples:
 					fieldList[i] = strings.TrimSpace(fieldList[i])
 				}
 			}
-			return runGet(cmd.Context(), opts, args[0], args[1], fieldList)
+			var format *view.TypedFormat
+			if typed {
+				format = &view.TypedFormat{YesNo: !rawBooleans}
+			}
+			return runGet(cmd.Context(), opts, args[0], args[1], fieldList, withHistory, format)
 		},
 	}
 
 	cmd.Flags().StringVar(&fields, "fields", "", "Comma-separated list of fields to retrieve")
+	cmd.Flags().BoolVar(&withHistory, "with-history", false, "Include the record's field history")
+	cmd.Flags().BoolVar(&typed, "typed", false, "Format values by field type (currency, percent, date, datetime, checkbox)")
+	cmd.Flags().BoolVar(&rawBooleans, "raw-booleans", false, "With --typed, show checkboxes as true/false instead of yes/no")
 
 	return cmd
 }
 
-func runGet(ctx context.Context, opts *root.Options, objectName, recordID string, fields []string) error {
+func runGet(ctx context.Context, opts *root.Options, objectName, recordID string, fields []string, withHistory bool, typed *view.TypedFormat) error {
 	client, err := opts.APIClient()
 	if err != nil {
 		return fmt.Errorf("failed to create API client: %w", err)
@@ -52,12 +77,49 @@ func runGet(ctx context.Context, opts *root.Options, objectName, recordID string
 		return fmt.Errorf("failed to get record: %w", err)
 	}
 
+	var (
+		history        []api.SObject
+		historyTracked bool
+	)
+	if withHistory {
+		history, historyTracked, err = fieldHistory(ctx, client, objectName, recordID)
+		if err != nil {
+			return fmt.Errorf("failed to get field history: %w", err)
+		}
+	}
+
 	v := opts.View()
 
 	if opts.Output == "json" {
+		if withHistory {
+			if history == nil {
+				history = []api.SObject{}
+			}
+			return v.JSON(map[string]interface{}{
+				"record":         record,
+				"history":        history,
+				"historyTracked": historyTracked,
+			})
+		}
 		return v.JSON(record)
 	}
 
+	var fieldTypes map[string]api.Field
+	if typed != nil {
+		desc, err := client.DescribeSObject(ctx, objectName)
+		if err != nil {
+			return fmt.Errorf("failed to describe object: %w", err)
+		}
+		fieldTypes = make(map[string]api.Field, len(desc.Fields))
+		for _, f := range desc.Fields {
+			fieldTypes[f.Name] = f
+		}
+
+		// Without the org's default currency, amounts are just shown
+		// without a currency unless the record has CurrencyIsoCode
+		typed.DefaultCurrency, _ = opts.DefaultCurrency(ctx)
+	}
+
 	// Display as key-value pairs
 	v.Info("Object: %s", record.Attributes.Type)
 	v.Info("ID: %s", record.ID)
@@ -70,18 +132,71 @@ func runGet(ctx context.Context, opts *root.Options, objectName, recordID string
 	}
 	sort.Strings(fieldNames)
 
+	currencyCode := record.GetString("CurrencyIsoCode")
+	var typedNames, typedTypes []string
 	for _, name := range fieldNames {
 		value := formatFieldValue(record.Fields[name])
+		if f, ok := fieldTypes[name]; ok {
+			typedNames = append(typedNames, name)
+			typedTypes = append(typedTypes, f.Type)
+			if record.Fields[name] != nil {
+				if s, ok := typed.Format(record.Fields[name], f.Type, f.Scale, currencyCode); ok {
+					value = s
+				}
+			}
+		}
 		v.Info("%s: %s", name, value)
 	}
 
+	if typed != nil {
+		if legend := typed.Legend(typedNames, typedTypes); legend != "" {
+			v.Info("")
+			v.Info("%s", legend)
+		}
+	}
+
+	if withHistory {
+		v.Info("")
+		if err := renderHistory(opts, objectName, history, historyTracked); err != nil {
+			return err
+		}
+	}
+
 	// Show record URL
 	v.Info("")
-	v.Info("URL: %s", client.RecordURL(record.ID))
+	v.Info("URL: %s", v.Link(client.RecordURL(record.ID)))
 
 	return nil
 }
 
+// renderHistory displays field history rows as a change log table.
+func renderHistory(opts *root.Options, objectName string, history []api.SObject, tracked bool) error {
+	v := opts.View()
+
+	if !tracked {
+		v.Info("History: field history tracking is not enabled for %s", objectName)
+		return nil
+	}
+	if len(history) == 0 {
+		v.Info("History: no changes recorded")
+		return nil
+	}
+
+	v.Info("History:")
+	headers := []string{"Date", "Field", "Old Value", "New Value", "Changed By"}
+	rows := make([][]string, 0, len(history))
+	for _, h := range history {
+		rows = append(rows, []string{
+			h.GetString("CreatedDate"),
+			h.GetString("Field"),
+			formatFieldValue(h.Fields["OldValue"]),
+			formatFieldValue(h.Fields["NewValue"]),
+			formatFieldValue(h.Fields["CreatedBy"]),
+		})
+	}
+	return v.Table(headers, rows)
+}
+
 // formatFieldValue converts a field value to a string for display
 func formatFieldValue(v interface{}) string {
 	if v == nil {
@@ -0,0 +1,58 @@
+package recordcmd
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/open-cli-collective/salesforce-cli/api"
+	"github.com/open-cli-collective/salesforce-cli/api/soql"
+)
+
+// historyObject returns the field history object name and the field on it that
+// references the parent record for the given object.
+//
+// Standard objects use <Object>History with an <Object>Id lookup (Opportunity is
+// the exception with OpportunityFieldHistory). Custom objects use
+// <Name>__History with a ParentId lookup.
+func historyObject(objectName string) (name, parentField string) {
+	if strings.HasSuffix(objectName, "__c") {
+		return strings.TrimSuffix(objectName, "__c") + "__History", "ParentId"
+	}
+	if objectName == "Opportunity" {
+		return "OpportunityFieldHistory", "OpportunityId"
+	}
+	return objectName + "History", objectName + "Id"
+}
+
+// fieldHistory returns the field history rows for a record, newest first.
+// It returns ok=false if the object has no history object (history tracking
+// is not enabled).
+func fieldHistory(ctx context.Context, client *api.Client, objectName, recordID string) (records []api.SObject, ok bool, err error) {
+	historyName, parentField := historyObject(objectName)
+
+	q, err := soql.Select("Field", "OldValue", "NewValue", "CreatedDate", "CreatedBy.Name").
+		From(historyName).
+		Where(parentField+" = :id").
+		Bind("id", recordID).
+		OrderBy("CreatedDate DESC").
+		Build()
+	if err != nil {
+		return nil, false, err
+	}
+
+	result, err := client.QueryAll(ctx, q)
+	if err != nil {
+		var apiErr *api.APIError
+		if errors.As(err, &apiErr) {
+			for _, e := range apiErr.Errors {
+				if e.ErrorCode == "INVALID_TYPE" {
+					return nil, false, nil
+				}
+			}
+		}
+		return nil, false, err
+	}
+
+	return result.Records, true, nil
+}
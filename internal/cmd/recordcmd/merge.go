@@ -0,0 +1,118 @@
+package recordcmd
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/salesforce-cli/api"
+	"github.com/open-cli-collective/salesforce-cli/internal/cmd/root"
+)
+
+func newMergeCommand(opts *root.Options) *cobra.Command {
+	var confirm bool
+
+	cmd := &cobra.Command{
+		Use:   "merge <object> <master-id> <duplicate-id> [duplicate-id]",
+		Short: "Merge duplicate records into a master record",
+		Long: `Merge up to two duplicate records into a master record.
+
+The duplicates are deleted and their related records (contacts, opportunities,
+activities, etc.) are re-parented to the master record. Merge is supported for
+Account, Contact, and Lead.
+
+Examples:
+  sfdc record merge Account 001xx000003DGbYAAW 001xx000003DGbZAAW
+  sfdc record merge Contact 003xx000001abcd 003xx000001abce 003xx000001abcf --confirm`,
+		Args: cobra.RangeArgs(3, 2+api.MaxMergeRecords),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runMerge(cmd.Context(), opts, args[0], args[1], args[2:], confirm)
+		},
+	}
+
+	cmd.Flags().BoolVar(&confirm, "confirm", false, "Skip confirmation prompt")
+
+	return cmd
+}
+
+func runMerge(ctx context.Context, opts *root.Options, objectName, masterID string, mergeIDs []string, confirm bool) error {
+	if !api.IsMergeable(objectName) {
+		return fmt.Errorf("%w: %s", api.ErrMergeNotSupported, objectName)
+	}
+
+	v := opts.View()
+
+	if opts.DryRun {
+		client, err := opts.APIClient()
+		if err != nil {
+			return fmt.Errorf("failed to create API client: %w", err)
+		}
+		return opts.PrintDryRun(root.DryRunRequest{
+			Operation: "merge",
+			Object:    objectName,
+			Method:    http.MethodPost,
+			URL:       client.SOAPURL(),
+			Payload: map[string]interface{}{
+				"masterRecordId":   masterID,
+				"recordToMergeIds": mergeIDs,
+			},
+		})
+	}
+
+	// Prompt for confirmation if not confirmed
+	if !confirm {
+		v.Print("Merge %s into %s record %s? The merged records will be deleted. [y/N]: ",
+			strings.Join(mergeIDs, ", "), objectName, masterID)
+		reader := bufio.NewReader(opts.Stdin)
+		response, err := reader.ReadString('\n')
+		if err != nil {
+			return fmt.Errorf("failed to read input: %w", err)
+		}
+		response = strings.TrimSpace(strings.ToLower(response))
+		if response != "y" && response != "yes" {
+			v.Info("Cancelled")
+			return nil
+		}
+	}
+
+	client, err := opts.APIClient()
+	if err != nil {
+		return fmt.Errorf("failed to create API client: %w", err)
+	}
+
+	result, err := client.MergeRecords(ctx, objectName, masterID, mergeIDs)
+	if err != nil {
+		return fmt.Errorf("failed to merge records: %w", err)
+	}
+
+	if opts.Output == "json" {
+		return v.JSON(result)
+	}
+
+	v.Success("Merged %d %s record(s) into %s", len(result.MergedRecordIDs), objectName, result.ID)
+	v.Info("URL: %s", v.Link(client.RecordURL(result.ID)))
+	for _, id := range result.MergedRecordIDs {
+		v.Info("  Deleted: %s", id)
+	}
+
+	if len(result.UpdatedRelatedIDs) == 0 {
+		v.Info("\nNo related records were re-parented")
+		return nil
+	}
+
+	v.Info("\nRe-parented related records:")
+	rows := make([][]string, 0, len(result.UpdatedRelatedIDs))
+	for _, id := range result.UpdatedRelatedIDs {
+		rows = append(rows, []string{id})
+	}
+	if err := v.Table([]string{"Id"}, rows); err != nil {
+		return err
+	}
+
+	v.Info("\n%d related record(s) updated", len(result.UpdatedRelatedIDs))
+	return nil
+}
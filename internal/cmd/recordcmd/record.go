@@ -17,13 +17,18 @@ func NewCommand(opts *root.Options) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "record",
 		Short: "Work with Salesforce records",
-		Long:  "Get, create, update, and delete Salesforce records.",
+		Long:  "Get, create, update, upsert, delete, and merge Salesforce records, import record trees, and list recently deleted or updated records.",
 	}
 
 	cmd.AddCommand(newGetCommand(opts))
 	cmd.AddCommand(newCreateCommand(opts))
 	cmd.AddCommand(newUpdateCommand(opts))
+	cmd.AddCommand(newUpsertCommand(opts))
 	cmd.AddCommand(newDeleteCommand(opts))
+	cmd.AddCommand(newMergeCommand(opts))
+	cmd.AddCommand(newTreeCommand(opts))
+	cmd.AddCommand(newDeletedCommand(opts))
+	cmd.AddCommand(newUpdatedCommand(opts))
 
 	return cmd
 }
@@ -2,16 +2,24 @@ package recordcmd
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
 
 	"github.com/open-cli-collective/salesforce-cli/api"
 	"github.com/open-cli-collective/salesforce-cli/internal/cmd/root"
+	"github.com/open-cli-collective/salesforce-cli/internal/config"
 )
 
 func TestGetCommand(t *testing.T) {
@@ -62,6 +70,93 @@ func TestGetCommand(t *testing.T) {
 	assert.Contains(t, output, "Technology")
 }
 
+func TestGetCommand_Typed(t *testing.T) {
+	t.Setenv(config.HomeEnvVar, t.TempDir())
+
+	record := api.SObject{
+		ID:         "006xx000001",
+		Attributes: api.SObjectAttributes{Type: "Opportunity"},
+		Fields: map[string]interface{}{
+			"Name":        "Big Deal",
+			"Amount":      1250000.0,
+			"Probability": 75.0,
+			"IsPrivate":   false,
+			"CloseDate":   "2024-03-31",
+		},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if strings.HasSuffix(r.URL.Path, "/describe") {
+			_ = json.NewEncoder(w).Encode(api.SObjectDescribe{
+				Name: "Opportunity",
+				Fields: []api.Field{
+					{Name: "Name", Type: "string"},
+					{Name: "Amount", Type: "currency", Scale: 2},
+					{Name: "Probability", Type: "percent", Scale: 0},
+					{Name: "IsPrivate", Type: "boolean"},
+					{Name: "CloseDate", Type: "date"},
+				},
+			})
+			return
+		}
+		if strings.Contains(r.URL.RawQuery, "FROM+Organization") {
+			_ = json.NewEncoder(w).Encode(api.QueryResult{
+				TotalSize: 1,
+				Done:      true,
+				Records: []api.SObject{{
+					ID:     "00Dxx0000001gPL",
+					Fields: map[string]interface{}{"Name": "Acme", "DefaultCurrencyIsoCode": "USD"},
+				}},
+			})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(record)
+	}))
+	defer server.Close()
+
+	client, err := api.New(api.ClientConfig{
+		InstanceURL: server.URL,
+		HTTPClient:  server.Client(),
+	})
+	require.NoError(t, err)
+
+	for _, args := range [][]string{nil, {"--typed"}, {"--typed", "--raw-booleans"}} {
+		stdout := &bytes.Buffer{}
+		opts := &root.Options{
+			Output: "table",
+			Stdout: stdout,
+			Stderr: &bytes.Buffer{},
+		}
+		opts.SetAPIClient(client)
+
+		cmd := newGetCommand(opts)
+		cmd.SetArgs(append([]string{"Opportunity", "006xx000001"}, args...))
+		cmd.SetOut(stdout)
+
+		err = cmd.Execute()
+		require.NoError(t, err)
+
+		output := stdout.String()
+		switch len(args) {
+		case 1:
+			assert.Contains(t, output, "Amount: USD 1,250,000.00")
+			assert.Contains(t, output, "Probability: 75%")
+			assert.Contains(t, output, "IsPrivate: no")
+			assert.Contains(t, output, "CloseDate: 2024-03-31")
+			assert.Contains(t, output, "Legend: Amount: currency (USD unless the record has a CurrencyIsoCode), "+
+				"CloseDate: date, IsPrivate: checkbox (yes/no), Probability: percent")
+		case 2:
+			assert.Contains(t, output, "IsPrivate: false")
+			assert.NotContains(t, output, "checkbox")
+		default:
+			assert.Contains(t, output, "Amount: 1250000")
+			assert.Contains(t, output, "Probability: 75\n")
+			assert.Contains(t, output, "IsPrivate: false")
+		}
+	}
+}
+
 func TestGetCommand_WithFields(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// Verify fields parameter is passed
@@ -98,6 +193,110 @@ func TestGetCommand_WithFields(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestGetCommand_WithHistory(t *testing.T) {
+	tests := []struct {
+		name         string
+		historyResp  func(w http.ResponseWriter)
+		wantContains []string
+	}{
+		{
+			name: "tracked object",
+			historyResp: func(w http.ResponseWriter) {
+				w.Header().Set("Content-Type", "application/json")
+				_ = json.NewEncoder(w).Encode(api.QueryResult{
+					TotalSize: 1,
+					Done:      true,
+					Records: []api.SObject{
+						{Fields: map[string]interface{}{
+							"Field":       "Industry",
+							"OldValue":    "Retail",
+							"NewValue":    "Technology",
+							"CreatedDate": "2024-01-15T10:30:00.000+0000",
+							"CreatedBy":   map[string]interface{}{"Name": "Jane Admin"},
+						}},
+					},
+				})
+			},
+			wantContains: []string{"History:", "Industry", "Retail", "Technology", "Jane Admin"},
+		},
+		{
+			name: "untracked object",
+			historyResp: func(w http.ResponseWriter) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`[{"errorCode":"INVALID_TYPE","message":"sObject type 'AccountHistory' is not supported."}]`))
+			},
+			wantContains: []string{"Acme Corp", "field history tracking is not enabled for Account"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if strings.HasSuffix(r.URL.Path, "/query") {
+					assert.Contains(t, r.URL.Query().Get("q"), "FROM AccountHistory WHERE AccountId = '001xx000001'")
+					tt.historyResp(w)
+					return
+				}
+				w.Header().Set("Content-Type", "application/json")
+				_ = json.NewEncoder(w).Encode(api.SObject{
+					ID:         "001xx000001",
+					Attributes: api.SObjectAttributes{Type: "Account"},
+					Fields:     map[string]interface{}{"Name": "Acme Corp"},
+				})
+			}))
+			defer server.Close()
+
+			client, err := api.New(api.ClientConfig{
+				InstanceURL: server.URL,
+				HTTPClient:  server.Client(),
+			})
+			require.NoError(t, err)
+
+			stdout := &bytes.Buffer{}
+			opts := &root.Options{
+				Output: "table",
+				Stdout: stdout,
+				Stderr: &bytes.Buffer{},
+			}
+			opts.SetAPIClient(client)
+
+			cmd := newGetCommand(opts)
+			cmd.SetArgs([]string{"Account", "001xx000001", "--with-history"})
+			cmd.SetOut(stdout)
+
+			err = cmd.Execute()
+			require.NoError(t, err)
+
+			output := stdout.String()
+			for _, want := range tt.wantContains {
+				assert.Contains(t, output, want)
+			}
+		})
+	}
+}
+
+func TestHistoryObject(t *testing.T) {
+	tests := []struct {
+		object     string
+		wantName   string
+		wantParent string
+	}{
+		{"Account", "AccountHistory", "AccountId"},
+		{"Case", "CaseHistory", "CaseId"},
+		{"Opportunity", "OpportunityFieldHistory", "OpportunityId"},
+		{"Invoice__c", "Invoice__History", "ParentId"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.object, func(t *testing.T) {
+			name, parent := historyObject(tt.object)
+			assert.Equal(t, tt.wantName, name)
+			assert.Equal(t, tt.wantParent, parent)
+		})
+	}
+}
+
 func TestCreateCommand(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		assert.Equal(t, http.MethodPost, r.Method)
@@ -143,6 +342,49 @@ func TestCreateCommand(t *testing.T) {
 	assert.Contains(t, output, "001xx000001")
 }
 
+func TestWriteCommands_CallOptions(t *testing.T) {
+	tests := []struct {
+		name string
+		cmd  func(*root.Options) *cobra.Command
+		args []string
+	}{
+		{"create", newCreateCommand, []string{"Lead", "--set", "LastName=Doe"}},
+		{"update", newUpdateCommand, []string{"Lead", "00Qxx0000001", "--set", "LastName=Doe"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var header http.Header
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				header = r.Header.Clone()
+				if r.Method == http.MethodPatch {
+					w.WriteHeader(http.StatusNoContent)
+					return
+				}
+				_, _ = w.Write([]byte(`{"id":"00Qxx0000001","success":true,"errors":[]}`))
+			}))
+			defer server.Close()
+
+			client, err := api.New(api.ClientConfig{InstanceURL: server.URL, HTTPClient: server.Client()})
+			require.NoError(t, err)
+
+			opts := &root.Options{
+				Output: "table",
+				Stdout: &bytes.Buffer{},
+				Stderr: &bytes.Buffer{},
+			}
+			opts.SetAPIClient(client)
+
+			cmd := tt.cmd(opts)
+			cmd.SetArgs(append(tt.args, "--allow-duplicates", "--no-auto-assign"))
+			require.NoError(t, cmd.Execute())
+
+			assert.Equal(t, "allowSave=true", header.Get("Sforce-Duplicate-Rule-Header"))
+			assert.Equal(t, "FALSE", header.Get("Sforce-Auto-Assign"))
+		})
+	}
+}
+
 func TestCreateCommand_NoFields(t *testing.T) {
 	opts := &root.Options{
 		Output: "table",
@@ -158,6 +400,132 @@ func TestCreateCommand_NoFields(t *testing.T) {
 	assert.Contains(t, err.Error(), "at least one --set flag")
 }
 
+func TestWriteCommands_DryRun(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request in dry run: %s %s", r.Method, r.URL.Path)
+	}))
+	defer server.Close()
+
+	client, err := api.New(api.ClientConfig{
+		InstanceURL: server.URL,
+		HTTPClient:  server.Client(),
+	})
+	require.NoError(t, err)
+
+	treeFile := filepath.Join(t.TempDir(), "accounts.json")
+	require.NoError(t, os.WriteFile(treeFile, []byte(`{"records":[{"attributes":{"type":"Account","referenceId":"AcmeRef"},"Name":"Acme"}]}`), 0644))
+
+	tests := []struct {
+		name    string
+		cmd     func(*root.Options) *cobra.Command
+		args    []string
+		want    []string
+		wantURL string
+	}{
+		{
+			name:    "create",
+			cmd:     newCreateCommand,
+			args:    []string{"Account", "--set", "Name=Acme Corp"},
+			want:    []string{"Operation: create", "Object:    Account", `"Name": "Acme Corp"`},
+			wantURL: "POST " + server.URL + "/services/data/v62.0/sobjects/Account/",
+		},
+		{
+			name:    "update",
+			cmd:     newUpdateCommand,
+			args:    []string{"Account", "001xx000001", "--set", "Phone=555-0100", "--allow-duplicates"},
+			want:    []string{"Operation: update", `"Phone": "555-0100"`, "sforce-duplicate-rule-header: allowSave=true"},
+			wantURL: "PATCH " + server.URL + "/services/data/v62.0/sobjects/Account/001xx000001",
+		},
+		{
+			name:    "delete",
+			cmd:     newDeleteCommand,
+			args:    []string{"Account", "001xx000001"},
+			want:    []string{"Operation: delete"},
+			wantURL: "DELETE " + server.URL + "/services/data/v62.0/sobjects/Account/001xx000001",
+		},
+		{
+			name:    "merge",
+			cmd:     newMergeCommand,
+			args:    []string{"Account", "001xx000001", "001xx000002"},
+			want:    []string{"Operation: merge", `"masterRecordId": "001xx000001"`},
+			wantURL: "POST " + server.URL + "/services/Soap/u/62.0",
+		},
+		{
+			name:    "upsert",
+			cmd:     newUpsertCommand,
+			args:    []string{"Account", "My_Ext_Id__c", "A/1", "--set", "Name=Acme"},
+			want:    []string{"Operation: upsert", `"Name": "Acme"`},
+			wantURL: "PATCH " + server.URL + "/services/data/v62.0/sobjects/Account/My_Ext_Id__c/A%2F1",
+		},
+		{
+			name:    "tree import",
+			cmd:     newTreeCommand,
+			args:    []string{"import", treeFile},
+			want:    []string{"Operation: tree import", "records:   1"},
+			wantURL: "POST " + server.URL + "/services/data/v62.0/composite/tree/Account",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stdout := &bytes.Buffer{}
+			stderr := &bytes.Buffer{}
+			opts := &root.Options{
+				Output:  "table",
+				NoColor: true,
+				DryRun:  true,
+				Stdin:   strings.NewReader(""),
+				Stdout:  stdout,
+				Stderr:  stderr,
+			}
+			opts.SetAPIClient(client)
+
+			cmd := tt.cmd(opts)
+			cmd.SetArgs(tt.args)
+
+			require.NoError(t, cmd.Execute())
+
+			output := stdout.String()
+			assert.Contains(t, stderr.String(), "Dry run: no changes were made")
+			assert.Contains(t, output, tt.wantURL)
+			for _, want := range tt.want {
+				assert.Contains(t, output, want)
+			}
+		})
+	}
+}
+
+func TestCreateCommand_DryRunJSON(t *testing.T) {
+	client, err := api.New(api.ClientConfig{
+		InstanceURL: "https://example.my.salesforce.com",
+		HTTPClient:  http.DefaultClient,
+	})
+	require.NoError(t, err)
+
+	stdout := &bytes.Buffer{}
+	opts := &root.Options{
+		Output: "json",
+		DryRun: true,
+		Stdout: stdout,
+		Stderr: &bytes.Buffer{},
+	}
+	opts.SetAPIClient(client)
+
+	cmd := newCreateCommand(opts)
+	cmd.SetArgs([]string{"Contact", "--set", "LastName=Doe"})
+	require.NoError(t, cmd.Execute())
+
+	var got struct {
+		DryRun  bool               `json:"dryRun"`
+		Request root.DryRunRequest `json:"request"`
+	}
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &got))
+	assert.True(t, got.DryRun)
+	assert.Equal(t, "create", got.Request.Operation)
+	assert.Equal(t, http.MethodPost, got.Request.Method)
+	assert.Equal(t, map[string]interface{}{"LastName": "Doe"}, got.Request.Payload)
+}
+
 func TestUpdateCommand(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		assert.Equal(t, http.MethodPatch, r.Method)
@@ -196,6 +564,125 @@ func TestUpdateCommand(t *testing.T) {
 
 	output := stdout.String()
 	assert.Contains(t, output, "Updated")
+	assert.Contains(t, output, "URL: "+server.URL+"/001xx000001")
+}
+
+func TestUpdateCommand_IfUnmodifiedSince(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Mon, 15 Jan 2024 10:30:00 GMT", r.Header.Get("If-Unmodified-Since"))
+		w.WriteHeader(http.StatusPreconditionFailed)
+		_, _ = w.Write([]byte(`[{"errorCode":"PRECONDITION_FAILED","message":"The requested resource has been modified"}]`))
+	}))
+	defer server.Close()
+
+	client, err := api.New(api.ClientConfig{
+		InstanceURL: server.URL,
+		HTTPClient:  server.Client(),
+	})
+	require.NoError(t, err)
+
+	opts := &root.Options{
+		Output: "table",
+		Stdout: &bytes.Buffer{},
+		Stderr: &bytes.Buffer{},
+	}
+	opts.SetAPIClient(client)
+
+	cmd := newUpdateCommand(opts)
+	cmd.SetArgs([]string{"Account", "001xx000001", "--set", "Name=Acme", "--if-unmodified-since", "2024-01-15T10:30:00.000+0000"})
+
+	err = cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Account 001xx000001 was modified after 2024-01-15T10:30:00Z and was not updated")
+}
+
+func TestParseTimestamp(t *testing.T) {
+	utc := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		value   string
+		want    time.Time
+		wantErr bool
+	}{
+		{"2024-01-15T10:30:00Z", utc, false},
+		{"2024-01-15T11:30:00+01:00", utc, false},
+		{"2024-01-15T10:30:00.000+0000", utc, false},
+		{"2024-01-15T10:30:00.000Z", utc, false},
+		{"2024-01-15 10:30", time.Date(2024, 1, 15, 10, 30, 0, 0, time.Local), false},
+		{"2024-01-15", time.Date(2024, 1, 15, 0, 0, 0, 0, time.Local), false},
+		{"yesterday", time.Time{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := parseTimestamp(tt.value)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, tt.want.Equal(got), "got %s", got)
+		})
+	}
+}
+
+func TestUpsertCommand(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		response string
+		wantErr  string
+		want     string
+	}{
+		{"created", http.StatusCreated, `{"id":"001xx000001","success":true,"errors":[],"created":true}`, "", "Created Account record: 001xx000001"},
+		{"updated", http.StatusOK, `{"id":"001xx000001","success":true,"errors":[],"created":false}`, "", "Updated Account record: 001xx000001"},
+		{"updated without content", http.StatusNoContent, "", "", "Updated Account record with My_Ext_Id__c 12345"},
+		{"multiple matches", http.StatusMultipleChoices, `["/services/data/v62.0/sobjects/Account/001xx000001","/services/data/v62.0/sobjects/Account/001xx000002"]`, `My_Ext_Id__c value "12345" matches more than one Account record`, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodPatch, r.Method)
+				assert.Equal(t, "/services/data/v62.0/sobjects/Account/My_Ext_Id__c/12345", r.URL.Path)
+
+				var body map[string]interface{}
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+				assert.Equal(t, "Acme", body["Name"])
+
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.response))
+			}))
+			defer server.Close()
+
+			client, err := api.New(api.ClientConfig{
+				InstanceURL: server.URL,
+				HTTPClient:  server.Client(),
+			})
+			require.NoError(t, err)
+
+			stdout := &bytes.Buffer{}
+			opts := &root.Options{
+				Output:  "table",
+				NoColor: true,
+				Stdout:  stdout,
+				Stderr:  &bytes.Buffer{},
+			}
+			opts.SetAPIClient(client)
+
+			cmd := newUpsertCommand(opts)
+			cmd.SetArgs([]string{"Account", "My_Ext_Id__c", "12345", "--set", "Name=Acme"})
+
+			err = cmd.Execute()
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Contains(t, stdout.String(), tt.want)
+		})
+	}
 }
 
 func TestDeleteCommand_PromptYes(t *testing.T) {
@@ -294,6 +781,176 @@ func TestDeleteCommand_WithConfirm(t *testing.T) {
 	assert.Contains(t, output, "Deleted")
 }
 
+func TestDeletedCommand(t *testing.T) {
+	var start, end time.Time
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/services/data/v62.0/sobjects/Account/deleted/", r.URL.Path)
+		var err error
+		start, err = time.Parse(time.RFC3339, r.URL.Query().Get("start"))
+		require.NoError(t, err)
+		end, err = time.Parse(time.RFC3339, r.URL.Query().Get("end"))
+		require.NoError(t, err)
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"deletedRecords": [{"id": "001xx000003DGbYAAW", "deletedDate": "2024-01-15T08:12:45.000+0000"}],
+			"earliestDateAvailable": "2023-12-20T00:00:00.000+0000",
+			"latestDateCovered": "2024-01-15T10:30:00.000+0000"
+		}`))
+	}))
+	defer server.Close()
+
+	client, err := api.New(api.ClientConfig{InstanceURL: server.URL, HTTPClient: server.Client()})
+	require.NoError(t, err)
+
+	stdout := &bytes.Buffer{}
+	opts := &root.Options{
+		Output: "table",
+		Stdout: stdout,
+		Stderr: &bytes.Buffer{},
+	}
+	opts.SetAPIClient(client)
+
+	cmd := newDeletedCommand(opts)
+	cmd.SetArgs([]string{"Account", "--since", "2h"})
+
+	require.NoError(t, cmd.Execute())
+
+	assert.WithinDuration(t, time.Now(), end, time.Minute)
+	assert.WithinDuration(t, end.Add(-2*time.Hour), start, time.Second)
+
+	output := stdout.String()
+	assert.Contains(t, output, "001xx000003DGbYAAW")
+	assert.Contains(t, output, "1 deleted record(s)")
+	assert.Contains(t, output, "Covered through "+time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC).Local().Format("2006-01-02 15:04:05"))
+}
+
+func TestUpdatedCommand(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/services/data/v62.0/sobjects/Contact/updated/", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"ids": ["003xx0000001", "003xx0000002"], "latestDateCovered": "2024-01-15T10:30:00.000+0000"}`))
+	}))
+	defer server.Close()
+
+	client, err := api.New(api.ClientConfig{InstanceURL: server.URL, HTTPClient: server.Client()})
+	require.NoError(t, err)
+
+	stdout := &bytes.Buffer{}
+	opts := &root.Options{
+		Output: "json",
+		Stdout: stdout,
+		Stderr: &bytes.Buffer{},
+	}
+	opts.SetAPIClient(client)
+
+	cmd := newUpdatedCommand(opts)
+	cmd.SetArgs([]string{"Contact"})
+
+	require.NoError(t, cmd.Execute())
+
+	var result api.UpdatedResult
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &result))
+	assert.Equal(t, []string{"003xx0000001", "003xx0000002"}, result.IDs)
+}
+
+func TestReplicationFlags_TimeRange(t *testing.T) {
+	now := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+
+	start, end, err := replicationFlags{since: "24h"}.timeRange(now)
+	require.NoError(t, err)
+	assert.Equal(t, now.Add(-24*time.Hour), start)
+	assert.Equal(t, now, end)
+
+	_, _, err = replicationFlags{since: "1h", until: "2h"}.timeRange(now)
+	assert.EqualError(t, err, "--until must not be before --since")
+
+	_, _, err = replicationFlags{since: "yesterday"}.timeRange(now)
+	assert.ErrorContains(t, err, "invalid --since")
+}
+
+func TestMergeCommand(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Contains(t, r.URL.Path, "/services/Soap/u/")
+		w.Header().Set("Content-Type", "text/xml")
+		_, _ = w.Write([]byte(`<soapenv:Envelope xmlns:soapenv="http://schemas.xmlsoap.org/soap/envelope/">
+<soapenv:Body><mergeResponse><result>
+<id>001xx000001</id>
+<mergedRecordIds>001xx000002</mergedRecordIds>
+<mergedRecordIds>001xx000003</mergedRecordIds>
+<success>true</success>
+<updatedRelatedIds>003xx000009</updatedRelatedIds>
+</result></mergeResponse></soapenv:Body></soapenv:Envelope>`))
+	}))
+	defer server.Close()
+
+	ctx := context.WithValue(context.Background(), oauth2.HTTPClient, server.Client())
+	client, err := api.New(api.ClientConfig{
+		InstanceURL: server.URL,
+		HTTPClient:  oauth2.NewClient(ctx, oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "token"})),
+	})
+	require.NoError(t, err)
+
+	stdout := &bytes.Buffer{}
+	opts := &root.Options{
+		Output: "table",
+		Stdout: stdout,
+		Stderr: &bytes.Buffer{},
+	}
+	opts.SetAPIClient(client)
+
+	cmd := newMergeCommand(opts)
+	cmd.SetArgs([]string{"Account", "001xx000001", "001xx000002", "001xx000003", "--confirm"})
+	cmd.SetOut(stdout)
+
+	err = cmd.Execute()
+	require.NoError(t, err)
+
+	output := stdout.String()
+	assert.Contains(t, output, "Merged 2 Account record(s) into 001xx000001")
+	assert.Contains(t, output, "Deleted: 001xx000003")
+	assert.Contains(t, output, "003xx000009")
+	assert.Contains(t, output, "1 related record(s) updated")
+}
+
+func TestMergeCommand_Validation(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{
+			name:    "unsupported object",
+			args:    []string{"Opportunity", "006xx000001", "006xx000002", "--confirm"},
+			wantErr: "only supported for Account, Contact, and Lead",
+		},
+		{
+			name:    "too many records",
+			args:    []string{"Account", "001xx000001", "001xx000002", "001xx000003", "001xx000004", "--confirm"},
+			wantErr: "accepts between 3 and 4 arg(s)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := &root.Options{
+				Output: "table",
+				Stdout: &bytes.Buffer{},
+				Stderr: &bytes.Buffer{},
+			}
+
+			cmd := newMergeCommand(opts)
+			cmd.SetArgs(tt.args)
+			cmd.SetOut(&bytes.Buffer{})
+			cmd.SetErr(&bytes.Buffer{})
+
+			err := cmd.Execute()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
 func TestParseSetFlags(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -354,3 +1011,98 @@ func TestParseSetFlags(t *testing.T) {
 		})
 	}
 }
+
+func TestTreeImportCommand(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "accounts.json")
+	require.NoError(t, os.WriteFile(file, []byte(`{"records":[{
+		"attributes":{"type":"Account","referenceId":"AcmeRef"},
+		"Name":"Acme",
+		"Contacts":{"records":[{"attributes":{"type":"Contact","referenceId":"SmithRef"},"LastName":"Smith"}]}
+	}]}`), 0644))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/services/data/v62.0/composite/tree/Account", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"hasErrors":false,"results":[{"referenceId":"AcmeRef","id":"001xx000001"},{"referenceId":"SmithRef","id":"003xx000001"}]}`))
+	}))
+	defer server.Close()
+
+	client, err := api.New(api.ClientConfig{
+		InstanceURL: server.URL,
+		HTTPClient:  server.Client(),
+	})
+	require.NoError(t, err)
+
+	stdout := &bytes.Buffer{}
+	opts := &root.Options{
+		Output: "table",
+		Stdout: stdout,
+		Stderr: &bytes.Buffer{},
+	}
+	opts.SetAPIClient(client)
+
+	cmd := newTreeCommand(opts)
+	cmd.SetArgs([]string{"import", file})
+
+	require.NoError(t, cmd.Execute())
+
+	output := stdout.String()
+	assert.Contains(t, output, "Created 2 records from "+file)
+	assert.Contains(t, output, "AcmeRef")
+	assert.Contains(t, output, "003xx000001")
+}
+
+func TestTreeImportCommand_Errors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"hasErrors":true,"results":[{"referenceId":"AcmeRef","errors":[{"statusCode":"REQUIRED_FIELD_MISSING","message":"Required fields are missing: [Name]","fields":["Name"]}]}]}`))
+	}))
+	defer server.Close()
+
+	client, err := api.New(api.ClientConfig{
+		InstanceURL: server.URL,
+		HTTPClient:  server.Client(),
+	})
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	valid := filepath.Join(dir, "valid.json")
+	require.NoError(t, os.WriteFile(valid, []byte(`{"records":[{"attributes":{"type":"Account","referenceId":"AcmeRef"}}]}`), 0644))
+	mixed := filepath.Join(dir, "mixed.json")
+	require.NoError(t, os.WriteFile(mixed, []byte(`{"records":[{"attributes":{"type":"Account"}},{"attributes":{"type":"Contact"}}]}`), 0644))
+	untyped := filepath.Join(dir, "untyped.json")
+	require.NoError(t, os.WriteFile(untyped, []byte(`{"records":[{"Name":"Acme"}]}`), 0644))
+
+	tests := []struct {
+		name       string
+		file       string
+		wantErr    string
+		wantStderr string
+	}{
+		{"record errors", valid, "no records were created", "AcmeRef: REQUIRED_FIELD_MISSING"},
+		{"mixed types", mixed, "must all be the same type (found Account and Contact)", ""},
+		{"missing type", untyped, "record 1 has no attributes.type", ""},
+		{"missing file", filepath.Join(dir, "missing.json"), "failed to read tree file", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stderr := &bytes.Buffer{}
+			opts := &root.Options{
+				Output:  "table",
+				NoColor: true,
+				Stdout:  &bytes.Buffer{},
+				Stderr:  stderr,
+			}
+			opts.SetAPIClient(client)
+
+			err := runTreeImport(context.Background(), opts, []string{tt.file})
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+			assert.Contains(t, stderr.String(), tt.wantStderr)
+		})
+	}
+}
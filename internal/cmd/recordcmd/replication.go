@@ -0,0 +1,183 @@
+package recordcmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/salesforce-cli/internal/cmd/root"
+)
+
+// replicationFlags holds the flags of the deleted and updated commands.
+type replicationFlags struct {
+	since string
+	until string
+}
+
+// timeRange parses --since and --until relative to now.
+func (f replicationFlags) timeRange(now time.Time) (start, end time.Time, err error) {
+	if start, err = root.ParseTimeFlag(f.since, now); err != nil {
+		return start, end, fmt.Errorf("invalid --since: %w", err)
+	}
+	end = now
+	if f.until != "" {
+		if end, err = root.ParseTimeFlag(f.until, now); err != nil {
+			return start, end, fmt.Errorf("invalid --until: %w", err)
+		}
+	}
+	if end.Before(start) {
+		return start, end, fmt.Errorf("--until must not be before --since")
+	}
+	return start, end, nil
+}
+
+func (f *replicationFlags) register(cmd *cobra.Command, what string) {
+	cmd.Flags().StringVar(&f.since, "since", "24h", "Only records "+what+" at or after this time")
+	cmd.Flags().StringVar(&f.until, "until", "", "Only records "+what+" before this time (default: now)")
+}
+
+func newDeletedCommand(opts *root.Options) *cobra.Command {
+	var flags replicationFlags
+
+	cmd := &cobra.Command{
+		Use:   "deleted <object>",
+		Short: "List recently deleted records",
+		Long: `List the records of an object deleted in a time range, for syncing
+deletions to another system.
+
+Salesforce keeps deletions for 30 days. --since and --until accept a duration
+relative to now (e.g. 15m, 24h) or a timestamp (RFC 3339, "2006-01-02 15:04",
+or "2006-01-02" in local time); they are rounded down to the minute. Start the
+next sync from the "covered through" time, not the time of this run.
+
+Examples:
+  sfdc record deleted Account
+  sfdc record deleted Account --since 24h
+  sfdc record deleted Contact --since 2024-01-15 --until 2024-01-16 -o json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			start, end, err := flags.timeRange(time.Now())
+			if err != nil {
+				return err
+			}
+			return runDeleted(cmd.Context(), opts, args[0], start, end)
+		},
+	}
+
+	flags.register(cmd, "deleted")
+
+	return cmd
+}
+
+func runDeleted(ctx context.Context, opts *root.Options, objectName string, start, end time.Time) error {
+	client, err := opts.APIClient()
+	if err != nil {
+		return fmt.Errorf("failed to create API client: %w", err)
+	}
+
+	result, err := client.GetDeleted(ctx, objectName, start, end)
+	if err != nil {
+		return fmt.Errorf("failed to get deleted records: %w", err)
+	}
+
+	v := opts.View()
+
+	if opts.Output == "json" {
+		return v.JSON(result)
+	}
+
+	if len(result.DeletedRecords) == 0 {
+		v.Info("No deleted %s records", objectName)
+	} else {
+		headers := []string{"ID", "Deleted Date"}
+		rows := make([][]string, 0, len(result.DeletedRecords))
+		for _, rec := range result.DeletedRecords {
+			rows = append(rows, []string{rec.ID, formatReplicationTime(rec.DeletedDate.Time)})
+		}
+		if err := v.Render(headers, rows, result); err != nil {
+			return err
+		}
+		v.Info("\n%d deleted record(s)", len(result.DeletedRecords))
+	}
+	v.Info("Covered through %s", formatReplicationTime(result.LatestDateCovered.Time))
+
+	return nil
+}
+
+func newUpdatedCommand(opts *root.Options) *cobra.Command {
+	var flags replicationFlags
+
+	cmd := &cobra.Command{
+		Use:   "updated <object>",
+		Short: "List recently created or updated records",
+		Long: `List the IDs of the records of an object created or updated in a time
+range, for syncing changes to another system.
+
+Salesforce reports the last 30 days. --since and --until accept a duration
+relative to now (e.g. 15m, 24h) or a timestamp (RFC 3339, "2006-01-02 15:04",
+or "2006-01-02" in local time); they are rounded down to the minute. Start the
+next sync from the "covered through" time, not the time of this run.
+
+Examples:
+  sfdc record updated Account
+  sfdc record updated Account --since 2h
+  sfdc record updated Opportunity --since 2024-01-15 -o plain`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			start, end, err := flags.timeRange(time.Now())
+			if err != nil {
+				return err
+			}
+			return runUpdated(cmd.Context(), opts, args[0], start, end)
+		},
+	}
+
+	flags.register(cmd, "created or updated")
+
+	return cmd
+}
+
+func runUpdated(ctx context.Context, opts *root.Options, objectName string, start, end time.Time) error {
+	client, err := opts.APIClient()
+	if err != nil {
+		return fmt.Errorf("failed to create API client: %w", err)
+	}
+
+	result, err := client.GetUpdated(ctx, objectName, start, end)
+	if err != nil {
+		return fmt.Errorf("failed to get updated records: %w", err)
+	}
+
+	v := opts.View()
+
+	if opts.Output == "json" {
+		return v.JSON(result)
+	}
+
+	if len(result.IDs) == 0 {
+		v.Info("No updated %s records", objectName)
+	} else {
+		rows := make([][]string, 0, len(result.IDs))
+		for _, id := range result.IDs {
+			rows = append(rows, []string{id})
+		}
+		if err := v.Render([]string{"ID"}, rows, result); err != nil {
+			return err
+		}
+		v.Info("\n%d updated record(s)", len(result.IDs))
+	}
+	v.Info("Covered through %s", formatReplicationTime(result.LatestDateCovered.Time))
+
+	return nil
+}
+
+// formatReplicationTime formats a time in local time, as --since and
+// --until take it.
+func formatReplicationTime(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.Local().Format("2006-01-02 15:04:05")
+}
@@ -0,0 +1,167 @@
+package recordcmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/salesforce-cli/api"
+	"github.com/open-cli-collective/salesforce-cli/internal/cmd/root"
+)
+
+func newTreeCommand(opts *root.Options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "tree",
+		Short: "Work with record trees",
+		Long:  "Create parent records together with their child records.",
+	}
+
+	cmd.AddCommand(newTreeImportCommand(opts))
+
+	return cmd
+}
+
+func newTreeImportCommand(opts *root.Options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import <file>...",
+		Short: "Create records and their children from tree JSON files",
+		Long: `Create records and their nested child records from JSON files in the
+standard record tree format, as written by "sf data export tree".
+
+Each file holds records of one object type, each with a referenceId, and
+child records nested under their relationship name:
+
+  {"records": [{
+    "attributes": {"type": "Account", "referenceId": "AcmeRef"},
+    "Name": "Acme",
+    "Contacts": {"records": [{
+      "attributes": {"type": "Contact", "referenceId": "SmithRef"},
+      "LastName": "Smith"
+    }]}
+  }]}
+
+Each file is created in a single call, as a whole or not at all, and may hold
+up to 200 records including children.
+
+Examples:
+  sfdc record tree import accounts.json
+  sfdc record tree import accounts.json opportunities.json
+  sfdc record tree import accounts.json -o json`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runTreeImport(cmd.Context(), opts, args)
+		},
+	}
+
+	return cmd
+}
+
+// treeImport is the result of importing one tree file
+type treeImport struct {
+	File    string                        `json:"file"`
+	Object  string                        `json:"object"`
+	Results []api.SObjectTreeRecordResult `json:"results"`
+}
+
+func runTreeImport(ctx context.Context, opts *root.Options, files []string) error {
+	client, err := opts.APIClient()
+	if err != nil {
+		return fmt.Errorf("failed to create API client: %w", err)
+	}
+
+	v := opts.View()
+
+	imports := make([]treeImport, 0, len(files))
+	for _, file := range files {
+		tree, objectName, err := readTreeFile(file)
+		if err != nil {
+			return err
+		}
+
+		if opts.DryRun {
+			if err := opts.PrintDryRun(root.DryRunRequest{
+				Operation: "tree import",
+				Object:    objectName,
+				Method:    http.MethodPost,
+				URL:       client.ResourceURL(fmt.Sprintf("/composite/tree/%s", objectName)),
+				Details: map[string]interface{}{
+					"file":    file,
+					"records": tree.Count(),
+				},
+			}); err != nil {
+				return err
+			}
+			continue
+		}
+
+		result, err := client.CreateRecordTree(ctx, objectName, tree)
+		if err != nil {
+			return fmt.Errorf("failed to import %s: %w", file, err)
+		}
+		if result.HasErrors {
+			for _, r := range result.Results {
+				for _, e := range r.Errors {
+					v.Error("%s: %s: %s", r.ReferenceID, e.StatusCode, e.Message)
+				}
+			}
+			return fmt.Errorf("failed to import %s: no records were created", file)
+		}
+
+		imports = append(imports, treeImport{File: file, Object: objectName, Results: result.Results})
+	}
+
+	if opts.DryRun {
+		return nil
+	}
+
+	if opts.Output == "json" {
+		return v.JSON(imports)
+	}
+
+	var rows [][]string
+	for _, imp := range imports {
+		v.Success("Created %d records from %s", len(imp.Results), imp.File)
+		for _, r := range imp.Results {
+			rows = append(rows, []string{imp.File, r.ReferenceID, r.ID})
+		}
+	}
+
+	return v.Table([]string{"File", "Reference ID", "Id"}, rows)
+}
+
+// readTreeFile reads a record tree file and returns it with the object type
+// of its top-level records.
+func readTreeFile(file string) (*api.SObjectTree, string, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read tree file: %w", err)
+	}
+
+	var tree api.SObjectTree
+	if err := json.Unmarshal(data, &tree); err != nil {
+		return nil, "", fmt.Errorf("failed to parse %s: %w", file, err)
+	}
+	if len(tree.Records) == 0 {
+		return nil, "", fmt.Errorf("%s has no records", file)
+	}
+
+	var objectName string
+	for i, record := range tree.Records {
+		attributes, _ := record["attributes"].(map[string]interface{})
+		recordType, _ := attributes["type"].(string)
+		if recordType == "" {
+			return nil, "", fmt.Errorf("%s: record %d has no attributes.type", file, i+1)
+		}
+		if objectName == "" {
+			objectName = recordType
+		} else if recordType != objectName {
+			return nil, "", fmt.Errorf("%s: top-level records must all be the same type (found %s and %s)", file, objectName, recordType)
+		}
+	}
+
+	return &tree, objectName, nil
+}
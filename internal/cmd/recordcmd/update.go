@@ -3,23 +3,40 @@ package recordcmd
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"time"
 
 	"github.com/spf13/cobra"
 
+	"github.com/open-cli-collective/salesforce-cli/api"
 	"github.com/open-cli-collective/salesforce-cli/internal/cmd/root"
 )
 
 func newUpdateCommand(opts *root.Options) *cobra.Command {
-	var setFlags []string
+	var (
+		setFlags          []string
+		ifUnmodifiedSince string
+		call              callFlags
+	)
 
 	cmd := &cobra.Command{
 		Use:   "update <object> <id>",
 		Short: "Update an existing record",
 		Long: `Update an existing Salesforce record.
 
+With --if-unmodified-since, the update is only made if the record has not
+changed since the given time, e.g. the LastModifiedDate you read it with, so
+that someone else's changes are not overwritten.
+
+With --allow-duplicates, the record is saved even if a duplicate rule that
+allows it would otherwise block it. With --no-auto-assign, lead and case
+assignment rules are not run.
+
 Examples:
   sfdc record update Account 001xx000003DGbYAAW --set Name="New Name"
-  sfdc record update Contact 003xx000001abcd --set Phone="555-1234" --set Email=new@example.com`,
+  sfdc record update Contact 003xx000001abcd --set Phone="555-1234" --set Email=new@example.com
+  sfdc record update Account 001xx000003DGbYAAW --set Name="New Name" --if-unmodified-since 2024-01-15T10:30:00.000+0000
+  sfdc record update Case 500xx000001abcd --set Status=Escalated --no-auto-assign`,
 		Args: cobra.ExactArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			fields, err := parseSetFlags(setFlags)
@@ -29,22 +46,53 @@ Examples:
 			if len(fields) == 0 {
 				return fmt.Errorf("at least one --set flag is required")
 			}
-			return runUpdate(cmd.Context(), opts, args[0], args[1], fields)
+			var cond api.Conditions
+			if ifUnmodifiedSince != "" {
+				t, err := parseTimestamp(ifUnmodifiedSince)
+				if err != nil {
+					return fmt.Errorf("invalid --if-unmodified-since: %w", err)
+				}
+				cond.IfUnmodifiedSince = t
+			}
+			return runUpdate(cmd.Context(), opts, args[0], args[1], fields, cond, call.options())
 		},
 	}
 
 	cmd.Flags().StringArrayVar(&setFlags, "set", nil, "Set field value (format: Field=Value)")
+	cmd.Flags().StringVar(&ifUnmodifiedSince, "if-unmodified-since", "", "Only update if the record has not changed since this time")
+	call.register(cmd)
 
 	return cmd
 }
 
-func runUpdate(ctx context.Context, opts *root.Options, objectName, recordID string, fields map[string]interface{}) error {
+func runUpdate(ctx context.Context, opts *root.Options, objectName, recordID string, fields map[string]interface{}, cond api.Conditions, callOpts api.CallOptions) error {
 	client, err := opts.APIClient()
 	if err != nil {
 		return fmt.Errorf("failed to create API client: %w", err)
 	}
 
-	err = client.UpdateRecord(ctx, objectName, recordID, fields)
+	if opts.DryRun {
+		req := root.DryRunRequest{
+			Operation: "update",
+			Object:    objectName,
+			Method:    http.MethodPatch,
+			URL:       client.ResourceURL(fmt.Sprintf("/sobjects/%s/%s", objectName, recordID)),
+			Payload:   fields,
+		}
+		if !cond.IfUnmodifiedSince.IsZero() {
+			req.Details = map[string]interface{}{
+				"if-unmodified-since": cond.IfUnmodifiedSince.UTC().Format(http.TimeFormat),
+			}
+		}
+		req.Details = headerDetails(req.Details, callOpts.Header())
+		return opts.PrintDryRun(req)
+	}
+
+	err = client.UpdateRecordIf(api.WithCallOptions(ctx, callOpts), objectName, recordID, fields, cond)
+	if api.IsPreconditionFailed(err) {
+		return fmt.Errorf("%s %s was modified after %s and was not updated; get it again and retry",
+			objectName, recordID, cond.IfUnmodifiedSince.Format(time.RFC3339))
+	}
 	if err != nil {
 		return fmt.Errorf("failed to update record: %w", err)
 	}
@@ -60,5 +108,24 @@ func runUpdate(ctx context.Context, opts *root.Options, objectName, recordID str
 	}
 
 	v.Success("Updated %s record: %s", objectName, recordID)
+	v.Info("URL: %s", v.Link(client.RecordURL(recordID)))
 	return nil
 }
+
+// parseTimestamp parses a timestamp given as RFC 3339, in the Salesforce
+// datetime format (as in LastModifiedDate), or as a local date and time.
+func parseTimestamp(value string) (time.Time, error) {
+	for _, layout := range []string{time.RFC3339, "2006-01-02T15:04:05.000-0700", "2006-01-02T15:04:05.000Z0700"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+
+	for _, layout := range []string{"2006-01-02 15:04:05", "2006-01-02 15:04", "2006-01-02"} {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("expected a timestamp (e.g. 2024-01-15T10:30:00Z or 2024-01-15 10:30), got %q", value)
+}
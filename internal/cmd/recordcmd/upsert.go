@@ -0,0 +1,93 @@
+package recordcmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/salesforce-cli/internal/cmd/root"
+)
+
+func newUpsertCommand(opts *root.Options) *cobra.Command {
+	var setFlags []string
+
+	cmd := &cobra.Command{
+		Use:   "upsert <object> <external-id-field> <value>",
+		Short: "Create or update a record by external ID",
+		Long: `Create or update a Salesforce record matched by an external ID field.
+
+If a record has the given value in the external ID field, it is updated;
+otherwise a new record is created with that value. The command fails if the
+value matches more than one record.
+
+Examples:
+  sfdc record upsert Account My_Ext_Id__c 12345 --set Name=Acme
+  sfdc record upsert Contact Email__c jane@example.com --set LastName=Doe --set Phone=555-0100
+  sfdc record upsert Account My_Ext_Id__c 12345 --set Name=Acme -o json`,
+		Args: cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			fields, err := parseSetFlags(setFlags)
+			if err != nil {
+				return err
+			}
+			if len(fields) == 0 {
+				return fmt.Errorf("at least one --set flag is required")
+			}
+			return runUpsert(cmd.Context(), opts, args[0], args[1], args[2], fields)
+		},
+	}
+
+	cmd.Flags().StringArrayVar(&setFlags, "set", nil, "Set field value (format: Field=Value)")
+
+	return cmd
+}
+
+func runUpsert(ctx context.Context, opts *root.Options, objectName, extIDField, extIDValue string, fields map[string]interface{}) error {
+	client, err := opts.APIClient()
+	if err != nil {
+		return fmt.Errorf("failed to create API client: %w", err)
+	}
+
+	if opts.DryRun {
+		return opts.PrintDryRun(root.DryRunRequest{
+			Operation: "upsert",
+			Object:    objectName,
+			Method:    http.MethodPatch,
+			URL:       client.ResourceURL(fmt.Sprintf("/sobjects/%s/%s/%s", objectName, extIDField, url.PathEscape(extIDValue))),
+			Payload:   fields,
+		})
+	}
+
+	result, err := client.UpsertRecord(ctx, objectName, extIDField, extIDValue, fields)
+	if err != nil {
+		return fmt.Errorf("failed to upsert record: %w", err)
+	}
+
+	v := opts.View()
+
+	if opts.Output == "json" {
+		return v.JSON(map[string]interface{}{
+			"success": result.Success,
+			"id":      result.ID,
+			"object":  objectName,
+			"created": result.Created,
+		})
+	}
+
+	action := "Updated"
+	if result.Created {
+		action = "Created"
+	}
+	if result.ID == "" {
+		// Older API versions return no ID for an update
+		v.Success("%s %s record with %s %s", action, objectName, extIDField, extIDValue)
+		return nil
+	}
+
+	v.Success("%s %s record: %s", action, objectName, result.ID)
+	v.Info("URL: %s", v.Link(client.RecordURL(result.ID)))
+	return nil
+}
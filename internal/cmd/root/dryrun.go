@@ -0,0 +1,66 @@
+package root
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// DryRunRequest describes a write that --dry-run skipped.
+type DryRunRequest struct {
+	// Operation is the write, e.g. "create" or "bulk delete"
+	Operation string `json:"operation"`
+	// Object is the SObject type written to, if any
+	Object string `json:"object,omitempty"`
+	// Method and URL are the HTTP request that would have been sent
+	Method string `json:"method,omitempty"`
+	URL    string `json:"url,omitempty"`
+	// Payload is the request body that would have been sent
+	Payload interface{} `json:"payload,omitempty"`
+	// Details are extra facts about the write, e.g. the number of rows
+	Details map[string]interface{} `json:"details,omitempty"`
+}
+
+// PrintDryRun shows the request a write command would have sent. Commands
+// call it instead of writing when DryRun is set.
+func (o *Options) PrintDryRun(req DryRunRequest) error {
+	v := o.View()
+
+	if o.Output == "json" {
+		return v.JSON(map[string]interface{}{
+			"dryRun":  true,
+			"request": req,
+		})
+	}
+
+	v.Warning("Dry run: no changes were made")
+	v.Info("Operation: %s", req.Operation)
+	if req.Object != "" {
+		v.Info("Object:    %s", req.Object)
+	}
+	if req.URL != "" {
+		v.Info("Request:   %s %s", req.Method, req.URL)
+	}
+	for _, key := range sortedKeys(req.Details) {
+		v.Info("%-10s %v", key+":", req.Details[key])
+	}
+	if req.Payload != nil {
+		payload, err := json.MarshalIndent(req.Payload, "  ", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode payload: %w", err)
+		}
+		v.Info("Payload:\n  %s", strings.TrimSpace(string(payload)))
+	}
+
+	return nil
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
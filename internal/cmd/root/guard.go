@@ -0,0 +1,158 @@
+package root
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/open-cli-collective/salesforce-cli/api"
+	"github.com/open-cli-collective/salesforce-cli/internal/config"
+)
+
+// ConfirmProduction asks for confirmation before a destructive operation
+// (described by action, e.g. "bulk hardDelete on Account") when the org is
+// production. Sandboxes are allowed without a prompt. If the org type cannot
+// be determined, the org is treated as production.
+//
+// It returns false if the user declined. Passing yes skips the check, as
+// does setting "production_guard": false in the config file or
+// SFDC_PRODUCTION_GUARD=false.
+func (o *Options) ConfirmProduction(ctx context.Context, action string, yes bool) (bool, error) {
+	if yes {
+		return true, nil
+	}
+
+	if cfg, err := config.Load(); err == nil && !cfg.ProductionGuardEnabled() {
+		return true, nil
+	}
+
+	v := o.View()
+
+	org, err := o.orgInfo(ctx)
+	if err != nil {
+		v.Warning("Could not determine whether the org is a sandbox: %v", err)
+		org = &config.CachedOrg{Name: "unknown"}
+	}
+
+	if org.IsSandbox {
+		v.Info("Target org: %s (sandbox)", org.Name)
+		return true, nil
+	}
+
+	v.Warning("About to run %s in PRODUCTION org %q", action, orgLabel(org))
+	v.Print("Continue? [y/N]: ")
+
+	yes, ok := o.readAnswer()
+	if !ok {
+		return false, fmt.Errorf("confirmation required for production org %q: use --yes to proceed", orgLabel(org))
+	}
+	return yes, nil
+}
+
+// Confirm prints prompt, such as "Delete 3 records? [y/N]: ", and reports
+// whether the user answered yes. It returns an error if there is no answer
+// to read, so that commands run without input need --yes.
+func (o *Options) Confirm(prompt string) (bool, error) {
+	o.View().Print("%s", prompt)
+
+	yes, ok := o.readAnswer()
+	if !ok {
+		return false, fmt.Errorf("confirmation required: use --yes to proceed")
+	}
+	return yes, nil
+}
+
+// readAnswer reads a line from Stdin and reports whether it is yes, and
+// whether there was an answer at all. Every prompt reads through the same
+// buffered reader, so answers piped in for several prompts are not lost to
+// the buffer of an earlier one.
+func (o *Options) readAnswer() (yes, ok bool) {
+	if o.Stdin == nil {
+		return false, false
+	}
+	if o.stdinReader == nil {
+		o.stdinReader = bufio.NewReader(o.Stdin)
+	}
+
+	response, err := o.stdinReader.ReadString('\n')
+	if err != nil && (err != io.EOF || response == "") {
+		return false, false
+	}
+
+	response = strings.TrimSpace(strings.ToLower(response))
+	return response == "y" || response == "yes", true
+}
+
+// IsSandbox reports whether the org is a sandbox, from the same cached org
+// details as ConfirmProduction. Callers that can't tell should treat the org
+// as production.
+func (o *Options) IsSandbox(ctx context.Context) (bool, error) {
+	org, err := o.orgInfo(ctx)
+	if err != nil {
+		return false, err
+	}
+	return org.IsSandbox, nil
+}
+
+// DefaultCurrency returns the ISO code of the org's default currency, from
+// the same cached org details as ConfirmProduction.
+func (o *Options) DefaultCurrency(ctx context.Context) (string, error) {
+	client, err := o.APIClient()
+	if err != nil {
+		return "", err
+	}
+
+	org, err := o.orgInfo(ctx)
+	if err != nil {
+		return "", err
+	}
+	if org.DefaultCurrency == "" {
+		// The details were cached before the default currency was
+		if org, err = queryOrgInfo(ctx, client); err != nil {
+			return "", err
+		}
+	}
+	return org.DefaultCurrency, nil
+}
+
+// orgInfo returns the org's details from the cache, querying and caching
+// them on first use.
+func (o *Options) orgInfo(ctx context.Context) (*config.CachedOrg, error) {
+	client, err := o.APIClient()
+	if err != nil {
+		return nil, err
+	}
+
+	if org, err := config.LoadCachedOrg(client.InstanceURL); err == nil && org != nil {
+		return org, nil
+	}
+	return queryOrgInfo(ctx, client)
+}
+
+// queryOrgInfo queries the org's details and caches them.
+func queryOrgInfo(ctx context.Context, client *api.Client) (*config.CachedOrg, error) {
+	info, err := client.GetOrgInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	org := config.CachedOrg{
+		ID:              info.ID,
+		Name:            info.Name,
+		IsSandbox:       info.IsSandbox,
+		DefaultCurrency: info.DefaultCurrency,
+	}
+	// The cache only saves a query, so failing to write it is not an error
+	_ = config.SaveCachedOrg(client.InstanceURL, org)
+
+	return &org, nil
+}
+
+func orgLabel(org *config.CachedOrg) string {
+	if org.ID == "" {
+		return org.Name
+	}
+	return fmt.Sprintf("%s (%s)", org.Name, org.ID)
+}
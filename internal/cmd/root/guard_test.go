@@ -0,0 +1,211 @@
+package root
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/salesforce-cli/api"
+	"github.com/open-cli-collective/salesforce-cli/internal/config"
+)
+
+func newOrgServer(t *testing.T, isSandbox bool, queries *int) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*queries++
+		assert.Contains(t, r.URL.RawQuery, "FROM+Organization")
+		_ = json.NewEncoder(w).Encode(api.QueryResult{
+			TotalSize: 1,
+			Done:      true,
+			Records: []api.SObject{{
+				ID:     "00Dxx0000001gPL",
+				Fields: map[string]interface{}{"Name": "Acme Corp", "IsSandbox": isSandbox, "DefaultCurrencyIsoCode": "USD"},
+			}},
+		})
+	}))
+}
+
+func newGuardOptions(t *testing.T, server *httptest.Server, stdin string) (*Options, *bytes.Buffer) {
+	t.Helper()
+	t.Setenv(config.HomeEnvVar, t.TempDir())
+	t.Setenv("SFDC_PRODUCTION_GUARD", "")
+
+	client, err := api.New(api.ClientConfig{
+		InstanceURL: server.URL,
+		HTTPClient:  server.Client(),
+	})
+	require.NoError(t, err)
+
+	stderr := &bytes.Buffer{}
+	opts := &Options{
+		NoColor: true,
+		Stdin:   strings.NewReader(stdin),
+		Stdout:  &bytes.Buffer{},
+		Stderr:  stderr,
+	}
+	opts.SetAPIClient(client)
+	return opts, stderr
+}
+
+func TestConfirmProduction(t *testing.T) {
+	tests := []struct {
+		name        string
+		sandbox     bool
+		stdin       string
+		yes         bool
+		wantProceed bool
+		wantErr     string
+		wantQueries int
+	}{
+		{name: "sandbox", sandbox: true, wantProceed: true, wantQueries: 1},
+		{name: "production confirmed", stdin: "y\n", wantProceed: true, wantQueries: 1},
+		{name: "production declined", stdin: "n\n", wantProceed: false, wantQueries: 1},
+		{name: "production without input", stdin: "", wantErr: "use --yes", wantQueries: 1},
+		{name: "yes skips lookup", yes: true, wantProceed: true, wantQueries: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			queries := 0
+			server := newOrgServer(t, tt.sandbox, &queries)
+			defer server.Close()
+
+			opts, stderr := newGuardOptions(t, server, tt.stdin)
+
+			proceed, err := opts.ConfirmProduction(context.Background(), "bulk hardDelete on Account", tt.yes)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.wantProceed, proceed)
+			}
+			assert.Equal(t, tt.wantQueries, queries)
+
+			if !tt.sandbox && !tt.yes {
+				assert.Contains(t, stderr.String(), `PRODUCTION org "Acme Corp (00Dxx0000001gPL)"`)
+			}
+		})
+	}
+}
+
+func TestConfirmProduction_CachesOrg(t *testing.T) {
+	queries := 0
+	server := newOrgServer(t, true, &queries)
+	defer server.Close()
+
+	opts, _ := newGuardOptions(t, server, "")
+
+	for i := 0; i < 2; i++ {
+		proceed, err := opts.ConfirmProduction(context.Background(), "metadata deploy", false)
+		require.NoError(t, err)
+		assert.True(t, proceed)
+	}
+	assert.Equal(t, 1, queries)
+}
+
+func TestDefaultCurrency(t *testing.T) {
+	queries := 0
+	server := newOrgServer(t, true, &queries)
+	defer server.Close()
+
+	opts, _ := newGuardOptions(t, server, "")
+
+	// A cache written before the default currency was kept is refreshed
+	require.NoError(t, config.SaveCachedOrg(server.URL, config.CachedOrg{ID: "00Dxx0000001gPL", Name: "Acme Corp", IsSandbox: true}))
+
+	for i := 0; i < 2; i++ {
+		currency, err := opts.DefaultCurrency(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "USD", currency)
+	}
+	assert.Equal(t, 1, queries)
+}
+
+func TestConfirmProduction_GuardDisabled(t *testing.T) {
+	queries := 0
+	server := newOrgServer(t, false, &queries)
+	defer server.Close()
+
+	opts, _ := newGuardOptions(t, server, "")
+	t.Setenv("SFDC_PRODUCTION_GUARD", "false")
+
+	proceed, err := opts.ConfirmProduction(context.Background(), "metadata deploy", false)
+	require.NoError(t, err)
+	assert.True(t, proceed)
+	assert.Equal(t, 0, queries)
+}
+
+func TestConfirm(t *testing.T) {
+	tests := []struct {
+		name        string
+		stdin       string
+		wantProceed bool
+		wantErr     bool
+	}{
+		{name: "yes", stdin: "y\n", wantProceed: true},
+		{name: "yes without newline", stdin: "YES", wantProceed: true},
+		{name: "no", stdin: "n\n"},
+		{name: "empty answer", stdin: "\n"},
+		{name: "no input", stdin: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stdout := &bytes.Buffer{}
+			opts := &Options{NoColor: true, Stdin: strings.NewReader(tt.stdin), Stdout: stdout, Stderr: &bytes.Buffer{}}
+
+			proceed, err := opts.Confirm("Delete 2 records? [y/N]: ")
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "use --yes")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantProceed, proceed)
+			assert.Equal(t, "Delete 2 records? [y/N]: ", stdout.String())
+		})
+	}
+}
+
+func TestConfirm_SharesInput(t *testing.T) {
+	queries := 0
+	server := newOrgServer(t, false, &queries)
+	defer server.Close()
+
+	// Both answers are piped at once; the first prompt must not consume the
+	// second
+	opts, _ := newGuardOptions(t, server, "y\ny\n")
+
+	proceed, err := opts.Confirm("Delete 2 records? [y/N]: ")
+	require.NoError(t, err)
+	assert.True(t, proceed)
+
+	proceed, err = opts.ConfirmProduction(context.Background(), "bulk delete on Account", false)
+	require.NoError(t, err)
+	assert.True(t, proceed)
+}
+
+func TestIsSandbox(t *testing.T) {
+	for _, sandbox := range []bool{true, false} {
+		queries := 0
+		server := newOrgServer(t, sandbox, &queries)
+
+		opts, _ := newGuardOptions(t, server, "")
+		// The guard being off doesn't hide the org type
+		t.Setenv("SFDC_PRODUCTION_GUARD", "false")
+
+		isSandbox, err := opts.IsSandbox(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, sandbox, isSandbox)
+		assert.Equal(t, 1, queries)
+		server.Close()
+	}
+}
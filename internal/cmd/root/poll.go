@@ -0,0 +1,43 @@
+package root
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// DefaultWaitTimeout is how long commands wait for bulk jobs, deployments,
+// and test runs unless --wait-timeout says otherwise.
+const DefaultWaitTimeout = 30 * time.Minute
+
+// ErrWaitTimeout is returned, wrapped, by Poll when the wait timeout runs
+// out.
+var ErrWaitTimeout = errors.New("timed out")
+
+// Poll calls check, then calls it again every interval until it reports
+// done or fails. It returns ctx's error if ctx is done first, and an error
+// wrapping ErrWaitTimeout once timeout has passed. A timeout of zero or
+// less waits indefinitely.
+func Poll(ctx context.Context, interval, timeout time.Duration, check func() (bool, error)) error {
+	deadline := time.Now().Add(timeout)
+
+	for {
+		done, err := check()
+		if err != nil || done {
+			return err
+		}
+
+		if timeout > 0 && time.Now().After(deadline) {
+			return fmt.Errorf("%w after %s", ErrWaitTimeout, timeout)
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package root
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPoll(t *testing.T) {
+	calls := 0
+	err := Poll(context.Background(), time.Millisecond, time.Minute, func() (bool, error) {
+		calls++
+		return calls == 3, nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 3, calls)
+}
+
+func TestPoll_Error(t *testing.T) {
+	want := errors.New("boom")
+	err := Poll(context.Background(), time.Millisecond, 0, func() (bool, error) {
+		return false, want
+	})
+	assert.Equal(t, want, err)
+}
+
+func TestPoll_Timeout(t *testing.T) {
+	err := Poll(context.Background(), time.Millisecond, 10*time.Millisecond, func() (bool, error) {
+		return false, nil
+	})
+	require.ErrorIs(t, err, ErrWaitTimeout)
+	assert.EqualError(t, err, "timed out after 10ms")
+}
+
+func TestPoll_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	err := Poll(ctx, time.Hour, 0, func() (bool, error) {
+		cancel()
+		return false, nil
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+}
@@ -2,15 +2,23 @@
 package root
 
 import (
+	"bufio"
 	"context"
+	"fmt"
 	"io"
 	"net/http"
 	"os"
+	"path/filepath"
+	"strings"
+	"time"
 
+	"github.com/mattn/go-isatty"
 	"github.com/spf13/cobra"
+	"golang.org/x/oauth2"
 
 	"github.com/open-cli-collective/salesforce-cli/api"
 	"github.com/open-cli-collective/salesforce-cli/api/bulk"
+	"github.com/open-cli-collective/salesforce-cli/api/bulkv1"
 	"github.com/open-cli-collective/salesforce-cli/api/metadata"
 	"github.com/open-cli-collective/salesforce-cli/api/tooling"
 	"github.com/open-cli-collective/salesforce-cli/internal/auth"
@@ -24,19 +32,56 @@ type Options struct {
 	Output     string
 	NoColor    bool
 	Verbose    bool
+	Debug      bool
+	DryRun     bool
+	Hyperlinks bool
 	APIVersion string
+	Retries    int
+	Timeout    time.Duration
+	NoCache    bool
+	ConfigDir  string
+	Org        string
 	Stdin      io.Reader
 	Stdout     io.Writer
 	Stderr     io.Writer
 
+	// WaitOnRateLimit makes clients wait out rate limits instead of failing.
+	// Long-running commands set it with their --wait-on-rate-limit flag.
+	WaitOnRateLimit bool
+
+	// WaitTimeout limits how long commands wait for jobs, deployments, and
+	// test runs to finish. Commands that wait set it with --wait-timeout.
+	WaitTimeout time.Duration
+
+	// MaxRPS limits the requests per second of all clients, overriding the
+	// max_rps setting. Commands that send many requests set it with
+	// --max-rps.
+	MaxRPS float64
+
+	// limiter is the rate limiter shared by the clients of the command
+	limiter *api.RateLimiter
+
+	// stdinReader buffers Stdin for confirmation prompts, created on first
+	// use
+	stdinReader *bufio.Reader
+
+	// ctx is the context of the running command, used when creating clients
+	// so that token refreshes are cancelled with the command
+	ctx context.Context
+
 	// testClient is used for testing; if set, APIClient() returns this instead
 	testClient *api.Client
 	// testBulkClient is used for testing; if set, BulkClient() returns this instead
 	testBulkClient *bulk.Client
+	// testBulkV1Client is used for testing; if set, BulkV1Client() returns this instead
+	testBulkV1Client *bulkv1.Client
 	// testToolingClient is used for testing; if set, ToolingClient() returns this instead
 	testToolingClient *tooling.Client
 	// testMetadataClient is used for testing; if set, MetadataClient() returns this instead
 	testMetadataClient *metadata.Client
+	// testOrgClients are used for testing; within WithOrg, APIClient()
+	// returns the one of the org instead
+	testOrgClients map[string]*api.Client
 }
 
 // View returns a configured View instance
@@ -44,22 +89,77 @@ func (o *Options) View() *view.View {
 	v := view.NewWithFormat(o.Output, o.NoColor)
 	v.Out = o.Stdout
 	v.Err = o.Stderr
+	v.Hyperlinks = o.Hyperlinks || IsTerminal(o.Stdout)
 	return v
 }
 
+// IsTerminal reports whether w is a terminal, where URLs are printed as
+// clickable hyperlinks and progress is redrawn in place.
+func IsTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	return ok && (isatty.IsTerminal(f.Fd()) || isatty.IsCygwinTerminal(f.Fd()))
+}
+
+// Context returns the context of the running command, or
+// context.Background() if no command is running (e.g. in tests).
+func (o *Options) Context() context.Context {
+	if o.ctx == nil {
+		return context.Background()
+	}
+	return o.ctx
+}
+
 // loadClientConfig loads common configuration needed for API clients.
-func (o *Options) loadClientConfig() (instanceURL string, httpClient *http.Client, err error) {
-	cfg, err := config.Load()
+func (o *Options) loadClientConfig() (cfg *config.Config, httpClient *http.Client, err error) {
+	cfg, err = config.Load()
 	if err != nil {
-		return "", nil, err
+		return nil, nil, err
 	}
 
-	httpClient, err = auth.GetHTTPClient(context.Background())
+	httpClient, err = auth.GetHTTPClient(o.Context())
 	if err != nil {
-		return "", nil, err
+		return nil, nil, err
+	}
+
+	return cfg, httpClient, nil
+}
+
+// compression returns how API clients compress request and response
+// bodies: both are gzipped unless turned off in the config.
+func compression(cfg *config.Config) api.Compression {
+	if !cfg.CompressionEnabled() {
+		return api.Compression{}
+	}
+	return api.Compression{Response: true, Request: true}
+}
+
+// rateLimiter returns the rate limiter shared by all clients, created on
+// first use, or nil if requests are not limited. --max-rps takes precedence
+// over the max_rps setting.
+func (o *Options) rateLimiter(cfg *config.Config) *api.RateLimiter {
+	if o.limiter != nil {
+		return o.limiter
+	}
+
+	rps := cfg.MaxRPS
+	if o.MaxRPS > 0 {
+		rps = o.MaxRPS
+	}
+	if rps <= 0 {
+		return nil
 	}
 
-	return cfg.InstanceURL, httpClient, nil
+	o.limiter = api.NewRateLimiter(rps, cfg.RateLimitBurst)
+	return o.limiter
+}
+
+// retryPolicy returns the retry policy for API clients, allowing the number
+// of retries set with --retries.
+func (o *Options) retryPolicy() api.RetryPolicy {
+	policy := api.DefaultRetryPolicy()
+	policy.MaxAttempts = o.Retries + 1
+	policy.WaitOnRateLimit = o.WaitOnRateLimit
+	return policy
 }
 
 // APIClient creates a new API client from config
@@ -68,38 +168,118 @@ func (o *Options) APIClient() (*api.Client, error) {
 		return o.testClient, nil
 	}
 
-	instanceURL, httpClient, err := o.loadClientConfig()
+	cfg, httpClient, err := o.loadClientConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	cache, ttl, err := o.describeCache(cfg)
 	if err != nil {
 		return nil, err
 	}
 
 	return api.New(api.ClientConfig{
-		InstanceURL: instanceURL,
-		HTTPClient:  httpClient,
-		APIVersion:  o.APIVersion,
+		InstanceURL:      cfg.InstanceURL,
+		HTTPClient:       httpClient,
+		APIVersion:       o.APIVersion,
+		Retry:            o.retryPolicy(),
+		Compression:      compression(cfg),
+		Timeout:          o.Timeout,
+		RateLimiter:      o.rateLimiter(cfg),
+		DescribeCache:    cache,
+		DescribeCacheTTL: ttl,
 	})
 }
 
+// describeCache returns the on-disk describe cache of the org and API
+// version, and how long its entries are used. It returns a nil cache with
+// --no-cache or when the configured TTL is zero.
+func (o *Options) describeCache(cfg *config.Config) (api.DescribeCache, time.Duration, error) {
+	ttl, err := cfg.DescribeCacheMaxAge()
+	if err != nil {
+		return nil, 0, err
+	}
+	if o.NoCache || ttl == 0 {
+		return nil, 0, nil
+	}
+
+	host := strings.TrimSpace(cfg.InstanceURL)
+	host = strings.TrimPrefix(strings.TrimPrefix(host, "https://"), "http://")
+	host = strings.TrimSuffix(host, "/")
+	if host == "" || strings.ContainsAny(host, `/\:`) {
+		return nil, 0, nil
+	}
+	dir, err := config.GetCacheDir()
+	if err != nil {
+		return nil, 0, nil
+	}
+
+	apiVersion := o.APIVersion
+	if apiVersion == "" {
+		apiVersion = api.DefaultAPIVersion
+	}
+
+	return api.NewFileDescribeCache(filepath.Join(dir, "describe", host, apiVersion)), ttl, nil
+}
+
 // SetAPIClient sets a test client (for testing only)
 func (o *Options) SetAPIClient(client *api.Client) {
 	o.testClient = client
 }
 
+// SetOrgAPIClient sets the test client of an org profile, used within
+// WithOrg (for testing only)
+func (o *Options) SetOrgAPIClient(alias string, client *api.Client) {
+	if o.testOrgClients == nil {
+		o.testOrgClients = make(map[string]*api.Client)
+	}
+	o.testOrgClients[alias] = client
+}
+
+// WithOrg runs fn with the org profile alias selected, as --org selects it
+// for the whole command, then restores the previous selection. Clients
+// created in fn connect to that org. They must not be used after fn
+// returns: tokens they refresh are stored for the selected org. An empty
+// alias runs fn with the command's org.
+func (o *Options) WithOrg(alias string, fn func() error) error {
+	if alias == "" {
+		return fn()
+	}
+
+	if client, ok := o.testOrgClients[alias]; ok {
+		previous := o.testClient
+		o.testClient = client
+		defer func() { o.testClient = previous }()
+		return fn()
+	}
+
+	previous := config.OrgOverride()
+	if err := selectOrg(alias); err != nil {
+		return err
+	}
+	defer config.SetOrg(previous)
+	return fn()
+}
+
 // BulkClient creates a new Bulk API client from config
 func (o *Options) BulkClient() (*bulk.Client, error) {
 	if o.testBulkClient != nil {
 		return o.testBulkClient, nil
 	}
 
-	instanceURL, httpClient, err := o.loadClientConfig()
+	cfg, httpClient, err := o.loadClientConfig()
 	if err != nil {
 		return nil, err
 	}
 
 	return bulk.New(bulk.ClientConfig{
-		InstanceURL: instanceURL,
+		InstanceURL: cfg.InstanceURL,
 		HTTPClient:  httpClient,
 		APIVersion:  o.APIVersion,
+		Retry:       o.retryPolicy(),
+		Compression: compression(cfg),
+		Timeout:     o.Timeout,
+		RateLimiter: o.rateLimiter(cfg),
 	})
 }
 
@@ -108,21 +288,59 @@ func (o *Options) SetBulkClient(client *bulk.Client) {
 	o.testBulkClient = client
 }
 
+// BulkV1Client creates a new Bulk API 1.0 client from config
+func (o *Options) BulkV1Client() (*bulkv1.Client, error) {
+	if o.testBulkV1Client != nil {
+		return o.testBulkV1Client, nil
+	}
+
+	cfg, httpClient, err := o.loadClientConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	return bulkv1.New(bulkv1.ClientConfig{
+		InstanceURL: cfg.InstanceURL,
+		HTTPClient:  httpClient,
+		APIVersion:  o.APIVersion,
+		SessionID: func(ctx context.Context) (string, error) {
+			token, err := auth.Token(ctx, false)
+			if err != nil {
+				return "", err
+			}
+			return token.AccessToken, nil
+		},
+		Retry:       o.retryPolicy(),
+		Compression: compression(cfg),
+		Timeout:     o.Timeout,
+		RateLimiter: o.rateLimiter(cfg),
+	})
+}
+
+// SetBulkV1Client sets a test Bulk API 1.0 client (for testing only)
+func (o *Options) SetBulkV1Client(client *bulkv1.Client) {
+	o.testBulkV1Client = client
+}
+
 // ToolingClient creates a new Tooling API client from config
 func (o *Options) ToolingClient() (*tooling.Client, error) {
 	if o.testToolingClient != nil {
 		return o.testToolingClient, nil
 	}
 
-	instanceURL, httpClient, err := o.loadClientConfig()
+	cfg, httpClient, err := o.loadClientConfig()
 	if err != nil {
 		return nil, err
 	}
 
 	return tooling.New(tooling.ClientConfig{
-		InstanceURL: instanceURL,
+		InstanceURL: cfg.InstanceURL,
 		HTTPClient:  httpClient,
 		APIVersion:  o.APIVersion,
+		Retry:       o.retryPolicy(),
+		Compression: compression(cfg),
+		Timeout:     o.Timeout,
+		RateLimiter: o.rateLimiter(cfg),
 	})
 }
 
@@ -137,15 +355,19 @@ func (o *Options) MetadataClient() (*metadata.Client, error) {
 		return o.testMetadataClient, nil
 	}
 
-	instanceURL, httpClient, err := o.loadClientConfig()
+	cfg, httpClient, err := o.loadClientConfig()
 	if err != nil {
 		return nil, err
 	}
 
 	return metadata.New(metadata.ClientConfig{
-		InstanceURL: instanceURL,
+		InstanceURL: cfg.InstanceURL,
 		HTTPClient:  httpClient,
 		APIVersion:  o.APIVersion,
+		Retry:       o.retryPolicy(),
+		Compression: compression(cfg),
+		Timeout:     o.Timeout,
+		RateLimiter: o.rateLimiter(cfg),
 	})
 }
 
@@ -154,6 +376,11 @@ func (o *Options) SetMetadataClient(client *metadata.Client) {
 	o.testMetadataClient = client
 }
 
+// CSVOutputAnnotation marks the commands that write their results as CSV
+// with -o csv. Other commands reject it, since they print summary lines
+// after their tables on stdout.
+const CSVOutputAnnotation = "sfdc_csv_output"
+
 // NewCmd creates the root command and returns the options struct
 func NewCmd() (*cobra.Command, *Options) {
 	opts := &Options{
@@ -172,17 +399,101 @@ Run 'sfdc init' to set up authentication.`,
 		Version:       version.Info(),
 		SilenceUsage:  true,
 		SilenceErrors: true,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			opts.ctx = cmd.Context()
+			if err := view.ValidateFormat(opts.Output); err != nil {
+				return err
+			}
+			if opts.Output == string(view.FormatCSV) && cmd.Annotations[CSVOutputAnnotation] == "" {
+				return fmt.Errorf("-o csv is only supported by the query command")
+			}
+			if opts.Retries < 0 {
+				return fmt.Errorf("--retries must not be negative")
+			}
+			if opts.Timeout < 0 {
+				return fmt.Errorf("--timeout must not be negative")
+			}
+			if opts.MaxRPS < 0 {
+				return fmt.Errorf("--max-rps must not be negative")
+			}
+			if opts.ConfigDir != "" {
+				config.SetConfigDir(opts.ConfigDir)
+			}
+			if opts.Org != "" {
+				if err := selectOrg(opts.Org); err != nil {
+					return err
+				}
+			}
+
+			ctx, err := opts.withTransport(cmd.Context())
+			if err != nil {
+				return err
+			}
+			cmd.SetContext(ctx)
+			opts.ctx = ctx
+			return nil
+		},
 	}
 
 	// Global flags - bound to opts struct
-	cmd.PersistentFlags().StringVarP(&opts.Output, "output", "o", "table", "Output format: table, json, plain")
+	cmd.PersistentFlags().StringVarP(&opts.Output, "output", "o", "table", "Output format: table, json, plain (csv for query)")
 	cmd.PersistentFlags().BoolVar(&opts.NoColor, "no-color", false, "Disable colored output")
 	cmd.PersistentFlags().BoolVarP(&opts.Verbose, "verbose", "v", false, "Enable verbose output")
+	cmd.PersistentFlags().BoolVar(&opts.Debug, "debug", false, "Log HTTP requests and responses to stderr, with tokens redacted")
+	cmd.PersistentFlags().BoolVar(&opts.DryRun, "dry-run", false, "Show what write commands would send without making changes")
+	cmd.PersistentFlags().BoolVar(&opts.Hyperlinks, "hyperlinks", false, "Print record URLs as clickable terminal hyperlinks even when output is not a terminal")
 	cmd.PersistentFlags().StringVar(&opts.APIVersion, "api-version", "", "Salesforce API version (default: v62.0)")
+	cmd.PersistentFlags().IntVar(&opts.Retries, "retries", 2, "Times to retry requests that fail with a network or server error")
+	cmd.PersistentFlags().DurationVar(&opts.Timeout, "timeout", api.DefaultTimeout, "Fail requests that make no progress for this long (0 to disable)")
+	cmd.PersistentFlags().BoolVar(&opts.NoCache, "no-cache", false, "Fetch object describes from Salesforce instead of the local cache")
+	cmd.PersistentFlags().StringVar(&opts.Org, "org", "", "Org profile to use (overrides SFDC_ORG and the default org)")
+	cmd.PersistentFlags().StringVar(&opts.ConfigDir, "config-dir", "", "Configuration directory (overrides SFDC_HOME and ~/.config/salesforce-cli)")
 
 	return cmd, opts
 }
 
+// withTransport returns ctx with the HTTP client that API clients and
+// logins send requests through: the auth package builds every client's
+// transport on it, and uses it for token requests. It applies the proxy and
+// TLS settings of the config, and with --debug traces requests to stderr.
+func (o *Options) withTransport(ctx context.Context) (context.Context, error) {
+	transport := http.DefaultTransport
+
+	// A config file that cannot be loaded is reported by the commands that
+	// need it
+	if cfg, err := config.Load(); err == nil {
+		transport, err = auth.NewTransport(cfg)
+		if err != nil {
+			return nil, fmt.Errorf("invalid network settings: %w", err)
+		}
+		if cfg.InsecureSkipVerify {
+			o.View().Warning("TLS certificate verification is disabled (insecure_skip_verify)")
+		}
+	}
+
+	if o.Debug {
+		transport = api.Trace(o.Stderr)(transport)
+	}
+	if transport == http.DefaultTransport {
+		return ctx, nil
+	}
+	return context.WithValue(ctx, oauth2.HTTPClient, &http.Client{Transport: transport}), nil
+}
+
+// selectOrg makes the org profile with the given alias the active one for
+// the command.
+func selectOrg(alias string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if !cfg.HasOrg(alias) {
+		return fmt.Errorf("unknown org %q: run 'sfdc config orgs' to list org profiles, or 'sfdc init --alias %s' to add it", alias, alias)
+	}
+	config.SetOrg(alias)
+	return nil
+}
+
 // RegisterCommands registers subcommands with the root command
 func RegisterCommands(root *cobra.Command, opts *Options, registrars ...func(*cobra.Command, *Options)) {
 	for _, register := range registrars {
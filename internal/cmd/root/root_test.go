@@ -2,11 +2,20 @@ package root
 
 import (
 	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+
+	"github.com/open-cli-collective/salesforce-cli/api"
+	"github.com/open-cli-collective/salesforce-cli/internal/config"
 )
 
 func TestNewCmd(t *testing.T) {
@@ -22,11 +31,317 @@ func TestNewCmd(t *testing.T) {
 	assert.NotNil(t, cmd.PersistentFlags().Lookup("no-color"))
 	assert.NotNil(t, cmd.PersistentFlags().Lookup("verbose"))
 	assert.NotNil(t, cmd.PersistentFlags().Lookup("api-version"))
+	assert.NotNil(t, cmd.PersistentFlags().Lookup("config-dir"))
+	assert.NotNil(t, cmd.PersistentFlags().Lookup("org"))
+	assert.NotNil(t, cmd.PersistentFlags().Lookup("retries"))
+	assert.NotNil(t, cmd.PersistentFlags().Lookup("timeout"))
 
 	// Check default values
 	assert.Equal(t, "table", opts.Output)
 	assert.False(t, opts.NoColor)
 	assert.False(t, opts.Verbose)
+	assert.Equal(t, 2, opts.Retries)
+	assert.Equal(t, api.DefaultTimeout, opts.Timeout)
+}
+
+func TestNewCmd_Debug(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("pong"))
+	}))
+	defer server.Close()
+
+	cmd, opts := NewCmd()
+	stderr := &bytes.Buffer{}
+	opts.Stderr = stderr
+	cmd.AddCommand(&cobra.Command{
+		Use: "ping",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, ok := cmd.Context().Value(oauth2.HTTPClient).(*http.Client)
+			require.True(t, ok, "--debug should set the HTTP client used for API and token requests")
+			resp, err := client.Get(server.URL + "/ping")
+			if err != nil {
+				return err
+			}
+			return resp.Body.Close()
+		},
+	})
+	cmd.SetArgs([]string{"ping", "--debug"})
+
+	require.NoError(t, cmd.Execute())
+	assert.Contains(t, stderr.String(), "--> GET "+server.URL+"/ping")
+	assert.Contains(t, stderr.String(), "<-- 200 OK")
+}
+
+func TestNewCmd_Retries(t *testing.T) {
+	cmd, opts := NewCmd()
+	cmd.AddCommand(&cobra.Command{
+		Use: "noop",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return nil
+		},
+	})
+
+	cmd.SetArgs([]string{"noop", "--retries", "5"})
+	require.NoError(t, cmd.Execute())
+	assert.Equal(t, 6, opts.retryPolicy().MaxAttempts)
+	assert.False(t, opts.retryPolicy().WaitOnRateLimit)
+
+	opts.WaitOnRateLimit = true
+	assert.True(t, opts.retryPolicy().WaitOnRateLimit)
+
+	cmd.SetArgs([]string{"noop", "--retries", "-1"})
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--retries must not be negative")
+}
+
+func TestNewCmd_Output(t *testing.T) {
+	cmd, opts := NewCmd()
+	cmd.AddCommand(&cobra.Command{
+		Use: "noop",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return nil
+		},
+	})
+	cmd.AddCommand(&cobra.Command{
+		Use:         "export",
+		Annotations: map[string]string{CSVOutputAnnotation: "true"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return nil
+		},
+	})
+
+	cmd.SetArgs([]string{"export", "-o", "csv"})
+	require.NoError(t, cmd.Execute())
+	assert.Equal(t, "csv", opts.Output)
+
+	cmd.SetArgs([]string{"noop", "-o", "csv"})
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "-o csv is only supported by the query command")
+
+	cmd.SetArgs([]string{"noop", "-o", "xml"})
+	err = cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `invalid output format: "xml" (valid formats: table, json, plain, csv)`)
+}
+
+func TestNewCmd_Timeout(t *testing.T) {
+	cmd, opts := NewCmd()
+	cmd.AddCommand(&cobra.Command{
+		Use: "noop",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return nil
+		},
+	})
+
+	cmd.SetArgs([]string{"noop", "--timeout", "30s"})
+	require.NoError(t, cmd.Execute())
+	assert.Equal(t, 30*time.Second, opts.Timeout)
+
+	cmd.SetArgs([]string{"noop", "--timeout", "-1s"})
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--timeout must not be negative")
+}
+
+func TestOptions_RateLimiter(t *testing.T) {
+	opts := &Options{}
+	assert.Nil(t, opts.rateLimiter(&config.Config{}))
+
+	// The setting applies unless --max-rps overrides it, and the limiter is
+	// shared once created
+	limiter := opts.rateLimiter(&config.Config{MaxRPS: 5})
+	require.NotNil(t, limiter)
+	assert.Same(t, limiter, opts.rateLimiter(&config.Config{MaxRPS: 5}))
+
+	opts = &Options{MaxRPS: 2}
+	assert.NotNil(t, opts.rateLimiter(&config.Config{}))
+
+}
+
+func TestNewCmd_MaxRPS(t *testing.T) {
+	cmd, opts := NewCmd()
+	noop := &cobra.Command{
+		Use: "noop",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return nil
+		},
+	}
+	noop.Flags().Float64Var(&opts.MaxRPS, "max-rps", 0, "")
+	cmd.AddCommand(noop)
+
+	cmd.SetArgs([]string{"noop", "--max-rps", "2.5"})
+	require.NoError(t, cmd.Execute())
+	assert.Equal(t, 2.5, opts.MaxRPS)
+
+	cmd.SetArgs([]string{"noop", "--max-rps", "-1"})
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--max-rps must not be negative")
+}
+
+func TestNewCmd_NetworkSettings(t *testing.T) {
+	t.Setenv(config.HomeEnvVar, t.TempDir())
+	t.Setenv("SFDC_PROXY", "")
+	t.Setenv("SFDC_CA_CERT", "")
+	t.Setenv("SFDC_INSECURE_SKIP_VERIFY", "")
+
+	// run executes a command that records the HTTP client in its context
+	run := func() (*http.Client, string, error) {
+		cmd, opts := NewCmd()
+		stderr := &bytes.Buffer{}
+		opts.Stderr = stderr
+
+		var client *http.Client
+		cmd.AddCommand(&cobra.Command{
+			Use: "noop",
+			RunE: func(cmd *cobra.Command, args []string) error {
+				client, _ = cmd.Context().Value(oauth2.HTTPClient).(*http.Client)
+				return nil
+			},
+		})
+		cmd.SetArgs([]string{"noop"})
+
+		err := cmd.Execute()
+		return client, stderr.String(), err
+	}
+
+	t.Run("default", func(t *testing.T) {
+		client, _, err := run()
+		require.NoError(t, err)
+		assert.Nil(t, client)
+	})
+
+	t.Run("proxy", func(t *testing.T) {
+		t.Setenv("SFDC_PROXY", "http://proxy.corp:3128")
+
+		client, _, err := run()
+		require.NoError(t, err)
+		require.NotNil(t, client, "the proxy should apply to API and token requests")
+
+		req, _ := http.NewRequest(http.MethodGet, "https://example.my.salesforce.com", nil)
+		proxyURL, err := client.Transport.(*http.Transport).Proxy(req)
+		require.NoError(t, err)
+		assert.Equal(t, "http://proxy.corp:3128", proxyURL.String())
+	})
+
+	t.Run("insecure", func(t *testing.T) {
+		t.Setenv("SFDC_INSECURE_SKIP_VERIFY", "1")
+
+		_, stderr, err := run()
+		require.NoError(t, err)
+		assert.Contains(t, stderr, "TLS certificate verification is disabled")
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		t.Setenv("SFDC_CA_CERT", filepath.Join(t.TempDir(), "missing.pem"))
+
+		_, _, err := run()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid network settings: failed to read CA certificates")
+	})
+}
+
+func TestNewCmd_ConfigDir(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "project")
+	defer config.SetConfigDir("")
+
+	cmd, _ := NewCmd()
+	cmd.AddCommand(&cobra.Command{
+		Use: "noop",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return nil
+		},
+	})
+	cmd.SetArgs([]string{"noop", "--config-dir", dir})
+
+	require.NoError(t, cmd.Execute())
+
+	path, err := config.GetConfigPath()
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, config.ConfigFile), path)
+}
+
+func TestNewCmd_Org(t *testing.T) {
+	dir := t.TempDir()
+	defer config.SetConfigDir("")
+	defer config.SetOrg("")
+	t.Setenv(config.OrgEnvVar, "")
+
+	config.SetConfigDir(dir)
+	require.NoError(t, config.Save(&config.Config{
+		Orgs: map[string]config.OrgProfile{
+			"dev1": {InstanceURL: "https://dev1.my.salesforce.com", ClientID: "dev1-client"},
+		},
+	}))
+
+	newRoot := func() *cobra.Command {
+		cmd, _ := NewCmd()
+		cmd.AddCommand(&cobra.Command{
+			Use: "noop",
+			RunE: func(cmd *cobra.Command, args []string) error {
+				return nil
+			},
+		})
+		return cmd
+	}
+
+	t.Run("known org", func(t *testing.T) {
+		cmd := newRoot()
+		cmd.SetArgs([]string{"noop", "--config-dir", dir, "--org", "dev1"})
+		require.NoError(t, cmd.Execute())
+
+		cfg, err := config.Load()
+		require.NoError(t, err)
+		assert.Equal(t, "dev1", cfg.OrgAlias())
+		assert.Equal(t, "https://dev1.my.salesforce.com", cfg.InstanceURL)
+	})
+
+	t.Run("unknown org", func(t *testing.T) {
+		cmd := newRoot()
+		cmd.SetArgs([]string{"noop", "--config-dir", dir, "--org", "nope"})
+		err := cmd.Execute()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `unknown org "nope"`)
+	})
+}
+
+func TestOptions_WithOrg(t *testing.T) {
+	dir := t.TempDir()
+	defer config.SetConfigDir("")
+	defer config.SetOrg("")
+	t.Setenv(config.OrgEnvVar, "")
+
+	config.SetConfigDir(dir)
+	require.NoError(t, config.Save(&config.Config{
+		DefaultOrg: "prod",
+		Orgs: map[string]config.OrgProfile{
+			"prod": {InstanceURL: "https://prod.my.salesforce.com"},
+			"dev1": {InstanceURL: "https://dev1.my.salesforce.com"},
+		},
+	}))
+
+	opts := &Options{}
+	var instanceURL string
+	err := opts.WithOrg("dev1", func() error {
+		cfg, err := config.Load()
+		instanceURL = cfg.InstanceURL
+		return err
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "https://dev1.my.salesforce.com", instanceURL)
+
+	// The selection is restored afterwards
+	assert.Equal(t, "", config.OrgOverride())
+	assert.Equal(t, "prod", config.ActiveOrg())
+
+	err = opts.WithOrg("nope", func() error {
+		t.Error("fn ran for an unknown org")
+		return nil
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown org "nope"`)
 }
 
 func TestOptions_View(t *testing.T) {
@@ -56,6 +371,45 @@ func TestOptions_SetAPIClient(t *testing.T) {
 	assert.Nil(t, opts.testClient)
 }
 
+func TestOptions_DescribeCache(t *testing.T) {
+	config.SetConfigDir(t.TempDir())
+	defer config.SetConfigDir("")
+
+	cfg := &config.Config{InstanceURL: "https://example.my.salesforce.com/"}
+
+	t.Run("per org and API version", func(t *testing.T) {
+		opts := &Options{APIVersion: "v60.0"}
+		cache, ttl, err := opts.describeCache(cfg)
+		require.NoError(t, err)
+		assert.Equal(t, config.DefaultDescribeCacheTTL, ttl)
+
+		dir, err := config.GetCacheDir()
+		require.NoError(t, err)
+		require.IsType(t, &api.FileDescribeCache{}, cache)
+		assert.Equal(t, filepath.Join(dir, "describe", "example.my.salesforce.com", "v60.0"), cache.(*api.FileDescribeCache).Dir)
+	})
+
+	t.Run("no-cache", func(t *testing.T) {
+		opts := &Options{NoCache: true}
+		cache, _, err := opts.describeCache(cfg)
+		require.NoError(t, err)
+		assert.Nil(t, cache)
+	})
+
+	t.Run("disabled by TTL", func(t *testing.T) {
+		opts := &Options{}
+		cache, _, err := opts.describeCache(&config.Config{InstanceURL: cfg.InstanceURL, DescribeCacheTTL: "0"})
+		require.NoError(t, err)
+		assert.Nil(t, cache)
+	})
+
+	t.Run("invalid TTL", func(t *testing.T) {
+		opts := &Options{}
+		_, _, err := opts.describeCache(&config.Config{InstanceURL: cfg.InstanceURL, DescribeCacheTTL: "soon"})
+		assert.ErrorContains(t, err, "invalid describe_cache_ttl")
+	})
+}
+
 func TestRegisterCommands(t *testing.T) {
 	cmd, opts := NewCmd()
 
@@ -81,3 +435,25 @@ func TestRegisterCommands_Multiple(t *testing.T) {
 	RegisterCommands(cmd, opts, registrar1, registrar2, registrar3)
 	assert.Equal(t, 3, callCount)
 }
+
+func TestOptions_Context(t *testing.T) {
+	opts := &Options{}
+	assert.NotNil(t, opts.Context())
+
+	type key struct{}
+	ctx := context.WithValue(context.Background(), key{}, "command")
+
+	cmd, opts := NewCmd()
+	var got context.Context
+	cmd.AddCommand(&cobra.Command{
+		Use: "noop",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			got = opts.Context()
+			return nil
+		},
+	})
+	cmd.SetArgs([]string{"noop"})
+
+	require.NoError(t, cmd.ExecuteContext(ctx))
+	assert.Equal(t, "command", got.Value(key{}))
+}
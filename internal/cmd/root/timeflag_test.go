@@ -0,0 +1,32 @@
+package root
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTimeFlag(t *testing.T) {
+	now := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+
+	got, err := ParseTimeFlag("30m", now)
+	require.NoError(t, err)
+	assert.Equal(t, now.Add(-30*time.Minute), got)
+
+	got, err = ParseTimeFlag("7d", now)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 1, 8, 12, 0, 0, 0, time.UTC), got)
+
+	got, err = ParseTimeFlag("2024-01-15T10:00:00Z", now)
+	require.NoError(t, err)
+	assert.True(t, got.Equal(time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)))
+
+	got, err = ParseTimeFlag("2024-01-15", now)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 1, 15, 0, 0, 0, 0, time.Local), got)
+
+	_, err = ParseTimeFlag("soon", now)
+	assert.Error(t, err)
+}
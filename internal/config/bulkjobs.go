@@ -0,0 +1,133 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// PendingBulkJob is a bulk ingest job that an import created and had not
+// closed yet. It is recorded until the job is closed, so that the job can be
+// finished or aborted if the import was interrupted.
+type PendingBulkJob struct {
+	JobID       string `json:"job_id"`
+	InstanceURL string `json:"instance_url"`
+
+	Object          string `json:"object"`
+	Operation       string `json:"operation"`
+	ExternalID      string `json:"external_id,omitempty"`
+	ContentType     string `json:"content_type,omitempty"`
+	LineEnding      string `json:"line_ending,omitempty"`
+	ColumnDelimiter string `json:"column_delimiter,omitempty"`
+
+	// File is the file the job's data was read from, and FileSize its size
+	// then; empty when the data can't be read again, e.g. from stdin
+	File     string `json:"file,omitempty"`
+	FileSize int64  `json:"file_size,omitempty"`
+	Mapping  string `json:"mapping,omitempty"`
+	// Offset and Length locate the job's records in the file's data: bytes
+	// after the header row for CSV, or record indexes for JSON
+	Offset int `json:"offset"`
+	Length int `json:"length"`
+	Rows   int `json:"rows"`
+
+	// Uploaded is set once all of the job's data has been uploaded
+	Uploaded bool      `json:"uploaded"`
+	Created  time.Time `json:"created"`
+}
+
+// bulkJobsMu serializes updates of the pending jobs file by the jobs of an
+// import, which are started concurrently.
+var bulkJobsMu sync.Mutex
+
+// LoadPendingBulkJobs returns the recorded pending bulk jobs, oldest first.
+func LoadPendingBulkJobs() ([]PendingBulkJob, error) {
+	bulkJobsMu.Lock()
+	defer bulkJobsMu.Unlock()
+	return loadPendingBulkJobs()
+}
+
+// SavePendingBulkJob records a pending bulk job, replacing any record of
+// the same job.
+func SavePendingBulkJob(job PendingBulkJob) error {
+	bulkJobsMu.Lock()
+	defer bulkJobsMu.Unlock()
+
+	jobs, err := loadPendingBulkJobs()
+	if err != nil {
+		return err
+	}
+	for i := range jobs {
+		if jobs[i].JobID == job.JobID {
+			jobs[i] = job
+			return savePendingBulkJobs(jobs)
+		}
+	}
+	return savePendingBulkJobs(append(jobs, job))
+}
+
+// RemovePendingBulkJob removes the record of a bulk job, once it has been
+// closed or aborted. Removing a job that is not recorded is not an error.
+func RemovePendingBulkJob(jobID string) error {
+	bulkJobsMu.Lock()
+	defer bulkJobsMu.Unlock()
+
+	jobs, err := loadPendingBulkJobs()
+	if err != nil {
+		return err
+	}
+	kept := jobs[:0]
+	for _, job := range jobs {
+		if job.JobID != jobID {
+			kept = append(kept, job)
+		}
+	}
+	if len(kept) == len(jobs) {
+		return nil
+	}
+	return savePendingBulkJobs(kept)
+}
+
+func loadPendingBulkJobs() ([]PendingBulkJob, error) {
+	dir, err := GetConfigDir()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, BulkJobsFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var jobs []PendingBulkJob
+	if err := json.Unmarshal(data, &jobs); err != nil {
+		return nil, err
+	}
+	return jobs, nil
+}
+
+func savePendingBulkJobs(jobs []PendingBulkJob) error {
+	dir, err := GetConfigDir()
+	if err != nil {
+		return err
+	}
+
+	path := filepath.Join(dir, BulkJobsFile)
+	if len(jobs) == 0 {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+
+	data, err := json.MarshalIndent(jobs, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, FilePerm)
+}
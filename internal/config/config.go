@@ -4,8 +4,13 @@ package config
 
 import (
 	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
 )
 
 const (
@@ -15,8 +20,24 @@ const (
 	ConfigFile = "config.json"
 	// TokenFile is the name of the OAuth token file (fallback storage)
 	TokenFile = "token.json"
+	// OrgCacheFile is the name of the file caching org details per instance
+	OrgCacheFile = "orgs.json"
+	// BulkJobsFile is the name of the file recording bulk jobs an import
+	// created but has not closed yet
+	BulkJobsFile = "bulk-jobs.json"
+	// TokenDir is the directory holding per-org OAuth token files (fallback storage)
+	TokenDir = "tokens"
+	// ClientSecretFile is the name of the client secret file (fallback
+	// storage); per-org secrets are kept in TokenDir
+	ClientSecretFile = "client_secret"
+	// CacheDir is the directory holding cached API results, e.g. describes
+	CacheDir = "cache"
 )
 
+// DefaultDescribeCacheTTL is how long cached object describes are used
+// before Salesforce is asked whether they changed.
+const DefaultDescribeCacheTTL = 24 * time.Hour
+
 // File and directory permission constants for consistent security settings.
 const (
 	// DirPerm is the permission for config directories (owner read/write/execute only)
@@ -25,26 +46,255 @@ const (
 	FilePerm = 0600
 )
 
+// HomeEnvVar is the environment variable that overrides the configuration directory.
+const HomeEnvVar = "SFDC_HOME"
+
+// OrgEnvVar is the environment variable that selects the org profile.
+const OrgEnvVar = "SFDC_ORG"
+
+// dirOverride is the configuration directory set via SetConfigDir (e.g. --config-dir).
+var dirOverride string
+
+// orgOverride is the org profile alias set via SetOrg (e.g. --org).
+var orgOverride string
+
+// orgAlias matches valid org profile aliases.
+var orgAlias = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]*$`)
+
+// Non-interactive values of AuthFlow. An empty AuthFlow is the browser login
+// of 'sfdc init'.
+const (
+	// AuthFlowJWT authenticates with the OAuth 2.0 JWT bearer flow
+	AuthFlowJWT = "jwt"
+	// AuthFlowClientCredentials authenticates with the OAuth 2.0 client
+	// credentials flow
+	AuthFlowClientCredentials = "client_credentials"
+	// AuthFlowPassword authenticates with a username, password, and security
+	// token through the SOAP API login call. Discouraged, but it needs no
+	// Connected App.
+	AuthFlowPassword = "password"
+)
+
+// TokenStorageEncryptedFile is the TokenStorage value that stores tokens in
+// a file encrypted with a passphrase instead of the system keychain.
+const TokenStorageEncryptedFile = "encrypted_file"
+
+// OrgProfile holds the connection settings of a named org.
+type OrgProfile struct {
+	// InstanceURL is the Salesforce instance URL of the org
+	InstanceURL string `json:"instance_url,omitempty"`
+	// ClientID is the OAuth Connected App Consumer Key used for the org
+	ClientID string `json:"client_id,omitempty"`
+	// AuthFlow is how tokens are obtained: empty for the browser login of
+	// 'sfdc init', AuthFlowJWT, AuthFlowClientCredentials, or AuthFlowPassword
+	AuthFlow string `json:"auth_flow,omitempty"`
+	// Username is the Salesforce user the JWT bearer and password flows log
+	// in as
+	Username string `json:"username,omitempty"`
+	// JWTKeyFile is the path to the private key that signs JWT assertions
+	JWTKeyFile string `json:"jwt_key_file,omitempty"`
+	// JWTAudience is the login URL JWT assertions are issued for
+	JWTAudience string `json:"jwt_audience,omitempty"`
+}
+
 // Config represents the CLI configuration.
 type Config struct {
 	// InstanceURL is the Salesforce instance URL (e.g., https://mycompany.my.salesforce.com)
 	InstanceURL string `json:"instance_url,omitempty"`
 	// ClientID is the OAuth Connected App Consumer Key
 	ClientID string `json:"client_id,omitempty"`
+	// ClientSecret is the Consumer Secret from SFDC_CLIENT_SECRET. It is
+	// never saved: a secret given at login is kept with the tokens, in
+	// secure storage.
+	ClientSecret string `json:"-"`
+	// AuthFlow is how tokens are obtained: empty for the browser login of
+	// 'sfdc init', AuthFlowJWT, AuthFlowClientCredentials, or AuthFlowPassword
+	AuthFlow string `json:"auth_flow,omitempty"`
+	// Username is the Salesforce user the JWT bearer and password flows log
+	// in as
+	Username string `json:"username,omitempty"`
+	// JWTKeyFile is the path to the private key that signs JWT assertions
+	JWTKeyFile string `json:"jwt_key_file,omitempty"`
+	// JWTAudience is the login URL JWT assertions are issued for
+	JWTAudience string `json:"jwt_audience,omitempty"`
+	// Password and SecurityToken are used by the password flow to log in
+	// again when the session expires. They are only read from the
+	// environment, never saved.
+	Password      string `json:"-"`
+	SecurityToken string `json:"-"`
+	// ProductionGuard requires confirmation before destructive operations
+	// against production orgs. Unset means enabled.
+	ProductionGuard *bool `json:"production_guard,omitempty"`
+	// TokenStorage selects where tokens are stored: empty for the system
+	// keychain (with a plaintext file fallback), or TokenStorageEncryptedFile
+	TokenStorage string `json:"token_storage,omitempty"`
+	// DescribeCacheTTL is how long cached object describes are used, as a
+	// duration such as "12h"; "0" turns the cache off. Empty means
+	// DefaultDescribeCacheTTL.
+	DescribeCacheTTL string `json:"describe_cache_ttl,omitempty"`
+	// Compression gzips request and response bodies. Unset means enabled.
+	Compression *bool `json:"compression,omitempty"`
+	// Proxy is the URL of the HTTP(S) proxy requests go through. Empty means
+	// the HTTPS_PROXY, HTTP_PROXY, and NO_PROXY environment variables apply.
+	Proxy string `json:"proxy,omitempty"`
+	// CACertFile is a PEM file of certificate authorities trusted in
+	// addition to the system ones, e.g. for a proxy that inspects TLS
+	CACertFile string `json:"ca_cert_file,omitempty"`
+	// InsecureSkipVerify turns off TLS certificate verification. Only for
+	// testing, e.g. against a sandbox behind TLS inspection.
+	InsecureSkipVerify bool `json:"insecure_skip_verify,omitempty"`
+	// MaxRPS limits the requests per second commands send, across all API
+	// clients. Zero means no limit.
+	MaxRPS float64 `json:"max_rps,omitempty"`
+	// RateLimitBurst is how many requests may be sent at once before
+	// MaxRPS applies. Zero means MaxRPS, rounded up.
+	RateLimitBurst int `json:"rate_limit_burst,omitempty"`
+	// DefaultOrg is the alias of the org profile used when none is selected
+	DefaultOrg string `json:"default_org,omitempty"`
+	// Orgs are the named org profiles, keyed by alias
+	Orgs map[string]OrgProfile `json:"orgs,omitempty"`
+
+	// org is the alias of the active org profile. When set, the connection
+	// settings hold the profile's values and Save writes them back to it.
+	org string
+	// base holds the top-level connection settings from the file, which are
+	// restored on save while an org profile is active
+	base OrgProfile
+}
+
+// profile returns the connection settings.
+func (c *Config) profile() OrgProfile {
+	return OrgProfile{
+		InstanceURL: c.InstanceURL,
+		ClientID:    c.ClientID,
+		AuthFlow:    c.AuthFlow,
+		Username:    c.Username,
+		JWTKeyFile:  c.JWTKeyFile,
+		JWTAudience: c.JWTAudience,
+	}
+}
+
+// setProfile replaces the connection settings.
+func (c *Config) setProfile(p OrgProfile) {
+	c.InstanceURL = p.InstanceURL
+	c.ClientID = p.ClientID
+	c.AuthFlow = p.AuthFlow
+	c.Username = p.Username
+	c.JWTKeyFile = p.JWTKeyFile
+	c.JWTAudience = p.JWTAudience
+}
+
+// OrgAlias returns the alias of the active org profile, or an empty string
+// if the top-level settings are in use.
+func (c *Config) OrgAlias() string {
+	return c.org
+}
+
+// HasOrg reports whether an org profile with the given alias exists.
+func (c *Config) HasOrg(alias string) bool {
+	_, ok := c.Orgs[alias]
+	return ok
+}
+
+// HasDefault reports whether commands have an org to use without --org,
+// either a default profile or top-level settings.
+func (c *Config) HasDefault() bool {
+	return c.DefaultOrg != "" || c.base.InstanceURL != ""
+}
+
+// Configured reports whether the connection settings are complete enough to
+// log in. The password flow needs no Connected App, so no client ID.
+func (c *Config) Configured() bool {
+	return c.InstanceURL != "" && (c.ClientID != "" || c.AuthFlow == AuthFlowPassword)
+}
+
+// DescribeCacheMaxAge returns how long cached object describes are used.
+// Zero means describes are not cached.
+func (c *Config) DescribeCacheMaxAge() (time.Duration, error) {
+	if c.DescribeCacheTTL == "" {
+		return DefaultDescribeCacheTTL, nil
+	}
+	ttl, err := time.ParseDuration(c.DescribeCacheTTL)
+	if err != nil || ttl < 0 {
+		return 0, fmt.Errorf("invalid describe_cache_ttl %q (expected a duration such as 12h, or 0 to disable)", c.DescribeCacheTTL)
+	}
+	return ttl, nil
+}
+
+// CompressionEnabled reports whether request and response bodies are
+// gzipped.
+func (c *Config) CompressionEnabled() bool {
+	return c.Compression == nil || *c.Compression
+}
+
+// ProductionGuardEnabled reports whether destructive operations against
+// production orgs require confirmation.
+func (c *Config) ProductionGuardEnabled() bool {
+	return c.ProductionGuard == nil || *c.ProductionGuard
+}
+
+// SetConfigDir overrides the configuration directory. An empty string restores
+// the default resolution.
+func SetConfigDir(dir string) {
+	dirOverride = dir
+}
+
+// SetOrg selects the org profile by alias, overriding SFDC_ORG and the
+// default org. An empty string restores the default resolution.
+func SetOrg(alias string) {
+	orgOverride = alias
+}
+
+// OrgOverride returns the alias set with SetOrg, or an empty string if
+// there is none.
+func OrgOverride() string {
+	return orgOverride
+}
+
+// ValidateOrgAlias returns an error if alias cannot be used as an org
+// profile name.
+func ValidateOrgAlias(alias string) error {
+	if !orgAlias.MatchString(alias) {
+		return fmt.Errorf("invalid org alias %q: must start with a letter or digit and contain only letters, digits, '.', '_' and '-'", alias)
+	}
+	return nil
+}
+
+// ActiveOrg returns the alias of the org profile in use, or an empty string
+// if the top-level settings are in use.
+func ActiveOrg() string {
+	cfg, err := Load()
+	if err != nil {
+		return orgOverride
+	}
+	return cfg.OrgAlias()
+}
+
+// ConfigDirOverride returns the overridden configuration directory from
+// SetConfigDir or SFDC_HOME, or an empty string if the default is in use.
+func ConfigDirOverride() string {
+	if dirOverride != "" {
+		return dirOverride
+	}
+	return os.Getenv(HomeEnvVar)
 }
 
 // GetConfigDir returns the configuration directory path, creating it if needed.
-// Uses XDG_CONFIG_HOME if set, otherwise ~/.config/salesforce-cli
+// Precedence: SetConfigDir (--config-dir) → SFDC_HOME → XDG_CONFIG_HOME/salesforce-cli
+// → ~/.config/salesforce-cli
 func GetConfigDir() (string, error) {
-	configHome := os.Getenv("XDG_CONFIG_HOME")
-	if configHome == "" {
-		home, err := os.UserHomeDir()
-		if err != nil {
-			return "", err
+	configDir := ConfigDirOverride()
+	if configDir == "" {
+		configHome := os.Getenv("XDG_CONFIG_HOME")
+		if configHome == "" {
+			home, err := os.UserHomeDir()
+			if err != nil {
+				return "", err
+			}
+			configHome = filepath.Join(home, ".config")
 		}
-		configHome = filepath.Join(home, ".config")
+		configDir = filepath.Join(configHome, DirName)
 	}
-	configDir := filepath.Join(configHome, DirName)
 
 	if err := os.MkdirAll(configDir, DirPerm); err != nil {
 		return "", err
@@ -62,15 +312,52 @@ func GetConfigPath() (string, error) {
 	return filepath.Join(dir, ConfigFile), nil
 }
 
-// GetTokenPath returns the full path to token.json (fallback storage)
+// GetTokenPath returns the full path to the token file (fallback storage):
+// token.json, or tokens/<alias>.json when an org profile is active.
 func GetTokenPath() (string, error) {
 	dir, err := GetConfigDir()
 	if err != nil {
 		return "", err
 	}
+	if alias := ActiveOrg(); alias != "" {
+		return filepath.Join(dir, TokenDir, alias+".json"), nil
+	}
 	return filepath.Join(dir, TokenFile), nil
 }
 
+// GetClientSecretPath returns the full path to the client secret file
+// (fallback storage): client_secret, or tokens/<alias>.secret when an org
+// profile is active.
+func GetClientSecretPath() (string, error) {
+	dir, err := GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+	if alias := ActiveOrg(); alias != "" {
+		return filepath.Join(dir, TokenDir, alias+".secret"), nil
+	}
+	return filepath.Join(dir, ClientSecretFile), nil
+}
+
+// GetCacheDir returns the path of the cache directory. It is not created;
+// caches create their own subdirectories when they store entries.
+func GetCacheDir() (string, error) {
+	dir, err := GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, CacheDir), nil
+}
+
+// ClearCache removes the cache directory and everything in it.
+func ClearCache() error {
+	dir, err := GetCacheDir()
+	if err != nil {
+		return err
+	}
+	return os.RemoveAll(dir)
+}
+
 // ShortenPath replaces the home directory prefix with ~ for display purposes.
 // This prevents exposing full paths including usernames in error messages.
 func ShortenPath(path string) string {
@@ -86,6 +373,10 @@ func ShortenPath(path string) string {
 
 // Load loads the configuration from config.json with environment variable overrides.
 // Environment variable precedence: SFDC_* → SALESFORCE_* → config file
+//
+// If an org profile is selected (SetOrg, then SFDC_ORG, then the default
+// org), InstanceURL and ClientID are taken from that profile. They are empty
+// if the profile does not exist yet.
 func Load() (*Config, error) {
 	cfg := &Config{}
 
@@ -107,6 +398,12 @@ func Load() (*Config, error) {
 		}
 	}
 
+	cfg.base = cfg.profile()
+	if alias := selectedOrg(cfg); alias != "" {
+		cfg.org = alias
+		cfg.setProfile(cfg.Orgs[alias])
+	}
+
 	// Override with environment variables (SFDC_* takes precedence over SALESFORCE_*)
 	if v := getEnvWithFallback("SFDC_INSTANCE_URL", "SALESFORCE_INSTANCE_URL"); v != "" {
 		cfg.InstanceURL = v
@@ -114,18 +411,91 @@ func Load() (*Config, error) {
 	if v := getEnvWithFallback("SFDC_CLIENT_ID", "SALESFORCE_CLIENT_ID"); v != "" {
 		cfg.ClientID = v
 	}
+	if v := getEnvWithFallback("SFDC_CLIENT_SECRET", "SALESFORCE_CLIENT_SECRET"); v != "" {
+		cfg.ClientSecret = v
+	}
+	if v := getEnvWithFallback("SFDC_USERNAME", "SALESFORCE_USERNAME"); v != "" {
+		cfg.Username = v
+	}
+	if v := getEnvWithFallback("SFDC_PASSWORD", "SALESFORCE_PASSWORD"); v != "" {
+		cfg.Password = v
+	}
+	if v := getEnvWithFallback("SFDC_SECURITY_TOKEN", "SALESFORCE_SECURITY_TOKEN"); v != "" {
+		cfg.SecurityToken = v
+	}
+	if v := os.Getenv("SFDC_JWT_KEY_FILE"); v != "" {
+		cfg.AuthFlow = AuthFlowJWT
+		cfg.JWTKeyFile = v
+	}
+	if v := os.Getenv("SFDC_PRODUCTION_GUARD"); v != "" {
+		enabled := v != "0" && !strings.EqualFold(v, "false") && !strings.EqualFold(v, "off")
+		cfg.ProductionGuard = &enabled
+	}
+	if v := os.Getenv("SFDC_TOKEN_STORAGE"); v != "" {
+		cfg.TokenStorage = v
+	}
+	if v := os.Getenv("SFDC_COMPRESSION"); v != "" {
+		enabled := v != "0" && !strings.EqualFold(v, "false") && !strings.EqualFold(v, "off")
+		cfg.Compression = &enabled
+	}
+	if v := os.Getenv("SFDC_DESCRIBE_CACHE_TTL"); v != "" {
+		cfg.DescribeCacheTTL = v
+	}
+	if v := os.Getenv("SFDC_PROXY"); v != "" {
+		cfg.Proxy = v
+	}
+	if v := os.Getenv("SFDC_CA_CERT"); v != "" {
+		cfg.CACertFile = v
+	}
+	if v := os.Getenv("SFDC_INSECURE_SKIP_VERIFY"); v != "" {
+		cfg.InsecureSkipVerify = v != "0" && !strings.EqualFold(v, "false") && !strings.EqualFold(v, "off")
+	}
+	if v := os.Getenv("SFDC_MAX_RPS"); v != "" {
+		rps, err := strconv.ParseFloat(v, 64)
+		if err != nil || rps < 0 {
+			return nil, fmt.Errorf("invalid SFDC_MAX_RPS %q (expected requests per second, or 0 for no limit)", v)
+		}
+		cfg.MaxRPS = rps
+	}
+	if v := os.Getenv("SFDC_RATE_LIMIT_BURST"); v != "" {
+		burst, err := strconv.Atoi(v)
+		if err != nil || burst < 0 {
+			return nil, fmt.Errorf("invalid SFDC_RATE_LIMIT_BURST %q (expected a number of requests)", v)
+		}
+		cfg.RateLimitBurst = burst
+	}
 
 	return cfg, nil
 }
 
-// Save saves the configuration to config.json
+// Save saves the configuration to config.json. While an org profile is
+// active, InstanceURL and ClientID are saved to that profile; if both are
+// empty, the profile is removed.
 func Save(cfg *Config) error {
 	path, err := GetConfigPath()
 	if err != nil {
 		return err
 	}
 
-	data, err := json.MarshalIndent(cfg, "", "  ")
+	out := *cfg
+	if cfg.org != "" {
+		out.Orgs = make(map[string]OrgProfile, len(cfg.Orgs)+1)
+		for alias, profile := range cfg.Orgs {
+			out.Orgs[alias] = profile
+		}
+
+		if profile := cfg.profile(); profile.InstanceURL == "" && profile.ClientID == "" {
+			delete(out.Orgs, cfg.org)
+			if out.DefaultOrg == cfg.org {
+				out.DefaultOrg = ""
+			}
+		} else {
+			out.Orgs[cfg.org] = profile
+		}
+		out.setProfile(cfg.base)
+	}
+
+	data, err := json.MarshalIndent(&out, "", "  ")
 	if err != nil {
 		return err
 	}
@@ -152,7 +522,19 @@ func IsConfigured() bool {
 	if err != nil {
 		return false
 	}
-	return cfg.InstanceURL != "" && cfg.ClientID != ""
+	return cfg.Configured()
+}
+
+// selectedOrg returns the alias of the selected org profile: SetOrg, then
+// SFDC_ORG, then the config's default org.
+func selectedOrg(cfg *Config) string {
+	if orgOverride != "" {
+		return orgOverride
+	}
+	if v := os.Getenv(OrgEnvVar); v != "" {
+		return v
+	}
+	return cfg.DefaultOrg
 }
 
 // getEnvWithFallback returns the value of the primary environment variable,
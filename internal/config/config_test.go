@@ -4,6 +4,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -30,6 +31,7 @@ func TestGetConfigDir(t *testing.T) {
 
 	t.Run("without XDG_CONFIG_HOME", func(t *testing.T) {
 		os.Unsetenv("XDG_CONFIG_HOME")
+		t.Setenv(HomeEnvVar, "")
 
 		dir, err := GetConfigDir()
 		require.NoError(t, err)
@@ -40,6 +42,44 @@ func TestGetConfigDir(t *testing.T) {
 	})
 }
 
+func TestGetConfigDir_Override(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	t.Run("SFDC_HOME", func(t *testing.T) {
+		home := filepath.Join(t.TempDir(), "sfdc-home")
+		t.Setenv(HomeEnvVar, home)
+
+		dir, err := GetConfigDir()
+		require.NoError(t, err)
+		assert.Equal(t, home, dir)
+		assert.Equal(t, home, ConfigDirOverride())
+
+		path, err := GetTokenPath()
+		require.NoError(t, err)
+		assert.Equal(t, filepath.Join(home, TokenFile), path)
+	})
+
+	t.Run("SetConfigDir takes precedence over SFDC_HOME", func(t *testing.T) {
+		t.Setenv(HomeEnvVar, t.TempDir())
+		custom := filepath.Join(t.TempDir(), "project")
+		SetConfigDir(custom)
+		defer SetConfigDir("")
+
+		dir, err := GetConfigDir()
+		require.NoError(t, err)
+		assert.Equal(t, custom, dir)
+
+		info, err := os.Stat(dir)
+		require.NoError(t, err)
+		assert.True(t, info.IsDir())
+	})
+
+	t.Run("no override", func(t *testing.T) {
+		t.Setenv(HomeEnvVar, "")
+		assert.Empty(t, ConfigDirOverride())
+	})
+}
+
 func TestShortenPath(t *testing.T) {
 	home, _ := os.UserHomeDir()
 
@@ -188,3 +228,280 @@ func TestIsConfigured(t *testing.T) {
 		assert.True(t, IsConfigured())
 	})
 }
+
+func TestProductionGuardEnabled(t *testing.T) {
+	t.Setenv(HomeEnvVar, t.TempDir())
+
+	t.Run("default on", func(t *testing.T) {
+		t.Setenv("SFDC_PRODUCTION_GUARD", "")
+		cfg, err := Load()
+		require.NoError(t, err)
+		assert.True(t, cfg.ProductionGuardEnabled())
+	})
+
+	t.Run("disabled by config", func(t *testing.T) {
+		t.Setenv("SFDC_PRODUCTION_GUARD", "")
+		disabled := false
+		require.NoError(t, Save(&Config{ProductionGuard: &disabled}))
+		defer Clear()
+
+		cfg, err := Load()
+		require.NoError(t, err)
+		assert.False(t, cfg.ProductionGuardEnabled())
+	})
+
+	t.Run("disabled by env", func(t *testing.T) {
+		t.Setenv("SFDC_PRODUCTION_GUARD", "false")
+		cfg, err := Load()
+		require.NoError(t, err)
+		assert.False(t, cfg.ProductionGuardEnabled())
+	})
+}
+
+func TestDescribeCacheMaxAge(t *testing.T) {
+	tests := []struct {
+		ttl     string
+		want    time.Duration
+		wantErr bool
+	}{
+		{ttl: "", want: DefaultDescribeCacheTTL},
+		{ttl: "12h", want: 12 * time.Hour},
+		{ttl: "0", want: 0},
+		{ttl: "-1h", wantErr: true},
+		{ttl: "tomorrow", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ttl, func(t *testing.T) {
+			ttl, err := (&Config{DescribeCacheTTL: tt.ttl}).DescribeCacheMaxAge()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, ttl)
+		})
+	}
+
+	t.Run("env override", func(t *testing.T) {
+		t.Setenv(HomeEnvVar, t.TempDir())
+		t.Setenv("SFDC_DESCRIBE_CACHE_TTL", "30m")
+
+		cfg, err := Load()
+		require.NoError(t, err)
+		ttl, err := cfg.DescribeCacheMaxAge()
+		require.NoError(t, err)
+		assert.Equal(t, 30*time.Minute, ttl)
+	})
+}
+
+func TestClearCache(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv(HomeEnvVar, home)
+
+	dir, err := GetCacheDir()
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(home, CacheDir), dir)
+
+	entry := filepath.Join(dir, "describe", "example.my.salesforce.com", "v62.0", "Account.json")
+	require.NoError(t, os.MkdirAll(filepath.Dir(entry), DirPerm))
+	require.NoError(t, os.WriteFile(entry, []byte("{}"), FilePerm))
+
+	require.NoError(t, ClearCache())
+	assert.NoDirExists(t, dir)
+
+	// Clearing an absent cache is not an error
+	require.NoError(t, ClearCache())
+}
+
+func TestCompressionEnabled(t *testing.T) {
+	t.Setenv(HomeEnvVar, t.TempDir())
+
+	t.Run("default on", func(t *testing.T) {
+		t.Setenv("SFDC_COMPRESSION", "")
+		cfg, err := Load()
+		require.NoError(t, err)
+		assert.True(t, cfg.CompressionEnabled())
+	})
+
+	t.Run("disabled by env", func(t *testing.T) {
+		t.Setenv("SFDC_COMPRESSION", "off")
+		cfg, err := Load()
+		require.NoError(t, err)
+		assert.False(t, cfg.CompressionEnabled())
+	})
+}
+
+func TestNetworkSettings(t *testing.T) {
+	t.Setenv(HomeEnvVar, t.TempDir())
+	require.NoError(t, Save(&Config{Proxy: "http://proxy.corp:3128", CACertFile: "/etc/ssl/corp.pem"}))
+
+	t.Run("from file", func(t *testing.T) {
+		cfg, err := Load()
+		require.NoError(t, err)
+		assert.Equal(t, "http://proxy.corp:3128", cfg.Proxy)
+		assert.Equal(t, "/etc/ssl/corp.pem", cfg.CACertFile)
+		assert.False(t, cfg.InsecureSkipVerify)
+	})
+
+	t.Run("from env", func(t *testing.T) {
+		t.Setenv("SFDC_PROXY", "http://other:8080")
+		t.Setenv("SFDC_CA_CERT", "/tmp/ca.pem")
+		t.Setenv("SFDC_INSECURE_SKIP_VERIFY", "true")
+
+		cfg, err := Load()
+		require.NoError(t, err)
+		assert.Equal(t, "http://other:8080", cfg.Proxy)
+		assert.Equal(t, "/tmp/ca.pem", cfg.CACertFile)
+		assert.True(t, cfg.InsecureSkipVerify)
+	})
+}
+
+func TestRateLimitSettings(t *testing.T) {
+	t.Setenv(HomeEnvVar, t.TempDir())
+	t.Setenv("SFDC_MAX_RPS", "")
+	t.Setenv("SFDC_RATE_LIMIT_BURST", "")
+	require.NoError(t, Save(&Config{MaxRPS: 5, RateLimitBurst: 10}))
+
+	t.Run("from file", func(t *testing.T) {
+		cfg, err := Load()
+		require.NoError(t, err)
+		assert.Equal(t, 5.0, cfg.MaxRPS)
+		assert.Equal(t, 10, cfg.RateLimitBurst)
+	})
+
+	t.Run("from env", func(t *testing.T) {
+		t.Setenv("SFDC_MAX_RPS", "2.5")
+		t.Setenv("SFDC_RATE_LIMIT_BURST", "4")
+
+		cfg, err := Load()
+		require.NoError(t, err)
+		assert.Equal(t, 2.5, cfg.MaxRPS)
+		assert.Equal(t, 4, cfg.RateLimitBurst)
+	})
+
+	t.Run("invalid env", func(t *testing.T) {
+		t.Setenv("SFDC_MAX_RPS", "fast")
+
+		_, err := Load()
+		assert.EqualError(t, err, `invalid SFDC_MAX_RPS "fast" (expected requests per second, or 0 for no limit)`)
+	})
+}
+
+func TestOrgProfiles(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv(HomeEnvVar, "")
+	t.Setenv(OrgEnvVar, "")
+	t.Setenv("SFDC_INSTANCE_URL", "")
+	t.Setenv("SFDC_CLIENT_ID", "")
+	t.Setenv("SALESFORCE_INSTANCE_URL", "")
+	t.Setenv("SALESFORCE_CLIENT_ID", "")
+	defer SetOrg("")
+
+	require.NoError(t, Save(&Config{
+		InstanceURL: "https://legacy.my.salesforce.com",
+		ClientID:    "legacy-client",
+		Orgs: map[string]OrgProfile{
+			"prod": {InstanceURL: "https://prod.my.salesforce.com", ClientID: "prod-client"},
+		},
+	}))
+
+	t.Run("top-level settings without an org", func(t *testing.T) {
+		cfg, err := Load()
+		require.NoError(t, err)
+		assert.Empty(t, cfg.OrgAlias())
+		assert.Equal(t, "https://legacy.my.salesforce.com", cfg.InstanceURL)
+		assert.True(t, cfg.HasDefault())
+	})
+
+	t.Run("SFDC_ORG selects a profile", func(t *testing.T) {
+		t.Setenv(OrgEnvVar, "prod")
+		cfg, err := Load()
+		require.NoError(t, err)
+		assert.Equal(t, "prod", cfg.OrgAlias())
+		assert.Equal(t, "https://prod.my.salesforce.com", cfg.InstanceURL)
+		assert.Equal(t, "prod-client", cfg.ClientID)
+	})
+
+	t.Run("save writes to the active profile", func(t *testing.T) {
+		SetOrg("dev1")
+		defer SetOrg("")
+
+		cfg, err := Load()
+		require.NoError(t, err)
+		assert.False(t, cfg.HasOrg("dev1"))
+		assert.Empty(t, cfg.InstanceURL)
+
+		cfg.InstanceURL = "https://dev1.sandbox.my.salesforce.com"
+		cfg.ClientID = "dev1-client"
+		cfg.DefaultOrg = "dev1"
+		require.NoError(t, Save(cfg))
+
+		SetOrg("")
+		loaded, err := Load()
+		require.NoError(t, err)
+		assert.Equal(t, "dev1", loaded.OrgAlias())
+		assert.Equal(t, "https://dev1.sandbox.my.salesforce.com", loaded.InstanceURL)
+		assert.True(t, loaded.HasOrg("prod"))
+
+		path, err := GetTokenPath()
+		require.NoError(t, err)
+		assert.Equal(t, filepath.Join(TokenDir, "dev1.json"), filepath.Join(filepath.Base(filepath.Dir(path)), filepath.Base(path)))
+	})
+
+	t.Run("clearing a profile removes it", func(t *testing.T) {
+		cfg, err := Load()
+		require.NoError(t, err)
+		require.Equal(t, "dev1", cfg.OrgAlias())
+
+		cfg.InstanceURL = ""
+		cfg.ClientID = ""
+		require.NoError(t, Save(cfg))
+
+		loaded, err := Load()
+		require.NoError(t, err)
+		assert.Empty(t, loaded.OrgAlias())
+		assert.Empty(t, loaded.DefaultOrg)
+		assert.False(t, loaded.HasOrg("dev1"))
+		assert.Equal(t, "https://legacy.my.salesforce.com", loaded.InstanceURL)
+	})
+}
+
+func TestValidateOrgAlias(t *testing.T) {
+	assert.NoError(t, ValidateOrgAlias("prod"))
+	assert.NoError(t, ValidateOrgAlias("dev-1.sandbox_a"))
+	assert.Error(t, ValidateOrgAlias(""))
+	assert.Error(t, ValidateOrgAlias("../prod"))
+	assert.Error(t, ValidateOrgAlias("my org"))
+}
+
+func TestPendingBulkJobs(t *testing.T) {
+	t.Setenv(HomeEnvVar, t.TempDir())
+
+	jobs, err := LoadPendingBulkJobs()
+	require.NoError(t, err)
+	assert.Empty(t, jobs)
+
+	require.NoError(t, SavePendingBulkJob(PendingBulkJob{JobID: "750xx000000001", Object: "Account"}))
+	require.NoError(t, SavePendingBulkJob(PendingBulkJob{JobID: "750xx000000002", Object: "Contact"}))
+	require.NoError(t, SavePendingBulkJob(PendingBulkJob{JobID: "750xx000000001", Object: "Account", Uploaded: true}))
+
+	jobs, err = LoadPendingBulkJobs()
+	require.NoError(t, err)
+	require.Len(t, jobs, 2)
+	assert.Equal(t, "750xx000000001", jobs[0].JobID)
+	assert.True(t, jobs[0].Uploaded)
+
+	require.NoError(t, RemovePendingBulkJob("750xx000000001"))
+	require.NoError(t, RemovePendingBulkJob("750xx000000009"))
+	jobs, err = LoadPendingBulkJobs()
+	require.NoError(t, err)
+	require.Len(t, jobs, 1)
+	assert.Equal(t, "750xx000000002", jobs[0].JobID)
+
+	// The file is removed with the last job
+	require.NoError(t, RemovePendingBulkJob("750xx000000002"))
+	dir, err := GetConfigDir()
+	require.NoError(t, err)
+	assert.NoFileExists(t, filepath.Join(dir, BulkJobsFile))
+}
@@ -0,0 +1,75 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+// CachedOrg holds org details that do not change for an instance, so they
+// need not be queried on every command.
+type CachedOrg struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	IsSandbox bool   `json:"is_sandbox"`
+
+	// DefaultCurrency is empty in caches written before it was added
+	DefaultCurrency string `json:"default_currency,omitempty"`
+}
+
+// LoadCachedOrg returns the cached details of the org at instanceURL, or nil
+// if none are cached.
+func LoadCachedOrg(instanceURL string) (*CachedOrg, error) {
+	orgs, err := loadOrgCache()
+	if err != nil {
+		return nil, err
+	}
+
+	org, ok := orgs[instanceURL]
+	if !ok {
+		return nil, nil
+	}
+	return &org, nil
+}
+
+// SaveCachedOrg caches the details of the org at instanceURL.
+func SaveCachedOrg(instanceURL string, org CachedOrg) error {
+	orgs, err := loadOrgCache()
+	if err != nil {
+		orgs = make(map[string]CachedOrg)
+	}
+	orgs[instanceURL] = org
+
+	dir, err := GetConfigDir()
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(orgs, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(dir, OrgCacheFile), data, FilePerm)
+}
+
+func loadOrgCache() (map[string]CachedOrg, error) {
+	dir, err := GetConfigDir()
+	if err != nil {
+		return nil, err
+	}
+
+	orgs := make(map[string]CachedOrg)
+	data, err := os.ReadFile(filepath.Join(dir, OrgCacheFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return orgs, nil
+		}
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, &orgs); err != nil {
+		return nil, err
+	}
+	return orgs, nil
+}
@@ -0,0 +1,332 @@
+// Package csvmap transforms CSV files from other systems into the columns a
+// Salesforce import expects, as described by a mapping file: renaming
+// columns, setting constant values, reformatting dates, and concatenating
+// columns.
+//
+// A mapping lists the output fields in order:
+//
+//	{
+//	  "fields": [
+//	    {"field": "Name", "column": "company_name"},
+//	    {"field": "Type", "value": "Customer"},
+//	    {"field": "CloseDate", "column": "closed_on", "dateFormat": "MM/DD/YYYY"},
+//	    {"field": "Description", "concat": ["notes", "region"], "separator": " - "}
+//	  ]
+//	}
+//
+// Rows are transformed one at a time, so files of any size can be mapped.
+package csvmap
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// SalesforceDate and SalesforceDateTime are the formats dates and times are
+// written in.
+const (
+	SalesforceDate     = "2006-01-02"
+	SalesforceDateTime = "2006-01-02T15:04:05.000Z07:00"
+)
+
+// Mapping describes how to build the output columns from the input ones.
+type Mapping struct {
+	Fields []Field `json:"fields"`
+
+	// Comma is the column delimiter of the input and output; ',' if zero
+	Comma rune `json:"-"`
+	// UseCRLF ends output lines with \r\n instead of \n
+	UseCRLF bool `json:"-"`
+}
+
+// Field is an output column and where its values come from: exactly one of
+// Column, Value, and Concat.
+type Field struct {
+	// Field is the name of the output column, usually a Salesforce field
+	Field string `json:"field"`
+
+	// Column copies the value of an input column
+	Column string `json:"column,omitempty"`
+	// Value sets the same value on every row
+	Value *string `json:"value,omitempty"`
+	// Concat joins the values of several input columns with Separator,
+	// skipping empty ones
+	Concat    []string `json:"concat,omitempty"`
+	Separator string   `json:"separator,omitempty"`
+
+	// DateFormat parses the value as a date in this format, e.g.
+	// "MM/DD/YYYY", and writes it as a Salesforce date
+	DateFormat string `json:"dateFormat,omitempty"`
+	// DateTimeFormat parses the value as a date and time in this format,
+	// e.g. "DD.MM.YYYY HH:mm", and writes it as a Salesforce datetime.
+	// Times without a zone are taken as UTC.
+	DateTimeFormat string `json:"dateTimeFormat,omitempty"`
+}
+
+// Load reads a mapping file.
+func Load(path string) (*Mapping, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read mapping file: %w", err)
+	}
+	m, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid mapping file %s: %w", path, err)
+	}
+	return m, nil
+}
+
+// Parse decodes and checks a mapping.
+func Parse(data []byte) (*Mapping, error) {
+	var m Mapping
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// Validate checks that every field has a name, which is not repeated, and
+// exactly one source.
+func (m *Mapping) Validate() error {
+	if len(m.Fields) == 0 {
+		return errors.New("no fields")
+	}
+
+	seen := make(map[string]bool, len(m.Fields))
+	for i, f := range m.Fields {
+		if f.Field == "" {
+			return fmt.Errorf("field %d has no name", i+1)
+		}
+		if seen[strings.ToLower(f.Field)] {
+			return fmt.Errorf("field %s is mapped more than once", f.Field)
+		}
+		seen[strings.ToLower(f.Field)] = true
+
+		sources := 0
+		if f.Column != "" {
+			sources++
+		}
+		if f.Value != nil {
+			sources++
+		}
+		if len(f.Concat) > 0 {
+			sources++
+		}
+		if sources != 1 {
+			return fmt.Errorf("field %s needs exactly one of column, value, or concat", f.Field)
+		}
+		if f.DateFormat != "" && f.DateTimeFormat != "" {
+			return fmt.Errorf("field %s has both dateFormat and dateTimeFormat", f.Field)
+		}
+	}
+	return nil
+}
+
+// Header returns the output column names.
+func (m *Mapping) Header() []string {
+	header := make([]string, len(m.Fields))
+	for i, f := range m.Fields {
+		header[i] = f.Field
+	}
+	return header
+}
+
+// Transform reads CSV data with a header row from r and writes the mapped
+// columns to w, one row at a time. Errors name the line and field of the
+// value that could not be mapped.
+func (m *Mapping) Transform(w io.Writer, r io.Reader) error {
+	cr := csv.NewReader(r)
+	cr.ReuseRecord = true
+	if m.Comma != 0 {
+		cr.Comma = m.Comma
+	}
+
+	header, err := cr.Read()
+	if errors.Is(err, io.EOF) {
+		return errors.New("CSV file is empty")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read CSV header: %w", err)
+	}
+
+	fields, err := m.compile(header)
+	if err != nil {
+		return err
+	}
+
+	cw := csv.NewWriter(w)
+	cw.Comma = cr.Comma
+	cw.UseCRLF = m.UseCRLF
+	if err := cw.Write(m.Header()); err != nil {
+		return err
+	}
+
+	out := make([]string, len(fields))
+	for {
+		row, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read CSV: %w", err)
+		}
+
+		line, _ := cr.FieldPos(0)
+		for i, f := range fields {
+			if out[i], err = f(row); err != nil {
+				return fmt.Errorf("line %d: field %s: %w", line, m.Fields[i].Field, err)
+			}
+		}
+		if err := cw.Write(out); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// valueFunc returns the value of an output column for an input row.
+type valueFunc func(row []string) (string, error)
+
+// compile returns a valueFunc for each field, with its input columns looked
+// up in header.
+func (m *Mapping) compile(header []string) ([]valueFunc, error) {
+	index := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.TrimSpace(strings.TrimPrefix(name, "\ufeff"))
+		index[name] = i
+		if _, ok := index[strings.ToLower(name)]; !ok {
+			index[strings.ToLower(name)] = i
+		}
+	}
+	lookup := func(f Field, column string) (int, error) {
+		if i, ok := index[column]; ok {
+			return i, nil
+		}
+		if i, ok := index[strings.ToLower(column)]; ok {
+			return i, nil
+		}
+		return 0, fmt.Errorf("field %s: column %q is not in the CSV header", f.Field, column)
+	}
+
+	fields := make([]valueFunc, len(m.Fields))
+	for i, f := range m.Fields {
+		var raw func(row []string) string
+
+		switch {
+		case f.Value != nil:
+			value := *f.Value
+			raw = func([]string) string { return value }
+		case f.Column != "":
+			col, err := lookup(f, f.Column)
+			if err != nil {
+				return nil, err
+			}
+			raw = func(row []string) string { return row[col] }
+		default:
+			cols := make([]int, len(f.Concat))
+			for j, column := range f.Concat {
+				col, err := lookup(f, column)
+				if err != nil {
+					return nil, err
+				}
+				cols[j] = col
+			}
+			separator := f.Separator
+			raw = func(row []string) string {
+				parts := make([]string, 0, len(cols))
+				for _, col := range cols {
+					if v := strings.TrimSpace(row[col]); v != "" {
+						parts = append(parts, v)
+					}
+				}
+				return strings.Join(parts, separator)
+			}
+		}
+
+		fields[i] = convert(raw, f)
+	}
+	return fields, nil
+}
+
+// convert wraps raw with the date conversion of f, if it has one.
+func convert(raw func(row []string) string, f Field) valueFunc {
+	var layout, format, out string
+	switch {
+	case f.DateFormat != "":
+		format, out = f.DateFormat, SalesforceDate
+	case f.DateTimeFormat != "":
+		format, out = f.DateTimeFormat, SalesforceDateTime
+	default:
+		return func(row []string) (string, error) { return raw(row), nil }
+	}
+	layout = goLayout(format)
+
+	return func(row []string) (string, error) {
+		value := strings.TrimSpace(raw(row))
+		if value == "" {
+			return "", nil
+		}
+		t, err := time.Parse(layout, value)
+		if err != nil {
+			return "", fmt.Errorf("invalid date %q (expected %s)", value, format)
+		}
+		if out == SalesforceDateTime {
+			t = t.UTC()
+		}
+		return t.Format(out), nil
+	}
+}
+
+// dateTokens are the parts of a date format and their Go layout, longest
+// first so that e.g. YYYY is matched before YY.
+var dateTokens = []struct{ token, layout string }{
+	{"YYYY", "2006"},
+	{"YY", "06"},
+	{"MMMM", "January"},
+	{"MMM", "Jan"},
+	{"MM", "01"},
+	{"M", "1"},
+	{"DD", "02"},
+	{"D", "2"},
+	{"HH", "15"},
+	{"hh", "03"},
+	{"h", "3"},
+	{"mm", "04"},
+	{"ss", "05"},
+	{"SSS", "000"},
+	{"A", "PM"},
+	{"Z", "Z07:00"},
+}
+
+// goLayout converts a date format such as "DD/MM/YYYY HH:mm" to a Go time
+// layout. Characters that are not tokens are copied as they are.
+func goLayout(format string) string {
+	var b strings.Builder
+	for i := 0; i < len(format); {
+		matched := false
+		for _, t := range dateTokens {
+			if strings.HasPrefix(format[i:], t.token) {
+				b.WriteString(t.layout)
+				i += len(t.token)
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			b.WriteByte(format[i])
+			i++
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,110 @@
+package csvmap
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransform(t *testing.T) {
+	m, err := Parse([]byte(`{
+		"fields": [
+			{"field": "Name", "column": "company_name"},
+			{"field": "Type", "value": "Customer"},
+			{"field": "CloseDate", "column": "Closed_On", "dateFormat": "MM/DD/YYYY"},
+			{"field": "LastSeen__c", "column": "seen", "dateTimeFormat": "DD.MM.YYYY HH:mm"},
+			{"field": "Description", "concat": ["notes", "region"], "separator": " - "}
+		]
+	}`))
+	require.NoError(t, err)
+
+	in := "\ufeffcompany_name,closed_on,seen,notes,region,ignored\n" +
+		"Acme,01/15/2024,15.01.2024 10:30,Big deal,EMEA,x\n" +
+		"\"Globex, Inc.\",,,,APAC,y\n"
+
+	var out bytes.Buffer
+	require.NoError(t, m.Transform(&out, strings.NewReader(in)))
+
+	assert.Equal(t, "Name,Type,CloseDate,LastSeen__c,Description\n"+
+		"Acme,Customer,2024-01-15,2024-01-15T10:30:00.000Z,Big deal - EMEA\n"+
+		"\"Globex, Inc.\",Customer,,,APAC\n", out.String())
+}
+
+func TestTransform_Delimiter(t *testing.T) {
+	m, err := Parse([]byte(`{"fields": [{"field": "Name", "column": "firma"}, {"field": "Description", "column": "notiz"}]}`))
+	require.NoError(t, err)
+	m.Comma = ';'
+	m.UseCRLF = true
+
+	var out bytes.Buffer
+	require.NoError(t, m.Transform(&out, strings.NewReader("firma;notiz\r\nAcme;\"a;b\"\r\n")))
+	assert.Equal(t, "Name;Description\r\nAcme;\"a;b\"\r\n", out.String())
+}
+
+func TestTransform_Errors(t *testing.T) {
+	m, err := Parse([]byte(`{"fields": [{"field": "CloseDate", "column": "closed_on", "dateFormat": "YYYY-MM-DD"}]}`))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"missing column", "close\n2024-01-15\n", `field CloseDate: column "closed_on" is not in the CSV header`},
+		{"invalid date", "closed_on\n2024-01-15\n15/01/2024\n", `line 3: field CloseDate: invalid date "15/01/2024" (expected YYYY-MM-DD)`},
+		{"empty file", "", "CSV file is empty"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := m.Transform(&bytes.Buffer{}, strings.NewReader(tt.input))
+			assert.EqualError(t, err, tt.want)
+		})
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	tests := []struct {
+		mapping string
+		want    string
+	}{
+		{`{"fields": []}`, "no fields"},
+		{`{"fields": [{"column": "a"}]}`, "field 1 has no name"},
+		{`{"fields": [{"field": "Name"}]}`, "field Name needs exactly one of column, value, or concat"},
+		{`{"fields": [{"field": "Name", "column": "a", "value": "b"}]}`, "field Name needs exactly one of column, value, or concat"},
+		{`{"fields": [{"field": "Name", "column": "a"}, {"field": "name", "column": "b"}]}`, "field name is mapped more than once"},
+		{`{"fields": [{"field": "D", "column": "a", "dateFormat": "YYYY", "dateTimeFormat": "YYYY"}]}`, "field D has both dateFormat and dateTimeFormat"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			_, err := Parse([]byte(tt.mapping))
+			assert.EqualError(t, err, tt.want)
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mapping.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"fields": [{"field": "Name", "value": ""}]}`), 0644))
+
+	m, err := Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Name"}, m.Header())
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"fields": [{"field": "Name"}]}`), 0644))
+	_, err = Load(path)
+	assert.EqualError(t, err, "invalid mapping file "+path+": field Name needs exactly one of column, value, or concat")
+}
+
+func TestGoLayout(t *testing.T) {
+	assert.Equal(t, "01/02/2006", goLayout("MM/DD/YYYY"))
+	assert.Equal(t, "2.1.06 15:04:05", goLayout("D.M.YY HH:mm:ss"))
+	assert.Equal(t, "Jan 2, 2006 3:04 PM", goLayout("MMM D, YYYY h:mm A"))
+	assert.Equal(t, "2006-01-02T15:04:05.000Z07:00", goLayout("YYYY-MM-DDTHH:mm:ss.SSSZ"))
+}
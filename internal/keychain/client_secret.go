@@ -0,0 +1,180 @@
+package keychain
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/open-cli-collective/salesforce-cli/internal/config"
+)
+
+const clientSecretLabel = "salesforce-cli Client Secret"
+
+var (
+	// ErrClientSecretNotFound indicates no client secret exists in storage
+	ErrClientSecretNotFound = errors.New("no client secret found in secure storage")
+
+	// errNoSecureStorage is returned by the platform storage functions when
+	// the platform has no secure storage available
+	errNoSecureStorage = errors.New("secure storage is not available")
+)
+
+// clientSecretAccount returns the account name used for the client secret in
+// the system keychain.
+func clientSecretAccount() string {
+	return storageAccount(clientSecretKey)
+}
+
+// GetClientSecret retrieves the Connected App client secret of the active
+// org profile from secure storage, where it is kept next to the profile's
+// token rather than in the config file.
+func GetClientSecret() (string, error) {
+	if useEncryptedFile() {
+		return getClientSecretFromEncryptedFile()
+	}
+	return getClientSecret()
+}
+
+// SetClientSecret stores the client secret of the active org profile in
+// secure storage.
+func SetClientSecret(secret string) error {
+	if secret == "" {
+		return DeleteClientSecret()
+	}
+	if useEncryptedFile() {
+		path, err := clientSecretEncryptedPath()
+		if err != nil {
+			return err
+		}
+		return writeEncryptedFile(path, []byte(secret))
+	}
+	return setClientSecret(secret)
+}
+
+// DeleteClientSecret removes the client secret of the active org profile
+// from every storage backend. It is not an error if there is none.
+func DeleteClientSecret() error {
+	if err := deleteClientSecretFile(); err != nil {
+		return err
+	}
+	if path, err := clientSecretEncryptedPath(); err == nil {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to delete client secret file: %w", err)
+		}
+	}
+	// The secret may not have been in the system keychain
+	_ = deleteSecret(clientSecretAccount())
+	return nil
+}
+
+// getClientSecret reads the client secret from the system keychain, falling
+// back to the client secret file.
+func getClientSecret() (string, error) {
+	if data, err := getSecret(clientSecretAccount()); err == nil {
+		return string(data), nil
+	}
+	return getClientSecretFromFile()
+}
+
+// setClientSecret stores the client secret in the system keychain, falling
+// back to the client secret file.
+func setClientSecret(secret string) error {
+	err := setSecret(clientSecretAccount(), clientSecretLabel, []byte(secret))
+	if err == nil {
+		// Don't leave an older secret behind in the fallback file
+		return deleteClientSecretFile()
+	}
+	if !errors.Is(err, errNoSecureStorage) {
+		fmt.Fprintf(os.Stderr, "Warning: secure storage of the client secret failed, using a file: %v\n", err)
+	}
+	return setClientSecretFile(secret)
+}
+
+func getClientSecretFromEncryptedFile() (string, error) {
+	path, err := clientSecretEncryptedPath()
+	if err != nil {
+		return "", err
+	}
+
+	plain, err := readEncryptedFile(path)
+	if os.IsNotExist(err) {
+		return migrateClientSecretToEncryptedFile(path)
+	}
+	if err != nil {
+		return "", err
+	}
+	return string(plain), nil
+}
+
+// migrateClientSecretToEncryptedFile moves a client secret from the system
+// keychain or plaintext file into the encrypted file at path, after
+// encrypted storage is switched on.
+func migrateClientSecretToEncryptedFile(path string) (string, error) {
+	secret, err := getClientSecret()
+	if err != nil {
+		return "", ErrClientSecretNotFound
+	}
+
+	if err := writeEncryptedFile(path, []byte(secret)); err != nil {
+		return "", fmt.Errorf("failed to migrate client secret to encrypted file: %w", err)
+	}
+	_ = deleteClientSecretFile()
+	_ = deleteSecret(clientSecretAccount())
+
+	return secret, nil
+}
+
+func clientSecretEncryptedPath() (string, error) {
+	path, err := config.GetClientSecretPath()
+	if err != nil {
+		return "", err
+	}
+	return path + encryptedFileSuffix, nil
+}
+
+// File-based storage implementation (fallback)
+
+func getClientSecretFromFile() (string, error) {
+	path, err := config.GetClientSecretPath()
+	if err != nil {
+		return "", err
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return "", ErrClientSecretNotFound
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read client secret file: %w", err)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+func setClientSecretFile(secret string) error {
+	path, err := config.GetClientSecretPath()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), config.DirPerm); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(secret), config.FilePerm); err != nil {
+		return fmt.Errorf("failed to write client secret file: %w", err)
+	}
+	return nil
+}
+
+func deleteClientSecretFile() error {
+	path, err := config.GetClientSecretPath()
+	if err != nil {
+		return err
+	}
+
+	if err := secureDelete(path); err != nil {
+		return fmt.Errorf("failed to delete client secret file: %w", err)
+	}
+	return nil
+}
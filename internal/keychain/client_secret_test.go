@@ -0,0 +1,88 @@
+package keychain
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/salesforce-cli/internal/config"
+)
+
+func TestClientSecret_PerOrg(t *testing.T) {
+	t.Setenv("SFDC_HOME", t.TempDir())
+	t.Setenv("SFDC_TOKEN_STORAGE", "")
+
+	t.Setenv("SFDC_ORG", "prod")
+	_, err := GetClientSecret()
+	assert.ErrorIs(t, err, ErrClientSecretNotFound)
+	require.NoError(t, SetClientSecret("prod-secret"))
+
+	t.Setenv("SFDC_ORG", "dev1")
+	_, err = GetClientSecret()
+	assert.ErrorIs(t, err, ErrClientSecretNotFound)
+	require.NoError(t, SetClientSecret("dev1-secret"))
+
+	t.Setenv("SFDC_ORG", "prod")
+	secret, err := GetClientSecret()
+	require.NoError(t, err)
+	assert.Equal(t, "prod-secret", secret)
+
+	require.NoError(t, DeleteClientSecret())
+	_, err = GetClientSecret()
+	assert.ErrorIs(t, err, ErrClientSecretNotFound)
+
+	t.Setenv("SFDC_ORG", "dev1")
+	secret, err = GetClientSecret()
+	require.NoError(t, err)
+	assert.Equal(t, "dev1-secret", secret)
+	require.NoError(t, DeleteClientSecret())
+}
+
+func TestClientSecret_EncryptedFile(t *testing.T) {
+	setupEncryptedStorage(t)
+
+	require.NoError(t, SetClientSecret("client-secret"))
+
+	path, err := clientSecretEncryptedPath()
+	require.NoError(t, err)
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "client-secret")
+
+	secret, err := GetClientSecret()
+	require.NoError(t, err)
+	assert.Equal(t, "client-secret", secret)
+
+	t.Setenv(TokenKeyEnvVar, "wrong")
+	_, err = GetClientSecret()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "wrong passphrase")
+
+	require.NoError(t, DeleteClientSecret())
+	_, err = os.Stat(path)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestClientSecret_MigratesToEncryptedFile(t *testing.T) {
+	setupEncryptedStorage(t)
+
+	t.Setenv("SFDC_TOKEN_STORAGE", "")
+	require.NoError(t, SetClientSecret("client-secret"))
+
+	t.Setenv("SFDC_TOKEN_STORAGE", config.TokenStorageEncryptedFile)
+	secret, err := GetClientSecret()
+	require.NoError(t, err)
+	assert.Equal(t, "client-secret", secret)
+
+	plainPath, err := config.GetClientSecretPath()
+	require.NoError(t, err)
+	_, err = os.Stat(plainPath)
+	assert.True(t, os.IsNotExist(err), "plaintext client secret file should be removed")
+
+	encPath, err := clientSecretEncryptedPath()
+	require.NoError(t, err)
+	_, err = os.Stat(encPath)
+	assert.NoError(t, err)
+}
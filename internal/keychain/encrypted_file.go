@@ -0,0 +1,267 @@
+package keychain
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/charmbracelet/x/term"
+	"github.com/mattn/go-isatty"
+	"golang.org/x/oauth2"
+
+	"github.com/open-cli-collective/salesforce-cli/internal/config"
+)
+
+const (
+	// TokenKeyEnvVar holds the passphrase for encrypted token storage
+	TokenKeyEnvVar = "SFDC_TOKEN_KEY"
+
+	// encryptedFileSuffix is appended to the token file path
+	encryptedFileSuffix = ".enc"
+
+	// encryptedFileVersion is the format version of encrypted token files
+	encryptedFileVersion = 1
+
+	saltSize = 16
+)
+
+// tokenKeyIterations is the PBKDF2 iteration count for new files, following
+// the OWASP recommendation for PBKDF2-HMAC-SHA256. It is a variable so tests
+// can lower it.
+var tokenKeyIterations = 600_000
+
+// ErrPassphraseRequired is returned when encrypted token storage is used
+// without a passphrase.
+var ErrPassphraseRequired = errors.New("encrypted token storage requires a passphrase: set " + TokenKeyEnvVar)
+
+// encryptedFile is the on-disk format of an encrypted token.
+type encryptedFile struct {
+	Version    int    `json:"version"`
+	KDF        string `json:"kdf"`
+	Iterations int    `json:"iterations"`
+	Salt       []byte `json:"salt"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+var (
+	passphraseMu sync.Mutex
+	// passphrase is cached after the first prompt so it is asked for at
+	// most once per command
+	passphrase string
+)
+
+// useEncryptedFile reports whether the config selects encrypted token storage.
+func useEncryptedFile() bool {
+	cfg, err := config.Load()
+	return err == nil && cfg.TokenStorage == config.TokenStorageEncryptedFile
+}
+
+// encryptedFilePath returns the full path to the encrypted token file
+func encryptedFilePath() (string, error) {
+	path, err := tokenFilePath()
+	if err != nil {
+		return "", err
+	}
+	return path + encryptedFileSuffix, nil
+}
+
+// tokenPassphrase returns the passphrase from SFDC_TOKEN_KEY, prompting for
+// it on the terminal if unset.
+func tokenPassphrase() (string, error) {
+	if v := os.Getenv(TokenKeyEnvVar); v != "" {
+		return v, nil
+	}
+
+	passphraseMu.Lock()
+	defer passphraseMu.Unlock()
+
+	if passphrase != "" {
+		return passphrase, nil
+	}
+	if !isatty.IsTerminal(os.Stdin.Fd()) {
+		return "", ErrPassphraseRequired
+	}
+
+	fmt.Fprint(os.Stderr, "Token passphrase: ")
+	input, err := term.ReadPassword(os.Stdin.Fd())
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", fmt.Errorf("failed to read passphrase: %w", err)
+	}
+	if len(input) == 0 {
+		return "", ErrPassphraseRequired
+	}
+
+	passphrase = string(input)
+	return passphrase, nil
+}
+
+// tokenCipher derives the AES-256-GCM cipher for a salt from the passphrase.
+func tokenCipher(salt []byte, iterations int) (cipher.AEAD, error) {
+	pass, err := tokenPassphrase()
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := pbkdf2.Key(sha256.New, pass, salt, iterations, 32)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive key: %w", err)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func getFromEncryptedFile() (*oauth2.Token, error) {
+	path, err := encryptedFilePath()
+	if err != nil {
+		return nil, err
+	}
+
+	plain, err := readEncryptedFile(path)
+	if os.IsNotExist(err) {
+		return migrateToEncryptedFile()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var token oauth2.Token
+	if err := json.Unmarshal(plain, &token); err != nil {
+		return nil, fmt.Errorf("failed to parse token file: %w", err)
+	}
+
+	return &token, nil
+}
+
+func setInEncryptedFile(token *oauth2.Token) error {
+	path, err := encryptedFilePath()
+	if err != nil {
+		return err
+	}
+
+	plain, err := json.Marshal(token)
+	if err != nil {
+		return fmt.Errorf("failed to serialize token: %w", err)
+	}
+
+	return writeEncryptedFile(path, plain)
+}
+
+// readEncryptedFile decrypts the file at path. It returns an error
+// satisfying os.IsNotExist if there is no file.
+func readEncryptedFile(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", filepath.Base(path), err)
+	}
+
+	var file encryptedFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", filepath.Base(path), err)
+	}
+	if file.Version != encryptedFileVersion {
+		return nil, fmt.Errorf("unsupported %s version %d", filepath.Base(path), file.Version)
+	}
+
+	gcm, err := tokenCipher(file.Salt, file.Iterations)
+	if err != nil {
+		return nil, err
+	}
+	plain, err := gcm.Open(nil, file.Nonce, file.Ciphertext, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt %s (wrong passphrase?): %w", filepath.Base(path), err)
+	}
+	return plain, nil
+}
+
+// writeEncryptedFile encrypts plain with a new salt and nonce and writes it
+// to path.
+func writeEncryptedFile(path string, plain []byte) error {
+	file := encryptedFile{
+		Version:    encryptedFileVersion,
+		KDF:        "pbkdf2-sha256",
+		Iterations: tokenKeyIterations,
+		Salt:       make([]byte, saltSize),
+	}
+	if _, err := rand.Read(file.Salt); err != nil {
+		return err
+	}
+
+	gcm, err := tokenCipher(file.Salt, file.Iterations)
+	if err != nil {
+		return err
+	}
+	file.Nonce = make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(file.Nonce); err != nil {
+		return err
+	}
+	file.Ciphertext = gcm.Seal(nil, file.Nonce, plain, nil)
+
+	data, err := json.MarshalIndent(&file, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to serialize %s: %w", filepath.Base(path), err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), config.DirPerm); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	if err := os.WriteFile(path, data, config.FilePerm); err != nil {
+		return fmt.Errorf("failed to write %s: %w", filepath.Base(path), err)
+	}
+
+	return nil
+}
+
+func deleteFromEncryptedFile() error {
+	path, err := encryptedFilePath()
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to delete token file: %w", err)
+	}
+
+	return nil
+}
+
+// migrateToEncryptedFile moves a token from the system keychain or plaintext
+// file into the encrypted file, after encrypted storage is switched on. The
+// plaintext file is securely deleted.
+func migrateToEncryptedFile() (*oauth2.Token, error) {
+	token, err := getToken()
+	if err != nil {
+		return nil, ErrTokenNotFound
+	}
+
+	if err := setInEncryptedFile(token); err != nil {
+		return nil, fmt.Errorf("failed to migrate token to encrypted file: %w", err)
+	}
+
+	if path, err := tokenFilePath(); err == nil {
+		if _, statErr := os.Stat(path); statErr == nil {
+			if err := secureDelete(path); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: could not securely delete old token file: %v\n", err)
+			}
+		}
+	}
+	_ = deleteToken()
+
+	fmt.Fprintf(os.Stderr, "Migrated token to encrypted file storage.\n")
+	return token, nil
+}
@@ -0,0 +1,96 @@
+package keychain
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+
+	"github.com/open-cli-collective/salesforce-cli/internal/config"
+)
+
+func setupEncryptedStorage(t *testing.T) {
+	t.Helper()
+	t.Setenv("SFDC_HOME", t.TempDir())
+	t.Setenv("SFDC_ORG", "")
+	t.Setenv("SFDC_TOKEN_STORAGE", config.TokenStorageEncryptedFile)
+	t.Setenv(TokenKeyEnvVar, "correct horse battery staple")
+
+	iterations := tokenKeyIterations
+	tokenKeyIterations = 1000
+	t.Cleanup(func() { tokenKeyIterations = iterations })
+}
+
+func TestEncryptedFile(t *testing.T) {
+	setupEncryptedStorage(t)
+
+	token := &oauth2.Token{
+		AccessToken:  "test-access-token",
+		RefreshToken: "test-refresh-token",
+		TokenType:    "Bearer",
+		Expiry:       time.Now().Add(time.Hour),
+	}
+
+	_, err := GetToken()
+	assert.ErrorIs(t, err, ErrTokenNotFound)
+
+	require.NoError(t, SetToken(token))
+	assert.Equal(t, BackendEncrypted, GetStorageBackend())
+	assert.True(t, IsSecureStorage())
+
+	path, err := encryptedFilePath()
+	require.NoError(t, err)
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "test-refresh-token")
+
+	retrieved, err := GetToken()
+	require.NoError(t, err)
+	assert.Equal(t, token.AccessToken, retrieved.AccessToken)
+	assert.Equal(t, token.RefreshToken, retrieved.RefreshToken)
+
+	t.Setenv(TokenKeyEnvVar, "wrong")
+	_, err = GetToken()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "wrong passphrase")
+
+	require.NoError(t, DeleteToken())
+	_, err = os.Stat(path)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestEncryptedFile_NoPassphrase(t *testing.T) {
+	setupEncryptedStorage(t)
+	t.Setenv(TokenKeyEnvVar, "")
+
+	// Test stdin is not a terminal, so there is no prompt
+	err := SetToken(&oauth2.Token{AccessToken: "token"})
+	assert.ErrorIs(t, err, ErrPassphraseRequired)
+}
+
+func TestEncryptedFile_MigratesPlaintext(t *testing.T) {
+	setupEncryptedStorage(t)
+
+	require.NoError(t, setInConfigFile(&oauth2.Token{AccessToken: "plain-token"}))
+	plainPath, err := tokenFilePath()
+	require.NoError(t, err)
+
+	token, err := GetToken()
+	require.NoError(t, err)
+	assert.Equal(t, "plain-token", token.AccessToken)
+
+	_, err = os.Stat(plainPath)
+	assert.True(t, os.IsNotExist(err), "plaintext token file should be removed")
+
+	encPath, err := encryptedFilePath()
+	require.NoError(t, err)
+	_, err = os.Stat(encPath)
+	assert.NoError(t, err)
+
+	token, err = GetToken()
+	require.NoError(t, err)
+	assert.Equal(t, "plain-token", token.AccessToken)
+}
@@ -1,5 +1,6 @@
-// Package keychain provides secure storage for OAuth tokens using platform-native
-// secure storage mechanisms (macOS Keychain, Linux secret-tool) with file fallback.
+// Package keychain provides secure storage for OAuth tokens and client
+// secrets using platform-native secure storage mechanisms (macOS Keychain,
+// Linux secret-tool, Windows Credential Manager) with file fallback.
 package keychain
 
 import (
@@ -15,17 +16,20 @@ import (
 )
 
 const (
-	serviceName = config.DirName
-	tokenKey    = "oauth_token"
+	serviceName     = config.DirName
+	tokenKey        = "oauth_token"
+	clientSecretKey = "client_secret"
 )
 
 // StorageBackend represents where tokens are stored
 type StorageBackend string
 
 const (
-	BackendKeychain   StorageBackend = "Keychain"    // macOS Keychain
-	BackendSecretTool StorageBackend = "secret-tool" // Linux libsecret
-	BackendFile       StorageBackend = "config file" // File fallback
+	BackendKeychain   StorageBackend = "Keychain"           // macOS Keychain
+	BackendSecretTool StorageBackend = "secret-tool"        // Linux libsecret
+	BackendWinCred    StorageBackend = "Credential Manager" // Windows Credential Manager
+	BackendEncrypted  StorageBackend = "encrypted file"     // Passphrase-encrypted file
+	BackendFile       StorageBackend = "config file"        // File fallback
 )
 
 var (
@@ -33,6 +37,30 @@ var (
 	ErrTokenNotFound = errors.New("no token found in secure storage")
 )
 
+// tokenAccount returns the account name used for the token in the system
+// keychain.
+func tokenAccount() string {
+	return storageAccount(tokenKey)
+}
+
+// storageAccount returns the account name an item named key is stored under
+// in the system keychain. The active org profile's alias is included so each
+// org keeps its own items, and when the config directory is overridden the
+// directory is included so each configuration does too.
+func storageAccount(key string) string {
+	account := key
+	if alias := config.ActiveOrg(); alias != "" {
+		account += "@" + alias
+	}
+	if dir := config.ConfigDirOverride(); dir != "" {
+		if abs, err := filepath.Abs(dir); err == nil {
+			dir = abs
+		}
+		account += ":" + dir
+	}
+	return account
+}
+
 // tokenFilePath returns the full path to the token file
 func tokenFilePath() (string, error) {
 	return config.GetTokenPath()
@@ -40,16 +68,25 @@ func tokenFilePath() (string, error) {
 
 // GetToken retrieves the OAuth token from secure storage
 func GetToken() (*oauth2.Token, error) {
+	if useEncryptedFile() {
+		return getFromEncryptedFile()
+	}
 	return getToken()
 }
 
 // SetToken stores the OAuth token in secure storage
 func SetToken(token *oauth2.Token) error {
+	if useEncryptedFile() {
+		return setInEncryptedFile(token)
+	}
 	return setToken(token)
 }
 
 // DeleteToken removes the OAuth token from secure storage
 func DeleteToken() error {
+	if useEncryptedFile() {
+		return deleteFromEncryptedFile()
+	}
 	return deleteToken()
 }
 
@@ -61,13 +98,17 @@ func HasStoredToken() bool {
 
 // GetStorageBackend returns the current storage backend being used
 func GetStorageBackend() StorageBackend {
+	if useEncryptedFile() {
+		return BackendEncrypted
+	}
 	return getStorageBackend()
 }
 
-// IsSecureStorage returns true if using secure storage (keychain/secret-tool)
+// IsSecureStorage returns true if using secure storage (keychain/secret-tool
+// or an encrypted file)
 func IsSecureStorage() bool {
 	backend := GetStorageBackend()
-	return backend == BackendKeychain || backend == BackendSecretTool
+	return backend == BackendKeychain || backend == BackendSecretTool || backend == BackendWinCred || backend == BackendEncrypted
 }
 
 // MigrateFromFile migrates token.json to secure storage if it exists
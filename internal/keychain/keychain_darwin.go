@@ -65,21 +65,31 @@ func getStorageBackend() StorageBackend {
 	return BackendKeychain
 }
 
+// getSecret reads an item from macOS Keychain
+func getSecret(account string) ([]byte, error) {
+	return findInKeychain(account)
+}
+
+// setSecret stores an item in macOS Keychain
+func setSecret(account, _ string, data []byte) error {
+	return addToKeychain(account, data)
+}
+
+// deleteSecret removes an item from macOS Keychain
+func deleteSecret(account string) error {
+	return removeFromKeychain(account)
+}
+
 // macOS Keychain implementation using security CLI
 
 func getFromKeychain() (*oauth2.Token, error) {
-	cmd := exec.Command("security", "find-generic-password",
-		"-s", serviceName,
-		"-a", tokenKey,
-		"-w")
-
-	output, err := cmd.Output()
+	output, err := findInKeychain(tokenAccount())
 	if err != nil {
-		return nil, fmt.Errorf("failed to read from keychain: %w", err)
+		return nil, err
 	}
 
 	var token oauth2.Token
-	if err := json.Unmarshal([]byte(strings.TrimSpace(string(output))), &token); err != nil {
+	if err := json.Unmarshal(output, &token); err != nil {
 		return nil, fmt.Errorf("failed to parse token from keychain: %w", err)
 	}
 
@@ -92,17 +102,40 @@ func setInKeychain(token *oauth2.Token) error {
 		return fmt.Errorf("failed to serialize token: %w", err)
 	}
 
+	return addToKeychain(tokenAccount(), data)
+}
+
+func deleteFromKeychain() error {
+	return removeFromKeychain(tokenAccount())
+}
+
+func findInKeychain(account string) ([]byte, error) {
+	cmd := exec.Command("security", "find-generic-password",
+		"-s", serviceName,
+		"-a", account,
+		"-w")
+
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read from keychain: %w", err)
+	}
+
+	return []byte(strings.TrimSpace(string(output))), nil
+}
+
+func addToKeychain(account string, data []byte) error {
 	// Delete existing entry (ignore error if not exists)
-	_ = deleteFromKeychain()
+	_ = removeFromKeychain(account)
 
-	// Add new entry using interactive mode to avoid exposing token in process list.
-	// The -i flag reads commands from stdin, keeping sensitive data out of ps output.
+	// Add new entry using interactive mode to avoid exposing the value in the
+	// process list. The -i flag reads commands from stdin, keeping sensitive
+	// data out of ps output.
 	cmd := exec.Command("security", "-i")
 
 	// Build the command to send via stdin
 	// Note: The password value is quoted to handle special characters in JSON
 	stdinCmd := fmt.Sprintf("add-generic-password -s %q -a %q -w %q -U\n",
-		serviceName, tokenKey, string(data))
+		serviceName, account, string(data))
 	cmd.Stdin = strings.NewReader(stdinCmd)
 
 	if err := cmd.Run(); err != nil {
@@ -112,10 +145,10 @@ func setInKeychain(token *oauth2.Token) error {
 	return nil
 }
 
-func deleteFromKeychain() error {
+func removeFromKeychain(account string) error {
 	cmd := exec.Command("security", "delete-generic-password",
 		"-s", serviceName,
-		"-a", tokenKey)
+		"-a", account)
 
 	if err := cmd.Run(); err != nil {
 		return fmt.Errorf("failed to delete from keychain: %w", err)
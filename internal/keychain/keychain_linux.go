@@ -83,20 +83,40 @@ func getStorageBackend() StorageBackend {
 	return BackendFile
 }
 
+// getSecret reads an item from Linux secret storage
+func getSecret(account string) ([]byte, error) {
+	if !isSecretToolAvailable() {
+		return nil, errNoSecureStorage
+	}
+	return lookupSecretTool(account)
+}
+
+// setSecret stores an item in Linux secret storage
+func setSecret(account, label string, data []byte) error {
+	if !isSecretToolAvailable() {
+		return errNoSecureStorage
+	}
+	return storeSecretTool(account, label, data)
+}
+
+// deleteSecret removes an item from Linux secret storage
+func deleteSecret(account string) error {
+	if !isSecretToolAvailable() {
+		return errNoSecureStorage
+	}
+	return clearSecretTool(account)
+}
+
 // Linux secret-tool implementation
 
 func getFromSecretTool() (*oauth2.Token, error) {
-	cmd := exec.Command("secret-tool", "lookup",
-		"service", serviceName,
-		"account", tokenKey)
-
-	output, err := cmd.Output()
+	output, err := lookupSecretTool(tokenAccount())
 	if err != nil {
-		return nil, fmt.Errorf("failed to read from secret-tool: %w", err)
+		return nil, err
 	}
 
 	var token oauth2.Token
-	if err := json.Unmarshal([]byte(strings.TrimSpace(string(output))), &token); err != nil {
+	if err := json.Unmarshal(output, &token); err != nil {
 		return nil, fmt.Errorf("failed to parse token from secret-tool: %w", err)
 	}
 
@@ -109,13 +129,34 @@ func setInSecretTool(token *oauth2.Token) error {
 		return fmt.Errorf("failed to serialize token: %w", err)
 	}
 
+	return storeSecretTool(tokenAccount(), "salesforce-cli OAuth Token", data)
+}
+
+func deleteFromSecretTool() error {
+	return clearSecretTool(tokenAccount())
+}
+
+func lookupSecretTool(account string) ([]byte, error) {
+	cmd := exec.Command("secret-tool", "lookup",
+		"service", serviceName,
+		"account", account)
+
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read from secret-tool: %w", err)
+	}
+
+	return []byte(strings.TrimSpace(string(output))), nil
+}
+
+func storeSecretTool(account, label string, data []byte) error {
 	// Delete existing entry (ignore error if not exists)
-	_ = deleteFromSecretTool()
+	_ = clearSecretTool(account)
 
 	cmd := exec.Command("secret-tool", "store",
-		"--label", "salesforce-cli OAuth Token",
+		"--label", label,
 		"service", serviceName,
-		"account", tokenKey)
+		"account", account)
 	cmd.Stdin = strings.NewReader(string(data))
 
 	if err := cmd.Run(); err != nil {
@@ -125,10 +166,10 @@ func setInSecretTool(token *oauth2.Token) error {
 	return nil
 }
 
-func deleteFromSecretTool() error {
+func clearSecretTool(account string) error {
 	cmd := exec.Command("secret-tool", "clear",
 		"service", serviceName,
-		"account", tokenKey)
+		"account", account)
 
 	if err := cmd.Run(); err != nil {
 		return fmt.Errorf("failed to delete from secret-tool: %w", err)
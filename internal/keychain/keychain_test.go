@@ -110,6 +110,46 @@ func TestIsSecureStorage(t *testing.T) {
 func TestGetStorageBackend(t *testing.T) {
 	// This test just verifies the function returns a valid backend
 	backend := GetStorageBackend()
-	validBackends := []StorageBackend{BackendKeychain, BackendSecretTool, BackendFile}
+	validBackends := []StorageBackend{BackendKeychain, BackendSecretTool, BackendWinCred, BackendFile}
 	assert.Contains(t, validBackends, backend)
 }
+
+func TestTokenAccount(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("SFDC_ORG", "")
+
+	t.Run("default", func(t *testing.T) {
+		t.Setenv("SFDC_HOME", "")
+		assert.Equal(t, tokenKey, tokenAccount())
+	})
+
+	t.Run("scoped to config dir", func(t *testing.T) {
+		dir := t.TempDir()
+		t.Setenv("SFDC_HOME", dir)
+		assert.Equal(t, tokenKey+":"+dir, tokenAccount())
+	})
+
+	t.Run("scoped to org", func(t *testing.T) {
+		t.Setenv("SFDC_HOME", "")
+		t.Setenv("SFDC_ORG", "dev1")
+		assert.Equal(t, tokenKey+"@dev1", tokenAccount())
+	})
+}
+
+func TestFileStorage_PerOrg(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("SFDC_HOME", "")
+
+	t.Setenv("SFDC_ORG", "prod")
+	require.NoError(t, setInConfigFile(&oauth2.Token{AccessToken: "prod-token"}))
+
+	t.Setenv("SFDC_ORG", "dev1")
+	_, err := getFromConfigFile()
+	assert.ErrorIs(t, err, ErrTokenNotFound)
+	require.NoError(t, setInConfigFile(&oauth2.Token{AccessToken: "dev1-token"}))
+
+	t.Setenv("SFDC_ORG", "prod")
+	token, err := getFromConfigFile()
+	require.NoError(t, err)
+	assert.Equal(t, "prod-token", token.AccessToken)
+}
@@ -3,32 +3,225 @@
 package keychain
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"unsafe"
+
 	"golang.org/x/oauth2"
+	"golang.org/x/sys/windows"
+)
+
+// Credential Manager constants from wincred.h
+const (
+	credTypeGeneric         = 1
+	credPersistLocalMachine = 2
+	// credMaxBlobSize is CRED_MAX_CREDENTIAL_BLOB_SIZE
+	credMaxBlobSize = 5 * 512
+)
+
+var (
+	advapi32       = windows.NewLazySystemDLL("advapi32.dll")
+	procCredReadW  = advapi32.NewProc("CredReadW")
+	procCredWriteW = advapi32.NewProc("CredWriteW")
+	procCredDelete = advapi32.NewProc("CredDeleteW")
+	procCredFree   = advapi32.NewProc("CredFree")
 )
 
-// Windows does not have native secure storage support like macOS Keychain
-// or Linux secret-tool. All token operations fall back to config file storage.
+// credential mirrors the CREDENTIALW structure.
+type credential struct {
+	Flags              uint32
+	Type               uint32
+	TargetName         *uint16
+	Comment            *uint16
+	LastWritten        windows.Filetime
+	CredentialBlobSize uint32
+	CredentialBlob     *byte
+	Persist            uint32
+	AttributeCount     uint32
+	Attributes         uintptr
+	TargetAlias        *uint16
+	UserName           *uint16
+}
 
-// getToken retrieves the OAuth token from Windows storage (config file only)
+// getToken retrieves the OAuth token from Windows Credential Manager
 func getToken() (*oauth2.Token, error) {
+	token, err := getFromCredentialManager()
+	if err == nil {
+		return token, nil
+	}
+
+	// Fall back to config file
 	return getFromConfigFile()
 }
 
-// setToken stores the OAuth token in Windows storage (config file only)
+// setToken stores the OAuth token in Windows Credential Manager
 func setToken(token *oauth2.Token) error {
+	err := setInCredentialManager(token)
+	if err == nil {
+		return nil
+	}
+
+	// Fall back to config file
+	fmt.Printf("Warning: Credential Manager storage failed, using config file: %v\n", err)
 	return setInConfigFile(token)
 }
 
 // deleteToken removes the OAuth token from storage
 func deleteToken() error {
-	return deleteFromConfigFile()
+	// Try to delete from both Credential Manager and file
+	credErr := deleteFromCredentialManager()
+	fileErr := deleteFromConfigFile()
+
+	// Return Credential Manager error if both fail, otherwise nil
+	if credErr != nil && fileErr != nil {
+		return credErr
+	}
+	return nil
 }
 
-// getStorageBackend returns the current storage backend (always file on Windows)
+// getStorageBackend returns the current storage backend
 func getStorageBackend() StorageBackend {
-	_, err := getFromConfigFile()
+	// Check if token exists in Credential Manager
+	_, err := getFromCredentialManager()
+	if err == nil {
+		return BackendWinCred
+	}
+
+	// Check if token exists in file
+	_, err = getFromConfigFile()
 	if err == nil {
 		return BackendFile
 	}
-	return BackendFile
+
+	// Default to Credential Manager (preferred backend)
+	return BackendWinCred
+}
+
+// getSecret reads an item from Windows Credential Manager
+func getSecret(account string) ([]byte, error) {
+	return readCredential(account)
+}
+
+// setSecret stores an item in Windows Credential Manager
+func setSecret(account, label string, data []byte) error {
+	return writeCredential(account, label, data)
+}
+
+// deleteSecret removes an item from Windows Credential Manager
+func deleteSecret(account string) error {
+	return deleteCredential(account)
+}
+
+// Windows Credential Manager implementation using advapi32
+
+// credentialTarget returns the target name of an account's credential.
+func credentialTarget(account string) string {
+	return serviceName + ":" + account
+}
+
+func getFromCredentialManager() (*oauth2.Token, error) {
+	data, err := readCredential(tokenAccount())
+	if err != nil {
+		return nil, err
+	}
+
+	var token oauth2.Token
+	if err := json.Unmarshal(data, &token); err != nil {
+		return nil, fmt.Errorf("failed to parse token from Credential Manager: %w", err)
+	}
+
+	return &token, nil
+}
+
+func setInCredentialManager(token *oauth2.Token) error {
+	data, err := json.Marshal(token)
+	if err != nil {
+		return fmt.Errorf("failed to serialize token: %w", err)
+	}
+	if len(data) > credMaxBlobSize {
+		return fmt.Errorf("token is too large for Credential Manager (%d bytes)", len(data))
+	}
+
+	return writeCredential(tokenAccount(), "salesforce-cli OAuth Token", data)
+}
+
+func deleteFromCredentialManager() error {
+	return deleteCredential(tokenAccount())
+}
+
+// readCredential returns a copy of the blob of an account's credential, or
+// ErrTokenNotFound if there is none.
+func readCredential(account string) ([]byte, error) {
+	target, err := windows.UTF16PtrFromString(credentialTarget(account))
+	if err != nil {
+		return nil, err
+	}
+
+	var cred *credential
+	r, _, callErr := procCredReadW.Call(
+		uintptr(unsafe.Pointer(target)),
+		credTypeGeneric,
+		0,
+		uintptr(unsafe.Pointer(&cred)))
+	if r == 0 {
+		if errors.Is(callErr, windows.ERROR_NOT_FOUND) {
+			return nil, ErrTokenNotFound
+		}
+		return nil, fmt.Errorf("failed to read from Credential Manager: %w", callErr)
+	}
+	defer func() { _, _, _ = procCredFree.Call(uintptr(unsafe.Pointer(cred))) }()
+
+	// The blob is freed with the credential
+	return append([]byte(nil), unsafe.Slice(cred.CredentialBlob, cred.CredentialBlobSize)...), nil
+}
+
+func writeCredential(account, comment string, data []byte) error {
+	if len(data) == 0 || len(data) > credMaxBlobSize {
+		return fmt.Errorf("cannot store %d bytes in Credential Manager", len(data))
+	}
+
+	target, err := windows.UTF16PtrFromString(credentialTarget(account))
+	if err != nil {
+		return err
+	}
+	userName, err := windows.UTF16PtrFromString(account)
+	if err != nil {
+		return err
+	}
+	commentPtr, err := windows.UTF16PtrFromString(comment)
+	if err != nil {
+		return err
+	}
+
+	cred := credential{
+		Type:               credTypeGeneric,
+		TargetName:         target,
+		Comment:            commentPtr,
+		CredentialBlobSize: uint32(len(data)),
+		CredentialBlob:     &data[0],
+		Persist:            credPersistLocalMachine,
+		UserName:           userName,
+	}
+
+	r, _, callErr := procCredWriteW.Call(uintptr(unsafe.Pointer(&cred)), 0)
+	if r == 0 {
+		return fmt.Errorf("failed to store in Credential Manager: %w", callErr)
+	}
+
+	return nil
+}
+
+func deleteCredential(account string) error {
+	target, err := windows.UTF16PtrFromString(credentialTarget(account))
+	if err != nil {
+		return err
+	}
+
+	r, _, callErr := procCredDelete.Call(uintptr(unsafe.Pointer(target)), credTypeGeneric, 0)
+	if r == 0 {
+		return fmt.Errorf("failed to delete from Credential Manager: %w", callErr)
+	}
+
+	return nil
 }
@@ -0,0 +1,51 @@
+//go:build windows
+
+package keychain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+)
+
+func TestCredentialManager(t *testing.T) {
+	// A config directory override gives the credential a unique target
+	t.Setenv("SFDC_HOME", t.TempDir())
+	t.Setenv("SFDC_ORG", "")
+
+	token := &oauth2.Token{
+		AccessToken:  "test-access-token",
+		RefreshToken: "test-refresh-token",
+		TokenType:    "Bearer",
+		Expiry:       time.Now().Add(time.Hour),
+	}
+
+	_, err := getFromCredentialManager()
+	require.ErrorIs(t, err, ErrTokenNotFound)
+
+	require.NoError(t, setInCredentialManager(token))
+	defer func() { _ = deleteFromCredentialManager() }()
+
+	retrieved, err := getFromCredentialManager()
+	require.NoError(t, err)
+	assert.Equal(t, token.AccessToken, retrieved.AccessToken)
+	assert.Equal(t, token.RefreshToken, retrieved.RefreshToken)
+	assert.Equal(t, BackendWinCred, GetStorageBackend())
+	assert.True(t, IsSecureStorage())
+
+	require.NoError(t, deleteFromCredentialManager())
+	_, err = getFromCredentialManager()
+	assert.ErrorIs(t, err, ErrTokenNotFound)
+}
+
+func TestCredentialManager_TooLarge(t *testing.T) {
+	t.Setenv("SFDC_HOME", t.TempDir())
+
+	token := &oauth2.Token{AccessToken: string(make([]byte, credMaxBlobSize))}
+	err := setInCredentialManager(token)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "too large")
+}
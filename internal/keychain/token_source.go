@@ -20,11 +20,16 @@ type PersistentTokenSource struct {
 
 // NewPersistentTokenSource creates a TokenSource that persists refreshed tokens.
 // When the underlying oauth2 package refreshes an expired token, this wrapper
-// detects the change and saves the new token to secure storage.
-func NewPersistentTokenSource(config *oauth2.Config, initial *oauth2.Token) oauth2.TokenSource {
+// detects the change and saves the new token to secure storage. Refresh
+// requests are made with ctx, so cancelling it aborts a pending refresh.
+func NewPersistentTokenSource(ctx context.Context, config *oauth2.Config, initial *oauth2.Token) oauth2.TokenSource {
 	// Create base token source that handles refresh
-	base := config.TokenSource(context.Background(), initial)
+	return PersistTokenSource(config.TokenSource(ctx, initial), initial)
+}
 
+// PersistTokenSource wraps base so that each new token it returns is saved
+// to secure storage. initial is the token already stored, if any.
+func PersistTokenSource(base oauth2.TokenSource, initial *oauth2.Token) oauth2.TokenSource {
 	return &PersistentTokenSource{
 		base:    base,
 		current: initial,
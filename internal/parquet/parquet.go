@@ -0,0 +1,217 @@
+// Package parquet writes Apache Parquet files with a flat schema of
+// optional columns: enough to hand exports to data warehouses and
+// dataframe libraries without an intermediate conversion.
+//
+// The encoding is left to github.com/parquet-go/parquet-go; this package
+// keeps the columns in the order given, which that library's dynamic
+// schemas don't, and checks values against their column types.
+//
+// Rows are buffered until a row group is full and then written, so memory
+// use depends on the row group size, not the size of the file. Pages are
+// Snappy compressed.
+//
+//	w := parquet.NewWriter(f, []parquet.Column{
+//		{Name: "Name", Type: parquet.String},
+//		{Name: "AnnualRevenue", Type: parquet.Double},
+//	})
+//	err := w.Write([]interface{}{"Acme", 1.5e6})
+//	...
+//	err = w.Close()
+package parquet
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"time"
+
+	pq "github.com/parquet-go/parquet-go"
+)
+
+// Type is the type of a column's values.
+type Type int
+
+// Column types, and the Go type of their values in Write.
+const (
+	String    Type = iota // string
+	Boolean               // bool
+	Int64                 // int64
+	Double                // float64
+	Date                  // time.Time, stored as days since 1970-01-01
+	Timestamp             // time.Time, stored as UTC milliseconds
+)
+
+// DefaultRowGroupSize is the number of rows in each row group, unless the
+// Writer's RowGroupSize says otherwise.
+const DefaultRowGroupSize = 50000
+
+// ErrClosed is returned by Write and Close once the file has been closed.
+var ErrClosed = errors.New("parquet: writer is closed")
+
+// Column is a column of the file.
+type Column struct {
+	Name string
+	Type Type
+}
+
+// Writer writes rows to a Parquet file. Call Close to finish the file.
+type Writer struct {
+	// RowGroupSize is the number of rows buffered before a row group is
+	// written; zero means DefaultRowGroupSize
+	RowGroupSize int
+
+	w       *pq.Writer
+	columns []Column
+	row     pq.Row
+	rows    int
+	err     error
+}
+
+// NewWriter returns a Writer writing rows with the given columns to w.
+func NewWriter(w io.Writer, columns []Column) *Writer {
+	schema := pq.NewSchema("schema", newGroup(columns))
+	return &Writer{
+		w:       pq.NewWriter(w, schema, pq.Compression(&pq.Snappy)),
+		columns: columns,
+		row:     make(pq.Row, len(columns)),
+	}
+}
+
+// Write adds a row, with a value for each column: nil for null, or a value
+// of the column type's Go type.
+func (w *Writer) Write(row []interface{}) error {
+	if w.err != nil {
+		return w.err
+	}
+	if len(row) != len(w.columns) {
+		return fmt.Errorf("row has %d values, want %d", len(row), len(w.columns))
+	}
+
+	// Check the whole row first so a bad value leaves no partial row
+	for i, value := range row {
+		v, err := columnValue(w.columns[i], value)
+		if err != nil {
+			return err
+		}
+		w.row[i] = v.Level(0, definitionLevel(value), i)
+	}
+	if _, err := w.w.WriteRows([]pq.Row{w.row}); err != nil {
+		w.err = err
+		return err
+	}
+	w.rows++
+
+	size := w.RowGroupSize
+	if size <= 0 {
+		size = DefaultRowGroupSize
+	}
+	if w.rows >= size {
+		w.rows = 0
+		if err := w.w.Flush(); err != nil {
+			w.err = err
+			return err
+		}
+	}
+	return nil
+}
+
+// Close writes any buffered rows and the file footer. It does not close the
+// underlying writer.
+func (w *Writer) Close() error {
+	if w.err != nil {
+		return w.err
+	}
+	w.err = ErrClosed
+	return w.w.Close()
+}
+
+// definitionLevel is 1 for a value of an optional column, 0 for a null.
+func definitionLevel(value interface{}) int {
+	if value == nil {
+		return 0
+	}
+	return 1
+}
+
+// columnValue converts value to a Parquet value of the column's type, or
+// reports an error if it is not nil or of the Go type of the column's type.
+func columnValue(col Column, value interface{}) (pq.Value, error) {
+	switch v := value.(type) {
+	case nil:
+		return pq.NullValue(), nil
+	case string:
+		if col.Type == String {
+			return pq.ByteArrayValue([]byte(v)), nil
+		}
+	case bool:
+		if col.Type == Boolean {
+			return pq.BooleanValue(v), nil
+		}
+	case int64:
+		if col.Type == Int64 {
+			return pq.Int64Value(v), nil
+		}
+	case float64:
+		if col.Type == Double {
+			return pq.DoubleValue(v), nil
+		}
+	case time.Time:
+		switch col.Type {
+		case Date:
+			days := time.Date(v.Year(), v.Month(), v.Day(), 0, 0, 0, 0, time.UTC).Unix() / 86400
+			return pq.Int32Value(int32(days)), nil
+		case Timestamp:
+			return pq.Int64Value(v.UnixMilli()), nil
+		}
+	}
+	return pq.Value{}, fmt.Errorf("column %s: unexpected %T value", col.Name, value)
+}
+
+// node returns the schema node of a column of type t.
+func (t Type) node() pq.Node {
+	switch t {
+	case Boolean:
+		return pq.Leaf(pq.BooleanType)
+	case Int64:
+		return pq.Int(64)
+	case Double:
+		return pq.Leaf(pq.DoubleType)
+	case Date:
+		return pq.Date()
+	case Timestamp:
+		return pq.Timestamp(pq.Millisecond)
+	}
+	return pq.String()
+}
+
+// group is the schema's root: a pq.Group of optional columns whose fields
+// keep the order of the columns rather than being sorted by name.
+type group struct {
+	pq.Group
+	fields []pq.Field
+}
+
+func newGroup(columns []Column) group {
+	g := group{Group: make(pq.Group, len(columns))}
+	for _, col := range columns {
+		node := pq.Optional(col.Type.node())
+		g.Group[col.Name] = node
+		g.fields = append(g.fields, field{Node: node, name: col.Name})
+	}
+	return g
+}
+
+func (g group) Fields() []pq.Field { return g.fields }
+
+// field is a column of the root group.
+type field struct {
+	pq.Node
+	name string
+}
+
+func (f field) Name() string { return f.name }
+
+func (f field) Value(base reflect.Value) reflect.Value {
+	return base.MapIndex(reflect.ValueOf(f.name))
+}
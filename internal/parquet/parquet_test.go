@@ -0,0 +1,157 @@
+package parquet
+
+import (
+	"bytes"
+	"io"
+	"testing"
+	"time"
+
+	pq "github.com/parquet-go/parquet-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// readFile opens a written file and reads all its rows with parquet-go's
+// reader.
+func readFile(t *testing.T, data []byte) (*pq.File, []pq.Row) {
+	t.Helper()
+	f, err := pq.OpenFile(bytes.NewReader(data), int64(len(data)))
+	require.NoError(t, err)
+
+	r := pq.NewReader(bytes.NewReader(data))
+	defer r.Close()
+	rows := make([]pq.Row, f.NumRows())
+	n, err := r.ReadRows(rows)
+	if err != io.EOF {
+		require.NoError(t, err)
+	}
+	require.Equal(t, len(rows), n)
+	return f, rows
+}
+
+func TestWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf, []Column{
+		{Name: "Name", Type: String},
+		{Name: "IsActive", Type: Boolean},
+		{Name: "Employees", Type: Int64},
+		{Name: "Revenue", Type: Double},
+		{Name: "CloseDate", Type: Date},
+		{Name: "CreatedDate", Type: Timestamp},
+	})
+
+	created := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+	require.NoError(t, w.Write([]interface{}{"Acme", true, int64(250), 1.5e6, time.Date(1970, 1, 3, 0, 0, 0, 0, time.UTC), created}))
+	require.NoError(t, w.Write([]interface{}{nil, false, nil, nil, nil, nil}))
+	require.NoError(t, w.Write([]interface{}{"Globex", nil, int64(-1), 0.25, time.Date(1969, 12, 31, 0, 0, 0, 0, time.UTC), nil}))
+	require.NoError(t, w.Close())
+
+	data := buf.Bytes()
+	assert.Equal(t, "PAR1", string(data[:4]))
+	assert.Equal(t, "PAR1", string(data[len(data)-4:]))
+
+	f, rows := readFile(t, data)
+
+	// Columns keep their order, each optional with its logical type
+	var names []string
+	for _, field := range f.Schema().Fields() {
+		names = append(names, field.Name())
+		assert.True(t, field.Optional(), field.Name())
+	}
+	assert.Equal(t, []string{"Name", "IsActive", "Employees", "Revenue", "CloseDate", "CreatedDate"}, names)
+
+	fields := f.Schema().Fields()
+	assert.NotNil(t, fields[0].Type().LogicalType().UTF8)
+	assert.Equal(t, pq.Boolean, fields[1].Type().Kind())
+	assert.Equal(t, pq.Int64, fields[2].Type().Kind())
+	assert.Equal(t, pq.Double, fields[3].Type().Kind())
+	assert.NotNil(t, fields[4].Type().LogicalType().Date)
+	timestamp := fields[5].Type().LogicalType().Timestamp
+	require.NotNil(t, timestamp)
+	assert.True(t, timestamp.IsAdjustedToUTC)
+	assert.NotNil(t, timestamp.Unit.Millis)
+
+	require.Len(t, rows, 3)
+
+	acme := rows[0]
+	assert.Equal(t, "Acme", string(acme[0].ByteArray()))
+	assert.True(t, acme[1].Boolean())
+	assert.Equal(t, int64(250), acme[2].Int64())
+	assert.Equal(t, 1.5e6, acme[3].Double())
+	assert.Equal(t, int32(2), acme[4].Int32())
+	assert.Equal(t, created.UnixMilli(), acme[5].Int64())
+
+	empty := rows[1]
+	for i, v := range empty {
+		if i == 1 {
+			assert.False(t, v.IsNull())
+			assert.False(t, v.Boolean())
+			continue
+		}
+		assert.True(t, v.IsNull(), "column %d", i)
+	}
+
+	globex := rows[2]
+	assert.Equal(t, "Globex", string(globex[0].ByteArray()))
+	assert.True(t, globex[1].IsNull())
+	assert.Equal(t, int64(-1), globex[2].Int64())
+	assert.Equal(t, 0.25, globex[3].Double())
+	assert.Equal(t, int32(-1), globex[4].Int32())
+	assert.True(t, globex[5].IsNull())
+}
+
+func TestWriter_RowGroups(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf, []Column{{Name: "N", Type: Int64}})
+	w.RowGroupSize = 2
+
+	for i := int64(0); i < 5; i++ {
+		require.NoError(t, w.Write([]interface{}{i}))
+	}
+	require.NoError(t, w.Close())
+
+	f, rows := readFile(t, buf.Bytes())
+	assert.Equal(t, int64(5), f.NumRows())
+
+	var sizes []int64
+	for _, g := range f.RowGroups() {
+		sizes = append(sizes, g.NumRows())
+	}
+	assert.Equal(t, []int64{2, 2, 1}, sizes)
+
+	for i, row := range rows {
+		assert.Equal(t, int64(i), row[0].Int64())
+	}
+}
+
+func TestWriter_Empty(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf, []Column{{Name: "Name", Type: String}})
+	require.NoError(t, w.Close())
+
+	f, rows := readFile(t, buf.Bytes())
+	assert.Equal(t, int64(0), f.NumRows())
+	assert.Empty(t, rows)
+	assert.ErrorIs(t, w.Write([]interface{}{"late"}), ErrClosed)
+	assert.ErrorIs(t, w.Close(), ErrClosed)
+}
+
+func TestWriter_WrongType(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf, []Column{{Name: "Name", Type: String}, {Name: "N", Type: Int64}})
+
+	err := w.Write([]interface{}{"Acme", "ten"})
+	require.Error(t, err)
+	assert.Equal(t, "column N: unexpected string value", err.Error())
+
+	assert.Error(t, w.Write([]interface{}{"Acme"}))
+
+	// The rejected rows left nothing behind
+	require.NoError(t, w.Write([]interface{}{"Acme", int64(10)}))
+	require.NoError(t, w.Close())
+
+	_, rows := readFile(t, buf.Bytes())
+	require.Len(t, rows, 1)
+	assert.Equal(t, "Acme", string(rows[0][0].ByteArray()))
+	assert.Equal(t, int64(10), rows[0][1].Int64())
+}
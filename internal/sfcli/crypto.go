@@ -0,0 +1,84 @@
+package sfcli
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const (
+	// keyService and keyAccount identify the sf CLI's key in the system
+	// keychain
+	keyService = "sfdx"
+	keyAccount = "local"
+
+	// legacyIVLength is the length of the IV prefix written by older sf
+	// versions, whose IV is the hex string itself rather than its bytes
+	legacyIVLength = 12
+
+	// ivLength is the length of the hex-encoded IV prefix written by newer sf
+	// versions, which use a 64 character hex key
+	ivLength = 24
+)
+
+// encryptedValue matches <iv><ciphertext>:<tag> in hex.
+var encryptedValue = regexp.MustCompile(`^[a-f0-9]+:[a-f0-9]{32}$`)
+
+// isEncrypted reports whether a stored value was encrypted by the sf CLI.
+func isEncrypted(value string) bool {
+	return encryptedValue.MatchString(value)
+}
+
+// decrypt decrypts a value encrypted by the sf CLI with AES-256-GCM.
+func decrypt(value, key string) (string, error) {
+	data, tagHex, _ := strings.Cut(value, ":")
+
+	var keyBytes, iv []byte
+	var ciphertextHex string
+	switch len(key) {
+	case 32:
+		if len(data) < legacyIVLength {
+			return "", fmt.Errorf("encrypted value is too short")
+		}
+		keyBytes = []byte(key)
+		iv = []byte(data[:legacyIVLength])
+		ciphertextHex = data[legacyIVLength:]
+	case 64:
+		if len(data) < ivLength {
+			return "", fmt.Errorf("encrypted value is too short")
+		}
+		var err error
+		if keyBytes, err = hex.DecodeString(key); err != nil {
+			return "", fmt.Errorf("invalid key: %w", err)
+		}
+		if iv, err = hex.DecodeString(data[:ivLength]); err != nil {
+			return "", fmt.Errorf("invalid IV: %w", err)
+		}
+		ciphertextHex = data[ivLength:]
+	default:
+		return "", fmt.Errorf("unsupported key length %d", len(key))
+	}
+
+	ciphertext, err := hex.DecodeString(ciphertextHex + tagHex)
+	if err != nil {
+		return "", fmt.Errorf("invalid encrypted value: %w", err)
+	}
+
+	block, err := aes.NewCipher(keyBytes)
+	if err != nil {
+		return "", err
+	}
+	gcm, err := cipher.NewGCMWithNonceSize(block, len(iv))
+	if err != nil {
+		return "", err
+	}
+
+	plain, err := gcm.Open(nil, iv, ciphertext, nil)
+	if err != nil {
+		return "", fmt.Errorf("wrong key or corrupt value: %w", err)
+	}
+	return string(plain), nil
+}
@@ -0,0 +1,20 @@
+//go:build darwin
+
+package sfcli
+
+import (
+	"fmt"
+	"os/exec"
+)
+
+// systemKey reads the sf CLI's key from the macOS Keychain.
+func systemKey() (string, error) {
+	output, err := exec.Command("security", "find-generic-password",
+		"-s", keyService,
+		"-a", keyAccount,
+		"-w").Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to read from keychain: %w", err)
+	}
+	return string(output), nil
+}
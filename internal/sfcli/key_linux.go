@@ -0,0 +1,23 @@
+//go:build linux
+
+package sfcli
+
+import (
+	"fmt"
+	"os/exec"
+)
+
+// systemKey reads the sf CLI's key with secret-tool.
+func systemKey() (string, error) {
+	if _, err := exec.LookPath("secret-tool"); err != nil {
+		return "", fmt.Errorf("secret-tool is not installed and no key.json was found")
+	}
+
+	output, err := exec.Command("secret-tool", "lookup",
+		"user", keyAccount,
+		"domain", keyService).Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to read from secret-tool: %w", err)
+	}
+	return string(output), nil
+}
@@ -0,0 +1,11 @@
+//go:build windows
+
+package sfcli
+
+import "fmt"
+
+// systemKey is not supported on Windows, where the sf CLI keeps its key in
+// key.json.
+func systemKey() (string, error) {
+	return "", fmt.Errorf("no key.json was found")
+}
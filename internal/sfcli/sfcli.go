@@ -0,0 +1,171 @@
+// Package sfcli reads orgs authorized with the official Salesforce CLI (sf,
+// formerly sfdx) from its state directories.
+package sfcli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// stateDirs are the sf CLI state directories under the home directory, in
+// the order they are searched. Org auth files and aliases are kept in
+// ~/.sfdx by both sf and sfdx; ~/.sf is checked in case that changes.
+var stateDirs = []string{".sfdx", ".sf"}
+
+// homeOverride replaces the home directory when set (for testing).
+var homeOverride string
+
+// SetHomeDir overrides the directory the state directories are looked up
+// in. Pass "" to use the user's home directory.
+func SetHomeDir(dir string) {
+	homeOverride = dir
+}
+
+// ErrOrgNotFound is returned when no auth file exists for an org.
+var ErrOrgNotFound = errors.New("org not found in the sf CLI")
+
+// AuthInfo is an org authorization stored by the sf CLI. Secrets are
+// decrypted.
+type AuthInfo struct {
+	Username     string `json:"username"`
+	OrgID        string `json:"orgId"`
+	InstanceURL  string `json:"instanceUrl"`
+	LoginURL     string `json:"loginUrl"`
+	ClientID     string `json:"clientId"`
+	ClientSecret string `json:"clientSecret"`
+	RefreshToken string `json:"refreshToken"`
+	// Alias is the sf alias the org was looked up by, if any
+	Alias string `json:"-"`
+}
+
+// aliasFile is the sf CLI's alias.json.
+type aliasFile struct {
+	Orgs map[string]string `json:"orgs"`
+}
+
+// LoadAuth returns the sf CLI's authorization for an org, given its alias
+// or username.
+func LoadAuth(aliasOrUsername string) (*AuthInfo, error) {
+	dirs, err := searchDirs()
+	if err != nil {
+		return nil, err
+	}
+
+	username, alias := aliasOrUsername, ""
+	if resolved := resolveAlias(dirs, aliasOrUsername); resolved != "" {
+		username, alias = resolved, aliasOrUsername
+	}
+
+	for _, dir := range dirs {
+		data, err := os.ReadFile(filepath.Join(dir, username+".json"))
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read sf auth file: %w", err)
+		}
+
+		var info AuthInfo
+		if err := json.Unmarshal(data, &info); err != nil {
+			return nil, fmt.Errorf("failed to parse sf auth file: %w", err)
+		}
+		info.Alias = alias
+
+		if err := decryptSecrets(dir, &info); err != nil {
+			return nil, err
+		}
+		return &info, nil
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrOrgNotFound, aliasOrUsername)
+}
+
+// searchDirs returns the paths of the state directories.
+func searchDirs() ([]string, error) {
+	home := homeOverride
+	if home == "" {
+		var err error
+		home, err = os.UserHomeDir()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get home directory: %w", err)
+		}
+	}
+
+	dirs := make([]string, len(stateDirs))
+	for i, dir := range stateDirs {
+		dirs[i] = filepath.Join(home, dir)
+	}
+	return dirs, nil
+}
+
+// resolveAlias returns the username an sf alias refers to, or "" if it is
+// not an alias.
+func resolveAlias(dirs []string, alias string) string {
+	for _, dir := range dirs {
+		data, err := os.ReadFile(filepath.Join(dir, "alias.json"))
+		if err != nil {
+			continue
+		}
+		var aliases aliasFile
+		if err := json.Unmarshal(data, &aliases); err != nil {
+			continue
+		}
+		if username := aliases.Orgs[alias]; username != "" {
+			return username
+		}
+	}
+	return ""
+}
+
+// decryptSecrets decrypts the refresh token and client secret in place. The
+// sf CLI encrypts them unless encryption was disabled, in which case they
+// are used as stored.
+func decryptSecrets(dir string, info *AuthInfo) error {
+	if !isEncrypted(info.RefreshToken) && !isEncrypted(info.ClientSecret) {
+		return nil
+	}
+
+	key, err := loadKey(dir)
+	if err != nil {
+		return fmt.Errorf("failed to read the sf CLI encryption key: %w", err)
+	}
+
+	for _, value := range []*string{&info.RefreshToken, &info.ClientSecret} {
+		if !isEncrypted(*value) {
+			continue
+		}
+		plain, err := decrypt(*value, key)
+		if err != nil {
+			return fmt.Errorf("failed to decrypt sf auth file: %w", err)
+		}
+		*value = plain
+	}
+	return nil
+}
+
+// loadKey returns the sf CLI's encryption key: from key.json when the
+// generic keychain is in use, otherwise from the system keychain.
+func loadKey(dir string) (string, error) {
+	data, err := os.ReadFile(filepath.Join(dir, "key.json"))
+	if err == nil {
+		var generic struct {
+			Key string `json:"key"`
+		}
+		if err := json.Unmarshal(data, &generic); err != nil {
+			return "", fmt.Errorf("failed to parse key.json: %w", err)
+		}
+		if generic.Key != "" {
+			return generic.Key, nil
+		}
+	}
+
+	key, err := systemKey()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(key), nil
+}
@@ -0,0 +1,103 @@
+package sfcli
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// encryptLegacy encrypts like older sf versions: the key and IV strings are
+// used as bytes.
+func encryptLegacy(t *testing.T, plain, key, iv string) string {
+	t.Helper()
+	return seal(t, plain, []byte(key), []byte(iv), iv)
+}
+
+func seal(t *testing.T, plain string, key, iv []byte, ivPrefix string) string {
+	t.Helper()
+
+	block, err := aes.NewCipher(key)
+	require.NoError(t, err)
+	gcm, err := cipher.NewGCMWithNonceSize(block, len(iv))
+	require.NoError(t, err)
+
+	sealed := gcm.Seal(nil, iv, []byte(plain), nil)
+	ciphertext, tag := sealed[:len(sealed)-gcm.Overhead()], sealed[len(sealed)-gcm.Overhead():]
+	return ivPrefix + hex.EncodeToString(ciphertext) + ":" + hex.EncodeToString(tag)
+}
+
+func TestDecrypt(t *testing.T) {
+	legacyKey := "0123456789abcdef0123456789abcdef"
+	encrypted := encryptLegacy(t, "5Aep861refresh", legacyKey, "a1b2c3d4e5f6")
+	assert.True(t, isEncrypted(encrypted))
+
+	plain, err := decrypt(encrypted, legacyKey)
+	require.NoError(t, err)
+	assert.Equal(t, "5Aep861refresh", plain)
+
+	key := make([]byte, 32)
+	iv := make([]byte, 12)
+	for i := range key {
+		key[i] = byte(i)
+	}
+	encrypted = seal(t, "5Aep861refresh", key, iv, hex.EncodeToString(iv))
+	plain, err = decrypt(encrypted, hex.EncodeToString(key))
+	require.NoError(t, err)
+	assert.Equal(t, "5Aep861refresh", plain)
+
+	_, err = decrypt(encrypted, "fedcba9876543210fedcba9876543210")
+	assert.Error(t, err)
+}
+
+func TestIsEncrypted(t *testing.T) {
+	assert.False(t, isEncrypted("5Aep861TSESvWeug_xvFHRBTTbf_YrTWgEyjBJrnZ5J0fK.4tkdLZGwjBD0PBn9"))
+	assert.False(t, isEncrypted(""))
+}
+
+func TestLoadAuth(t *testing.T) {
+	home := t.TempDir()
+	SetHomeDir(home)
+	defer SetHomeDir("")
+
+	dir := filepath.Join(home, ".sfdx")
+	require.NoError(t, os.MkdirAll(dir, 0700))
+
+	key := "0123456789abcdef0123456789abcdef"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "key.json"),
+		[]byte(`{"service":"sfdx","account":"local","key":"`+key+`"}`), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "alias.json"),
+		[]byte(`{"orgs":{"dev":"admin@example.com.dev"}}`), 0600))
+
+	authFile := `{
+		"username": "admin@example.com.dev",
+		"orgId": "00D000000000001",
+		"instanceUrl": "https://mycompany--dev.sandbox.my.salesforce.com",
+		"loginUrl": "https://test.salesforce.com",
+		"clientId": "PlatformCLI",
+		"accessToken": "` + encryptLegacy(t, "00D!access", key, "a1b2c3d4e5f6") + `",
+		"refreshToken": "` + encryptLegacy(t, "5Aep861refresh", key, "0a0b0c0d0e0f") + `"
+	}`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "admin@example.com.dev.json"), []byte(authFile), 0600))
+
+	info, err := LoadAuth("dev")
+	require.NoError(t, err)
+	assert.Equal(t, "dev", info.Alias)
+	assert.Equal(t, "admin@example.com.dev", info.Username)
+	assert.Equal(t, "https://mycompany--dev.sandbox.my.salesforce.com", info.InstanceURL)
+	assert.Equal(t, "PlatformCLI", info.ClientID)
+	assert.Equal(t, "5Aep861refresh", info.RefreshToken)
+	assert.Empty(t, info.ClientSecret)
+
+	info, err = LoadAuth("admin@example.com.dev")
+	require.NoError(t, err)
+	assert.Empty(t, info.Alias)
+
+	_, err = LoadAuth("missing")
+	assert.ErrorIs(t, err, ErrOrgNotFound)
+}
@@ -0,0 +1,158 @@
+package view
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// salesforceDateTimeFormats are the layouts of datetime values in API responses.
+var salesforceDateTimeFormats = []string{
+	"2006-01-02T15:04:05.000-0700",
+	"2006-01-02T15:04:05.000Z",
+	"2006-01-02T15:04:05Z",
+}
+
+// salesforceDateFormat is the layout of date values in API responses.
+const salesforceDateFormat = "2006-01-02"
+
+// TypedFormat holds the settings for formatting field values by their
+// Salesforce field type (as reported by describe).
+type TypedFormat struct {
+	// DefaultCurrency is the ISO code shown with currency values of records
+	// that have no CurrencyIsoCode, usually the org's default currency
+	DefaultCurrency string
+
+	// YesNo shows booleans as yes/no instead of true/false
+	YesNo bool
+}
+
+// Format formats a field value for display according to its field type.
+// Currency values get thousands separators, the field's decimal places, and
+// currencyCode (or the default currency) as a prefix; percent values get a
+// % suffix; datetimes are shortened to the date in local time; and, with
+// YesNo, booleans are shown as yes/no.
+//
+// It returns false if the type has no special formatting or the value does
+// not have the expected JSON type, in which case the caller should fall back
+// to its raw formatting.
+func (f TypedFormat) Format(value interface{}, fieldType string, scale int, currencyCode string) (string, bool) {
+	switch fieldType {
+	case "currency":
+		n, ok := value.(float64)
+		if !ok {
+			return "", false
+		}
+		if scale == 0 {
+			scale = 2
+		}
+		s := formatNumber(n, scale)
+		if currencyCode == "" {
+			currencyCode = f.DefaultCurrency
+		}
+		if currencyCode != "" {
+			s = currencyCode + " " + s
+		}
+		return s, true
+	case "percent":
+		n, ok := value.(float64)
+		if !ok {
+			return "", false
+		}
+		return formatNumber(n, scale) + "%", true
+	case "date":
+		s, ok := value.(string)
+		if !ok {
+			return "", false
+		}
+		if _, err := time.Parse(salesforceDateFormat, s); err != nil {
+			return "", false
+		}
+		return s, true
+	case "datetime":
+		s, ok := value.(string)
+		if !ok {
+			return "", false
+		}
+		for _, layout := range salesforceDateTimeFormats {
+			if t, err := time.Parse(layout, s); err == nil {
+				return t.Local().Format(salesforceDateFormat), true
+			}
+		}
+		return "", false
+	case "boolean":
+		b, ok := value.(bool)
+		if !ok || !f.YesNo {
+			return "", false
+		}
+		if b {
+			return "yes", true
+		}
+		return "no", true
+	default:
+		return "", false
+	}
+}
+
+// Legend describes how Format shows the given fields, for printing below
+// typed output. names and types are parallel; fields whose type has no
+// special formatting are left out. It returns "" if no field is left.
+func (f TypedFormat) Legend(names, types []string) string {
+	var entries []string
+	for i, name := range names {
+		var desc string
+		switch types[i] {
+		case "currency":
+			desc = "currency"
+			if f.DefaultCurrency != "" {
+				desc = fmt.Sprintf("currency (%s unless the record has a CurrencyIsoCode)", f.DefaultCurrency)
+			}
+		case "percent":
+			desc = "percent"
+		case "date":
+			desc = "date"
+		case "datetime":
+			desc = "datetime (local date, time omitted)"
+		case "boolean":
+			if !f.YesNo {
+				continue
+			}
+			desc = "checkbox (yes/no)"
+		default:
+			continue
+		}
+		entries = append(entries, name+": "+desc)
+	}
+
+	if len(entries) == 0 {
+		return ""
+	}
+	return "Legend: " + strings.Join(entries, ", ")
+}
+
+// formatNumber formats n with the given number of decimals and comma
+// thousands separators.
+func formatNumber(n float64, decimals int) string {
+	s := strconv.FormatFloat(n, 'f', decimals, 64)
+
+	sign := ""
+	if strings.HasPrefix(s, "-") {
+		sign, s = "-", s[1:]
+	}
+
+	intPart, frac := s, ""
+	if idx := strings.Index(s, "."); idx >= 0 {
+		intPart, frac = s[:idx], s[idx:]
+	}
+
+	var b strings.Builder
+	for i, r := range intPart {
+		if i > 0 && (len(intPart)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(r)
+	}
+
+	return sign + b.String() + frac
+}
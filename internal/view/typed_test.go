@@ -0,0 +1,71 @@
+package view
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTypedFormat_Format(t *testing.T) {
+	created := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC).Local().Format("2006-01-02")
+
+	tests := []struct {
+		name      string
+		format    TypedFormat
+		value     interface{}
+		fieldType string
+		scale     int
+		currency  string
+		want      string
+		wantOK    bool
+	}{
+		{"currency", TypedFormat{}, 1234567.5, "currency", 2, "", "1,234,567.50", true},
+		{"currency default scale", TypedFormat{}, 42.0, "currency", 0, "", "42.00", true},
+		{"currency with code", TypedFormat{}, 99.9, "currency", 2, "EUR", "EUR 99.90", true},
+		{"negative currency", TypedFormat{}, -1500.0, "currency", 2, "", "-1,500.00", true},
+		{"percent", TypedFormat{}, 12.5, "percent", 1, "", "12.5%", true},
+		{"whole percent", TypedFormat{}, 80.0, "percent", 0, "", "80%", true},
+		{"datetime", TypedFormat{}, "2024-01-15T10:30:00.000+0000", "datetime", 0, "", created, true},
+		{"true boolean", TypedFormat{YesNo: true}, true, "boolean", 0, "", "yes", true},
+		{"false boolean", TypedFormat{YesNo: true}, false, "boolean", 0, "", "no", true},
+		{"string is raw", TypedFormat{}, "hello", "string", 0, "", "", false},
+		{"default currency", TypedFormat{DefaultCurrency: "USD"}, 10.0, "currency", 2, "", "USD 10.00", true},
+		{"record currency over default", TypedFormat{DefaultCurrency: "USD"}, 10.0, "currency", 2, "EUR", "EUR 10.00", true},
+		{"date", TypedFormat{}, "2024-01-15", "date", 0, "", "2024-01-15", true},
+		{"boolean without yes/no is raw", TypedFormat{}, true, "boolean", 0, "", "", false},
+		{"unexpected value type", TypedFormat{}, "abc", "currency", 2, "", "", false},
+		{"unparseable datetime", TypedFormat{}, "yesterday", "datetime", 0, "", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.format.Format(tt.value, tt.fieldType, tt.scale, tt.currency)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestTypedFormat_Legend(t *testing.T) {
+	names := []string{"Name", "Amount", "Probability", "CloseDate", "CreatedDate", "IsWon"}
+	types := []string{"string", "currency", "percent", "date", "datetime", "boolean"}
+
+	legend := TypedFormat{DefaultCurrency: "USD", YesNo: true}.Legend(names, types)
+	assert.Equal(t, "Legend: Amount: currency (USD unless the record has a CurrencyIsoCode), Probability: percent, "+
+		"CloseDate: date, CreatedDate: datetime (local date, time omitted), IsWon: checkbox (yes/no)", legend)
+
+	legend = TypedFormat{}.Legend(names, types)
+	assert.NotContains(t, legend, "IsWon")
+	assert.Contains(t, legend, "Amount: currency,")
+
+	assert.Empty(t, TypedFormat{}.Legend([]string{"Name"}, []string{"string"}))
+}
+
+func TestFormatNumber(t *testing.T) {
+	assert.Equal(t, "0", formatNumber(0, 0))
+	assert.Equal(t, "999", formatNumber(999, 0))
+	assert.Equal(t, "1,000", formatNumber(1000, 0))
+	assert.Equal(t, "12,345.68", formatNumber(12345.678, 2))
+	assert.Equal(t, "-1,000,000.5", formatNumber(-1000000.5, 1))
+}
@@ -20,30 +20,37 @@ const (
 	FormatTable Format = "table"
 	FormatJSON  Format = "json"
 	FormatPlain Format = "plain"
+	FormatCSV   Format = "csv" // only supported by commands that write CSV themselves
 )
 
 // ValidFormats returns the list of valid output formats.
 func ValidFormats() []string {
-	return []string{string(FormatTable), string(FormatJSON), string(FormatPlain)}
+	return []string{string(FormatTable), string(FormatJSON), string(FormatPlain), string(FormatCSV)}
 }
 
 // ValidateFormat checks if a format string is valid.
 // Returns an error if the format is not supported.
 func ValidateFormat(format string) error {
-	switch format {
-	case "", string(FormatTable), string(FormatJSON), string(FormatPlain):
+	if format == "" {
 		return nil
-	default:
-		return fmt.Errorf("invalid output format: %q (valid formats: table, json, plain)", format)
 	}
+	for _, valid := range ValidFormats() {
+		if format == valid {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid output format: %q (valid formats: %s)", format, strings.Join(ValidFormats(), ", "))
 }
 
 // View handles output formatting.
 type View struct {
 	Format  Format
 	NoColor bool
-	Out     io.Writer
-	Err     io.Writer
+	// Hyperlinks wraps URLs printed with Link in OSC 8 escape sequences,
+	// making them clickable in terminals that support it
+	Hyperlinks bool
+	Out        io.Writer
+	Err        io.Writer
 }
 
 // New creates a new View with the given format.
@@ -197,6 +204,16 @@ func (v *View) Println(format string, args ...interface{}) {
 	_, _ = fmt.Fprintln(v.Out, fmt.Sprintf(format, args...))
 }
 
+// Link returns url for printing. When Hyperlinks is set, it is wrapped in
+// an OSC 8 hyperlink; the visible text is still the plain URL, so terminals
+// without hyperlink support show it unchanged.
+func (v *View) Link(url string) string {
+	if !v.Hyperlinks {
+		return url
+	}
+	return "\x1b]8;;" + url + "\x1b\\" + url + "\x1b]8;;\x1b\\"
+}
+
 // Truncate truncates a string to the specified length, adding "..." if truncated.
 func Truncate(s string, maxLen int) string {
 	if len(s) <= maxLen {
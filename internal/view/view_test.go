@@ -15,6 +15,7 @@ func TestValidFormats(t *testing.T) {
 	assert.Contains(t, formats, "table")
 	assert.Contains(t, formats, "json")
 	assert.Contains(t, formats, "plain")
+	assert.Contains(t, formats, "csv")
 }
 
 func TestValidateFormat(t *testing.T) {
@@ -26,6 +27,7 @@ func TestValidateFormat(t *testing.T) {
 		{"table", false},
 		{"json", false},
 		{"plain", false},
+		{"csv", false},
 		{"invalid", true},
 		{"XML", true},
 	}
@@ -241,3 +243,13 @@ func TestTruncate(t *testing.T) {
 		})
 	}
 }
+
+func TestLink(t *testing.T) {
+	v := New(FormatTable, true)
+	assert.Equal(t, "https://example.my.salesforce.com/001xx", v.Link("https://example.my.salesforce.com/001xx"))
+
+	v.Hyperlinks = true
+	assert.Equal(t,
+		"\x1b]8;;https://example.my.salesforce.com/001xx\x1b\\https://example.my.salesforce.com/001xx\x1b]8;;\x1b\\",
+		v.Link("https://example.my.salesforce.com/001xx"))
+}